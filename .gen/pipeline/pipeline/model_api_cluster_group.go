@@ -22,6 +22,8 @@ type ApiClusterGroup struct {
 
 	OrganizationId int32 `json:"organizationId,omitempty"`
 
+	Selector ApiMemberSelector `json:"selector,omitempty"`
+
 	Uid string `json:"uid,omitempty"`
 }
 
@@ -32,6 +34,9 @@ func AssertApiClusterGroupRequired(obj ApiClusterGroup) error {
 			return err
 		}
 	}
+	if err := AssertApiMemberSelectorRequired(obj.Selector); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -15,10 +15,15 @@ type ApiCreateRequest struct {
 	Members []int32 `json:"members,omitempty"`
 
 	Name string `json:"name,omitempty"`
+
+	Selector ApiMemberSelector `json:"selector,omitempty"`
 }
 
 // AssertApiCreateRequestRequired checks if the required fields are not zero-ed
 func AssertApiCreateRequestRequired(obj ApiCreateRequest) error {
+	if err := AssertApiMemberSelectorRequired(obj.Selector); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,40 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+// ApiMemberSelector - Clusters meeting every criteria join the cluster group automatically
+type ApiMemberSelector struct {
+
+	Cloud string `json:"cloud,omitempty"`
+
+	Distribution string `json:"distribution,omitempty"`
+
+	Location string `json:"location,omitempty"`
+
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// AssertApiMemberSelectorRequired checks if the required fields are not zero-ed
+func AssertApiMemberSelectorRequired(obj ApiMemberSelector) error {
+	return nil
+}
+
+// AssertRecurseApiMemberSelectorRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ApiMemberSelector (e.g. [][]ApiMemberSelector), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseApiMemberSelectorRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aApiMemberSelector, ok := obj.(ApiMemberSelector)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertApiMemberSelectorRequired(aApiMemberSelector)
+	})
+}
@@ -15,10 +15,15 @@ type ApiUpdateRequest struct {
 	Members []int32 `json:"members,omitempty"`
 
 	Name string `json:"name,omitempty"`
+
+	Selector ApiMemberSelector `json:"selector,omitempty"`
 }
 
 // AssertApiUpdateRequestRequired checks if the required fields are not zero-ed
 func AssertApiUpdateRequestRequired(obj ApiUpdateRequest) error {
+	if err := AssertApiMemberSelectorRequired(obj.Selector); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -13,6 +13,9 @@ package pipeline
 // BaseUpdateNodePoolOptions - Base node pool update options object for all cluster distributions.
 type BaseUpdateNodePoolOptions struct {
 
+	// Node replacement strategy. `rollingUpdate` relies on the rolling update of the cloud provider, `replace` replaces the nodes from Pipeline in batches, cordoning and draining them through the eviction API respecting pod disruption budgets (EKS only).
+	Strategy string `json:"strategy,omitempty"`
+
 	// Maximum number of extra nodes that can be created during the update.
 	MaxSurge int32 `json:"maxSurge,omitempty"`
 
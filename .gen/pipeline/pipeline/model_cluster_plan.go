@@ -0,0 +1,47 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type ClusterPlan struct {
+
+	Changes []ClusterPlanChange `json:"changes"`
+}
+
+// AssertClusterPlanRequired checks if the required fields are not zero-ed
+func AssertClusterPlanRequired(obj ClusterPlan) error {
+	elements := map[string]interface{}{
+		"changes": obj.Changes,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	for _, el := range obj.Changes {
+		if err := AssertClusterPlanChangeRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseClusterPlanRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ClusterPlan (e.g. [][]ClusterPlan), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseClusterPlanRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aClusterPlan, ok := obj.(ClusterPlan)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertClusterPlanRequired(aClusterPlan)
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type ClusterPlanChange struct {
+
+	Action string `json:"action"`
+
+	// Type of the changed resource.
+	Resource string `json:"resource"`
+
+	Name string `json:"name"`
+
+	// Changed attribute of the resource (eg. a CloudFormation stack parameter).
+	Attribute string `json:"attribute,omitempty"`
+
+	// Current value of the attribute. Omitted when unknown.
+	Current interface{} `json:"current,omitempty"`
+
+	// Desired value of the attribute.
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+// AssertClusterPlanChangeRequired checks if the required fields are not zero-ed
+func AssertClusterPlanChangeRequired(obj ClusterPlanChange) error {
+	elements := map[string]interface{}{
+		"action": obj.Action,
+		"resource": obj.Resource,
+		"name": obj.Name,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseClusterPlanChangeRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ClusterPlanChange (e.g. [][]ClusterPlanChange), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseClusterPlanChangeRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aClusterPlanChange, ok := obj.(ClusterPlanChange)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertClusterPlanChangeRequired(aClusterPlanChange)
+	})
+}
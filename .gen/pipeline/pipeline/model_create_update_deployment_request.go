@@ -12,6 +12,7 @@ package pipeline
 
 type CreateUpdateDeploymentRequest struct {
 
+	// Chart name prefixed with the repository name or an OCI chart reference (eg. oci://registry.example.com/charts/pipeline).
 	Name string `json:"name"`
 
 	// Version of the deployment. If not specified, the latest version is used.
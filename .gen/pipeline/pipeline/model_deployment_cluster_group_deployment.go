@@ -28,6 +28,8 @@ type DeploymentClusterGroupDeployment struct {
 
 	RollingMode bool `json:"rollingMode,omitempty"`
 
+	RolloutStrategy DeploymentRolloutStrategy `json:"rolloutStrategy,omitempty"`
+
 	ValueOverrides map[string]interface{} `json:"valueOverrides,omitempty"`
 
 	Values map[string]interface{} `json:"values,omitempty"`
@@ -46,6 +48,9 @@ func AssertDeploymentClusterGroupDeploymentRequired(obj DeploymentClusterGroupDe
 		}
 	}
 
+	if err := AssertDeploymentRolloutStrategyRequired(obj.RolloutStrategy); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -14,6 +14,9 @@ type DeploymentCreateUpdateDeploymentResponse struct {
 
 	ReleaseName string `json:"releaseName,omitempty"`
 
+	// ID of the rollout process if the deployment has a rollout strategy
+	RolloutId string `json:"rolloutId,omitempty"`
+
 	TargetClusters []DeploymentTargetClusterStatus `json:"targetClusters,omitempty"`
 }
 
@@ -26,6 +26,11 @@ type DeploymentDeploymentInfo struct {
 
 	ReleaseName string `json:"releaseName,omitempty"`
 
+	// ID of the latest rollout process of the deployment
+	RolloutId string `json:"rolloutId,omitempty"`
+
+	RolloutStrategy DeploymentRolloutStrategy `json:"rolloutStrategy,omitempty"`
+
 	TargetClusters []DeploymentTargetClusterStatus `json:"targetClusters,omitempty"`
 
 	UpdatedAt string `json:"updatedAt,omitempty"`
@@ -39,6 +44,9 @@ type DeploymentDeploymentInfo struct {
 
 // AssertDeploymentDeploymentInfoRequired checks if the required fields are not zero-ed
 func AssertDeploymentDeploymentInfoRequired(obj DeploymentDeploymentInfo) error {
+	if err := AssertDeploymentRolloutStrategyRequired(obj.RolloutStrategy); err != nil {
+		return err
+	}
 	for _, el := range obj.TargetClusters {
 		if err := AssertDeploymentTargetClusterStatusRequired(el); err != nil {
 			return err
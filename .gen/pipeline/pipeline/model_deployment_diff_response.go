@@ -0,0 +1,48 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type DeploymentDiffResponse struct {
+
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	FromRevision int32 `json:"fromRevision,omitempty"`
+
+	FromChartVersion string `json:"fromChartVersion,omitempty"`
+
+	// Omitted when compared against a proposed upgrade
+	ToRevision int32 `json:"toRevision,omitempty"`
+
+	ToChartVersion string `json:"toChartVersion,omitempty"`
+
+	// Unified diff of the override values
+	Values string `json:"values,omitempty"`
+
+	// Unified diff of the rendered manifest
+	Manifest string `json:"manifest,omitempty"`
+}
+
+// AssertDeploymentDiffResponseRequired checks if the required fields are not zero-ed
+func AssertDeploymentDiffResponseRequired(obj DeploymentDiffResponse) error {
+	return nil
+}
+
+// AssertRecurseDeploymentDiffResponseRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of DeploymentDiffResponse (e.g. [][]DeploymentDiffResponse), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseDeploymentDiffResponseRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aDeploymentDiffResponse, ok := obj.(DeploymentDiffResponse)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertDeploymentDiffResponseRequired(aDeploymentDiffResponse)
+	})
+}
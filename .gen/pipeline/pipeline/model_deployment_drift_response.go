@@ -0,0 +1,58 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type DeploymentDriftResponse struct {
+
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	Namespace string `json:"namespace,omitempty"`
+
+	// Revision the live objects were compared with
+	Revision int32 `json:"revision,omitempty"`
+
+	Drifted bool `json:"drifted,omitempty"`
+
+	// Whether the deployment is re-applied when drift is detected
+	SelfHeal bool `json:"selfHeal,omitempty"`
+
+	Resources []DriftedResource `json:"resources,omitempty"`
+
+	CheckedAt time.Time `json:"checkedAt,omitempty"`
+
+	HealedAt time.Time `json:"healedAt,omitempty"`
+}
+
+// AssertDeploymentDriftResponseRequired checks if the required fields are not zero-ed
+func AssertDeploymentDriftResponseRequired(obj DeploymentDriftResponse) error {
+	for _, el := range obj.Resources {
+		if err := AssertDriftedResourceRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseDeploymentDriftResponseRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of DeploymentDriftResponse (e.g. [][]DeploymentDriftResponse), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseDeploymentDriftResponseRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aDeploymentDriftResponse, ok := obj.(DeploymentDriftResponse)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertDeploymentDriftResponseRequired(aDeploymentDriftResponse)
+	})
+}
@@ -0,0 +1,47 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type DeploymentHistoryResponseInner struct {
+
+	Revision int32 `json:"revision,omitempty"`
+
+	ChartName string `json:"chartName,omitempty"`
+
+	ChartVersion string `json:"chartVersion,omitempty"`
+
+	Status string `json:"status,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	Description string `json:"description,omitempty"`
+}
+
+// AssertDeploymentHistoryResponseInnerRequired checks if the required fields are not zero-ed
+func AssertDeploymentHistoryResponseInnerRequired(obj DeploymentHistoryResponseInner) error {
+	return nil
+}
+
+// AssertRecurseDeploymentHistoryResponseInnerRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of DeploymentHistoryResponseInner (e.g. [][]DeploymentHistoryResponseInner), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseDeploymentHistoryResponseInnerRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aDeploymentHistoryResponseInner, ok := obj.(DeploymentHistoryResponseInner)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertDeploymentHistoryResponseInnerRequired(aDeploymentHistoryResponseInner)
+	})
+}
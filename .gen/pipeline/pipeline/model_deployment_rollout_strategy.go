@@ -0,0 +1,47 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+// DeploymentRolloutStrategy - Describes how the deployment is rolled out to the member clusters
+type DeploymentRolloutStrategy struct {
+
+	// Clusters receiving the release first, the rest of the clusters are touched only if these become healthy
+	Canary []string `json:"canary,omitempty"`
+
+	// Ordered groups of clusters rolled out one after the other, clusters not listed anywhere are rolled out in a final wave
+	Waves [][]string `json:"waves,omitempty"`
+
+	// Maximum number of clusters upgraded at the same time, 0 means no limit
+	MaxParallel int32 `json:"maxParallel,omitempty"`
+
+	// Time in seconds the clusters of a wave have to become healthy (release deployed and pods ready)
+	HealthCheckTimeout int32 `json:"healthCheckTimeout,omitempty"`
+
+	// Roll the release back on the already upgraded clusters if the rollout halts
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+}
+
+// AssertDeploymentRolloutStrategyRequired checks if the required fields are not zero-ed
+func AssertDeploymentRolloutStrategyRequired(obj DeploymentRolloutStrategy) error {
+	return nil
+}
+
+// AssertRecurseDeploymentRolloutStrategyRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of DeploymentRolloutStrategy (e.g. [][]DeploymentRolloutStrategy), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseDeploymentRolloutStrategyRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aDeploymentRolloutStrategy, ok := obj.(DeploymentRolloutStrategy)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertDeploymentRolloutStrategyRequired(aDeploymentRolloutStrategy)
+	})
+}
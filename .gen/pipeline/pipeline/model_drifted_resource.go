@@ -0,0 +1,45 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type DriftedResource struct {
+
+	ApiVersion string `json:"apiVersion,omitempty"`
+
+	Kind string `json:"kind,omitempty"`
+
+	Namespace string `json:"namespace,omitempty"`
+
+	Name string `json:"name,omitempty"`
+
+	// The resource can not be found in the cluster
+	Missing bool `json:"missing,omitempty"`
+
+	// Paths of the fields differing from the deployment manifest
+	Fields []string `json:"fields,omitempty"`
+}
+
+// AssertDriftedResourceRequired checks if the required fields are not zero-ed
+func AssertDriftedResourceRequired(obj DriftedResource) error {
+	return nil
+}
+
+// AssertRecurseDriftedResourceRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of DriftedResource (e.g. [][]DriftedResource), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseDriftedResourceRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aDriftedResource, ok := obj.(DriftedResource)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertDriftedResourceRequired(aDriftedResource)
+	})
+}
@@ -0,0 +1,56 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+// EksNodePoolMixedInstancesPolicy - Mixed instances policy of an EKS node pool backing the node pool with multiple instance types and a mix of on-demand and spot instances. The node pool instance type must be one of the policy instance types and the node pool spot price is used as the maximum spot price. Node pool sizes are interpreted in weighted capacity units.
+type EksNodePoolMixedInstancesPolicy struct {
+
+	InstanceTypes []EksNodePoolMixedInstancesPolicyInstanceTypesInner `json:"instanceTypes"`
+
+	// Minimum amount of the node pool capacity fulfilled by on-demand instances.
+	OnDemandBaseCapacity int32 `json:"onDemandBaseCapacity,omitempty"`
+
+	// Percentage of on-demand instances above the on-demand base capacity, the rest is fulfilled by spot instances.
+	OnDemandPercentageAboveBaseCapacity int32 `json:"onDemandPercentageAboveBaseCapacity,omitempty"`
+
+	SpotAllocationStrategy string `json:"spotAllocationStrategy,omitempty"`
+}
+
+// AssertEksNodePoolMixedInstancesPolicyRequired checks if the required fields are not zero-ed
+func AssertEksNodePoolMixedInstancesPolicyRequired(obj EksNodePoolMixedInstancesPolicy) error {
+	elements := map[string]interface{}{
+		"instanceTypes": obj.InstanceTypes,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	for _, el := range obj.InstanceTypes {
+		if err := AssertEksNodePoolMixedInstancesPolicyInstanceTypesInnerRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseEksNodePoolMixedInstancesPolicyRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of EksNodePoolMixedInstancesPolicy (e.g. [][]EksNodePoolMixedInstancesPolicy), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseEksNodePoolMixedInstancesPolicyRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aEksNodePoolMixedInstancesPolicy, ok := obj.(EksNodePoolMixedInstancesPolicy)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertEksNodePoolMixedInstancesPolicyRequired(aEksNodePoolMixedInstancesPolicy)
+	})
+}
@@ -0,0 +1,45 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type EksNodePoolMixedInstancesPolicyInstanceTypesInner struct {
+
+	InstanceType string `json:"instanceType"`
+
+	// Number of capacity units provided by an instance of the type (default 1, also used when 0).
+	WeightedCapacity int32 `json:"weightedCapacity,omitempty"`
+}
+
+// AssertEksNodePoolMixedInstancesPolicyInstanceTypesInnerRequired checks if the required fields are not zero-ed
+func AssertEksNodePoolMixedInstancesPolicyInstanceTypesInnerRequired(obj EksNodePoolMixedInstancesPolicyInstanceTypesInner) error {
+	elements := map[string]interface{}{
+		"instanceType": obj.InstanceType,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseEksNodePoolMixedInstancesPolicyInstanceTypesInnerRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of EksNodePoolMixedInstancesPolicyInstanceTypesInner (e.g. [][]EksNodePoolMixedInstancesPolicyInstanceTypesInner), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseEksNodePoolMixedInstancesPolicyInstanceTypesInnerRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aEksNodePoolMixedInstancesPolicyInstanceTypesInner, ok := obj.(EksNodePoolMixedInstancesPolicyInstanceTypesInner)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertEksNodePoolMixedInstancesPolicyInstanceTypesInnerRequired(aEksNodePoolMixedInstancesPolicyInstanceTypesInner)
+	})
+}
@@ -40,6 +40,12 @@ type EksUpdateNodePoolRequestAllOf struct {
 	// Setup available instance stores (NVMe disks) to use for Kubelet root if available. As a result emptyDir volumes will be provisioned on local instance storage disks. You can check out available instance storages here https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/InstanceStorage.html#instance-store-volumes.
 	UseInstanceStore *bool `json:"useInstanceStore,omitempty"`
 
+	// Kubernetes taints placed onto the nodes of the node pool.
+	Taints *[]NodePoolTaint `json:"taints,omitempty"`
+
+	// Kubernetes taints placed onto the nodes of the node pool at registration which are expected to be removed by a component running on the node once it is ready. These taints are not advertised to the cluster autoscaler.
+	StartupTaints *[]NodePoolTaint `json:"startupTaints,omitempty"`
+
 	Options BaseUpdateNodePoolOptions `json:"options,omitempty"`
 }
 
@@ -53,6 +59,20 @@ func AssertEksUpdateNodePoolRequestAllOfRequired(obj EksUpdateNodePoolRequestAll
 			return err
 		}
 	}
+	if obj.Taints != nil {
+		for _, el := range *obj.Taints {
+			if err := AssertNodePoolTaintRequired(el); err != nil {
+				return err
+			}
+		}
+	}
+	if obj.StartupTaints != nil {
+		for _, el := range *obj.StartupTaints {
+			if err := AssertNodePoolTaintRequired(el); err != nil {
+				return err
+			}
+		}
+	}
 	if err := AssertBaseUpdateNodePoolOptionsRequired(obj.Options); err != nil {
 		return err
 	}
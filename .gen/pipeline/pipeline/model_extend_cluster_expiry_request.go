@@ -0,0 +1,43 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type ExtendClusterExpiryRequest struct {
+
+	// Number of hours to postpone the expiry of the cluster with
+	Hours int32 `json:"hours"`
+}
+
+// AssertExtendClusterExpiryRequestRequired checks if the required fields are not zero-ed
+func AssertExtendClusterExpiryRequestRequired(obj ExtendClusterExpiryRequest) error {
+	elements := map[string]interface{}{
+		"hours": obj.Hours,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseExtendClusterExpiryRequestRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ExtendClusterExpiryRequest (e.g. [][]ExtendClusterExpiryRequest), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseExtendClusterExpiryRequestRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aExtendClusterExpiryRequest, ok := obj.(ExtendClusterExpiryRequest)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertExtendClusterExpiryRequestRequired(aExtendClusterExpiryRequest)
+	})
+}
@@ -0,0 +1,38 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type ExtendClusterExpiryResponse struct {
+
+	// The new expiry date of the cluster
+	Date time.Time `json:"date,omitempty"`
+}
+
+// AssertExtendClusterExpiryResponseRequired checks if the required fields are not zero-ed
+func AssertExtendClusterExpiryResponseRequired(obj ExtendClusterExpiryResponse) error {
+	return nil
+}
+
+// AssertRecurseExtendClusterExpiryResponseRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ExtendClusterExpiryResponse (e.g. [][]ExtendClusterExpiryResponse), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseExtendClusterExpiryResponseRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aExtendClusterExpiryResponse, ok := obj.(ExtendClusterExpiryResponse)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertExtendClusterExpiryResponseRequired(aExtendClusterExpiryResponse)
+	})
+}
@@ -14,6 +14,7 @@ type HelmReposAddRequest struct {
 
 	Name string `json:"name"`
 
+	// Repository URL. OCI registries are referenced with the oci scheme (eg. oci://registry.example.com/charts).
 	Url string `json:"url"`
 
 	CertFile string `json:"certFile,omitempty"`
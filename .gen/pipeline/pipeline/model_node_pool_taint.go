@@ -0,0 +1,48 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+// NodePoolTaint - Kubernetes taint of the nodes in a node pool.
+type NodePoolTaint struct {
+
+	Key string `json:"key"`
+
+	Value string `json:"value,omitempty"`
+
+	Effect string `json:"effect"`
+}
+
+// AssertNodePoolTaintRequired checks if the required fields are not zero-ed
+func AssertNodePoolTaintRequired(obj NodePoolTaint) error {
+	elements := map[string]interface{}{
+		"key": obj.Key,
+		"effect": obj.Effect,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseNodePoolTaintRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of NodePoolTaint (e.g. [][]NodePoolTaint), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseNodePoolTaintRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aNodePoolTaint, ok := obj.(NodePoolTaint)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertNodePoolTaintRequired(aNodePoolTaint)
+	})
+}
@@ -19,6 +19,12 @@ type NodePoolsPke struct {
 	// user provided custom node labels to be placed onto the nodes of the node pool
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// Kubernetes taints placed onto the nodes of the node pool in key=value:effect format.
+	Taints []string `json:"taints,omitempty"`
+
+	// Kubernetes taints placed onto the nodes of the node pool at registration in key=value:effect format which are expected to be removed by a component running on the node once it is ready.
+	StartupTaints []string `json:"startupTaints,omitempty"`
+
 	// Enables/disables autoscaling of this node pool through Kubernetes cluster autoscaler.
 	Autoscaling bool `json:"autoscaling"`
 
@@ -0,0 +1,36 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type RollbackDeploymentRequest struct {
+
+	// Revision to roll back to (defaults to the previous one)
+	Revision int32 `json:"revision,omitempty"`
+
+	Wait bool `json:"wait,omitempty"`
+}
+
+// AssertRollbackDeploymentRequestRequired checks if the required fields are not zero-ed
+func AssertRollbackDeploymentRequestRequired(obj RollbackDeploymentRequest) error {
+	return nil
+}
+
+// AssertRecurseRollbackDeploymentRequestRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of RollbackDeploymentRequest (e.g. [][]RollbackDeploymentRequest), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseRollbackDeploymentRequestRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aRollbackDeploymentRequest, ok := obj.(RollbackDeploymentRequest)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertRollbackDeploymentRequestRequired(aRollbackDeploymentRequest)
+	})
+}
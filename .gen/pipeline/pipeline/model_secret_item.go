@@ -26,6 +26,8 @@ type SecretItem struct {
 
 	UpdatedBy string `json:"updatedBy,omitempty"`
 
+	Version int32 `json:"version,omitempty"`
+
 	Tags []string `json:"tags,omitempty"`
 
 	Values map[string]interface{} `json:"values,omitempty"`
@@ -0,0 +1,43 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type SecretRotationPolicy struct {
+
+	Interval string `json:"interval,omitempty"`
+
+	NotifyBefore string `json:"notifyBefore,omitempty"`
+
+	LastRotatedAt time.Time `json:"lastRotatedAt,omitempty"`
+
+	NextRotation time.Time `json:"nextRotation,omitempty"`
+}
+
+// AssertSecretRotationPolicyRequired checks if the required fields are not zero-ed
+func AssertSecretRotationPolicyRequired(obj SecretRotationPolicy) error {
+	return nil
+}
+
+// AssertRecurseSecretRotationPolicyRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of SecretRotationPolicy (e.g. [][]SecretRotationPolicy), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseSecretRotationPolicyRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aSecretRotationPolicy, ok := obj.(SecretRotationPolicy)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertSecretRotationPolicyRequired(aSecretRotationPolicy)
+	})
+}
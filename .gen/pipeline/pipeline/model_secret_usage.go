@@ -0,0 +1,39 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type SecretUsage struct {
+
+	Type string `json:"type,omitempty"`
+
+	Id int32 `json:"id,omitempty"`
+
+	Name string `json:"name,omitempty"`
+
+	ClusterId int32 `json:"clusterId,omitempty"`
+}
+
+// AssertSecretUsageRequired checks if the required fields are not zero-ed
+func AssertSecretUsageRequired(obj SecretUsage) error {
+	return nil
+}
+
+// AssertRecurseSecretUsageRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of SecretUsage (e.g. [][]SecretUsage), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseSecretUsageRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aSecretUsage, ok := obj.(SecretUsage)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertSecretUsageRequired(aSecretUsage)
+	})
+}
@@ -0,0 +1,43 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type SecretVersion struct {
+
+	Version int32 `json:"version,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	Deleted bool `json:"deleted,omitempty"`
+
+	Destroyed bool `json:"destroyed,omitempty"`
+}
+
+// AssertSecretVersionRequired checks if the required fields are not zero-ed
+func AssertSecretVersionRequired(obj SecretVersion) error {
+	return nil
+}
+
+// AssertRecurseSecretVersionRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of SecretVersion (e.g. [][]SecretVersion), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseSecretVersionRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aSecretVersion, ok := obj.(SecretVersion)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertSecretVersionRequired(aSecretVersion)
+	})
+}
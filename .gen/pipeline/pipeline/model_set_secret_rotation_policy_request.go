@@ -0,0 +1,46 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type SetSecretRotationPolicyRequest struct {
+
+	// Time between two rotations
+	Interval string `json:"interval"`
+
+	// Time before the rotation when a notification is sent
+	NotifyBefore string `json:"notifyBefore,omitempty"`
+}
+
+// AssertSetSecretRotationPolicyRequestRequired checks if the required fields are not zero-ed
+func AssertSetSecretRotationPolicyRequestRequired(obj SetSecretRotationPolicyRequest) error {
+	elements := map[string]interface{}{
+		"interval": obj.Interval,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseSetSecretRotationPolicyRequestRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of SetSecretRotationPolicyRequest (e.g. [][]SetSecretRotationPolicyRequest), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseSetSecretRotationPolicyRequestRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aSetSecretRotationPolicyRequest, ok := obj.(SetSecretRotationPolicyRequest)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertSetSecretRotationPolicyRequestRequired(aSetSecretRotationPolicyRequest)
+	})
+}
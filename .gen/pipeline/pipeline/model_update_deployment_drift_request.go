@@ -0,0 +1,43 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type UpdateDeploymentDriftRequest struct {
+
+	// Re-apply the deployment when drift is detected
+	SelfHeal bool `json:"selfHeal"`
+}
+
+// AssertUpdateDeploymentDriftRequestRequired checks if the required fields are not zero-ed
+func AssertUpdateDeploymentDriftRequestRequired(obj UpdateDeploymentDriftRequest) error {
+	elements := map[string]interface{}{
+		"selfHeal": obj.SelfHeal,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseUpdateDeploymentDriftRequestRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of UpdateDeploymentDriftRequest (e.g. [][]UpdateDeploymentDriftRequest), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseUpdateDeploymentDriftRequestRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aUpdateDeploymentDriftRequest, ok := obj.(UpdateDeploymentDriftRequest)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertUpdateDeploymentDriftRequestRequired(aUpdateDeploymentDriftRequest)
+	})
+}
@@ -13,7 +13,7 @@ package pipeline
 // UpdateNodePoolDrainOptions - Drain options for old nodes.
 type UpdateNodePoolDrainOptions struct {
 
-	// How long should drain wait for pod eviction (in seconds)
+	// How long should drain wait for pod eviction (in seconds). When 0, the default timeout (15 minutes) is used by the `replace` strategy.
 	Timeout int32 `json:"timeout,omitempty"`
 
 	// Whether the process should fail if draining fails/times out.
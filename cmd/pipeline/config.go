@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"emperror.dev/errors"
 	"github.com/spf13/pflag"
@@ -53,7 +54,7 @@ type configuration struct {
 
 // Validate validates the configuration.
 func (c configuration) Validate() error {
-	return errors.Combine(c.Auth.Validate(), c.Config.Validate(), c.Frontend.Validate())
+	return errors.Combine(c.Auth.Validate(), c.Config.Validate(), c.Frontend.Validate(), c.AuditLog.Validate())
 }
 
 // Process post-processes the configuration after loading (before validation).
@@ -104,7 +105,50 @@ type auditLogConfig struct {
 		Database struct {
 			Enabled bool
 		}
+
+		File struct {
+			Enabled bool
+
+			Config auditlogdriver.FileDriverConfig `mapstructure:",squash"`
+		}
+
+		Webhook struct {
+			Enabled bool
+
+			Config auditlogdriver.HTTPDriverConfig `mapstructure:",squash"`
+		}
+
+		Syslog struct {
+			Enabled bool
+
+			Config auditlogdriver.SyslogDriverConfig `mapstructure:",squash"`
+		}
 	}
+
+	// Queue buffers the entries sent to external sinks (webhook and syslog).
+	Queue auditlogdriver.AsyncDriverConfig
+
+	// RedactedKeys lists the JSON keys whose values are redacted from every recorded request body.
+	RedactedKeys []string
+}
+
+// Validate validates the configuration.
+func (c auditLogConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	var err error
+
+	if c.Driver.File.Enabled {
+		err = errors.Append(err, c.Driver.File.Config.Validate())
+	}
+
+	if c.Driver.Webhook.Enabled {
+		err = errors.Append(err, c.Driver.Webhook.Config.Validate())
+	}
+
+	return err
 }
 
 // configure configures some defaults in the Viper instance.
@@ -127,6 +171,8 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	// Load common configuration
 	cmd.Configure(v, p)
 
+	v.SetDefault("eventBus::consumerGroup", "pipeline")
+
 	// Pipeline configuration
 	p.String("addr", "127.0.0.1:9090", "Pipeline HTTP server address")
 	_ = v.BindPFlag("pipeline::addr", p.Lookup("addr"))
@@ -165,6 +211,22 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	v.SetDefault("auditLog::driver::log::verbosity", 1)
 	v.SetDefault("auditLog::driver::log::fields", []string{})
 	v.SetDefault("auditLog::driver::database::enabled", true)
+	v.SetDefault("auditLog::driver::file::enabled", false)
+	v.SetDefault("auditLog::driver::file::path", "audit.log")
+	v.SetDefault("auditLog::driver::file::maxSize", 100)
+	v.SetDefault("auditLog::driver::file::maxBackups", 5)
+	v.SetDefault("auditLog::driver::webhook::enabled", false)
+	v.SetDefault("auditLog::driver::webhook::url", "")
+	v.SetDefault("auditLog::driver::webhook::headers", map[string]string{})
+	v.SetDefault("auditLog::driver::webhook::timeout", 5*time.Second)
+	v.SetDefault("auditLog::driver::syslog::enabled", false)
+	v.SetDefault("auditLog::driver::syslog::network", "")
+	v.SetDefault("auditLog::driver::syslog::address", "")
+	v.SetDefault("auditLog::driver::syslog::tag", "pipeline-audit")
+	v.SetDefault("auditLog::queue::queueSize", 1000)
+	v.SetDefault("auditLog::queue::maxRetries", 5)
+	v.SetDefault("auditLog::queue::retryInterval", time.Second)
+	v.SetDefault("auditLog::redactedKeys", []string{"password", "secret", "token", "kubeconfig", "privateKey", "clientSecret"})
 
 	// Database config
 	v.SetDefault("database::autoMigrate", false)
@@ -57,6 +57,7 @@ import (
 	"github.com/sagikazarmark/ocmux"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	watermilllog "logur.dev/integration/watermill"
 	zaplog "logur.dev/integration/zap"
 	"logur.dev/logur"
 
@@ -69,11 +70,19 @@ import (
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/cap/capdriver"
 	googleproject "github.com/banzaicloud/pipeline/internal/app/pipeline/cloud/google/project"
 	googleprojectdriver "github.com/banzaicloud/pipeline/internal/app/pipeline/cloud/google/project/projectdriver"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops/gitopsadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops/gitopsdriver"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops/gitopsworkflow"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
 	processapp "github.com/banzaicloud/pipeline/internal/app/pipeline/process/app"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process/processadapter"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/secrettype"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/secrettype/secrettypedriver"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook/webhookadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook/webhookdriver"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook/webhookworkflow"
 	arkClusterManager "github.com/banzaicloud/pipeline/internal/ark/clustermanager"
 	arkEvents "github.com/banzaicloud/pipeline/internal/ark/events"
 	arkSync "github.com/banzaicloud/pipeline/internal/ark/sync"
@@ -83,6 +92,9 @@ import (
 	"github.com/banzaicloud/pipeline/internal/cluster/clusterdriver"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersecret"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersecret/clustersecretadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate"
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate/clustertemplateadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate/clustertemplatedriver"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksadapter"
 	eksDriver "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/driver"
@@ -94,6 +106,9 @@ import (
 	"github.com/banzaicloud/pipeline/internal/clustergroup"
 	cgroupAdapter "github.com/banzaicloud/pipeline/internal/clustergroup/adapter"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment/deploymentworkflow"
+	cgroupEvents "github.com/banzaicloud/pipeline/internal/clustergroup/events"
+	"github.com/banzaicloud/pipeline/internal/clustergroup/secretsync"
 	"github.com/banzaicloud/pipeline/internal/cmd"
 	"github.com/banzaicloud/pipeline/internal/common/commonadapter"
 	"github.com/banzaicloud/pipeline/internal/dashboard"
@@ -115,6 +130,7 @@ import (
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/ingress"
 	integratedServiceLogging "github.com/banzaicloud/pipeline/internal/integratedservices/services/logging"
 	featureMonitoring "github.com/banzaicloud/pipeline/internal/integratedservices/services/monitoring"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/securityscan"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/securityscan/securityscanadapter"
 	integratedServiceVault "github.com/banzaicloud/pipeline/internal/integratedservices/services/vault"
@@ -143,8 +159,12 @@ import (
 	vspherePKEDriver "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/driver"
 	"github.com/banzaicloud/pipeline/internal/secret/pkesecret"
 	"github.com/banzaicloud/pipeline/internal/secret/restricted"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation/rotationadapter"
 	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
 	"github.com/banzaicloud/pipeline/internal/secret/types"
+	"github.com/banzaicloud/pipeline/internal/secret/usage"
+	"github.com/banzaicloud/pipeline/internal/secret/usage/usageadapter"
 	anchore "github.com/banzaicloud/pipeline/internal/security"
 	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 	"github.com/banzaicloud/pipeline/pkg/cloudinfo"
@@ -193,6 +213,7 @@ func main() {
 
 	p.String("config", "", "Configuration file")
 	p.Bool("version", false, "Show version information")
+	p.String("migrate-secrets-from", "", "Copy every secret from the given secret store backend to the configured one, then exit")
 
 	_ = p.Parse(os.Args[1:])
 
@@ -276,7 +297,14 @@ func main() {
 	emperror.Panic(err)
 	global.SetVault(vaultClient)
 
-	secretStore := secretadapter.NewVaultStore(vaultClient, "secret")
+	// Connect to database
+	db, err := database.Connect(config.Database.Config)
+	emperror.Panic(errors.WithMessage(err, "failed to initialize db"))
+	global.SetDB(db)
+
+	secretStore, err := secretadapter.NewStore(config.Secret.Store, vaultClient, db)
+	emperror.Panic(errors.WithMessage(err, "failed to initialize secret store"))
+
 	pkeSecreter := pkesecret.NewPkeSecreter(vaultClient, commonLogger)
 	secretTypes := types.NewDefaultTypeList(types.DefaultTypeListConfig{
 		AmazonRegion:       config.Cloud.Amazon.DefaultRegion,
@@ -286,12 +314,8 @@ func main() {
 	secret.InitSecretStore(secretStore, secretTypes)
 	restricted.InitSecretStore(secret.Store)
 
-	// Connect to database
-	db, err := database.Connect(config.Database.Config)
-	emperror.Panic(errors.WithMessage(err, "failed to initialize db"))
-	global.SetDB(db)
-
-	publisher, subscriber := watermill.NewPubSub(logger)
+	publisher, subscriber, err := watermill.NewPubSub(config.EventBus, db, logger)
+	emperror.Panic(errors.WithMessage(err, "failed to initialize event bus"))
 	defer publisher.Close()
 	defer subscriber.Close()
 
@@ -301,11 +325,18 @@ func main() {
 		}
 	})(publisher)
 
-	subscriber, _ = message.MessageTransformSubscriberDecorator(func(msg *message.Message) {
+	correlationSubscriberDecorator := message.MessageTransformSubscriberDecorator(func(msg *message.Message) {
 		if cid := watermillMiddleware.MessageCorrelationID(msg); cid != "" {
 			msg.SetContext(correlation.ToContext(msg.Context(), cid))
 		}
-	})(subscriber)
+	})
+
+	// handlerSubscriber returns a subscriber for a router handler
+	handlerSubscriber := func(handlerName string) message.Subscriber {
+		s, _ := correlationSubscriberDecorator(watermill.HandlerSubscriber(subscriber, handlerName))
+
+		return s
+	}
 
 	// Used internally to make sure every event/command bus uses the same one
 	eventMarshaler := cqrs.JSONMarshaler{GenerateName: cqrs.StructName}
@@ -313,6 +344,7 @@ func main() {
 	commonSecretStore := commonadapter.NewSecretStore(secret.Store, commonadapter.OrgIDContextExtractorFunc(auth.GetCurrentOrganizationID))
 
 	organizationStore := authadapter.NewGormOrganizationStore(db)
+	roleStore := authadapter.NewGormRoleStore(db)
 
 	const organizationTopic = "organization"
 	var organizationSyncer auth.OIDCOrganizationSyncer
@@ -357,6 +389,17 @@ func main() {
 		}
 	}
 
+	if from, _ := p.GetString("migrate-secrets-from"); from != "" {
+		err := migrateSecrets(context.Background(), config.Secret.Store, from, vaultClient, db, secretStore, commonLogger)
+		if err != nil {
+			logger.Error(err.Error())
+
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
 	// External DNS service
 	dnsSvc, err := dns.GetExternalDnsServiceClient()
 	if err != nil {
@@ -370,7 +413,17 @@ func main() {
 	prometheus.MustRegister(cluster.NewExporter())
 
 	clusterEventBus := evbus.New()
-	clusterEvents := cluster.NewClusterEvents(clusterEventBus)
+	clusterEventDispatcher, _ := cqrs.NewEventBus(
+		publisher,
+		func(eventName string) string { return cluster.EventTopic },
+		eventMarshaler,
+	)
+	clusterEvents := cluster.NewDispatchingClusterEvents(
+		cluster.NewClusterEvents(clusterEventBus),
+		cluster.NewClusterEventDispatcher(clusterEventDispatcher),
+		errorHandler,
+	)
+
 	clusters := clusteradapter.NewClusters(db)
 	secretValidator := providers.NewSecretValidator(secret.Store)
 	statusChangeDurationMetric := prometheusMetrics.MakePrometheusClusterStatusChangeDurationMetric()
@@ -417,13 +470,57 @@ func main() {
 		emperror.Panic(errors.WrapIf(err, "Failed to configure Cadence client"))
 	}
 
+	webhookStore := webhookadapter.NewGormStore(db)
+	webhookAddresses := webhook.AddressPolicy{AllowPrivateNetworks: config.Webhook.AllowPrivateNetworks}
+	webhookSender := webhookadapter.NewHTTPSender(webhookadapter.NewSecretStore(secret.Store), config.Webhook.Timeout, webhookAddresses)
+	webhookDispatcher := webhook.NewDispatcher(
+		webhookStore,
+		webhookworkflow.NewDeliveryScheduler(workflowClient, webhookworkflow.DeliveryConfig{
+			MaxAttempts:   config.Webhook.MaxAttempts,
+			RetryInterval: config.Webhook.RetryInterval,
+		}),
+		commonLogger.WithFields(map[string]interface{}{"component": "webhook"}),
+		errorHandler,
+	)
+
 	releaseDeleter := cmd.CreateReleaseDeleter(config.Helm, db, commonSecretStore, commonLogger)
 
 	clusterManager := cluster.NewManager(clusters, secretValidator, clusterEvents, statusChangeDurationMetric, clusterTotalMetric, workflowClient, logrusLogger, errorHandler, clusteradapter.NewStore(db, clusters), releaseDeleter)
 	commonClusterGetter := common.NewClusterGetter(clusterManager, logrusLogger, errorHandler)
+	clusterAccessChecker := intCluster.NewAccessChecker(
+		clusteradapter.NewStore(db, clusters),
+		auth.NewClusterAccessResolver(organizationStore, roleStore),
+	)
 
 	var group run.Group
 
+	// Event bus subscriptions
+	{
+		router, err := message.NewRouter(
+			message.RouterConfig{},
+			watermilllog.New(logur.WithFields(logger, map[string]interface{}{"component": "watermill"})),
+		)
+		emperror.Panic(err)
+
+		router.AddMiddleware(watermillMiddleware.Retry{
+			MaxRetries:      3,
+			InitialInterval: time.Second,
+			Multiplier:      2,
+		}.Middleware)
+
+		router.AddNoPublisherHandler(
+			"webhook_cluster_events",
+			cluster.EventTopic,
+			handlerSubscriber("webhook_cluster_events"),
+			webhookadapter.NewClusterEventHandler(webhookDispatcher, clusteradapter.NewStore(db, clusters)).Handle,
+		)
+
+		group.Add(
+			func() error { return router.Run(context.Background()) },
+			func(error) { _ = router.Close() },
+		)
+	}
+
 	url, _ := url.Parse(config.Cloudinfo.Endpoint)
 	if err != nil {
 		emperror.Panic(errors.WrapIf(err, "failed to parse Cloudinfo endpoint"))
@@ -498,11 +595,20 @@ func main() {
 		commonLogger,
 	)
 
-	cgroupAdapter := cgroupAdapter.NewClusterGetter(clusterManager)
+	cgroupAdapter := cgroupAdapter.NewClusterGetter(clusterManager, clusteradapter.NewStore(db, clusters))
 	clusterGroupManager := clustergroup.NewManager(cgroupAdapter, clustergroup.NewClusterGroupRepository(db, logrusLogger), logrusLogger, errorHandler)
-	deploymentManager := deployment.NewCGDeploymentManager(db, cgroupAdapter, logrusLogger, errorHandler, deployment.NewHelmService(helmFacade, unifiedHelmReleaser))
+	deploymentManager := deployment.NewCGDeploymentManager(db, cgroupAdapter, logrusLogger, errorHandler, deployment.NewHelmService(helmFacade, unifiedHelmReleaser), deploymentworkflow.NewRolloutStarter(workflowClient))
 
 	clusterGroupManager.RegisterFeatureHandler(deployment.FeatureName, deploymentManager)
+	clusterGroupManager.RegisterFeatureHandler(secretsync.FeatureName, secretsync.NewManager(
+		secretsync.NewSyncedSecretRepository(db),
+		cgroupAdapter,
+		restricted.NewSecretStore(secret.Store),
+		secretsync.NewKubernetesSecretWriter(),
+		logrusLogger,
+		errorHandler,
+	))
+	cgroupEvents.NewClusterEventHandler(cgroupEvents.NewClusterEvents(clusterEventBus), clusterGroupManager, errorHandler)
 	clusterUpdaters := api.ClusterUpdaters{
 		PKEOnAzure: azurePKEDriver.MakeClusterUpdater(
 			logrusLogger,
@@ -599,14 +705,58 @@ func main() {
 			driver = append(driver, auditlogdriver.NewDatabaseDriver(db))
 		}
 
+		if config.AuditLog.Driver.File.Enabled {
+			driver = append(driver, auditlogdriver.NewFileDriver(config.AuditLog.Driver.File.Config))
+		}
+
+		if config.AuditLog.Driver.Webhook.Enabled {
+			webhookDriver := auditlogdriver.NewAsyncDriver(
+				auditlogdriver.NewHTTPDriver(config.AuditLog.Driver.Webhook.Config),
+				config.AuditLog.Queue,
+				errorHandler,
+			)
+			defer webhookDriver.Close()
+
+			driver = append(driver, webhookDriver)
+		}
+
+		if config.AuditLog.Driver.Syslog.Enabled {
+			syslogDriver, err := auditlogdriver.NewSyslogDriver(config.AuditLog.Driver.Syslog.Config)
+			emperror.Panic(err)
+
+			asyncSyslogDriver := auditlogdriver.NewAsyncDriver(syslogDriver, config.AuditLog.Queue, errorHandler)
+			defer asyncSyslogDriver.Close()
+
+			driver = append(driver, asyncSyslogDriver)
+		}
+
+		redactionRules := []auditlog.RedactionRule{
+			{
+				// Secrets installed to clusters
+				Path: regexp.MustCompile("^/(?:[^/]*/)*api/v1/orgs/[0-9]+/clusters/[^/]+/secrets(?:/[^/]+)*"),
+			},
+		}
+
+		if len(config.AuditLog.RedactedKeys) > 0 {
+			redactionRules = append(redactionRules, auditlog.RedactionRule{Keys: config.AuditLog.RedactedKeys})
+		}
+
 		engine.Use(auditlog.Middleware(
 			driver,
 			auditlog.WithUserIDExtractor(auth.GetCurrentUserID),
+			auditlog.WithOrganizationIDExtractor(func(req *http.Request) uint {
+				if organization := auth.GetCurrentOrganization(req); organization != nil {
+					return organization.ID
+				}
+
+				return 0
+			}),
 			auditlog.WithSensitivePaths([]*regexp.Regexp{
 				regexp.MustCompile("^/auth/dex(?:/[^/]+)*"),
 				regexp.MustCompile("^/(?:[^/]*/)*api/v1/orgs/[0-9]+/secrets(?:/[^/]+)*"),
 				regexp.MustCompile("^/(?:[^/]*/)*api/v1/orgs/[0-9]+/clusters/[^/]+/pke/ready"),
 			}),
+			auditlog.WithRedactionRules(redactionRules),
 			auditlog.WithErrorHandler(errorHandler),
 		))
 	}
@@ -642,7 +792,7 @@ func main() {
 
 	auth.Install(engine)
 
-	enforcer := auth.NewRbacEnforcer(organizationStore, serviceAccountService, commonLogger)
+	enforcer := auth.NewRbacEnforcer(organizationStore, roleStore, serviceAccountService, commonLogger)
 	authorizationMiddleware := ginauth.NewMiddleware(enforcer, basePath, errorHandler)
 
 	clusterSecretStore := clustersecret.NewStore(
@@ -653,7 +803,7 @@ func main() {
 	clusterAuthService, err := intClusterAuth.NewDexClusterAuthService(clusterSecretStore)
 	emperror.Panic(errors.WrapIf(err, "failed to create DexClusterAuthService"))
 
-	dashboardAPI := dashboard.NewDashboardAPI(clusterManager, clusterGroupManager, logrusLogger, errorHandler, config.Auth, clusterAuthService)
+	dashboardAPI := dashboard.NewDashboardAPI(clusterManager, clusterGroupManager, logrusLogger, errorHandler, config.Auth, clusterAuthService, clusterAccessChecker)
 	dgroup := base.Group(path.Join("dashboard", "orgs"))
 	dgroup.Use(auth.InternalHandler)
 	dgroup.Use(auth.Handler)
@@ -665,6 +815,7 @@ func main() {
 		// Cluster details dashboard
 		dcGroup := dgroup.Group("/:orgid/clusters/:id")
 		dcGroup.Use(cluster.NewClusterCheckMiddleware(clusterManager, errorHandler))
+		dcGroup.Use(cluster.NewClusterAccessMiddleware(clusterAccessChecker, errorHandler))
 		dcGroup.GET("", dashboardAPI.GetClusterDashboard)
 	}
 
@@ -673,6 +824,15 @@ func main() {
 
 	networkAPI := api.NewNetworkAPI(logrusLogger)
 
+	secretRotationAPI := api.NewSecretRotationAPI(
+		rotation.NewService(
+			rotationadapter.NewGormStore(db),
+			rotationadapter.NewSecretStore(secret.Store),
+			secretTypes,
+		),
+		logrusLogger,
+	)
+
 	clusterAPI := api.NewClusterAPI(
 		clusterManager,
 		commonClusterGetter,
@@ -688,10 +848,12 @@ func main() {
 		config.Auth,
 		config.Distribution,
 		clusterAuthService,
+		clusterAccessChecker,
 	)
 
 	v1 := base.Group("api/v1")
 	var isServiceV2 integratedservices.Service
+	var isRepositoryV2 integratedservices.IntegratedServiceRepository
 	apiRouter := router.PathPrefix("/api/v1").Subrouter()
 	{
 		apiRouter.NotFoundHandler = problems.StatusProblemHandler(problems.NewStatusProblem(http.StatusNotFound))
@@ -731,10 +893,12 @@ func main() {
 			cRouter := orgs.Group("/:orgid/clusters/:id")
 			clusterRouter := orgRouter.PathPrefix("/clusters/{clusterId}").Subrouter()
 			clusterStore := clusteradapter.NewStore(db, clusters)
+			var clusterService intCluster.Service
 			{
 				logger := commonadapter.NewLogger(logger) // TODO: make this a context aware logger
 
 				cRouter.Use(cluster.NewClusterCheckMiddleware(clusterManager, errorHandler))
+				cRouter.Use(cluster.NewClusterAccessMiddleware(clusterAccessChecker, errorHandler))
 
 				cRouter.GET("", clusterAPI.GetCluster)
 				cRouter.GET("/pods", api.GetPodDetails)
@@ -774,6 +938,16 @@ func main() {
 						kitxhttp.ServerOptions(httpServerOptions),
 					)
 
+					driftEndpoints := helmdriver.MakeDriftEndpoints(
+						helm.NewDriftService(helmFacade, helmadapter.NewDriftStore(db, commonLogger)),
+						kitxendpoint.Combine(endpointMiddleware...),
+					)
+
+					helmdriver.RegisterDriftHTTPHandlers(driftEndpoints,
+						clusterRouter,
+						kitxhttp.ServerOptions(httpServerOptions),
+					)
+
 					deploymentsRouter := cRouter.Group("/deployments")
 					deploymentsRouter.POST("", gin.WrapH(router))
 					deploymentsRouter.GET("", gin.WrapH(router))
@@ -782,6 +956,13 @@ func main() {
 					deploymentsRouter.HEAD(":name", gin.WrapH(router))
 					deploymentsRouter.DELETE(":name", gin.WrapH(router))
 					deploymentsRouter.GET(":name/resources", gin.WrapH(router))
+					deploymentsRouter.GET(":name/history", gin.WrapH(router))
+					deploymentsRouter.POST(":name/rollback", gin.WrapH(router))
+					deploymentsRouter.GET(":name/diff", gin.WrapH(router))
+					deploymentsRouter.POST(":name/diff", gin.WrapH(router))
+					deploymentsRouter.GET(":name/drift", gin.WrapH(router))
+					deploymentsRouter.PUT(":name/drift", gin.WrapH(router))
+					cRouter.GET("/deployment-drifts", gin.WrapH(router))
 
 					// other version dependant operations
 					cRouter.GET("/endpoints", api.MakeEndpointLister(cs, helmFacade, logger).ListEndpoints)
@@ -861,6 +1042,7 @@ func main() {
 								pkeawsadapter.NewNodePoolStore(db),
 								config.Pipeline.Enterprise,
 							)),
+							"pkeazure": clusteradapter.NewAzurePKEService(azurePKEClusterStore),
 						},
 						clusteradapter.NewNodePoolStore(db, clusterStore),
 						intCluster.NodePoolValidators{
@@ -869,8 +1051,27 @@ func main() {
 						intCluster.NodePoolProcessors{
 							intCluster.NewCommonNodePoolProcessor(labelSource),
 						},
+						intCluster.KubernetesVersionGetterFunc(func(ctx context.Context, clusterID uint) (string, error) {
+							c, err := clusterManager.GetClusterByIDOnly(ctx, clusterID)
+							if err != nil {
+								return "", err
+							}
+
+							versionGetter, ok := c.(interface {
+								GetKubernetesVersion() (string, error)
+							})
+							if !ok {
+								return "", errors.NewWithDetails("cluster does not provide its Kubernetes version", "clusterId", clusterID)
+							}
+
+							return versionGetter.GetKubernetesVersion()
+						}),
 					)
 
+					clusterService = service
+
+					service = clusterdriver.AccessMiddleware(clusterAccessChecker)(service)
+
 					endpoints := clusterdriver.MakeEndpoints(
 						service,
 						kitxendpoint.Combine(endpointMiddleware...),
@@ -884,9 +1085,11 @@ func main() {
 
 					cRouter.DELETE("", gin.WrapH(router))
 					cRouter.PUT("/update", gin.WrapH(router))
+					cRouter.POST("/update/plan", gin.WrapH(router))
 					cRouter.Any("/nodepools", gin.WrapH(router))
-					cRouter.Any("/nodepools/:nodePoolName", gin.WrapH(router))
+					cRouter.Any("/nodepools/:nodePoolName", gin.WrapH(router)) // also serves the node pool creation plan
 					cRouter.Any("/nodepools/:nodePoolName/update", gin.WrapH(router))
+					cRouter.POST("/nodepools/:nodePoolName/update/plan", gin.WrapH(router))
 				}
 			}
 
@@ -920,14 +1123,19 @@ func main() {
 						integratedServiceBackup.IntegratedServiceName: integratedServiceBackup.OutputResolver{},
 					}
 					serviceConversion := integratedserviceadapter.NewServiceConversion(services.NewServiceStatusMapper(), specConversions, outputResolvers)
-					repository := integratedserviceadapter.NewCustomResourceRepository(clusterManager.KubeConfigFunc(), commonLogger, serviceConversion, config.Cluster.Namespace)
-					isServiceV2 = integratedservices.NewISServiceV2(integratedServiceManagerRegistry, integratedServiceOperationDispatcher, repository, commonLogger)
+					isRepositoryV2 = integratedserviceadapter.NewCustomResourceRepository(clusterManager.KubeConfigFunc(), commonLogger, serviceConversion, config.Cluster.Namespace)
+					isServiceV2 = integratedservices.NewISServiceV2(integratedServiceManagerRegistry, integratedServiceOperationDispatcher, isRepositoryV2, commonLogger)
 				}
 
 				// integrated service service V2 setup
 				{
 					// legacy setup
-					featureRepository := integratedserviceadapter.NewGormIntegratedServiceRepository(db, commonLogger)
+					featureRepository := webhookadapter.NewIntegratedServiceRepository(
+						integratedserviceadapter.NewGormIntegratedServiceRepository(db, commonLogger),
+						clusteradapter.NewStore(db, clusters),
+						webhookDispatcher,
+						errorHandler,
+					)
 
 					integratedServiceManagers = append(integratedServiceManagers, securityscan.MakeIntegratedServiceManager(commonLogger, config.Cluster.SecurityScan.Config))
 
@@ -1000,6 +1208,11 @@ func main() {
 							expiry.NewExpiryServiceManager(services.BindIntegratedServiceSpec))
 					}
 
+					if config.Cluster.Schedule.Enabled {
+						integratedServiceManagers = append(integratedServiceManagers,
+							schedule.NewScheduleServiceManager(clusterStore, services.BindIntegratedServiceSpec))
+					}
+
 					if config.Cluster.Ingress.Enabled {
 						integratedServiceManagers = append(integratedServiceManagers, ingress.NewManager(
 							config.Cluster.Ingress.Config,
@@ -1053,10 +1266,24 @@ func main() {
 					cRouter.Any("/features", gin.WrapH(router))
 					cRouter.Any("/features/:featureName", gin.WrapH(router))
 				}
+
+				if config.Cluster.Expiry.Enabled {
+					expiryIntegratedServices := isServiceV1
+					if config.IntegratedService.V2 {
+						expiryIntegratedServices = isRouter
+					}
+
+					clusterExpiryAPI := api.NewClusterExpiryAPI(
+						commonClusterGetter,
+						expiry.NewExtensionService(expiryIntegratedServices, services.BindIntegratedServiceSpec),
+						logrusLogger,
+					)
+					cRouter.POST("/expiry/extend", clusterExpiryAPI.ExtendClusterExpiry)
+				}
 			}
 
 			// ClusterGroupAPI
-			cgroupsAPI := cgroupAPI.NewAPI(clusterGroupManager, deploymentManager, logrusLogger, errorHandler)
+			cgroupsAPI := cgroupAPI.NewAPI(clusterGroupManager, deploymentManager, logrusLogger, errorHandler, clusterAccessChecker)
 			cgroupsAPI.AddRoutes(orgs.Group("/:orgid/clustergroups"))
 
 			namespaceAPI := namespace.NewAPI(commonClusterGetter, clientFactory, errorHandler)
@@ -1077,7 +1304,11 @@ func main() {
 			)
 			pkeAPI.RegisterRoutes(pkeGroup)
 
-			processService := process.NewWorkflowService(processadapter.NewGormStore(db), workflowClient)
+			processService := webhookadapter.NewProcessService(
+				process.NewWorkflowService(processadapter.NewGormStore(db), workflowClient),
+				webhookDispatcher,
+				errorHandler,
+			)
 			pkeService := pkeservice.NewService(
 				clusterStore,
 				processService,
@@ -1137,12 +1368,29 @@ func main() {
 
 				orgs.GET("/:orgid/helm/cluster-charts", gin.WrapH(router))
 			}
+
+			// integrated services v2 are stored in the clusters, they have to be checked separately
+			secretUsageFinders := usage.Finders{usageadapter.NewGormFinder(db)}
+			if config.IntegratedService.V2 {
+				secretUsageFinders = append(secretUsageFinders, usageadapter.NewIntegratedServiceFinder(db, isRepositoryV2))
+			}
+
+			secretUsageAPI := api.NewSecretUsageAPI(usage.NewService(secretUsageFinders), logrusLogger)
+
 			orgs.GET("/:orgid/secrets", api.ListSecrets)
 			orgs.GET("/:orgid/secrets/:id", api.GetSecret)
 			orgs.POST("/:orgid/secrets", api.AddSecrets)
 			orgs.PUT("/:orgid/secrets/:id", api.UpdateSecrets)
-			orgs.DELETE("/:orgid/secrets/:id", api.DeleteSecrets)
+			orgs.DELETE("/:orgid/secrets/:id", secretUsageAPI.DeleteSecret)
+			orgs.POST("/:orgid/secrets/:id/undelete", api.UndeleteSecret)
 			orgs.GET("/:orgid/secrets/:id/validate", api.ValidateSecret)
+			orgs.GET("/:orgid/secrets/:id/usages", secretUsageAPI.ListSecretUsages)
+			orgs.GET("/:orgid/secrets/:id/versions", api.ListSecretVersions)
+			orgs.GET("/:orgid/secrets/:id/versions/:version", api.GetSecretVersion)
+			orgs.POST("/:orgid/secrets/:id/versions/:version/rollback", api.RollbackSecret)
+			orgs.GET("/:orgid/secrets/:id/rotation", secretRotationAPI.GetSecretRotation)
+			orgs.PUT("/:orgid/secrets/:id/rotation", secretRotationAPI.SetSecretRotation)
+			orgs.DELETE("/:orgid/secrets/:id/rotation", secretRotationAPI.DeleteSecretRotation)
 			orgs.GET("/:orgid/secrets/:id/tags", api.GetSecretTags)
 			orgs.PUT("/:orgid/secrets/:id/tags/*tag", api.AddSecretTag)
 			orgs.DELETE("/:orgid/secrets/:id/tags/*tag", api.DeleteSecretTag)
@@ -1181,6 +1429,110 @@ func main() {
 				orgs.Any("/:orgid/cloud/google/projects", gin.WrapH(router))
 			}
 
+			{
+				service := auth.NewRoleService(roleStore)
+				endpoints := authdriver.MakeRoleEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				authdriver.RegisterRoleHTTPHandlers(
+					endpoints,
+					orgRouter,
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.Any("/:orgid/roles", gin.WrapH(router))
+				orgs.Any("/:orgid/roles/*path", gin.WrapH(router))
+				orgs.Any("/:orgid/rolebindings", gin.WrapH(router))
+				orgs.Any("/:orgid/rolebindings/*path", gin.WrapH(router))
+				orgs.Any("/:orgid/clusteraccessrules", gin.WrapH(router))
+				orgs.Any("/:orgid/clusteraccessrules/*path", gin.WrapH(router))
+			}
+
+			{
+				service := webhook.NewService(webhookStore, webhookadapter.NewSecretStore(secret.Store), webhookSender, webhookAddresses)
+				endpoints := webhookdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				webhookdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter.PathPrefix("/webhooks").Subrouter(),
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.Any("/:orgid/webhooks", gin.WrapH(router))
+				orgs.Any("/:orgid/webhooks/*path", gin.WrapH(router))
+			}
+
+			{
+				store := clustertemplateadapter.NewGormStore(db)
+				service := clustertemplate.NewService(store, clusterAPI, auth.UserExtractor{})
+				endpoints := clustertemplatedriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				clustertemplatedriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter.PathPrefix("/cluster-templates").Subrouter(),
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.Any("/:orgid/cluster-templates", gin.WrapH(router))
+				orgs.Any("/:orgid/cluster-templates/*path", gin.WrapH(router))
+			}
+
+			{
+				store := gitopsadapter.NewGormStore(db)
+				reconciler := gitops.NewReconciler(
+					store,
+					gitopsadapter.NewGitSource(config.Gitops.WorkDir, config.Gitops.AllowInsecureRepositories),
+					clusterStore,
+					clusterAPI,
+					clusterService,
+					isRouter,
+					helmFacade,
+					commonLogger.WithFields(map[string]interface{}{"component": "gitops"}),
+				)
+				service := gitops.NewService(store, reconciler, auth.UserExtractor{}, config.Gitops.AllowInsecureRepositories)
+				endpoints := gitopsdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				gitopsdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter.PathPrefix("/gitops").Subrouter(),
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.Any("/:orgid/gitops", gin.WrapH(router))
+				orgs.Any("/:orgid/gitops/*path", gin.WrapH(router))
+
+				// Synchronizations scheduled by the worker are executed here
+				syncWorker, err := cadence.NewWorker(
+					config.Cadence,
+					gitopsworkflow.SyncTaskList,
+					zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-worker"})),
+				)
+				emperror.Panic(errors.WrapIf(err, "failed to create gitops synchronization worker"))
+
+				gitopsworkflow.NewSyncOrganizationActivity(reconciler).Register(syncWorker)
+
+				group.Add(appkitrun.CadenceWorkerRun(syncWorker))
+			}
+
+			if config.AuditLog.Enabled && config.AuditLog.Driver.Database.Enabled {
+				orgs.GET("/:orgid/auditlog", auditlog.QueryHandler(
+					auditlogdriver.NewDatabaseReader(db),
+					func(req *http.Request) uint { return auth.GetCurrentOrganization(req).ID },
+					errorHandler,
+				))
+			}
+
 			orgs.GET("/:orgid", organizationAPI.GetOrganizations)
 			orgs.DELETE("/:orgid", organizationAPI.DeleteOrganization)
 		}
@@ -1193,7 +1545,7 @@ func main() {
 				tokenadapter.NewBankVaultsStore(tokenStore),
 				tokenGenerator,
 			)
-			service = tokendriver.AuthorizationMiddleware(auth.NewAuthorizer(db, organizationStore))(service)
+			service = tokendriver.AuthorizationMiddleware(auth.NewAuthorizer(db, organizationStore, roleStore))(service)
 
 			endpoints := tokendriver.MakeEndpoints(
 				service,
@@ -1241,18 +1593,24 @@ func main() {
 			orgs.Any("/:orgid/processes/*path", gin.WrapH(router))
 		}
 
-		backups.AddRoutes(orgs.Group("/:orgid/clusters/:id/backups"))
+		arkClusters := orgs.Group(
+			"/:orgid/clusters/:id",
+			cluster.NewClusterCheckMiddleware(clusterManager, errorHandler),
+			cluster.NewClusterAccessMiddleware(clusterAccessChecker, errorHandler),
+		)
+
+		backups.AddRoutes(arkClusters.Group("/backups"))
 
 		if config.Cluster.DisasterRecovery.RunAsIntegratedServiceV2 {
-			backupservice.AddRoutes(orgs.Group("/:orgid/clusters/:id/backupservice"), isServiceV2)
+			backupservice.AddRoutes(arkClusters.Group("/backupservice"), isServiceV2)
 		} else {
-			backupservice.AddRoutes(orgs.Group("/:orgid/clusters/:id/backupservice"), unifiedHelmReleaser)
+			backupservice.AddRoutes(arkClusters.Group("/backupservice"), unifiedHelmReleaser)
 		}
 
-		restores.AddRoutes(orgs.Group("/:orgid/clusters/:id/restores"))
-		schedules.AddRoutes(orgs.Group("/:orgid/clusters/:id/schedules"))
-		buckets.AddRoutes(orgs.Group("/:orgid/backupbuckets"))
-		backups.AddOrgRoutes(orgs.Group("/:orgid/backups"), clusterManager)
+		restores.AddRoutes(arkClusters.Group("/restores"))
+		schedules.AddRoutes(arkClusters.Group("/schedules"))
+		buckets.AddRoutes(orgs.Group("/:orgid/backupbuckets"), clusterAccessChecker)
+		backups.AddOrgRoutes(orgs.Group("/:orgid/backups"), clusterManager, clusterAccessChecker)
 	}
 
 	arkEvents.NewClusterEventHandler(arkEvents.NewClusterEvents(clusterEventBus), db, logrusLogger)
@@ -1280,6 +1638,27 @@ func main() {
 		)
 	}
 
+	{
+		ctx, cancel := context.WithCancel(context.Background())
+
+		group.Add(
+			func() error {
+				secretsync.RunSync(
+					ctx,
+					clusterGroupManager,
+					config.Cluster.Group.SecretSync.Interval,
+					logrusLogger.WithField("subsystem", "clustergroup-secret-sync"),
+					errorHandler,
+				)
+
+				return nil
+			},
+			func(err error) {
+				cancel()
+			},
+		)
+	}
+
 	base.GET("api", api.MetaHandler(engine, basePath+"/api"))
 
 	{
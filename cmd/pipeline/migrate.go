@@ -19,20 +19,28 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/banzaicloud/pipeline/internal/app/frontend/notification/notificationadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops/gitopsadapter"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process/processadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook/webhookadapter"
 	"github.com/banzaicloud/pipeline/internal/ark"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate/clustertemplateadapter"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
 	"github.com/banzaicloud/pipeline/internal/clustergroup"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+	"github.com/banzaicloud/pipeline/internal/clustergroup/secretsync"
 	"github.com/banzaicloud/pipeline/internal/common"
 	"github.com/banzaicloud/pipeline/internal/helm/helmadapter"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
 	"github.com/banzaicloud/pipeline/internal/platform/gin/auditlog/auditlogdriver"
+	"github.com/banzaicloud/pipeline/internal/platform/watermill"
 	"github.com/banzaicloud/pipeline/internal/providers"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/azureadapter"
 	"github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation/rotationadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
 	"github.com/banzaicloud/pipeline/src/auth"
+	"github.com/banzaicloud/pipeline/src/auth/authadapter"
 	route53model "github.com/banzaicloud/pipeline/src/dns/route53/model"
 	"github.com/banzaicloud/pipeline/src/model"
 )
@@ -63,6 +71,10 @@ func Migrate(db *gorm.DB, logger logrus.FieldLogger, commonLogger common.Logger)
 		return err
 	}
 
+	if err := authadapter.Migrate(db, logger); err != nil {
+		return err
+	}
+
 	if err := route53model.Migrate(db, logger); err != nil {
 		return err
 	}
@@ -79,6 +91,10 @@ func Migrate(db *gorm.DB, logger logrus.FieldLogger, commonLogger common.Logger)
 		return err
 	}
 
+	if err := secretsync.Migrate(db, logger); err != nil {
+		return err
+	}
+
 	if err := providers.Migrate(db, logger); err != nil {
 		return err
 	}
@@ -103,5 +119,29 @@ func Migrate(db *gorm.DB, logger logrus.FieldLogger, commonLogger common.Logger)
 		return err
 	}
 
+	if err := watermill.Migrate(db, logger); err != nil {
+		return err
+	}
+
+	if err := webhookadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := clustertemplateadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := gitopsadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := rotationadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := secretadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,71 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/banzaicloud/bank-vaults/pkg/sdk/vault"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// migrateSecrets copies the secrets of every organization from the given secret store backend to the configured one.
+func migrateSecrets(
+	ctx context.Context,
+	config secretadapter.Config,
+	from string,
+	vaultClient *vault.Client,
+	db *gorm.DB,
+	destination secret.Store,
+	logger common.Logger,
+) error {
+	if from == config.Backend {
+		return errors.NewWithDetails("source and destination secret store backends must differ", "backend", from)
+	}
+
+	to := config.Backend
+	config.Backend = from
+
+	if err := config.Validate(); err != nil {
+		return errors.WithMessage(err, "invalid source secret store")
+	}
+
+	source, err := secretadapter.NewStore(config, vaultClient, db)
+	if err != nil {
+		return errors.WithMessage(err, "failed to initialize source secret store")
+	}
+
+	var organizationIDs []uint
+	if err := db.Model(&auth.Organization{}).Pluck("id", &organizationIDs).Error; err != nil {
+		return errors.Wrap(err, "failed to list organizations")
+	}
+
+	logger.Info("migrating secrets", map[string]interface{}{"from": from, "to": to, "organizations": len(organizationIDs)})
+
+	copied, err := secret.CopySecrets(ctx, source, destination, organizationIDs)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("secrets migrated", map[string]interface{}{"secrets": copied})
+
+	return nil
+}
@@ -143,4 +143,17 @@ func registerAwsWorkflows(
 	awsSessionFactory := awsworkflow.NewAWSSessionFactory(awsSecretStore)
 	deleteStackActivity := awsworkflow.NewDeleteStackActivity(awsSessionFactory)
 	worker.RegisterActivityWithOptions(deleteStackActivity.Execute, activity.RegisterOptions{Name: awsworkflow.DeleteStackActivityName})
+
+	// node pool instance replacement activities
+	getAutoScalingGroupActivity := awsworkflow.NewGetAutoScalingGroupActivity(awsSessionFactory)
+	worker.RegisterActivityWithOptions(getAutoScalingGroupActivity.Execute, activity.RegisterOptions{Name: awsworkflow.GetAutoScalingGroupActivityName})
+
+	setAutoScalingGroupCapacityActivity := awsworkflow.NewSetAutoScalingGroupCapacityActivity(awsSessionFactory)
+	worker.RegisterActivityWithOptions(setAutoScalingGroupCapacityActivity.Execute, activity.RegisterOptions{Name: awsworkflow.SetAutoScalingGroupCapacityActivityName})
+
+	terminateAutoScalingGroupInstanceActivity := awsworkflow.NewTerminateAutoScalingGroupInstanceActivity(awsSessionFactory)
+	worker.RegisterActivityWithOptions(terminateAutoScalingGroupInstanceActivity.Execute, activity.RegisterOptions{Name: awsworkflow.TerminateAutoScalingGroupInstanceActivityName})
+
+	waitAutoScalingGroupActivity := awsworkflow.NewWaitAutoScalingGroupActivity(awsSessionFactory)
+	worker.RegisterActivityWithOptions(waitAutoScalingGroupActivity.Execute, activity.RegisterOptions{Name: awsworkflow.WaitAutoScalingGroupActivityName})
 }
@@ -131,6 +131,8 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	// Load common configuration
 	cmd.Configure(v, p)
 
+	v.SetDefault("eventBus::consumerGroup", "worker")
+
 	// Global configuration
 	v.SetDefault("environment", "production")
 	v.SetDefault("debug", false)
@@ -193,6 +193,7 @@ func registerEKSWorkflows(
 	eksworkflow.NewCalculateNodePoolVersionActivity().Register(worker)
 	eksworkflow2.NewUpdateNodeGroupActivity(awsSessionFactory, nodePoolTemplate, defaultNodeVolumeEncryption).Register(worker)
 	eksworkflow2.NewWaitCloudFormationStackUpdateActivity(awsSessionFactory).Register(worker)
+	eksworkflow2.NewSetNodePoolAutoscalerTaintsActivity(awsSessionFactory).Register(worker)
 
 	// New cluster update
 	eksworkflow2.NewUpdateClusterWorkflow().Register(worker)
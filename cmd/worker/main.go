@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/base32"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"syscall"
@@ -42,8 +43,13 @@ import (
 
 	cloudinfoapi "github.com/banzaicloud/pipeline/.gen/cloudinfo"
 	anchore2 "github.com/banzaicloud/pipeline/internal/anchore"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops/gitopsadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops/gitopsworkflow"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process/processadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook/webhookadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook/webhookworkflow"
 	cluster2 "github.com/banzaicloud/pipeline/internal/cluster"
 	intClusterAuth "github.com/banzaicloud/pipeline/internal/cluster/auth"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter"
@@ -52,6 +58,9 @@ import (
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup/autoscaler"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup/velero"
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate"
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate/clustertemplateadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate/clustertemplateworkflow"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksadapter"
 	eksClusterAdapter "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/adapter"
@@ -60,16 +69,20 @@ import (
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws/pkeawsadapter"
 	intClusterDNS "github.com/banzaicloud/pipeline/internal/cluster/dns"
 	"github.com/banzaicloud/pipeline/internal/cluster/endpoints"
+	"github.com/banzaicloud/pipeline/internal/cluster/infrastructure/aws/awsworkflow"
 	intClusterK8s "github.com/banzaicloud/pipeline/internal/cluster/kubernetes"
 	intClusterWorkflow "github.com/banzaicloud/pipeline/internal/cluster/workflow"
 	"github.com/banzaicloud/pipeline/internal/clustergroup"
 	cgroupAdapter "github.com/banzaicloud/pipeline/internal/clustergroup/adapter"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment/deploymentworkflow"
+	"github.com/banzaicloud/pipeline/internal/clustergroup/secretsync"
 	"github.com/banzaicloud/pipeline/internal/cmd"
 	"github.com/banzaicloud/pipeline/internal/common/commonadapter"
 	"github.com/banzaicloud/pipeline/internal/global"
 	"github.com/banzaicloud/pipeline/internal/helm"
 	"github.com/banzaicloud/pipeline/internal/helm/helmadapter"
+	"github.com/banzaicloud/pipeline/internal/helm/helmworkflow"
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
 	clusterfeatureworkflow "github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter/workflow"
@@ -85,6 +98,9 @@ import (
 	intsvcingressadapter "github.com/banzaicloud/pipeline/internal/integratedservices/services/ingress/ingressadapter"
 	integratedServiceLogging "github.com/banzaicloud/pipeline/internal/integratedservices/services/logging"
 	integratedServiceMonitoring "github.com/banzaicloud/pipeline/internal/integratedservices/services/monitoring"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule"
+	scheduleAdapter "github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule/adapter"
+	scheduleWorkflow "github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule/adapter/workflow"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/securityscan"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/securityscan/securityscanadapter"
 	integratedServiceVault "github.com/banzaicloud/pipeline/internal/integratedservices/services/vault"
@@ -106,6 +122,9 @@ import (
 	"github.com/banzaicloud/pipeline/internal/secret/kubesecret"
 	"github.com/banzaicloud/pipeline/internal/secret/pkesecret"
 	"github.com/banzaicloud/pipeline/internal/secret/restricted"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation/rotationadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation/rotationworkflow"
 	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
 	"github.com/banzaicloud/pipeline/internal/secret/types"
 	anchore "github.com/banzaicloud/pipeline/internal/security"
@@ -114,6 +133,7 @@ import (
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	"github.com/banzaicloud/pipeline/pkg/hook"
 	sdkcadence "github.com/banzaicloud/pipeline/pkg/sdk/cadence"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
 	"github.com/banzaicloud/pipeline/src/auth"
 	"github.com/banzaicloud/pipeline/src/auth/authdriver"
 	authworkflow "github.com/banzaicloud/pipeline/src/auth/workflow"
@@ -218,7 +238,15 @@ func main() {
 	emperror.Panic(err)
 	global.SetVault(vaultClient)
 
-	secretStore := secretadapter.NewVaultStore(vaultClient, "secret")
+	db, err := database.Connect(config.Database.Config)
+	if err != nil {
+		emperror.Panic(err)
+	}
+	global.SetDB(db)
+
+	secretStore, err := secretadapter.NewStore(config.Secret.Store, vaultClient, db)
+	emperror.Panic(errors.WithMessage(err, "failed to initialize secret store"))
+
 	pkeSecreter := pkesecret.NewPkeSecreter(vaultClient, commonLogger)
 	secretTypes := types.NewDefaultTypeList(types.DefaultTypeListConfig{
 		AmazonRegion:       config.Cloud.Amazon.DefaultRegion,
@@ -236,12 +264,6 @@ func main() {
 		worker, err := cadence.NewWorker(config.Cadence, taskList, zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-worker"})))
 		emperror.Panic(err)
 
-		db, err := database.Connect(config.Database.Config)
-		if err != nil {
-			emperror.Panic(err)
-		}
-		global.SetDB(db)
-
 		workflowClient, err := cadence.NewClient(config.Cadence, zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-client"})))
 		if err != nil {
 			emperror.Panic(errors.WrapIf(err, "Failed to configure Cadence client"))
@@ -264,6 +286,28 @@ func main() {
 			releaseDeleter,
 		)
 
+		webhookStore := webhookadapter.NewGormStore(db)
+		webhookDispatcher := webhook.NewDispatcher(
+			webhookStore,
+			webhookworkflow.NewDeliveryScheduler(workflowClient, webhookworkflow.DeliveryConfig{
+				MaxAttempts:   config.Webhook.MaxAttempts,
+				RetryInterval: config.Webhook.RetryInterval,
+			}),
+			commonLogger.WithFields(map[string]interface{}{"component": "webhook"}),
+			errorHandler,
+		)
+
+		{
+			webhookSender := webhookadapter.NewHTTPSender(
+				webhookadapter.NewSecretStore(secret.Store),
+				config.Webhook.Timeout,
+				webhook.AddressPolicy{AllowPrivateNetworks: config.Webhook.AllowPrivateNetworks},
+			)
+
+			webhookworkflow.NewDeliverWorkflow().Register(worker)
+			webhookworkflow.NewDeliverActivity(webhook.NewDeliverer(webhookStore, webhookSender)).Register(worker)
+		}
+
 		tokenStore := bauth.NewVaultTokenStore("pipeline")
 		err = registerAuthWorkflows(worker, tokenStore)
 		emperror.Panic(errors.WrapIf(err, "failed to register auth workflows"))
@@ -301,6 +345,33 @@ func main() {
 			commonLogger,
 		)
 
+		{
+			driftDetector := helm.NewDriftDetector(
+				helmFacade,
+				unifiedHelmReleaser,
+				helmadapter.NewManifestComparer(clusterSvc, commonLogger),
+				helmadapter.NewDriftStore(db, commonLogger),
+				commonLogger,
+			)
+
+			helmworkflow.NewListRunningClustersActivity(helmworkflow.NewClusterManagerAdapter(clusterManager)).Register(worker)
+			helmworkflow.NewDetectClusterReleaseDriftActivity(driftDetector).Register(worker)
+			helmworkflow.NewDetectReleaseDriftWorkflow().Register(worker)
+
+			if config.Helm.Drift.Enabled {
+				releaseDriftCronConfiguration := sdkcadence.NewCronConfiguration(
+					workflowClient,
+					sdkcadence.CronInstanceTypeDomain,
+					config.Helm.Drift.Schedule,
+					time.Hour,
+					taskList,
+					helmworkflow.DetectReleaseDriftWorkflowName,
+				)
+				err = releaseDriftCronConfiguration.StartCronWorkflow(context.Background())
+				emperror.Panic(errors.WrapIf(err, "failed to start release drift detection cron workflow"))
+			}
+		}
+
 		clusters := pkeworkflowadapter.NewClusterManagerAdapter(clusterManager)
 		secretStore := pkeworkflowadapter.NewSecretStore(secret.Store)
 
@@ -316,7 +387,11 @@ func main() {
 
 		configFactory := kubernetes.NewConfigFactory(commonSecretStore)
 
-		processService := process.NewWorkflowService(processadapter.NewGormStore(db), workflowClient)
+		processService := webhookadapter.NewProcessService(
+			process.NewWorkflowService(processadapter.NewGormStore(db), workflowClient),
+			webhookDispatcher,
+			errorHandler,
+		)
 		processActivity := process.NewProcessActivity(processService)
 
 		worker.RegisterActivityWithOptions(processActivity.ExecuteProcess, activity.RegisterOptions{Name: process.ProcessActivityName})
@@ -490,14 +565,28 @@ func main() {
 
 		vsphereClusterStore := vsphereadapter.NewClusterStore(db)
 
-		cgroupAdapter := cgroupAdapter.NewClusterGetter(clusterManager)
+		cgroupAdapter := cgroupAdapter.NewClusterGetter(clusterManager, clusterStore)
 		clusterGroupManager := clustergroup.NewManager(cgroupAdapter, clustergroup.NewClusterGroupRepository(db, logrusLogger), logrusLogger, errorHandler)
 		{
 			deleteClusterWorkflow := clusterworkflow.NewDeleteClusterWorkflow(config.IntegratedService.V2)
 			worker.RegisterWorkflowWithOptions(deleteClusterWorkflow.Execute, workflow.RegisterOptions{Name: clusterworkflow.DeleteClusterWorkflowName})
 
-			deploymentManager := deployment.NewCGDeploymentManager(db, cgroupAdapter, logrusLogger, errorHandler, deployment.NewHelmService(helmFacade, unifiedHelmReleaser))
+			deploymentManager := deployment.NewCGDeploymentManager(db, cgroupAdapter, logrusLogger, errorHandler, deployment.NewHelmService(helmFacade, unifiedHelmReleaser), deploymentworkflow.NewRolloutStarter(workflowClient))
 			clusterGroupManager.RegisterFeatureHandler(deployment.FeatureName, deploymentManager)
+			clusterGroupManager.RegisterFeatureHandler(secretsync.FeatureName, secretsync.NewManager(
+				secretsync.NewSyncedSecretRepository(db),
+				cgroupAdapter,
+				restricted.NewSecretStore(secret.Store),
+				secretsync.NewKubernetesSecretWriter(),
+				logrusLogger,
+				errorHandler,
+			))
+
+			deploymentworkflow.NewRolloutWorkflow(processlog.New()).Register(worker)
+			deploymentworkflow.NewGetClusterRevisionActivity(deploymentManager).Register(worker)
+			deploymentworkflow.NewRolloutClusterActivity(deploymentManager).Register(worker)
+			deploymentworkflow.NewCheckClusterHealthActivity(deploymentManager).Register(worker)
+			deploymentworkflow.NewRollbackClusterActivity(deploymentManager).Register(worker)
 
 			removeClusterFromGroupActivity := clusterworkflow.MakeRemoveClusterFromGroupActivity(clusterGroupManager)
 			worker.RegisterActivityWithOptions(removeClusterFromGroupActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.RemoveClusterFromGroupActivityName})
@@ -586,6 +675,14 @@ func main() {
 
 			setClusterStatusActivity := clusterworkflow.NewSetClusterStatusActivity(clusterStore)
 			worker.RegisterActivityWithOptions(setClusterStatusActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.SetClusterStatusActivityName})
+
+			clusterClientFactory := cluster2.NewClientFactory(clusterStore, kubernetes.NewClientFactory(configFactory))
+
+			drainNodeActivity := clusterworkflow.NewDrainNodeActivity(clusterClientFactory)
+			worker.RegisterActivityWithOptions(drainNodeActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.DrainNodeActivityName})
+
+			waitForNodesReadyActivity := clusterworkflow.NewWaitForNodesReadyActivity(clusterClientFactory)
+			worker.RegisterActivityWithOptions(waitForNodesReadyActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.WaitForNodesReadyActivityName})
 		}
 
 		systemNamespaces := []string{"kube-system"}
@@ -673,7 +770,12 @@ func main() {
 
 			// V1 setup
 			{
-				featureRepository = integratedserviceadapter.NewGormIntegratedServiceRepository(db, logger)
+				featureRepository = webhookadapter.NewIntegratedServiceRepository(
+					integratedserviceadapter.NewGormIntegratedServiceRepository(db, logger),
+					clusteradapter.NewStore(db, clusterRepo),
+					webhookDispatcher,
+					errorHandler,
+				)
 			}
 
 			kubernetesService := kubernetes.NewService(
@@ -721,8 +823,40 @@ func main() {
 			expiryActivity := expiryWorkflow.NewExpiryActivity(clusterDeleter)
 			worker.RegisterActivityWithOptions(expiryActivity.Execute, activity.RegisterOptions{Name: expiryWorkflow.ExpireActivityName})
 
+			expiryNotifyActivity := expiryWorkflow.NewNotifyActivity(expiry.Notifiers{
+				webhookadapter.NewClusterExpiryNotifier(webhookDispatcher, clusterStore),
+				adapter.NewChannelNotifier(clusterStore, secret.Store, &http.Client{Timeout: 30 * time.Second}),
+			})
+			worker.RegisterActivityWithOptions(expiryNotifyActivity.Execute, activity.RegisterOptions{Name: expiryWorkflow.NotifyActivityName})
+
+			activityTracker := adapter.NewActivityTracker(
+				clusterStore,
+				helmFacade,
+				cluster2.NewClientFactory(clusterStore, kubernetes.NewClientFactory(configFactory)),
+				[]string{config.Cluster.Namespace},
+			)
+			getLastActivityActivity := expiryWorkflow.NewGetLastActivityActivity(activityTracker)
+			worker.RegisterActivityWithOptions(getLastActivityActivity.Execute, activity.RegisterOptions{Name: expiryWorkflow.GetLastActivityActivityName})
+
 			expirerService := adapter.NewAsyncExpiryService(workflowClient, logger)
 
+			// schedule integrated service
+			worker.RegisterWorkflowWithOptions(scheduleWorkflow.ScheduleJobWorkflow, workflow.RegisterOptions{Name: scheduleWorkflow.ScheduleJobWorkflowName})
+
+			nodePoolScaler := scheduleAdapter.NewEKSNodePoolScaler(
+				clusterStore,
+				eksadapter.NewNodePoolStore(db),
+				awsworkflow.NewAWSSessionFactory(secret.Store),
+			)
+			getNodePoolSizesActivity := scheduleWorkflow.NewGetNodePoolSizesActivity(nodePoolScaler)
+			worker.RegisterActivityWithOptions(getNodePoolSizesActivity.Execute, activity.RegisterOptions{Name: scheduleWorkflow.GetNodePoolSizesActivityName})
+			scaleNodePoolsActivity := scheduleWorkflow.NewScaleNodePoolsActivity(nodePoolScaler)
+			worker.RegisterActivityWithOptions(scaleNodePoolsActivity.Execute, activity.RegisterOptions{Name: scheduleWorkflow.ScaleNodePoolsActivityName})
+			scheduleNotifyActivity := scheduleWorkflow.NewNotifyActivity(webhookadapter.NewClusterScheduleNotifier(webhookDispatcher, clusterStore))
+			worker.RegisterActivityWithOptions(scheduleNotifyActivity.Execute, activity.RegisterOptions{Name: scheduleWorkflow.NotifyActivityName})
+
+			scheduleService := scheduleAdapter.NewAsyncScheduleService(workflowClient, logger)
+
 			featureOperatorRegistry := integratedservices.MakeIntegratedServiceOperatorRegistry([]integratedservices.IntegratedServiceOperator{
 				integratedServiceDNS.MakeIntegratedServiceOperator(
 					clusterGetter,
@@ -773,6 +907,7 @@ func main() {
 					commonSecretStore,
 				),
 				expiry.NewExpiryServiceOperator(expirerService, services.BindIntegratedServiceSpec, logger),
+				schedule.NewScheduleServiceOperator(scheduleService, services.BindIntegratedServiceSpec, logger),
 				intsvcingress.NewOperator(
 					intsvcingressadapter.NewOperatorClusterStore(clusterStore),
 					clusterService,
@@ -801,6 +936,162 @@ func main() {
 			registerClusterFeatureWorkflows(worker, featureOperatorRegistry, featureRepository, clusterfeatureworkflow.IntegratedServiceJobWorkflowName, false)
 			registerClusterFeatureWorkflows(worker, featureOperatorRegistryV2, featureRepositoryV2, clusterfeatureworkflow.IntegratedServiceJobWorkflowV2Name, true)
 
+			// integrated service service (used by workflows activating integrated services)
+			var isRouter integratedservices.Service
+			{
+				clusterPropertyGetter := dnsadapter.NewClusterPropertyGetter(clusterManager)
+				integratedServiceManagers := make([]integratedservices.IntegratedServiceManager, 0)
+
+				if config.Cluster.DNS.Enabled {
+					integratedServiceManagers = append(integratedServiceManagers, integratedServiceDNS.NewIntegratedServicesManager(clusterPropertyGetter, clusterPropertyGetter, config.Cluster.DNS.Config))
+				}
+
+				if config.Cluster.DisasterRecovery.RunAsIntegratedServiceV2 {
+					integratedServiceManagers = append(integratedServiceManagers, integratedServiceBackup.NewManager(config.Cluster.DisasterRecovery.Charts.Ark.Version))
+				}
+
+				isServiceV2 := integratedservices.NewISServiceV2(
+					integratedservices.MakeIntegratedServiceManagerRegistry(integratedServiceManagers),
+					integratedserviceadapter.NewCadenceOperationDispatcher(workflowClient, commonLogger),
+					featureRepositoryV2,
+					commonLogger,
+				)
+
+				integratedServiceManagers = append(integratedServiceManagers, securityscan.MakeIntegratedServiceManager(commonLogger, config.Cluster.SecurityScan.Config))
+
+				if config.Cluster.Vault.Enabled {
+					integratedServiceManagers = append(integratedServiceManagers, integratedServiceVault.MakeIntegratedServiceManager(clusterGetter, commonSecretStore, config.Cluster.Vault.Config, commonLogger))
+				}
+
+				if config.Cluster.Monitoring.Enabled {
+					integratedServiceManagers = append(integratedServiceManagers, integratedServiceMonitoring.MakeIntegratedServiceManager(
+						clusterGetter,
+						commonSecretStore,
+						endpointManager,
+						unifiedHelmReleaser,
+						config.Cluster.Monitoring.Config,
+						commonLogger,
+					))
+				}
+
+				if config.Cluster.Logging.Enabled {
+					integratedServiceManagers = append(integratedServiceManagers, integratedServiceLogging.MakeIntegratedServiceManager(
+						clusterGetter,
+						commonSecretStore,
+						endpointManager,
+						config.Cluster.Logging.Config,
+						commonLogger,
+					))
+				}
+
+				if config.Cluster.Expiry.Enabled {
+					integratedServiceManagers = append(integratedServiceManagers, expiry.NewExpiryServiceManager(services.BindIntegratedServiceSpec))
+				}
+
+				if config.Cluster.Schedule.Enabled {
+					integratedServiceManagers = append(integratedServiceManagers, schedule.NewScheduleServiceManager(clusterStore, services.BindIntegratedServiceSpec))
+				}
+
+				if config.Cluster.Ingress.Enabled {
+					integratedServiceManagers = append(integratedServiceManagers, intsvcingress.NewManager(
+						config.Cluster.Ingress.Config,
+						unifiedHelmReleaser,
+						commonLogger,
+					))
+				}
+
+				isServiceV1 := integratedservices.MakeIntegratedServiceService(
+					integratedserviceadapter.MakeCadenceIntegratedServiceOperationDispatcher(workflowClient, commonLogger),
+					integratedservices.MakeIntegratedServiceManagerRegistry(integratedServiceManagers),
+					featureRepository,
+					commonLogger,
+				)
+
+				isRouter = integratedservices.NewServiceRouter(isServiceV1, isServiceV2, commonLogger)
+			}
+
+			// cluster templates
+			{
+				reconciler := clustertemplate.NewReconciler(
+					clustertemplateadapter.NewGormStore(db),
+					clustertemplateadapter.NewClusterStatusGetter(clusterStore),
+					isRouter,
+					clustertemplateadapter.NewReleaseInstaller(helmFacade),
+					commonLogger.WithFields(map[string]interface{}{"component": "cluster-template"}),
+				)
+
+				clustertemplateworkflow.NewListPendingInstancesActivity(reconciler).Register(worker)
+				clustertemplateworkflow.NewReconcileInstanceActivity(reconciler).Register(worker)
+				clustertemplateworkflow.NewReconcileWorkflow().Register(worker)
+
+				clusterTemplateCronConfiguration := sdkcadence.NewCronConfiguration(
+					workflowClient,
+					sdkcadence.CronInstanceTypeDomain,
+					config.ClusterTemplate.ReconcileSchedule,
+					time.Hour,
+					taskList,
+					clustertemplateworkflow.ReconcileWorkflowName,
+				)
+				err = clusterTemplateCronConfiguration.StartCronWorkflow(context.Background())
+				emperror.Panic(errors.WrapIf(err, "failed to start cluster template reconciliation cron workflow"))
+			}
+
+			// gitops
+			{
+				gitopsworkflow.NewListDueOrganizationsActivity(gitopsadapter.NewGormStore(db)).Register(worker)
+				gitopsworkflow.NewSyncRepositoriesWorkflow().Register(worker)
+
+				gitopsCronConfiguration := sdkcadence.NewCronConfiguration(
+					workflowClient,
+					sdkcadence.CronInstanceTypeDomain,
+					config.Gitops.SyncSchedule,
+					time.Hour,
+					taskList,
+					gitopsworkflow.SyncRepositoriesWorkflowName,
+				)
+				err = gitopsCronConfiguration.StartCronWorkflow(context.Background())
+				emperror.Panic(errors.WrapIf(err, "failed to start gitops synchronization cron workflow"))
+			}
+
+			// secret rotation
+			{
+				rotator := rotation.NewRotator(
+					rotationadapter.NewGormStore(db),
+					rotationadapter.NewSecretStore(secret.Store),
+					secretTypes,
+					webhookadapter.NewSecretRotationNotifier(webhookDispatcher),
+					rotation.DependentHandlers{
+						rotationadapter.NewIntegratedServiceRedeployer(
+							rotationadapter.NewClusterManagerAdapter(clusterManager),
+							featureRepository,
+							integratedserviceadapter.MakeCadenceIntegratedServiceOperationDispatcher(workflowClient, commonLogger),
+						),
+					},
+					commonLogger,
+					errorHandler,
+				)
+
+				rotationworkflow.NewListDueActionsActivity(rotator).Register(worker)
+				rotationworkflow.NewRotateSecretActivity(rotator).Register(worker)
+				rotationworkflow.NewNotifySecretRotationActivity(rotator).Register(worker)
+				rotationworkflow.NewRevokeSecretActivity(rotator).Register(worker)
+				rotationworkflow.NewRotateSecretsWorkflow(config.Secret.Rotation.RevocationGracePeriod).Register(worker)
+				rotationworkflow.NewRevokeSecretWorkflow().Register(worker)
+
+				if config.Secret.Rotation.Enabled {
+					secretRotationCronConfiguration := sdkcadence.NewCronConfiguration(
+						workflowClient,
+						sdkcadence.CronInstanceTypeDomain,
+						config.Secret.Rotation.Schedule,
+						time.Hour,
+						taskList,
+						rotationworkflow.RotateSecretsWorkflowName,
+					)
+					err = secretRotationCronConfiguration.StartCronWorkflow(context.Background())
+					emperror.Panic(errors.WrapIf(err, "failed to start secret rotation cron workflow"))
+				}
+			}
+
 			// integrated service operator setup
 			{
 				singleClusterIntegratedServiceOperatorInstallerWf := operator.NewSingleClusterIntegratedServiceOperatorInstallerWorkflow()
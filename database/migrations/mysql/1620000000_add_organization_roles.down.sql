@@ -0,0 +1,5 @@
+ALTER TABLE `user_organizations` DROP COLUMN `groups`;
+
+DROP TABLE `organization_role_bindings`;
+
+DROP TABLE `organization_roles`;
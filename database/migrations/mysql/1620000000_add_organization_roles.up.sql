@@ -0,0 +1,24 @@
+CREATE TABLE `organization_roles` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `organization_id` int(10) unsigned NOT NULL,
+    `name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `description` text COLLATE utf8mb4_unicode_ci,
+    `rules` text COLLATE utf8mb4_unicode_ci,
+    `created_at` timestamp NULL DEFAULT NULL,
+    `updated_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_organization_roles_org_id_name` (`organization_id`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `organization_role_bindings` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `organization_id` int(10) unsigned NOT NULL,
+    `role` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `subject_kind` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `subject` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `created_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_organization_role_bindings_organization_id` (`organization_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `user_organizations` ADD COLUMN `groups` text COLLATE utf8mb4_unicode_ci;
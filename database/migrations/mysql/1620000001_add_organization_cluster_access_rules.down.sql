@@ -0,0 +1 @@
+DROP TABLE `organization_cluster_access_rules`;
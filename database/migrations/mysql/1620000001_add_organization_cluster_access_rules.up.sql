@@ -0,0 +1,10 @@
+CREATE TABLE `organization_cluster_access_rules` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `organization_id` int(10) unsigned NOT NULL,
+    `subject_kind` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `subject` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `selector` text COLLATE utf8mb4_unicode_ci,
+    `created_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_organization_cluster_access_rules_organization_id` (`organization_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
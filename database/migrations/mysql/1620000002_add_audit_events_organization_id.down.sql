@@ -0,0 +1,2 @@
+ALTER TABLE `audit_events` DROP INDEX `idx_audit_events_organization_id`;
+ALTER TABLE `audit_events` DROP COLUMN `organization_id`;
@@ -0,0 +1,2 @@
+ALTER TABLE `audit_events` ADD COLUMN `organization_id` int(10) unsigned DEFAULT NULL;
+ALTER TABLE `audit_events` ADD INDEX `idx_audit_events_organization_id` (`organization_id`);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `event_bus_offsets`;
+DROP TABLE IF EXISTS `event_bus_messages`;
@@ -0,0 +1,19 @@
+CREATE TABLE `event_bus_messages` (
+    `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+    `topic` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `uuid` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `metadata` text COLLATE utf8mb4_unicode_ci,
+    `payload` longblob,
+    `created_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_event_bus_messages_topic` (`topic`),
+    KEY `idx_event_bus_messages_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `event_bus_offsets` (
+    `consumer_group` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `topic` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `last_message_id` bigint(20) unsigned NOT NULL,
+    `updated_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`consumer_group`, `topic`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
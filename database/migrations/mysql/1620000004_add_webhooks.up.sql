@@ -0,0 +1,26 @@
+CREATE TABLE `webhook_subscriptions` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `organization_id` int(10) unsigned NOT NULL,
+    `url` text COLLATE utf8mb4_unicode_ci NOT NULL,
+    `events` text COLLATE utf8mb4_unicode_ci,
+    `secret_id` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+    `created_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_webhook_subscriptions_organization_id` (`organization_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `webhook_deliveries` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `subscription_id` int(10) unsigned NOT NULL,
+    `event_id` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `event_type` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `payload` text COLLATE utf8mb4_unicode_ci,
+    `status` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `attempts` int(11) NOT NULL,
+    `response_code` int(11) DEFAULT NULL,
+    `error` text COLLATE utf8mb4_unicode_ci,
+    `created_at` timestamp NULL DEFAULT NULL,
+    `updated_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_webhook_deliveries_subscription_id` (`subscription_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
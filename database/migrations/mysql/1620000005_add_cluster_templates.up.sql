@@ -0,0 +1,28 @@
+CREATE TABLE `cluster_templates` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `organization_id` int(10) unsigned NOT NULL,
+    `name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `version` int(11) NOT NULL,
+    `description` text COLLATE utf8mb4_unicode_ci,
+    `spec` text COLLATE utf8mb4_unicode_ci,
+    `created_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_cluster_templates_org_name_version` (`organization_id`, `name`, `version`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `cluster_template_instances` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `organization_id` int(10) unsigned NOT NULL,
+    `template_name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `template_version` int(11) NOT NULL,
+    `cluster_id` int(10) unsigned NOT NULL,
+    `cluster_name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `spec` text COLLATE utf8mb4_unicode_ci,
+    `status` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `status_message` text COLLATE utf8mb4_unicode_ci,
+    `created_at` timestamp NULL DEFAULT NULL,
+    `updated_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_cluster_template_instances_org_template` (`organization_id`, `template_name`),
+    KEY `idx_cluster_template_instances_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,17 @@
+CREATE TABLE `gitops_repositories` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `organization_id` int(10) unsigned NOT NULL,
+    `url` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `ref` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `path` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `interval` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+    `dry_run` tinyint(1) NOT NULL,
+    `prune` text COLLATE utf8mb4_unicode_ci,
+    `user_id` int(10) unsigned NOT NULL,
+    `status` text COLLATE utf8mb4_unicode_ci,
+    `inventory` text COLLATE utf8mb4_unicode_ci,
+    `created_at` timestamp NULL DEFAULT NULL,
+    `updated_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_gitops_repositories_org` (`organization_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
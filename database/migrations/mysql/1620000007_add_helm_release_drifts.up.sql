@@ -0,0 +1,16 @@
+CREATE TABLE `helm_release_drifts` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `created_at` timestamp NULL DEFAULT NULL,
+    `updated_at` timestamp NULL DEFAULT NULL,
+    `cluster_id` int(10) unsigned DEFAULT NULL,
+    `namespace` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+    `release_name` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+    `revision` int(11) DEFAULT NULL,
+    `drifted` tinyint(1) DEFAULT NULL,
+    `resources` text COLLATE utf8mb4_unicode_ci,
+    `self_heal` tinyint(1) DEFAULT NULL,
+    `checked_at` timestamp NULL DEFAULT NULL,
+    `healed_at` timestamp NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_helm_release_drifts_cluster_release` (`cluster_id`,`namespace`,`release_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
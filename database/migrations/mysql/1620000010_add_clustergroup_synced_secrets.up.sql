@@ -0,0 +1,14 @@
+CREATE TABLE `clustergroup_synced_secrets` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `created_at` timestamp NULL DEFAULT NULL,
+    `updated_at` timestamp NULL DEFAULT NULL,
+    `cluster_group_id` int(10) unsigned DEFAULT NULL,
+    `cluster_id` int(10) unsigned DEFAULT NULL,
+    `namespace` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+    `secret_name` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+    `secret_hash` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+    `status` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+    `status_message` text COLLATE utf8mb4_unicode_ci,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_clustergroup_synced_secret` (`cluster_group_id`,`cluster_id`,`namespace`,`secret_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,13 @@
+CREATE TABLE `secret_rotation_policies` (
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `created_at` timestamp NULL DEFAULT NULL,
+    `updated_at` timestamp NULL DEFAULT NULL,
+    `organization_id` int(10) unsigned DEFAULT NULL,
+    `secret_id` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+    `rotation_interval` bigint(20) DEFAULT NULL,
+    `notify_before` bigint(20) DEFAULT NULL,
+    `last_rotated_at` timestamp NULL DEFAULT NULL,
+    `notified` tinyint(1) DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_secret_rotation_policies_org_secret` (`organization_id`,`secret_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
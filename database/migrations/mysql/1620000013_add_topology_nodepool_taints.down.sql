@@ -0,0 +1,2 @@
+ALTER TABLE `topology_nodepools` DROP COLUMN `taints`;
+ALTER TABLE `topology_nodepools` DROP COLUMN `startup_taints`;
@@ -0,0 +1 @@
+ALTER TABLE `amazon_node_pools` DROP COLUMN `mixed_instances_policy`;
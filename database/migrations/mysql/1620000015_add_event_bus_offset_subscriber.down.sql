@@ -0,0 +1,5 @@
+DELETE FROM `event_bus_offsets` WHERE `subscriber` <> '';
+ALTER TABLE `event_bus_offsets`
+    DROP PRIMARY KEY,
+    DROP COLUMN `subscriber`,
+    ADD PRIMARY KEY (`consumer_group`, `topic`);
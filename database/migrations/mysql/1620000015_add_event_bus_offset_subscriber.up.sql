@@ -0,0 +1,4 @@
+ALTER TABLE `event_bus_offsets`
+    ADD `subscriber` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' AFTER `consumer_group`,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (`consumer_group`, `subscriber`, `topic`);
@@ -0,0 +1,5 @@
+ALTER TABLE "user_organizations" DROP COLUMN IF EXISTS "groups";
+
+DROP TABLE IF EXISTS "organization_role_bindings";
+
+DROP TABLE IF EXISTS "organization_roles";
@@ -0,0 +1,26 @@
+CREATE TABLE "organization_roles" (
+    "id" serial,
+    "organization_id" integer NOT NULL,
+    "name" text NOT NULL,
+    "description" text,
+    "rules" text,
+    "created_at" timestamp with time zone,
+    "updated_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_organization_roles_org_id_name ON "organization_roles"(organization_id, name);
+
+CREATE TABLE "organization_role_bindings" (
+    "id" serial,
+    "organization_id" integer NOT NULL,
+    "role" text NOT NULL,
+    "subject_kind" text NOT NULL,
+    "subject" text NOT NULL,
+    "created_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_organization_role_bindings_organization_id ON "organization_role_bindings"(organization_id);
+
+ALTER TABLE "user_organizations" ADD COLUMN "groups" text;
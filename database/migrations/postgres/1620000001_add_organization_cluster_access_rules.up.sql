@@ -0,0 +1,11 @@
+CREATE TABLE "organization_cluster_access_rules" (
+    "id" serial,
+    "organization_id" integer NOT NULL,
+    "subject_kind" text NOT NULL,
+    "subject" text NOT NULL,
+    "selector" text,
+    "created_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_organization_cluster_access_rules_organization_id ON "organization_cluster_access_rules"(organization_id);
@@ -0,0 +1,20 @@
+CREATE TABLE "event_bus_messages" (
+    "id" bigserial,
+    "topic" text NOT NULL,
+    "uuid" text NOT NULL,
+    "metadata" text,
+    "payload" bytea,
+    "created_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_event_bus_messages_topic ON "event_bus_messages"(topic);
+CREATE INDEX idx_event_bus_messages_created_at ON "event_bus_messages"(created_at);
+
+CREATE TABLE "event_bus_offsets" (
+    "consumer_group" varchar(255) NOT NULL,
+    "topic" varchar(255) NOT NULL,
+    "last_message_id" bigint NOT NULL,
+    "updated_at" timestamp with time zone,
+    PRIMARY KEY ("consumer_group", "topic")
+);
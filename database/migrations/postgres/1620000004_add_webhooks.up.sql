@@ -0,0 +1,28 @@
+CREATE TABLE "webhook_subscriptions" (
+    "id" serial,
+    "organization_id" integer NOT NULL,
+    "url" text NOT NULL,
+    "events" text,
+    "secret_id" text,
+    "created_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_webhook_subscriptions_organization_id ON "webhook_subscriptions"(organization_id);
+
+CREATE TABLE "webhook_deliveries" (
+    "id" serial,
+    "subscription_id" integer NOT NULL,
+    "event_id" text NOT NULL,
+    "event_type" text NOT NULL,
+    "payload" text,
+    "status" text NOT NULL,
+    "attempts" integer NOT NULL,
+    "response_code" integer,
+    "error" text,
+    "created_at" timestamp with time zone,
+    "updated_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription_id ON "webhook_deliveries"(subscription_id);
@@ -0,0 +1,30 @@
+CREATE TABLE "cluster_templates" (
+    "id" serial,
+    "organization_id" integer NOT NULL,
+    "name" text NOT NULL,
+    "version" integer NOT NULL,
+    "description" text,
+    "spec" text,
+    "created_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_cluster_templates_org_name_version ON "cluster_templates"(organization_id, name, version);
+
+CREATE TABLE "cluster_template_instances" (
+    "id" serial,
+    "organization_id" integer NOT NULL,
+    "template_name" text NOT NULL,
+    "template_version" integer NOT NULL,
+    "cluster_id" integer NOT NULL,
+    "cluster_name" text NOT NULL,
+    "spec" text,
+    "status" text NOT NULL,
+    "status_message" text,
+    "created_at" timestamp with time zone,
+    "updated_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_cluster_template_instances_org_template ON "cluster_template_instances"(organization_id, template_name);
+CREATE INDEX idx_cluster_template_instances_status ON "cluster_template_instances"(status);
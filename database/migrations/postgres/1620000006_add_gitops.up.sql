@@ -0,0 +1,18 @@
+CREATE TABLE "gitops_repositories" (
+    "id" serial,
+    "organization_id" integer NOT NULL,
+    "url" text NOT NULL,
+    "ref" text NOT NULL,
+    "path" text NOT NULL,
+    "interval" text NOT NULL,
+    "dry_run" boolean NOT NULL,
+    "prune" text,
+    "user_id" integer NOT NULL,
+    "status" text,
+    "inventory" text,
+    "created_at" timestamp with time zone,
+    "updated_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_gitops_repositories_org ON "gitops_repositories"(organization_id);
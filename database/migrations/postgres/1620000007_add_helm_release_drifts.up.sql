@@ -0,0 +1,17 @@
+CREATE TABLE "helm_release_drifts" (
+    "id" serial,
+    "created_at" timestamp with time zone,
+    "updated_at" timestamp with time zone,
+    "cluster_id" integer,
+    "namespace" text,
+    "release_name" text,
+    "revision" integer,
+    "drifted" boolean,
+    "resources" text,
+    "self_heal" boolean,
+    "checked_at" timestamp with time zone,
+    "healed_at" timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_helm_release_drifts_cluster_release ON "helm_release_drifts"(cluster_id, namespace, release_name);
@@ -0,0 +1 @@
+ALTER TABLE "clustergroup_deployments" DROP COLUMN "rollout_strategy", DROP COLUMN "rollout_id";
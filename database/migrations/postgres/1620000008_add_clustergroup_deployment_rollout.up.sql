@@ -0,0 +1 @@
+ALTER TABLE "clustergroup_deployments" ADD COLUMN "rollout_strategy" text, ADD COLUMN "rollout_id" text;
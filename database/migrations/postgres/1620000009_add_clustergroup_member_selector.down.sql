@@ -0,0 +1 @@
+ALTER TABLE "clustergroups" DROP COLUMN "member_selector";
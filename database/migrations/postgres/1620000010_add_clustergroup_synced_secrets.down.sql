@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "clustergroup_synced_secrets";
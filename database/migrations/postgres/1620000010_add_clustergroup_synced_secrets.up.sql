@@ -0,0 +1,15 @@
+CREATE TABLE "clustergroup_synced_secrets" (
+    "id" serial,
+    "created_at" timestamp with time zone,
+    "updated_at" timestamp with time zone,
+    "cluster_group_id" integer,
+    "cluster_id" integer,
+    "namespace" text,
+    "secret_name" text,
+    "secret_hash" text,
+    "status" text,
+    "status_message" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_clustergroup_synced_secret ON "clustergroup_synced_secrets"(cluster_group_id, cluster_id, namespace, secret_name);
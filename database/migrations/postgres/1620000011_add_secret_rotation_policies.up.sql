@@ -0,0 +1,14 @@
+CREATE TABLE "secret_rotation_policies" (
+    "id" serial,
+    "created_at" timestamp with time zone,
+    "updated_at" timestamp with time zone,
+    "organization_id" integer,
+    "secret_id" text,
+    "rotation_interval" bigint,
+    "notify_before" bigint,
+    "last_rotated_at" timestamp with time zone,
+    "notified" boolean,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_secret_rotation_policies_org_secret ON "secret_rotation_policies"(organization_id, secret_id);
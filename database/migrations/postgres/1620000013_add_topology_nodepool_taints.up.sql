@@ -0,0 +1,2 @@
+ALTER TABLE "topology_nodepools" ADD "taints" text;
+ALTER TABLE "topology_nodepools" ADD "startup_taints" text;
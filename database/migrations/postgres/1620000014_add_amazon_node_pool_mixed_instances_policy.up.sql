@@ -0,0 +1 @@
+ALTER TABLE "amazon_node_pools" ADD "mixed_instances_policy" text;
@@ -0,0 +1,4 @@
+DELETE FROM "event_bus_offsets" WHERE "subscriber" <> '';
+ALTER TABLE "event_bus_offsets" DROP CONSTRAINT "event_bus_offsets_pkey";
+ALTER TABLE "event_bus_offsets" DROP COLUMN "subscriber";
+ALTER TABLE "event_bus_offsets" ADD PRIMARY KEY ("consumer_group", "topic");
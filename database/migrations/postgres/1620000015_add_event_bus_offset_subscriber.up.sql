@@ -0,0 +1,3 @@
+ALTER TABLE "event_bus_offsets" ADD "subscriber" varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE "event_bus_offsets" DROP CONSTRAINT "event_bus_offsets_pkey";
+ALTER TABLE "event_bus_offsets" ADD PRIMARY KEY ("consumer_group", "subscriber", "topic");
@@ -0,0 +1,417 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// DefaultSyncInterval is used when a repository does not specify a sync interval.
+const DefaultSyncInterval = 5 * time.Minute
+
+// Repository is a Git repository an organization is reconciled from.
+type Repository struct {
+	// URL is the Git URL of the repository.
+	URL string `json:"url"`
+
+	// Ref is the branch or tag to check out. The default branch is used when empty.
+	Ref string `json:"ref,omitempty"`
+
+	// Path is the directory of the manifests in the repository.
+	Path string `json:"path,omitempty"`
+
+	// Interval is the time between synchronizations (eg. "5m").
+	Interval string `json:"interval,omitempty"`
+
+	// DryRun disables applying changes: synchronizations only report drift.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	Prune PruneOptions `json:"prune"`
+
+	// UserID is the user changes are made on behalf of.
+	UserID uint `json:"userId"`
+
+	Status SyncStatus `json:"status"`
+
+	// Inventory lists the resources created by previous synchronizations.
+	Inventory []string `json:"-"`
+}
+
+// SyncInterval returns the time between synchronizations.
+func (r Repository) SyncInterval() time.Duration {
+	interval, err := time.ParseDuration(r.Interval)
+	if err != nil || interval <= 0 {
+		return DefaultSyncInterval
+	}
+
+	return interval
+}
+
+// Due tells whether the sync interval of the repository has passed since its last synchronization.
+func (r Repository) Due(now time.Time) bool {
+	return r.Status.SyncedAt == nil || now.Sub(*r.Status.SyncedAt) >= r.SyncInterval()
+}
+
+// PruneOptions controls which resources are deleted when they are removed from the repository.
+//
+// Only resources created by a previous synchronization are ever deleted.
+type PruneOptions struct {
+	Clusters           bool `json:"clusters,omitempty"`
+	NodePools          bool `json:"nodePools,omitempty"`
+	IntegratedServices bool `json:"integratedServices,omitempty"`
+	Releases           bool `json:"releases,omitempty"`
+	HelmRepositories   bool `json:"helmRepositories,omitempty"`
+}
+
+// Synchronization results.
+const (
+	SyncSucceeded = "succeeded"
+	SyncFailed    = "failed"
+)
+
+// SyncStatus describes the last synchronization of a repository.
+type SyncStatus struct {
+	SyncedAt *time.Time `json:"syncedAt,omitempty"`
+	Revision string     `json:"revision,omitempty"`
+	Result   string     `json:"result,omitempty"`
+	Message  string     `json:"message,omitempty"`
+
+	// Drift lists the differences found between the repository and the organization.
+	// When the repository is not in dry run mode, the listed actions are applied.
+	Drift Plan `json:"drift"`
+}
+
+// Action operations.
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Resource types.
+const (
+	ResourceCluster           = "cluster"
+	ResourceNodePool          = "nodePool"
+	ResourceIntegratedService = "integratedService"
+	ResourceRelease           = "release"
+	ResourceHelmRepository    = "helmRepository"
+)
+
+// Action is a change needed to make the organization match the repository.
+type Action struct {
+	Operation    string `json:"operation"`
+	ResourceType string `json:"resourceType"`
+	Cluster      string `json:"cluster,omitempty"`
+	Name         string `json:"name"`
+	Reason       string `json:"reason,omitempty"`
+
+	// Error is set when applying the action failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Plan lists the actions needed to make the organization match the repository.
+type Plan struct {
+	Revision string   `json:"revision,omitempty"`
+	Actions  []Action `json:"actions"`
+
+	// Warnings lists differences that cannot be reconciled (yet).
+	Warnings []string `json:"warnings,omitempty"`
+
+	// SecretIDs lists the secrets referenced by the manifests of the revision.
+	SecretIDs []string `json:"secretIds,omitempty"`
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock
+
+// Service manages the Git repositories organizations are reconciled from.
+type Service interface {
+	// GetRepository returns the repository of an organization together with its last synchronization status.
+	GetRepository(ctx context.Context, organizationID uint) (repository Repository, err error)
+
+	// SetRepository sets the repository of an organization.
+	SetRepository(ctx context.Context, organizationID uint, repository Repository) (newRepository Repository, err error)
+
+	// DeleteRepository stops reconciling an organization. Resources are left intact.
+	DeleteRepository(ctx context.Context, organizationID uint) error
+
+	// Plan returns the changes a synchronization would make without applying them.
+	Plan(ctx context.Context, organizationID uint) (plan Plan, err error)
+
+	// Sync synchronizes an organization with its repository immediately.
+	Sync(ctx context.Context, organizationID uint) (status SyncStatus, err error)
+}
+
+// +testify:mock:testOnly=true
+
+// Store is a persistence layer for repositories.
+type Store interface {
+	// GetRepository returns the repository of an organization.
+	GetRepository(ctx context.Context, organizationID uint) (Repository, error)
+
+	// ListRepositories returns the repositories of every organization keyed by organization ID.
+	ListRepositories(ctx context.Context) (map[uint]Repository, error)
+
+	// SaveRepository creates or updates the repository of an organization.
+	// The synchronization status and the inventory are left intact.
+	SaveRepository(ctx context.Context, organizationID uint, repository Repository) (Repository, error)
+
+	// DeleteRepository deletes the repository of an organization.
+	DeleteRepository(ctx context.Context, organizationID uint) error
+
+	// SaveSyncStatus records the result of a synchronization and the resulting inventory.
+	SaveSyncStatus(ctx context.Context, organizationID uint, status SyncStatus, inventory []string) error
+}
+
+// +testify:mock:testOnly=true
+
+// Source fetches the contents of repositories.
+type Source interface {
+	// Fetch checks out a repository and returns the directory of the working copy and the checked out revision.
+	Fetch(ctx context.Context, organizationID uint, repository Repository) (dir string, revision string, err error)
+}
+
+// UserExtractor extracts user information from the context.
+type UserExtractor interface {
+	// GetUserID returns the ID of the currently authenticated user.
+	// If a user cannot be found in the context, it returns false as the second return value.
+	GetUserID(ctx context.Context) (uint, bool)
+}
+
+// NewService returns a new Service.
+//
+// Repositories are fetched over HTTPS or SSH, unless insecure repositories
+// (local paths, file:// and http:// URLs, eg. a local git server during development) are allowed.
+func NewService(store Store, reconciler Reconciler, userExtractor UserExtractor, allowInsecureRepositories bool) Service {
+	return service{
+		store:                     store,
+		reconciler:                reconciler,
+		userExtractor:             userExtractor,
+		allowInsecureRepositories: allowInsecureRepositories,
+	}
+}
+
+type service struct {
+	store                     Store
+	reconciler                Reconciler
+	userExtractor             UserExtractor
+	allowInsecureRepositories bool
+}
+
+func (s service) GetRepository(ctx context.Context, organizationID uint) (Repository, error) {
+	return s.store.GetRepository(ctx, organizationID)
+}
+
+func (s service) SetRepository(ctx context.Context, organizationID uint, repository Repository) (Repository, error) {
+	userID, ok := s.userExtractor.GetUserID(ctx)
+	if !ok {
+		return Repository{}, errors.New("user not found in the context")
+	}
+
+	repository.UserID = userID
+
+	var violations []string
+
+	if repository.URL == "" {
+		violations = append(violations, "url is required")
+	} else if err := validateURL(repository.URL, s.allowInsecureRepositories); err != nil {
+		violations = append(violations, err.Error())
+	}
+
+	if repository.Ref != "" {
+		if err := validateRef(repository.Ref); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+
+	if repository.Interval != "" {
+		if interval, err := time.ParseDuration(repository.Interval); err != nil || interval < time.Minute {
+			violations = append(violations, "interval must be a duration of at least one minute")
+		}
+	}
+
+	if len(violations) > 0 {
+		return Repository{}, NewValidationError("invalid repository", violations)
+	}
+
+	return s.store.SaveRepository(ctx, organizationID, repository)
+}
+
+// nolint: gochecknoglobals
+var scpLikeURLRegexp = regexp.MustCompile(`^(?:[A-Za-z0-9._-]+@)?[A-Za-z0-9][A-Za-z0-9.-]*:.+$`)
+
+// validateURL accepts HTTPS and SSH repository URLs (including the scp-like user@host:path syntax).
+// Absolute local paths, file:// and http:// URLs are only accepted if insecure repositories are allowed.
+// Other transports (eg. ext::) are always rejected.
+func validateURL(rawURL string, allowInsecure bool) error {
+	invalid := errors.New("url must be an https or ssh url")
+	if allowInsecure {
+		invalid = errors.New("url must be an https, ssh, http or file url or an absolute local path")
+	}
+
+	if strings.HasPrefix(rawURL, "-") || strings.Contains(rawURL, "::") || strings.ContainsAny(rawURL, " \t\r\n") {
+		return invalid
+	}
+
+	if !strings.Contains(rawURL, "://") {
+		if allowInsecure && filepath.IsAbs(rawURL) {
+			return nil
+		}
+
+		if !scpLikeURLRegexp.MatchString(rawURL) {
+			return invalid
+		}
+
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return invalid
+	}
+
+	switch {
+	case u.Scheme == "https" || u.Scheme == "ssh" || (allowInsecure && u.Scheme == "http"):
+		if u.Hostname() == "" || strings.HasPrefix(u.Hostname(), "-") {
+			return invalid
+		}
+
+	case allowInsecure && u.Scheme == "file":
+		if u.Path == "" {
+			return invalid
+		}
+
+	default:
+		return invalid
+	}
+
+	return nil
+}
+
+// validateRef accepts branch and tag names following the rules of git check-ref-format --allow-onelevel.
+func validateRef(ref string) error {
+	invalid := errors.New("ref must be a valid branch or tag name")
+
+	if strings.HasPrefix(ref, "-") || strings.HasPrefix(ref, "/") || strings.HasSuffix(ref, "/") || strings.HasSuffix(ref, ".") {
+		return invalid
+	}
+
+	if ref == "@" || strings.Contains(ref, "..") || strings.Contains(ref, "//") || strings.Contains(ref, "@{") {
+		return invalid
+	}
+
+	for _, c := range ref {
+		if c < 0x20 || c == 0x7f || strings.ContainsRune(" ~^:?*[\\", c) {
+			return invalid
+		}
+	}
+
+	for _, component := range strings.Split(ref, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return invalid
+		}
+	}
+
+	return nil
+}
+
+func (s service) DeleteRepository(ctx context.Context, organizationID uint) error {
+	return s.store.DeleteRepository(ctx, organizationID)
+}
+
+func (s service) Plan(ctx context.Context, organizationID uint) (Plan, error) {
+	repository, err := s.store.GetRepository(ctx, organizationID)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	plan, _, err := s.reconciler.Reconcile(ctx, organizationID, repository, false)
+
+	return plan, err
+}
+
+func (s service) Sync(ctx context.Context, organizationID uint) (SyncStatus, error) {
+	return s.reconciler.SyncOrganization(ctx, organizationID)
+}
+
+// ValidationError is returned when a repository or its manifests are invalid.
+type ValidationError struct {
+	message    string
+	violations []string
+}
+
+// NewValidationError returns a new ValidationError.
+func NewValidationError(message string, violations []string) ValidationError {
+	return ValidationError{
+		message:    message,
+		violations: violations,
+	}
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	return e.message
+}
+
+// Violations returns details of the failed validation.
+func (e ValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (ValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (ValidationError) ServiceError() bool {
+	return true
+}
+
+// NotFoundError is returned if an organization has no repository.
+type NotFoundError struct {
+	OrganizationID uint
+}
+
+// Error implements the error interface.
+func (NotFoundError) Error() string {
+	return "gitops repository not found"
+}
+
+// Details returns error details.
+func (e NotFoundError) Details() []interface{} {
+	return []interface{}{"organizationId", e.OrganizationID}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (NotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (NotFoundError) ServiceError() bool {
+	return true
+}
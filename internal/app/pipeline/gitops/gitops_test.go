@@ -0,0 +1,130 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type userExtractor uint
+
+func (u userExtractor) GetUserID(_ context.Context) (uint, bool) {
+	return uint(u), u != 0
+}
+
+func TestService_SetRepository(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("SaveRepository", ctx, uint(1), mock.Anything).Return(func(_ context.Context, _ uint, repository Repository) Repository {
+		return repository
+	}, nil)
+
+	service := NewService(store, Reconciler{}, userExtractor(2), false)
+
+	valid := []Repository{
+		{URL: "https://github.com/banzaicloud/pipeline.git"},
+		{URL: "ssh://git@github.com/banzaicloud/pipeline.git", Ref: "master"},
+		{URL: "git@github.com:banzaicloud/pipeline.git", Ref: "release/1.0"},
+		{URL: "https://example.com:8443/org/repo", Ref: "v1.0.0"},
+	}
+
+	for _, repository := range valid {
+		result, err := service.SetRepository(ctx, 1, repository)
+		require.NoError(t, err, "repository: %+v", repository)
+		assert.Equal(t, uint(2), result.UserID)
+	}
+
+	invalid := []Repository{
+		{},
+		{URL: "file:///etc"},
+		{URL: "/var/lib/repo"},
+		{URL: "./repo"},
+		{URL: "ext::sh -c touch% /tmp/pwned"},
+		{URL: "http://github.com/banzaicloud/pipeline.git"},
+		{URL: "ssh://-oProxyCommand=touch/tmp/pwned/repo"},
+		{URL: "--upload-pack=touch /tmp/pwned"},
+		{URL: "https://github.com/banzaicloud/pipeline.git", Ref: "--upload-pack=touch"},
+		{URL: "https://github.com/banzaicloud/pipeline.git", Ref: "a..b"},
+		{URL: "https://github.com/banzaicloud/pipeline.git", Ref: "feature/.hidden"},
+		{URL: "https://github.com/banzaicloud/pipeline.git", Ref: "branch.lock"},
+		{URL: "https://github.com/banzaicloud/pipeline.git", Ref: "HEAD@{1}"},
+		{URL: "https://github.com/banzaicloud/pipeline.git", Ref: "with space"},
+		{URL: "https://github.com/banzaicloud/pipeline.git", Interval: "10s"},
+	}
+
+	for _, repository := range invalid {
+		_, err := service.SetRepository(ctx, 1, repository)
+		assert.True(t, errors.As(err, &ValidationError{}), "repository: %+v", repository)
+	}
+
+	store.AssertNumberOfCalls(t, "SaveRepository", len(valid))
+}
+
+func TestService_SetRepository_Insecure(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("SaveRepository", ctx, uint(1), mock.Anything).Return(func(_ context.Context, _ uint, repository Repository) Repository {
+		return repository
+	}, nil)
+
+	service := NewService(store, Reconciler{}, userExtractor(2), true)
+
+	valid := []Repository{
+		{URL: "https://github.com/banzaicloud/pipeline.git"},
+		{URL: "http://localhost:3000/org/repo.git"},
+		{URL: "file:///var/lib/repo"},
+		{URL: "/var/lib/repo"},
+	}
+
+	for _, repository := range valid {
+		_, err := service.SetRepository(ctx, 1, repository)
+		require.NoError(t, err, "repository: %+v", repository)
+	}
+
+	invalid := []Repository{
+		{URL: "./repo"},
+		{URL: "file://"},
+		{URL: "ext::sh -c touch% /tmp/pwned"},
+		{URL: "--upload-pack=touch /tmp/pwned"},
+		{URL: "http://-oProxyCommand=touch/repo"},
+	}
+
+	for _, repository := range invalid {
+		_, err := service.SetRepository(ctx, 1, repository)
+		assert.True(t, errors.As(err, &ValidationError{}), "repository: %+v", repository)
+	}
+
+	store.AssertNumberOfCalls(t, "SaveRepository", len(valid))
+}
+
+func TestRepository_Due(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Minute)
+	old := now.Add(-DefaultSyncInterval)
+
+	assert.True(t, Repository{}.Due(now))
+	assert.False(t, Repository{Status: SyncStatus{SyncedAt: &recent}}.Due(now))
+	assert.True(t, Repository{Status: SyncStatus{SyncedAt: &old}}.Due(now))
+	assert.True(t, Repository{Interval: "1m", Status: SyncStatus{SyncedAt: &recent}}.Due(now))
+}
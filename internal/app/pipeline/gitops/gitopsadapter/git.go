@@ -0,0 +1,176 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitopsadapter
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops"
+)
+
+// Transports git may use to fetch repositories.
+const (
+	allowedProtocols         = "https:ssh"
+	allowedInsecureProtocols = "https:ssh:http:file"
+)
+
+// GitSource fetches repositories using the git command line tool.
+// Every organization has its own working copy in the work directory.
+type GitSource struct {
+	workDir string
+
+	// allowedProtocols is passed to git in GIT_ALLOW_PROTOCOL.
+	allowedProtocols string
+
+	// mu serializes fetches so that working copies are not modified concurrently.
+	mu *sync.Mutex
+}
+
+// NewGitSource returns a new GitSource.
+// Local and http repositories can only be fetched if insecure repositories are allowed.
+func NewGitSource(workDir string, allowInsecureRepositories bool) GitSource {
+	protocols := allowedProtocols
+	if allowInsecureRepositories {
+		protocols = allowedInsecureProtocols
+	}
+
+	return GitSource{
+		workDir:          workDir,
+		allowedProtocols: protocols,
+		mu:               &sync.Mutex{},
+	}
+}
+
+// Fetch checks out a repository and returns the directory of the working copy and the checked out revision.
+func (s GitSource) Fetch(ctx context.Context, organizationID uint, repository gitops.Repository) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if repository.Ref != "" {
+		if err := s.checkRef(ctx, repository.Ref); err != nil {
+			return "", "", err
+		}
+	}
+
+	dir := filepath.Join(s.workDir, strconv.FormatUint(uint64(organizationID), 10))
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		if err := s.update(ctx, dir, repository); err != nil {
+			return "", "", err
+		}
+	} else {
+		if err := s.clone(ctx, dir, repository); err != nil {
+			return "", "", err
+		}
+	}
+
+	revision, err := s.git(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+
+	return dir, revision, nil
+}
+
+func (s GitSource) clone(ctx context.Context, dir string, repository gitops.Repository) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.WrapIf(err, "failed to clean up working copy")
+	}
+
+	if err := os.MkdirAll(s.workDir, 0o700); err != nil {
+		return errors.WrapIf(err, "failed to create work directory")
+	}
+
+	args := []string{"clone", "--depth", "1"}
+	if repository.Ref != "" {
+		args = append(args, "--branch", repository.Ref)
+	}
+
+	args = append(args, "--", repository.URL, dir)
+
+	_, err := s.git(ctx, "", args...)
+
+	return err
+}
+
+func (s GitSource) update(ctx context.Context, dir string, repository gitops.Repository) error {
+	ref := repository.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	commands := [][]string{
+		{"remote", "set-url", "--", "origin", repository.URL},
+		{"fetch", "--depth", "1", "--", "origin", ref},
+		{"checkout", "--force", "FETCH_HEAD", "--"},
+		{"clean", "-fdx"},
+	}
+
+	for _, args := range commands {
+		if _, err := s.git(ctx, dir, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkRef makes sure a ref is a valid branch or tag name and cannot be mistaken for an option.
+func (s GitSource) checkRef(ctx context.Context, ref string) error {
+	if strings.HasPrefix(ref, "-") {
+		return errors.NewWithDetails("invalid git ref", "ref", ref)
+	}
+
+	if _, err := s.git(ctx, "", "check-ref-format", "--allow-onelevel", ref); err != nil {
+		return errors.WrapIfWithDetails(err, "invalid git ref", "ref", ref)
+	}
+
+	return nil
+}
+
+// git runs a git command restricted to the allowed protocols.
+func (s GitSource) git(ctx context.Context, dir string, args ...string) (string, error) {
+	return git(ctx, dir, []string{"GIT_ALLOW_PROTOCOL=" + s.allowedProtocols}, args...)
+}
+
+// git runs a git command with additional environment variables and returns its trimmed output.
+func git(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), env...), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.WrapIfWithDetails(
+			err, "git command failed",
+			"command", args[0],
+			"output", strings.TrimSpace(stderr.String()),
+		)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,112 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitopsadapter
+
+import (
+	"context"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops"
+)
+
+func commit(t *testing.T, dir string, file string, content string) string {
+	t.Helper()
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+
+	ctx := context.Background()
+
+	for _, args := range [][]string{
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "update"},
+	} {
+		_, err := git(ctx, dir, nil, args...)
+		require.NoError(t, err)
+	}
+
+	revision, err := git(ctx, dir, nil, "rev-parse", "HEAD")
+	require.NoError(t, err)
+
+	return revision
+}
+
+func TestGitSource_Fetch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	ctx := context.Background()
+
+	upstream := t.TempDir()
+
+	_, err := git(ctx, upstream, nil, "init", "-q")
+	require.NoError(t, err)
+
+	first := commit(t, upstream, "cluster.yaml", "kind: Cluster\nname: first\n")
+
+	source := NewGitSource(t.TempDir(), true)
+
+	repository := gitops.Repository{URL: "file://" + upstream}
+
+	dir, revision, err := source.Fetch(ctx, 1, repository)
+	require.NoError(t, err)
+	assert.Equal(t, first, revision)
+
+	second := commit(t, upstream, "cluster.yaml", "kind: Cluster\nname: second\n")
+
+	dir, revision, err = source.Fetch(ctx, 1, repository)
+	require.NoError(t, err)
+	assert.Equal(t, second, revision)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "cluster.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Cluster\nname: second\n", string(content))
+}
+
+func TestGitSource_Fetch_Rejected(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	ctx := context.Background()
+
+	upstream := t.TempDir()
+
+	_, err := git(ctx, upstream, nil, "init", "-q")
+	require.NoError(t, err)
+
+	commit(t, upstream, "cluster.yaml", "kind: Cluster\nname: first\n")
+
+	source := NewGitSource(t.TempDir(), false)
+
+	repositories := []gitops.Repository{
+		{URL: "file://" + upstream},
+		{URL: upstream},
+		{URL: "ext::sh -c true"},
+		{URL: "https://example.com/repo.git", Ref: "--upload-pack=true"},
+		{URL: "https://example.com/repo.git", Ref: "a..b"},
+	}
+
+	for _, repository := range repositories {
+		_, _, err := source.Fetch(ctx, 1, repository)
+		assert.Error(t, err, "repository: %+v", repository)
+	}
+}
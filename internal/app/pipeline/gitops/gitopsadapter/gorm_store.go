@@ -0,0 +1,207 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitopsadapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops"
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+)
+
+// TableName constants
+const (
+	repositoryTableName = "gitops_repositories"
+)
+
+type repositoryModel struct {
+	ID             uint         `gorm:"primary_key"`
+	OrganizationID uint         `gorm:"unique_index:idx_gitops_repositories_org;not null"`
+	URL            string       `gorm:"not null"`
+	Ref            string       `gorm:"not null"`
+	Path           string       `gorm:"not null"`
+	Interval       string       `gorm:"not null"`
+	DryRun         bool         `gorm:"not null"`
+	Prune          pruneOptions `gorm:"type:text"`
+	UserID         uint         `gorm:"not null"`
+	Status         syncStatus   `gorm:"type:text"`
+	Inventory      inventory    `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (repositoryModel) TableName() string {
+	return repositoryTableName
+}
+
+func (m repositoryModel) toRepository() gitops.Repository {
+	return gitops.Repository{
+		URL:       m.URL,
+		Ref:       m.Ref,
+		Path:      m.Path,
+		Interval:  m.Interval,
+		DryRun:    m.DryRun,
+		Prune:     gitops.PruneOptions(m.Prune),
+		UserID:    m.UserID,
+		Status:    gitops.SyncStatus(m.Status),
+		Inventory: m.Inventory,
+	}
+}
+
+type pruneOptions gitops.PruneOptions
+
+// Scan implements the sql.Scanner interface.
+func (o *pruneOptions) Scan(src interface{}) error {
+	return json.Scan(src, o)
+}
+
+// Value implements the driver.Valuer interface.
+func (o pruneOptions) Value() (driver.Value, error) {
+	return json.Value(o)
+}
+
+type syncStatus gitops.SyncStatus
+
+// Scan implements the sql.Scanner interface.
+func (s *syncStatus) Scan(src interface{}) error {
+	return json.Scan(src, s)
+}
+
+// Value implements the driver.Valuer interface.
+func (s syncStatus) Value() (driver.Value, error) {
+	return json.Value(s)
+}
+
+type inventory []string
+
+// Scan implements the sql.Scanner interface.
+func (i *inventory) Scan(src interface{}) error {
+	return json.Scan(src, i)
+}
+
+// Value implements the driver.Valuer interface.
+func (i inventory) Value() (driver.Value, error) {
+	if i == nil {
+		return json.Value([]string{})
+	}
+
+	return json.Value([]string(i))
+}
+
+// GormStore is a gitops repository store using Gorm for persistence.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new GormStore.
+func NewGormStore(db *gorm.DB) GormStore {
+	return GormStore{
+		db: db,
+	}
+}
+
+// GetRepository returns the repository of an organization.
+func (s GormStore) GetRepository(ctx context.Context, organizationID uint) (gitops.Repository, error) {
+	var model repositoryModel
+
+	err := s.db.Where(repositoryModel{OrganizationID: organizationID}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return gitops.Repository{}, errors.WithStack(gitops.NotFoundError{OrganizationID: organizationID})
+	} else if err != nil {
+		return gitops.Repository{}, errors.WrapIfWithDetails(err, "failed to get gitops repository", "organizationId", organizationID)
+	}
+
+	return model.toRepository(), nil
+}
+
+// ListRepositories returns the repositories of every organization keyed by organization ID.
+func (s GormStore) ListRepositories(ctx context.Context) (map[uint]gitops.Repository, error) {
+	var models []repositoryModel
+
+	err := s.db.Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list gitops repositories")
+	}
+
+	repositories := make(map[uint]gitops.Repository, len(models))
+	for _, model := range models {
+		repositories[model.OrganizationID] = model.toRepository()
+	}
+
+	return repositories, nil
+}
+
+// SaveRepository creates or updates the repository of an organization.
+func (s GormStore) SaveRepository(ctx context.Context, organizationID uint, repository gitops.Repository) (gitops.Repository, error) {
+	var model repositoryModel
+
+	err := s.db.Where(repositoryModel{OrganizationID: organizationID}).First(&model).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return gitops.Repository{}, errors.WrapIfWithDetails(err, "failed to get gitops repository", "organizationId", organizationID)
+	}
+
+	model.OrganizationID = organizationID
+	model.URL = repository.URL
+	model.Ref = repository.Ref
+	model.Path = repository.Path
+	model.Interval = repository.Interval
+	model.DryRun = repository.DryRun
+	model.Prune = pruneOptions(repository.Prune)
+	model.UserID = repository.UserID
+
+	err = s.db.Save(&model).Error
+	if err != nil {
+		return gitops.Repository{}, errors.WrapIfWithDetails(err, "failed to save gitops repository", "organizationId", organizationID)
+	}
+
+	return model.toRepository(), nil
+}
+
+// DeleteRepository deletes the repository of an organization.
+func (s GormStore) DeleteRepository(ctx context.Context, organizationID uint) error {
+	result := s.db.Where(repositoryModel{OrganizationID: organizationID}).Delete(repositoryModel{})
+	if result.Error != nil {
+		return errors.WrapIfWithDetails(result.Error, "failed to delete gitops repository", "organizationId", organizationID)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.WithStack(gitops.NotFoundError{OrganizationID: organizationID})
+	}
+
+	return nil
+}
+
+// SaveSyncStatus records the result of a synchronization and the resulting inventory.
+func (s GormStore) SaveSyncStatus(ctx context.Context, organizationID uint, status gitops.SyncStatus, resources []string) error {
+	result := s.db.Model(&repositoryModel{}).Where(repositoryModel{OrganizationID: organizationID}).Updates(map[string]interface{}{
+		"status":    syncStatus(status),
+		"inventory": inventory(resources),
+	})
+	if result.Error != nil {
+		return errors.WrapIfWithDetails(result.Error, "failed to save gitops sync status", "organizationId", organizationID)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.WithStack(gitops.NotFoundError{OrganizationID: organizationID})
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitopsadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	//  SQLite driver used for integration test
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, gitops.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	_, err := store.GetRepository(ctx, 1)
+	require.Error(t, err)
+	assert.True(t, errors.As(err, &gitops.NotFoundError{}))
+
+	repository := gitops.Repository{
+		URL:    "https://github.com/example/infrastructure.git",
+		Path:   "clusters",
+		DryRun: true,
+		Prune:  gitops.PruneOptions{Releases: true},
+		UserID: 1,
+	}
+
+	_, err = store.SaveRepository(ctx, 1, repository)
+	require.NoError(t, err)
+
+	syncedAt := time.Now().UTC().Truncate(time.Second)
+	status := gitops.SyncStatus{
+		SyncedAt: &syncedAt,
+		Revision: "abc123",
+		Result:   gitops.SyncSucceeded,
+		Drift:    gitops.Plan{Revision: "abc123", Actions: []gitops.Action{}},
+	}
+
+	err = store.SaveSyncStatus(ctx, 1, status, []string{"cluster/prod"})
+	require.NoError(t, err)
+
+	repository.DryRun = false
+
+	_, err = store.SaveRepository(ctx, 1, repository)
+	require.NoError(t, err)
+
+	saved, err := store.GetRepository(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, repository.URL, saved.URL)
+	assert.False(t, saved.DryRun)
+	assert.Equal(t, repository.Prune, saved.Prune)
+	assert.Equal(t, "abc123", saved.Status.Revision)
+	assert.True(t, syncedAt.Equal(*saved.Status.SyncedAt))
+	assert.Equal(t, []string{"cluster/prod"}, saved.Inventory)
+
+	repositories, err := store.ListRepositories(ctx)
+	require.NoError(t, err)
+	assert.Len(t, repositories, 1)
+	assert.Contains(t, repositories, uint(1))
+
+	err = store.DeleteRepository(ctx, 1)
+	require.NoError(t, err)
+
+	err = store.DeleteRepository(ctx, 1)
+	assert.True(t, errors.As(err, &gitops.NotFoundError{}))
+
+	err = store.SaveSyncStatus(ctx, 1, status, nil)
+	assert.True(t, errors.As(err, &gitops.NotFoundError{}))
+}
@@ -0,0 +1,162 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitopsdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into a router.
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodGet).Path("").Handler(kithttp.NewServer(
+		endpoints.GetRepository,
+		decodeGetRepositoryHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetRepositoryHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPut).Path("").Handler(kithttp.NewServer(
+		endpoints.SetRepository,
+		decodeSetRepositoryHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeSetRepositoryHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("").Handler(kithttp.NewServer(
+		endpoints.DeleteRepository,
+		decodeDeleteRepositoryHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/plan").Handler(kithttp.NewServer(
+		endpoints.Plan,
+		decodePlanHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodePlanHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/sync").Handler(kithttp.NewServer(
+		endpoints.Sync,
+		decodeSyncHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeSyncHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+func decodeGetRepositoryHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetRepositoryRequest{OrganizationID: orgID}, nil
+}
+
+func encodeGetRepositoryHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetRepositoryResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Repository)
+}
+
+func decodeSetRepositoryHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository gitops.Repository
+
+	err = json.NewDecoder(r.Body).Decode(&repository)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return SetRepositoryRequest{OrganizationID: orgID, Repository: repository}, nil
+}
+
+func encodeSetRepositoryHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(SetRepositoryResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.NewRepository)
+}
+
+func decodeDeleteRepositoryHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeleteRepositoryRequest{OrganizationID: orgID}, nil
+}
+
+func decodePlanHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return PlanRequest{OrganizationID: orgID}, nil
+}
+
+func encodePlanHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(PlanResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Plan)
+}
+
+func decodeSyncHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return SyncRequest{OrganizationID: orgID}, nil
+}
+
+func encodeSyncHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(SyncResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Status)
+}
+
+func extractUintParamFromRequest(key string, r *http.Request) (uint, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[key]
+	if !ok || value == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", key)
+	}
+
+	uintVal, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to parse path parameter", "param", key, "value", value)
+	}
+
+	return uint(uintVal), nil
+}
@@ -0,0 +1,98 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitopsworkflow
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/gitops"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+const ListDueOrganizationsActivityName = "gitops-list-due-organizations"
+
+type RepositoryLister interface {
+	// ListRepositories returns the repositories of every organization keyed by organization ID.
+	ListRepositories(ctx context.Context) (map[uint]gitops.Repository, error)
+}
+
+type ListDueOrganizationsActivity struct {
+	repositories RepositoryLister
+}
+
+func NewListDueOrganizationsActivity(repositories RepositoryLister) ListDueOrganizationsActivity {
+	return ListDueOrganizationsActivity{
+		repositories: repositories,
+	}
+}
+
+func (a ListDueOrganizationsActivity) Execute(ctx context.Context) ([]uint, error) {
+	repositories, err := a.repositories.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	organizationIDs := make([]uint, 0, len(repositories))
+	for organizationID, repository := range repositories {
+		if repository.Due(now) {
+			organizationIDs = append(organizationIDs, organizationID)
+		}
+	}
+
+	sort.Slice(organizationIDs, func(i, j int) bool { return organizationIDs[i] < organizationIDs[j] })
+
+	return organizationIDs, nil
+}
+
+func (a ListDueOrganizationsActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: ListDueOrganizationsActivityName})
+}
+
+const SyncOrganizationActivityName = "gitops-sync-organization"
+
+type SyncOrganizationActivityInput struct {
+	OrganizationID uint
+}
+
+type OrganizationSyncer interface {
+	// SyncOrganization synchronizes an organization with its repository and records the result.
+	SyncOrganization(ctx context.Context, organizationID uint) (gitops.SyncStatus, error)
+}
+
+type SyncOrganizationActivity struct {
+	syncer OrganizationSyncer
+}
+
+func NewSyncOrganizationActivity(syncer OrganizationSyncer) SyncOrganizationActivity {
+	return SyncOrganizationActivity{
+		syncer: syncer,
+	}
+}
+
+func (a SyncOrganizationActivity) Execute(ctx context.Context, input SyncOrganizationActivityInput) error {
+	_, err := a.syncer.SyncOrganization(ctx, input.OrganizationID)
+
+	return err
+}
+
+func (a SyncOrganizationActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: SyncOrganizationActivityName})
+}
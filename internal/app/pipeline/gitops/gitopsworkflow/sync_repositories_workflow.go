@@ -0,0 +1,85 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitopsworkflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+const SyncRepositoriesWorkflowName = "gitops-sync-repositories"
+
+// SyncTaskList is the task list of the synchronization activities.
+// Synchronizations are executed by the Pipeline API server, because they create and update clusters
+// the same way the API does.
+const SyncTaskList = "pipeline-gitops"
+
+type SyncRepositoriesWorkflow struct{}
+
+func NewSyncRepositoriesWorkflow() *SyncRepositoriesWorkflow {
+	return &SyncRepositoriesWorkflow{}
+}
+
+func (w SyncRepositoriesWorkflow) Execute(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+
+	activityOptions := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    30 * time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          time.Minute,
+			BackoffCoefficient:       2.0,
+			ExpirationInterval:       30 * time.Minute,
+			MaximumAttempts:          3,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+		},
+	}
+
+	var organizationIDs []uint
+	err := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, activityOptions), ListDueOrganizationsActivityName).Get(ctx, &organizationIDs)
+	if err != nil {
+		return err
+	}
+
+	activityOptions.TaskList = SyncTaskList
+	syncContext := workflow.WithActivityOptions(ctx, activityOptions)
+
+	futures := make([]workflow.Future, 0, len(organizationIDs))
+	for _, organizationID := range organizationIDs {
+		input := SyncOrganizationActivityInput{
+			OrganizationID: organizationID,
+		}
+
+		futures = append(futures, workflow.ExecuteActivity(syncContext, SyncOrganizationActivityName, input))
+	}
+
+	// synchronization failing for an organization should not affect the other organizations
+	for i, future := range futures {
+		if err := future.Get(ctx, nil); err != nil {
+			logger.Sugar().Warnw("gitops synchronization failed", "organizationId", organizationIDs[i], "error", err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (w SyncRepositoriesWorkflow) Register(worker worker.Registry) {
+	worker.RegisterWorkflowWithOptions(w.Execute, workflow.RegisterOptions{Name: SyncRepositoriesWorkflowName})
+}
@@ -0,0 +1,66 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitopsworkflow
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+)
+
+func TestSyncRepositoriesWorkflow(t *testing.T) {
+	env := (&testsuite.WorkflowTestSuite{}).NewTestWorkflowEnvironment()
+
+	NewSyncRepositoriesWorkflow().Register(env)
+
+	env.RegisterActivityWithOptions(
+		func(ctx context.Context) ([]uint, error) {
+			return []uint{1, 2}, nil
+		},
+		activity.RegisterOptions{Name: ListDueOrganizationsActivityName},
+	)
+
+	var synced []uint
+
+	env.RegisterActivityWithOptions(
+		func(ctx context.Context, input SyncOrganizationActivityInput) error {
+			assert.Equal(t, SyncTaskList, activity.GetInfo(ctx).TaskList)
+
+			synced = append(synced, input.OrganizationID)
+
+			if input.OrganizationID == 1 {
+				return errors.New("failed to save sync status")
+			}
+
+			return nil
+		},
+		activity.RegisterOptions{Name: SyncOrganizationActivityName},
+	)
+
+	env.ExecuteWorkflow(SyncRepositoriesWorkflowName)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	// failing organizations are retried without affecting the others
+	assert.Contains(t, synced, uint(1))
+	assert.Contains(t, synced, uint(2))
+	assert.Greater(t, len(synced), 2)
+}
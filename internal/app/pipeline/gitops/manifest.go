@@ -0,0 +1,314 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Manifest kinds.
+const (
+	KindCluster        = "Cluster"
+	KindHelmRepository = "HelmRepository"
+)
+
+// Manifests describe the desired state of an organization.
+type Manifests struct {
+	Clusters         []ClusterManifest
+	HelmRepositories []HelmRepositoryManifest
+}
+
+// ClusterManifest describes a cluster and the resources installed on it.
+//
+//	kind: Cluster
+//	name: prod
+//	spec: {} # cluster creation request
+//	nodePools:
+//	  pool2: {} # node pool creation request
+//	integratedServices:
+//	  dns: {} # integrated service spec
+//	releases:
+//	  - releaseName: ingress
+//	    chartName: stable/nginx-ingress
+type ClusterManifest struct {
+	Name string `json:"name"`
+
+	// Spec is the cluster creation request used when the cluster does not exist.
+	Spec map[string]interface{} `json:"spec"`
+
+	// NodePools are created, updated (and pruned) once the cluster is running.
+	NodePools map[string]map[string]interface{} `json:"nodePools,omitempty"`
+
+	// IntegratedServices are activated, updated (and deactivated) once the cluster is running.
+	IntegratedServices map[string]map[string]interface{} `json:"integratedServices,omitempty"`
+
+	// Releases are installed, upgraded (and deleted) once the cluster is running.
+	Releases []ReleaseManifest `json:"releases,omitempty"`
+}
+
+// ReleaseManifest describes a Helm release.
+type ReleaseManifest struct {
+	ReleaseName string                 `json:"releaseName"`
+	ChartName   string                 `json:"chartName"`
+	Version     string                 `json:"version,omitempty"`
+	Namespace   string                 `json:"namespace,omitempty"`
+	Values      map[string]interface{} `json:"values,omitempty"`
+}
+
+// HelmRepositoryManifest describes a Helm repository of the organization.
+type HelmRepositoryManifest struct {
+	Name             string `json:"name"`
+	URL              string `json:"url"`
+	PasswordSecretID string `json:"passwordSecretId,omitempty"`
+	TLSSecretID      string `json:"tlsSecretId,omitempty"`
+}
+
+// SecretIDs returns the secrets referenced by the manifests.
+// Secrets are recognized by the name of the fields referencing them (eg. "secretId", "sshSecretId", "secretIds").
+func (m Manifests) SecretIDs() []string {
+	ids := make(map[string]bool)
+
+	for _, repository := range m.HelmRepositories {
+		ids[repository.PasswordSecretID] = true
+		ids[repository.TLSSecretID] = true
+	}
+
+	for _, cluster := range m.Clusters {
+		collectSecretIDs(cluster.Spec, ids)
+
+		for _, nodePool := range cluster.NodePools {
+			collectSecretIDs(nodePool, ids)
+		}
+
+		for _, spec := range cluster.IntegratedServices {
+			collectSecretIDs(spec, ids)
+		}
+
+		for _, release := range cluster.Releases {
+			collectSecretIDs(release.Values, ids)
+		}
+	}
+
+	delete(ids, "")
+
+	secretIDs := make([]string, 0, len(ids))
+	for id := range ids {
+		secretIDs = append(secretIDs, id)
+	}
+
+	sort.Strings(secretIDs)
+
+	return secretIDs
+}
+
+func collectSecretIDs(value interface{}, ids map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			switch key := strings.ToLower(key); {
+			case strings.HasSuffix(key, "secretid"):
+				if id, ok := item.(string); ok {
+					ids[id] = true
+				}
+
+			case strings.HasSuffix(key, "secretids"):
+				if items, ok := item.([]interface{}); ok {
+					for _, item := range items {
+						if id, ok := item.(string); ok {
+							ids[id] = true
+						}
+					}
+				}
+
+			default:
+				collectSecretIDs(item, ids)
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			collectSecretIDs(item, ids)
+		}
+	}
+}
+
+type manifestHeader struct {
+	Kind string `json:"kind"`
+}
+
+// LoadManifests reads every YAML file in a directory tree.
+// A file may contain multiple manifests separated by "---".
+func LoadManifests(dir string) (Manifests, error) {
+	var files []string
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != dir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Manifests{}, errors.WrapIfWithDetails(err, "failed to list manifests", "dir", dir)
+	}
+
+	sort.Strings(files)
+
+	var manifests Manifests
+
+	for _, file := range files {
+		if err := loadManifestFile(file, &manifests); err != nil {
+			rel, _ := filepath.Rel(dir, file)
+
+			return Manifests{}, errors.WrapIfWithDetails(err, "failed to load manifest", "file", rel)
+		}
+	}
+
+	if violations := validateManifests(manifests); len(violations) > 0 {
+		return Manifests{}, NewValidationError("invalid manifests", violations)
+	}
+
+	return manifests, nil
+}
+
+func loadManifestFile(path string, manifests *Manifests) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := yaml.NewYAMLOrJSONDecoder(file, 4096)
+
+	for {
+		var document json.RawMessage
+
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if len(document) == 0 || string(document) == "null" {
+			continue
+		}
+
+		var header manifestHeader
+		if err := json.Unmarshal(document, &header); err != nil {
+			return err
+		}
+
+		switch header.Kind {
+		case KindCluster:
+			var manifest ClusterManifest
+			if err := json.Unmarshal(document, &manifest); err != nil {
+				return err
+			}
+
+			manifests.Clusters = append(manifests.Clusters, manifest)
+
+		case KindHelmRepository:
+			var manifest HelmRepositoryManifest
+			if err := json.Unmarshal(document, &manifest); err != nil {
+				return err
+			}
+
+			manifests.HelmRepositories = append(manifests.HelmRepositories, manifest)
+
+		default:
+			return errors.Errorf("unknown manifest kind %q", header.Kind)
+		}
+	}
+}
+
+func validateManifests(manifests Manifests) []string {
+	var violations []string
+
+	clusters := make(map[string]bool, len(manifests.Clusters))
+
+	for _, cluster := range manifests.Clusters {
+		if cluster.Name == "" {
+			violations = append(violations, "cluster name is required")
+
+			continue
+		}
+
+		if clusters[cluster.Name] {
+			violations = append(violations, fmt.Sprintf("cluster %q is declared more than once", cluster.Name))
+		}
+
+		clusters[cluster.Name] = true
+
+		if len(cluster.Spec) == 0 {
+			violations = append(violations, fmt.Sprintf("cluster %q: spec is required", cluster.Name))
+		}
+
+		releases := make(map[string]bool, len(cluster.Releases))
+
+		for _, release := range cluster.Releases {
+			if release.ReleaseName == "" || release.ChartName == "" {
+				violations = append(violations, fmt.Sprintf("cluster %q: release name and chart name are required for every release", cluster.Name))
+
+				continue
+			}
+
+			if releases[release.ReleaseName] {
+				violations = append(violations, fmt.Sprintf("cluster %q: release %q is declared more than once", cluster.Name, release.ReleaseName))
+			}
+
+			releases[release.ReleaseName] = true
+		}
+	}
+
+	repositories := make(map[string]bool, len(manifests.HelmRepositories))
+
+	for _, repository := range manifests.HelmRepositories {
+		if repository.Name == "" || repository.URL == "" {
+			violations = append(violations, "helm repository name and url are required")
+
+			continue
+		}
+
+		if repositories[repository.Name] {
+			violations = append(violations, fmt.Sprintf("helm repository %q is declared more than once", repository.Name))
+		}
+
+		repositories[repository.Name] = true
+	}
+
+	return violations
+}
@@ -0,0 +1,132 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestLoadManifests(t *testing.T) {
+	dir := t.TempDir()
+
+	writeManifest(t, dir, "repositories.yaml", `
+kind: HelmRepository
+name: stable
+url: https://charts.helm.sh/stable
+`)
+	writeManifest(t, dir, "clusters/prod.yml", `
+kind: Cluster
+name: prod
+spec:
+  cloud: amazon
+nodePools:
+  pool1:
+    size: 3
+releases:
+  - releaseName: ingress
+    chartName: stable/nginx-ingress
+    values:
+      replicaCount: 2
+---
+kind: Cluster
+name: staging
+spec:
+  cloud: amazon
+`)
+	writeManifest(t, dir, "README.md", "not a manifest")
+	writeManifest(t, dir, ".github/workflow.yaml", "kind: Workflow")
+
+	manifests, err := LoadManifests(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []HelmRepositoryManifest{{Name: "stable", URL: "https://charts.helm.sh/stable"}}, manifests.HelmRepositories)
+
+	require.Len(t, manifests.Clusters, 2)
+	assert.Equal(t, "prod", manifests.Clusters[0].Name)
+	assert.Equal(t, map[string]interface{}{"cloud": "amazon"}, manifests.Clusters[0].Spec)
+	assert.Equal(t, map[string]map[string]interface{}{"pool1": {"size": float64(3)}}, manifests.Clusters[0].NodePools)
+	assert.Equal(t, []ReleaseManifest{{
+		ReleaseName: "ingress",
+		ChartName:   "stable/nginx-ingress",
+		Values:      map[string]interface{}{"replicaCount": float64(2)},
+	}}, manifests.Clusters[0].Releases)
+	assert.Equal(t, "staging", manifests.Clusters[1].Name)
+}
+
+func TestManifests_SecretIDs(t *testing.T) {
+	manifests := Manifests{
+		Clusters: []ClusterManifest{
+			{
+				Name: "prod",
+				Spec: map[string]interface{}{
+					"secretId":    "cloud",
+					"sshSecretId": "ssh",
+					"name":        "not-a-secret",
+				},
+				IntegratedServices: map[string]map[string]interface{}{
+					"dns": {"clusterDomain": "example.com", "provider": map[string]interface{}{"secretId": "dns"}},
+				},
+				Releases: []ReleaseManifest{
+					{ReleaseName: "app", ChartName: "app", Values: map[string]interface{}{"secretIds": []interface{}{"app", "cloud"}}},
+				},
+			},
+		},
+		HelmRepositories: []HelmRepositoryManifest{
+			{Name: "private", URL: "https://charts.example.com", PasswordSecretID: "helm"},
+		},
+	}
+
+	assert.Equal(t, []string{"app", "cloud", "dns", "helm", "ssh"}, manifests.SecretIDs())
+}
+
+func TestLoadManifests_Invalid(t *testing.T) {
+	t.Run("UnknownKind", func(t *testing.T) {
+		dir := t.TempDir()
+
+		writeManifest(t, dir, "manifest.yaml", "kind: Unknown")
+
+		_, err := LoadManifests(dir)
+		require.Error(t, err)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		dir := t.TempDir()
+
+		writeManifest(t, dir, "a.yaml", "kind: Cluster\nname: prod\nspec: {cloud: amazon}")
+		writeManifest(t, dir, "b.yaml", "kind: Cluster\nname: prod")
+
+		_, err := LoadManifests(dir)
+		require.Error(t, err)
+
+		var validationErr ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{`cluster "prod" is declared more than once`, `cluster "prod": spec is required`}, validationErr.Violations())
+	})
+}
@@ -0,0 +1,793 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/helm"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// +testify:mock:testOnly=true
+
+// ClusterCreator creates clusters.
+type ClusterCreator interface {
+	// CreateClusterFromRequest validates a cluster creation request and starts creating the cluster.
+	CreateClusterFromRequest(ctx context.Context, organizationID uint, userID uint, request pkgCluster.CreateClusterRequest) (uint, error)
+}
+
+// +testify:mock:testOnly=true
+
+// HelmService manages Helm repositories and releases.
+// It is the subset of helm.Service used for reconciliation.
+type HelmService interface {
+	ListRepositories(ctx context.Context, organizationID uint) (repos []helm.Repository, err error)
+	AddRepository(ctx context.Context, organizationID uint, repository helm.Repository) error
+	ModifyRepository(ctx context.Context, organizationID uint, repository helm.Repository) error
+	DeleteRepository(ctx context.Context, organizationID uint, repoName string) error
+
+	ListReleases(ctx context.Context, organizationID uint, clusterID uint, filters helm.ReleaseFilter, options helm.Options) ([]helm.Release, error)
+	InstallRelease(ctx context.Context, organizationID uint, clusterID uint, releaseInput helm.Release, options helm.Options) (release helm.Release, err error)
+	UpgradeRelease(ctx context.Context, organizationID uint, clusterID uint, releaseInput helm.Release, options helm.Options) (release helm.Release, err error)
+	DeleteRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options helm.Options) error
+}
+
+// Reconciler makes organizations match the manifests of their repositories.
+type Reconciler struct {
+	store  Store
+	source Source
+
+	clusters           cluster.Store
+	clusterCreator     ClusterCreator
+	clusterService     cluster.Service
+	integratedServices integratedservices.Service
+	helm               HelmService
+
+	logger Logger
+}
+
+// NewReconciler returns a new Reconciler.
+func NewReconciler(
+	store Store,
+	source Source,
+	clusters cluster.Store,
+	clusterCreator ClusterCreator,
+	clusterService cluster.Service,
+	integratedServices integratedservices.Service,
+	helmService HelmService,
+	logger Logger,
+) Reconciler {
+	return Reconciler{
+		store:              store,
+		source:             source,
+		clusters:           clusters,
+		clusterCreator:     clusterCreator,
+		clusterService:     clusterService,
+		integratedServices: integratedServices,
+		helm:               helmService,
+		logger:             logger,
+	}
+}
+
+// SyncOrganization synchronizes an organization with its repository and records the result.
+func (r Reconciler) SyncOrganization(ctx context.Context, organizationID uint) (SyncStatus, error) {
+	repository, err := r.store.GetRepository(ctx, organizationID)
+	if err != nil {
+		return SyncStatus{}, err
+	}
+
+	status, err := r.Sync(ctx, organizationID, repository)
+	if err != nil {
+		return SyncStatus{}, err
+	}
+
+	r.logger.Info("organization synchronized", map[string]interface{}{
+		"organizationId": organizationID,
+		"revision":       status.Revision,
+		"result":         status.Result,
+		"actions":        len(status.Drift.Actions),
+	})
+
+	return status, nil
+}
+
+// Sync reconciles an organization with its repository and records the result.
+// Changes are only reported when the repository is in dry run mode.
+func (r Reconciler) Sync(ctx context.Context, organizationID uint, repository Repository) (SyncStatus, error) {
+	plan, inventory, err := r.Reconcile(ctx, organizationID, repository, !repository.DryRun)
+
+	now := time.Now()
+	status := SyncStatus{
+		SyncedAt: &now,
+		Revision: plan.Revision,
+		Result:   SyncSucceeded,
+		Drift:    plan,
+	}
+
+	if err != nil {
+		status.Result = SyncFailed
+		status.Message = err.Error()
+	}
+
+	if repository.DryRun || inventory == nil {
+		inventory = repository.Inventory
+	}
+
+	if err := r.store.SaveSyncStatus(ctx, organizationID, status, inventory); err != nil {
+		return SyncStatus{}, err
+	}
+
+	return status, nil
+}
+
+// Reconcile compares an organization with the manifests of its repository and applies the differences when requested.
+// It returns the list of differences and the inventory of resources managed by the repository.
+func (r Reconciler) Reconcile(ctx context.Context, organizationID uint, repository Repository, apply bool) (Plan, []string, error) {
+	dir, revision, err := r.source.Fetch(ctx, organizationID, repository)
+	if err != nil {
+		return Plan{}, nil, err
+	}
+
+	manifestDir := filepath.Join(dir, filepath.Clean("/"+repository.Path))
+
+	manifests, err := LoadManifests(manifestDir)
+	if err != nil {
+		return Plan{Revision: revision}, nil, err
+	}
+
+	rec := reconciliation{
+		Reconciler:     r,
+		ctx:            ctx,
+		organizationID: organizationID,
+		userID:         repository.UserID,
+		apply:          apply,
+		pruneOptions:   repository.Prune,
+		previous:       make(map[string]bool, len(repository.Inventory)),
+		inventory:      make(map[string]bool, len(repository.Inventory)),
+		plan:           Plan{Revision: revision, Actions: []Action{}, SecretIDs: manifests.SecretIDs()},
+	}
+
+	for _, key := range repository.Inventory {
+		rec.previous[key] = true
+	}
+
+	rec.reconcileHelmRepositories(manifests.HelmRepositories)
+	rec.reconcileClusters(manifests.Clusters)
+
+	inventory := make([]string, 0, len(rec.inventory))
+	for key, managed := range rec.inventory {
+		if managed {
+			inventory = append(inventory, key)
+		}
+	}
+
+	sort.Strings(inventory)
+
+	return rec.plan, inventory, rec.errs
+}
+
+// reconciliation holds the state of a single reconciliation.
+type reconciliation struct {
+	Reconciler
+
+	ctx            context.Context
+	organizationID uint
+	userID         uint
+	apply          bool
+	pruneOptions   PruneOptions
+
+	// previous is the inventory of the previous synchronization.
+	previous map[string]bool
+
+	// inventory collects the resources managed after this reconciliation.
+	inventory map[string]bool
+
+	plan Plan
+	errs error
+}
+
+// do records an action and applies it unless the reconciliation is a dry run.
+// It returns false if applying the action failed.
+func (r *reconciliation) do(action Action, fn func() error) bool {
+	if r.apply {
+		if err := fn(); err != nil {
+			action.Error = err.Error()
+
+			r.errs = errors.Append(r.errs, errors.WrapIfWithDetails(
+				err, fmt.Sprintf("failed to %s %s", action.Operation, action.ResourceType),
+				"cluster", action.Cluster,
+				"name", action.Name,
+			))
+		}
+	}
+
+	r.plan.Actions = append(r.plan.Actions, action)
+
+	return action.Error == ""
+}
+
+// fail records an error that prevents reconciling some resources and keeps their previous inventory.
+func (r *reconciliation) fail(err error, path string) {
+	r.errs = errors.Append(r.errs, err)
+	r.keep(path)
+}
+
+// keep retains a resource (or every resource under a path ending with "/") and its sub-resources
+// from the previous inventory.
+func (r *reconciliation) keep(path string) {
+	path = strings.TrimSuffix(path, "/")
+
+	for key := range r.previous {
+		if key == path || strings.HasPrefix(key, path+"/") {
+			r.inventory[key] = true
+		}
+	}
+}
+
+// prune deletes the resources created by previous synchronizations that are no longer declared.
+func (r *reconciliation) prune(
+	prefix string,
+	resourceType string,
+	clusterName string,
+	enabled bool,
+	declared map[string]bool,
+	exists func(name string) (bool, error),
+	remove func(name string) error,
+) {
+	var keys []string
+	for key := range r.previous {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, prefix)
+		if name == key || strings.Contains(name, "/") || declared[name] {
+			continue
+		}
+
+		ok, err := exists(name)
+		if err != nil {
+			r.fail(err, key)
+
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		if !enabled {
+			r.plan.Warnings = append(r.plan.Warnings, fmt.Sprintf("%s %q is no longer declared, but pruning is disabled", resourceType, name))
+			r.keep(key)
+
+			continue
+		}
+
+		action := Action{
+			Operation:    OperationDelete,
+			ResourceType: resourceType,
+			Cluster:      clusterName,
+			Name:         name,
+			Reason:       "no longer declared",
+		}
+
+		if !r.do(action, func() error { return remove(name) }) {
+			r.keep(key)
+		}
+	}
+}
+
+func (r *reconciliation) reconcileHelmRepositories(manifests []HelmRepositoryManifest) {
+	const prefix = ResourceHelmRepository + "/"
+
+	current, err := r.helm.ListRepositories(r.ctx, r.organizationID)
+	if err != nil {
+		r.fail(errors.WrapIf(err, "failed to list helm repositories"), prefix)
+
+		return
+	}
+
+	existing := make(map[string]helm.Repository, len(current))
+	for _, repository := range current {
+		existing[repository.Name] = repository
+	}
+
+	declared := make(map[string]bool, len(manifests))
+
+	for _, manifest := range manifests {
+		key := prefix + manifest.Name
+		declared[manifest.Name] = true
+
+		repository := helm.Repository{
+			Name:             manifest.Name,
+			URL:              manifest.URL,
+			PasswordSecretID: manifest.PasswordSecretID,
+			TlsSecretID:      manifest.TLSSecretID,
+		}
+
+		actual, ok := existing[manifest.Name]
+		if !ok {
+			action := Action{Operation: OperationCreate, ResourceType: ResourceHelmRepository, Name: manifest.Name}
+
+			if r.do(action, func() error { return r.helm.AddRepository(r.ctx, r.organizationID, repository) }) {
+				r.inventory[key] = true
+			}
+
+			continue
+		}
+
+		r.inventory[key] = r.previous[key]
+
+		if actual.URL != repository.URL || actual.PasswordSecretID != repository.PasswordSecretID || actual.TlsSecretID != repository.TlsSecretID {
+			action := Action{Operation: OperationUpdate, ResourceType: ResourceHelmRepository, Name: manifest.Name, Reason: "repository settings changed"}
+
+			r.do(action, func() error { return r.helm.ModifyRepository(r.ctx, r.organizationID, repository) })
+		}
+	}
+
+	r.prune(
+		prefix, ResourceHelmRepository, "", r.pruneOptions.HelmRepositories, declared,
+		func(name string) (bool, error) {
+			_, ok := existing[name]
+
+			return ok, nil
+		},
+		func(name string) error {
+			return r.helm.DeleteRepository(r.ctx, r.organizationID, name)
+		},
+	)
+}
+
+func (r *reconciliation) reconcileClusters(manifests []ClusterManifest) {
+	const prefix = ResourceCluster + "/"
+
+	declared := make(map[string]bool, len(manifests))
+
+	for _, manifest := range manifests {
+		key := prefix + manifest.Name
+		declared[manifest.Name] = true
+
+		c, err := r.clusters.GetClusterByName(r.ctx, r.organizationID, manifest.Name)
+		if isNotFound(err) {
+			action := Action{Operation: OperationCreate, ResourceType: ResourceCluster, Name: manifest.Name}
+
+			request, err := clusterRequest(manifest)
+			if err != nil {
+				action.Error = err.Error()
+
+				r.errs = errors.Append(r.errs, errors.WrapIfWithDetails(err, "invalid cluster manifest", "cluster", manifest.Name))
+				r.plan.Actions = append(r.plan.Actions, action)
+
+				continue
+			}
+
+			created := r.do(action, func() error {
+				_, err := r.clusterCreator.CreateClusterFromRequest(r.ctx, r.organizationID, r.userID, request)
+
+				return err
+			})
+			if created {
+				r.inventory[key] = true
+			}
+
+			if len(manifest.NodePools) > 0 || len(manifest.IntegratedServices) > 0 || len(manifest.Releases) > 0 {
+				r.plan.Warnings = append(r.plan.Warnings, fmt.Sprintf("resources of cluster %q are reconciled once the cluster is running", manifest.Name))
+			}
+
+			continue
+		} else if err != nil {
+			r.fail(errors.WrapIfWithDetails(err, "failed to get cluster", "cluster", manifest.Name), key)
+
+			continue
+		}
+
+		r.inventory[key] = r.previous[key]
+
+		if c.Status != pkgCluster.Running {
+			r.plan.Warnings = append(r.plan.Warnings, fmt.Sprintf("cluster %q is %s, its resources are reconciled once it is running", manifest.Name, c.Status))
+			r.keep(key + "/")
+
+			continue
+		}
+
+		r.reconcileNodePools(manifest, c.ID)
+		r.reconcileIntegratedServices(manifest, c.ID)
+		r.reconcileReleases(manifest, c.ID)
+	}
+
+	r.prune(
+		prefix, ResourceCluster, "", r.pruneOptions.Clusters, declared,
+		func(name string) (bool, error) {
+			_, err := r.clusters.GetClusterByName(r.ctx, r.organizationID, name)
+			if isNotFound(err) {
+				return false, nil
+			}
+
+			return err == nil, err
+		},
+		func(name string) error {
+			c, err := r.clusters.GetClusterByName(r.ctx, r.organizationID, name)
+			if err != nil {
+				return err
+			}
+
+			_, err = r.clusterService.DeleteCluster(
+				r.ctx,
+				cluster.Identifier{OrganizationID: r.organizationID, ClusterID: c.ID},
+				cluster.DeleteClusterOptions{},
+			)
+
+			return err
+		},
+	)
+}
+
+func (r *reconciliation) reconcileNodePools(manifest ClusterManifest, clusterID uint) {
+	prefix := ResourceCluster + "/" + manifest.Name + "/" + ResourceNodePool + "/"
+
+	current, err := r.clusterService.ListNodePools(r.ctx, clusterID)
+	if err != nil {
+		r.fail(errors.WrapIfWithDetails(err, "failed to list node pools", "cluster", manifest.Name), prefix)
+
+		return
+	}
+
+	existing := make(map[string]map[string]interface{}, len(current))
+
+	for _, item := range current {
+		var nodePool map[string]interface{}
+		if err := normalize(item, &nodePool); err != nil {
+			r.fail(errors.WrapIfWithDetails(err, "failed to decode node pool", "cluster", manifest.Name), prefix)
+
+			return
+		}
+
+		if name, ok := nodePool["name"].(string); ok {
+			existing[name] = nodePool
+		}
+	}
+
+	declared := make(map[string]bool, len(manifest.NodePools))
+
+	for _, name := range sortedKeys(manifest.NodePools) {
+		key := prefix + name
+		declared[name] = true
+
+		spec := manifest.NodePools[name]
+
+		actual, ok := existing[name]
+		if !ok {
+			nodePool := cluster.NewRawNodePool{"name": name}
+			for k, v := range spec {
+				nodePool[k] = v
+			}
+
+			action := Action{Operation: OperationCreate, ResourceType: ResourceNodePool, Cluster: manifest.Name, Name: name}
+
+			created := r.do(action, func() error {
+				return r.clusterService.CreateNodePools(r.ctx, clusterID, map[string]cluster.NewRawNodePool{name: nodePool})
+			})
+			if created {
+				r.inventory[key] = true
+			}
+
+			continue
+		}
+
+		r.inventory[key] = r.previous[key]
+
+		if changed := changedFields(spec, actual); len(changed) > 0 {
+			action := Action{
+				Operation:    OperationUpdate,
+				ResourceType: ResourceNodePool,
+				Cluster:      manifest.Name,
+				Name:         name,
+				Reason:       "changed: " + strings.Join(changed, ", "),
+			}
+
+			r.do(action, func() error {
+				_, err := r.clusterService.UpdateNodePool(r.ctx, clusterID, name, cluster.RawNodePoolUpdate(spec))
+
+				return err
+			})
+		}
+	}
+
+	r.prune(
+		prefix, ResourceNodePool, manifest.Name, r.pruneOptions.NodePools, declared,
+		func(name string) (bool, error) {
+			_, ok := existing[name]
+
+			return ok, nil
+		},
+		func(name string) error {
+			_, err := r.clusterService.DeleteNodePool(r.ctx, clusterID, name)
+
+			return err
+		},
+	)
+}
+
+func (r *reconciliation) reconcileIntegratedServices(manifest ClusterManifest, clusterID uint) {
+	prefix := ResourceCluster + "/" + manifest.Name + "/" + ResourceIntegratedService + "/"
+
+	current, err := r.integratedServices.List(r.ctx, clusterID)
+	if err != nil {
+		r.fail(errors.WrapIfWithDetails(err, "failed to list integrated services", "cluster", manifest.Name), prefix)
+
+		return
+	}
+
+	existing := make(map[string]integratedservices.IntegratedService, len(current))
+	for _, is := range current {
+		existing[is.Name] = is
+	}
+
+	declared := make(map[string]bool, len(manifest.IntegratedServices))
+
+	for _, name := range sortedKeys(manifest.IntegratedServices) {
+		key := prefix + name
+		declared[name] = true
+
+		spec := manifest.IntegratedServices[name]
+
+		actual, ok := existing[name]
+		if !ok {
+			action := Action{Operation: OperationCreate, ResourceType: ResourceIntegratedService, Cluster: manifest.Name, Name: name}
+
+			if r.do(action, func() error { return r.integratedServices.Activate(r.ctx, clusterID, name, spec) }) {
+				r.inventory[key] = true
+			}
+
+			continue
+		}
+
+		r.inventory[key] = r.previous[key]
+
+		var actualSpec map[string]interface{}
+		if err := normalize(actual.Spec, &actualSpec); err != nil {
+			r.fail(errors.WrapIfWithDetails(err, "failed to decode integrated service spec", "cluster", manifest.Name), key)
+
+			continue
+		}
+
+		if changed := changedFields(spec, actualSpec); len(changed) > 0 {
+			action := Action{
+				Operation:    OperationUpdate,
+				ResourceType: ResourceIntegratedService,
+				Cluster:      manifest.Name,
+				Name:         name,
+				Reason:       "changed: " + strings.Join(changed, ", "),
+			}
+
+			r.do(action, func() error { return r.integratedServices.Update(r.ctx, clusterID, name, spec) })
+		}
+	}
+
+	r.prune(
+		prefix, ResourceIntegratedService, manifest.Name, r.pruneOptions.IntegratedServices, declared,
+		func(name string) (bool, error) {
+			_, ok := existing[name]
+
+			return ok, nil
+		},
+		func(name string) error {
+			return r.integratedServices.Deactivate(r.ctx, clusterID, name)
+		},
+	)
+}
+
+func (r *reconciliation) reconcileReleases(manifest ClusterManifest, clusterID uint) {
+	prefix := ResourceCluster + "/" + manifest.Name + "/" + ResourceRelease + "/"
+
+	current, err := r.helm.ListReleases(r.ctx, r.organizationID, clusterID, helm.ReleaseFilter{}, helm.Options{})
+	if err != nil {
+		r.fail(errors.WrapIfWithDetails(err, "failed to list releases", "cluster", manifest.Name), prefix)
+
+		return
+	}
+
+	existing := make(map[string]helm.Release, len(current))
+	for _, release := range current {
+		existing[release.ReleaseName] = release
+	}
+
+	declared := make(map[string]bool, len(manifest.Releases))
+
+	for _, release := range manifest.Releases {
+		release := release
+		key := prefix + release.ReleaseName
+		declared[release.ReleaseName] = true
+
+		input := helm.Release{
+			ReleaseName: release.ReleaseName,
+			ChartName:   release.ChartName,
+			Namespace:   release.Namespace,
+			Values:      release.Values,
+			Version:     release.Version,
+		}
+		options := helm.Options{Namespace: release.Namespace}
+
+		actual, ok := existing[release.ReleaseName]
+		if !ok {
+			action := Action{Operation: OperationCreate, ResourceType: ResourceRelease, Cluster: manifest.Name, Name: release.ReleaseName}
+
+			installed := r.do(action, func() error {
+				_, err := r.helm.InstallRelease(r.ctx, r.organizationID, clusterID, input, options)
+
+				return err
+			})
+			if installed {
+				r.inventory[key] = true
+			}
+
+			continue
+		}
+
+		r.inventory[key] = r.previous[key]
+
+		var changed []string
+
+		if release.Version != "" && release.Version != actual.Version {
+			changed = append(changed, "version")
+		}
+
+		var actualValues map[string]interface{}
+		if err := normalize(actual.ReleaseInfo.Values, &actualValues); err != nil {
+			r.fail(errors.WrapIfWithDetails(err, "failed to decode release values", "cluster", manifest.Name), key)
+
+			continue
+		}
+
+		for _, field := range changedFields(release.Values, actualValues) {
+			changed = append(changed, "values."+field)
+		}
+
+		if len(changed) > 0 {
+			action := Action{
+				Operation:    OperationUpdate,
+				ResourceType: ResourceRelease,
+				Cluster:      manifest.Name,
+				Name:         release.ReleaseName,
+				Reason:       "changed: " + strings.Join(changed, ", "),
+			}
+
+			r.do(action, func() error {
+				_, err := r.helm.UpgradeRelease(r.ctx, r.organizationID, clusterID, input, options)
+
+				return err
+			})
+		}
+	}
+
+	r.prune(
+		prefix, ResourceRelease, manifest.Name, r.pruneOptions.Releases, declared,
+		func(name string) (bool, error) {
+			_, ok := existing[name]
+
+			return ok, nil
+		},
+		func(name string) error {
+			return r.helm.DeleteRelease(r.ctx, r.organizationID, clusterID, name, helm.Options{Namespace: existing[name].Namespace})
+		},
+	)
+}
+
+// clusterRequest converts a cluster manifest to a cluster creation request.
+func clusterRequest(manifest ClusterManifest) (pkgCluster.CreateClusterRequest, error) {
+	var request pkgCluster.CreateClusterRequest
+
+	if err := normalize(manifest.Spec, &request); err != nil {
+		return request, errors.WrapIf(err, "invalid cluster spec")
+	}
+
+	request.Name = manifest.Name
+
+	return request, nil
+}
+
+// normalize converts a value to another type through its JSON representation.
+func normalize(src interface{}, dst interface{}) error {
+	raw, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, dst)
+}
+
+// changedFields returns the top level fields of desired that differ from actual.
+// Fields that are not present in desired are ignored.
+func changedFields(desired map[string]interface{}, actual map[string]interface{}) []string {
+	var normalized map[string]interface{}
+	if err := normalize(desired, &normalized); err != nil {
+		return fieldNames(desired)
+	}
+
+	var changed []string
+
+	for _, field := range fieldNames(normalized) {
+		if !contains(actual[field], normalized[field]) {
+			changed = append(changed, field)
+		}
+	}
+
+	return changed
+}
+
+// contains checks whether every field of desired is present in actual with the same value.
+func contains(actual interface{}, desired interface{}) bool {
+	desiredMap, ok := desired.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(actual, desired)
+	}
+
+	actualMap, ok := actual.(map[string]interface{})
+	if !ok {
+		return len(desiredMap) == 0 && actual == nil
+	}
+
+	for key, value := range desiredMap {
+		if !contains(actualMap[key], value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedKeys(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func fieldNames(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func isNotFound(err error) bool {
+	var notFoundErr interface {
+		NotFound() bool
+	}
+
+	return errors.As(err, &notFoundErr) && notFoundErr.NotFound()
+}
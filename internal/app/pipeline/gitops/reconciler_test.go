@@ -0,0 +1,216 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/helm"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+type reconcilerMocks struct {
+	store              *MockStore
+	source             *MockSource
+	clusters           *cluster.MockStore
+	clusterCreator     *MockClusterCreator
+	clusterService     *cluster.MockService
+	integratedServices *integratedservices.MockService
+	helm               *MockHelmService
+}
+
+func (m reconcilerMocks) assertExpectations(t *testing.T) {
+	m.store.AssertExpectations(t)
+	m.source.AssertExpectations(t)
+	m.clusters.AssertExpectations(t)
+	m.clusterCreator.AssertExpectations(t)
+	m.clusterService.AssertExpectations(t)
+	m.integratedServices.AssertExpectations(t)
+	m.helm.AssertExpectations(t)
+}
+
+func newTestReconciler(t *testing.T, ctx context.Context) (Reconciler, Repository, reconcilerMocks) {
+	dir := t.TempDir()
+
+	writeManifest(t, dir, "manifests/repositories.yaml", `
+kind: HelmRepository
+name: stable
+url: https://charts.helm.sh/stable
+`)
+	writeManifest(t, dir, "manifests/clusters.yaml", `
+kind: Cluster
+name: prod
+spec:
+  cloud: amazon
+nodePools:
+  pool1:
+    size: 3
+  pool2:
+    size: 1
+integratedServices:
+  dns:
+    clusterDomain: prod.example.com
+releases:
+  - releaseName: ingress
+    chartName: stable/nginx-ingress
+    version: 1.1.0
+---
+kind: Cluster
+name: staging
+spec:
+  cloud: amazon
+  location: eu-west-1
+`)
+
+	repository := Repository{
+		URL:    dir,
+		Path:   "manifests",
+		UserID: 2,
+		Prune:  PruneOptions{HelmRepositories: true},
+		Inventory: []string{
+			"cluster/old",
+			"cluster/prod",
+			"cluster/prod/integratedService/logging",
+			"cluster/prod/nodePool/pool1",
+			"helmRepository/old",
+		},
+	}
+
+	m := reconcilerMocks{
+		store:              new(MockStore),
+		source:             new(MockSource),
+		clusters:           new(cluster.MockStore),
+		clusterCreator:     new(MockClusterCreator),
+		clusterService:     new(cluster.MockService),
+		integratedServices: new(integratedservices.MockService),
+		helm:               new(MockHelmService),
+	}
+
+	m.source.On("Fetch", ctx, uint(1), repository).Return(dir, "abc123", nil)
+
+	m.helm.On("ListRepositories", ctx, uint(1)).Return([]helm.Repository{
+		{Name: "stable", URL: "https://kubernetes-charts.storage.googleapis.com"},
+		{Name: "old", URL: "https://example.com/charts"},
+	}, nil)
+
+	m.clusters.On("GetClusterByName", ctx, uint(1), "prod").Return(cluster.Cluster{ID: 1, Name: "prod", Status: pkgCluster.Running}, nil)
+	m.clusters.On("GetClusterByName", ctx, uint(1), "staging").Return(cluster.Cluster{}, cluster.NotFoundError{OrganizationID: 1, ClusterName: "staging"})
+	m.clusters.On("GetClusterByName", ctx, uint(1), "old").Return(cluster.Cluster{ID: 3, Name: "old", Status: pkgCluster.Running}, nil)
+
+	m.clusterService.On("ListNodePools", ctx, uint(1)).Return(cluster.RawNodePoolList{
+		map[string]interface{}{"name": "pool1", "size": 2, "instanceType": "t2.medium"},
+	}, nil)
+
+	m.integratedServices.On("List", ctx, uint(1)).Return([]integratedservices.IntegratedService{
+		{Name: "dns", Spec: integratedservices.IntegratedServiceSpec{"clusterDomain": "prod.example.com", "provider": "route53"}},
+		{Name: "logging", Spec: integratedservices.IntegratedServiceSpec{}},
+	}, nil)
+
+	m.helm.On("ListReleases", ctx, uint(1), uint(1), helm.ReleaseFilter{}, helm.Options{}).Return([]helm.Release{
+		{ReleaseName: "ingress", ChartName: "stable/nginx-ingress", Version: "1.0.0"},
+	}, nil)
+
+	reconciler := NewReconciler(
+		m.store,
+		m.source,
+		m.clusters,
+		m.clusterCreator,
+		m.clusterService,
+		m.integratedServices,
+		m.helm,
+		NoopLogger{},
+	)
+
+	return reconciler, repository, m
+}
+
+var expectedActions = []Action{
+	{Operation: OperationUpdate, ResourceType: ResourceHelmRepository, Name: "stable", Reason: "repository settings changed"},
+	{Operation: OperationDelete, ResourceType: ResourceHelmRepository, Name: "old", Reason: "no longer declared"},
+	{Operation: OperationUpdate, ResourceType: ResourceNodePool, Cluster: "prod", Name: "pool1", Reason: "changed: size"},
+	{Operation: OperationCreate, ResourceType: ResourceNodePool, Cluster: "prod", Name: "pool2"},
+	{Operation: OperationUpdate, ResourceType: ResourceRelease, Cluster: "prod", Name: "ingress", Reason: "changed: version"},
+	{Operation: OperationCreate, ResourceType: ResourceCluster, Name: "staging"},
+}
+
+var expectedWarnings = []string{
+	`integratedService "logging" is no longer declared, but pruning is disabled`,
+	`cluster "old" is no longer declared, but pruning is disabled`,
+}
+
+func TestReconciler_Reconcile_DryRun(t *testing.T) {
+	ctx := context.Background()
+
+	reconciler, repository, m := newTestReconciler(t, ctx)
+
+	plan, _, err := reconciler.Reconcile(ctx, 1, repository, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc123", plan.Revision)
+	assert.Equal(t, expectedActions, plan.Actions)
+	assert.Equal(t, expectedWarnings, plan.Warnings)
+
+	m.assertExpectations(t)
+}
+
+func TestReconciler_Sync(t *testing.T) {
+	ctx := context.Background()
+
+	reconciler, repository, m := newTestReconciler(t, ctx)
+
+	m.helm.On("ModifyRepository", ctx, uint(1), helm.Repository{Name: "stable", URL: "https://charts.helm.sh/stable"}).Return(nil)
+	m.helm.On("DeleteRepository", ctx, uint(1), "old").Return(nil)
+	m.clusterService.On("UpdateNodePool", ctx, uint(1), "pool1", cluster.RawNodePoolUpdate{"size": float64(3)}).Return("", nil)
+	m.clusterService.On(
+		"CreateNodePools", ctx, uint(1),
+		map[string]cluster.NewRawNodePool{"pool2": {"name": "pool2", "size": float64(1)}},
+	).Return(nil)
+	m.helm.On(
+		"UpgradeRelease", ctx, uint(1), uint(1),
+		helm.Release{ReleaseName: "ingress", ChartName: "stable/nginx-ingress", Version: "1.1.0"},
+		helm.Options{},
+	).Return(helm.Release{}, nil)
+	m.clusterCreator.On(
+		"CreateClusterFromRequest", ctx, uint(1), uint(2),
+		pkgCluster.CreateClusterRequest{Name: "staging", Cloud: "amazon", Location: "eu-west-1"},
+	).Return(uint(4), nil)
+
+	expectedInventory := []string{
+		"cluster/old",
+		"cluster/prod",
+		"cluster/prod/integratedService/logging",
+		"cluster/prod/nodePool/pool1",
+		"cluster/prod/nodePool/pool2",
+		"cluster/staging",
+	}
+
+	m.store.On("SaveSyncStatus", ctx, uint(1), mock.Anything, expectedInventory).Return(nil)
+
+	status, err := reconciler.Sync(ctx, 1, repository)
+	require.NoError(t, err)
+
+	assert.Equal(t, SyncSucceeded, status.Result)
+	assert.Equal(t, "abc123", status.Revision)
+	assert.Equal(t, expectedActions, status.Drift.Actions)
+
+	m.assertExpectations(t)
+}
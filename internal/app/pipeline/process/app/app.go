@@ -16,6 +16,7 @@ package process
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/tracing/opencensus"
@@ -66,11 +67,23 @@ func RegisterApp(
 		kithttp.ServerBefore(correlation.HTTPToContext()),
 	}
 
+	processRouter := router.PathPrefix("/processes").Subrouter()
+
 	processdriver.RegisterHTTPHandlers(
 		endpoints,
-		router.PathPrefix("/processes").Subrouter(),
+		processRouter,
 		kitxhttp.ServerOptions(httpServerOptions),
 	)
 
+	processdriver.RegisterEventStreamHTTPHandlers(
+		service,
+		processRouter,
+		processdriver.StreamConfig{
+			PollInterval:      2 * time.Second,
+			KeepAliveInterval: 15 * time.Second,
+		},
+		errorHandler,
+	)
+
 	return nil
 }
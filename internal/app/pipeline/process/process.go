@@ -16,9 +16,16 @@ package process
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"emperror.dev/errors"
+	"github.com/gofrs/uuid"
 	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
 
 	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
 )
@@ -45,6 +52,91 @@ type Service interface {
 
 	// GetProcess returns a single process.
 	GetProcess(ctx context.Context, id string) (process Process, err error)
+
+	// QueryProcesses lists processes matching a query one page at a time.
+	QueryProcesses(ctx context.Context, query Query) (page ProcessPage, err error)
+
+	// ListProcessEvents lists process events logged after a given event.
+	ListProcessEvents(ctx context.Context, query EventQuery) (events []ProcessEvent, err error)
+}
+
+// Query filters and paginates processes.
+// Processes are ordered by their start time, most recent first.
+type Query struct {
+	OrgID        uint
+	ParentID     string
+	Type         string
+	ResourceID   string
+	ResourceType string
+	Status       []ProcessStatus
+
+	// StartedAfter and StartedBefore restrict the results to processes started in a time range.
+	StartedAfter  *time.Time
+	StartedBefore *time.Time
+
+	// After returns processes following the given cursor.
+	After *Cursor
+
+	// Limit is the maximum number of processes on a page. Zero means no limit.
+	Limit int
+}
+
+// ProcessPage is a page of processes.
+type ProcessPage struct {
+	Processes []Process
+
+	// Next points to the next page (if there is any).
+	Next *Cursor
+}
+
+// Cursor points to a process in an ordered list of processes.
+type Cursor struct {
+	StartedAt time.Time
+	ID        string
+}
+
+// String encodes the cursor into an opaque string.
+func (c Cursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.StartedAt.UTC().Format(time.RFC3339Nano) + "/" + c.ID))
+}
+
+// ParseCursor decodes a cursor encoded by Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	invalidCursor := NewValidationError("invalid cursor", []string{"cursor is malformed"})
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, invalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), "/", 2)
+	if len(parts) != 2 {
+		return Cursor{}, invalidCursor
+	}
+
+	startedAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return Cursor{}, invalidCursor
+	}
+
+	return Cursor{StartedAt: startedAt, ID: parts[1]}, nil
+}
+
+// EventQuery selects process events of an organization or of a single process.
+// Events are ordered by their ID (ie. the order they were logged in).
+type EventQuery struct {
+	OrgID     uint
+	ProcessID string
+
+	// AfterID returns events logged after the event with the given ID.
+	AfterID int32
+
+	// Limit is the maximum number of events returned. Zero means no limit.
+	Limit int
+
+	// Latest returns the most recently logged events (up to Limit) instead of the first ones after AfterID.
+	// Events are returned in the order they were logged either way.
+	Latest bool
 }
 
 // +kit:endpoint:errorStrategy=service
@@ -58,6 +150,9 @@ type WorkflowService interface {
 
 	// SignalProcess sends a signal to a single process.
 	SignalProcess(ctx context.Context, id string, signal string, value interface{}) (err error)
+
+	// RetryProcess resumes a failed or canceled process of an organization from the step that failed.
+	RetryProcess(ctx context.Context, orgID uint, id string) (err error)
 }
 
 // NewService returns a new Service.
@@ -88,6 +183,51 @@ type Store interface {
 
 	// LogProcessEvent adds a process event to a process.
 	LogProcessEvent(ctx context.Context, p ProcessEvent) error
+
+	// QueryProcesses lists processes matching a query.
+	QueryProcesses(ctx context.Context, query Query) ([]Process, error)
+
+	// ListProcessEvents lists process events matching a query.
+	ListProcessEvents(ctx context.Context, query EventQuery) ([]ProcessEvent, error)
+}
+
+// MaxPageSize is the maximum number of processes returned on a single page.
+const MaxPageSize = 1000
+
+// ValidationError is returned when a request is semantically invalid.
+type ValidationError struct {
+	message    string
+	violations []string
+}
+
+// NewValidationError returns a new ValidationError.
+func NewValidationError(message string, violations []string) ValidationError {
+	return ValidationError{
+		message:    message,
+		violations: violations,
+	}
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	return e.message
+}
+
+// Violations returns details of the failed validation.
+func (e ValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (ValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (ValidationError) ServiceError() bool {
+	return true
 }
 
 // AlreadyCompletedError is returned if a process the user is trying to mutate
@@ -120,6 +260,34 @@ func (AlreadyCompletedError) ServiceError() bool {
 	return true
 }
 
+// NotRetryableError is returned if a process cannot be retried.
+type NotRetryableError struct {
+	ID     string
+	Reason string
+}
+
+// Error implements the error interface.
+func (e NotRetryableError) Error() string {
+	return "process cannot be retried: " + e.Reason
+}
+
+// Details returns error details.
+func (e NotRetryableError) Details() []interface{} {
+	return []interface{}{"processId", e.ID}
+}
+
+// Conflict tells a client that this error is related to a conflicting request.
+// Can be used to translate the error to eg. status code.
+func (NotRetryableError) Conflict() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (NotRetryableError) ServiceError() bool {
+	return true
+}
+
 // NotFoundError is returned if a process cannot be found.
 type NotFoundError struct {
 	ID string
@@ -155,6 +323,49 @@ func (s service) GetProcess(ctx context.Context, id string) (Process, error) {
 	return s.store.GetProcess(ctx, id)
 }
 
+func (s service) QueryProcesses(ctx context.Context, query Query) (ProcessPage, error) {
+	var violations []string
+
+	if query.Limit < 0 || query.Limit > MaxPageSize {
+		violations = append(violations, fmt.Sprintf("limit must be between 0 and %d", MaxPageSize))
+	}
+
+	if query.StartedAfter != nil && query.StartedBefore != nil && !query.StartedAfter.Before(*query.StartedBefore) {
+		violations = append(violations, "startedAfter must be earlier than startedBefore")
+	}
+
+	if len(violations) > 0 {
+		return ProcessPage{}, NewValidationError("invalid process query", violations)
+	}
+
+	limit := query.Limit
+
+	// Fetch an extra process to see if there is a next page
+	if limit > 0 {
+		query.Limit++
+	}
+
+	processes, err := s.store.QueryProcesses(ctx, query)
+	if err != nil {
+		return ProcessPage{}, err
+	}
+
+	page := ProcessPage{Processes: processes}
+
+	if limit > 0 && len(processes) > limit {
+		page.Processes = processes[:limit]
+
+		last := page.Processes[limit-1]
+		page.Next = &Cursor{StartedAt: last.StartedAt, ID: last.Id}
+	}
+
+	return page, nil
+}
+
+func (s service) ListProcessEvents(ctx context.Context, query EventQuery) ([]ProcessEvent, error) {
+	return s.store.ListProcessEvents(ctx, query)
+}
+
 func (s service) LogProcess(ctx context.Context, p Process) (Process, error) {
 	return p, s.store.LogProcess(ctx, p)
 }
@@ -166,6 +377,8 @@ func (s service) LogProcessEvent(ctx context.Context, p ProcessEvent) (ProcessEv
 type workflowClient interface {
 	CancelWorkflow(ctx context.Context, workflowID string, runID string) error
 	SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error
+	GetWorkflowHistory(ctx context.Context, workflowID string, runID string, isLongPoll bool, filterType shared.HistoryEventFilterType) client.HistoryEventIterator
+	ResetWorkflow(ctx context.Context, request *shared.ResetWorkflowExecutionRequest) (*shared.ResetWorkflowExecutionResponse, error)
 }
 
 func (s service) CancelProcess(ctx context.Context, id string) error {
@@ -196,3 +409,114 @@ func (s service) SignalProcess(ctx context.Context, id string, signal string, va
 	}
 	return err
 }
+
+func (s service) RetryProcess(ctx context.Context, orgID uint, id string) error {
+	if s.workflowClient == nil {
+		return errors.New("workflow client not available")
+	}
+
+	proc, err := s.store.GetProcess(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Note: processes of other organizations are reported as missing.
+	if uint(proc.OrgId) != orgID {
+		return NotFoundError{ID: id}
+	}
+
+	if proc.Status != pipeline.FAILED && proc.Status != pipeline.CANCELED {
+		return NotRetryableError{ID: id, Reason: "only failed or canceled processes can be retried"}
+	}
+
+	resetEventID, err := s.findResetPoint(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.workflowClient.ResetWorkflow(ctx, &shared.ResetWorkflowExecutionRequest{
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: &id,
+		},
+		Reason:                stringPtr("retried by user"),
+		DecisionFinishEventId: &resetEventID,
+		RequestId:             stringPtr(uuid.Must(uuid.NewV4()).String()),
+	})
+	if errors.As(err, new(*shared.EntityNotExistsError)) {
+		return NotFoundError{ID: id}
+	} else if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to reset workflow", "processId", id)
+	}
+
+	// The workflow does not log its start again, because the recorded history is replayed
+	proc.Status = pipeline.RUNNING
+	proc.FinishedAt = nil
+
+	if err := s.store.LogProcess(ctx, proc); err != nil {
+		return err
+	}
+
+	return s.store.LogProcessEvent(ctx, ProcessEvent{
+		ProcessId: id,
+		Type:      "retry",
+		Log:       "process retried from event " + strconv.FormatInt(resetEventID, 10),
+		Status:    pipeline.RUNNING,
+		Timestamp: time.Now(),
+	})
+}
+
+// findResetPoint returns the decision that started the last failed step (activity or child workflow) of a workflow.
+// Resetting the workflow to this decision schedules the failed step again,
+// while the results of the steps completed before are replayed from the history.
+func (s service) findResetPoint(ctx context.Context, id string) (int64, error) {
+	// Decision completed event IDs of scheduled steps indexed by their scheduled event ID
+	scheduledBy := make(map[int64]int64)
+
+	var resetEventID int64
+
+	iter := s.workflowClient.GetWorkflowHistory(ctx, id, "", false, shared.HistoryEventFilterTypeAllEvent)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if errors.As(err, new(*shared.EntityNotExistsError)) {
+			return 0, NotFoundError{ID: id}
+		} else if err != nil {
+			return 0, errors.WrapIfWithDetails(err, "failed to get workflow history", "processId", id)
+		}
+
+		switch event.GetEventType() {
+		case shared.EventTypeActivityTaskScheduled:
+			scheduledBy[event.GetEventId()] = event.ActivityTaskScheduledEventAttributes.GetDecisionTaskCompletedEventId()
+
+		case shared.EventTypeStartChildWorkflowExecutionInitiated:
+			scheduledBy[event.GetEventId()] = event.StartChildWorkflowExecutionInitiatedEventAttributes.GetDecisionTaskCompletedEventId()
+
+		case shared.EventTypeActivityTaskFailed:
+			resetEventID = scheduledBy[event.ActivityTaskFailedEventAttributes.GetScheduledEventId()]
+
+		case shared.EventTypeActivityTaskTimedOut:
+			resetEventID = scheduledBy[event.ActivityTaskTimedOutEventAttributes.GetScheduledEventId()]
+
+		case shared.EventTypeActivityTaskCanceled:
+			resetEventID = scheduledBy[event.ActivityTaskCanceledEventAttributes.GetScheduledEventId()]
+
+		case shared.EventTypeChildWorkflowExecutionFailed:
+			resetEventID = scheduledBy[event.ChildWorkflowExecutionFailedEventAttributes.GetInitiatedEventId()]
+
+		case shared.EventTypeChildWorkflowExecutionTimedOut:
+			resetEventID = scheduledBy[event.ChildWorkflowExecutionTimedOutEventAttributes.GetInitiatedEventId()]
+
+		case shared.EventTypeChildWorkflowExecutionCanceled:
+			resetEventID = scheduledBy[event.ChildWorkflowExecutionCanceledEventAttributes.GetInitiatedEventId()]
+		}
+	}
+
+	if resetEventID == 0 {
+		return 0, NotRetryableError{ID: id, Reason: "no failed step found in the workflow history"}
+	}
+
+	return resetEventID, nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,179 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
+)
+
+type inmemoryStore struct {
+	processes map[string]Process
+	events    []ProcessEvent
+}
+
+func (s *inmemoryStore) ListProcesses(ctx context.Context, query Process) ([]Process, error) {
+	return nil, nil
+}
+
+func (s *inmemoryStore) LogProcess(ctx context.Context, p Process) error {
+	s.processes[p.Id] = p
+
+	return nil
+}
+
+func (s *inmemoryStore) GetProcess(ctx context.Context, id string) (Process, error) {
+	p, ok := s.processes[id]
+	if !ok {
+		return Process{}, NotFoundError{ID: id}
+	}
+
+	return p, nil
+}
+
+func (s *inmemoryStore) LogProcessEvent(ctx context.Context, p ProcessEvent) error {
+	s.events = append(s.events, p)
+
+	return nil
+}
+
+func (s *inmemoryStore) QueryProcesses(ctx context.Context, query Query) ([]Process, error) {
+	return nil, nil
+}
+
+func (s *inmemoryStore) ListProcessEvents(ctx context.Context, query EventQuery) ([]ProcessEvent, error) {
+	return nil, nil
+}
+
+type historyWorkflowClient struct {
+	history []*shared.HistoryEvent
+
+	resetRequest *shared.ResetWorkflowExecutionRequest
+}
+
+func (c *historyWorkflowClient) CancelWorkflow(ctx context.Context, workflowID string, runID string) error {
+	return nil
+}
+
+func (c *historyWorkflowClient) SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error {
+	return nil
+}
+
+func (c *historyWorkflowClient) GetWorkflowHistory(ctx context.Context, workflowID string, runID string, isLongPoll bool, filterType shared.HistoryEventFilterType) client.HistoryEventIterator {
+	return &historyIterator{events: c.history}
+}
+
+func (c *historyWorkflowClient) ResetWorkflow(ctx context.Context, request *shared.ResetWorkflowExecutionRequest) (*shared.ResetWorkflowExecutionResponse, error) {
+	c.resetRequest = request
+
+	return &shared.ResetWorkflowExecutionResponse{}, nil
+}
+
+type historyIterator struct {
+	events []*shared.HistoryEvent
+}
+
+func (i *historyIterator) HasNext() bool {
+	return len(i.events) > 0
+}
+
+func (i *historyIterator) Next() (*shared.HistoryEvent, error) {
+	event := i.events[0]
+	i.events = i.events[1:]
+
+	return event, nil
+}
+
+func historyEvent(id int64, eventType shared.EventType) *shared.HistoryEvent {
+	return &shared.HistoryEvent{EventId: &id, EventType: &eventType}
+}
+
+func activityScheduled(id int64, decisionID int64) *shared.HistoryEvent {
+	event := historyEvent(id, shared.EventTypeActivityTaskScheduled)
+	event.ActivityTaskScheduledEventAttributes = &shared.ActivityTaskScheduledEventAttributes{DecisionTaskCompletedEventId: &decisionID}
+
+	return event
+}
+
+func activityFailed(id int64, scheduledID int64) *shared.HistoryEvent {
+	event := historyEvent(id, shared.EventTypeActivityTaskFailed)
+	event.ActivityTaskFailedEventAttributes = &shared.ActivityTaskFailedEventAttributes{ScheduledEventId: &scheduledID}
+
+	return event
+}
+
+func TestService_RetryProcess(t *testing.T) {
+	store := &inmemoryStore{
+		processes: map[string]Process{
+			"failed":  {Id: "failed", OrgId: 1, Status: pipeline.FAILED},
+			"running": {Id: "running", OrgId: 1, Status: pipeline.RUNNING},
+		},
+	}
+
+	workflowClient := &historyWorkflowClient{
+		history: []*shared.HistoryEvent{
+			historyEvent(1, shared.EventTypeWorkflowExecutionStarted),
+			historyEvent(4, shared.EventTypeDecisionTaskCompleted),
+			activityScheduled(5, 4),
+			historyEvent(7, shared.EventTypeActivityTaskCompleted),
+			historyEvent(10, shared.EventTypeDecisionTaskCompleted),
+			activityScheduled(11, 10),
+			activityFailed(13, 11),
+			historyEvent(16, shared.EventTypeDecisionTaskCompleted),
+			historyEvent(17, shared.EventTypeWorkflowExecutionFailed),
+		},
+	}
+
+	service := NewWorkflowService(store, workflowClient)
+
+	t.Run("Failed", func(t *testing.T) {
+		err := service.RetryProcess(context.Background(), 1, "failed")
+		require.NoError(t, err)
+
+		require.NotNil(t, workflowClient.resetRequest)
+		assert.Equal(t, int64(10), workflowClient.resetRequest.GetDecisionFinishEventId())
+		assert.Equal(t, "failed", workflowClient.resetRequest.GetWorkflowExecution().GetWorkflowId())
+
+		assert.Equal(t, pipeline.RUNNING, store.processes["failed"].Status)
+		require.Len(t, store.events, 1)
+		assert.Equal(t, "retry", store.events[0].Type)
+	})
+
+	t.Run("Running", func(t *testing.T) {
+		err := service.RetryProcess(context.Background(), 1, "running")
+
+		assert.True(t, errors.As(err, &NotRetryableError{}))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := service.RetryProcess(context.Background(), 1, "unknown")
+
+		assert.True(t, errors.As(err, &NotFoundError{}))
+	})
+
+	t.Run("OtherOrganization", func(t *testing.T) {
+		err := service.RetryProcess(context.Background(), 2, "failed")
+
+		assert.True(t, errors.As(err, &NotFoundError{}))
+	})
+}
@@ -16,6 +16,7 @@ package processadapter
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"emperror.dev/errors"
@@ -222,3 +223,142 @@ func (s *GormStore) LogProcessEvent(ctx context.Context, p process.ProcessEvent)
 	err := s.db.Create(&pem).Error
 	return errors.Wrap(err, "failed to create process event")
 }
+
+// QueryProcesses returns the list of processes matching a query.
+func (s *GormStore) QueryProcesses(ctx context.Context, query process.Query) ([]process.Process, error) {
+	db := s.db.Where("org_id = ?", query.OrgID)
+
+	if query.ParentID != "" {
+		db = db.Where("parent_id = ?", query.ParentID)
+	}
+
+	if query.Type != "" {
+		db = db.Where("type = ?", query.Type)
+	}
+
+	if query.ResourceID != "" {
+		db = db.Where("resource_id = ?", query.ResourceID)
+	}
+
+	if query.ResourceType != "" {
+		db = db.Where("resource_type = ?", query.ResourceType)
+	}
+
+	if len(query.Status) > 0 {
+		statuses := make([]string, 0, len(query.Status))
+		for _, status := range query.Status {
+			statuses = append(statuses, string(status))
+		}
+
+		db = db.Where("status IN (?)", statuses)
+	}
+
+	if query.StartedAfter != nil {
+		db = db.Where("started_at >= ?", *query.StartedAfter)
+	}
+
+	if query.StartedBefore != nil {
+		db = db.Where("started_at < ?", *query.StartedBefore)
+	}
+
+	if query.After != nil {
+		db = db.Where(
+			"started_at < ? OR (started_at = ? AND id < ?)",
+			query.After.StartedAt, query.After.StartedAt, query.After.ID,
+		)
+	}
+
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	var processes []processModel
+
+	err := db.
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Order("started_at DESC, id DESC").
+		Find(&processes).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find processes")
+	}
+
+	result := make([]process.Process, 0, len(processes))
+
+	for _, pm := range processes {
+		p := process.Process{
+			Id:           pm.ID,
+			ParentId:     pm.ParentID,
+			OrgId:        int32(pm.OrgID),
+			Log:          pm.Log,
+			StartedAt:    pm.StartedAt,
+			FinishedAt:   pm.FinishedAt,
+			ResourceId:   pm.ResourceID,
+			ResourceType: pm.ResourceType,
+			Type:         pm.Type,
+			Status:       process.ProcessStatus(pm.Status),
+		}
+
+		for _, em := range pm.Events {
+			p.Events = append(p.Events, toProcessEvent(em))
+		}
+
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
+// ListProcessEvents returns the list of process events matching a query.
+func (s *GormStore) ListProcessEvents(ctx context.Context, query process.EventQuery) ([]process.ProcessEvent, error) {
+	db := s.db.
+		Select(fmt.Sprintf("%s.*", processEventTableName)).
+		Joins(fmt.Sprintf("JOIN %s ON %s.id = %s.process_id", processTableName, processTableName, processEventTableName)).
+		Where(fmt.Sprintf("%s.org_id = ?", processTableName), query.OrgID).
+		Where(fmt.Sprintf("%s.id > ?", processEventTableName), query.AfterID)
+
+	if query.ProcessID != "" {
+		db = db.Where(fmt.Sprintf("%s.process_id = ?", processEventTableName), query.ProcessID)
+	}
+
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	order := fmt.Sprintf("%s.id", processEventTableName)
+	if query.Latest {
+		order += " DESC"
+	}
+
+	var events []processEventModel
+
+	err := db.Order(order).Find(&events).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find process events")
+	}
+
+	result := make([]process.ProcessEvent, 0, len(events))
+
+	for _, em := range events {
+		result = append(result, toProcessEvent(em))
+	}
+
+	if query.Latest {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+
+	return result, nil
+}
+
+func toProcessEvent(em processEventModel) process.ProcessEvent {
+	return process.ProcessEvent{
+		Id:        int32(em.ID),
+		ProcessId: em.ProcessID,
+		Type:      em.Type,
+		Log:       em.Log,
+		Status:    process.ProcessStatus(em.Status),
+		Timestamp: em.Timestamp,
+	}
+}
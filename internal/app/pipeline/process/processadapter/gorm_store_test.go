@@ -0,0 +1,124 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	//  SQLite driver used for integration test
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	// SQLite does not support adding foreign keys to existing tables
+	err = db.AutoMigrate(&processModel{}, &processEventModel{}).Error
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore_QueryProcesses(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	start := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	processes := []process.Process{
+		{Id: "a", OrgId: 1, Type: "cluster-create", ResourceId: "1", ResourceType: "cluster", Status: pipeline.FINISHED, StartedAt: start},
+		{Id: "b", OrgId: 1, Type: "cluster-update", ResourceId: "1", ResourceType: "cluster", Status: pipeline.FAILED, StartedAt: start.Add(time.Minute)},
+		{Id: "c", OrgId: 1, Type: "cluster-update", ResourceId: "2", ResourceType: "cluster", Status: pipeline.RUNNING, StartedAt: start.Add(time.Minute)},
+		{Id: "d", OrgId: 1, Type: "helm-install", ResourceId: "2", ResourceType: "release", Status: pipeline.RUNNING, StartedAt: start.Add(2 * time.Minute)},
+		{Id: "e", OrgId: 2, Type: "cluster-create", ResourceId: "3", ResourceType: "cluster", Status: pipeline.RUNNING, StartedAt: start},
+	}
+
+	for _, p := range processes {
+		require.NoError(t, store.LogProcess(ctx, p))
+	}
+
+	require.NoError(t, store.LogProcessEvent(ctx, process.ProcessEvent{ProcessId: "b", Type: "create-node-pool", Status: pipeline.FAILED, Timestamp: start}))
+
+	ids := func(processes []process.Process) []string {
+		var ids []string
+		for _, p := range processes {
+			ids = append(ids, p.Id)
+		}
+
+		return ids
+	}
+
+	result, err := store.QueryProcesses(ctx, process.Query{OrgID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d", "c", "b", "a"}, ids(result))
+	assert.Len(t, result[2].Events, 1)
+
+	result, err = store.QueryProcesses(ctx, process.Query{OrgID: 1, ResourceType: "cluster", Status: []process.ProcessStatus{pipeline.RUNNING, pipeline.FAILED}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, ids(result))
+
+	startedBefore := start.Add(2 * time.Minute)
+	result, err = store.QueryProcesses(ctx, process.Query{OrgID: 1, StartedAfter: &start, StartedBefore: &startedBefore, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, ids(result))
+
+	result, err = store.QueryProcesses(ctx, process.Query{OrgID: 1, After: &process.Cursor{StartedAt: result[0].StartedAt, ID: result[0].Id}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, ids(result))
+}
+
+func TestGormStore_ListProcessEvents(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	require.NoError(t, store.LogProcess(ctx, process.Process{Id: "a", OrgId: 1, Type: "cluster-create", ResourceId: "1", ResourceType: "cluster", Status: pipeline.RUNNING, StartedAt: time.Now()}))
+	require.NoError(t, store.LogProcess(ctx, process.Process{Id: "b", OrgId: 2, Type: "cluster-create", ResourceId: "2", ResourceType: "cluster", Status: pipeline.RUNNING, StartedAt: time.Now()}))
+
+	for _, id := range []string{"a", "b", "a"} {
+		require.NoError(t, store.LogProcessEvent(ctx, process.ProcessEvent{ProcessId: id, Type: "step", Status: pipeline.RUNNING, Timestamp: time.Now()}))
+	}
+
+	events, err := store.ListProcessEvents(ctx, process.EventQuery{OrgID: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int32(1), events[0].Id)
+	assert.Equal(t, int32(3), events[1].Id)
+
+	events, err = store.ListProcessEvents(ctx, process.EventQuery{OrgID: 1, AfterID: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int32(3), events[0].Id)
+
+	events, err = store.ListProcessEvents(ctx, process.EventQuery{OrgID: 1, ProcessID: "b"})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = store.ListProcessEvents(ctx, process.EventQuery{OrgID: 1, Limit: 1, Latest: true})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int32(3), events[0].Id)
+}
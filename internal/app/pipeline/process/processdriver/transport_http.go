@@ -17,6 +17,9 @@ package processdriver
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"emperror.dev/errors"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -34,9 +37,16 @@ func RegisterHTTPHandlers(endpoints WorkflowEndpoints, router *mux.Router, optio
 	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
 
 	router.Methods(http.MethodGet).Path("").Handler(kithttp.NewServer(
-		endpoints.ListProcesses,
-		decodeListProcessesHTTPRequest,
-		kitxhttp.ErrorResponseEncoder(encodeListProcessesHTTPResponse, errorEncoder),
+		endpoints.QueryProcesses,
+		decodeQueryProcessesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeQueryProcessesHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/events").Handler(kithttp.NewServer(
+		endpoints.ListProcessEvents,
+		decodeListProcessEventsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListProcessEventsHTTPResponse, errorEncoder),
 		options...,
 	))
 
@@ -53,12 +63,32 @@ func RegisterHTTPHandlers(endpoints WorkflowEndpoints, router *mux.Router, optio
 		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusAccepted), errorEncoder),
 		options...,
 	))
+
+	router.Methods(http.MethodPost).Path("/{id}/retry").Handler(kithttp.NewServer(
+		endpoints.RetryProcess,
+		decodeRetryProcessHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusAccepted), errorEncoder),
+		options...,
+	))
 }
 
-func encodeListProcessesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	resp := response.(ListProcessesWorkflowResponse)
+// NextCursorHeader contains the cursor pointing to the next page of processes.
+const NextCursorHeader = "X-Next-Cursor"
 
-	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Processes)
+func encodeQueryProcessesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(QueryProcessesWorkflowResponse)
+
+	if resp.Page.Next != nil {
+		w.Header().Set(NextCursorHeader, resp.Page.Next.String())
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Page.Processes)
+}
+
+func encodeListProcessEventsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListProcessEventsWorkflowResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Events)
 }
 
 func decodeGetProcessHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -83,36 +113,139 @@ func decodeCancelProcessHTTPRequest(_ context.Context, r *http.Request) (interfa
 	return CancelProcessWorkflowRequest{Id: id}, nil
 }
 
+func decodeRetryProcessHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+
+	id, ok := vars["id"]
+	if !ok || id == "" {
+		return nil, errors.NewWithDetails("missing parameter from the URL", "param", "id")
+	}
+
+	return RetryProcessWorkflowRequest{OrgID: auth.GetCurrentOrganization(r).ID, Id: id}, nil
+}
+
 func encodeGetProcessHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
 	resp := response.(GetProcessWorkflowResponse)
 
 	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Process)
 }
 
-func decodeListProcessesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+func decodeQueryProcessesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	org := auth.GetCurrentOrganization(r)
+
+	query := process.Query{
+		OrgID: org.ID,
+	}
+
+	values := r.URL.Query()
+
+	query.Type = values.Get("type")
+	query.ResourceID = values.Get("resourceId")
+	query.ResourceType = values.Get("resourceType")
+	query.ParentID = values.Get("parentId")
+
+	for _, status := range values["status"] {
+		for _, s := range strings.Split(status, ",") {
+			query.Status = append(query.Status, pipeline.ProcessStatus(s))
+		}
+	}
+
+	var violations []string
+
+	if v := values.Get("startedAfter"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			violations = append(violations, "startedAfter must be an RFC3339 timestamp")
+		}
+
+		query.StartedAfter = &t
+	}
+
+	if v := values.Get("startedBefore"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			violations = append(violations, "startedBefore must be an RFC3339 timestamp")
+		}
+
+		query.StartedBefore = &t
+	}
+
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			violations = append(violations, "limit must be a number")
+		}
+
+		query.Limit = limit
+	}
+
+	if len(violations) > 0 {
+		return nil, process.NewValidationError("invalid process query", violations)
+	}
+
+	if v := values.Get("cursor"); v != "" {
+		cursor, err := process.ParseCursor(v)
+		if err != nil {
+			return nil, err
+		}
+
+		query.After = &cursor
+	}
+
+	return QueryProcessesWorkflowRequest{Query: query}, nil
+}
+
+func decodeListProcessEventsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	org := auth.GetCurrentOrganization(r)
 
-	query := process.Process{
-		OrgId: int32(org.ID),
+	query, err := decodeEventQuery(r)
+	if err != nil {
+		return nil, err
 	}
 
+	query.OrgID = org.ID
+
+	return ListProcessEventsWorkflowRequest{Query: query}, nil
+}
+
+// decodeEventQuery decodes the common process event query parameters.
+func decodeEventQuery(r *http.Request) (process.EventQuery, error) {
 	values := r.URL.Query()
 
-	if rt := values["type"]; len(rt) > 0 {
-		query.Type = rt[0]
+	query := process.EventQuery{
+		ProcessID: values.Get("processId"),
 	}
 
-	if rt := values["resourceId"]; len(rt) > 0 {
-		query.ResourceId = rt[0]
+	var violations []string
+
+	// A resumed Server-Sent Events stream continues after the last received event,
+	// even if the stream was originally requested from an earlier one.
+	after := r.Header.Get("Last-Event-ID")
+	if after == "" {
+		after = values.Get("after")
 	}
 
-	if rt := values["parentId"]; len(rt) > 0 {
-		query.ParentId = rt[0]
+	if after != "" {
+		id, err := strconv.ParseInt(after, 10, 32)
+		if err != nil {
+			violations = append(violations, "after must be a process event ID")
+		}
+
+		query.AfterID = int32(id)
+	}
+
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			violations = append(violations, "limit must be a positive number")
+		}
+
+		query.Limit = limit
 	}
 
-	if rt := values["status"]; len(rt) > 0 {
-		query.Status = pipeline.ProcessStatus(rt[0])
+	if len(violations) > 0 {
+		return process.EventQuery{}, process.NewValidationError("invalid process event query", violations)
 	}
 
-	return ListProcessesWorkflowRequest{Query: query}, nil
+	return query, nil
 }
@@ -0,0 +1,270 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processdriver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// streamPageSize is the maximum number of events loaded at once.
+const streamPageSize = 100
+
+// Server-Sent Events types.
+const (
+	eventTypeProcessEvent = "process-event"
+	eventTypeDone         = "done"
+)
+
+// StreamConfig configures process event streams.
+type StreamConfig struct {
+	// PollInterval is the time between checking for new process events.
+	PollInterval time.Duration
+
+	// KeepAliveInterval is the time between keep-alive comments sent on idle streams.
+	KeepAliveInterval time.Duration
+}
+
+// RegisterEventStreamHTTPHandlers mounts process event streams into an http.Handler.
+//
+// Events are streamed as Server-Sent Events. Clients can resume a stream using the Last-Event-ID header.
+// Streams of a single process end once the process is no longer running.
+func RegisterEventStreamHTTPHandlers(
+	service process.Service,
+	router *mux.Router,
+	config StreamConfig,
+	errorHandler process.ErrorHandler,
+) {
+	stream := eventStream{
+		service:      service,
+		config:       config,
+		errorHandler: errorHandler,
+		errorEncoder: kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter()),
+	}
+
+	router.Methods(http.MethodGet).Path("/events/stream").HandlerFunc(stream.serveOrganization)
+	router.Methods(http.MethodGet).Path("/{id}/events/stream").HandlerFunc(stream.serveProcess)
+}
+
+type eventStream struct {
+	service      process.Service
+	config       StreamConfig
+	errorHandler process.ErrorHandler
+	errorEncoder func(ctx context.Context, err error, w http.ResponseWriter)
+}
+
+func (s eventStream) serveOrganization(w http.ResponseWriter, r *http.Request) {
+	query, err := decodeEventQuery(r)
+	if err != nil {
+		s.errorEncoder(r.Context(), err, w)
+
+		return
+	}
+
+	query.OrgID = auth.GetCurrentOrganization(r).ID
+
+	// Unless resumed, organization streams start with the events logged after connecting
+	if r.Header.Get("Last-Event-ID") == "" && r.URL.Query().Get("after") == "" {
+		latest := query
+		latest.Limit = 1
+		latest.Latest = true
+
+		events, err := s.service.ListProcessEvents(r.Context(), latest)
+		if err != nil {
+			s.errorEncoder(r.Context(), err, w)
+
+			return
+		}
+
+		if len(events) > 0 {
+			query.AfterID = events[0].Id
+		}
+	}
+
+	s.serve(w, r, query, nil)
+}
+
+func (s eventStream) serveProcess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query, err := decodeEventQuery(r)
+	if err != nil {
+		s.errorEncoder(ctx, err, w)
+
+		return
+	}
+
+	query.OrgID = auth.GetCurrentOrganization(r).ID
+	query.ProcessID = mux.Vars(r)["id"]
+
+	// Make sure the process exists and belongs to the organization
+	proc, err := s.service.GetProcess(ctx, query.ProcessID)
+	if err == nil && uint(proc.OrgId) != query.OrgID {
+		err = process.NotFoundError{ID: query.ProcessID}
+	}
+	if err != nil {
+		s.errorEncoder(ctx, err, w)
+
+		return
+	}
+
+	s.serve(w, r, query, func() (bool, error) {
+		proc, err := s.service.GetProcess(ctx, query.ProcessID)
+		if err != nil {
+			return false, err
+		}
+
+		return proc.Status != pipeline.RUNNING, nil
+	})
+}
+
+// serve streams the events matching a query until the client disconnects or the stream is done.
+func (s eventStream) serve(w http.ResponseWriter, r *http.Request, query process.EventQuery, done func() (bool, error)) {
+	ctx := r.Context()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.errorEncoder(ctx, errors.New("streaming is not supported"), w)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	if query.Limit <= 0 || query.Limit > streamPageSize {
+		query.Limit = streamPageSize
+	}
+
+	poll := time.NewTicker(s.config.PollInterval)
+	defer poll.Stop()
+
+	lastWrite := time.Now()
+
+	// sendNextPage writes the next page of events and returns them
+	sendNextPage := func() ([]process.ProcessEvent, bool) {
+		events, err := s.service.ListProcessEvents(ctx, query)
+		if err != nil {
+			s.errorHandler.HandleContext(ctx, err)
+
+			return nil, false
+		}
+
+		for _, event := range events {
+			if err := writeEvent(w, fmt.Sprint(event.Id), eventTypeProcessEvent, event); err != nil {
+				return nil, false
+			}
+
+			query.AfterID = event.Id
+		}
+
+		return events, true
+	}
+
+	for {
+		events, ok := sendNextPage()
+		if !ok {
+			return
+		}
+
+		if len(events) == 0 && done != nil {
+			finished, err := done()
+			if err != nil {
+				s.errorHandler.HandleContext(ctx, err)
+
+				return
+			}
+
+			if finished {
+				// Send the events logged between the last page and the status change
+				for {
+					events, ok := sendNextPage()
+					if !ok {
+						return
+					}
+
+					if len(events) < query.Limit {
+						break
+					}
+				}
+
+				_ = writeEvent(w, "", eventTypeDone, struct{}{})
+				flusher.Flush()
+
+				return
+			}
+		}
+
+		if len(events) > 0 {
+			lastWrite = time.Now()
+		} else if time.Since(lastWrite) >= s.config.KeepAliveInterval {
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+
+			lastWrite = time.Now()
+		}
+
+		flusher.Flush()
+
+		// Load the next page right away while replaying past events
+		if len(events) == query.Limit {
+			if ctx.Err() != nil {
+				return
+			}
+
+			continue
+		}
+
+		select {
+		case <-poll.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, id string, eventType string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, body)
+
+	return err
+}
@@ -28,12 +28,15 @@ type serviceError interface {
 // meant to be used as a helper struct, to collect all of the endpoints into a
 // single parameter.
 type WorkflowEndpoints struct {
-	CancelProcess   endpoint.Endpoint
-	GetProcess      endpoint.Endpoint
-	ListProcesses   endpoint.Endpoint
-	LogProcess      endpoint.Endpoint
-	LogProcessEvent endpoint.Endpoint
-	SignalProcess   endpoint.Endpoint
+	CancelProcess     endpoint.Endpoint
+	GetProcess        endpoint.Endpoint
+	ListProcessEvents endpoint.Endpoint
+	ListProcesses     endpoint.Endpoint
+	LogProcess        endpoint.Endpoint
+	LogProcessEvent   endpoint.Endpoint
+	QueryProcesses    endpoint.Endpoint
+	RetryProcess      endpoint.Endpoint
+	SignalProcess     endpoint.Endpoint
 }
 
 // MakeWorkflowEndpoints returns a(n) WorkflowEndpoints struct where each endpoint invokes
@@ -42,12 +45,15 @@ func MakeWorkflowEndpoints(service process.WorkflowService, middleware ...endpoi
 	mw := kitxendpoint.Combine(middleware...)
 
 	return WorkflowEndpoints{
-		CancelProcess:   kitxendpoint.OperationNameMiddleware("process.Workflow.CancelProcess")(mw(MakeCancelProcessWorkflowEndpoint(service))),
-		GetProcess:      kitxendpoint.OperationNameMiddleware("process.Workflow.GetProcess")(mw(MakeGetProcessWorkflowEndpoint(service))),
-		ListProcesses:   kitxendpoint.OperationNameMiddleware("process.Workflow.ListProcesses")(mw(MakeListProcessesWorkflowEndpoint(service))),
-		LogProcess:      kitxendpoint.OperationNameMiddleware("process.Workflow.LogProcess")(mw(MakeLogProcessWorkflowEndpoint(service))),
-		LogProcessEvent: kitxendpoint.OperationNameMiddleware("process.Workflow.LogProcessEvent")(mw(MakeLogProcessEventWorkflowEndpoint(service))),
-		SignalProcess:   kitxendpoint.OperationNameMiddleware("process.Workflow.SignalProcess")(mw(MakeSignalProcessWorkflowEndpoint(service))),
+		CancelProcess:     kitxendpoint.OperationNameMiddleware("process.Workflow.CancelProcess")(mw(MakeCancelProcessWorkflowEndpoint(service))),
+		GetProcess:        kitxendpoint.OperationNameMiddleware("process.Workflow.GetProcess")(mw(MakeGetProcessWorkflowEndpoint(service))),
+		ListProcessEvents: kitxendpoint.OperationNameMiddleware("process.Workflow.ListProcessEvents")(mw(MakeListProcessEventsWorkflowEndpoint(service))),
+		ListProcesses:     kitxendpoint.OperationNameMiddleware("process.Workflow.ListProcesses")(mw(MakeListProcessesWorkflowEndpoint(service))),
+		LogProcess:        kitxendpoint.OperationNameMiddleware("process.Workflow.LogProcess")(mw(MakeLogProcessWorkflowEndpoint(service))),
+		LogProcessEvent:   kitxendpoint.OperationNameMiddleware("process.Workflow.LogProcessEvent")(mw(MakeLogProcessEventWorkflowEndpoint(service))),
+		QueryProcesses:    kitxendpoint.OperationNameMiddleware("process.Workflow.QueryProcesses")(mw(MakeQueryProcessesWorkflowEndpoint(service))),
+		RetryProcess:      kitxendpoint.OperationNameMiddleware("process.Workflow.RetryProcess")(mw(MakeRetryProcessWorkflowEndpoint(service))),
+		SignalProcess:     kitxendpoint.OperationNameMiddleware("process.Workflow.SignalProcess")(mw(MakeSignalProcessWorkflowEndpoint(service))),
 	}
 }
 
@@ -124,6 +130,46 @@ func MakeGetProcessWorkflowEndpoint(service process.WorkflowService) endpoint.En
 	}
 }
 
+// ListProcessEventsWorkflowRequest is a request struct for ListProcessEvents endpoint.
+type ListProcessEventsWorkflowRequest struct {
+	Query process.EventQuery
+}
+
+// ListProcessEventsWorkflowResponse is a response struct for ListProcessEvents endpoint.
+type ListProcessEventsWorkflowResponse struct {
+	Events []pipeline.ProcessEvent
+	Err    error
+}
+
+func (r ListProcessEventsWorkflowResponse) Failed() error {
+	return r.Err
+}
+
+// MakeListProcessEventsWorkflowEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeListProcessEventsWorkflowEndpoint(service process.WorkflowService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListProcessEventsWorkflowRequest)
+
+		events, err := service.ListProcessEvents(ctx, req.Query)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return ListProcessEventsWorkflowResponse{
+					Err:    err,
+					Events: events,
+				}, nil
+			}
+
+			return ListProcessEventsWorkflowResponse{
+				Err:    err,
+				Events: events,
+			}, err
+		}
+
+		return ListProcessEventsWorkflowResponse{Events: events}, nil
+	}
+}
+
 // ListProcessesWorkflowRequest is a request struct for ListProcesses endpoint.
 type ListProcessesWorkflowRequest struct {
 	Query pipeline.Process
@@ -244,6 +290,80 @@ func MakeLogProcessEventWorkflowEndpoint(service process.WorkflowService) endpoi
 	}
 }
 
+// QueryProcessesWorkflowRequest is a request struct for QueryProcesses endpoint.
+type QueryProcessesWorkflowRequest struct {
+	Query process.Query
+}
+
+// QueryProcessesWorkflowResponse is a response struct for QueryProcesses endpoint.
+type QueryProcessesWorkflowResponse struct {
+	Page process.ProcessPage
+	Err  error
+}
+
+func (r QueryProcessesWorkflowResponse) Failed() error {
+	return r.Err
+}
+
+// MakeQueryProcessesWorkflowEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeQueryProcessesWorkflowEndpoint(service process.WorkflowService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(QueryProcessesWorkflowRequest)
+
+		page, err := service.QueryProcesses(ctx, req.Query)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return QueryProcessesWorkflowResponse{
+					Err:  err,
+					Page: page,
+				}, nil
+			}
+
+			return QueryProcessesWorkflowResponse{
+				Err:  err,
+				Page: page,
+			}, err
+		}
+
+		return QueryProcessesWorkflowResponse{Page: page}, nil
+	}
+}
+
+// RetryProcessWorkflowRequest is a request struct for RetryProcess endpoint.
+type RetryProcessWorkflowRequest struct {
+	OrgID uint
+	Id    string
+}
+
+// RetryProcessWorkflowResponse is a response struct for RetryProcess endpoint.
+type RetryProcessWorkflowResponse struct {
+	Err error
+}
+
+func (r RetryProcessWorkflowResponse) Failed() error {
+	return r.Err
+}
+
+// MakeRetryProcessWorkflowEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeRetryProcessWorkflowEndpoint(service process.WorkflowService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RetryProcessWorkflowRequest)
+
+		err := service.RetryProcess(ctx, req.OrgID, req.Id)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return RetryProcessWorkflowResponse{Err: err}, nil
+			}
+
+			return RetryProcessWorkflowResponse{Err: err}, err
+		}
+
+		return RetryProcessWorkflowResponse{}, nil
+	}
+}
+
 // SignalProcessWorkflowRequest is a request struct for SignalProcess endpoint.
 type SignalProcessWorkflowRequest struct {
 	Id     string
@@ -51,6 +51,29 @@ func (_m *MockWorkflowService) GetProcess(ctx context.Context, id string) (proce
 	return r0, r1
 }
 
+// ListProcessEvents provides a mock function.
+func (_m *MockWorkflowService) ListProcessEvents(ctx context.Context, query EventQuery) (events []pipeline.ProcessEvent, err error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []pipeline.ProcessEvent
+	if rf, ok := ret.Get(0).(func(context.Context, EventQuery) []pipeline.ProcessEvent); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.ProcessEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, EventQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListProcesses provides a mock function.
 func (_m *MockWorkflowService) ListProcesses(ctx context.Context, query pipeline.Process) (processes []pipeline.Process, err error) {
 	ret := _m.Called(ctx, query)
@@ -116,6 +139,41 @@ func (_m *MockWorkflowService) LogProcessEvent(ctx context.Context, proc pipelin
 	return r0, r1
 }
 
+// QueryProcesses provides a mock function.
+func (_m *MockWorkflowService) QueryProcesses(ctx context.Context, query Query) (page ProcessPage, err error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 ProcessPage
+	if rf, ok := ret.Get(0).(func(context.Context, Query) ProcessPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(ProcessPage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryProcess provides a mock function.
+func (_m *MockWorkflowService) RetryProcess(ctx context.Context, orgID uint, id string) (err error) {
+	ret := _m.Called(ctx, orgID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, orgID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SignalProcess provides a mock function.
 func (_m *MockWorkflowService) SignalProcess(ctx context.Context, id string, signal string, value interface{}) (err error) {
 	ret := _m.Called(ctx, id, signal, value)
@@ -0,0 +1,146 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"emperror.dev/errors"
+)
+
+// deniedNetworks are the loopback, private, link-local and unspecified address ranges.
+var deniedNetworks = func() []*net.IPNet {
+	cidrs := []string{
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"127.0.0.0/8",
+		"169.254.0.0/16",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"::/128",
+		"::1/128",
+		"fc00::/7",
+		"fe80::/10",
+	}
+
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}()
+
+// Resolver resolves host names to IP addresses.
+type Resolver interface {
+	// LookupIPAddr looks up the IP addresses of a host.
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// AddressPolicy decides whether webhook requests can be sent to an address.
+//
+// Unless private networks are allowed, requests cannot be sent to loopback, private, link-local or unspecified addresses,
+// so that subscriptions cannot reach the internal services of the network running Pipeline.
+type AddressPolicy struct {
+	// AllowPrivateNetworks allows sending requests to any address (eg. to subscribers in the same private network).
+	AllowPrivateNetworks bool
+
+	// Resolver resolves the host names of subscription URLs. Defaults to net.DefaultResolver.
+	Resolver Resolver
+}
+
+// IsAllowed checks whether requests can be sent to an IP address.
+func (p AddressPolicy) IsAllowed(ip net.IP) bool {
+	if p.AllowPrivateNetworks {
+		return true
+	}
+
+	for _, network := range deniedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateURL checks whether every address of the host of a URL is allowed.
+func (p AddressPolicy) ValidateURL(ctx context.Context, rawURL string) error {
+	if p.AllowPrivateNetworks {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.New("url must be an absolute http or https URL")
+	}
+
+	host := u.Hostname()
+
+	if ip := net.ParseIP(host); ip != nil {
+		if !p.IsAllowed(ip) {
+			return errors.New("url must not point to a loopback, private or link-local address")
+		}
+
+		return nil
+	}
+
+	resolver := p.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return errors.New("url host cannot be resolved")
+	}
+
+	for _, addr := range addrs {
+		if !p.IsAllowed(addr.IP) {
+			return errors.New("url must not point to a loopback, private or link-local address")
+		}
+	}
+
+	return nil
+}
+
+// CheckDialAddress checks whether a connection can be opened to a resolved network address (host:port).
+//
+// Checking the address right before connecting prevents subscribers from changing the resolved addresses
+// after the subscription is validated (DNS rebinding).
+func (p AddressPolicy) CheckDialAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.WrapIf(err, "invalid webhook address")
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return errors.NewWithDetails("invalid webhook address", "address", address)
+	}
+
+	if !p.IsAllowed(ip) {
+		return errors.NewWithDetails("webhook address is not allowed", "address", address)
+	}
+
+	return nil
+}
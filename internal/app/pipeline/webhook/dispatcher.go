@@ -0,0 +1,165 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/gofrs/uuid"
+)
+
+// Event types.
+const (
+	EventClusterCreated = "cluster.created"
+	EventClusterUpdated = "cluster.updated"
+	EventClusterDeleted = "cluster.deleted"
+
+	EventClusterExpiryDue = "cluster.expiry-due"
+
+	EventClusterHibernationDue = "cluster.hibernation-due"
+	EventClusterHibernated     = "cluster.hibernated"
+	EventClusterWokenUp        = "cluster.woken-up"
+
+	EventSecretRotationDue = "secret.rotation-due"
+	EventSecretRotated     = "secret.rotated"
+)
+
+// ProcessEventType returns the event type of a process event (eg. "process.update-node-pool.finished").
+func ProcessEventType(eventType string, status string) string {
+	return "process." + eventType + "." + status
+}
+
+// IntegratedServiceEventType returns the event type of an integrated service status change (eg. "integratedservice.dns.error").
+func IntegratedServiceEventType(name string, status string) string {
+	return "integratedservice." + name + "." + status
+}
+
+// Dispatcher sends events to the matching webhook subscriptions of an organization.
+type Dispatcher struct {
+	store     Store
+	scheduler DeliveryScheduler
+
+	logger       Logger
+	errorHandler ErrorHandler
+}
+
+// NewDispatcher returns a new Dispatcher.
+func NewDispatcher(store Store, scheduler DeliveryScheduler, logger Logger, errorHandler ErrorHandler) Dispatcher {
+	return Dispatcher{
+		store:        store,
+		scheduler:    scheduler,
+		logger:       logger,
+		errorHandler: errorHandler,
+	}
+}
+
+// Dispatch records a delivery for every matching subscription, then schedules sending them in the background.
+//
+// Deliveries which cannot be scheduled are left pending, so that they can be redelivered later.
+func (d Dispatcher) Dispatch(ctx context.Context, eventType string, organizationID uint, data interface{}) error {
+	subscriptions, err := d.store.ListSubscriptions(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	event := Event{
+		ID:             uuid.Must(uuid.NewV4()).String(),
+		Type:           eventType,
+		OrganizationID: organizationID,
+		Time:           time.Now().UTC(),
+		Data:           data,
+	}
+
+	var payload []byte
+
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(eventType) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(event)
+			if err != nil {
+				return errors.WrapIfWithDetails(err, "failed to marshal event", "eventType", eventType)
+			}
+		}
+
+		delivery, err := d.store.CreateDelivery(ctx, Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      eventType,
+			Payload:        string(payload),
+			Status:         DeliveryPending,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = d.scheduler.ScheduleDelivery(ctx, DeliveryRef{
+			OrganizationID: organizationID,
+			SubscriptionID: subscription.ID,
+			DeliveryID:     delivery.ID,
+		})
+		if err != nil {
+			d.errorHandler.HandleContext(ctx, errors.WithDetails(err, "organizationId", organizationID, "deliveryId", delivery.ID))
+		}
+	}
+
+	return nil
+}
+
+// Deliverer sends pending deliveries.
+type Deliverer struct {
+	store  Store
+	sender Sender
+}
+
+// NewDeliverer returns a new Deliverer.
+func NewDeliverer(store Store, sender Sender) Deliverer {
+	return Deliverer{
+		store:  store,
+		sender: sender,
+	}
+}
+
+// Deliver attempts to send a pending delivery once and records the result.
+// Failed deliveries remain pending unless it is the last attempt.
+//
+// Deliveries which are not pending anymore are returned as is.
+// Deliveries of deleted subscriptions are skipped and an empty delivery is returned.
+func (d Deliverer) Deliver(ctx context.Context, ref DeliveryRef, lastAttempt bool) (Delivery, error) {
+	subscription, err := d.store.GetSubscription(ctx, ref.OrganizationID, ref.SubscriptionID)
+	if errors.As(err, &SubscriptionNotFoundError{}) {
+		return Delivery{}, nil
+	} else if err != nil {
+		return Delivery{}, err
+	}
+
+	delivery, err := d.store.GetDelivery(ctx, ref.SubscriptionID, ref.DeliveryID)
+	if errors.As(err, &DeliveryNotFoundError{}) {
+		return Delivery{}, nil
+	} else if err != nil {
+		return Delivery{}, err
+	}
+
+	if delivery.Status != DeliveryPending {
+		return delivery, nil
+	}
+
+	return attemptDelivery(ctx, d.store, d.sender, ref.OrganizationID, subscription, delivery, lastAttempt)
+}
@@ -0,0 +1,397 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"time"
+)
+
+// Subscription is an organization scoped webhook subscription.
+type Subscription struct {
+	ID  uint   `json:"id"`
+	URL string `json:"url"`
+
+	// Events filters the events sent to the subscriber using shell file name patterns (eg. "cluster.*").
+	Events []string `json:"events"`
+
+	// SecretID refers to a secret in the secret store holding the key used for signing the requests.
+	// The key is read from the "secret" field of the secret.
+	SecretID string `json:"secretId,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// Matches checks whether the subscription filter selects an event type.
+func (s Subscription) Matches(eventType string) bool {
+	for _, pattern := range s.Events {
+		if ok, _ := path.Match(pattern, eventType); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Event is sent to the subscribers.
+type Event struct {
+	ID             string      `json:"id"`
+	Type           string      `json:"type"`
+	OrganizationID uint        `json:"organizationId"`
+	Time           time.Time   `json:"time"`
+	Data           interface{} `json:"data"`
+}
+
+// Delivery statuses.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Delivery records sending an event to a subscriber.
+type Delivery struct {
+	ID             uint   `json:"id"`
+	SubscriptionID uint   `json:"subscriptionId"`
+	EventID        string `json:"eventId"`
+	EventType      string `json:"eventType"`
+
+	// Payload is the request body sent to the subscriber.
+	Payload string `json:"payload"`
+
+	Status       string `json:"status"`
+	Attempts     int    `json:"attempts"`
+	ResponseCode int    `json:"responseCode,omitempty"`
+	Error        string `json:"error,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock
+
+// Service manages webhook subscriptions.
+type Service interface {
+	// ListSubscriptions lists the webhook subscriptions of an organization.
+	ListSubscriptions(ctx context.Context, organizationID uint) (subscriptions []Subscription, err error)
+
+	// GetSubscription returns a single webhook subscription.
+	GetSubscription(ctx context.Context, organizationID uint, subscriptionID uint) (subscription Subscription, err error)
+
+	// CreateSubscription creates a new webhook subscription.
+	CreateSubscription(ctx context.Context, organizationID uint, subscription Subscription) (newSubscription Subscription, err error)
+
+	// DeleteSubscription deletes a webhook subscription together with its delivery history.
+	DeleteSubscription(ctx context.Context, organizationID uint, subscriptionID uint) error
+
+	// ListDeliveries lists the most recent deliveries of a webhook subscription.
+	ListDeliveries(ctx context.Context, organizationID uint, subscriptionID uint) (deliveries []Delivery, err error)
+
+	// Redeliver sends the event of a previous delivery again.
+	Redeliver(ctx context.Context, organizationID uint, subscriptionID uint, deliveryID uint) (delivery Delivery, err error)
+}
+
+// +testify:mock:testOnly=true
+
+// Store is a persistence layer for webhook subscriptions and deliveries.
+type Store interface {
+	// ListSubscriptions lists the webhook subscriptions of an organization.
+	ListSubscriptions(ctx context.Context, organizationID uint) ([]Subscription, error)
+
+	// GetSubscription returns a single webhook subscription.
+	GetSubscription(ctx context.Context, organizationID uint, subscriptionID uint) (Subscription, error)
+
+	// CreateSubscription persists a new webhook subscription.
+	CreateSubscription(ctx context.Context, organizationID uint, subscription Subscription) (Subscription, error)
+
+	// DeleteSubscription deletes a webhook subscription together with its deliveries.
+	DeleteSubscription(ctx context.Context, organizationID uint, subscriptionID uint) error
+
+	// ListDeliveries lists the most recent deliveries of a webhook subscription.
+	ListDeliveries(ctx context.Context, subscriptionID uint, limit int) ([]Delivery, error)
+
+	// GetDelivery returns a single delivery of a webhook subscription.
+	GetDelivery(ctx context.Context, subscriptionID uint, deliveryID uint) (Delivery, error)
+
+	// CreateDelivery persists a new delivery.
+	CreateDelivery(ctx context.Context, delivery Delivery) (Delivery, error)
+
+	// UpdateDelivery updates the status of a delivery.
+	UpdateDelivery(ctx context.Context, delivery Delivery) (Delivery, error)
+}
+
+// +testify:mock:testOnly=true
+
+// Sender sends deliveries to subscribers.
+type Sender interface {
+	// Send sends the payload of a delivery to a subscriber and returns the response status code.
+	Send(ctx context.Context, organizationID uint, subscription Subscription, delivery Delivery) (int, error)
+}
+
+// DeliveryRef identifies a delivery of a webhook subscription.
+type DeliveryRef struct {
+	OrganizationID uint
+	SubscriptionID uint
+	DeliveryID     uint
+}
+
+// +testify:mock:testOnly=true
+
+// DeliveryScheduler sends pending deliveries in the background, retrying the failed attempts.
+type DeliveryScheduler interface {
+	// ScheduleDelivery schedules sending a pending delivery.
+	ScheduleDelivery(ctx context.Context, ref DeliveryRef) error
+}
+
+// +testify:mock:testOnly=true
+
+// SecretStore reads the signing keys of webhook subscriptions.
+type SecretStore interface {
+	// GetSigningKey returns the signing key stored in a secret.
+	GetSigningKey(ctx context.Context, organizationID uint, secretID string) (string, error)
+}
+
+// DeliveryHistoryLimit is the number of deliveries returned for a subscription.
+const DeliveryHistoryLimit = 100
+
+// NewService returns a new Service.
+func NewService(store Store, secrets SecretStore, sender Sender, addresses AddressPolicy) Service {
+	return service{
+		store:     store,
+		secrets:   secrets,
+		sender:    sender,
+		addresses: addresses,
+	}
+}
+
+type service struct {
+	store     Store
+	secrets   SecretStore
+	sender    Sender
+	addresses AddressPolicy
+}
+
+func (s service) ListSubscriptions(ctx context.Context, organizationID uint) ([]Subscription, error) {
+	return s.store.ListSubscriptions(ctx, organizationID)
+}
+
+func (s service) GetSubscription(ctx context.Context, organizationID uint, subscriptionID uint) (Subscription, error) {
+	return s.store.GetSubscription(ctx, organizationID, subscriptionID)
+}
+
+func (s service) CreateSubscription(ctx context.Context, organizationID uint, subscription Subscription) (Subscription, error) {
+	violations := validateSubscription(subscription)
+
+	if len(violations) == 0 {
+		if err := s.addresses.ValidateURL(ctx, subscription.URL); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+
+	if len(violations) == 0 && subscription.SecretID != "" {
+		_, err := s.secrets.GetSigningKey(ctx, organizationID, subscription.SecretID)
+		if err != nil {
+			violations = append(violations, "secret must exist and contain a signing key")
+		}
+	}
+
+	if len(violations) > 0 {
+		return Subscription{}, NewValidationError("invalid webhook subscription", violations)
+	}
+
+	return s.store.CreateSubscription(ctx, organizationID, subscription)
+}
+
+func (s service) DeleteSubscription(ctx context.Context, organizationID uint, subscriptionID uint) error {
+	return s.store.DeleteSubscription(ctx, organizationID, subscriptionID)
+}
+
+func (s service) ListDeliveries(ctx context.Context, organizationID uint, subscriptionID uint) ([]Delivery, error) {
+	_, err := s.store.GetSubscription(ctx, organizationID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.ListDeliveries(ctx, subscriptionID, DeliveryHistoryLimit)
+}
+
+func (s service) Redeliver(ctx context.Context, organizationID uint, subscriptionID uint, deliveryID uint) (Delivery, error) {
+	subscription, err := s.store.GetSubscription(ctx, organizationID, subscriptionID)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	previous, err := s.store.GetDelivery(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	delivery, err := s.store.CreateDelivery(ctx, Delivery{
+		SubscriptionID: subscriptionID,
+		EventID:        previous.EventID,
+		EventType:      previous.EventType,
+		Payload:        previous.Payload,
+		Status:         DeliveryPending,
+	})
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	return attemptDelivery(ctx, s.store, s.sender, organizationID, subscription, delivery, true)
+}
+
+// attemptDelivery sends a delivery once and records the result.
+// Failed deliveries remain pending unless it is the last attempt.
+func attemptDelivery(
+	ctx context.Context,
+	store Store,
+	sender Sender,
+	organizationID uint,
+	subscription Subscription,
+	delivery Delivery,
+	lastAttempt bool,
+) (Delivery, error) {
+	code, err := sender.Send(ctx, organizationID, subscription, delivery)
+
+	delivery.Attempts++
+	delivery.ResponseCode = code
+
+	if err != nil {
+		delivery.Error = err.Error()
+
+		if lastAttempt {
+			delivery.Status = DeliveryFailed
+		}
+	} else {
+		delivery.Status = DeliverySucceeded
+		delivery.Error = ""
+	}
+
+	return store.UpdateDelivery(ctx, delivery)
+}
+
+func validateSubscription(subscription Subscription) []string {
+	var violations []string
+
+	u, err := url.Parse(subscription.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		violations = append(violations, "url must be an absolute http or https URL")
+	}
+
+	if len(subscription.Events) == 0 {
+		violations = append(violations, "at least one event filter is required")
+	}
+
+	for _, pattern := range subscription.Events {
+		if _, err := path.Match(pattern, ""); err != nil {
+			violations = append(violations, "invalid event filter: "+pattern)
+		}
+	}
+
+	return violations
+}
+
+// ValidationError is returned when a webhook subscription is invalid.
+type ValidationError struct {
+	message    string
+	violations []string
+}
+
+// NewValidationError returns a new ValidationError.
+func NewValidationError(message string, violations []string) ValidationError {
+	return ValidationError{
+		message:    message,
+		violations: violations,
+	}
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	return e.message
+}
+
+// Violations returns details of the failed validation.
+func (e ValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (ValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (ValidationError) ServiceError() bool {
+	return true
+}
+
+// SubscriptionNotFoundError is returned if a webhook subscription cannot be found.
+type SubscriptionNotFoundError struct {
+	ID uint
+}
+
+// Error implements the error interface.
+func (SubscriptionNotFoundError) Error() string {
+	return "webhook subscription not found"
+}
+
+// Details returns error details.
+func (e SubscriptionNotFoundError) Details() []interface{} {
+	return []interface{}{"subscriptionId", e.ID}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (SubscriptionNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (SubscriptionNotFoundError) ServiceError() bool {
+	return true
+}
+
+// DeliveryNotFoundError is returned if a webhook delivery cannot be found.
+type DeliveryNotFoundError struct {
+	ID uint
+}
+
+// Error implements the error interface.
+func (DeliveryNotFoundError) Error() string {
+	return "webhook delivery not found"
+}
+
+// Details returns error details.
+func (e DeliveryNotFoundError) Details() []interface{} {
+	return []interface{}{"deliveryId", e.ID}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (DeliveryNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (DeliveryNotFoundError) ServiceError() bool {
+	return true
+}
@@ -0,0 +1,280 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscription_Matches(t *testing.T) {
+	subscription := Subscription{
+		Events: []string{"cluster.created", "process.*.failed", "integratedservice.*.error"},
+	}
+
+	assert.True(t, subscription.Matches(EventClusterCreated))
+	assert.False(t, subscription.Matches(EventClusterDeleted))
+	assert.True(t, subscription.Matches(ProcessEventType("update-node-pool", "failed")))
+	assert.False(t, subscription.Matches(ProcessEventType("update-node-pool", "finished")))
+	assert.True(t, subscription.Matches(IntegratedServiceEventType("dns", "error")))
+}
+
+func TestService_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	subscription := Subscription{
+		URL:      "https://ci.example.com/hooks/pipeline",
+		Events:   []string{"cluster.*"},
+		SecretID: "secret",
+	}
+
+	store := new(MockStore)
+	store.On("CreateSubscription", ctx, uint(1), subscription).Return(subscription, nil)
+
+	secrets := new(MockSecretStore)
+	secrets.On("GetSigningKey", ctx, uint(1), "secret").Return("key", nil)
+	secrets.On("GetSigningKey", ctx, uint(1), "missing").Return("", errors.New("secret not found"))
+
+	addresses := AddressPolicy{
+		Resolver: resolverStub{
+			"ci.example.com": {{IP: net.ParseIP("93.184.216.34")}},
+			"example.com":    {{IP: net.ParseIP("93.184.216.34")}},
+		},
+	}
+
+	service := NewService(store, secrets, new(MockSender), addresses)
+
+	_, err := service.CreateSubscription(ctx, 1, subscription)
+	require.NoError(t, err)
+
+	invalid := []Subscription{
+		{URL: "ftp://example.com", Events: []string{"*"}},
+		{URL: "https://example.com"},
+		{URL: "https://example.com", Events: []string{"["}},
+		{URL: "https://example.com", Events: []string{"*"}, SecretID: "missing"},
+		{URL: "http://127.0.0.1:9090", Events: []string{"*"}},
+	}
+
+	for _, subscription := range invalid {
+		_, err := service.CreateSubscription(ctx, 1, subscription)
+		assert.True(t, errors.As(err, &ValidationError{}))
+	}
+
+	store.AssertExpectations(t)
+}
+
+func TestService_Redeliver(t *testing.T) {
+	ctx := context.Background()
+
+	subscription := Subscription{ID: 1, URL: "https://example.com", Events: []string{"*"}}
+	previous := Delivery{
+		ID:             1,
+		SubscriptionID: 1,
+		EventID:        "event",
+		EventType:      EventClusterCreated,
+		Payload:        "{}",
+		Status:         DeliveryFailed,
+		Attempts:       3,
+	}
+	delivery := Delivery{
+		ID:             2,
+		SubscriptionID: 1,
+		EventID:        "event",
+		EventType:      EventClusterCreated,
+		Payload:        "{}",
+		Status:         DeliveryPending,
+	}
+	delivered := delivery
+	delivered.Status = DeliverySucceeded
+	delivered.Attempts = 1
+	delivered.ResponseCode = 200
+
+	store := new(MockStore)
+	store.On("GetSubscription", ctx, uint(1), uint(1)).Return(subscription, nil)
+	store.On("GetDelivery", ctx, uint(1), uint(1)).Return(previous, nil)
+	store.On("CreateDelivery", ctx, mock.Anything).Return(delivery, nil)
+	store.On("UpdateDelivery", ctx, delivered).Return(delivered, nil)
+
+	sender := new(MockSender)
+	sender.On("Send", ctx, uint(1), subscription, delivery).Return(200, nil)
+
+	service := NewService(store, new(MockSecretStore), sender, AddressPolicy{})
+
+	result, err := service.Redeliver(ctx, 1, 1, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, delivered, result)
+	store.AssertExpectations(t)
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	ctx := context.Background()
+
+	subscriptions := []Subscription{
+		{ID: 1, URL: "https://example.com/clusters", Events: []string{"cluster.*"}},
+		{ID: 2, URL: "https://example.com/processes", Events: []string{"process.*"}},
+	}
+
+	store := new(MockStore)
+	store.On("ListSubscriptions", ctx, uint(1)).Return(subscriptions, nil)
+	store.On("CreateDelivery", ctx, mock.MatchedBy(func(d Delivery) bool {
+		return d.SubscriptionID == 1 && d.EventType == EventClusterCreated && d.Status == DeliveryPending
+	})).Return(Delivery{ID: 1, SubscriptionID: 1, EventType: EventClusterCreated, Status: DeliveryPending}, nil)
+
+	scheduler := new(MockDeliveryScheduler)
+	scheduler.On("ScheduleDelivery", ctx, DeliveryRef{OrganizationID: 1, SubscriptionID: 1, DeliveryID: 1}).Return(nil)
+
+	dispatcher := NewDispatcher(store, scheduler, NoopLogger{}, NoopErrorHandler{})
+
+	err := dispatcher.Dispatch(ctx, EventClusterCreated, 1, map[string]interface{}{"clusterId": 1})
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+	scheduler.AssertExpectations(t)
+}
+
+func TestDeliverer_Deliver(t *testing.T) {
+	ctx := context.Background()
+
+	subscription := Subscription{ID: 1, URL: "https://example.com", Events: []string{"*"}}
+	pending := Delivery{ID: 1, SubscriptionID: 1, EventType: EventClusterCreated, Status: DeliveryPending}
+	ref := DeliveryRef{OrganizationID: 1, SubscriptionID: 1, DeliveryID: 1}
+
+	newStore := func(delivery Delivery) *MockStore {
+		store := new(MockStore)
+		store.On("GetSubscription", ctx, uint(1), uint(1)).Return(subscription, nil)
+		store.On("GetDelivery", ctx, uint(1), uint(1)).Return(delivery, nil)
+		store.On("UpdateDelivery", ctx, mock.Anything).Return(
+			func(_ context.Context, d Delivery) Delivery { return d },
+			nil,
+		)
+
+		return store
+	}
+
+	t.Run("FailedAttempt", func(t *testing.T) {
+		sender := new(MockSender)
+		sender.On("Send", ctx, uint(1), subscription, pending).Return(500, errors.New("unexpected status code"))
+
+		delivery, err := NewDeliverer(newStore(pending), sender).Deliver(ctx, ref, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, DeliveryPending, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, 500, delivery.ResponseCode)
+	})
+
+	t.Run("LastAttempt", func(t *testing.T) {
+		sender := new(MockSender)
+		sender.On("Send", ctx, uint(1), subscription, pending).Return(500, errors.New("unexpected status code"))
+
+		delivery, err := NewDeliverer(newStore(pending), sender).Deliver(ctx, ref, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, DeliveryFailed, delivery.Status)
+	})
+
+	t.Run("Succeeded", func(t *testing.T) {
+		sender := new(MockSender)
+		sender.On("Send", ctx, uint(1), subscription, pending).Return(200, nil)
+
+		delivery, err := NewDeliverer(newStore(pending), sender).Deliver(ctx, ref, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, DeliverySucceeded, delivery.Status)
+		assert.Empty(t, delivery.Error)
+	})
+
+	t.Run("AlreadySent", func(t *testing.T) {
+		sent := pending
+		sent.Status = DeliverySucceeded
+
+		sender := new(MockSender)
+
+		delivery, err := NewDeliverer(newStore(sent), sender).Deliver(ctx, ref, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, DeliverySucceeded, delivery.Status)
+		sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("SubscriptionDeleted", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("GetSubscription", ctx, uint(1), uint(1)).Return(Subscription{}, SubscriptionNotFoundError{ID: 1})
+
+		delivery, err := NewDeliverer(store, new(MockSender)).Deliver(ctx, ref, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, Delivery{}, delivery)
+	})
+}
+
+type resolverStub map[string][]net.IPAddr
+
+func (r resolverStub) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	return addrs, nil
+}
+
+func TestAddressPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	policy := AddressPolicy{
+		Resolver: resolverStub{
+			"example.com":        {{IP: net.ParseIP("93.184.216.34")}},
+			"internal.example":   {{IP: net.ParseIP("10.0.0.1")}},
+			"metadata.internal":  {{IP: net.ParseIP("169.254.169.254")}},
+			"rebinding.example":  {{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("127.0.0.1")}},
+			"ipv6-local.example": {{IP: net.ParseIP("fe80::1")}},
+		},
+	}
+
+	assert.NoError(t, policy.ValidateURL(ctx, "https://example.com/hooks"))
+	assert.NoError(t, policy.ValidateURL(ctx, "https://93.184.216.34/hooks"))
+
+	for _, u := range []string{
+		"http://127.0.0.1:8080",
+		"http://[::1]/hooks",
+		"http://[::ffff:127.0.0.1]/hooks",
+		"http://192.168.1.1",
+		"http://0.0.0.0",
+		"https://internal.example",
+		"https://metadata.internal",
+		"https://rebinding.example",
+		"https://ipv6-local.example",
+		"https://unknown.example",
+	} {
+		assert.Error(t, policy.ValidateURL(ctx, u), u)
+	}
+
+	assert.NoError(t, policy.CheckDialAddress("93.184.216.34:443"))
+	assert.Error(t, policy.CheckDialAddress("172.16.0.1:443"))
+	assert.Error(t, policy.CheckDialAddress("[fc00::1]:443"))
+
+	policy.AllowPrivateNetworks = true
+
+	assert.NoError(t, policy.ValidateURL(ctx, "https://internal.example"))
+	assert.NoError(t, policy.CheckDialAddress("127.0.0.1:8080"))
+}
@@ -0,0 +1,354 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/expiry"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+	legacycluster "github.com/banzaicloud/pipeline/src/cluster"
+)
+
+type eventDispatcher interface {
+	Dispatch(ctx context.Context, eventType string, organizationID uint, data interface{}) error
+}
+
+type clusterGetter interface {
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+}
+
+// ClusterEventData is sent with cluster events.
+type ClusterEventData struct {
+	ClusterID    uint   `json:"clusterId,omitempty"`
+	ClusterName  string `json:"clusterName"`
+	Status       string `json:"status,omitempty"`
+	Cloud        string `json:"cloud,omitempty"`
+	Distribution string `json:"distribution,omitempty"`
+}
+
+// ClusterEventHandler dispatches cluster events received from the event bus to webhook subscriptions.
+type ClusterEventHandler struct {
+	dispatcher eventDispatcher
+	clusters   clusterGetter
+}
+
+// NewClusterEventHandler returns a new ClusterEventHandler.
+func NewClusterEventHandler(dispatcher eventDispatcher, clusters clusterGetter) ClusterEventHandler {
+	return ClusterEventHandler{
+		dispatcher: dispatcher,
+		clusters:   clusters,
+	}
+}
+
+// Handle handles a cluster event message.
+func (h ClusterEventHandler) Handle(msg *message.Message) error {
+	ctx := msg.Context()
+
+	switch name := msg.Metadata.Get("name"); name {
+	case "ClusterCreated", "ClusterUpdated":
+		var event legacycluster.ClusterUpdated // both events have the same structure
+
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to decode event", "event", name)
+		}
+
+		c, err := h.clusters.GetCluster(ctx, event.ClusterID)
+		if cluster.IsNotFoundError(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		eventType := webhook.EventClusterUpdated
+		if name == "ClusterCreated" {
+			eventType = webhook.EventClusterCreated
+		}
+
+		return h.dispatcher.Dispatch(ctx, eventType, c.OrganizationID, ClusterEventData{
+			ClusterID:    c.ID,
+			ClusterName:  c.Name,
+			Status:       c.Status,
+			Cloud:        c.Cloud,
+			Distribution: c.Distribution,
+		})
+
+	case "ClusterDeleted":
+		var event legacycluster.ClusterDeleted
+
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to decode event", "event", name)
+		}
+
+		return h.dispatcher.Dispatch(ctx, webhook.EventClusterDeleted, event.OrganizationID, ClusterEventData{
+			ClusterName: event.ClusterName,
+		})
+
+	default:
+		return nil
+	}
+}
+
+// ProcessEventData is sent with process events.
+type ProcessEventData struct {
+	ProcessID    string `json:"processId"`
+	ProcessType  string `json:"processType"`
+	ResourceID   string `json:"resourceId"`
+	ResourceType string `json:"resourceType"`
+	Type         string `json:"type"`
+	Status       string `json:"status"`
+	Log          string `json:"log,omitempty"`
+}
+
+// NewProcessService returns a process service dispatching logged process events to webhook subscriptions.
+func NewProcessService(service process.WorkflowService, dispatcher eventDispatcher, errorHandler webhook.ErrorHandler) process.WorkflowService {
+	return processService{
+		WorkflowService: service,
+		dispatcher:      dispatcher,
+		errorHandler:    errorHandler,
+	}
+}
+
+type processService struct {
+	process.WorkflowService
+
+	dispatcher   eventDispatcher
+	errorHandler webhook.ErrorHandler
+}
+
+func (s processService) LogProcessEvent(ctx context.Context, event process.ProcessEvent) (process.ProcessEvent, error) {
+	event, err := s.WorkflowService.LogProcessEvent(ctx, event)
+	if err != nil {
+		return event, err
+	}
+
+	if err := s.dispatch(ctx, event); err != nil {
+		s.errorHandler.HandleContext(ctx, err)
+	}
+
+	return event, nil
+}
+
+func (s processService) dispatch(ctx context.Context, event process.ProcessEvent) error {
+	proc, err := s.WorkflowService.GetProcess(ctx, event.ProcessId)
+	if err != nil {
+		return err
+	}
+
+	return s.dispatcher.Dispatch(ctx, webhook.ProcessEventType(event.Type, string(event.Status)), uint(proc.OrgId), ProcessEventData{
+		ProcessID:    proc.Id,
+		ProcessType:  proc.Type,
+		ResourceID:   proc.ResourceId,
+		ResourceType: proc.ResourceType,
+		Type:         event.Type,
+		Status:       string(event.Status),
+		Log:          event.Log,
+	})
+}
+
+// IntegratedServiceEventData is sent with integrated service events.
+type IntegratedServiceEventData struct {
+	ClusterID   uint   `json:"clusterId"`
+	ClusterName string `json:"clusterName"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+}
+
+// NewIntegratedServiceRepository returns an integrated service repository dispatching status changes to webhook subscriptions.
+func NewIntegratedServiceRepository(
+	repository integratedservices.IntegratedServiceRepository,
+	clusters clusterGetter,
+	dispatcher eventDispatcher,
+	errorHandler webhook.ErrorHandler,
+) integratedservices.IntegratedServiceRepository {
+	return integratedServiceRepository{
+		IntegratedServiceRepository: repository,
+		clusters:                    clusters,
+		dispatcher:                  dispatcher,
+		errorHandler:                errorHandler,
+	}
+}
+
+type integratedServiceRepository struct {
+	integratedservices.IntegratedServiceRepository
+
+	clusters     clusterGetter
+	dispatcher   eventDispatcher
+	errorHandler webhook.ErrorHandler
+}
+
+func (r integratedServiceRepository) UpdateIntegratedServiceStatus(
+	ctx context.Context,
+	clusterID uint,
+	integratedServiceName string,
+	status string,
+) error {
+	err := r.IntegratedServiceRepository.UpdateIntegratedServiceStatus(ctx, clusterID, integratedServiceName, status)
+	if err != nil {
+		return err
+	}
+
+	if err := r.dispatch(ctx, clusterID, integratedServiceName, status); err != nil {
+		r.errorHandler.HandleContext(ctx, err)
+	}
+
+	return nil
+}
+
+func (r integratedServiceRepository) dispatch(ctx context.Context, clusterID uint, integratedServiceName string, status string) error {
+	c, err := r.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	eventType := webhook.IntegratedServiceEventType(integratedServiceName, strings.ToLower(status))
+
+	return r.dispatcher.Dispatch(ctx, eventType, c.OrganizationID, IntegratedServiceEventData{
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+		Name:        integratedServiceName,
+		Status:      status,
+	})
+}
+
+// SecretRotationEventData is sent with secret rotation events.
+type SecretRotationEventData struct {
+	SecretID     string    `json:"secretId"`
+	NextRotation time.Time `json:"nextRotation"`
+}
+
+// SecretRotationNotifier dispatches secret rotation events to webhook subscriptions.
+type SecretRotationNotifier struct {
+	dispatcher eventDispatcher
+}
+
+// NewSecretRotationNotifier returns a new SecretRotationNotifier.
+func NewSecretRotationNotifier(dispatcher eventDispatcher) SecretRotationNotifier {
+	return SecretRotationNotifier{
+		dispatcher: dispatcher,
+	}
+}
+
+// SecretRotationDue dispatches an event about an upcoming rotation.
+func (n SecretRotationNotifier) SecretRotationDue(ctx context.Context, policy rotation.Policy) error {
+	return n.dispatch(ctx, webhook.EventSecretRotationDue, policy)
+}
+
+// SecretRotated dispatches an event about a finished rotation.
+func (n SecretRotationNotifier) SecretRotated(ctx context.Context, policy rotation.Policy) error {
+	return n.dispatch(ctx, webhook.EventSecretRotated, policy)
+}
+
+func (n SecretRotationNotifier) dispatch(ctx context.Context, eventType string, policy rotation.Policy) error {
+	return n.dispatcher.Dispatch(ctx, eventType, policy.OrganizationID, SecretRotationEventData{
+		SecretID:     policy.SecretID,
+		NextRotation: policy.NextRotation(),
+	})
+}
+
+// ClusterScheduleEventData is sent with cluster hibernation events.
+type ClusterScheduleEventData struct {
+	ClusterID   uint      `json:"clusterId"`
+	ClusterName string    `json:"clusterName"`
+	SleepAt     time.Time `json:"sleepAt"`
+	WakeAt      time.Time `json:"wakeAt"`
+}
+
+// ClusterScheduleNotifier dispatches cluster hibernation events to webhook subscriptions.
+type ClusterScheduleNotifier struct {
+	dispatcher eventDispatcher
+	clusters   clusterGetter
+}
+
+// NewClusterScheduleNotifier returns a new ClusterScheduleNotifier.
+func NewClusterScheduleNotifier(dispatcher eventDispatcher, clusters clusterGetter) ClusterScheduleNotifier {
+	return ClusterScheduleNotifier{
+		dispatcher: dispatcher,
+		clusters:   clusters,
+	}
+}
+
+// Notify dispatches an event about the hibernation of a cluster.
+func (n ClusterScheduleNotifier) Notify(ctx context.Context, notification schedule.Notification) error {
+	var eventType string
+	switch notification.Type {
+	case schedule.NotificationHibernationDue:
+		eventType = webhook.EventClusterHibernationDue
+	case schedule.NotificationHibernated:
+		eventType = webhook.EventClusterHibernated
+	case schedule.NotificationWokenUp:
+		eventType = webhook.EventClusterWokenUp
+	default:
+		return errors.NewWithDetails("unknown notification type", "type", notification.Type)
+	}
+
+	c, err := n.clusters.GetCluster(ctx, notification.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	return n.dispatcher.Dispatch(ctx, eventType, c.OrganizationID, ClusterScheduleEventData{
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+		SleepAt:     notification.SleepAt,
+		WakeAt:      notification.WakeAt,
+	})
+}
+
+// ClusterExpiryEventData is sent with cluster expiry events.
+type ClusterExpiryEventData struct {
+	ClusterID   uint      `json:"clusterId"`
+	ClusterName string    `json:"clusterName"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// ClusterExpiryNotifier dispatches cluster expiry events to webhook subscriptions.
+type ClusterExpiryNotifier struct {
+	dispatcher eventDispatcher
+	clusters   clusterGetter
+}
+
+// NewClusterExpiryNotifier returns a new ClusterExpiryNotifier.
+func NewClusterExpiryNotifier(dispatcher eventDispatcher, clusters clusterGetter) ClusterExpiryNotifier {
+	return ClusterExpiryNotifier{
+		dispatcher: dispatcher,
+		clusters:   clusters,
+	}
+}
+
+// NotifyExpiry dispatches an event about the upcoming expiry of a cluster.
+func (n ClusterExpiryNotifier) NotifyExpiry(ctx context.Context, clusterID uint, expiresAt time.Time, _ expiry.NotificationSpec) error {
+	c, err := n.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return n.dispatcher.Dispatch(ctx, webhook.EventClusterExpiryDue, c.OrganizationID, ClusterExpiryEventData{
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+		ExpiresAt:   expiresAt,
+	})
+}
@@ -0,0 +1,286 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+)
+
+// TableName constants
+const (
+	subscriptionTableName = "webhook_subscriptions"
+	deliveryTableName     = "webhook_deliveries"
+)
+
+type subscriptionModel struct {
+	ID             uint        `gorm:"primary_key"`
+	OrganizationID uint        `gorm:"index;not null"`
+	URL            string      `gorm:"type:text;not null"`
+	Events         eventsModel `gorm:"type:text"`
+	SecretID       string
+	CreatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (subscriptionModel) TableName() string {
+	return subscriptionTableName
+}
+
+func (m subscriptionModel) toSubscription() webhook.Subscription {
+	createdAt := m.CreatedAt
+
+	return webhook.Subscription{
+		ID:        m.ID,
+		URL:       m.URL,
+		Events:    []string(m.Events),
+		SecretID:  m.SecretID,
+		CreatedAt: &createdAt,
+	}
+}
+
+type eventsModel []string
+
+// Scan implements the sql.Scanner interface.
+func (m *eventsModel) Scan(src interface{}) error {
+	return json.Scan(src, m)
+}
+
+// Value implements the driver.Valuer interface.
+func (m eventsModel) Value() (driver.Value, error) {
+	return json.Value(m)
+}
+
+type deliveryModel struct {
+	ID             uint   `gorm:"primary_key"`
+	SubscriptionID uint   `gorm:"index;not null"`
+	EventID        string `gorm:"not null"`
+	EventType      string `gorm:"not null"`
+	Payload        string `gorm:"type:text"`
+	Status         string `gorm:"not null"`
+	Attempts       int    `gorm:"not null"`
+	ResponseCode   int
+	Error          string `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (deliveryModel) TableName() string {
+	return deliveryTableName
+}
+
+func (m deliveryModel) toDelivery() webhook.Delivery {
+	createdAt := m.CreatedAt
+	updatedAt := m.UpdatedAt
+
+	return webhook.Delivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		EventType:      m.EventType,
+		Payload:        m.Payload,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		ResponseCode:   m.ResponseCode,
+		Error:          m.Error,
+		CreatedAt:      &createdAt,
+		UpdatedAt:      &updatedAt,
+	}
+}
+
+// GormStore is a webhook store using Gorm for persistence.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new GormStore.
+func NewGormStore(db *gorm.DB) GormStore {
+	return GormStore{
+		db: db,
+	}
+}
+
+// ListSubscriptions lists the webhook subscriptions of an organization.
+func (s GormStore) ListSubscriptions(ctx context.Context, organizationID uint) ([]webhook.Subscription, error) {
+	var models []subscriptionModel
+
+	err := s.db.Where(subscriptionModel{OrganizationID: organizationID}).Order("id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list webhook subscriptions", "organizationId", organizationID)
+	}
+
+	subscriptions := make([]webhook.Subscription, 0, len(models))
+	for _, model := range models {
+		subscriptions = append(subscriptions, model.toSubscription())
+	}
+
+	return subscriptions, nil
+}
+
+// GetSubscription returns a single webhook subscription.
+func (s GormStore) GetSubscription(ctx context.Context, organizationID uint, subscriptionID uint) (webhook.Subscription, error) {
+	var model subscriptionModel
+
+	err := s.db.Where("id = ? AND organization_id = ?", subscriptionID, organizationID).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return webhook.Subscription{}, errors.WithStack(webhook.SubscriptionNotFoundError{ID: subscriptionID})
+	} else if err != nil {
+		return webhook.Subscription{}, errors.WrapIfWithDetails(
+			err, "failed to get webhook subscription",
+			"organizationId", organizationID,
+			"subscriptionId", subscriptionID,
+		)
+	}
+
+	return model.toSubscription(), nil
+}
+
+// CreateSubscription persists a new webhook subscription.
+func (s GormStore) CreateSubscription(ctx context.Context, organizationID uint, subscription webhook.Subscription) (webhook.Subscription, error) {
+	model := subscriptionModel{
+		OrganizationID: organizationID,
+		URL:            subscription.URL,
+		Events:         eventsModel(subscription.Events),
+		SecretID:       subscription.SecretID,
+	}
+
+	err := s.db.Create(&model).Error
+	if err != nil {
+		return webhook.Subscription{}, errors.WrapIfWithDetails(err, "failed to create webhook subscription", "organizationId", organizationID)
+	}
+
+	return model.toSubscription(), nil
+}
+
+// DeleteSubscription deletes a webhook subscription together with its deliveries.
+func (s GormStore) DeleteSubscription(ctx context.Context, organizationID uint, subscriptionID uint) error {
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	result := tx.Where("id = ? AND organization_id = ?", subscriptionID, organizationID).Delete(subscriptionModel{})
+	if result.Error != nil {
+		tx.Rollback()
+
+		return errors.WrapIfWithDetails(
+			result.Error, "failed to delete webhook subscription",
+			"organizationId", organizationID,
+			"subscriptionId", subscriptionID,
+		)
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+
+		return errors.WithStack(webhook.SubscriptionNotFoundError{ID: subscriptionID})
+	}
+
+	err := tx.Where(deliveryModel{SubscriptionID: subscriptionID}).Delete(deliveryModel{}).Error
+	if err != nil {
+		tx.Rollback()
+
+		return errors.WrapIfWithDetails(err, "failed to delete webhook deliveries", "subscriptionId", subscriptionID)
+	}
+
+	return errors.WrapIf(tx.Commit().Error, "failed to commit transaction")
+}
+
+// ListDeliveries lists the most recent deliveries of a webhook subscription.
+func (s GormStore) ListDeliveries(ctx context.Context, subscriptionID uint, limit int) ([]webhook.Delivery, error) {
+	var models []deliveryModel
+
+	err := s.db.Where(deliveryModel{SubscriptionID: subscriptionID}).Order("id desc").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list webhook deliveries", "subscriptionId", subscriptionID)
+	}
+
+	deliveries := make([]webhook.Delivery, 0, len(models))
+	for _, model := range models {
+		deliveries = append(deliveries, model.toDelivery())
+	}
+
+	return deliveries, nil
+}
+
+// GetDelivery returns a single delivery of a webhook subscription.
+func (s GormStore) GetDelivery(ctx context.Context, subscriptionID uint, deliveryID uint) (webhook.Delivery, error) {
+	var model deliveryModel
+
+	err := s.db.Where("id = ? AND subscription_id = ?", deliveryID, subscriptionID).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return webhook.Delivery{}, errors.WithStack(webhook.DeliveryNotFoundError{ID: deliveryID})
+	} else if err != nil {
+		return webhook.Delivery{}, errors.WrapIfWithDetails(
+			err, "failed to get webhook delivery",
+			"subscriptionId", subscriptionID,
+			"deliveryId", deliveryID,
+		)
+	}
+
+	return model.toDelivery(), nil
+}
+
+// CreateDelivery persists a new delivery.
+func (s GormStore) CreateDelivery(ctx context.Context, delivery webhook.Delivery) (webhook.Delivery, error) {
+	model := deliveryModel{
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+	}
+
+	err := s.db.Create(&model).Error
+	if err != nil {
+		return webhook.Delivery{}, errors.WrapIfWithDetails(
+			err, "failed to create webhook delivery",
+			"subscriptionId", delivery.SubscriptionID,
+		)
+	}
+
+	return model.toDelivery(), nil
+}
+
+// UpdateDelivery updates the status of a delivery.
+func (s GormStore) UpdateDelivery(ctx context.Context, delivery webhook.Delivery) (webhook.Delivery, error) {
+	model := deliveryModel{ID: delivery.ID}
+
+	err := s.db.Model(&model).Updates(map[string]interface{}{
+		"status":        delivery.Status,
+		"attempts":      delivery.Attempts,
+		"response_code": delivery.ResponseCode,
+		"error":         delivery.Error,
+	}).Error
+	if err != nil {
+		return webhook.Delivery{}, errors.WrapIfWithDetails(err, "failed to update webhook delivery", "deliveryId", delivery.ID)
+	}
+
+	err = s.db.First(&model).Error
+	if err != nil {
+		return webhook.Delivery{}, errors.WrapIfWithDetails(err, "failed to get webhook delivery", "deliveryId", delivery.ID)
+	}
+
+	return model.toDelivery(), nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	//  SQLite driver used for integration test
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, webhook.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	subscription, err := store.CreateSubscription(ctx, 1, webhook.Subscription{
+		URL:      "https://ci.example.com/hooks/pipeline",
+		Events:   []string{"cluster.*", "process.*.failed"},
+		SecretID: "secret",
+	})
+	require.NoError(t, err)
+
+	subscriptions, err := store.ListSubscriptions(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, []string{"cluster.*", "process.*.failed"}, subscriptions[0].Events)
+
+	_, err = store.GetSubscription(ctx, 2, subscription.ID)
+	assert.True(t, errors.As(err, &webhook.SubscriptionNotFoundError{}))
+
+	delivery, err := store.CreateDelivery(ctx, webhook.Delivery{
+		SubscriptionID: subscription.ID,
+		EventID:        "event",
+		EventType:      webhook.EventClusterCreated,
+		Payload:        "{}",
+		Status:         webhook.DeliveryPending,
+	})
+	require.NoError(t, err)
+
+	delivery.Status = webhook.DeliveryFailed
+	delivery.Attempts = 3
+	delivery.ResponseCode = 500
+	delivery.Error = "unexpected response status code"
+
+	updated, err := store.UpdateDelivery(ctx, delivery)
+	require.NoError(t, err)
+	assert.Equal(t, webhook.DeliveryFailed, updated.Status)
+	assert.Equal(t, 3, updated.Attempts)
+
+	deliveries, err := store.ListDeliveries(ctx, subscription.ID, webhook.DeliveryHistoryLimit)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, 500, deliveries[0].ResponseCode)
+
+	err = store.DeleteSubscription(ctx, 1, subscription.ID)
+	require.NoError(t, err)
+
+	_, err = store.GetDelivery(ctx, subscription.ID, delivery.ID)
+	assert.True(t, errors.As(err, &webhook.DeliveryNotFoundError{}))
+
+	err = store.DeleteSubscription(ctx, 1, subscription.ID)
+	assert.True(t, errors.As(err, &webhook.SubscriptionNotFoundError{}))
+}
@@ -0,0 +1,57 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// SigningKeySecretField is the secret field holding the signing key of a webhook subscription.
+const SigningKeySecretField = "secret"
+
+type secretGetter interface {
+	Get(organizationID uint, secretID string) (*secret.SecretItemResponse, error)
+}
+
+// SecretStore reads webhook signing keys from the secret store.
+type SecretStore struct {
+	secrets secretGetter
+}
+
+// NewSecretStore returns a new SecretStore.
+func NewSecretStore(secrets secretGetter) SecretStore {
+	return SecretStore{
+		secrets: secrets,
+	}
+}
+
+// GetSigningKey returns the signing key stored in a secret.
+func (s SecretStore) GetSigningKey(ctx context.Context, organizationID uint, secretID string) (string, error) {
+	item, err := s.secrets.Get(organizationID, secretID)
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to get secret", "organizationId", organizationID, "secretId", secretID)
+	}
+
+	key, ok := item.Values[SigningKeySecretField]
+	if !ok || key == "" {
+		return "", errors.NewWithDetails("secret has no signing key", "organizationId", organizationID, "secretId", secretID)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,126 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+)
+
+// Webhook request headers.
+const (
+	EventHeader     = "X-Pipeline-Event"
+	DeliveryHeader  = "X-Pipeline-Delivery"
+	SignatureHeader = "X-Pipeline-Signature"
+)
+
+// HTTPSender sends deliveries as HTTP POST requests.
+// Requests of subscriptions with a secret are signed with HMAC-SHA256 (hex encoded, prefixed with "sha256=").
+//
+// Connections are only opened to the addresses allowed by the address policy (including redirects).
+// Requests are sent directly, proxies configured in the environment are not used.
+type HTTPSender struct {
+	client  *http.Client
+	secrets webhook.SecretStore
+}
+
+// NewHTTPSender returns a new HTTPSender.
+func NewHTTPSender(secrets webhook.SecretStore, timeout time.Duration, addresses webhook.AddressPolicy) HTTPSender {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_ string, address string, _ syscall.RawConn) error {
+			return addresses.CheckDialAddress(address)
+		},
+	}
+
+	return HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:           dialer.DialContext,
+				MaxIdleConns:          100,
+				IdleConnTimeout:       90 * time.Second,
+				TLSHandshakeTimeout:   10 * time.Second,
+				ExpectContinueTimeout: 1 * time.Second,
+			},
+		},
+		secrets: secrets,
+	}
+}
+
+// Send sends the payload of a delivery to a subscriber and returns the response status code.
+func (s HTTPSender) Send(
+	ctx context.Context,
+	organizationID uint,
+	subscription webhook.Subscription,
+	delivery webhook.Delivery,
+) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.WrapIf(err, "failed to create request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+
+	if subscription.SecretID != "" {
+		key, err := s.secrets.GetSigningKey(ctx, organizationID, subscription.SecretID)
+		if err != nil {
+			return 0, err
+		}
+
+		req.Header.Set(SignatureHeader, Sign([]byte(key), body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.WrapIf(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, errors.NewWithDetails("unexpected response status code", "statusCode", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature of a request body.
+func Sign(key []byte, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,80 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+type secretGetterStub map[string]map[string]string
+
+func (s secretGetterStub) Get(_ uint, secretID string) (*secret.SecretItemResponse, error) {
+	values, ok := s[secretID]
+	if !ok {
+		return nil, secret.ErrSecretNotExists
+	}
+
+	return &secret.SecretItemResponse{ID: secretID, Values: values}, nil
+}
+
+func TestHTTPSender_Send(t *testing.T) {
+	var (
+		body      []byte
+		signature string
+		event     string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		event = r.Header.Get(EventHeader)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	secrets := NewSecretStore(secretGetterStub{"secret": {SigningKeySecretField: "key"}})
+	sender := NewHTTPSender(secrets, time.Second, webhook.AddressPolicy{AllowPrivateNetworks: true})
+
+	delivery := webhook.Delivery{ID: 1, EventType: webhook.EventClusterCreated, Payload: `{"type":"cluster.created"}`}
+
+	code, err := sender.Send(context.Background(), 1, webhook.Subscription{URL: server.URL, SecretID: "secret"}, delivery)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.Equal(t, webhook.EventClusterCreated, event)
+	assert.Equal(t, Sign([]byte("key"), body), signature)
+
+	_, err = sender.Send(context.Background(), 1, webhook.Subscription{URL: server.URL, SecretID: "missing"}, delivery)
+	assert.Error(t, err)
+
+	// the test server listens on a loopback address
+	sender = NewHTTPSender(secrets, time.Second, webhook.AddressPolicy{})
+
+	_, err = sender.Send(context.Background(), 1, webhook.Subscription{URL: server.URL}, delivery)
+	assert.Error(t, err, "sending requests to loopback addresses should fail")
+}
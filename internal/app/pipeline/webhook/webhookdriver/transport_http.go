@@ -0,0 +1,209 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into a router.
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodGet).Path("").Handler(kithttp.NewServer(
+		endpoints.ListSubscriptions,
+		decodeListSubscriptionsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListSubscriptionsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("").Handler(kithttp.NewServer(
+		endpoints.CreateSubscription,
+		decodeCreateSubscriptionHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateSubscriptionHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{subscriptionId}").Handler(kithttp.NewServer(
+		endpoints.GetSubscription,
+		decodeGetSubscriptionHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetSubscriptionHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/{subscriptionId}").Handler(kithttp.NewServer(
+		endpoints.DeleteSubscription,
+		decodeDeleteSubscriptionHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{subscriptionId}/deliveries").Handler(kithttp.NewServer(
+		endpoints.ListDeliveries,
+		decodeListDeliveriesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListDeliveriesHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/{subscriptionId}/deliveries/{deliveryId}/redeliver").Handler(kithttp.NewServer(
+		endpoints.Redeliver,
+		decodeRedeliverHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeRedeliverHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+func decodeListSubscriptionsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListSubscriptionsRequest{OrganizationID: orgID}, nil
+}
+
+func encodeListSubscriptionsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListSubscriptionsResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Subscriptions)
+}
+
+func decodeCreateSubscriptionHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription webhook.Subscription
+
+	err = json.NewDecoder(r.Body).Decode(&subscription)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return CreateSubscriptionRequest{OrganizationID: orgID, Subscription: subscription}, nil
+}
+
+func encodeCreateSubscriptionHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateSubscriptionResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.NewSubscription, http.StatusCreated))
+}
+
+func decodeGetSubscriptionHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionID, err := extractUintParamFromRequest("subscriptionId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetSubscriptionRequest{OrganizationID: orgID, SubscriptionID: subscriptionID}, nil
+}
+
+func encodeGetSubscriptionHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetSubscriptionResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Subscription)
+}
+
+func decodeDeleteSubscriptionHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionID, err := extractUintParamFromRequest("subscriptionId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeleteSubscriptionRequest{OrganizationID: orgID, SubscriptionID: subscriptionID}, nil
+}
+
+func decodeListDeliveriesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionID, err := extractUintParamFromRequest("subscriptionId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListDeliveriesRequest{OrganizationID: orgID, SubscriptionID: subscriptionID}, nil
+}
+
+func encodeListDeliveriesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListDeliveriesResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Deliveries)
+}
+
+func decodeRedeliverHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionID, err := extractUintParamFromRequest("subscriptionId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryID, err := extractUintParamFromRequest("deliveryId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return RedeliverRequest{OrganizationID: orgID, SubscriptionID: subscriptionID, DeliveryID: deliveryID}, nil
+}
+
+func encodeRedeliverHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(RedeliverResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Delivery)
+}
+
+func extractUintParamFromRequest(key string, r *http.Request) (uint, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[key]
+	if !ok || value == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", key)
+	}
+
+	uintVal, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to parse path parameter", "param", key, "value", value)
+	}
+
+	return uint(uintVal), nil
+}
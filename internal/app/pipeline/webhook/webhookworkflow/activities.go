@@ -0,0 +1,71 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+// DeliverActivityName is the name of the activity sending a webhook delivery.
+const DeliverActivityName = "webhook-deliver"
+
+// DeliverActivityInput identifies the delivery to send.
+type DeliverActivityInput struct {
+	webhook.DeliveryRef
+
+	// MaxAttempts is the number of times the delivery is attempted before it is marked as failed.
+	MaxAttempts int
+}
+
+// DeliverActivity attempts to send a pending webhook delivery.
+//
+// The activity fails while the delivery is pending, so that it is retried according to its retry policy.
+type DeliverActivity struct {
+	deliverer webhook.Deliverer
+}
+
+// NewDeliverActivity returns a new DeliverActivity.
+func NewDeliverActivity(deliverer webhook.Deliverer) DeliverActivity {
+	return DeliverActivity{
+		deliverer: deliverer,
+	}
+}
+
+// Execute executes the activity.
+func (a DeliverActivity) Execute(ctx context.Context, input DeliverActivityInput) error {
+	lastAttempt := int(activity.GetInfo(ctx).Attempt)+1 >= input.MaxAttempts
+
+	delivery, err := a.deliverer.Deliver(ctx, input.DeliveryRef, lastAttempt)
+	if err != nil {
+		return err
+	}
+
+	if delivery.Status == webhook.DeliveryPending {
+		return errors.NewWithDetails("webhook delivery attempt failed", "deliveryId", delivery.ID, "error", delivery.Error)
+	}
+
+	return nil
+}
+
+// Register registers the activity in the worker.
+func (a DeliverActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: DeliverActivityName})
+}
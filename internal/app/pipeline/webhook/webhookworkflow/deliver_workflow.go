@@ -0,0 +1,81 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookworkflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+// DeliverWorkflowName is the name of the webhook delivery workflow.
+const DeliverWorkflowName = "webhook-deliver"
+
+// deliveryTimeout limits the time spent on retrying a single delivery.
+const deliveryTimeout = 24 * time.Hour
+
+// DeliverWorkflowInput is the input of the webhook delivery workflow.
+type DeliverWorkflowInput struct {
+	webhook.DeliveryRef
+
+	// MaxAttempts is the number of times the delivery is attempted before it is marked as failed.
+	MaxAttempts int
+
+	// RetryInterval is the initial time to wait between attempts. It doubles after every attempt.
+	RetryInterval time.Duration
+}
+
+// DeliverWorkflow sends a webhook delivery, retrying the failed attempts with exponential backoff.
+type DeliverWorkflow struct{}
+
+// NewDeliverWorkflow instantiates a webhook delivery workflow.
+func NewDeliverWorkflow() *DeliverWorkflow {
+	return &DeliverWorkflow{}
+}
+
+// Execute runs the workflow.
+func (w DeliverWorkflow) Execute(ctx workflow.Context, input DeliverWorkflowInput) error {
+	activityContext := workflow.WithActivityOptions(
+		ctx,
+		workflow.ActivityOptions{
+			ScheduleToStartTimeout: 10 * time.Minute,
+			StartToCloseTimeout:    5 * time.Minute,
+			WaitForCancellation:    true,
+			RetryPolicy: &cadence.RetryPolicy{
+				InitialInterval:          input.RetryInterval,
+				BackoffCoefficient:       2.0,
+				ExpirationInterval:       deliveryTimeout,
+				MaximumAttempts:          int32(input.MaxAttempts),
+				NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+			},
+		},
+	)
+
+	activityInput := DeliverActivityInput{
+		DeliveryRef: input.DeliveryRef,
+		MaxAttempts: input.MaxAttempts,
+	}
+
+	return workflow.ExecuteActivity(activityContext, DeliverActivityName, activityInput).Get(ctx, nil)
+}
+
+// Register registers the workflow in the worker.
+func (w DeliverWorkflow) Register(worker worker.Registry) {
+	worker.RegisterWorkflowWithOptions(w.Execute, workflow.RegisterOptions{Name: DeliverWorkflowName})
+}
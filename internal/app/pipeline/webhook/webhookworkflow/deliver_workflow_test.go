@@ -0,0 +1,62 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookworkflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+)
+
+func TestDeliverWorkflow(t *testing.T) {
+	env := (&testsuite.WorkflowTestSuite{}).NewTestWorkflowEnvironment()
+
+	NewDeliverWorkflow().Register(env)
+
+	var attempts []bool
+
+	env.RegisterActivityWithOptions(
+		func(ctx context.Context, input DeliverActivityInput) error {
+			lastAttempt := int(activity.GetInfo(ctx).Attempt)+1 >= input.MaxAttempts
+			attempts = append(attempts, lastAttempt)
+
+			if len(attempts) < 3 {
+				return errors.New("webhook delivery attempt failed")
+			}
+
+			return nil
+		},
+		activity.RegisterOptions{Name: DeliverActivityName},
+	)
+
+	env.ExecuteWorkflow(DeliverWorkflowName, DeliverWorkflowInput{
+		DeliveryRef:   webhook.DeliveryRef{OrganizationID: 1, SubscriptionID: 1, DeliveryID: 1},
+		MaxAttempts:   3,
+		RetryInterval: time.Second,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	assert.Equal(t, []bool{false, false, true}, attempts)
+}
@@ -0,0 +1,75 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookworkflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/webhook"
+)
+
+// DeliveryConfig configures the retries of webhook deliveries.
+type DeliveryConfig struct {
+	// MaxAttempts is the number of times a delivery is attempted before it is marked as failed.
+	MaxAttempts int
+
+	// RetryInterval is the initial time to wait between attempts. It doubles after every attempt.
+	RetryInterval time.Duration
+}
+
+// DeliveryScheduler sends webhook deliveries using workflows.
+type DeliveryScheduler struct {
+	workflowClient client.Client
+	config         DeliveryConfig
+}
+
+// NewDeliveryScheduler returns a new DeliveryScheduler.
+func NewDeliveryScheduler(workflowClient client.Client, config DeliveryConfig) DeliveryScheduler {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+
+	return DeliveryScheduler{
+		workflowClient: workflowClient,
+		config:         config,
+	}
+}
+
+// ScheduleDelivery starts the delivery workflow of a pending delivery.
+func (s DeliveryScheduler) ScheduleDelivery(ctx context.Context, ref webhook.DeliveryRef) error {
+	options := client.StartWorkflowOptions{
+		ID:                           fmt.Sprintf("%s-%d", DeliverWorkflowName, ref.DeliveryID),
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: deliveryTimeout + time.Hour,
+	}
+
+	input := DeliverWorkflowInput{
+		DeliveryRef:   ref,
+		MaxAttempts:   s.config.MaxAttempts,
+		RetryInterval: s.config.RetryInterval,
+	}
+
+	_, err := s.workflowClient.StartWorkflow(ctx, options, DeliverWorkflowName, input)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to start webhook delivery workflow", "deliveryId", ref.DeliveryID)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewProcessCommand returns a cobra command for `process` subcommands.
+func NewProcessCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "process",
+		Short: "Manage processes",
+	}
+
+	flags := cmd.PersistentFlags()
+
+	flags.Uint("organization", 0, "Organization ID")
+	_ = viper.BindPFlag("api.organization", flags.Lookup("organization"))
+
+	flags.String("token", "", "Pipeline API token")
+	_ = viper.BindPFlag("api.token", flags.Lookup("token"))
+
+	cmd.AddCommand(
+		NewRetryCommand(),
+	)
+
+	return cmd
+}
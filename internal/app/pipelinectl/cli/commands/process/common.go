@@ -0,0 +1,53 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+type processOptions struct {
+	apiUrl         string
+	token          string
+	organizationID uint
+}
+
+func newProcessRequest(options processOptions, path string) (*http.Request, error) {
+	if options.organizationID == 0 {
+		return nil, errors.New("organization ID is required")
+	}
+
+	u, err := url.Parse(options.apiUrl)
+	if err != nil {
+		return nil, errors.Errorf("invalid api url: %s", options.apiUrl)
+	}
+
+	u.Path = fmt.Sprintf("/api/v1/orgs/%d/processes/%s", options.organizationID, path)
+
+	req, err := http.NewRequest("", u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed  to create HTTP request")
+	}
+
+	if options.token != "" {
+		req.Header.Set("Authorization", "Bearer "+options.token)
+	}
+
+	return req, nil
+}
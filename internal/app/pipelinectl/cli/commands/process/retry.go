@@ -0,0 +1,74 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewRetryCommand creates a new cobra.Command for `pipelinectl process retry`.
+func NewRetryCommand() *cobra.Command {
+	options := processOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "retry PROCESS_ID",
+		Short: "Retry a failed process from the step that failed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.apiUrl = viper.GetString("api.url")
+			options.token = viper.GetString("api.token")
+			options.organizationID = viper.GetUint("api.organization")
+
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return runRetry(options, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runRetry(options processOptions, id string) error {
+	req, err := newProcessRequest(options, url.PathEscape(id)+"/retry")
+	if err != nil {
+		return err
+	}
+
+	req.Method = http.MethodPost
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "retrying process failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusAccepted {
+		fmt.Printf("Process %s is being retried.\n", id)
+
+		return nil
+	}
+
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	return errors.Errorf("retrying process failed: %s %s", resp.Status, body)
+}
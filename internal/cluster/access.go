@@ -0,0 +1,95 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+)
+
+// +testify:mock:testOnly=true
+
+// AccessPolicy decides whether the current user (found in the context) can access a cluster.
+type AccessPolicy interface {
+	// CanAccessCluster checks whether the current user can access a cluster.
+	CanAccessCluster(
+		ctx context.Context,
+		organizationID uint,
+		clusterID uint,
+		clusterName string,
+		clusterTags map[string]string,
+	) (bool, error)
+}
+
+// AccessChecker checks cluster access of the current user.
+type AccessChecker struct {
+	clusters Store
+	policy   AccessPolicy
+}
+
+// NewAccessChecker returns a new AccessChecker.
+func NewAccessChecker(clusters Store, policy AccessPolicy) AccessChecker {
+	return AccessChecker{
+		clusters: clusters,
+		policy:   policy,
+	}
+}
+
+// CanAccessCluster checks whether the current user can access a cluster.
+// Returns an error with the NotFound behavior when the cluster cannot be found.
+func (c AccessChecker) CanAccessCluster(ctx context.Context, clusterID uint) (bool, error) {
+	cluster, err := c.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return false, err
+	}
+
+	return c.policy.CanAccessCluster(ctx, cluster.OrganizationID, cluster.ID, cluster.Name, cluster.Tags)
+}
+
+// CheckClusterAccess makes sure the current user can access a cluster.
+// Clusters the user cannot access are reported as missing to avoid disclosing their existence.
+// Missing clusters are not reported: it is up to the caller to handle them.
+func (c AccessChecker) CheckClusterAccess(ctx context.Context, clusterIdentifier Identifier) error {
+	var (
+		cluster Cluster
+		err     error
+	)
+
+	if clusterIdentifier.ClusterName != "" {
+		cluster, err = c.clusters.GetClusterByName(ctx, clusterIdentifier.OrganizationID, clusterIdentifier.ClusterName)
+	} else {
+		cluster, err = c.clusters.GetCluster(ctx, clusterIdentifier.ClusterID)
+	}
+
+	if IsNotFoundError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	ok, err := c.policy.CanAccessCluster(ctx, cluster.OrganizationID, cluster.ID, cluster.Name, cluster.Tags)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return NotFoundError{
+			OrganizationID: cluster.OrganizationID,
+			ClusterID:      cluster.ID,
+			ClusterName:    cluster.Name,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessChecker_CheckClusterAccess(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := Cluster{
+		ID:             1,
+		OrganizationID: 1,
+		Name:           "dev-cluster",
+		Tags:           map[string]string{"env": "dev"},
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		policy := new(MockAccessPolicy)
+		policy.On("CanAccessCluster", ctx, cluster.OrganizationID, cluster.ID, cluster.Name, cluster.Tags).Return(true, nil)
+
+		checker := NewAccessChecker(store, policy)
+
+		err := checker.CheckClusterAccess(ctx, Identifier{ClusterID: cluster.ID})
+		require.NoError(t, err)
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("GetClusterByName", ctx, cluster.OrganizationID, cluster.Name).Return(cluster, nil)
+
+		policy := new(MockAccessPolicy)
+		policy.On("CanAccessCluster", ctx, cluster.OrganizationID, cluster.ID, cluster.Name, cluster.Tags).Return(false, nil)
+
+		checker := NewAccessChecker(store, policy)
+
+		err := checker.CheckClusterAccess(ctx, Identifier{OrganizationID: cluster.OrganizationID, ClusterName: cluster.Name})
+		require.Error(t, err)
+
+		assert.True(t, IsNotFoundError(err))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("GetCluster", ctx, uint(2)).Return(Cluster{}, NotFoundError{ClusterID: 2})
+
+		policy := new(MockAccessPolicy)
+
+		checker := NewAccessChecker(store, policy)
+
+		err := checker.CheckClusterAccess(ctx, Identifier{ClusterID: 2})
+		require.NoError(t, err)
+
+		policy.AssertNotCalled(t, "CanAccessCluster", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -99,3 +99,46 @@ func (s eksService) ListNodePools(ctx context.Context, clusterID uint) (nodePool
 
 	return nodePoolList, nil
 }
+
+// PlanUpdateCluster returns the changes UpdateCluster would make without applying them.
+//
+// The control plane version change is planned by the generic cluster service,
+// updating an EKS cluster has no distribution specific changes.
+func (s eksService) PlanUpdateCluster(ctx context.Context, clusterIdentifier cluster.Identifier, rawUpdate cluster.ClusterUpdate) (cluster.Plan, error) {
+	var clusterUpdate eks.ClusterUpdate
+
+	err := mapstructure.Decode(rawUpdate, &clusterUpdate)
+	if err != nil {
+		// TODO: return a service error
+		return cluster.Plan{}, errors.Wrap(err, "failed to decode cluster update")
+	}
+
+	return cluster.Plan{}, nil
+}
+
+func (s eksService) PlanCreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]cluster.NewRawNodePool) (cluster.Plan, error) {
+	var nodePools map[string]eks.NewNodePool
+	err := mapstructure.Decode(rawNodePools, &nodePools)
+	if err != nil {
+		return cluster.Plan{}, cluster.NewValidationError(
+			"invalid node pool creation request",
+			[]string{
+				fmt.Sprintf("invalid structure: %s, expected: %+v, actual: %+v", err.Error(), nodePools, rawNodePools),
+			},
+		)
+	}
+
+	return s.service.PlanCreateNodePools(ctx, clusterID, nodePools)
+}
+
+func (s eksService) PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate cluster.RawNodePoolUpdate) (cluster.Plan, error) {
+	var nodePoolUpdate eks.NodePoolUpdate
+
+	err := mapstructure.Decode(rawNodePoolUpdate, &nodePoolUpdate)
+	if err != nil {
+		// TODO: return a service error
+		return cluster.Plan{}, errors.Wrap(err, "failed to decode node pool update")
+	}
+
+	return s.service.PlanUpdateNodePool(ctx, clusterID, nodePoolName, nodePoolUpdate)
+}
@@ -87,3 +87,35 @@ func (s pkeService) ListNodePools(ctx context.Context, clusterID uint) (nodePool
 
 	return nodePoolList, nil
 }
+
+// PlanUpdateCluster returns the changes UpdateCluster would make without applying them.
+//
+// The version change is planned by the generic cluster service,
+// updating a PKE cluster has no distribution specific changes.
+func (s pkeService) PlanUpdateCluster(ctx context.Context, clusterIdentifier cluster.Identifier, rawUpdate cluster.ClusterUpdate) (cluster.Plan, error) {
+	var clusterUpdate pke.ClusterUpdate
+
+	err := mapstructure.Decode(rawUpdate, &clusterUpdate)
+	if err != nil {
+		// TODO: return a service error
+		return cluster.Plan{}, errors.Wrap(err, "failed to decode cluster update")
+	}
+
+	return cluster.Plan{}, nil
+}
+
+func (s pkeService) PlanCreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]cluster.NewRawNodePool) (cluster.Plan, error) {
+	panic("implement me")
+}
+
+func (s pkeService) PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate cluster.RawNodePoolUpdate) (cluster.Plan, error) {
+	var nodePoolUpdate pke.NodePoolUpdate
+
+	err := mapstructure.Decode(rawNodePoolUpdate, &nodePoolUpdate)
+	if err != nil {
+		// TODO: return a service error
+		return cluster.Plan{}, errors.Wrap(err, "failed to decode node pool update")
+	}
+
+	return s.service.PlanUpdateNodePool(ctx, clusterID, nodePoolName, nodePoolUpdate)
+}
@@ -0,0 +1,149 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	azurepke "github.com/banzaicloud/pipeline/internal/providers/azure/pke"
+	"github.com/banzaicloud/pipeline/pkg/providers"
+)
+
+// NewAzurePKEService returns a new Azure PKE distribution service.
+//
+// Azure PKE clusters are still managed by the legacy cluster API,
+// so the service only supports planning node pool updates.
+func NewAzurePKEService(clusters azurepke.ClusterStore) cluster.Service {
+	return azurePKEService{
+		clusters: clusters,
+	}
+}
+
+type azurePKEService struct {
+	clusters azurepke.ClusterStore
+}
+
+// azurePKENodePoolUpdate is the subset of a node pool update applicable to Azure PKE node pools.
+type azurePKENodePoolUpdate struct {
+	Size        int `mapstructure:"size"`
+	Autoscaling struct {
+		Enabled bool `mapstructure:"enabled"`
+		MinSize int  `mapstructure:"minSize"`
+		MaxSize int  `mapstructure:"maxSize"`
+	} `mapstructure:"autoscaling"`
+}
+
+func (s azurePKEService) UpdateCluster(ctx context.Context, clusterIdentifier cluster.Identifier, rawUpdate cluster.ClusterUpdate) error {
+	return s.notSupported(clusterIdentifier.ClusterID)
+}
+
+func (s azurePKEService) DeleteCluster(ctx context.Context, clusterIdentifier cluster.Identifier, options cluster.DeleteClusterOptions) (deleted bool, err error) {
+	return false, s.notSupported(clusterIdentifier.ClusterID)
+}
+
+func (s azurePKEService) CreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]cluster.NewRawNodePool) error {
+	return s.notSupported(clusterID)
+}
+
+func (s azurePKEService) UpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate cluster.RawNodePoolUpdate) (string, error) {
+	return "", s.notSupported(clusterID)
+}
+
+func (s azurePKEService) DeleteNodePool(ctx context.Context, clusterID uint, nodePoolName string) (isDeleted bool, err error) {
+	return false, s.notSupported(clusterID)
+}
+
+func (s azurePKEService) ListNodePools(ctx context.Context, clusterID uint) (nodePoolList cluster.RawNodePoolList, err error) {
+	return nil, s.notSupported(clusterID)
+}
+
+func (s azurePKEService) PlanUpdateCluster(ctx context.Context, clusterIdentifier cluster.Identifier, rawUpdate cluster.ClusterUpdate) (cluster.Plan, error) {
+	return cluster.Plan{}, s.notSupported(clusterIdentifier.ClusterID)
+}
+
+func (s azurePKEService) PlanCreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]cluster.NewRawNodePool) (cluster.Plan, error) {
+	return cluster.Plan{}, s.notSupported(clusterID)
+}
+
+// PlanUpdateNodePool returns the virtual machine scale set changes of a node pool update.
+func (s azurePKEService) PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate cluster.RawNodePoolUpdate) (cluster.Plan, error) {
+	var nodePoolUpdate azurePKENodePoolUpdate
+
+	err := mapstructure.Decode(rawNodePoolUpdate, &nodePoolUpdate)
+	if err != nil {
+		// TODO: return a service error
+		return cluster.Plan{}, errors.Wrap(err, "failed to decode node pool update")
+	}
+
+	c, err := s.clusters.GetByID(clusterID)
+	if err != nil {
+		return cluster.Plan{}, err
+	}
+
+	var (
+		current azurepke.NodePool
+		found   bool
+	)
+
+	for _, nodePool := range c.NodePools {
+		if nodePool.Name == nodePoolName {
+			current = nodePool
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return cluster.Plan{}, errors.WithStack(cluster.NodePoolNotFoundError{
+			ClusterID: clusterID,
+			NodePool:  nodePoolName,
+		})
+	}
+
+	var plan cluster.Plan
+
+	plan.AddUpdate(cluster.PlanResourceNodePool, nodePoolName, "autoscaling", current.Autoscaling, nodePoolUpdate.Autoscaling.Enabled)
+
+	if nodePoolUpdate.Autoscaling.Enabled {
+		plan.AddUpdate(cluster.PlanResourceNodePool, nodePoolName, "minSize", current.Min, uint(nodePoolUpdate.Autoscaling.MinSize))
+		plan.AddUpdate(cluster.PlanResourceNodePool, nodePoolName, "maxSize", current.Max, uint(nodePoolUpdate.Autoscaling.MaxSize))
+	} else {
+		// Scale sets of autoscaled node pools are resized by the cluster autoscaler.
+		plan.AddUpdate(
+			cluster.PlanResourceVirtualMachineScaleSet,
+			azurepke.GetVMSSName(c.Name, nodePoolName),
+			"capacity",
+			current.DesiredCount,
+			uint(nodePoolUpdate.Size),
+		)
+	}
+
+	return plan, nil
+}
+
+func (s azurePKEService) notSupported(clusterID uint) error {
+	return errors.WithStack(cluster.NotSupportedDistributionError{
+		ID:           clusterID,
+		Cloud:        providers.Azure,
+		Distribution: "pke",
+
+		Message: "the node pool API does not support this distribution yet",
+	})
+}
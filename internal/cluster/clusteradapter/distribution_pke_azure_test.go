@@ -0,0 +1,120 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterbase"
+	azurepke "github.com/banzaicloud/pipeline/internal/providers/azure/pke"
+)
+
+type azurePKEClusterStoreStub struct {
+	azurepke.ClusterStore
+
+	cluster azurepke.Cluster
+}
+
+func (s azurePKEClusterStoreStub) GetByID(clusterID uint) (azurepke.Cluster, error) {
+	return s.cluster, nil
+}
+
+func TestAzurePKEServicePlanUpdateNodePool(t *testing.T) {
+	service := NewAzurePKEService(azurePKEClusterStoreStub{
+		cluster: azurepke.Cluster{
+			ClusterBase: clusterbase.ClusterBase{
+				Name: "cluster",
+			},
+			NodePools: []azurepke.NodePool{
+				{
+					Name:         "pool0",
+					DesiredCount: 3,
+					Min:          1,
+					Max:          3,
+				},
+			},
+		},
+	})
+
+	t.Run("Resize", func(t *testing.T) {
+		plan, err := service.PlanUpdateNodePool(context.Background(), 1, "pool0", cluster.RawNodePoolUpdate{
+			"size":        int32(5),
+			"autoscaling": pipeline.NodePoolAutoScaling{},
+		})
+		require.NoError(t, err)
+
+		require.Equal(
+			t,
+			[]cluster.PlanChange{
+				{
+					Action:    cluster.PlanActionUpdate,
+					Resource:  cluster.PlanResourceVirtualMachineScaleSet,
+					Name:      "cluster-pool0",
+					Attribute: "capacity",
+					Current:   uint(3),
+					Desired:   uint(5),
+				},
+			},
+			plan.Changes,
+		)
+	})
+
+	t.Run("EnableAutoscaling", func(t *testing.T) {
+		plan, err := service.PlanUpdateNodePool(context.Background(), 1, "pool0", cluster.RawNodePoolUpdate{
+			"size": int32(3),
+			"autoscaling": pipeline.NodePoolAutoScaling{
+				Enabled: true,
+				MinSize: 1,
+				MaxSize: 5,
+			},
+		})
+		require.NoError(t, err)
+
+		require.Equal(
+			t,
+			[]cluster.PlanChange{
+				{
+					Action:    cluster.PlanActionUpdate,
+					Resource:  cluster.PlanResourceNodePool,
+					Name:      "pool0",
+					Attribute: "autoscaling",
+					Current:   false,
+					Desired:   true,
+				},
+				{
+					Action:    cluster.PlanActionUpdate,
+					Resource:  cluster.PlanResourceNodePool,
+					Name:      "pool0",
+					Attribute: "maxSize",
+					Current:   uint(3),
+					Desired:   uint(5),
+				},
+			},
+			plan.Changes,
+		)
+	})
+
+	t.Run("NodePoolNotFound", func(t *testing.T) {
+		_, err := service.PlanUpdateNodePool(context.Background(), 1, "pool1", cluster.RawNodePoolUpdate{})
+		require.Error(t, err)
+
+		require.True(t, cluster.IsNotFoundError(err))
+	})
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
+	azurePKEAdapter "github.com/banzaicloud/pipeline/internal/providers/azure/pke/adapter"
 	"github.com/banzaicloud/pipeline/internal/providers/pke"
 	"github.com/banzaicloud/pipeline/pkg/providers"
 )
@@ -100,6 +101,31 @@ func (s nodePoolStore) NodePoolExists(ctx context.Context, clusterID uint, name
 		}
 
 		storedName = pkeCluster.NodePools[0].Name
+
+	case c.Cloud == providers.Azure && c.Distribution == "pke":
+		var nodePools []struct {
+			Name string
+		}
+
+		err := s.db.
+			Table(azurePKEAdapter.NodePoolsTableName).
+			Where("cluster_id = ? AND name = ? AND deleted_at IS NULL", clusterID, name).
+			Select("name").
+			Scan(&nodePools).Error
+		if err != nil {
+			return false, "", errors.WrapWithDetails(
+				err, "failed to check if node pool exists",
+				"clusterId", clusterID,
+				"nodePoolName", name,
+			)
+		}
+
+		if len(nodePools) == 0 {
+			return false, "", nil
+		}
+
+		storedName = nodePools[0].Name
+
 	default:
 		return false, "", errors.WithStack(cluster.NotSupportedDistributionError{
 			ID:           c.ID,
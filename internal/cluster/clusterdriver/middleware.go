@@ -0,0 +1,121 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterdriver
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// Middleware describes a service middleware.
+type Middleware func(cluster.Service) cluster.Service
+
+// AccessMiddleware makes sure the current user can access the cluster a call refers to.
+func AccessMiddleware(checker AccessChecker) Middleware {
+	return func(next cluster.Service) cluster.Service {
+		return accessMiddleware{
+			next: next,
+
+			checker: checker,
+		}
+	}
+}
+
+// +testify:mock:testOnly=true
+
+// AccessChecker checks whether the current user can access a cluster.
+type AccessChecker interface {
+	// CheckClusterAccess makes sure the current user can access a cluster.
+	CheckClusterAccess(ctx context.Context, clusterIdentifier cluster.Identifier) error
+}
+
+type accessMiddleware struct {
+	next cluster.Service
+
+	checker AccessChecker
+}
+
+func (m accessMiddleware) UpdateCluster(ctx context.Context, clusterIdentifier cluster.Identifier, clusterUpdate cluster.ClusterUpdate) error {
+	if err := m.checker.CheckClusterAccess(ctx, clusterIdentifier); err != nil {
+		return err
+	}
+
+	return m.next.UpdateCluster(ctx, clusterIdentifier, clusterUpdate)
+}
+
+func (m accessMiddleware) DeleteCluster(ctx context.Context, clusterIdentifier cluster.Identifier, options cluster.DeleteClusterOptions) (bool, error) {
+	if err := m.checker.CheckClusterAccess(ctx, clusterIdentifier); err != nil {
+		return false, err
+	}
+
+	return m.next.DeleteCluster(ctx, clusterIdentifier, options)
+}
+
+func (m accessMiddleware) CreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]cluster.NewRawNodePool) error {
+	if err := m.checker.CheckClusterAccess(ctx, cluster.Identifier{ClusterID: clusterID}); err != nil {
+		return err
+	}
+
+	return m.next.CreateNodePools(ctx, clusterID, rawNodePools)
+}
+
+func (m accessMiddleware) UpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate cluster.RawNodePoolUpdate) (string, error) {
+	if err := m.checker.CheckClusterAccess(ctx, cluster.Identifier{ClusterID: clusterID}); err != nil {
+		return "", err
+	}
+
+	return m.next.UpdateNodePool(ctx, clusterID, nodePoolName, rawNodePoolUpdate)
+}
+
+func (m accessMiddleware) DeleteNodePool(ctx context.Context, clusterID uint, name string) (bool, error) {
+	if err := m.checker.CheckClusterAccess(ctx, cluster.Identifier{ClusterID: clusterID}); err != nil {
+		return false, err
+	}
+
+	return m.next.DeleteNodePool(ctx, clusterID, name)
+}
+
+func (m accessMiddleware) ListNodePools(ctx context.Context, clusterID uint) (cluster.RawNodePoolList, error) {
+	if err := m.checker.CheckClusterAccess(ctx, cluster.Identifier{ClusterID: clusterID}); err != nil {
+		return nil, err
+	}
+
+	return m.next.ListNodePools(ctx, clusterID)
+}
+
+func (m accessMiddleware) PlanUpdateCluster(ctx context.Context, clusterIdentifier cluster.Identifier, clusterUpdate cluster.ClusterUpdate) (cluster.Plan, error) {
+	if err := m.checker.CheckClusterAccess(ctx, clusterIdentifier); err != nil {
+		return cluster.Plan{}, err
+	}
+
+	return m.next.PlanUpdateCluster(ctx, clusterIdentifier, clusterUpdate)
+}
+
+func (m accessMiddleware) PlanCreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]cluster.NewRawNodePool) (cluster.Plan, error) {
+	if err := m.checker.CheckClusterAccess(ctx, cluster.Identifier{ClusterID: clusterID}); err != nil {
+		return cluster.Plan{}, err
+	}
+
+	return m.next.PlanCreateNodePools(ctx, clusterID, rawNodePools)
+}
+
+func (m accessMiddleware) PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate cluster.RawNodePoolUpdate) (cluster.Plan, error) {
+	if err := m.checker.CheckClusterAccess(ctx, cluster.Identifier{ClusterID: clusterID}); err != nil {
+		return cluster.Plan{}, err
+	}
+
+	return m.next.PlanUpdateNodePool(ctx, clusterID, nodePoolName, rawNodePoolUpdate)
+}
@@ -76,6 +76,27 @@ func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...ki
 		kitxhttp.ErrorResponseEncoder(encodeDeleteNodePoolHTTPResponse, errorEncoder),
 		options...,
 	))
+
+	router.Methods(http.MethodPost).Path("/update/plan").Handler(kithttp.NewServer(
+		endpoints.PlanUpdateCluster,
+		decodePlanUpdateClusterHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodePlanUpdateClusterHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/nodepools/plan").Handler(kithttp.NewServer(
+		endpoints.PlanCreateNodePools,
+		decodePlanCreateNodePoolsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodePlanCreateNodePoolsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/nodepools/{nodePoolName}/update/plan").Handler(kithttp.NewServer(
+		endpoints.PlanUpdateNodePool,
+		decodePlanUpdateNodePoolHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodePlanUpdateNodePoolHTTPResponse, errorEncoder),
+		options...,
+	))
 }
 
 func decodeDeleteClusterHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -382,3 +403,72 @@ func decodeUpdateClusterHTTPRequest(_ context.Context, r *http.Request) (interfa
 		ClusterUpdate: update,
 	}, nil
 }
+
+func decodePlanUpdateClusterHTTPRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeUpdateClusterHTTPRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	updateRequest := req.(UpdateClusterRequest)
+
+	return PlanUpdateClusterRequest{
+		ClusterIdentifier: updateRequest.ClusterIdentifier,
+		ClusterUpdate:     updateRequest.ClusterUpdate,
+	}, nil
+}
+
+func encodePlanUpdateClusterHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(PlanUpdateClusterResponse)
+
+	return encodePlan(ctx, w, resp.Plan)
+}
+
+func decodePlanCreateNodePoolsHTTPRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeCreateNodePoolsHTTPRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	createRequest := req.(CreateNodePoolsRequest)
+
+	return PlanCreateNodePoolsRequest{
+		ClusterID:    createRequest.ClusterID,
+		RawNodePools: createRequest.RawNodePools,
+	}, nil
+}
+
+func encodePlanCreateNodePoolsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(PlanCreateNodePoolsResponse)
+
+	return encodePlan(ctx, w, resp.Plan)
+}
+
+func decodePlanUpdateNodePoolHTTPRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeUpdateNodePoolHTTPRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	updateRequest := req.(UpdateNodePoolRequest)
+
+	return PlanUpdateNodePoolRequest{
+		ClusterID:         updateRequest.ClusterID,
+		NodePoolName:      updateRequest.NodePoolName,
+		RawNodePoolUpdate: updateRequest.RawNodePoolUpdate,
+	}, nil
+}
+
+func encodePlanUpdateNodePoolHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(PlanUpdateNodePoolResponse)
+
+	return encodePlan(ctx, w, resp.Plan)
+}
+
+func encodePlan(ctx context.Context, w http.ResponseWriter, plan cluster.Plan) error {
+	if plan.Changes == nil {
+		plan.Changes = []cluster.PlanChange{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(plan, http.StatusOK))
+}
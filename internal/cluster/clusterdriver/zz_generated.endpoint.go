@@ -27,12 +27,15 @@ type serviceError interface {
 // meant to be used as a helper struct, to collect all of the endpoints into a
 // single parameter.
 type Endpoints struct {
-	CreateNodePools endpoint.Endpoint
-	DeleteCluster   endpoint.Endpoint
-	DeleteNodePool  endpoint.Endpoint
-	ListNodePools   endpoint.Endpoint
-	UpdateCluster   endpoint.Endpoint
-	UpdateNodePool  endpoint.Endpoint
+	CreateNodePools     endpoint.Endpoint
+	DeleteCluster       endpoint.Endpoint
+	DeleteNodePool      endpoint.Endpoint
+	ListNodePools       endpoint.Endpoint
+	PlanCreateNodePools endpoint.Endpoint
+	PlanUpdateCluster   endpoint.Endpoint
+	PlanUpdateNodePool  endpoint.Endpoint
+	UpdateCluster       endpoint.Endpoint
+	UpdateNodePool      endpoint.Endpoint
 }
 
 // MakeEndpoints returns a(n) Endpoints struct where each endpoint invokes
@@ -41,12 +44,15 @@ func MakeEndpoints(service cluster.Service, middleware ...endpoint.Middleware) E
 	mw := kitxendpoint.Combine(middleware...)
 
 	return Endpoints{
-		CreateNodePools: kitxendpoint.OperationNameMiddleware("cluster.CreateNodePools")(mw(MakeCreateNodePoolsEndpoint(service))),
-		DeleteCluster:   kitxendpoint.OperationNameMiddleware("cluster.DeleteCluster")(mw(MakeDeleteClusterEndpoint(service))),
-		DeleteNodePool:  kitxendpoint.OperationNameMiddleware("cluster.DeleteNodePool")(mw(MakeDeleteNodePoolEndpoint(service))),
-		ListNodePools:   kitxendpoint.OperationNameMiddleware("cluster.ListNodePools")(mw(MakeListNodePoolsEndpoint(service))),
-		UpdateCluster:   kitxendpoint.OperationNameMiddleware("cluster.UpdateCluster")(mw(MakeUpdateClusterEndpoint(service))),
-		UpdateNodePool:  kitxendpoint.OperationNameMiddleware("cluster.UpdateNodePool")(mw(MakeUpdateNodePoolEndpoint(service))),
+		CreateNodePools:     kitxendpoint.OperationNameMiddleware("cluster.CreateNodePools")(mw(MakeCreateNodePoolsEndpoint(service))),
+		DeleteCluster:       kitxendpoint.OperationNameMiddleware("cluster.DeleteCluster")(mw(MakeDeleteClusterEndpoint(service))),
+		DeleteNodePool:      kitxendpoint.OperationNameMiddleware("cluster.DeleteNodePool")(mw(MakeDeleteNodePoolEndpoint(service))),
+		ListNodePools:       kitxendpoint.OperationNameMiddleware("cluster.ListNodePools")(mw(MakeListNodePoolsEndpoint(service))),
+		PlanCreateNodePools: kitxendpoint.OperationNameMiddleware("cluster.PlanCreateNodePools")(mw(MakePlanCreateNodePoolsEndpoint(service))),
+		PlanUpdateCluster:   kitxendpoint.OperationNameMiddleware("cluster.PlanUpdateCluster")(mw(MakePlanUpdateClusterEndpoint(service))),
+		PlanUpdateNodePool:  kitxendpoint.OperationNameMiddleware("cluster.PlanUpdateNodePool")(mw(MakePlanUpdateNodePoolEndpoint(service))),
+		UpdateCluster:       kitxendpoint.OperationNameMiddleware("cluster.UpdateCluster")(mw(MakeUpdateClusterEndpoint(service))),
+		UpdateNodePool:      kitxendpoint.OperationNameMiddleware("cluster.UpdateNodePool")(mw(MakeUpdateNodePoolEndpoint(service))),
 	}
 }
 
@@ -206,6 +212,130 @@ func MakeListNodePoolsEndpoint(service cluster.Service) endpoint.Endpoint {
 	}
 }
 
+// PlanCreateNodePoolsRequest is a request struct for PlanCreateNodePools endpoint.
+type PlanCreateNodePoolsRequest struct {
+	ClusterID    uint
+	RawNodePools map[string]cluster.NewRawNodePool
+}
+
+// PlanCreateNodePoolsResponse is a response struct for PlanCreateNodePools endpoint.
+type PlanCreateNodePoolsResponse struct {
+	Plan cluster.Plan
+	Err  error
+}
+
+func (r PlanCreateNodePoolsResponse) Failed() error {
+	return r.Err
+}
+
+// MakePlanCreateNodePoolsEndpoint returns an endpoint for the matching method of the underlying service.
+func MakePlanCreateNodePoolsEndpoint(service cluster.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(PlanCreateNodePoolsRequest)
+
+		plan, err := service.PlanCreateNodePools(ctx, req.ClusterID, req.RawNodePools)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return PlanCreateNodePoolsResponse{
+					Err:  err,
+					Plan: plan,
+				}, nil
+			}
+
+			return PlanCreateNodePoolsResponse{
+				Err:  err,
+				Plan: plan,
+			}, err
+		}
+
+		return PlanCreateNodePoolsResponse{Plan: plan}, nil
+	}
+}
+
+// PlanUpdateClusterRequest is a request struct for PlanUpdateCluster endpoint.
+type PlanUpdateClusterRequest struct {
+	ClusterIdentifier cluster.Identifier
+	ClusterUpdate     cluster.ClusterUpdate
+}
+
+// PlanUpdateClusterResponse is a response struct for PlanUpdateCluster endpoint.
+type PlanUpdateClusterResponse struct {
+	Plan cluster.Plan
+	Err  error
+}
+
+func (r PlanUpdateClusterResponse) Failed() error {
+	return r.Err
+}
+
+// MakePlanUpdateClusterEndpoint returns an endpoint for the matching method of the underlying service.
+func MakePlanUpdateClusterEndpoint(service cluster.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(PlanUpdateClusterRequest)
+
+		plan, err := service.PlanUpdateCluster(ctx, req.ClusterIdentifier, req.ClusterUpdate)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return PlanUpdateClusterResponse{
+					Err:  err,
+					Plan: plan,
+				}, nil
+			}
+
+			return PlanUpdateClusterResponse{
+				Err:  err,
+				Plan: plan,
+			}, err
+		}
+
+		return PlanUpdateClusterResponse{Plan: plan}, nil
+	}
+}
+
+// PlanUpdateNodePoolRequest is a request struct for PlanUpdateNodePool endpoint.
+type PlanUpdateNodePoolRequest struct {
+	ClusterID         uint
+	NodePoolName      string
+	RawNodePoolUpdate cluster.RawNodePoolUpdate
+}
+
+// PlanUpdateNodePoolResponse is a response struct for PlanUpdateNodePool endpoint.
+type PlanUpdateNodePoolResponse struct {
+	Plan cluster.Plan
+	Err  error
+}
+
+func (r PlanUpdateNodePoolResponse) Failed() error {
+	return r.Err
+}
+
+// MakePlanUpdateNodePoolEndpoint returns an endpoint for the matching method of the underlying service.
+func MakePlanUpdateNodePoolEndpoint(service cluster.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(PlanUpdateNodePoolRequest)
+
+		plan, err := service.PlanUpdateNodePool(ctx, req.ClusterID, req.NodePoolName, req.RawNodePoolUpdate)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return PlanUpdateNodePoolResponse{
+					Err:  err,
+					Plan: plan,
+				}, nil
+			}
+
+			return PlanUpdateNodePoolResponse{
+				Err:  err,
+				Plan: plan,
+			}, err
+		}
+
+		return PlanUpdateNodePoolResponse{Plan: plan}, nil
+	}
+}
+
 // UpdateClusterRequest is a request struct for UpdateCluster endpoint.
 type UpdateClusterRequest struct {
 	ClusterIdentifier cluster.Identifier
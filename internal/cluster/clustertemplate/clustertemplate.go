@@ -0,0 +1,481 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"emperror.dev/errors"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// Parameter types.
+const (
+	ParameterTypeString  = "string"
+	ParameterTypeInteger = "integer"
+	ParameterTypeNumber  = "number"
+	ParameterTypeBoolean = "boolean"
+)
+
+// Template is a versioned blueprint for creating clusters.
+//
+// Parameters can be referenced anywhere in the cluster, integrated service and release specs using the ${name} syntax.
+type Template struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Version     int    `json:"version"`
+	Description string `json:"description,omitempty"`
+
+	Parameters []Parameter `json:"parameters,omitempty"`
+
+	// Cluster is a cluster creation request (including node pools and post hooks).
+	Cluster map[string]interface{} `json:"cluster"`
+
+	// IntegratedServices are activated once the cluster is running.
+	IntegratedServices []IntegratedService `json:"integratedServices,omitempty"`
+
+	// Releases are installed once the cluster is running.
+	Releases []Release `json:"releases,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// Parameter is a typed value provided when a cluster is created from a template.
+type Parameter struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+}
+
+// IntegratedService is an integrated service activated on clusters created from a template.
+type IntegratedService struct {
+	Name string                 `json:"name"`
+	Spec map[string]interface{} `json:"spec"`
+}
+
+// Release is a Helm release installed on clusters created from a template.
+type Release struct {
+	ReleaseName string                 `json:"releaseName"`
+	ChartName   string                 `json:"chartName"`
+	Version     string                 `json:"version,omitempty"`
+	Namespace   string                 `json:"namespace,omitempty"`
+	Values      map[string]interface{} `json:"values,omitempty"`
+}
+
+// Instance statuses.
+const (
+	InstancePending = "pending"
+	InstanceApplied = "applied"
+	InstanceFailed  = "failed"
+)
+
+// Instance records a cluster created from a template.
+//
+// The integrated services and releases of the template are applied once the cluster is running.
+type Instance struct {
+	ID              uint   `json:"id"`
+	OrganizationID  uint   `json:"organizationId"`
+	TemplateName    string `json:"templateName"`
+	TemplateVersion int    `json:"templateVersion"`
+	ClusterID       uint   `json:"clusterId"`
+	ClusterName     string `json:"clusterName"`
+
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	IntegratedServices []IntegratedService `json:"integratedServices,omitempty"`
+	Releases           []Release           `json:"releases,omitempty"`
+
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// CreateClusterRequest contains the parameters of a cluster created from a template.
+type CreateClusterRequest struct {
+	// Version selects a template version. The latest version is used when omitted.
+	Version int `json:"version,omitempty"`
+
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock
+
+// Service manages cluster templates.
+type Service interface {
+	// ListTemplates lists the latest version of every cluster template of an organization.
+	ListTemplates(ctx context.Context, organizationID uint) (templates []Template, err error)
+
+	// ListTemplateVersions lists every version of a cluster template.
+	ListTemplateVersions(ctx context.Context, organizationID uint, name string) (templates []Template, err error)
+
+	// GetTemplate returns a version of a cluster template. Version 0 returns the latest version.
+	GetTemplate(ctx context.Context, organizationID uint, name string, version int) (template Template, err error)
+
+	// CreateTemplate creates a new cluster template or a new version of an existing one.
+	CreateTemplate(ctx context.Context, organizationID uint, template Template) (newTemplate Template, err error)
+
+	// DeleteTemplate deletes every version of a cluster template.
+	DeleteTemplate(ctx context.Context, organizationID uint, name string) error
+
+	// CreateCluster renders a cluster template with the provided parameters and creates a cluster from it.
+	CreateCluster(ctx context.Context, organizationID uint, name string, request CreateClusterRequest) (instance Instance, err error)
+
+	// ListInstances lists the clusters created from a cluster template.
+	ListInstances(ctx context.Context, organizationID uint, name string) (instances []Instance, err error)
+}
+
+// +testify:mock:testOnly=true
+
+// Store is a persistence layer for cluster templates.
+type Store interface {
+	// ListTemplates lists the latest version of every cluster template of an organization.
+	ListTemplates(ctx context.Context, organizationID uint) ([]Template, error)
+
+	// ListTemplateVersions lists every version of a cluster template.
+	ListTemplateVersions(ctx context.Context, organizationID uint, name string) ([]Template, error)
+
+	// GetTemplate returns a version of a cluster template. Version 0 returns the latest version.
+	GetTemplate(ctx context.Context, organizationID uint, name string, version int) (Template, error)
+
+	// CreateTemplate persists a template as the next version of the named template.
+	CreateTemplate(ctx context.Context, organizationID uint, template Template) (Template, error)
+
+	// DeleteTemplate deletes every version of a cluster template.
+	DeleteTemplate(ctx context.Context, organizationID uint, name string) error
+
+	// CreateInstance persists a new template instance.
+	CreateInstance(ctx context.Context, instance Instance) (Instance, error)
+
+	// ListInstances lists the instances of a cluster template.
+	ListInstances(ctx context.Context, organizationID uint, name string) ([]Instance, error)
+
+	// ListPendingInstances lists every instance waiting to be applied.
+	ListPendingInstances(ctx context.Context) ([]Instance, error)
+
+	// UpdateInstanceStatus updates the status of a template instance.
+	UpdateInstanceStatus(ctx context.Context, id uint, status string, message string) error
+
+	// UpdateInstanceCluster records the cluster created for a template instance and updates its status.
+	UpdateInstanceCluster(ctx context.Context, id uint, clusterID uint, status string) error
+}
+
+// +testify:mock:testOnly=true
+
+// ClusterCreator creates clusters.
+type ClusterCreator interface {
+	// CreateClusterFromRequest validates a cluster creation request and starts creating the cluster.
+	CreateClusterFromRequest(ctx context.Context, organizationID uint, userID uint, request pkgCluster.CreateClusterRequest) (uint, error)
+}
+
+// UserExtractor extracts user information from the context.
+type UserExtractor interface {
+	// GetUserID returns the ID of the currently authenticated user.
+	// If a user cannot be found in the context, it returns false as the second return value.
+	GetUserID(ctx context.Context) (uint, bool)
+}
+
+// NewService returns a new Service.
+func NewService(store Store, clusters ClusterCreator, userExtractor UserExtractor) Service {
+	return service{
+		store:         store,
+		clusters:      clusters,
+		userExtractor: userExtractor,
+	}
+}
+
+type service struct {
+	store         Store
+	clusters      ClusterCreator
+	userExtractor UserExtractor
+}
+
+func (s service) ListTemplates(ctx context.Context, organizationID uint) ([]Template, error) {
+	return s.store.ListTemplates(ctx, organizationID)
+}
+
+func (s service) ListTemplateVersions(ctx context.Context, organizationID uint, name string) ([]Template, error) {
+	templates, err := s.store.ListTemplateVersions(ctx, organizationID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templates) == 0 {
+		return nil, errors.WithStack(NotFoundError{Name: name})
+	}
+
+	return templates, nil
+}
+
+func (s service) GetTemplate(ctx context.Context, organizationID uint, name string, version int) (Template, error) {
+	return s.store.GetTemplate(ctx, organizationID, name, version)
+}
+
+func (s service) CreateTemplate(ctx context.Context, organizationID uint, template Template) (Template, error) {
+	if violations := validateTemplate(template); len(violations) > 0 {
+		return Template{}, NewValidationError("invalid cluster template", violations)
+	}
+
+	return s.store.CreateTemplate(ctx, organizationID, template)
+}
+
+func (s service) DeleteTemplate(ctx context.Context, organizationID uint, name string) error {
+	return s.store.DeleteTemplate(ctx, organizationID, name)
+}
+
+func (s service) CreateCluster(ctx context.Context, organizationID uint, name string, request CreateClusterRequest) (Instance, error) {
+	userID, ok := s.userExtractor.GetUserID(ctx)
+	if !ok {
+		return Instance{}, errors.New("user not found in the context")
+	}
+
+	template, err := s.store.GetTemplate(ctx, organizationID, name, request.Version)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	parameters, violations := resolveParameters(template.Parameters, request.Parameters)
+	if len(violations) > 0 {
+		return Instance{}, NewValidationError("invalid template parameters", violations)
+	}
+
+	instance := Instance{
+		OrganizationID:  organizationID,
+		TemplateName:    template.Name,
+		TemplateVersion: template.Version,
+		Parameters:      parameters,
+		Status:          InstancePending,
+	}
+
+	var clusterRequest pkgCluster.CreateClusterRequest
+
+	if err := render(template.Cluster, parameters, &clusterRequest); err != nil {
+		return Instance{}, NewValidationError("invalid cluster template", []string{err.Error()})
+	}
+
+	if err := render(template.IntegratedServices, parameters, &instance.IntegratedServices); err != nil {
+		return Instance{}, NewValidationError("invalid cluster template", []string{err.Error()})
+	}
+
+	if err := render(template.Releases, parameters, &instance.Releases); err != nil {
+		return Instance{}, NewValidationError("invalid cluster template", []string{err.Error()})
+	}
+
+	instance.ClusterName = clusterRequest.Name
+
+	// The instance is persisted before creating the cluster,
+	// so that the cluster is never left without its integrated services and releases.
+	instance, err = s.store.CreateInstance(ctx, instance)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	clusterID, err := s.clusters.CreateClusterFromRequest(ctx, organizationID, userID, clusterRequest)
+	if err != nil {
+		if uerr := s.store.UpdateInstanceStatus(ctx, instance.ID, InstanceFailed, err.Error()); uerr != nil {
+			err = errors.Combine(err, uerr)
+		}
+
+		return Instance{}, err
+	}
+
+	status := InstancePending
+	if len(instance.IntegratedServices) == 0 && len(instance.Releases) == 0 {
+		status = InstanceApplied
+	}
+
+	if err := s.store.UpdateInstanceCluster(ctx, instance.ID, clusterID, status); err != nil {
+		return Instance{}, err
+	}
+
+	instance.ClusterID = clusterID
+	instance.Status = status
+
+	return instance, nil
+}
+
+func (s service) ListInstances(ctx context.Context, organizationID uint, name string) ([]Instance, error) {
+	return s.store.ListInstances(ctx, organizationID, name)
+}
+
+// nolint: gochecknoglobals
+var templateNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func validateTemplate(template Template) []string {
+	var violations []string
+
+	if !templateNameRegexp.MatchString(template.Name) || len(template.Name) > 63 {
+		violations = append(violations, "name must consist of at most 63 lower case alphanumeric characters or '-'")
+	}
+
+	if len(template.Cluster) == 0 {
+		violations = append(violations, "cluster spec is required")
+	}
+
+	declared := make(map[string]bool, len(template.Parameters))
+
+	for _, parameter := range template.Parameters {
+		if !parameterNameRegexp.MatchString(parameter.Name) {
+			violations = append(violations, fmt.Sprintf("invalid parameter name: %q", parameter.Name))
+		}
+
+		if declared[parameter.Name] {
+			violations = append(violations, fmt.Sprintf("parameter %q is declared more than once", parameter.Name))
+		}
+
+		declared[parameter.Name] = true
+
+		switch parameter.Type {
+		case ParameterTypeString, ParameterTypeInteger, ParameterTypeNumber, ParameterTypeBoolean:
+			if parameter.Default == nil {
+				break
+			}
+
+			if _, err := convertValue(parameter.Type, parameter.Default); err != nil {
+				violations = append(violations, fmt.Sprintf("default value of parameter %q: %s", parameter.Name, err.Error()))
+			}
+
+		default:
+			violations = append(violations, fmt.Sprintf("parameter %q has unknown type %q", parameter.Name, parameter.Type))
+		}
+	}
+
+	for _, is := range template.IntegratedServices {
+		if is.Name == "" {
+			violations = append(violations, "integrated service name is required")
+		}
+	}
+
+	for _, release := range template.Releases {
+		if release.ReleaseName == "" || release.ChartName == "" {
+			violations = append(violations, "release name and chart name are required for every release")
+		}
+	}
+
+	for _, spec := range []interface{}{template.Cluster, template.IntegratedServices, template.Releases} {
+		names, err := references(spec)
+		if err != nil {
+			violations = append(violations, err.Error())
+
+			continue
+		}
+
+		for _, name := range names {
+			if !declared[name] {
+				violations = append(violations, fmt.Sprintf("undeclared parameter %q", name))
+
+				declared[name] = true // report every undeclared parameter once
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return violations
+	}
+
+	// Make sure the template renders into a cluster creation request
+	parameters, _ := resolveParameters(optionalParameters(template.Parameters), nil)
+
+	var request pkgCluster.CreateClusterRequest
+	if err := render(template.Cluster, parameters, &request); err != nil {
+		violations = append(violations, err.Error())
+	}
+
+	return violations
+}
+
+// optionalParameters returns a copy of parameters that can be resolved without providing any values.
+func optionalParameters(parameters []Parameter) []Parameter {
+	optional := make([]Parameter, 0, len(parameters))
+
+	for _, parameter := range parameters {
+		parameter.Required = false
+		optional = append(optional, parameter)
+	}
+
+	return optional
+}
+
+// ValidationError is returned when a cluster template or its parameters are invalid.
+type ValidationError struct {
+	message    string
+	violations []string
+}
+
+// NewValidationError returns a new ValidationError.
+func NewValidationError(message string, violations []string) ValidationError {
+	return ValidationError{
+		message:    message,
+		violations: violations,
+	}
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	return e.message
+}
+
+// Violations returns details of the failed validation.
+func (e ValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (ValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (ValidationError) ServiceError() bool {
+	return true
+}
+
+// NotFoundError is returned if a cluster template cannot be found.
+type NotFoundError struct {
+	Name    string
+	Version int
+}
+
+// Error implements the error interface.
+func (NotFoundError) Error() string {
+	return "cluster template not found"
+}
+
+// Details returns error details.
+func (e NotFoundError) Details() []interface{} {
+	return []interface{}{"template", e.Name, "version", e.Version}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (NotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (NotFoundError) ServiceError() bool {
+	return true
+}
@@ -0,0 +1,200 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplate
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+type userExtractor uint
+
+func (u userExtractor) GetUserID(_ context.Context) (uint, bool) {
+	return uint(u), u != 0
+}
+
+func newTemplate() Template {
+	return Template{
+		Name:    "team-cluster",
+		Version: 2,
+		Parameters: []Parameter{
+			{Name: "team", Type: ParameterTypeString, Required: true},
+			{Name: "nodeCount", Type: ParameterTypeInteger, Default: float64(3)},
+			{Name: "monitoring", Type: ParameterTypeBoolean, Default: true},
+		},
+		Cluster: map[string]interface{}{
+			"name":       "${team}-cluster",
+			"cloud":      "amazon",
+			"location":   "eu-west-1",
+			"secretName": "aws",
+			"properties": map[string]interface{}{
+				"pke": map[string]interface{}{
+					"nodepools": []interface{}{
+						map[string]interface{}{
+							"name":     "pool1",
+							"provider": "amazon",
+							"providerConfig": map[string]interface{}{
+								"count": "${nodeCount}",
+							},
+						},
+					},
+				},
+			},
+		},
+		IntegratedServices: []IntegratedService{
+			{Name: "monitoring", Spec: map[string]interface{}{"enabled": "${monitoring}"}},
+		},
+		Releases: []Release{
+			{ReleaseName: "${team}-ingress", ChartName: "stable/nginx-ingress", Values: map[string]interface{}{"team": "${team}"}},
+		},
+	}
+}
+
+func TestService_CreateTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	template := newTemplate()
+
+	store := new(MockStore)
+	store.On("CreateTemplate", ctx, uint(1), template).Return(template, nil)
+
+	service := NewService(store, new(MockClusterCreator), userExtractor(1))
+
+	_, err := service.CreateTemplate(ctx, 1, template)
+	require.NoError(t, err)
+
+	invalid := []func(t *Template){
+		func(t *Template) { t.Name = "Invalid_Name" },
+		func(t *Template) { t.Cluster = nil },
+		func(t *Template) {
+			t.Parameters = append(t.Parameters, Parameter{Name: "team", Type: ParameterTypeString})
+		},
+		func(t *Template) { t.Parameters[1].Type = "list" },
+		func(t *Template) { t.Parameters[1].Default = "three" },
+		func(t *Template) { t.Parameters = t.Parameters[1:] },
+		func(t *Template) { t.Releases[0].ChartName = "" },
+		func(t *Template) { t.Cluster["properties"] = "${team}" },
+	}
+
+	for _, modify := range invalid {
+		template := newTemplate()
+		modify(&template)
+
+		_, err := service.CreateTemplate(ctx, 1, template)
+		assert.True(t, errors.As(err, &ValidationError{}), "template: %+v", template)
+	}
+
+	store.AssertExpectations(t)
+}
+
+func TestService_CreateCluster(t *testing.T) {
+	ctx := context.Background()
+
+	template := newTemplate()
+
+	store := new(MockStore)
+	store.On("GetTemplate", ctx, uint(1), "team-cluster", 0).Return(template, nil)
+	store.On("CreateInstance", ctx, mock.Anything).Return(func(_ context.Context, instance Instance) Instance {
+		instance.ID = 1
+
+		return instance
+	}, nil)
+	store.On("UpdateInstanceCluster", ctx, uint(1), uint(3), InstancePending).Return(nil)
+
+	clusters := new(MockClusterCreator)
+	clusters.On("CreateClusterFromRequest", ctx, uint(1), uint(2), mock.Anything).Return(uint(3), nil).Once()
+
+	service := NewService(store, clusters, userExtractor(2))
+
+	instance, err := service.CreateCluster(ctx, 1, "team-cluster", CreateClusterRequest{
+		Parameters: map[string]interface{}{"team": "data", "nodeCount": float64(5)},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(3), instance.ClusterID)
+	assert.Equal(t, "data-cluster", instance.ClusterName)
+	assert.Equal(t, 2, instance.TemplateVersion)
+	assert.Equal(t, InstancePending, instance.Status)
+	assert.Equal(t, []IntegratedService{{Name: "monitoring", Spec: map[string]interface{}{"enabled": true}}}, instance.IntegratedServices)
+	assert.Equal(t, "data-ingress", instance.Releases[0].ReleaseName)
+	assert.Equal(t, map[string]interface{}{"team": "data"}, instance.Releases[0].Values)
+
+	request := clusters.Calls[0].Arguments.Get(3).(pkgCluster.CreateClusterRequest)
+	assert.Equal(t, "data-cluster", request.Name)
+	assert.Equal(t, "aws", request.SecretName)
+	assert.Equal(t, float64(5), request.Properties.CreateClusterPKE.NodePools[0].ProviderConfig["count"])
+
+	invalid := []map[string]interface{}{
+		{},
+		{"team": 1.0},
+		{"team": "data", "nodeCount": 1.5},
+		{"team": "data", "unknown": "value"},
+	}
+
+	for _, parameters := range invalid {
+		_, err := service.CreateCluster(ctx, 1, "team-cluster", CreateClusterRequest{Parameters: parameters})
+		assert.True(t, errors.As(err, &ValidationError{}), "parameters: %v", parameters)
+	}
+
+	clusters.AssertNumberOfCalls(t, "CreateClusterFromRequest", 1)
+
+	clusterErr := errors.New("invalid cluster request")
+	clusters.On("CreateClusterFromRequest", ctx, uint(1), uint(2), mock.Anything).Return(uint(0), clusterErr)
+	store.On("UpdateInstanceStatus", ctx, uint(1), InstanceFailed, clusterErr.Error()).Return(nil)
+
+	_, err = service.CreateCluster(ctx, 1, "team-cluster", CreateClusterRequest{
+		Parameters: map[string]interface{}{"team": "data"},
+	})
+	assert.Equal(t, clusterErr, err)
+
+	store.AssertExpectations(t)
+}
+
+func TestRender(t *testing.T) {
+	parameters := map[string]interface{}{
+		"name":  "example",
+		"count": int64(2),
+		"ratio": 0.5,
+	}
+
+	var rendered map[string]interface{}
+
+	err := render(map[string]interface{}{
+		"name":   "${name}",
+		"label":  "${name}-${count}",
+		"count":  "${count}",
+		"ratio":  "${ratio}",
+		"values": []interface{}{"${name}", "literal"},
+	}, parameters, &rendered)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"name":   "example",
+		"label":  "example-2",
+		"count":  float64(2),
+		"ratio":  0.5,
+		"values": []interface{}{"example", "literal"},
+	}, rendered)
+
+	err = render(map[string]interface{}{"name": "${missing}"}, parameters, &rendered)
+	assert.Error(t, err)
+}
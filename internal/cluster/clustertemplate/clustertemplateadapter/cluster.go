@@ -0,0 +1,78 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplateadapter
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate"
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+// ClusterStatusGetter returns the status of clusters from the cluster store.
+type ClusterStatusGetter struct {
+	store cluster.Store
+}
+
+// NewClusterStatusGetter returns a new ClusterStatusGetter.
+func NewClusterStatusGetter(store cluster.Store) ClusterStatusGetter {
+	return ClusterStatusGetter{
+		store: store,
+	}
+}
+
+// GetClusterStatus returns the status of a cluster (eg. RUNNING).
+func (g ClusterStatusGetter) GetClusterStatus(ctx context.Context, clusterID uint) (string, error) {
+	c, err := g.store.GetCluster(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	return c.Status, nil
+}
+
+// ReleaseInstaller installs the releases of cluster templates using the Helm service.
+type ReleaseInstaller struct {
+	helmService helm.Service
+}
+
+// NewReleaseInstaller returns a new ReleaseInstaller.
+func NewReleaseInstaller(helmService helm.Service) ReleaseInstaller {
+	return ReleaseInstaller{
+		helmService: helmService,
+	}
+}
+
+// InstallRelease installs a Helm release on a cluster.
+func (i ReleaseInstaller) InstallRelease(ctx context.Context, organizationID uint, clusterID uint, release clustertemplate.Release) error {
+	_, err := i.helmService.InstallRelease(
+		ctx,
+		organizationID,
+		clusterID,
+		helm.Release{
+			ReleaseName: release.ReleaseName,
+			ChartName:   release.ChartName,
+			Namespace:   release.Namespace,
+			Values:      release.Values,
+			Version:     release.Version,
+		},
+		helm.Options{
+			Namespace: release.Namespace,
+		},
+	)
+
+	return err
+}
@@ -0,0 +1,372 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplateadapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate"
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+)
+
+// TableName constants
+const (
+	templateTableName = "cluster_templates"
+	instanceTableName = "cluster_template_instances"
+)
+
+type templateModel struct {
+	ID             uint         `gorm:"primary_key"`
+	OrganizationID uint         `gorm:"unique_index:idx_cluster_templates_org_name_version;not null"`
+	Name           string       `gorm:"unique_index:idx_cluster_templates_org_name_version;not null"`
+	Version        int          `gorm:"unique_index:idx_cluster_templates_org_name_version;not null"`
+	Description    string       `gorm:"type:text"`
+	Spec           templateSpec `gorm:"type:text"`
+	CreatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (templateModel) TableName() string {
+	return templateTableName
+}
+
+func (m templateModel) toTemplate() clustertemplate.Template {
+	createdAt := m.CreatedAt
+
+	return clustertemplate.Template{
+		ID:                 m.ID,
+		Name:               m.Name,
+		Version:            m.Version,
+		Description:        m.Description,
+		Parameters:         m.Spec.Parameters,
+		Cluster:            m.Spec.Cluster,
+		IntegratedServices: m.Spec.IntegratedServices,
+		Releases:           m.Spec.Releases,
+		CreatedAt:          &createdAt,
+	}
+}
+
+type templateSpec struct {
+	Parameters         []clustertemplate.Parameter         `json:"parameters,omitempty"`
+	Cluster            map[string]interface{}              `json:"cluster"`
+	IntegratedServices []clustertemplate.IntegratedService `json:"integratedServices,omitempty"`
+	Releases           []clustertemplate.Release           `json:"releases,omitempty"`
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *templateSpec) Scan(src interface{}) error {
+	return json.Scan(src, s)
+}
+
+// Value implements the driver.Valuer interface.
+func (s templateSpec) Value() (driver.Value, error) {
+	return json.Value(s)
+}
+
+type instanceModel struct {
+	ID              uint         `gorm:"primary_key"`
+	OrganizationID  uint         `gorm:"index:idx_cluster_template_instances_org_template;not null"`
+	TemplateName    string       `gorm:"index:idx_cluster_template_instances_org_template;not null"`
+	TemplateVersion int          `gorm:"not null"`
+	ClusterID       uint         `gorm:"not null"`
+	ClusterName     string       `gorm:"not null"`
+	Spec            instanceSpec `gorm:"type:text"`
+	Status          string       `gorm:"index;not null"`
+	StatusMessage   string       `gorm:"type:text"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// TableName changes the default table name.
+func (instanceModel) TableName() string {
+	return instanceTableName
+}
+
+func (m instanceModel) toInstance() clustertemplate.Instance {
+	createdAt := m.CreatedAt
+	updatedAt := m.UpdatedAt
+
+	return clustertemplate.Instance{
+		ID:                 m.ID,
+		OrganizationID:     m.OrganizationID,
+		TemplateName:       m.TemplateName,
+		TemplateVersion:    m.TemplateVersion,
+		ClusterID:          m.ClusterID,
+		ClusterName:        m.ClusterName,
+		Parameters:         m.Spec.Parameters,
+		IntegratedServices: m.Spec.IntegratedServices,
+		Releases:           m.Spec.Releases,
+		Status:             m.Status,
+		StatusMessage:      m.StatusMessage,
+		CreatedAt:          &createdAt,
+		UpdatedAt:          &updatedAt,
+	}
+}
+
+type instanceSpec struct {
+	Parameters         map[string]interface{}              `json:"parameters,omitempty"`
+	IntegratedServices []clustertemplate.IntegratedService `json:"integratedServices,omitempty"`
+	Releases           []clustertemplate.Release           `json:"releases,omitempty"`
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *instanceSpec) Scan(src interface{}) error {
+	return json.Scan(src, s)
+}
+
+// Value implements the driver.Valuer interface.
+func (s instanceSpec) Value() (driver.Value, error) {
+	return json.Value(s)
+}
+
+// GormStore is a cluster template store using Gorm for persistence.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new GormStore.
+func NewGormStore(db *gorm.DB) GormStore {
+	return GormStore{
+		db: db,
+	}
+}
+
+// ListTemplates lists the latest version of every cluster template of an organization.
+func (s GormStore) ListTemplates(ctx context.Context, organizationID uint) ([]clustertemplate.Template, error) {
+	var models []templateModel
+
+	err := s.db.Where(templateModel{OrganizationID: organizationID}).Order("name, version desc").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list cluster templates", "organizationId", organizationID)
+	}
+
+	templates := make([]clustertemplate.Template, 0, len(models))
+	for _, model := range models {
+		if len(templates) > 0 && templates[len(templates)-1].Name == model.Name {
+			continue
+		}
+
+		templates = append(templates, model.toTemplate())
+	}
+
+	return templates, nil
+}
+
+// ListTemplateVersions lists every version of a cluster template.
+func (s GormStore) ListTemplateVersions(ctx context.Context, organizationID uint, name string) ([]clustertemplate.Template, error) {
+	var models []templateModel
+
+	err := s.db.Where(templateModel{OrganizationID: organizationID, Name: name}).Order("version desc").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to list cluster template versions",
+			"organizationId", organizationID,
+			"template", name,
+		)
+	}
+
+	templates := make([]clustertemplate.Template, 0, len(models))
+	for _, model := range models {
+		templates = append(templates, model.toTemplate())
+	}
+
+	return templates, nil
+}
+
+// GetTemplate returns a version of a cluster template. Version 0 returns the latest version.
+func (s GormStore) GetTemplate(ctx context.Context, organizationID uint, name string, version int) (clustertemplate.Template, error) {
+	var model templateModel
+
+	query := s.db.Where(templateModel{OrganizationID: organizationID, Name: name})
+	if version > 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	err := query.Order("version desc").First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return clustertemplate.Template{}, errors.WithStack(clustertemplate.NotFoundError{Name: name, Version: version})
+	} else if err != nil {
+		return clustertemplate.Template{}, errors.WrapIfWithDetails(
+			err, "failed to get cluster template",
+			"organizationId", organizationID,
+			"template", name,
+			"version", version,
+		)
+	}
+
+	return model.toTemplate(), nil
+}
+
+// CreateTemplate persists a template as the next version of the named template.
+func (s GormStore) CreateTemplate(ctx context.Context, organizationID uint, template clustertemplate.Template) (clustertemplate.Template, error) {
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return clustertemplate.Template{}, errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	var latest templateModel
+
+	err := tx.Where(templateModel{OrganizationID: organizationID, Name: template.Name}).Order("version desc").First(&latest).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		tx.Rollback()
+
+		return clustertemplate.Template{}, errors.WrapIfWithDetails(
+			err, "failed to get latest cluster template version",
+			"organizationId", organizationID,
+			"template", template.Name,
+		)
+	}
+
+	model := templateModel{
+		OrganizationID: organizationID,
+		Name:           template.Name,
+		Version:        latest.Version + 1,
+		Description:    template.Description,
+		Spec: templateSpec{
+			Parameters:         template.Parameters,
+			Cluster:            template.Cluster,
+			IntegratedServices: template.IntegratedServices,
+			Releases:           template.Releases,
+		},
+	}
+
+	err = tx.Create(&model).Error
+	if err != nil {
+		tx.Rollback()
+
+		return clustertemplate.Template{}, errors.WrapIfWithDetails(
+			err, "failed to create cluster template",
+			"organizationId", organizationID,
+			"template", template.Name,
+		)
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return clustertemplate.Template{}, errors.WrapIf(err, "failed to commit transaction")
+	}
+
+	return model.toTemplate(), nil
+}
+
+// DeleteTemplate deletes every version of a cluster template.
+func (s GormStore) DeleteTemplate(ctx context.Context, organizationID uint, name string) error {
+	result := s.db.Where(templateModel{OrganizationID: organizationID, Name: name}).Delete(templateModel{})
+	if result.Error != nil {
+		return errors.WrapIfWithDetails(
+			result.Error, "failed to delete cluster template",
+			"organizationId", organizationID,
+			"template", name,
+		)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.WithStack(clustertemplate.NotFoundError{Name: name})
+	}
+
+	return nil
+}
+
+// CreateInstance persists a new template instance.
+func (s GormStore) CreateInstance(ctx context.Context, instance clustertemplate.Instance) (clustertemplate.Instance, error) {
+	model := instanceModel{
+		OrganizationID:  instance.OrganizationID,
+		TemplateName:    instance.TemplateName,
+		TemplateVersion: instance.TemplateVersion,
+		ClusterID:       instance.ClusterID,
+		ClusterName:     instance.ClusterName,
+		Spec: instanceSpec{
+			Parameters:         instance.Parameters,
+			IntegratedServices: instance.IntegratedServices,
+			Releases:           instance.Releases,
+		},
+		Status:        instance.Status,
+		StatusMessage: instance.StatusMessage,
+	}
+
+	err := s.db.Create(&model).Error
+	if err != nil {
+		return clustertemplate.Instance{}, errors.WrapIfWithDetails(
+			err, "failed to create cluster template instance",
+			"organizationId", instance.OrganizationID,
+			"template", instance.TemplateName,
+			"clusterId", instance.ClusterID,
+		)
+	}
+
+	return model.toInstance(), nil
+}
+
+// ListInstances lists the instances of a cluster template.
+func (s GormStore) ListInstances(ctx context.Context, organizationID uint, name string) ([]clustertemplate.Instance, error) {
+	var models []instanceModel
+
+	err := s.db.Where(instanceModel{OrganizationID: organizationID, TemplateName: name}).Order("id desc").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to list cluster template instances",
+			"organizationId", organizationID,
+			"template", name,
+		)
+	}
+
+	return toInstances(models), nil
+}
+
+// ListPendingInstances lists every instance waiting to be applied.
+func (s GormStore) ListPendingInstances(ctx context.Context) ([]clustertemplate.Instance, error) {
+	var models []instanceModel
+
+	err := s.db.Where(instanceModel{Status: clustertemplate.InstancePending}).Order("id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list pending cluster template instances")
+	}
+
+	return toInstances(models), nil
+}
+
+// UpdateInstanceStatus updates the status of a template instance.
+func (s GormStore) UpdateInstanceStatus(ctx context.Context, id uint, status string, message string) error {
+	err := s.db.Model(&instanceModel{ID: id}).Updates(map[string]interface{}{
+		"status":         status,
+		"status_message": message,
+	}).Error
+
+	return errors.WrapIfWithDetails(err, "failed to update cluster template instance", "instanceId", id)
+}
+
+// UpdateInstanceCluster records the cluster created for a template instance and updates its status.
+func (s GormStore) UpdateInstanceCluster(ctx context.Context, id uint, clusterID uint, status string) error {
+	err := s.db.Model(&instanceModel{ID: id}).Updates(map[string]interface{}{
+		"cluster_id": clusterID,
+		"status":     status,
+	}).Error
+
+	return errors.WrapIfWithDetails(err, "failed to update cluster template instance", "instanceId", id, "clusterId", clusterID)
+}
+
+func toInstances(models []instanceModel) []clustertemplate.Instance {
+	instances := make([]clustertemplate.Instance, 0, len(models))
+	for _, model := range models {
+		instances = append(instances, model.toInstance())
+	}
+
+	return instances
+}
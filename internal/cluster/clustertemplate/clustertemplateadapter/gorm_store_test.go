@@ -0,0 +1,139 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplateadapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	//  SQLite driver used for integration test
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, clustertemplate.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore_Templates(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	template := clustertemplate.Template{
+		Name:       "team-cluster",
+		Parameters: []clustertemplate.Parameter{{Name: "team", Type: clustertemplate.ParameterTypeString}},
+		Cluster:    map[string]interface{}{"name": "${team}-cluster"},
+	}
+
+	first, err := store.CreateTemplate(ctx, 1, template)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Version)
+
+	template.Description = "second version"
+
+	second, err := store.CreateTemplate(ctx, 1, template)
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Version)
+
+	_, err = store.CreateTemplate(ctx, 1, clustertemplate.Template{Name: "other", Cluster: map[string]interface{}{}})
+	require.NoError(t, err)
+
+	templates, err := store.ListTemplates(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "other", templates[0].Name)
+	assert.Equal(t, 2, templates[1].Version)
+
+	versions, err := store.ListTemplateVersions(ctx, 1, "team-cluster")
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	latest, err := store.GetTemplate(ctx, 1, "team-cluster", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "second version", latest.Description)
+	assert.Equal(t, template.Parameters, latest.Parameters)
+	assert.Equal(t, template.Cluster, latest.Cluster)
+
+	previous, err := store.GetTemplate(ctx, 1, "team-cluster", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "", previous.Description)
+
+	_, err = store.GetTemplate(ctx, 2, "team-cluster", 0)
+	assert.True(t, errors.As(err, &clustertemplate.NotFoundError{}))
+
+	err = store.DeleteTemplate(ctx, 1, "team-cluster")
+	require.NoError(t, err)
+
+	err = store.DeleteTemplate(ctx, 1, "team-cluster")
+	assert.True(t, errors.As(err, &clustertemplate.NotFoundError{}))
+}
+
+func TestGormStore_Instances(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	instance, err := store.CreateInstance(ctx, clustertemplate.Instance{
+		OrganizationID:  1,
+		TemplateName:    "team-cluster",
+		TemplateVersion: 1,
+		ClusterName:     "data-cluster",
+		Parameters:      map[string]interface{}{"team": "data"},
+		Releases:        []clustertemplate.Release{{ReleaseName: "ingress", ChartName: "stable/nginx-ingress"}},
+		Status:          clustertemplate.InstancePending,
+	})
+	require.NoError(t, err)
+
+	pending, err := store.ListPendingInstances(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, instance.Releases, pending[0].Releases)
+	assert.Equal(t, uint(0), pending[0].ClusterID)
+
+	err = store.UpdateInstanceCluster(ctx, instance.ID, 1, clustertemplate.InstancePending)
+	require.NoError(t, err)
+
+	pending, err = store.ListPendingInstances(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, uint(1), pending[0].ClusterID)
+
+	err = store.UpdateInstanceStatus(ctx, instance.ID, clustertemplate.InstanceFailed, "cluster creation failed")
+	require.NoError(t, err)
+
+	pending, err = store.ListPendingInstances(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	instances, err := store.ListInstances(ctx, 1, "team-cluster")
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, clustertemplate.InstanceFailed, instances[0].Status)
+	assert.Equal(t, "cluster creation failed", instances[0].StatusMessage)
+	assert.Equal(t, map[string]interface{}{"team": "data"}, instances[0].Parameters)
+}
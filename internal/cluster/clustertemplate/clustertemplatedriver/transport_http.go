@@ -0,0 +1,222 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplatedriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into a router.
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodGet).Path("").Handler(kithttp.NewServer(
+		endpoints.ListTemplates,
+		decodeListTemplatesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListTemplatesHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("").Handler(kithttp.NewServer(
+		endpoints.CreateTemplate,
+		decodeCreateTemplateHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateTemplateHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{name}").Handler(kithttp.NewServer(
+		endpoints.GetTemplate,
+		decodeGetTemplateHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetTemplateHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/{name}").Handler(kithttp.NewServer(
+		endpoints.DeleteTemplate,
+		decodeDeleteTemplateHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{name}/versions").Handler(kithttp.NewServer(
+		endpoints.ListTemplateVersions,
+		decodeListTemplateVersionsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListTemplateVersionsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/{name}/clusters").Handler(kithttp.NewServer(
+		endpoints.CreateCluster,
+		decodeCreateClusterHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateClusterHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{name}/clusters").Handler(kithttp.NewServer(
+		endpoints.ListInstances,
+		decodeListInstancesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListInstancesHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+func decodeListTemplatesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListTemplatesRequest{OrganizationID: orgID}, nil
+}
+
+func encodeListTemplatesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListTemplatesResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Templates)
+}
+
+func decodeCreateTemplateHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var template clustertemplate.Template
+
+	err = json.NewDecoder(r.Body).Decode(&template)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return CreateTemplateRequest{OrganizationID: orgID, Template: template}, nil
+}
+
+func encodeCreateTemplateHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateTemplateResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.NewTemplate, http.StatusCreated))
+}
+
+func decodeGetTemplateHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var version int
+
+	if v := r.URL.Query().Get("version"); v != "" {
+		version, err = strconv.Atoi(v)
+		if err != nil || version < 1 {
+			return nil, errors.NewWithDetails("invalid version", "version", v)
+		}
+	}
+
+	return GetTemplateRequest{OrganizationID: orgID, Name: mux.Vars(r)["name"], Version: version}, nil
+}
+
+func encodeGetTemplateHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetTemplateResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Template)
+}
+
+func decodeDeleteTemplateHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeleteTemplateRequest{OrganizationID: orgID, Name: mux.Vars(r)["name"]}, nil
+}
+
+func decodeListTemplateVersionsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListTemplateVersionsRequest{OrganizationID: orgID, Name: mux.Vars(r)["name"]}, nil
+}
+
+func encodeListTemplateVersionsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListTemplateVersionsResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Templates)
+}
+
+func decodeCreateClusterHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var request clustertemplate.CreateClusterRequest
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return CreateClusterRequest{OrganizationID: orgID, Name: mux.Vars(r)["name"], Request: request}, nil
+}
+
+func encodeCreateClusterHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateClusterResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.Instance, http.StatusAccepted))
+}
+
+func decodeListInstancesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListInstancesRequest{OrganizationID: orgID, Name: mux.Vars(r)["name"]}, nil
+}
+
+func encodeListInstancesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListInstancesResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Instances)
+}
+
+func extractUintParamFromRequest(key string, r *http.Request) (uint, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[key]
+	if !ok || value == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", key)
+	}
+
+	uintVal, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to parse path parameter", "param", key, "value", value)
+	}
+
+	return uint(uintVal), nil
+}
@@ -0,0 +1,78 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplateworkflow
+
+import (
+	"context"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+const ListPendingInstancesActivityName = "cluster-template-list-pending-instances"
+
+type InstanceLister interface {
+	// ListPendingInstances lists every template instance waiting to be applied.
+	ListPendingInstances(ctx context.Context) ([]clustertemplate.Instance, error)
+}
+
+type ListPendingInstancesActivity struct {
+	instances InstanceLister
+}
+
+func NewListPendingInstancesActivity(instances InstanceLister) ListPendingInstancesActivity {
+	return ListPendingInstancesActivity{
+		instances: instances,
+	}
+}
+
+func (a ListPendingInstancesActivity) Execute(ctx context.Context) ([]clustertemplate.Instance, error) {
+	return a.instances.ListPendingInstances(ctx)
+}
+
+func (a ListPendingInstancesActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: ListPendingInstancesActivityName})
+}
+
+const ReconcileInstanceActivityName = "cluster-template-reconcile-instance"
+
+type ReconcileInstanceActivityInput struct {
+	Instance clustertemplate.Instance
+}
+
+type InstanceReconciler interface {
+	// ReconcileInstance applies a pending template instance if its cluster is running.
+	ReconcileInstance(ctx context.Context, instance clustertemplate.Instance) error
+}
+
+type ReconcileInstanceActivity struct {
+	reconciler InstanceReconciler
+}
+
+func NewReconcileInstanceActivity(reconciler InstanceReconciler) ReconcileInstanceActivity {
+	return ReconcileInstanceActivity{
+		reconciler: reconciler,
+	}
+}
+
+func (a ReconcileInstanceActivity) Execute(ctx context.Context, input ReconcileInstanceActivityInput) error {
+	return a.reconciler.ReconcileInstance(ctx, input.Instance)
+}
+
+func (a ReconcileInstanceActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: ReconcileInstanceActivityName})
+}
@@ -0,0 +1,81 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplateworkflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustertemplate"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+const ReconcileWorkflowName = "cluster-template-reconcile"
+
+type ReconcileWorkflow struct{}
+
+func NewReconcileWorkflow() *ReconcileWorkflow {
+	return &ReconcileWorkflow{}
+}
+
+func (w ReconcileWorkflow) Execute(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+
+	activityContext := workflow.WithActivityOptions(
+		ctx,
+		workflow.ActivityOptions{
+			ScheduleToStartTimeout: 10 * time.Minute,
+			StartToCloseTimeout:    30 * time.Minute,
+			WaitForCancellation:    true,
+			RetryPolicy: &cadence.RetryPolicy{
+				InitialInterval:          time.Minute,
+				BackoffCoefficient:       2.0,
+				ExpirationInterval:       30 * time.Minute,
+				MaximumAttempts:          3,
+				NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+			},
+		},
+	)
+
+	var instances []clustertemplate.Instance
+	err := workflow.ExecuteActivity(activityContext, ListPendingInstancesActivityName).Get(ctx, &instances)
+	if err != nil {
+		return err
+	}
+
+	futures := make([]workflow.Future, 0, len(instances))
+	for _, instance := range instances {
+		input := ReconcileInstanceActivityInput{
+			Instance: instance,
+		}
+
+		futures = append(futures, workflow.ExecuteActivity(activityContext, ReconcileInstanceActivityName, input))
+	}
+
+	// failing to apply an instance should not affect the other instances
+	for i, future := range futures {
+		if err := future.Get(ctx, nil); err != nil {
+			logger.Sugar().Warnw("cluster template reconciliation failed", "instanceId", instances[i].ID, "error", err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (w ReconcileWorkflow) Register(worker worker.Registry) {
+	worker.RegisterWorkflowWithOptions(w.Execute, workflow.RegisterOptions{Name: ReconcileWorkflowName})
+}
@@ -0,0 +1,155 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplate
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// +testify:mock:testOnly=true
+
+// ClusterStatusGetter returns the status of clusters.
+type ClusterStatusGetter interface {
+	// GetClusterStatus returns the status of a cluster (eg. RUNNING).
+	GetClusterStatus(ctx context.Context, clusterID uint) (string, error)
+}
+
+// +testify:mock:testOnly=true
+
+// IntegratedServiceActivator activates integrated services on clusters.
+type IntegratedServiceActivator interface {
+	// Activate activates an integrated service on a cluster.
+	Activate(ctx context.Context, clusterID uint, serviceName string, spec map[string]interface{}) error
+}
+
+// +testify:mock:testOnly=true
+
+// ReleaseInstaller installs Helm releases on clusters.
+type ReleaseInstaller interface {
+	// InstallRelease installs a Helm release on a cluster.
+	InstallRelease(ctx context.Context, organizationID uint, clusterID uint, release Release) error
+}
+
+// clusterCreationTimeout is the time after which an instance without a cluster is considered failed.
+const clusterCreationTimeout = time.Hour
+
+// Reconciler applies the integrated services and releases of template instances once their clusters are running.
+type Reconciler struct {
+	store    Store
+	clusters ClusterStatusGetter
+	services IntegratedServiceActivator
+	releases ReleaseInstaller
+
+	logger Logger
+}
+
+// NewReconciler returns a new Reconciler.
+func NewReconciler(
+	store Store,
+	clusters ClusterStatusGetter,
+	services IntegratedServiceActivator,
+	releases ReleaseInstaller,
+	logger Logger,
+) Reconciler {
+	return Reconciler{
+		store:    store,
+		clusters: clusters,
+		services: services,
+		releases: releases,
+		logger:   logger,
+	}
+}
+
+// ListPendingInstances lists every template instance waiting to be applied.
+func (r Reconciler) ListPendingInstances(ctx context.Context) ([]Instance, error) {
+	return r.store.ListPendingInstances(ctx)
+}
+
+// ReconcileInstance applies a pending template instance if its cluster is running.
+// Instances whose cluster failed to be created are marked as failed.
+func (r Reconciler) ReconcileInstance(ctx context.Context, instance Instance) error {
+	logger := r.logger.WithFields(map[string]interface{}{
+		"organizationId": instance.OrganizationID,
+		"clusterId":      instance.ClusterID,
+		"template":       instance.TemplateName,
+	})
+
+	// The cluster creation has not been started yet
+	if instance.ClusterID == 0 {
+		if instance.CreatedAt != nil && time.Since(*instance.CreatedAt) > clusterCreationTimeout {
+			return r.store.UpdateInstanceStatus(ctx, instance.ID, InstanceFailed, "cluster creation was not started")
+		}
+
+		return nil
+	}
+
+	status, err := r.clusters.GetClusterStatus(ctx, instance.ClusterID)
+	if err != nil {
+		if isNotFound(err) {
+			return r.store.UpdateInstanceStatus(ctx, instance.ID, InstanceFailed, "cluster not found")
+		}
+
+		return err
+	}
+
+	switch status {
+	case pkgCluster.Running:
+	case pkgCluster.Error:
+		return r.store.UpdateInstanceStatus(ctx, instance.ID, InstanceFailed, "cluster creation failed")
+	default:
+		return nil
+	}
+
+	logger.Info("applying cluster template")
+
+	if err := r.apply(ctx, instance); err != nil {
+		logger.Info("failed to apply cluster template", map[string]interface{}{"error": err.Error()})
+
+		return r.store.UpdateInstanceStatus(ctx, instance.ID, InstanceFailed, err.Error())
+	}
+
+	return r.store.UpdateInstanceStatus(ctx, instance.ID, InstanceApplied, "")
+}
+
+func (r Reconciler) apply(ctx context.Context, instance Instance) error {
+	for _, is := range instance.IntegratedServices {
+		err := r.services.Activate(ctx, instance.ClusterID, is.Name, is.Spec)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to activate integrated service", "integratedService", is.Name)
+		}
+	}
+
+	for _, release := range instance.Releases {
+		err := r.releases.InstallRelease(ctx, instance.OrganizationID, instance.ClusterID, release)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to install release", "release", release.ReleaseName)
+		}
+	}
+
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var notFoundErr interface {
+		NotFound() bool
+	}
+
+	return errors.As(err, &notFoundErr) && notFoundErr.NotFound()
+}
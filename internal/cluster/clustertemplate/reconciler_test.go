@@ -0,0 +1,74 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/require"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+func TestReconciler_ReconcileInstance(t *testing.T) {
+	ctx := context.Background()
+
+	release := Release{ReleaseName: "ingress", ChartName: "stable/nginx-ingress"}
+	is := IntegratedService{Name: "monitoring", Spec: map[string]interface{}{}}
+
+	recent := time.Now()
+	stale := recent.Add(-2 * clusterCreationTimeout)
+
+	instances := []Instance{
+		{ID: 1, OrganizationID: 1, ClusterID: 1, Releases: []Release{release}, IntegratedServices: []IntegratedService{is}},
+		{ID: 2, OrganizationID: 1, ClusterID: 2, Releases: []Release{release}},
+		{ID: 3, OrganizationID: 1, ClusterID: 3, Releases: []Release{release}},
+		{ID: 4, OrganizationID: 1, ClusterID: 4, Releases: []Release{release}},
+		{ID: 5, OrganizationID: 1, Releases: []Release{release}, CreatedAt: &recent},
+		{ID: 6, OrganizationID: 1, Releases: []Release{release}, CreatedAt: &stale},
+	}
+
+	store := new(MockStore)
+	store.On("UpdateInstanceStatus", ctx, uint(1), InstanceApplied, "").Return(nil)
+	store.On("UpdateInstanceStatus", ctx, uint(3), InstanceFailed, "cluster creation failed").Return(nil)
+	store.On("UpdateInstanceStatus", ctx, uint(4), InstanceFailed, "cluster not found").Return(nil)
+	store.On("UpdateInstanceStatus", ctx, uint(6), InstanceFailed, "cluster creation was not started").Return(nil)
+
+	clusters := new(MockClusterStatusGetter)
+	clusters.On("GetClusterStatus", ctx, uint(1)).Return(pkgCluster.Running, nil)
+	clusters.On("GetClusterStatus", ctx, uint(2)).Return(pkgCluster.Creating, nil)
+	clusters.On("GetClusterStatus", ctx, uint(3)).Return(pkgCluster.Error, nil)
+	clusters.On("GetClusterStatus", ctx, uint(4)).Return("", errors.WithStack(NotFoundError{}))
+
+	services := new(MockIntegratedServiceActivator)
+	services.On("Activate", ctx, uint(1), "monitoring", is.Spec).Return(nil)
+
+	releases := new(MockReleaseInstaller)
+	releases.On("InstallRelease", ctx, uint(1), uint(1), release).Return(nil)
+
+	reconciler := NewReconciler(store, clusters, services, releases, NoopLogger{})
+
+	for _, instance := range instances {
+		err := reconciler.ReconcileInstance(ctx, instance)
+		require.NoError(t, err)
+	}
+
+	store.AssertExpectations(t)
+	services.AssertExpectations(t)
+	releases.AssertExpectations(t)
+}
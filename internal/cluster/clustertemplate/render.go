@@ -0,0 +1,241 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustertemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+
+	"emperror.dev/errors"
+)
+
+// nolint: gochecknoglobals
+var (
+	parameterNameRegexp      = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	parameterReferenceRegexp = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+)
+
+// resolveParameters merges the provided values with the parameter defaults and checks them against the parameter types.
+func resolveParameters(parameters []Parameter, values map[string]interface{}) (map[string]interface{}, []string) {
+	var violations []string
+
+	declared := make(map[string]bool, len(parameters))
+	resolved := make(map[string]interface{}, len(parameters))
+
+	for _, parameter := range parameters {
+		declared[parameter.Name] = true
+
+		value, ok := values[parameter.Name]
+		if !ok || value == nil {
+			if parameter.Default == nil {
+				if parameter.Required {
+					violations = append(violations, fmt.Sprintf("parameter %q is required", parameter.Name))
+
+					continue
+				}
+
+				resolved[parameter.Name] = zeroValue(parameter.Type)
+
+				continue
+			}
+
+			value = parameter.Default
+		}
+
+		converted, err := convertValue(parameter.Type, value)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("parameter %q: %s", parameter.Name, err.Error()))
+
+			continue
+		}
+
+		resolved[parameter.Name] = converted
+	}
+
+	var unknown []string
+	for name := range values {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	sort.Strings(unknown)
+
+	for _, name := range unknown {
+		violations = append(violations, fmt.Sprintf("parameter %q is not declared by the template", name))
+	}
+
+	return resolved, violations
+}
+
+// convertValue checks that a value matches a parameter type and returns it in its canonical form.
+func convertValue(parameterType string, value interface{}) (interface{}, error) {
+	switch parameterType {
+	case ParameterTypeString:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+
+	case ParameterTypeBoolean:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+
+	case ParameterTypeInteger:
+		switch v := value.(type) {
+		case int:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int64(v), nil
+			}
+		}
+
+	case ParameterTypeNumber:
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+
+	default:
+		return nil, errors.Errorf("unknown type %q", parameterType)
+	}
+
+	return nil, errors.Errorf("value must be of type %s", parameterType)
+}
+
+func zeroValue(parameterType string) interface{} {
+	switch parameterType {
+	case ParameterTypeBoolean:
+		return false
+	case ParameterTypeInteger:
+		return int64(0)
+	case ParameterTypeNumber:
+		return float64(0)
+	default:
+		return ""
+	}
+}
+
+// render substitutes parameter references in src and decodes the result into dst.
+//
+// A string consisting of a single reference (eg. "${nodeCount}") is replaced with the typed parameter value,
+// references embedded in longer strings (eg. "${env}-cluster") are replaced with their string representation.
+func render(src interface{}, parameters map[string]interface{}, dst interface{}) error {
+	raw, err := json.Marshal(src)
+	if err != nil {
+		return errors.WrapIf(err, "failed to encode template")
+	}
+
+	var tree interface{}
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return errors.WrapIf(err, "failed to decode template")
+	}
+
+	tree, err = renderValue(tree, parameters)
+	if err != nil {
+		return err
+	}
+
+	raw, err = json.Marshal(tree)
+	if err != nil {
+		return errors.WrapIf(err, "failed to encode rendered template")
+	}
+
+	return errors.WrapIf(json.Unmarshal(raw, dst), "rendered template is invalid")
+}
+
+func renderValue(value interface{}, parameters map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			rendered, err := renderValue(item, parameters)
+			if err != nil {
+				return nil, err
+			}
+
+			v[key] = rendered
+		}
+
+		return v, nil
+
+	case []interface{}:
+		for i, item := range v {
+			rendered, err := renderValue(item, parameters)
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = rendered
+		}
+
+		return v, nil
+
+	case string:
+		if match := parameterReferenceRegexp.FindStringSubmatch(v); match != nil && match[0] == v {
+			parameter, ok := parameters[match[1]]
+			if !ok {
+				return nil, errors.Errorf("undeclared parameter %q", match[1])
+			}
+
+			return parameter, nil
+		}
+
+		var err error
+
+		rendered := parameterReferenceRegexp.ReplaceAllStringFunc(v, func(reference string) string {
+			name := parameterReferenceRegexp.FindStringSubmatch(reference)[1]
+
+			parameter, ok := parameters[name]
+			if !ok {
+				err = errors.Errorf("undeclared parameter %q", name)
+
+				return reference
+			}
+
+			return fmt.Sprint(parameter)
+		})
+
+		return rendered, err
+
+	default:
+		return v, nil
+	}
+}
+
+// references returns the names of the parameters referenced in a value.
+func references(value interface{}) ([]string, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to encode template")
+	}
+
+	// JSON encoding escapes neither "$" nor braces, so references can be found in the encoded form
+	var names []string
+	for _, match := range parameterReferenceRegexp.FindAllStringSubmatch(string(raw), -1) {
+		names = append(names, match[1])
+	}
+
+	return names, nil
+}
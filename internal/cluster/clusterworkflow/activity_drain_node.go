@@ -0,0 +1,238 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const DrainNodeActivityName = "drain-node"
+
+// DefaultNodeDrainTimeout is the time to wait for the eviction of the pods of
+// a node when no drain timeout is specified.
+const DefaultNodeDrainTimeout = 15 * time.Minute
+
+// DrainNodeActivity cordons a node and evicts its pods through the eviction
+// API, so pod disruption budgets are respected.
+type DrainNodeActivity struct {
+	clientFactory ClientFactory
+	pollInterval  time.Duration
+}
+
+// NewDrainNodeActivity returns a new DrainNodeActivity.
+func NewDrainNodeActivity(clientFactory ClientFactory) DrainNodeActivity {
+	return DrainNodeActivity{
+		clientFactory: clientFactory,
+		pollInterval:  5 * time.Second,
+	}
+}
+
+type DrainNodeActivityInput struct {
+	ClusterID uint
+	NodeName  string
+
+	// Deadline of the pod evictions computed from the workflow time, so
+	// retries of the activity do not extend the drain timeout.
+	// DefaultNodeDrainTimeout is used from the start of the activity when zero.
+	Deadline time.Time
+
+	// FailOnError fails the drain when the pods cannot be evicted in time,
+	// otherwise the remaining pods are left to be removed together with the node.
+	FailOnError bool
+
+	// PodSelector restricts the evicted pods to the ones matching the label selector.
+	PodSelector string
+}
+
+type DrainNodeActivityOutput struct {
+	// BlockedPods lists the pods (namespace/name) which could not be evicted
+	// before the deadline and are removed together with the node.
+	BlockedPods []string
+}
+
+func (a DrainNodeActivity) Execute(ctx context.Context, input DrainNodeActivityInput) (DrainNodeActivityOutput, error) {
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return DrainNodeActivityOutput{}, cadence.WrapClientError(err)
+	}
+
+	logger := activity.GetLogger(ctx).Sugar().With(
+		"clusterID", input.ClusterID,
+		"node", input.NodeName,
+	)
+
+	node, err := client.CoreV1().Nodes().Get(ctx, input.NodeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		logger.Info("node not found, skipping drain")
+
+		return DrainNodeActivityOutput{}, nil
+	} else if err != nil {
+		return DrainNodeActivityOutput{}, errors.WrapIfWithDetails(err, "failed to get node", "node", input.NodeName)
+	}
+
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+
+		_, err = client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		if err != nil {
+			return DrainNodeActivityOutput{}, errors.WrapIfWithDetails(err, "failed to cordon node", "node", input.NodeName)
+		}
+	}
+
+	podList, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", input.NodeName).String(),
+		LabelSelector: input.PodSelector,
+	})
+	if err != nil {
+		return DrainNodeActivityOutput{}, errors.WrapIfWithDetails(err, "failed to list pods", "node", input.NodeName)
+	}
+
+	deadline := input.Deadline
+	if deadline.IsZero() {
+		deadline = time.Now().Add(DefaultNodeDrainTimeout)
+	}
+
+	pods := filterEvictablePods(podList.Items)
+	for len(pods) > 0 {
+		remainingPods := make([]corev1.Pod, 0, len(pods))
+		for _, pod := range pods {
+			isGone, err := evictPod(ctx, client, pod)
+			if err != nil {
+				if input.FailOnError {
+					return DrainNodeActivityOutput{}, err
+				}
+
+				logger.Warnf("skipping pod: %s", err.Error())
+
+				continue
+			}
+
+			if !isGone {
+				remainingPods = append(remainingPods, pod)
+			}
+		}
+
+		pods = remainingPods
+		if len(pods) == 0 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			blockedPods := make([]string, 0, len(pods))
+			for _, pod := range pods {
+				blockedPods = append(blockedPods, pod.Namespace+"/"+pod.Name)
+			}
+
+			if input.FailOnError {
+				return DrainNodeActivityOutput{}, errors.NewWithDetails(
+					"timed out waiting for the eviction of the pods",
+					"node", input.NodeName,
+					"pods", blockedPods,
+				)
+			}
+
+			logger.Warnw(
+				"timed out waiting for the eviction of the pods, the pods are removed together with the node",
+				"pods", blockedPods,
+			)
+
+			return DrainNodeActivityOutput{BlockedPods: blockedPods}, nil
+		}
+
+		activity.RecordHeartbeat(ctx, len(pods))
+
+		select {
+		case <-ctx.Done():
+			return DrainNodeActivityOutput{}, ctx.Err()
+		case <-time.After(a.pollInterval):
+		}
+	}
+
+	return DrainNodeActivityOutput{}, nil
+}
+
+// filterEvictablePods returns the pods which should be evicted from a node,
+// skipping the ones managed by a DaemonSet or the kubelet (mirror pods) and
+// the ones which have already completed.
+func filterEvictablePods(pods []corev1.Pod) []corev1.Pod {
+	evictablePods := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		pod := pods[i]
+
+		if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+			continue
+		}
+
+		if controller := metav1.GetControllerOf(&pod); controller != nil && controller.Kind == "DaemonSet" {
+			continue
+		}
+
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		evictablePods = append(evictablePods, pod)
+	}
+
+	return evictablePods
+}
+
+// evictPod requests the eviction of a pod and returns whether the pod is gone.
+func evictPod(ctx context.Context, client kubernetes.Interface, pod corev1.Pod) (bool, error) {
+	currentPod, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, errors.WrapIfWithDetails(err, "failed to get pod", "namespace", pod.Namespace, "pod", pod.Name)
+	}
+
+	if currentPod.UID != pod.UID { // Note: recreated by its controller (eg. StatefulSet) under the same name.
+		return true, nil
+	}
+
+	if currentPod.DeletionTimestamp != nil { // Note: already terminating.
+		return false, nil
+	}
+
+	eviction := &policyv1beta1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	err = client.PolicyV1beta1().Evictions(pod.Namespace).Evict(ctx, eviction)
+	switch {
+	case err == nil:
+		return false, nil
+	case apierrors.IsNotFound(err):
+		return true, nil
+	case apierrors.IsTooManyRequests(err): // Note: the eviction would violate a pod disruption budget, retrying later.
+		return false, nil
+	default:
+		return false, errors.WrapIfWithDetails(err, "failed to evict pod", "namespace", pod.Namespace, "pod", pod.Name)
+	}
+}
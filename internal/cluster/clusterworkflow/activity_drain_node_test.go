@@ -0,0 +1,199 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type clientFactoryStub struct {
+	client kubernetes.Interface
+}
+
+func (f clientFactoryStub) FromClusterID(_ context.Context, _ uint) (kubernetes.Interface, error) {
+	return f.client, nil
+}
+
+func newDrainTestPod(name string, uid string, modifiers ...func(pod *corev1.Pod)) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       k8stypes.UID(uid),
+			Labels:    map[string]string{"app": name},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+
+	for _, modify := range modifiers {
+		modify(pod)
+	}
+
+	return pod
+}
+
+// newDrainTestClient returns a fake client removing the evicted pods unless
+// their eviction is blocked by a pod disruption budget.
+func newDrainTestClient(objects []runtime.Object, blockedPods ...string) *fake.Clientset {
+	client := fake.NewSimpleClientset(objects...)
+
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1beta1.Eviction)
+
+		for _, blockedPod := range blockedPods {
+			if eviction.Name == blockedPod {
+				return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			}
+		}
+
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+		return true, nil, client.Tracker().Delete(gvr, eviction.Namespace, eviction.Name)
+	})
+
+	return client
+}
+
+func TestDrainNodeActivity(t *testing.T) {
+	daemonSetPod := newDrainTestPod("daemon", "uid-daemon", func(pod *corev1.Pod) {
+		isController := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "daemon", Controller: &isController}}
+	})
+	mirrorPod := newDrainTestPod("mirror", "uid-mirror", func(pod *corev1.Pod) {
+		pod.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "mirror"}
+	})
+
+	tests := map[string]struct {
+		input       DrainNodeActivityInput
+		blockedPods []string
+		wantErr     bool
+		wantPods    []string
+		wantBlocked []string
+	}{
+		"evicts pods": {
+			input: DrainNodeActivityInput{
+				NodeName: "node",
+			},
+			wantPods: []string{"daemon", "mirror"},
+		},
+		"evicts selected pods": {
+			input: DrainNodeActivityInput{
+				NodeName:    "node",
+				PodSelector: "app=app1",
+			},
+			wantPods: []string{"app2", "daemon", "mirror"},
+		},
+		"pod disruption budget": {
+			input: DrainNodeActivityInput{
+				NodeName: "node",
+				Deadline: time.Now().Add(100 * time.Millisecond),
+			},
+			blockedPods: []string{"app2"},
+			wantPods:    []string{"app2", "daemon", "mirror"},
+			wantBlocked: []string{"default/app2"},
+		},
+		"pod disruption budget with fail on error": {
+			input: DrainNodeActivityInput{
+				NodeName:    "node",
+				Deadline:    time.Now().Add(100 * time.Millisecond),
+				FailOnError: true,
+			},
+			blockedPods: []string{"app2"},
+			wantErr:     true,
+			wantPods:    []string{"app2", "daemon", "mirror"},
+		},
+		"missing node": {
+			input: DrainNodeActivityInput{
+				NodeName: "missing",
+			},
+			wantPods: []string{"app1", "app2", "daemon", "mirror"},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			client := newDrainTestClient(
+				[]runtime.Object{
+					&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}},
+					newDrainTestPod("app1", "uid-app1"),
+					newDrainTestPod("app2", "uid-app2"),
+					daemonSetPod.DeepCopy(),
+					mirrorPod.DeepCopy(),
+				},
+				test.blockedPods...,
+			)
+
+			drainNodeActivity := NewDrainNodeActivity(clientFactoryStub{client: client})
+			drainNodeActivity.pollInterval = 10 * time.Millisecond
+
+			env := (&testsuite.WorkflowTestSuite{}).NewTestActivityEnvironment()
+			env.RegisterActivityWithOptions(drainNodeActivity.Execute, activity.RegisterOptions{Name: DrainNodeActivityName})
+
+			value, err := env.ExecuteActivity(DrainNodeActivityName, test.input)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+
+				var output DrainNodeActivityOutput
+				require.NoError(t, value.Get(&output))
+				assert.ElementsMatch(t, test.wantBlocked, output.BlockedPods)
+			}
+
+			pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+
+			podNames := make([]string, 0, len(pods.Items))
+			for _, pod := range pods.Items {
+				podNames = append(podNames, pod.Name)
+			}
+			assert.ElementsMatch(t, test.wantPods, podNames)
+
+			if test.input.NodeName == "node" {
+				node, err := client.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.True(t, node.Spec.Unschedulable)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const WaitForNodesReadyActivityName = "wait-for-nodes-ready"
+
+// WaitForNodesReadyActivity waits until the specified nodes join the cluster and become ready.
+type WaitForNodesReadyActivity struct {
+	clientFactory ClientFactory
+	pollInterval  time.Duration
+}
+
+// NewWaitForNodesReadyActivity returns a new WaitForNodesReadyActivity.
+func NewWaitForNodesReadyActivity(clientFactory ClientFactory) WaitForNodesReadyActivity {
+	return WaitForNodesReadyActivity{
+		clientFactory: clientFactory,
+		pollInterval:  10 * time.Second,
+	}
+}
+
+type WaitForNodesReadyActivityInput struct {
+	ClusterID uint
+	NodeNames []string
+}
+
+func (a WaitForNodesReadyActivity) Execute(ctx context.Context, input WaitForNodesReadyActivityInput) error {
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	nodeNames := input.NodeNames
+	for len(nodeNames) > 0 {
+		notReadyNodeNames := make([]string, 0, len(nodeNames))
+		for _, nodeName := range nodeNames {
+			node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				notReadyNodeNames = append(notReadyNodeNames, nodeName)
+
+				continue
+			} else if err != nil {
+				return errors.WrapIfWithDetails(err, "failed to get node", "node", nodeName)
+			}
+
+			if !isNodeReady(node) {
+				notReadyNodeNames = append(notReadyNodeNames, nodeName)
+			}
+		}
+
+		nodeNames = notReadyNodeNames
+		if len(nodeNames) == 0 {
+			break
+		}
+
+		activity.RecordHeartbeat(ctx, nodeNames)
+
+		select {
+		case <-ctx.Done():
+			return errors.WrapIfWithDetails(ctx.Err(), "nodes are not ready", "nodes", nodeNames)
+		case <-time.After(a.pollInterval):
+		}
+	}
+
+	return nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
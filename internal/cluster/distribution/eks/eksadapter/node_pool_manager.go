@@ -253,8 +253,11 @@ func (n nodePoolManager) UpdateNodePool(
 		NodeImage:            nodePoolUpdate.Image,
 		SecurityGroups:       nodePoolUpdate.SecurityGroups,
 		UseInstanceStore:     nodePoolUpdate.UseInstanceStore,
+		Taints:               nodePoolUpdate.Taints,
+		StartupTaints:        nodePoolUpdate.StartupTaints,
 
 		Options: eks.NodePoolUpdateOptions{
+			Strategy:       nodePoolUpdate.Options.Strategy,
 			MaxSurge:       nodePoolUpdate.Options.MaxSurge,
 			MaxBatchSize:   nodePoolUpdate.Options.MaxBatchSize,
 			MaxUnavailable: nodePoolUpdate.Options.MaxUnavailable,
@@ -83,6 +83,8 @@ func (s nodePoolStore) CreateNodePool(
 		// NodeVolumeSize:   nodePool.VolumeSize, // Note: not stored in DB.
 		// NodeVolumeType:   nodepool.VolumeType, // Note: not stored in DB.
 		// Labels:           nodePool.Labels, // Note: not stored in DB.
+
+		MixedInstancesPolicy: (*eksmodel.JSONNodePoolMixedInstancesPolicy)(nodePool.MixedInstancesPolicy),
 	}
 
 	err = s.db.Save(nodePoolModel).Error
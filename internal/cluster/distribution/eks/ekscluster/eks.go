@@ -23,6 +23,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/ghodss/yaml"
 
+	"github.com/banzaicloud/pipeline/internal/cluster"
 	eks2 "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks"
 	"github.com/banzaicloud/pipeline/internal/global/globaleks"
 	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
@@ -125,6 +126,16 @@ type NodePool struct {
 	Image            string                    `json:"image" yaml:"image"`
 	Labels           map[string]string         `json:"labels,omitempty" yaml:"labels,omitempty"`
 
+	// Taints are registered on every node of the node pool and advertised to
+	// the cluster autoscaler, while StartupTaints are only registered on the
+	// nodes as they are expected to be removed once the node is ready.
+	Taints        []cluster.NodePoolTaint `json:"taints,omitempty" yaml:"taints,omitempty"`
+	StartupTaints []cluster.NodePoolTaint `json:"startupTaints,omitempty" yaml:"startupTaints,omitempty"`
+
+	// MixedInstancesPolicy optionally backs the node pool with multiple
+	// instance types and a mix of on-demand and spot instances.
+	MixedInstancesPolicy *eks2.NodePoolMixedInstancesPolicy `json:"mixedInstancesPolicy,omitempty" yaml:"mixedInstancesPolicy,omitempty"`
+
 	// SecurityGroups collects the user provided node security group IDs for the
 	// node pool.
 	SecurityGroups   []string `json:"securityGroups,omitempty" yaml:"securityGroups,omitempty"`
@@ -237,6 +248,16 @@ func (a *NodePool) Validate(npName string) error {
 		return err
 	}
 
+	// --- [Taint validation]--- //
+	if err := a.validateTaints(npName); err != nil {
+		return err
+	}
+
+	// --- [Mixed instances policy validation]--- //
+	if err := a.validateMixedInstancesPolicy(npName); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -277,6 +298,34 @@ func (a *NodePool) ValidateForUpdate(npName string) error {
 		return err
 	}
 
+	// --- [Taint validation]--- //
+	if err := a.validateTaints(npName); err != nil {
+		return err
+	}
+
+	// --- [Mixed instances policy validation]--- //
+	if err := a.validateMixedInstancesPolicy(npName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *NodePool) validateTaints(npName string) error {
+	violations := cluster.ValidateNodePoolTaints(a.Taints, a.StartupTaints)
+	if len(violations) > 0 {
+		return cluster.NewValidationError(fmt.Sprintf("invalid taints of node pool %q", npName), violations)
+	}
+
+	return nil
+}
+
+func (a *NodePool) validateMixedInstancesPolicy(npName string) error {
+	violations := eks2.ValidateNodePoolMixedInstancesPolicy(a.MixedInstancesPolicy, a.InstanceType)
+	if len(violations) > 0 {
+		return cluster.NewValidationError(fmt.Sprintf("invalid mixed instances policy of node pool %q", npName), violations)
+	}
+
 	return nil
 }
 
@@ -146,6 +146,8 @@ type AmazonNodePoolsModel struct {
 	StatusMessage    string             `gorm:"type:text"`
 	Labels           map[string]string  `gorm:"-"`
 	Delete           bool               `gorm:"-"`
+
+	MixedInstancesPolicy *JSONNodePoolMixedInstancesPolicy `gorm:"type:text"`
 }
 
 // TableName sets AmazonNodePoolsModel's table name
@@ -204,3 +206,24 @@ func (elt JSONStringArray) Value() (driver.Value, error) {
 func (elt *JSONStringArray) Scan(src interface{}) error {
 	return json.Unmarshal(src.([]byte), elt)
 }
+
+// JSONNodePoolMixedInstancesPolicy is a special type, that represents a node
+// pool mixed instances policy as a JSON object in SQL databases.
+type JSONNodePoolMixedInstancesPolicy eks.NodePoolMixedInstancesPolicy
+
+// Value implements the driver.Valuer interface
+func (policy JSONNodePoolMixedInstancesPolicy) Value() (driver.Value, error) {
+	return json.Marshal(policy)
+}
+
+// Scan implements the sql.Scanner interface
+func (policy *JSONNodePoolMixedInstancesPolicy) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, policy)
+	case string:
+		return json.Unmarshal([]byte(value), policy)
+	default:
+		return errors.Errorf("unsupported mixed instances policy value type %T", src)
+	}
+}
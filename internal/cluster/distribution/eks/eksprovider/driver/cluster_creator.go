@@ -218,9 +218,11 @@ func (c *EksClusterCreator) create(ctx context.Context, logger logrus.FieldLogge
 		}
 
 		nodePool := eks.NewNodePool{
-			Name:   requestedNodePoolName,
-			Labels: requestedNodePool.Labels,
-			Size:   requestedNodePool.Count,
+			Name:          requestedNodePoolName,
+			Labels:        requestedNodePool.Labels,
+			Taints:        requestedNodePool.Taints,
+			StartupTaints: requestedNodePool.StartupTaints,
+			Size:          requestedNodePool.Count,
 			Autoscaling: eks.Autoscaling{
 				Enabled: requestedNodePool.Autoscaling,
 				MinSize: requestedNodePool.MinCount,
@@ -234,6 +236,8 @@ func (c *EksClusterCreator) create(ctx context.Context, logger logrus.FieldLogge
 			SecurityGroups:   requestedNodePool.SecurityGroups,
 			SubnetID:         subnetID,
 			UseInstanceStore: requestedNodePool.UseInstanceStore,
+
+			MixedInstancesPolicy: requestedNodePool.MixedInstancesPolicy,
 		}
 
 		if requestedNodePool.VolumeEncryption != nil {
@@ -245,9 +245,13 @@ func newASGsFromRequestedUpdatedNodePools(
 			SecurityGroups:       nodePool.SecurityGroups,
 			UseInstanceStore:     nodePool.UseInstanceStore,
 			Labels:               nodePool.Labels,
+			Taints:               nodePool.Taints,
+			StartupTaints:        nodePool.StartupTaints,
 			Delete:               false,
 			Create:               false,
 			CreatedBy:            creators[nodePoolName],
+
+			MixedInstancesPolicy: nodePool.MixedInstancesPolicy,
 		})
 	}
 
@@ -450,9 +454,11 @@ func newNodePoolsFromRequestedNewNodePools(
 		}
 
 		newNodePools = append(newNodePools, eks.NewNodePool{
-			Name:   nodePoolName,
-			Labels: nodePool.Labels,
-			Size:   nodePool.Count,
+			Name:          nodePoolName,
+			Labels:        nodePool.Labels,
+			Taints:        nodePool.Taints,
+			StartupTaints: nodePool.StartupTaints,
+			Size:          nodePool.Count,
 			Autoscaling: eks.Autoscaling{
 				Enabled: nodePool.Autoscaling,
 				MinSize: nodePool.MinCount,
@@ -467,6 +473,8 @@ func newNodePoolsFromRequestedNewNodePools(
 			SecurityGroups:   nodePool.SecurityGroups,
 			SubnetID:         newNodePoolSubnetIDs[nodePoolName][0],
 			UseInstanceStore: nodePool.UseInstanceStore,
+
+			MixedInstancesPolicy: nodePool.MixedInstancesPolicy,
 		})
 	}
 
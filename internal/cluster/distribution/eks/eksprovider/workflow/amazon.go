@@ -17,6 +17,7 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,11 +33,14 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
 	zapadapter "logur.dev/adapter/zap"
 
+	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
 	internalAmazon "github.com/banzaicloud/pipeline/internal/providers/amazon"
 	"github.com/banzaicloud/pipeline/pkg/providers/amazon/autoscaling"
 	pkgCloudformation "github.com/banzaicloud/pipeline/pkg/providers/amazon/cloudformation"
+	sdkCloudformation "github.com/banzaicloud/pipeline/pkg/sdk/providers/amazon/cloudformation"
+	"github.com/banzaicloud/pipeline/pkg/sdk/semver"
 )
 
 // ErrReasonStackFailed cadence custom error reason that denotes a stack operation that resulted a stack failure
@@ -238,10 +242,14 @@ type AutoscaleGroup struct {
 	SecurityGroups   []string
 	UseInstanceStore *bool
 
-	Labels    map[string]string
-	Delete    bool
-	Create    bool
-	CreatedBy uint
+	Labels        map[string]string
+	Taints        []cluster.NodePoolTaint
+	StartupTaints []cluster.NodePoolTaint
+	Delete        bool
+	Create        bool
+	CreatedBy     uint
+
+	MixedInstancesPolicy *eks.NodePoolMixedInstancesPolicy
 }
 
 type Clusters interface {
@@ -516,3 +524,52 @@ func WaitForASGToBeFulfilled(
 		}
 	}
 }
+
+// SetNodePoolAutoscalerTaints advertises the specified node pool taints to the
+// cluster autoscaler through the node template tags of the node pool's ASG.
+func SetNodePoolAutoscalerTaints(awsSession *session.Session, stackName string, taints []cluster.NodePoolTaint) error {
+	asGroup, err := autoscaling.NewManager(awsSession).GetAutoscalingGroupByStackName(stackName)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not get ASG", "stackName", stackName)
+	}
+
+	nodeTemplateTaints := make(map[string]string, len(taints))
+	for _, taint := range taints {
+		nodeTemplateTaints[taint.Key] = taint.Value + ":" + taint.Effect
+	}
+
+	return asGroup.SetNodeTemplateTaints(nodeTemplateTaints)
+}
+
+// NewMixedInstancesPolicyStackParameters returns the node pool CloudFormation
+// stack parameters of the specified mixed instances policy ordered by key.
+func NewMixedInstancesPolicyStackParameters(policy *eks.NodePoolMixedInstancesPolicy) []*cloudformation.Parameter {
+	return newMixedInstancesPolicyStackParameters(policy, true)
+}
+
+// NewMixedInstancesPolicyUpdateStackParameters returns the node pool
+// CloudFormation stack update parameters keeping the current mixed instances
+// policy of the stack.
+func NewMixedInstancesPolicyUpdateStackParameters(currentTemplateVersion semver.Version) []*cloudformation.Parameter {
+	// Note: older templates cannot use non-existing previous value.
+	return newMixedInstancesPolicyStackParameters(nil, currentTemplateVersion.IsLessThan("2.6.0"))
+}
+
+func newMixedInstancesPolicyStackParameters(
+	policy *eks.NodePoolMixedInstancesPolicy, shouldUseNewValues bool,
+) []*cloudformation.Parameter {
+	values := eks.NewNodePoolMixedInstancesPolicyStackParameters(policy)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parameters := make([]*cloudformation.Parameter, 0, len(keys))
+	for _, key := range keys {
+		parameters = append(parameters, sdkCloudformation.NewOptionalStackParameter(key, shouldUseNewValues, values[key]))
+	}
+
+	return parameters
+}
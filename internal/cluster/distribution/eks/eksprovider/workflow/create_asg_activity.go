@@ -71,8 +71,12 @@ type CreateAsgActivityInput struct {
 	NodeImage            string
 	NodeInstanceType     string
 	Labels               map[string]string
+	Taints               []cluster.NodePoolTaint
+	StartupTaints        []cluster.NodePoolTaint
 	NodePoolVersion      string
 
+	MixedInstancesPolicy *eks.NodePoolMixedInstancesPolicy
+
 	Subnets             []Subnet
 	VpcID               string
 	SecurityGroupID     string
@@ -276,7 +280,16 @@ func (a *CreateAsgActivity) Execute(ctx context.Context, input CreateAsgActivity
 			ParameterKey:   aws.String("UseInstanceStore"),
 			ParameterValue: aws.String(strconv.FormatBool(aws.BoolValue(input.UseInstanceStore))),
 		},
+		{
+			ParameterKey:   aws.String("NodeTaints"),
+			ParameterValue: aws.String(cluster.FormatNodePoolTaints(input.Taints)),
+		},
+		{
+			ParameterKey:   aws.String("NodeStartupTaints"),
+			ParameterValue: aws.String(cluster.FormatNodePoolTaints(input.StartupTaints)),
+		},
 	}
+	stackParams = append(stackParams, NewMixedInstancesPolicyStackParameters(input.MixedInstancesPolicy)...)
 
 	requestToken := aws.String(sdkAmazon.NewNormalizedClientRequestToken(activity.GetInfo(ctx).WorkflowExecution.ID))
 
@@ -316,6 +329,15 @@ func (a *CreateAsgActivity) Execute(ctx context.Context, input CreateAsgActivity
 		return nil, packageCFError(err, input.StackName, *requestToken, cloudformationClient, "waiting for CF stack create operation to complete failed")
 	}
 
+	// Note: startup taints are expected to be removed once the node is ready,
+	// so only the regular taints are advertised to the cluster autoscaler.
+	if len(input.Taints) > 0 {
+		err = SetNodePoolAutoscalerTaints(awsSession, input.StackName, input.Taints)
+		if err != nil {
+			return nil, errors.WrapIff(err, "could not set autoscaler taints of node pool %q", input.Name)
+		}
+	}
+
 	// wait for ASG fulfillment
 	err = WaitForASGToBeFulfilled(ctx, logger, awsSession, input.StackName, input.Name)
 	if err != nil {
@@ -412,8 +434,12 @@ func createASGAsync(
 		NodeImage:            nodePool.Image,
 		NodeInstanceType:     nodePool.InstanceType,
 		Labels:               nodePool.Labels,
+		Taints:               nodePool.Taints,
+		StartupTaints:        nodePool.StartupTaints,
 		NodePoolVersion:      nodePoolVersion,
 
+		MixedInstancesPolicy: nodePool.MixedInstancesPolicy,
+
 		Subnets:             subnets,
 		VpcID:               vpcConfig.VpcID,
 		SecurityGroupID:     vpcConfig.SecurityGroupID,
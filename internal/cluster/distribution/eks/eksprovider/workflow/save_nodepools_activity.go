@@ -97,6 +97,8 @@ func (a SaveNodePoolsActivity) Execute(ctx context.Context, input SaveNodePoolsA
 				// NodeVolumeType:   asg.NodeVolumeType, // Note: not stored in DB.
 				// Labels:           asg.Labels, // Note: not stored in DB.
 				Delete: false,
+
+				MixedInstancesPolicy: (*eksmodel.JSONNodePoolMixedInstancesPolicy)(asg.MixedInstancesPolicy),
 			}
 			updatedNodepools = append(updatedNodepools, np)
 		}
@@ -80,6 +80,10 @@ type UpdateAsgActivityInput struct {
 	Labels map[string]string
 	Tags   map[string]string
 
+	// Taints and StartupTaints are left unchanged when nil.
+	Taints        []cluster.NodePoolTaint
+	StartupTaints []cluster.NodePoolTaint
+
 	CurrentTemplateVersion semver.Version
 }
 
@@ -318,7 +322,18 @@ func (a *UpdateAsgActivity) Execute(ctx context.Context, input UpdateAsgActivity
 			input.UseInstanceStore != nil || input.CurrentTemplateVersion.IsLessThan("2.2.0"),
 			strconv.FormatBool(aws.BoolValue(input.UseInstanceStore)), // Note: false default value for old stacks.
 		),
+		sdkCloudformation.NewOptionalStackParameter(
+			"NodeTaints",
+			input.Taints != nil || input.CurrentTemplateVersion.IsLessThan("2.5.0"),
+			cluster.FormatNodePoolTaints(input.Taints),
+		),
+		sdkCloudformation.NewOptionalStackParameter(
+			"NodeStartupTaints",
+			input.StartupTaints != nil || input.CurrentTemplateVersion.IsLessThan("2.5.0"),
+			cluster.FormatNodePoolTaints(input.StartupTaints),
+		),
 	}
+	stackParams = append(stackParams, NewMixedInstancesPolicyUpdateStackParameters(input.CurrentTemplateVersion)...)
 
 	requestToken := aws.String(sdkAmazon.NewNormalizedClientRequestToken(activity.GetInfo(ctx).WorkflowExecution.ID))
 
@@ -339,6 +354,14 @@ func (a *UpdateAsgActivity) Execute(ctx context.Context, input UpdateAsgActivity
 		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationError" && strings.HasPrefix(awsErr.Message(), awsNoUpdatesError) {
 			// Get error details
 			activity.GetLogger(ctx).Warn("nothing changed during update!")
+
+			if input.Taints != nil {
+				err = SetNodePoolAutoscalerTaints(awsSession, input.StackName, input.Taints)
+				if err != nil {
+					return nil, errors.WrapIff(err, "could not set autoscaler taints of node pool %q", input.Name)
+				}
+			}
+
 			return &outParams, nil
 		}
 
@@ -361,6 +384,13 @@ func (a *UpdateAsgActivity) Execute(ctx context.Context, input UpdateAsgActivity
 		return nil, packageCFError(err, input.StackName, *requestToken, cloudformationClient, "waiting for CF stack create operation to complete failed")
 	}
 
+	if input.Taints != nil {
+		err = SetNodePoolAutoscalerTaints(awsSession, input.StackName, input.Taints)
+		if err != nil {
+			return nil, errors.WrapIff(err, "could not set autoscaler taints of node pool %q", input.Name)
+		}
+	}
+
 	// wait for ASG fulfillment
 	err = WaitForASGToBeFulfilled(ctx, logger, awsSession, input.StackName, input.Name)
 	if err != nil {
@@ -0,0 +1,70 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eksworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/workflow"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+const SetNodePoolAutoscalerTaintsActivityName = "eks-set-node-pool-autoscaler-taints"
+
+// SetNodePoolAutoscalerTaintsActivity advertises the taints of a node pool to
+// the cluster autoscaler through the node template tags of its auto scaling group.
+type SetNodePoolAutoscalerTaintsActivity struct {
+	sessionFactory AWSSessionFactory
+}
+
+// SetNodePoolAutoscalerTaintsActivityInput holds the parameters of the node pool taint advertisement.
+type SetNodePoolAutoscalerTaintsActivityInput struct {
+	SecretID     string
+	Region       string
+	StackName    string
+	NodePoolName string
+	Taints       []cluster.NodePoolTaint
+}
+
+// NewSetNodePoolAutoscalerTaintsActivity creates a new SetNodePoolAutoscalerTaintsActivity instance.
+func NewSetNodePoolAutoscalerTaintsActivity(sessionFactory AWSSessionFactory) SetNodePoolAutoscalerTaintsActivity {
+	return SetNodePoolAutoscalerTaintsActivity{
+		sessionFactory: sessionFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a SetNodePoolAutoscalerTaintsActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: SetNodePoolAutoscalerTaintsActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a SetNodePoolAutoscalerTaintsActivity) Execute(ctx context.Context, input SetNodePoolAutoscalerTaintsActivityInput) error {
+	sess, err := a.sessionFactory.NewSession(input.SecretID, input.Region)
+	if err = errors.WrapIf(err, "failed to create AWS session"); err != nil {
+		return err
+	}
+
+	err = workflow.SetNodePoolAutoscalerTaints(sess, input.StackName, input.Taints)
+	if err != nil {
+		return errors.WrapIff(err, "could not set autoscaler taints of node pool %q", input.NodePoolName)
+	}
+
+	return nil
+}
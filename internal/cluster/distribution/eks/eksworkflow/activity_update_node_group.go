@@ -30,6 +30,7 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/workflow"
 	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
 	pkgCloudFormation "github.com/banzaicloud/pipeline/pkg/providers/amazon/cloudformation"
 	sdkAmazon "github.com/banzaicloud/pipeline/pkg/sdk/providers/amazon"
@@ -70,9 +71,16 @@ type UpdateNodeGroupActivityInput struct {
 	SecurityGroups       []string
 	UseInstanceStore     *bool
 
+	// Taints and StartupTaints are left unchanged when nil.
+	Taints        []cluster.NodePoolTaint
+	StartupTaints []cluster.NodePoolTaint
+
 	MaxBatchSize          int
 	MinInstancesInService int
 
+	// UpdateStrategy of the nodes, the rolling update of the ASG is disabled when the nodes are replaced by Pipeline.
+	UpdateStrategy string
+
 	ClusterTags map[string]string
 
 	CurrentTemplateVersion semver.Version
@@ -195,6 +203,10 @@ func (a UpdateNodeGroupActivity) Execute(ctx context.Context, input UpdateNodeGr
 			ParameterKey:   aws.String("NodeAutoScalingGroupMinInstancesInService"),
 			ParameterValue: aws.String(fmt.Sprintf("%d", input.MinInstancesInService)),
 		},
+		{
+			ParameterKey:   aws.String("NodeAutoScalingGroupRollingUpdateEnabled"),
+			ParameterValue: aws.String(strconv.FormatBool(input.UpdateStrategy != cluster.NodePoolUpdateStrategyReplace)),
+		},
 		sdkCloudFormation.NewOptionalStackParameter(
 			"NodeAutoScalingInitSize",
 			input.DesiredCapacity > 0,
@@ -269,7 +281,18 @@ func (a UpdateNodeGroupActivity) Execute(ctx context.Context, input UpdateNodeGr
 			input.UseInstanceStore != nil || input.CurrentTemplateVersion.IsLessThan("2.2.0"),
 			strconv.FormatBool(aws.BoolValue(input.UseInstanceStore)), // Note: false default value for old stacks.
 		),
+		sdkCloudFormation.NewOptionalStackParameter(
+			"NodeTaints",
+			input.Taints != nil || input.CurrentTemplateVersion.IsLessThan("2.5.0"), // Note: older templates cannot use non-existing previous value.
+			cluster.FormatNodePoolTaints(input.Taints),
+		),
+		sdkCloudFormation.NewOptionalStackParameter(
+			"NodeStartupTaints",
+			input.StartupTaints != nil || input.CurrentTemplateVersion.IsLessThan("2.5.0"), // Note: older templates cannot use non-existing previous value.
+			cluster.FormatNodePoolTaints(input.StartupTaints),
+		),
 	}
+	stackParams = append(stackParams, workflow.NewMixedInstancesPolicyUpdateStackParameters(input.CurrentTemplateVersion)...)
 
 	// we don't reuse the creation time template, since it may have changed
 	updateStackInput := &cloudformation.UpdateStackInput{
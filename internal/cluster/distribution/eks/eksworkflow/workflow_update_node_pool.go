@@ -76,6 +76,8 @@ type UpdateNodePoolWorkflowInput struct {
 	NodeImage            string
 	SecurityGroups       []string
 	UseInstanceStore     *bool
+	Taints               []cluster.NodePoolTaint
+	StartupTaints        []cluster.NodePoolTaint
 
 	Options eks.NodePoolUpdateOptions
 
@@ -267,6 +269,7 @@ func (w UpdateNodePoolWorkflow) Execute(ctx workflow.Context, input UpdateNodePo
 		nodePoolVersion = output.Version
 	}
 
+	nodePoolChanged := false
 	{
 		activityInput := UpdateNodeGroupActivityInput{
 			SecretID:               input.ProviderSecretID,
@@ -281,8 +284,11 @@ func (w UpdateNodePoolWorkflow) Execute(ctx workflow.Context, input UpdateNodePo
 			NodeImage:              input.NodeImage,
 			SecurityGroups:         input.SecurityGroups,
 			UseInstanceStore:       input.UseInstanceStore,
+			Taints:                 input.Taints,
+			StartupTaints:          input.StartupTaints,
 			MaxBatchSize:           input.Options.MaxBatchSize,
 			MinInstancesInService:  input.Options.MaxSurge,
+			UpdateStrategy:         input.Options.Strategy,
 			ClusterTags:            input.ClusterTags,
 			CurrentTemplateVersion: currentTemplateVersion,
 		}
@@ -305,12 +311,14 @@ func (w UpdateNodePoolWorkflow) Execute(ctx workflow.Context, input UpdateNodePo
 			activityInput,
 		).Get(ctx, &output)
 		processActivity.Finish(ctx, err)
-		if err != nil || !output.NodePoolChanged {
-			return
+		if err != nil {
+			return err
 		}
+
+		nodePoolChanged = output.NodePoolChanged
 	}
 
-	{
+	if nodePoolChanged {
 		activityInput := WaitCloudFormationStackUpdateActivityInput{
 			SecretID:  input.ProviderSecretID,
 			Region:    input.Region,
@@ -339,5 +347,49 @@ func (w UpdateNodePoolWorkflow) Execute(ctx workflow.Context, input UpdateNodePo
 		}
 	}
 
+	// Note: the taints are advertised to the cluster autoscaler only after
+	// the stack update completed, so a failed update does not leave them
+	// diverged from the nodes. Startup taints are expected to be removed once
+	// the node is ready, so only the regular taints are advertised.
+	if input.Taints != nil {
+		activityInput := SetNodePoolAutoscalerTaintsActivityInput{
+			SecretID:     input.ProviderSecretID,
+			Region:       input.Region,
+			StackName:    input.StackName,
+			NodePoolName: input.NodePoolName,
+			Taints:       input.Taints,
+		}
+
+		processActivity := process.StartActivity(ctx, SetNodePoolAutoscalerTaintsActivityName)
+		err = workflow.ExecuteActivity(ctx, SetNodePoolAutoscalerTaintsActivityName, activityInput).Get(ctx, nil)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Note: replacing outdated instances left by a previous update as well when the stack is unchanged.
+	if input.Options.Strategy == cluster.NodePoolUpdateStrategyReplace {
+		err = awsworkflow.ReplaceNodePoolInstances(ctx, process, awsworkflow.ReplaceNodePoolInstancesInput{
+			AWSCommonActivityInput: awsworkflow.AWSCommonActivityInput{
+				OrganizationID: input.OrganizationID,
+				SecretID:       providerSecretID.ResourceID,
+				Region:         input.Region,
+				ClusterName:    input.ClusterName,
+			},
+			ClusterID:         input.ClusterID,
+			StackName:         input.StackName,
+			LogicalResourceID: "NodeGroup",
+			MaxSurge:          input.Options.MaxSurge,
+			MaxUnavailable:    input.Options.MaxUnavailable,
+			DrainTimeout:      time.Duration(input.Options.Drain.Timeout) * time.Second,
+			DrainFailOnError:  input.Options.Drain.FailOnError,
+			DrainPodSelector:  input.Options.Drain.PodSelector,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,252 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+const (
+	SpotAllocationStrategyLowestPrice                  = "lowest-price"
+	SpotAllocationStrategyCapacityOptimized            = "capacity-optimized"
+	SpotAllocationStrategyCapacityOptimizedPrioritized = "capacity-optimized-prioritized"
+
+	// DefaultSpotAllocationStrategy is the spot allocation strategy used when
+	// none is specified in a mixed instances policy.
+	DefaultSpotAllocationStrategy = SpotAllocationStrategyCapacityOptimized
+
+	// MaxNodePoolInstanceTypes is the maximum number of instance types of a
+	// mixed instances policy, it is limited by the instance type override slots
+	// of the node pool CloudFormation template.
+	MaxNodePoolInstanceTypes = 8
+
+	maxNodePoolInstanceTypeWeightedCapacity = 999
+)
+
+// NodePoolMixedInstancesPolicy describes the auto scaling group mixed
+// instances policy of an EKS node pool.
+//
+// The node pool size values are interpreted in weighted capacity units when
+// weights are specified for the instance types.
+type NodePoolMixedInstancesPolicy struct {
+	InstanceTypes                       []NodePoolInstanceType `json:"instanceTypes" yaml:"instanceTypes" mapstructure:"instanceTypes"`
+	OnDemandBaseCapacity                int                    `json:"onDemandBaseCapacity,omitempty" yaml:"onDemandBaseCapacity,omitempty" mapstructure:"onDemandBaseCapacity"`
+	OnDemandPercentageAboveBaseCapacity int                    `json:"onDemandPercentageAboveBaseCapacity,omitempty" yaml:"onDemandPercentageAboveBaseCapacity,omitempty" mapstructure:"onDemandPercentageAboveBaseCapacity"`
+	SpotAllocationStrategy              string                 `json:"spotAllocationStrategy,omitempty" yaml:"spotAllocationStrategy,omitempty" mapstructure:"spotAllocationStrategy"`
+}
+
+// NodePoolInstanceType describes an instance type of a node pool mixed
+// instances policy.
+type NodePoolInstanceType struct {
+	InstanceType string `json:"instanceType" yaml:"instanceType" mapstructure:"instanceType"`
+
+	// WeightedCapacity is the number of capacity units an instance of the type
+	// provides, 0 is handled as 1.
+	WeightedCapacity int `json:"weightedCapacity,omitempty" yaml:"weightedCapacity,omitempty" mapstructure:"weightedCapacity"`
+}
+
+// String returns the instance type in the type[:weight] format used by the
+// node pool CloudFormation stack parameters.
+func (t NodePoolInstanceType) String() string {
+	weightedCapacity := t.WeightedCapacity
+	if weightedCapacity == 0 {
+		weightedCapacity = 1
+	}
+
+	return fmt.Sprintf("%s:%d", t.InstanceType, weightedCapacity)
+}
+
+// ParseNodePoolInstanceType parses an instance type in the type[:weight] format.
+func ParseNodePoolInstanceType(s string) (NodePoolInstanceType, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if parts[0] == "" {
+		return NodePoolInstanceType{}, errors.Errorf("missing instance type in %q", s)
+	}
+
+	if len(parts) == 1 {
+		return NodePoolInstanceType{InstanceType: parts[0]}, nil
+	}
+
+	weightedCapacity, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return NodePoolInstanceType{}, errors.WrapIff(err, "invalid weighted capacity in %q", s)
+	}
+
+	return NodePoolInstanceType{
+		InstanceType:     parts[0],
+		WeightedCapacity: weightedCapacity,
+	}, nil
+}
+
+// ValidateNodePoolMixedInstancesPolicy returns the violations of the specified
+// mixed instances policy of a node pool with the specified (default) instance
+// type.
+func ValidateNodePoolMixedInstancesPolicy(policy *NodePoolMixedInstancesPolicy, instanceType string) (violations []string) {
+	if policy == nil {
+		return nil
+	}
+
+	if len(policy.InstanceTypes) == 0 {
+		violations = append(violations, "mixed instances policy must contain at least one instance type")
+	} else if len(policy.InstanceTypes) > MaxNodePoolInstanceTypes {
+		violations = append(violations, fmt.Sprintf("mixed instances policy cannot contain more than %d instance types", MaxNodePoolInstanceTypes))
+	}
+
+	isInstanceTypeListed := false
+	instanceTypes := make(map[string]bool, len(policy.InstanceTypes))
+	for _, policyInstanceType := range policy.InstanceTypes {
+		if policyInstanceType.InstanceType == "" {
+			violations = append(violations, "mixed instances policy instance type cannot be empty")
+
+			continue
+		} else if instanceTypes[policyInstanceType.InstanceType] {
+			violations = append(violations, fmt.Sprintf("duplicate mixed instances policy instance type %q", policyInstanceType.InstanceType))
+		}
+
+		instanceTypes[policyInstanceType.InstanceType] = true
+
+		if strings.ContainsAny(policyInstanceType.InstanceType, ",:") {
+			violations = append(violations, fmt.Sprintf("invalid mixed instances policy instance type %q", policyInstanceType.InstanceType))
+		}
+
+		// Note: a zero weighted capacity is handled as unset (1).
+		if policyInstanceType.WeightedCapacity < 0 ||
+			policyInstanceType.WeightedCapacity > maxNodePoolInstanceTypeWeightedCapacity {
+			violations = append(violations, fmt.Sprintf(
+				"weighted capacity of instance type %q must be between 1 and %d (0 means the default 1)",
+				policyInstanceType.InstanceType, maxNodePoolInstanceTypeWeightedCapacity,
+			))
+		}
+
+		if policyInstanceType.InstanceType == instanceType {
+			isInstanceTypeListed = true
+		}
+	}
+
+	if instanceType != "" && len(policy.InstanceTypes) > 0 && !isInstanceTypeListed {
+		violations = append(violations, fmt.Sprintf("instance type %q must be one of the mixed instances policy instance types", instanceType))
+	}
+
+	if policy.OnDemandBaseCapacity < 0 {
+		violations = append(violations, "on-demand base capacity cannot be lower than zero")
+	}
+
+	if policy.OnDemandPercentageAboveBaseCapacity < 0 || policy.OnDemandPercentageAboveBaseCapacity > 100 {
+		violations = append(violations, "on-demand percentage above base capacity must be between 0 and 100")
+	}
+
+	switch policy.SpotAllocationStrategy {
+	case "",
+		SpotAllocationStrategyLowestPrice,
+		SpotAllocationStrategyCapacityOptimized,
+		SpotAllocationStrategyCapacityOptimizedPrioritized:
+	default:
+		violations = append(violations, fmt.Sprintf("invalid spot allocation strategy %q", policy.SpotAllocationStrategy))
+	}
+
+	return violations
+}
+
+// NewNodePoolMixedInstancesPolicyStackParameters returns the CloudFormation
+// stack parameters of the specified mixed instances policy.
+//
+// A nil policy results in the parameter values disabling the mixed instances
+// policy of the node pool.
+func NewNodePoolMixedInstancesPolicyStackParameters(policy *NodePoolMixedInstancesPolicy) map[string]string {
+	parameters := map[string]string{
+		"NodeOnDemandBaseCapacity":                "0",
+		"NodeOnDemandPercentageAboveBaseCapacity": "0",
+		"NodeSpotAllocationStrategy":              DefaultSpotAllocationStrategy,
+	}
+
+	for slotIndex := 0; slotIndex < MaxNodePoolInstanceTypes; slotIndex++ {
+		parameters[nodeInstanceTypeOverrideStackParameterKey(slotIndex)] = ""
+	}
+
+	if policy == nil {
+		return parameters
+	}
+
+	for instanceTypeIndex, instanceType := range policy.InstanceTypes {
+		parameters[nodeInstanceTypeOverrideStackParameterKey(instanceTypeIndex)] = instanceType.String()
+	}
+
+	parameters["NodeOnDemandBaseCapacity"] = strconv.Itoa(policy.OnDemandBaseCapacity)
+	parameters["NodeOnDemandPercentageAboveBaseCapacity"] = strconv.Itoa(policy.OnDemandPercentageAboveBaseCapacity)
+
+	if policy.SpotAllocationStrategy != "" {
+		parameters["NodeSpotAllocationStrategy"] = policy.SpotAllocationStrategy
+	}
+
+	return parameters
+}
+
+// NewNodePoolMixedInstancesPolicyFromStackParameters parses the mixed instances
+// policy from the specified CloudFormation stack parameter values.
+//
+// A nil policy is returned when the stack has no instance type overrides.
+func NewNodePoolMixedInstancesPolicyFromStackParameters(
+	parameters map[string]string,
+) (policy *NodePoolMixedInstancesPolicy, err error) {
+	for slotIndex := 0; slotIndex < MaxNodePoolInstanceTypes; slotIndex++ {
+		value := parameters[nodeInstanceTypeOverrideStackParameterKey(slotIndex)]
+		if value == "" {
+			continue
+		}
+
+		instanceType, err := ParseNodePoolInstanceType(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if policy == nil {
+			policy = &NodePoolMixedInstancesPolicy{}
+		}
+
+		policy.InstanceTypes = append(policy.InstanceTypes, instanceType)
+	}
+
+	if policy == nil {
+		return nil, nil
+	}
+
+	if value := parameters["NodeOnDemandBaseCapacity"]; value != "" {
+		policy.OnDemandBaseCapacity, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.WrapIf(err, "invalid on-demand base capacity")
+		}
+	}
+
+	if value := parameters["NodeOnDemandPercentageAboveBaseCapacity"]; value != "" {
+		policy.OnDemandPercentageAboveBaseCapacity, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.WrapIf(err, "invalid on-demand percentage above base capacity")
+		}
+	}
+
+	policy.SpotAllocationStrategy = parameters["NodeSpotAllocationStrategy"]
+
+	return policy, nil
+}
+
+// nodeInstanceTypeOverrideStackParameterKey returns the stack parameter key of
+// the instance type override slot with the specified zero based index.
+func nodeInstanceTypeOverrideStackParameterKey(slotIndex int) string {
+	return "NodeInstanceTypeOverride" + strconv.Itoa(slotIndex+1)
+}
@@ -0,0 +1,144 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNodePoolMixedInstancesPolicy(t *testing.T) {
+	testCases := []struct {
+		caseName           string
+		policy             *NodePoolMixedInstancesPolicy
+		instanceType       string
+		expectedViolations []string
+	}{
+		{
+			caseName:     "no policy",
+			policy:       nil,
+			instanceType: "m5.large",
+		},
+		{
+			caseName: "valid policy",
+			policy: &NodePoolMixedInstancesPolicy{
+				InstanceTypes: []NodePoolInstanceType{
+					{InstanceType: "m5.large"},
+					{InstanceType: "m5.xlarge", WeightedCapacity: 2},
+				},
+				OnDemandBaseCapacity:                1,
+				OnDemandPercentageAboveBaseCapacity: 25,
+				SpotAllocationStrategy:              SpotAllocationStrategyCapacityOptimized,
+			},
+			instanceType: "m5.large",
+		},
+		{
+			caseName:     "no instance types",
+			policy:       &NodePoolMixedInstancesPolicy{},
+			instanceType: "m5.large",
+			expectedViolations: []string{
+				"mixed instances policy must contain at least one instance type",
+			},
+		},
+		{
+			caseName: "invalid instance types",
+			policy: &NodePoolMixedInstancesPolicy{
+				InstanceTypes: []NodePoolInstanceType{
+					{InstanceType: "m5.large"},
+					{InstanceType: "m5.large", WeightedCapacity: 1000},
+					{InstanceType: ""},
+				},
+			},
+			instanceType: "c5.large",
+			expectedViolations: []string{
+				"duplicate mixed instances policy instance type \"m5.large\"",
+				"weighted capacity of instance type \"m5.large\" must be between 1 and 999 (0 means the default 1)",
+				"mixed instances policy instance type cannot be empty",
+				"instance type \"c5.large\" must be one of the mixed instances policy instance types",
+			},
+		},
+		{
+			caseName: "invalid distribution",
+			policy: &NodePoolMixedInstancesPolicy{
+				InstanceTypes:                       []NodePoolInstanceType{{InstanceType: "m5.large"}},
+				OnDemandBaseCapacity:                -1,
+				OnDemandPercentageAboveBaseCapacity: 101,
+				SpotAllocationStrategy:              "random",
+			},
+			instanceType: "m5.large",
+			expectedViolations: []string{
+				"on-demand base capacity cannot be lower than zero",
+				"on-demand percentage above base capacity must be between 0 and 100",
+				"invalid spot allocation strategy \"random\"",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.caseName, func(t *testing.T) {
+			violations := ValidateNodePoolMixedInstancesPolicy(testCase.policy, testCase.instanceType)
+
+			require.Equal(t, testCase.expectedViolations, violations)
+		})
+	}
+}
+
+func TestNodePoolMixedInstancesPolicyStackParameters(t *testing.T) {
+	t.Run("NoPolicy", func(t *testing.T) {
+		parameters := NewNodePoolMixedInstancesPolicyStackParameters(nil)
+
+		require.Equal(t, "", parameters["NodeInstanceTypeOverride1"])
+		require.Equal(t, "", parameters["NodeInstanceTypeOverride8"])
+		require.Equal(t, DefaultSpotAllocationStrategy, parameters["NodeSpotAllocationStrategy"])
+
+		policy, err := NewNodePoolMixedInstancesPolicyFromStackParameters(parameters)
+		require.NoError(t, err)
+		require.Nil(t, policy)
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		policy := &NodePoolMixedInstancesPolicy{
+			InstanceTypes: []NodePoolInstanceType{
+				{InstanceType: "m5.large", WeightedCapacity: 1},
+				{InstanceType: "m5.xlarge", WeightedCapacity: 2},
+			},
+			OnDemandBaseCapacity:                1,
+			OnDemandPercentageAboveBaseCapacity: 25,
+			SpotAllocationStrategy:              SpotAllocationStrategyLowestPrice,
+		}
+
+		parameters := NewNodePoolMixedInstancesPolicyStackParameters(policy)
+
+		require.Equal(t, "m5.large:1", parameters["NodeInstanceTypeOverride1"])
+		require.Equal(t, "m5.xlarge:2", parameters["NodeInstanceTypeOverride2"])
+		require.Equal(t, "", parameters["NodeInstanceTypeOverride3"])
+		require.Equal(t, "1", parameters["NodeOnDemandBaseCapacity"])
+		require.Equal(t, "25", parameters["NodeOnDemandPercentageAboveBaseCapacity"])
+
+		parsedPolicy, err := NewNodePoolMixedInstancesPolicyFromStackParameters(parameters)
+		require.NoError(t, err)
+		require.Equal(t, policy, parsedPolicy)
+	})
+
+	t.Run("InvalidInstanceType", func(t *testing.T) {
+		_, err := NewNodePoolMixedInstancesPolicyFromStackParameters(map[string]string{
+			"NodeInstanceTypeOverride1": "m5.large:many",
+		})
+		require.Error(t, err)
+	})
+}
@@ -22,10 +22,12 @@ import (
 
 // NewNodePool describes new a Kubernetes node pool in an Amazon EKS cluster.
 type NewNodePool struct {
-	Name        string            `mapstructure:"name"`
-	Labels      map[string]string `mapstructure:"labels"`
-	Size        int               `mapstructure:"size"`
-	Autoscaling struct {
+	Name          string                  `mapstructure:"name"`
+	Labels        map[string]string       `mapstructure:"labels"`
+	Taints        []cluster.NodePoolTaint `mapstructure:"taints"`
+	StartupTaints []cluster.NodePoolTaint `mapstructure:"startupTaints"`
+	Size          int                     `mapstructure:"size"`
+	Autoscaling   struct {
 		Enabled bool `mapstructure:"enabled"`
 		MinSize int  `mapstructure:"minSize"`
 		MaxSize int  `mapstructure:"maxSize"`
@@ -39,6 +41,11 @@ type NewNodePool struct {
 	SecurityGroups   []string                  `mapstructure:"securityGroups"`
 	SubnetID         string                    `mapstructure:"subnetId"`
 	UseInstanceStore *bool                     `mapstructure:"useInstanceStore,omitempty"`
+
+	// MixedInstancesPolicy optionally backs the node pool with multiple
+	// instance types and a mix of on-demand and spot instances, the spot price
+	// is used as the maximum spot price of the policy in this case.
+	MixedInstancesPolicy *NodePoolMixedInstancesPolicy `mapstructure:"mixedInstancesPolicy,omitempty"`
 }
 
 // Validate semantically validates the new node pool.
@@ -71,6 +78,9 @@ func (n NewNodePool) Validate() error {
 		violations = append(violations, "instance type cannot be empty")
 	}
 
+	violations = append(violations, cluster.ValidateNodePoolTaints(n.Taints, n.StartupTaints)...)
+	violations = append(violations, ValidateNodePoolMixedInstancesPolicy(n.MixedInstancesPolicy, n.InstanceType)...)
+
 	if len(violations) > 0 {
 		return cluster.NewValidationError("invalid node pool creation request", violations)
 	}
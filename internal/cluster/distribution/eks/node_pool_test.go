@@ -115,3 +115,36 @@ func TestNodePoolSizeValidation(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestNodePoolTaintValidation(t *testing.T) {
+	base := NewNodePool{
+		Name:         "pool",
+		Size:         1,
+		InstanceType: "c5.large",
+	}
+
+	t.Run("ValidTaints", func(t *testing.T) {
+		pool := base
+		pool.Taints = []cluster.NodePoolTaint{{Key: "dedicated", Value: "gpu", Effect: cluster.NodePoolTaintEffectNoSchedule}}
+		pool.StartupTaints = []cluster.NodePoolTaint{{Key: "example.com/not-ready", Effect: cluster.NodePoolTaintEffectNoExecute}}
+
+		err := pool.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("InvalidTaintEffect", func(t *testing.T) {
+		pool := base
+		pool.Taints = []cluster.NodePoolTaint{{Key: "dedicated", Value: "gpu", Effect: "NoWay"}}
+
+		err := pool.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+
+	t.Run("InvalidStartupTaintKey", func(t *testing.T) {
+		pool := base
+		pool.StartupTaints = []cluster.NodePoolTaint{{Key: "node.kubernetes.io/not-ready", Effect: cluster.NodePoolTaintEffectNoExecute}}
+
+		err := pool.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+}
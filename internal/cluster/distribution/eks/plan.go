@@ -0,0 +1,266 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// PlanCreateNodePools returns the changes CreateNodePools would make without applying them.
+func (s service) PlanCreateNodePools(ctx context.Context, clusterID uint, nodePools map[string]NewNodePool) (cluster.Plan, error) {
+	c, err := s.genericClusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return cluster.Plan{}, err
+	}
+
+	npErrors := make([]error, 0, len(nodePools))
+	for _, nodePool := range nodePools {
+		if err := s.nodePoolValidator.ValidateNewNodePool(ctx, c, nodePool); err != nil {
+			npErrors = append(npErrors, err)
+		}
+	}
+	if len(npErrors) > 0 {
+		return cluster.Plan{}, errors.Combine(npErrors...)
+	}
+
+	processedNodePools := make(map[string]NewNodePool, len(nodePools))
+	for nodePoolName, nodePool := range nodePools {
+		processedNodePools[nodePoolName], err = s.nodePoolProcessor.ProcessNewNodePool(ctx, c, nodePool)
+		if err != nil {
+			npErrors = append(npErrors, err)
+		}
+	}
+	if len(npErrors) > 0 {
+		return cluster.Plan{}, errors.Combine(npErrors...)
+	}
+
+	nodePoolNames := make([]string, 0, len(processedNodePools))
+	for nodePoolName := range processedNodePools {
+		nodePoolNames = append(nodePoolNames, nodePoolName)
+	}
+	sort.Strings(nodePoolNames)
+
+	var plan cluster.Plan
+
+	for _, nodePoolName := range nodePoolNames {
+		nodePool := processedNodePools[nodePoolName]
+
+		plan.Add(cluster.PlanChange{
+			Action:   cluster.PlanActionCreate,
+			Resource: cluster.PlanResourceNodePool,
+			Name:     nodePoolName,
+		})
+
+		if len(nodePool.Labels) > 0 {
+			plan.Add(cluster.PlanChange{
+				Action:    cluster.PlanActionCreate,
+				Resource:  cluster.PlanResourceNodePool,
+				Name:      nodePoolName,
+				Attribute: "labels",
+				Desired:   nodePool.Labels,
+			})
+		}
+
+		parameters := newNodePoolStackParameters(nodePool)
+
+		parameterNames := make([]string, 0, len(parameters))
+		for parameterName := range parameters {
+			parameterNames = append(parameterNames, parameterName)
+		}
+		sort.Strings(parameterNames)
+
+		for _, parameterName := range parameterNames {
+			plan.Add(cluster.PlanChange{
+				Action:    cluster.PlanActionCreate,
+				Resource:  cluster.PlanResourceCloudFormationStack,
+				Name:      nodePoolName,
+				Attribute: parameterName,
+				Desired:   parameters[parameterName],
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// PlanUpdateNodePool returns the changes UpdateNodePool would make without applying them.
+func (s service) PlanUpdateNodePool(
+	ctx context.Context,
+	clusterID uint,
+	nodePoolName string,
+	nodePoolUpdate NodePoolUpdate,
+) (cluster.Plan, error) {
+	if err := nodePoolUpdate.Validate(); err != nil {
+		return cluster.Plan{}, err
+	}
+
+	nodePools, err := s.ListNodePools(ctx, clusterID)
+	if err != nil {
+		return cluster.Plan{}, err
+	}
+
+	var (
+		current NodePool
+		found   bool
+	)
+
+	for _, nodePool := range nodePools {
+		if nodePool.Name == nodePoolName {
+			current = nodePool
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return cluster.Plan{}, errors.WithStack(cluster.NodePoolNotFoundError{
+			ClusterID: clusterID,
+			NodePool:  nodePoolName,
+		})
+	}
+
+	var plan cluster.Plan
+
+	addUpdate := func(parameterName string, currentValue string, desiredValue string) {
+		plan.AddUpdate(cluster.PlanResourceCloudFormationStack, nodePoolName, parameterName, currentValue, desiredValue)
+	}
+
+	if nodePoolUpdate.Image != "" {
+		addUpdate("NodeImageId", current.Image, nodePoolUpdate.Image)
+	}
+
+	if nodePoolUpdate.VolumeSize > 0 {
+		addUpdate("NodeVolumeSize", strconv.Itoa(current.VolumeSize), strconv.Itoa(nodePoolUpdate.VolumeSize))
+	}
+
+	if nodePoolUpdate.VolumeType != "" {
+		addUpdate("NodeVolumeType", current.VolumeType, nodePoolUpdate.VolumeType)
+	}
+
+	if nodePoolUpdate.VolumeEncryption != nil {
+		currentEncryption := NodePoolVolumeEncryption{}
+		if current.VolumeEncryption != nil {
+			currentEncryption = *current.VolumeEncryption
+		}
+
+		addUpdate(
+			"NodeVolumeEncryptionEnabled",
+			strconv.FormatBool(currentEncryption.Enabled),
+			strconv.FormatBool(nodePoolUpdate.VolumeEncryption.Enabled),
+		)
+		addUpdate("NodeVolumeEncryptionKeyARN", currentEncryption.EncryptionKeyARN, nodePoolUpdate.VolumeEncryption.EncryptionKeyARN)
+	}
+
+	if nodePoolUpdate.SecurityGroups != nil {
+		securityGroups := append([]string(nil), nodePoolUpdate.SecurityGroups...)
+		sort.Strings(securityGroups)
+
+		addUpdate("CustomNodeSecurityGroups", strings.Join(current.SecurityGroups, ","), strings.Join(securityGroups, ","))
+	}
+
+	if nodePoolUpdate.UseInstanceStore != nil {
+		addUpdate("UseInstanceStore", strconv.FormatBool(current.UseInstanceStore), strconv.FormatBool(*nodePoolUpdate.UseInstanceStore))
+	}
+
+	if nodePoolUpdate.Taints != nil {
+		addUpdate("NodeTaints", cluster.FormatNodePoolTaints(current.Taints), cluster.FormatNodePoolTaints(nodePoolUpdate.Taints))
+	}
+
+	if nodePoolUpdate.StartupTaints != nil {
+		addUpdate("NodeStartupTaints", cluster.FormatNodePoolTaints(current.StartupTaints), cluster.FormatNodePoolTaints(nodePoolUpdate.StartupTaints))
+	}
+
+	// Every parameter above changes the launch template, so the nodes are replaced in a rolling fashion.
+	if len(plan.Changes) > 0 {
+		plan.Add(cluster.PlanChange{
+			Action:    cluster.PlanActionReplace,
+			Resource:  cluster.PlanResourceNodePool,
+			Name:      nodePoolName,
+			Attribute: "nodes",
+		})
+	}
+
+	return plan, nil
+}
+
+// newNodePoolStackParameters returns the (user controlled) CloudFormation stack parameters of a new node pool.
+func newNodePoolStackParameters(nodePool NewNodePool) map[string]string {
+	minSize := nodePool.Size
+	maxSize := nodePool.Size + 1
+	if nodePool.Autoscaling.Enabled {
+		minSize = nodePool.Autoscaling.MinSize
+		maxSize = nodePool.Autoscaling.MaxSize
+	}
+
+	spotPrice := ""
+	if price, err := strconv.ParseFloat(nodePool.SpotPrice, 64); err == nil && price > 0.0 {
+		spotPrice = nodePool.SpotPrice
+	}
+
+	volumeType := "gp3"
+	if nodePool.VolumeType != "" {
+		volumeType = nodePool.VolumeType
+	}
+
+	parameters := map[string]string{
+		"ClusterAutoscalerEnabled":    strconv.FormatBool(nodePool.Autoscaling.Enabled),
+		"CustomNodeSecurityGroups":    strings.Join(nodePool.SecurityGroups, ","),
+		"NodeAutoScalingGroupMaxSize": strconv.Itoa(maxSize),
+		"NodeAutoScalingGroupMinSize": strconv.Itoa(minSize),
+		"NodeAutoScalingInitSize":     strconv.Itoa(nodePool.Size),
+		"NodeImageId":                 nodePool.Image,
+		"NodeInstanceType":            nodePool.InstanceType,
+		"NodeSpotPrice":               spotPrice,
+		"NodeVolumeType":              volumeType,
+		"UseInstanceStore":            strconv.FormatBool(nodePool.UseInstanceStore != nil && *nodePool.UseInstanceStore),
+	}
+
+	if len(nodePool.Taints) > 0 {
+		parameters["NodeTaints"] = cluster.FormatNodePoolTaints(nodePool.Taints)
+	}
+
+	if len(nodePool.StartupTaints) > 0 {
+		parameters["NodeStartupTaints"] = cluster.FormatNodePoolTaints(nodePool.StartupTaints)
+	}
+
+	if nodePool.VolumeSize > 0 {
+		parameters["NodeVolumeSize"] = strconv.Itoa(nodePool.VolumeSize)
+	}
+
+	if nodePool.SubnetID != "" {
+		parameters["Subnets"] = nodePool.SubnetID
+	}
+
+	if nodePool.VolumeEncryption != nil {
+		parameters["NodeVolumeEncryptionEnabled"] = strconv.FormatBool(nodePool.VolumeEncryption.Enabled)
+		parameters["NodeVolumeEncryptionKeyARN"] = nodePool.VolumeEncryption.EncryptionKeyARN
+	}
+
+	if nodePool.MixedInstancesPolicy != nil {
+		for key, value := range NewNodePoolMixedInstancesPolicyStackParameters(nodePool.MixedInstancesPolicy) {
+			parameters[key] = value
+		}
+	}
+
+	return parameters
+}
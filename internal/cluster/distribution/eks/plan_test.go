@@ -0,0 +1,181 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+func TestService_PlanUpdateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	c := cluster.Cluster{
+		ID:             1,
+		OrganizationID: 2,
+		Name:           "cluster",
+	}
+
+	genericClusters := new(MockStore)
+	genericClusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+
+	existingNodePools := map[string]ExistingNodePool{
+		"pool0": {Name: "pool0"},
+	}
+
+	nodePools := new(MockNodePoolStore)
+	nodePools.On("ListNodePools", ctx, c.OrganizationID, c.ID, c.Name).Return(existingNodePools, nil)
+
+	nodePoolManager := new(MockNodePoolManager)
+	nodePoolManager.On("ListNodePools", ctx, c, existingNodePools).Return(
+		[]NodePool{
+			{
+				Name:           "pool0",
+				Size:           3,
+				VolumeSize:     50,
+				VolumeType:     "gp3",
+				Image:          "ami-old",
+				SecurityGroups: []string{"sg-1"},
+			},
+		},
+		nil,
+	)
+
+	service := NewService(genericClusters, nil, nodePools, nodePoolManager, nil, nil)
+
+	t.Run("Changes", func(t *testing.T) {
+		plan, err := service.PlanUpdateNodePool(ctx, c.ID, "pool0", NodePoolUpdate{
+			Image:          "ami-new",
+			VolumeSize:     50,
+			SecurityGroups: []string{"sg-2", "sg-1"},
+		})
+		require.NoError(t, err)
+
+		require.Equal(
+			t,
+			[]cluster.PlanChange{
+				{
+					Action:    cluster.PlanActionUpdate,
+					Resource:  cluster.PlanResourceCloudFormationStack,
+					Name:      "pool0",
+					Attribute: "NodeImageId",
+					Current:   "ami-old",
+					Desired:   "ami-new",
+				},
+				{
+					Action:    cluster.PlanActionUpdate,
+					Resource:  cluster.PlanResourceCloudFormationStack,
+					Name:      "pool0",
+					Attribute: "CustomNodeSecurityGroups",
+					Current:   "sg-1",
+					Desired:   "sg-1,sg-2",
+				},
+				{
+					Action:    cluster.PlanActionReplace,
+					Resource:  cluster.PlanResourceNodePool,
+					Name:      "pool0",
+					Attribute: "nodes",
+				},
+			},
+			plan.Changes,
+		)
+	})
+
+	t.Run("Taints", func(t *testing.T) {
+		plan, err := service.PlanUpdateNodePool(ctx, c.ID, "pool0", NodePoolUpdate{
+			Taints: []cluster.NodePoolTaint{{Key: "dedicated", Value: "gpu", Effect: cluster.NodePoolTaintEffectNoSchedule}},
+		})
+		require.NoError(t, err)
+
+		require.Equal(
+			t,
+			[]cluster.PlanChange{
+				{
+					Action:    cluster.PlanActionUpdate,
+					Resource:  cluster.PlanResourceCloudFormationStack,
+					Name:      "pool0",
+					Attribute: "NodeTaints",
+					Current:   "",
+					Desired:   "dedicated=gpu:NoSchedule",
+				},
+				{
+					Action:    cluster.PlanActionReplace,
+					Resource:  cluster.PlanResourceNodePool,
+					Name:      "pool0",
+					Attribute: "nodes",
+				},
+			},
+			plan.Changes,
+		)
+	})
+
+	t.Run("InvalidTaints", func(t *testing.T) {
+		_, err := service.PlanUpdateNodePool(ctx, c.ID, "pool0", NodePoolUpdate{
+			Taints: []cluster.NodePoolTaint{{Key: "dedicated", Effect: "NoWay"}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		plan, err := service.PlanUpdateNodePool(ctx, c.ID, "pool0", NodePoolUpdate{Image: "ami-old", VolumeType: "gp3"})
+		require.NoError(t, err)
+
+		require.Empty(t, plan.Changes)
+	})
+
+	t.Run("NodePoolNotFound", func(t *testing.T) {
+		_, err := service.PlanUpdateNodePool(ctx, c.ID, "pool1", NodePoolUpdate{Image: "ami-new"})
+		require.Error(t, err)
+
+		require.True(t, cluster.IsNotFoundError(err))
+	})
+
+	genericClusters.AssertNotCalled(t, "SetStatus")
+}
+
+func TestNewNodePoolStackParameters(t *testing.T) {
+	nodePool := NewNodePool{
+		Name:         "pool0",
+		Size:         2,
+		InstanceType: "t3.large",
+		Image:        "ami-xyz",
+		SpotPrice:    "0",
+		SubnetID:     "subnet-1",
+	}
+
+	parameters := newNodePoolStackParameters(nodePool)
+
+	require.Equal(t, "2", parameters["NodeAutoScalingGroupMinSize"])
+	require.Equal(t, "3", parameters["NodeAutoScalingGroupMaxSize"])
+	require.Equal(t, "", parameters["NodeSpotPrice"])
+	require.Equal(t, "gp3", parameters["NodeVolumeType"])
+	require.Equal(t, "subnet-1", parameters["Subnets"])
+	require.NotContains(t, parameters, "NodeVolumeSize")
+	require.NotContains(t, parameters, "NodeInstanceTypeOverride1")
+
+	nodePool.MixedInstancesPolicy = &NodePoolMixedInstancesPolicy{
+		InstanceTypes: []NodePoolInstanceType{{InstanceType: "t3.large"}, {InstanceType: "t3a.large"}},
+	}
+
+	parameters = newNodePoolStackParameters(nodePool)
+
+	require.Equal(t, "t3.large:1", parameters["NodeInstanceTypeOverride1"])
+	require.Equal(t, "t3a.large:1", parameters["NodeInstanceTypeOverride2"])
+	require.Equal(t, DefaultSpotAllocationStrategy, parameters["NodeSpotAllocationStrategy"])
+}
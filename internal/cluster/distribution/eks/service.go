@@ -51,6 +51,12 @@ type Service interface {
 
 	// ListNodePools lists node pools from a cluster.
 	ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error)
+
+	// PlanCreateNodePools returns the changes CreateNodePools would make without applying them.
+	PlanCreateNodePools(ctx context.Context, clusterID uint, nodePools map[string]NewNodePool) (plan cluster.Plan, err error)
+
+	// PlanUpdateNodePool returns the changes UpdateNodePool would make without applying them.
+	PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, nodePoolUpdate NodePoolUpdate) (plan cluster.Plan, err error)
 }
 
 // ClusterUpdate describes a cluster update request.
@@ -74,10 +80,34 @@ type NodePoolUpdate struct {
 	SecurityGroups   []string `mapstructure:"securityGroups"`
 	UseInstanceStore *bool    `mapstructure:"useInstanceStore,omitempty"`
 
+	// Taints and StartupTaints are left unchanged when nil.
+	Taints        []cluster.NodePoolTaint `mapstructure:"taints"`
+	StartupTaints []cluster.NodePoolTaint `mapstructure:"startupTaints"`
+
 	Options NodePoolUpdateOptions `mapstructure:"options"`
 }
 
+// Validate semantically validates the node pool update.
+func (u NodePoolUpdate) Validate() error {
+	violations := cluster.ValidateNodePoolTaints(u.Taints, u.StartupTaints)
+	violations = append(violations, cluster.ValidateNodePoolUpdateOptions(
+		u.Options.Strategy,
+		u.Options.MaxSurge,
+		u.Options.MaxUnavailable,
+		u.Options.Drain.Timeout,
+	)...)
+	if len(violations) > 0 {
+		return cluster.NewValidationError("invalid node pool update request", violations)
+	}
+
+	return nil
+}
+
 type NodePoolUpdateOptions struct {
+	// Strategy of the node replacement, either the rolling update of the cloud provider (default)
+	// or the Pipeline-driven replacement of the nodes.
+	Strategy string `mapstructure:"strategy"`
+
 	// Maximum number of extra nodes that can be created during the update.
 	MaxSurge int `mapstructure:"maxSurge"`
 
@@ -103,6 +133,8 @@ type NodePoolUpdateDrainOptions struct {
 type NodePool struct {
 	Name             string                    `mapstructure:"name"`
 	Labels           map[string]string         `mapstructure:"labels"`
+	Taints           []cluster.NodePoolTaint   `mapstructure:"taints,omitempty"`
+	StartupTaints    []cluster.NodePoolTaint   `mapstructure:"startupTaints,omitempty"`
 	Size             int                       `mapstructure:"size"`
 	Autoscaling      Autoscaling               `mapstructure:"autoscaling"`
 	VolumeEncryption *NodePoolVolumeEncryption `mapstructure:"volumeEncryption,omitempty"`
@@ -116,6 +148,8 @@ type NodePool struct {
 	UseInstanceStore bool                      `mapstructure:"UseInstanceStore"`
 	Status           NodePoolStatus            `mapstructure:"status"`
 	StatusMessage    string                    `mapstructure:"statusMessage"`
+
+	MixedInstancesPolicy *NodePoolMixedInstancesPolicy `mapstructure:"mixedInstancesPolicy,omitempty"`
 }
 
 // NewNodePoolFromCFStack initializes a node pool object from a CloudFormation
@@ -135,7 +169,9 @@ func NewNodePoolFromCFStack(name string, labels map[string]string, stack *cloudf
 		NodeVolumeType              string `mapstructure:"NodeVolumeType,omitempty"`           // Note: NodeVolumeType is only available from template version 2.4.0.
 		CustomNodeSecurityGroups    string `mapstructure:"CustomNodeSecurityGroups,omitempty"` // Note: CustomNodeSecurityGroups is only available from template version 2.0.0.
 		Subnets                     string `mapstructure:"Subnets"`
-		UseInstanceStore            string `mapstructure:"UseInstanceStore,omitempty"` // Note: UseInstanceStore is only available from template version 2.2.0.
+		UseInstanceStore            string `mapstructure:"UseInstanceStore,omitempty"`  // Note: UseInstanceStore is only available from template version 2.2.0.
+		NodeTaints                  string `mapstructure:"NodeTaints,omitempty"`        // Note: NodeTaints is only available from template version 2.5.0.
+		NodeStartupTaints           string `mapstructure:"NodeStartupTaints,omitempty"` // Note: NodeStartupTaints is only available from template version 2.5.0.
 	}
 
 	err := sdkCloudFormation.ParseStackParameters(stack.Parameters, &nodePoolParameters)
@@ -185,6 +221,28 @@ func NewNodePoolFromCFStack(name string, labels map[string]string, stack *cloudf
 		nodePool.UseInstanceStore = useInstanceStore
 	}
 
+	nodePool.Taints, err = cluster.ParseNodePoolTaints(nodePoolParameters.NodeTaints)
+	if err != nil {
+		return NewNodePoolWithNoValues(name, NodePoolStatusError, "invalid taint information")
+	}
+
+	nodePool.StartupTaints, err = cluster.ParseNodePoolTaints(nodePoolParameters.NodeStartupTaints)
+	if err != nil {
+		return NewNodePoolWithNoValues(name, NodePoolStatusError, "invalid startup taint information")
+	}
+
+	stackParameters := map[string]string{}
+	err = sdkCloudFormation.ParseStackParameters(stack.Parameters, &stackParameters)
+	if err != nil {
+		return NewNodePoolWithNoValues(name, NodePoolStatusError, err.Error())
+	}
+
+	// Note: mixed instances policy parameters are only available from template version 2.6.0.
+	nodePool.MixedInstancesPolicy, err = NewNodePoolMixedInstancesPolicyFromStackParameters(stackParameters)
+	if err != nil {
+		return NewNodePoolWithNoValues(name, NodePoolStatusError, "invalid mixed instances policy information")
+	}
+
 	return nodePool
 }
 
@@ -447,6 +505,10 @@ func (s service) UpdateNodePool(
 ) (string, error) {
 	// TODO: check if node pool exists
 
+	if err := nodePoolUpdate.Validate(); err != nil {
+		return "", err
+	}
+
 	c, err := s.genericClusters.GetCluster(ctx, clusterID)
 	if err != nil {
 		return "", err
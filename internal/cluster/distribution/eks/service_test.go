@@ -311,6 +311,14 @@ func TestNewNodePoolFromCFStack(t *testing.T) {
 							ParameterKey:   aws.String("Subnets"),
 							ParameterValue: aws.String("subnet-0123456789"),
 						},
+						{
+							ParameterKey:   aws.String("NodeTaints"),
+							ParameterValue: aws.String("dedicated=gpu:NoSchedule"),
+						},
+						{
+							ParameterKey:   aws.String("NodeStartupTaints"),
+							ParameterValue: aws.String("example.com/not-ready:NoExecute"),
+						},
 					},
 					StackStatus:       aws.String(cloudformation.StackStatusCreateComplete),
 					StackStatusReason: aws.String("this is a test"),
@@ -322,6 +330,12 @@ func TestNewNodePoolFromCFStack(t *testing.T) {
 					Labels: map[string]string{
 						"key": "value",
 					},
+					Taints: []cluster.NodePoolTaint{
+						{Key: "dedicated", Value: "gpu", Effect: cluster.NodePoolTaintEffectNoSchedule},
+					},
+					StartupTaints: []cluster.NodePoolTaint{
+						{Key: "example.com/not-ready", Effect: cluster.NodePoolTaintEffectNoExecute},
+					},
 					Size: 1,
 					Autoscaling: Autoscaling{
 						Enabled: true,
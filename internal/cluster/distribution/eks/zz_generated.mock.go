@@ -203,6 +203,48 @@ func (_m *MockService) ListNodePools(ctx context.Context, clusterID uint) (_resu
 	return r0, r1
 }
 
+// PlanCreateNodePools provides a mock function.
+func (_m *MockService) PlanCreateNodePools(ctx context.Context, clusterID uint, nodePools map[string]NewNodePool) (plan cluster.Plan, err error) {
+	ret := _m.Called(ctx, clusterID, nodePools)
+
+	var r0 cluster.Plan
+	if rf, ok := ret.Get(0).(func(context.Context, uint, map[string]NewNodePool) cluster.Plan); ok {
+		r0 = rf(ctx, clusterID, nodePools)
+	} else {
+		r0 = ret.Get(0).(cluster.Plan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, map[string]NewNodePool) error); ok {
+		r1 = rf(ctx, clusterID, nodePools)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanUpdateNodePool provides a mock function.
+func (_m *MockService) PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, nodePoolUpdate NodePoolUpdate) (plan cluster.Plan, err error) {
+	ret := _m.Called(ctx, clusterID, nodePoolName, nodePoolUpdate)
+
+	var r0 cluster.Plan
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, NodePoolUpdate) cluster.Plan); ok {
+		r0 = rf(ctx, clusterID, nodePoolName, nodePoolUpdate)
+	} else {
+		r0 = ret.Get(0).(cluster.Plan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, NodePoolUpdate) error); ok {
+		r1 = rf(ctx, clusterID, nodePoolName, nodePoolUpdate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateCluster provides a mock function.
 func (_m *MockService) UpdateCluster(ctx context.Context, clusterID uint, clusterUpdate ClusterUpdate) (_result_0 error) {
 	ret := _m.Called(ctx, clusterID, clusterUpdate)
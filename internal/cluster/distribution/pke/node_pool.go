@@ -16,10 +16,15 @@ package pke
 
 import (
 	"context"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
 )
 
 type ExistingNodePool struct {
-	Name string
+	Name          string
+	Image         string
+	Taints        []cluster.NodePoolTaint
+	StartupTaints []cluster.NodePoolTaint
 }
 
 // +testify:mock
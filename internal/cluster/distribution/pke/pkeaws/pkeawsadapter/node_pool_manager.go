@@ -107,6 +107,7 @@ func (n nodePoolManager) UpdateNodePool(
 		Version:   nodePoolUpdate.Version,
 
 		Options: pke.NodePoolUpdateOptions{
+			Strategy:       nodePoolUpdate.Options.Strategy,
 			MaxSurge:       nodePoolUpdate.Options.MaxSurge,
 			MaxBatchSize:   nodePoolUpdate.Options.MaxBatchSize,
 			MaxUnavailable: nodePoolUpdate.Options.MaxUnavailable,
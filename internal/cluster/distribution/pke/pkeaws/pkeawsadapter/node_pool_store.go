@@ -16,9 +16,11 @@ package pkeawsadapter
 
 import (
 	"context"
+	"strings"
 
 	"emperror.dev/errors"
 	"github.com/jinzhu/gorm"
+	"github.com/mitchellh/mapstructure"
 
 	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke"
@@ -104,10 +106,29 @@ func (s nodePoolStore) ListNodePools(
 
 	existingNodePools = make(map[string]pke.ExistingNodePool, len(pkeAWSCluster.NodePools))
 	for _, nodePoolModel := range pkeAWSCluster.NodePools {
+		var providerConfig pkeprovider.NodePoolProviderConfigAmazon
+		_ = mapstructure.Decode(nodePoolModel.ProviderConfig, &providerConfig)
+
+		// Note: the stored taints are validated at creation and update time.
+		taints, _ := cluster.ParseNodePoolTaints(formatTaints(nodePoolModel.Taints))
+		startupTaints, _ := cluster.ParseNodePoolTaints(formatTaints(nodePoolModel.StartupTaints))
+
 		existingNodePools[nodePoolModel.Name] = pke.ExistingNodePool{
-			Name: nodePoolModel.Name,
+			Name:          nodePoolModel.Name,
+			Image:         providerConfig.AutoScalingGroup.Image,
+			Taints:        taints,
+			StartupTaints: startupTaints,
 		}
 	}
 
 	return existingNodePools, nil
 }
+
+func formatTaints(taints pkeprovider.Taints) string {
+	items := make([]string, 0, len(taints))
+	for _, taint := range taints {
+		items = append(items, string(taint))
+	}
+
+	return strings.Join(items, ",")
+}
@@ -40,6 +40,9 @@ type Service interface {
 
 	// ListNodePools lists node pools from a cluster.
 	ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error)
+
+	// PlanUpdateNodePool returns the changes UpdateNodePool would make without applying them.
+	PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, nodePoolUpdate NodePoolUpdate) (plan cluster.Plan, err error)
 }
 
 // ClusterUpdate describes a cluster update request.
@@ -59,10 +62,46 @@ type NodePoolUpdate struct {
 
 	Version string `mapstructure:"version"`
 
+	// Taints and StartupTaints are decoded only to reject their update:
+	// the open source PKE on AWS node pool update workflow does not replace
+	// the nodes, so the changed taints would never be applied.
+	Taints        []cluster.NodePoolTaint `mapstructure:"taints"`
+	StartupTaints []cluster.NodePoolTaint `mapstructure:"startupTaints"`
+
 	Options NodePoolUpdateOptions `mapstructure:"options"`
 }
 
+// Validate semantically validates the node pool update.
+func (u NodePoolUpdate) Validate() error {
+	violations := cluster.ValidateNodePoolTaints(u.Taints, u.StartupTaints)
+	violations = append(violations, cluster.ValidateNodePoolUpdateOptions(
+		u.Options.Strategy,
+		u.Options.MaxSurge,
+		u.Options.MaxUnavailable,
+		u.Options.Drain.Timeout,
+	)...)
+
+	if u.Taints != nil || u.StartupTaints != nil {
+		violations = append(violations, "updating the taints of PKE node pools is not supported")
+	}
+
+	// Pipeline-driven node replacement is only implemented for EKS node pools.
+	if u.Options.Strategy == cluster.NodePoolUpdateStrategyReplace {
+		violations = append(violations, "the replace update strategy is not supported for PKE node pools")
+	}
+
+	if len(violations) > 0 {
+		return cluster.NewValidationError("invalid node pool update request", violations)
+	}
+
+	return nil
+}
+
 type NodePoolUpdateOptions struct {
+	// Strategy of the node replacement, either the rolling update of the cloud provider (default)
+	// or the Pipeline-driven replacement of the nodes.
+	Strategy string `mapstructure:"strategy"`
+
 	// Maximum number of extra nodes that can be created during the update.
 	MaxSurge int `mapstructure:"maxSurge"`
 
@@ -86,15 +125,17 @@ type NodePoolUpdateDrainOptions struct {
 
 // NodePool encapsulates information about a cluster node pool.
 type NodePool struct {
-	Name         string            `mapstructure:"name"`
-	Labels       map[string]string `mapstructure:"labels"`
-	Size         int               `mapstructure:"size"`
-	Autoscaling  Autoscaling       `mapstructure:"autoscaling"`
-	VolumeSize   int               `mapstructure:"volumeSize"`
-	InstanceType string            `mapstructure:"instanceType"`
-	Image        string            `mapstructure:"image"`
-	SpotPrice    string            `mapstructure:"spotPrice"`
-	SubnetID     string            `mapstructure:"subnetId"`
+	Name          string                  `mapstructure:"name"`
+	Labels        map[string]string       `mapstructure:"labels"`
+	Taints        []cluster.NodePoolTaint `mapstructure:"taints,omitempty"`
+	StartupTaints []cluster.NodePoolTaint `mapstructure:"startupTaints,omitempty"`
+	Size          int                     `mapstructure:"size"`
+	Autoscaling   Autoscaling             `mapstructure:"autoscaling"`
+	VolumeSize    int                     `mapstructure:"volumeSize"`
+	InstanceType  string                  `mapstructure:"instanceType"`
+	Image         string                  `mapstructure:"image"`
+	SpotPrice     string                  `mapstructure:"spotPrice"`
+	SubnetID      string                  `mapstructure:"subnetId"`
 }
 
 // Autoscaling describes the EC2 node pool's autoscaling settings.
@@ -207,6 +248,10 @@ func (s service) UpdateNodePool(
 	nodePoolName string,
 	nodePoolUpdate NodePoolUpdate,
 ) (string, error) {
+	if err := nodePoolUpdate.Validate(); err != nil {
+		return "", err
+	}
+
 	c, err := s.genericClusters.GetCluster(ctx, clusterID)
 	if err != nil {
 		return "", err
@@ -220,6 +265,64 @@ func (s service) UpdateNodePool(
 	return s.nodePoolManager.UpdateNodePool(ctx, c, nodePoolName, nodePoolUpdate)
 }
 
+// PlanUpdateNodePool returns the changes UpdateNodePool would make without applying them.
+func (s service) PlanUpdateNodePool(
+	ctx context.Context,
+	clusterID uint,
+	nodePoolName string,
+	nodePoolUpdate NodePoolUpdate,
+) (cluster.Plan, error) {
+	if err := nodePoolUpdate.Validate(); err != nil {
+		return cluster.Plan{}, err
+	}
+
+	c, err := s.genericClusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return cluster.Plan{}, err
+	}
+
+	existingNodePools, err := s.nodePoolStore.ListNodePools(ctx, c.OrganizationID, c.ID, c.Name)
+	if err != nil {
+		return cluster.Plan{}, err
+	}
+
+	existingNodePool, isExisting := existingNodePools[nodePoolName]
+	if !isExisting {
+		return cluster.Plan{}, errors.WithStack(cluster.NodePoolNotFoundError{
+			ClusterID: clusterID,
+			NodePool:  nodePoolName,
+		})
+	}
+
+	var plan cluster.Plan
+
+	if nodePoolUpdate.Image != "" {
+		plan.AddUpdate(cluster.PlanResourceCloudFormationStack, nodePoolName, "ImageId", existingNodePool.Image, nodePoolUpdate.Image)
+	}
+
+	// The current version of the nodes is not stored, the update is always executed.
+	if nodePoolUpdate.Version != "" {
+		plan.Add(cluster.PlanChange{
+			Action:    cluster.PlanActionUpdate,
+			Resource:  cluster.PlanResourceNodePool,
+			Name:      nodePoolName,
+			Attribute: "version",
+			Desired:   nodePoolUpdate.Version,
+		})
+	}
+
+	if len(plan.Changes) > 0 {
+		plan.Add(cluster.PlanChange{
+			Action:    cluster.PlanActionReplace,
+			Resource:  cluster.PlanResourceNodePool,
+			Name:      nodePoolName,
+			Attribute: "nodes",
+		})
+	}
+
+	return plan, nil
+}
+
 // ListNodePools lists node pools from a cluster.
 func (s service) ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error) {
 	_, err := s.genericClusters.GetCluster(ctx, clusterID)
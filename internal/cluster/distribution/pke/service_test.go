@@ -262,3 +262,56 @@ func TestServiceDeleteNodePool(t *testing.T) {
 		})
 	}
 }
+
+func TestNodePoolUpdateValidate(t *testing.T) {
+	testCases := []struct {
+		caseDescription string
+		nodePoolUpdate  NodePoolUpdate
+		expectedError   bool
+	}{
+		{
+			caseDescription: "empty update -> success",
+			nodePoolUpdate:  NodePoolUpdate{},
+		},
+		{
+			caseDescription: "rolling update strategy -> success",
+			nodePoolUpdate: NodePoolUpdate{
+				Options: NodePoolUpdateOptions{
+					Strategy: cluster.NodePoolUpdateStrategyRollingUpdate,
+				},
+			},
+		},
+		{
+			caseDescription: "taints -> error",
+			nodePoolUpdate: NodePoolUpdate{
+				Taints: []cluster.NodePoolTaint{
+					{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			caseDescription: "replace strategy -> error",
+			nodePoolUpdate: NodePoolUpdate{
+				Options: NodePoolUpdateOptions{
+					Strategy: cluster.NodePoolUpdateStrategyReplace,
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.caseDescription, func(t *testing.T) {
+			err := testCase.nodePoolUpdate.Validate()
+
+			if testCase.expectedError {
+				require.IsType(t, cluster.ValidationError{}, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsworkflow
+
+import (
+	"context"
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// AutoScalingGroup describes the state of the auto scaling group of a node pool.
+type AutoScalingGroup struct {
+	Name            string
+	DesiredCapacity int
+	MaxSize         int
+	Instances       []AutoScalingGroupInstance
+
+	// IsWeighted is true when the group has a mixed instances policy, so its
+	// capacity is measured in the weighted capacity units of the instances.
+	IsWeighted bool
+}
+
+// AutoScalingGroupInstance describes an instance of an auto scaling group.
+type AutoScalingGroupInstance struct {
+	InstanceID     string
+	LifecycleState string
+
+	// NodeName is the private DNS name of the instance used as the name of the Kubernetes node.
+	NodeName string
+
+	// Outdated is true when the instance is not running the current
+	// launch configuration or launch template version of the group.
+	Outdated bool
+
+	// WeightedCapacity is the number of capacity units provided by the instance.
+	WeightedCapacity int
+}
+
+// InServiceInstances returns the instances of the group which are in service.
+func (g AutoScalingGroup) InServiceInstances() []AutoScalingGroupInstance {
+	instances := make([]AutoScalingGroupInstance, 0, len(g.Instances))
+	for _, instance := range g.Instances {
+		if instance.LifecycleState == autoscaling.LifecycleStateInService {
+			instances = append(instances, instance)
+		}
+	}
+
+	return instances
+}
+
+// IsFulfilled returns true when all instances of the group are in service
+// and they provide the desired capacity.
+func (g AutoScalingGroup) IsFulfilled() bool {
+	inServiceInstances := g.InServiceInstances()
+	if len(inServiceInstances) != len(g.Instances) {
+		return false
+	}
+
+	capacity := 0
+	for _, instance := range inServiceInstances {
+		capacity += instance.WeightedCapacity
+	}
+
+	// Note: weighted instances can exceed the desired capacity, because
+	// the last launched instance may provide more units than missing.
+	if g.IsWeighted {
+		return capacity >= g.DesiredCapacity
+	}
+
+	return capacity == g.DesiredCapacity
+}
+
+// OutdatedInstances returns the in service instances of the group which are outdated.
+func (g AutoScalingGroup) OutdatedInstances() []AutoScalingGroupInstance {
+	instances := make([]AutoScalingGroupInstance, 0, len(g.Instances))
+	for _, instance := range g.InServiceInstances() {
+		if instance.Outdated {
+			instances = append(instances, instance)
+		}
+	}
+
+	return instances
+}
+
+// getAutoScalingGroupName returns the name of the auto scaling group created by the specified stack resource.
+func getAutoScalingGroupName(ctx context.Context, awsSession *session.Session, stackName string, logicalResourceID string) (string, error) {
+	output, err := cloudformation.New(awsSession).DescribeStackResourceWithContext(ctx, &cloudformation.DescribeStackResourceInput{
+		LogicalResourceId: aws.String(logicalResourceID),
+		StackName:         aws.String(stackName),
+	})
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to describe stack resource", "stackName", stackName, "resource", logicalResourceID)
+	}
+
+	if output.StackResourceDetail == nil || aws.StringValue(output.StackResourceDetail.PhysicalResourceId) == "" {
+		return "", errors.NewWithDetails("auto scaling group not found", "stackName", stackName, "resource", logicalResourceID)
+	}
+
+	return aws.StringValue(output.StackResourceDetail.PhysicalResourceId), nil
+}
+
+// describeAutoScalingGroup returns the current state of the specified auto scaling group.
+func describeAutoScalingGroup(ctx context.Context, awsSession *session.Session, name string) (AutoScalingGroup, error) {
+	output, err := autoscaling.New(awsSession).DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(name)},
+	})
+	if err != nil {
+		return AutoScalingGroup{}, errors.WrapIfWithDetails(err, "failed to describe auto scaling group", "autoScalingGroup", name)
+	}
+
+	if len(output.AutoScalingGroups) != 1 {
+		return AutoScalingGroup{}, errors.NewWithDetails(
+			"auto scaling group not found", "autoScalingGroup", name, "count", len(output.AutoScalingGroups),
+		)
+	}
+
+	group := output.AutoScalingGroups[0]
+
+	ec2Client := ec2.New(awsSession)
+
+	launchTemplate := group.LaunchTemplate
+	if launchTemplate == nil &&
+		group.MixedInstancesPolicy != nil &&
+		group.MixedInstancesPolicy.LaunchTemplate != nil {
+		launchTemplate = group.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification
+	}
+
+	launchTemplateID := ""
+	launchTemplateVersion := ""
+	if launchTemplate != nil {
+		launchTemplateID = aws.StringValue(launchTemplate.LaunchTemplateId)
+		launchTemplateVersion = aws.StringValue(launchTemplate.Version)
+
+		// Note: resolving symbolic versions to compare them to the versions of the instances.
+		if launchTemplateVersion == "" || launchTemplateVersion == "$Latest" || launchTemplateVersion == "$Default" {
+			describeLaunchTemplatesInput := &ec2.DescribeLaunchTemplatesInput{}
+			if launchTemplateID != "" {
+				describeLaunchTemplatesInput.LaunchTemplateIds = []*string{aws.String(launchTemplateID)}
+			} else {
+				describeLaunchTemplatesInput.LaunchTemplateNames = []*string{launchTemplate.LaunchTemplateName}
+			}
+
+			describeLaunchTemplatesOutput, err := ec2Client.DescribeLaunchTemplatesWithContext(ctx, describeLaunchTemplatesInput)
+			if err != nil {
+				return AutoScalingGroup{}, errors.WrapIfWithDetails(err, "failed to describe launch template", "autoScalingGroup", name)
+			} else if len(describeLaunchTemplatesOutput.LaunchTemplates) != 1 {
+				return AutoScalingGroup{}, errors.NewWithDetails("launch template not found", "autoScalingGroup", name)
+			}
+
+			launchTemplateID = aws.StringValue(describeLaunchTemplatesOutput.LaunchTemplates[0].LaunchTemplateId)
+			if launchTemplateVersion == "$Latest" {
+				launchTemplateVersion = strconv.FormatInt(aws.Int64Value(describeLaunchTemplatesOutput.LaunchTemplates[0].LatestVersionNumber), 10)
+			} else {
+				launchTemplateVersion = strconv.FormatInt(aws.Int64Value(describeLaunchTemplatesOutput.LaunchTemplates[0].DefaultVersionNumber), 10)
+			}
+		}
+	}
+
+	nodeNames := make(map[string]string, len(group.Instances))
+	if len(group.Instances) > 0 {
+		instanceIDs := make([]*string, 0, len(group.Instances))
+		for _, instance := range group.Instances {
+			instanceIDs = append(instanceIDs, instance.InstanceId)
+		}
+
+		err = ec2Client.DescribeInstancesPagesWithContext(
+			ctx,
+			&ec2.DescribeInstancesInput{InstanceIds: instanceIDs},
+			func(output *ec2.DescribeInstancesOutput, _ bool) bool {
+				for _, reservation := range output.Reservations {
+					for _, instance := range reservation.Instances {
+						nodeNames[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.PrivateDnsName)
+					}
+				}
+
+				return true
+			},
+		)
+		if err != nil {
+			return AutoScalingGroup{}, errors.WrapIfWithDetails(err, "failed to describe instances", "autoScalingGroup", name)
+		}
+	}
+
+	autoScalingGroup := AutoScalingGroup{
+		Name:            aws.StringValue(group.AutoScalingGroupName),
+		DesiredCapacity: int(aws.Int64Value(group.DesiredCapacity)),
+		MaxSize:         int(aws.Int64Value(group.MaxSize)),
+		Instances:       make([]AutoScalingGroupInstance, 0, len(group.Instances)),
+		IsWeighted:      group.MixedInstancesPolicy != nil,
+	}
+	for _, instance := range group.Instances {
+		instanceID := aws.StringValue(instance.InstanceId)
+
+		autoScalingGroup.Instances = append(autoScalingGroup.Instances, AutoScalingGroupInstance{
+			InstanceID:     instanceID,
+			LifecycleState: aws.StringValue(instance.LifecycleState),
+			NodeName:       nodeNames[instanceID],
+			Outdated: isAutoScalingGroupInstanceOutdated(
+				instance, aws.StringValue(group.LaunchConfigurationName), launchTemplateID, launchTemplateVersion,
+			),
+			WeightedCapacity: getAutoScalingGroupInstanceWeightedCapacity(instance, group.MixedInstancesPolicy != nil),
+		})
+	}
+
+	return autoScalingGroup, nil
+}
+
+// isAutoScalingGroupInstanceOutdated returns true when the instance was not
+// launched with the current launch configuration or launch template version
+// of its group.
+func isAutoScalingGroupInstanceOutdated(
+	instance *autoscaling.Instance, launchConfigurationName string, launchTemplateID string, launchTemplateVersion string,
+) bool {
+	if launchConfigurationName != "" {
+		return aws.StringValue(instance.LaunchConfigurationName) != launchConfigurationName
+	}
+
+	if launchTemplateID != "" {
+		return instance.LaunchTemplate == nil ||
+			aws.StringValue(instance.LaunchTemplate.LaunchTemplateId) != launchTemplateID ||
+			aws.StringValue(instance.LaunchTemplate.Version) != launchTemplateVersion
+	}
+
+	return false
+}
+
+// getAutoScalingGroupInstanceWeightedCapacity returns the number of capacity
+// units an instance provides, which is 1 unless weights are set by the mixed
+// instances policy of the group.
+func getAutoScalingGroupInstanceWeightedCapacity(instance *autoscaling.Instance, isWeighted bool) int {
+	if !isWeighted || instance.WeightedCapacity == nil {
+		return 1
+	}
+
+	weightedCapacity, err := strconv.Atoi(aws.StringValue(instance.WeightedCapacity))
+	if err != nil || weightedCapacity < 1 {
+		return 1
+	}
+
+	return weightedCapacity
+}
@@ -0,0 +1,98 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsworkflow
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAutoScalingGroupInstanceOutdated(t *testing.T) {
+	launchTemplateInstance := &autoscaling.Instance{
+		InstanceId: aws.String("i-1"),
+		LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+			LaunchTemplateId: aws.String("lt-1"),
+			Version:          aws.String("2"),
+		},
+	}
+	launchConfigurationInstance := &autoscaling.Instance{
+		InstanceId:              aws.String("i-2"),
+		LaunchConfigurationName: aws.String("lc-1"),
+	}
+
+	assert.False(t, isAutoScalingGroupInstanceOutdated(launchTemplateInstance, "", "lt-1", "2"))
+	assert.True(t, isAutoScalingGroupInstanceOutdated(launchTemplateInstance, "", "lt-1", "3"))
+	assert.True(t, isAutoScalingGroupInstanceOutdated(launchTemplateInstance, "", "lt-2", "2"))
+	assert.True(t, isAutoScalingGroupInstanceOutdated(launchConfigurationInstance, "", "lt-1", "2"))
+
+	assert.False(t, isAutoScalingGroupInstanceOutdated(launchConfigurationInstance, "lc-1", "", ""))
+	assert.True(t, isAutoScalingGroupInstanceOutdated(launchConfigurationInstance, "lc-2", "", ""))
+	assert.True(t, isAutoScalingGroupInstanceOutdated(launchTemplateInstance, "lc-1", "", ""))
+}
+
+func TestAutoScalingGroupIsFulfilled(t *testing.T) {
+	inService := func(weightedCapacity int) AutoScalingGroupInstance {
+		return AutoScalingGroupInstance{
+			LifecycleState:   autoscaling.LifecycleStateInService,
+			WeightedCapacity: weightedCapacity,
+		}
+	}
+	pending := AutoScalingGroupInstance{LifecycleState: autoscaling.LifecycleStatePending, WeightedCapacity: 1}
+
+	tests := map[string]struct {
+		group AutoScalingGroup
+		want  bool
+	}{
+		"fulfilled": {
+			group: AutoScalingGroup{DesiredCapacity: 2, Instances: []AutoScalingGroupInstance{inService(1), inService(1)}},
+			want:  true,
+		},
+		"pending instance": {
+			group: AutoScalingGroup{DesiredCapacity: 2, Instances: []AutoScalingGroupInstance{inService(1), pending}},
+		},
+		"missing instance": {
+			group: AutoScalingGroup{DesiredCapacity: 2, Instances: []AutoScalingGroupInstance{inService(1)}},
+		},
+		"weighted": {
+			group: AutoScalingGroup{DesiredCapacity: 4, IsWeighted: true, Instances: []AutoScalingGroupInstance{inService(2), inService(2)}},
+			want:  true,
+		},
+		"weighted exceeding desired capacity": {
+			group: AutoScalingGroup{DesiredCapacity: 3, IsWeighted: true, Instances: []AutoScalingGroupInstance{inService(2), inService(2)}},
+			want:  true,
+		},
+		"weighted missing capacity": {
+			group: AutoScalingGroup{DesiredCapacity: 6, IsWeighted: true, Instances: []AutoScalingGroupInstance{inService(2), inService(2)}},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.group.IsFulfilled())
+		})
+	}
+}
+
+func TestGetAutoScalingGroupInstanceWeightedCapacity(t *testing.T) {
+	assert.Equal(t, 1, getAutoScalingGroupInstanceWeightedCapacity(&autoscaling.Instance{}, true))
+	assert.Equal(t, 1, getAutoScalingGroupInstanceWeightedCapacity(&autoscaling.Instance{WeightedCapacity: aws.String("3")}, false))
+	assert.Equal(t, 3, getAutoScalingGroupInstanceWeightedCapacity(&autoscaling.Instance{WeightedCapacity: aws.String("3")}, true))
+	assert.Equal(t, 1, getAutoScalingGroupInstanceWeightedCapacity(&autoscaling.Instance{WeightedCapacity: aws.String("invalid")}, true))
+}
@@ -0,0 +1,68 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+const GetAutoScalingGroupActivityName = "aws-common-get-auto-scaling-group"
+
+// GetAutoScalingGroupActivity returns the state of the auto scaling group of a node pool stack.
+type GetAutoScalingGroupActivity struct {
+	awsSessionFactory AWSFactory
+}
+
+type GetAutoScalingGroupActivityInput struct {
+	AWSCommonActivityInput
+
+	// name of the cloud formation template stack
+	StackName string
+
+	// logical ID of the auto scaling group in the cloud formation template
+	LogicalResourceID string
+}
+
+type GetAutoScalingGroupActivityOutput struct {
+	AutoScalingGroup AutoScalingGroup
+}
+
+// NewGetAutoScalingGroupActivity instantiates a new GetAutoScalingGroupActivity
+func NewGetAutoScalingGroupActivity(awsSessionFactory AWSFactory) *GetAutoScalingGroupActivity {
+	return &GetAutoScalingGroupActivity{
+		awsSessionFactory: awsSessionFactory,
+	}
+}
+
+func (a *GetAutoScalingGroupActivity) Execute(ctx context.Context, input GetAutoScalingGroupActivityInput) (GetAutoScalingGroupActivityOutput, error) {
+	awsSession, err := a.awsSessionFactory.New(input.OrganizationID, input.SecretID, input.Region)
+	if err = errors.WrapIf(err, "failed to create AWS session"); err != nil {
+		return GetAutoScalingGroupActivityOutput{}, err
+	}
+
+	name, err := getAutoScalingGroupName(ctx, awsSession, input.StackName, input.LogicalResourceID)
+	if err != nil {
+		return GetAutoScalingGroupActivityOutput{}, err
+	}
+
+	autoScalingGroup, err := describeAutoScalingGroup(ctx, awsSession, name)
+	if err != nil {
+		return GetAutoScalingGroupActivityOutput{}, err
+	}
+
+	return GetAutoScalingGroupActivityOutput{AutoScalingGroup: autoScalingGroup}, nil
+}
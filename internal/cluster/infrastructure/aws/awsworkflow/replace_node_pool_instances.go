@@ -0,0 +1,410 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsworkflow
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+// ReplaceNodePoolInstancesInput holds the parameters of the Pipeline-driven
+// replacement of the outdated instances of a node pool.
+type ReplaceNodePoolInstancesInput struct {
+	AWSCommonActivityInput
+
+	ClusterID uint
+
+	// name of the cloud formation template stack
+	StackName string
+
+	// logical ID of the auto scaling group in the cloud formation template
+	LogicalResourceID string
+
+	// Maximum number of extra instances launched during the replacement.
+	MaxSurge int
+
+	// Maximum number of instances which can be unavailable during the replacement.
+	MaxUnavailable int
+
+	DrainTimeout     time.Duration
+	DrainFailOnError bool
+	DrainPodSelector string
+}
+
+// ReplaceNodePoolInstances replaces the outdated instances of a node pool auto scaling group.
+//
+// The surge capacity is launched first, then the outdated instances are
+// replaced in batches: the nodes of a batch are cordoned and drained through
+// the eviction API, their instances are terminated and the replacement nodes
+// are waited for to become ready before the next batch. The surge capacity is
+// given back by the terminations of the last batch.
+func ReplaceNodePoolInstances(ctx workflow.Context, process processlog.Process, input ReplaceNodePoolInstancesInput) error {
+	activityOptions := workflow.ActivityOptions{
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 1.01,
+			MaximumAttempts:    10,
+			MaximumInterval:    10 * time.Minute,
+		},
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, activityOptions)
+
+	var autoScalingGroup AutoScalingGroup
+	{
+		activityInput := GetAutoScalingGroupActivityInput{
+			AWSCommonActivityInput: input.AWSCommonActivityInput,
+			StackName:              input.StackName,
+			LogicalResourceID:      input.LogicalResourceID,
+		}
+
+		var output GetAutoScalingGroupActivityOutput
+
+		processActivity := process.StartActivity(ctx, GetAutoScalingGroupActivityName)
+		err := workflow.ExecuteActivity(ctx, GetAutoScalingGroupActivityName, activityInput).Get(ctx, &output)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+
+		autoScalingGroup = output.AutoScalingGroup
+	}
+
+	surge, batches := planNodePoolInstanceReplacements(autoScalingGroup.OutdatedInstances(), input.MaxSurge, input.MaxUnavailable)
+	if len(batches) == 0 {
+		return nil
+	}
+
+	maxSize := autoScalingGroup.MaxSize
+	if surge > 0 {
+		if autoScalingGroup.DesiredCapacity+surge > maxSize {
+			maxSize = autoScalingGroup.DesiredCapacity + surge
+		}
+
+		err := setNodePoolCapacity(ctx, process, input, autoScalingGroup.Name, autoScalingGroup.DesiredCapacity+surge, maxSize)
+		if err != nil {
+			return err
+		}
+
+		err = waitForNodePoolInstances(ctx, process, input, autoScalingGroup.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, batch := range batches {
+		err := replaceNodePoolInstanceBatch(ctx, process, input, batch)
+		if err != nil {
+			return err
+		}
+
+		err = waitForNodePoolInstances(ctx, process, input, autoScalingGroup.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Note: the desired capacity is already restored by the last terminations.
+	if maxSize != autoScalingGroup.MaxSize {
+		err := setNodePoolCapacity(ctx, process, input, autoScalingGroup.Name, autoScalingGroup.DesiredCapacity, autoScalingGroup.MaxSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nodePoolInstanceReplacement describes the replacement of a single node pool instance.
+type nodePoolInstanceReplacement struct {
+	Instance AutoScalingGroupInstance
+
+	// ShouldDecrementDesiredCapacity gives back the surge capacity instead of launching a replacement instance.
+	ShouldDecrementDesiredCapacity bool
+}
+
+// planNodePoolInstanceReplacements returns the surge capacity to launch before
+// the replacement and the batches of instances replaced together.
+//
+// At most surge+maxUnavailable instances are replaced at a time, so at least
+// desired capacity-maxUnavailable nodes are kept available. When neither
+// surge nor unavailability is allowed, the instances are replaced one by one.
+func planNodePoolInstanceReplacements(
+	instances []AutoScalingGroupInstance, maxSurge int, maxUnavailable int,
+) (int, [][]nodePoolInstanceReplacement) {
+	if len(instances) == 0 {
+		return 0, nil
+	}
+
+	surge := maxSurge
+	if surge > len(instances) {
+		surge = len(instances)
+	} else if surge < 0 {
+		surge = 0
+	}
+
+	unavailable := maxUnavailable
+	if unavailable < 0 || (surge == 0 && unavailable == 0) {
+		unavailable = 1
+	}
+
+	batchSize := surge + unavailable
+
+	batches := make([][]nodePoolInstanceReplacement, 0, (len(instances)+batchSize-1)/batchSize)
+	for start := 0; start < len(instances); start += batchSize {
+		end := start + batchSize
+		if end > len(instances) {
+			end = len(instances)
+		}
+
+		batch := make([]nodePoolInstanceReplacement, 0, end-start)
+		for index := start; index < end; index++ {
+			batch = append(batch, nodePoolInstanceReplacement{
+				Instance:                       instances[index],
+				ShouldDecrementDesiredCapacity: index >= len(instances)-surge,
+			})
+		}
+
+		batches = append(batches, batch)
+	}
+
+	return surge, batches
+}
+
+func setNodePoolCapacity(
+	ctx workflow.Context,
+	process processlog.Process,
+	input ReplaceNodePoolInstancesInput,
+	autoScalingGroupName string,
+	desiredCapacity int,
+	maxSize int,
+) error {
+	activityInput := SetAutoScalingGroupCapacityActivityInput{
+		AWSCommonActivityInput: input.AWSCommonActivityInput,
+		AutoScalingGroupName:   autoScalingGroupName,
+		DesiredCapacity:        desiredCapacity,
+		MaxSize:                maxSize,
+	}
+
+	processActivity := process.StartActivity(ctx, SetAutoScalingGroupCapacityActivityName)
+	err := workflow.ExecuteActivity(ctx, SetAutoScalingGroupCapacityActivityName, activityInput).Get(ctx, nil)
+	processActivity.Finish(ctx, err)
+
+	return err
+}
+
+// waitForNodePoolInstances waits until the auto scaling group reaches its
+// desired capacity and the nodes of its up to date instances are ready.
+func waitForNodePoolInstances(
+	ctx workflow.Context,
+	process processlog.Process,
+	input ReplaceNodePoolInstancesInput,
+	autoScalingGroupName string,
+) error {
+	var autoScalingGroup AutoScalingGroup
+	{
+		activityInput := WaitAutoScalingGroupActivityInput{
+			AWSCommonActivityInput: input.AWSCommonActivityInput,
+			AutoScalingGroupName:   autoScalingGroupName,
+		}
+
+		activityOptions := workflow.ActivityOptions{
+			ScheduleToStartTimeout: 10 * time.Minute,
+			StartToCloseTimeout:    30 * time.Minute,
+			HeartbeatTimeout:       time.Minute,
+			RetryPolicy: &cadence.RetryPolicy{
+				InitialInterval:          20 * time.Second,
+				BackoffCoefficient:       1.1,
+				MaximumAttempts:          5,
+				NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+			},
+		}
+
+		var output WaitAutoScalingGroupActivityOutput
+
+		processActivity := process.StartActivity(ctx, WaitAutoScalingGroupActivityName)
+		err := workflow.ExecuteActivity(
+			workflow.WithActivityOptions(ctx, activityOptions),
+			WaitAutoScalingGroupActivityName,
+			activityInput,
+		).Get(ctx, &output)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+
+		autoScalingGroup = output.AutoScalingGroup
+	}
+
+	nodeNames := make([]string, 0, len(autoScalingGroup.Instances))
+	for _, instance := range autoScalingGroup.InServiceInstances() {
+		if !instance.Outdated && instance.NodeName != "" {
+			nodeNames = append(nodeNames, instance.NodeName)
+		}
+	}
+
+	activityInput := clusterworkflow.WaitForNodesReadyActivityInput{
+		ClusterID: input.ClusterID,
+		NodeNames: nodeNames,
+	}
+
+	activityOptions := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    20 * time.Minute,
+		HeartbeatTimeout:       time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          20 * time.Second,
+			BackoffCoefficient:       1.1,
+			MaximumAttempts:          5,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+		},
+	}
+
+	processActivity := process.StartActivity(ctx, clusterworkflow.WaitForNodesReadyActivityName)
+	err := workflow.ExecuteActivity(
+		workflow.WithActivityOptions(ctx, activityOptions),
+		clusterworkflow.WaitForNodesReadyActivityName,
+		activityInput,
+	).Get(ctx, nil)
+	processActivity.Finish(ctx, err)
+
+	return err
+}
+
+// replaceNodePoolInstanceBatch drains the nodes of a batch and terminates
+// their instances, recording a process event for every node.
+func replaceNodePoolInstanceBatch(
+	ctx workflow.Context,
+	process processlog.Process,
+	input ReplaceNodePoolInstancesInput,
+	batch []nodePoolInstanceReplacement,
+) error {
+	drainTimeout := input.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = clusterworkflow.DefaultNodeDrainTimeout
+	}
+
+	drainActivityOptions := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    drainTimeout + 5*time.Minute,
+		HeartbeatTimeout:       2 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          20 * time.Second,
+			BackoffCoefficient:       1.1,
+			MaximumAttempts:          3,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+		},
+	}
+	drainCtx := workflow.WithActivityOptions(ctx, drainActivityOptions)
+
+	// Note: the deadline is computed from the workflow time, so activity
+	// retries cannot extend the drain timeout.
+	drainDeadline := workflow.Now(ctx).Add(drainTimeout)
+
+	{
+		futures := make([]workflow.Future, 0, len(batch))
+		nodeNames := make([]string, 0, len(batch))
+		processActivities := make([]processlog.Activity, 0, len(batch))
+		for _, replacement := range batch {
+			if replacement.Instance.NodeName == "" { // Note: the instance never joined the cluster.
+				continue
+			}
+
+			activityInput := clusterworkflow.DrainNodeActivityInput{
+				ClusterID:   input.ClusterID,
+				NodeName:    replacement.Instance.NodeName,
+				Deadline:    drainDeadline,
+				FailOnError: input.DrainFailOnError,
+				PodSelector: input.DrainPodSelector,
+			}
+
+			processActivities = append(processActivities, process.StartActivity(
+				ctx, fmt.Sprintf("%s:%s", clusterworkflow.DrainNodeActivityName, replacement.Instance.NodeName),
+			))
+			futures = append(futures, workflow.ExecuteActivity(drainCtx, clusterworkflow.DrainNodeActivityName, activityInput))
+			nodeNames = append(nodeNames, replacement.Instance.NodeName)
+		}
+
+		errs := make([]error, 0, len(futures))
+		for index, future := range futures {
+			var output clusterworkflow.DrainNodeActivityOutput
+
+			err := future.Get(ctx, &output)
+			processActivities[index].Finish(ctx, err)
+			errs = append(errs, err)
+
+			if len(output.BlockedPods) > 0 {
+				recordForceRemovedPods(ctx, process, nodeNames[index], output.BlockedPods)
+			}
+		}
+
+		if err := errors.Combine(errs...); err != nil {
+			return err
+		}
+	}
+
+	futures := make([]workflow.Future, 0, len(batch))
+	processActivities := make([]processlog.Activity, 0, len(batch))
+	for _, replacement := range batch {
+		activityInput := TerminateAutoScalingGroupInstanceActivityInput{
+			AWSCommonActivityInput:         input.AWSCommonActivityInput,
+			InstanceID:                     replacement.Instance.InstanceID,
+			ShouldDecrementDesiredCapacity: replacement.ShouldDecrementDesiredCapacity,
+		}
+
+		processActivities = append(processActivities, process.StartActivity(
+			ctx, fmt.Sprintf("%s:%s", TerminateAutoScalingGroupInstanceActivityName, replacement.Instance.InstanceID),
+		))
+		futures = append(futures, workflow.ExecuteActivity(ctx, TerminateAutoScalingGroupInstanceActivityName, activityInput))
+	}
+
+	errs := make([]error, 0, len(futures))
+	for index, future := range futures {
+		err := future.Get(ctx, nil)
+		processActivities[index].Finish(ctx, err)
+		errs = append(errs, err)
+	}
+
+	return errors.Combine(errs...)
+}
+
+// ForceRemovePodsEventType is the type of the process event recording the
+// pods removed together with their node without being evicted.
+const ForceRemovePodsEventType = "force-remove-pods"
+
+// recordForceRemovedPods logs the pods which could not be evicted before the
+// drain deadline (eg. because of a pod disruption budget) and records them as a
+// failed process event, since they are removed together with the node.
+func recordForceRemovedPods(ctx workflow.Context, process processlog.Process, nodeName string, pods []string) {
+	message := fmt.Sprintf(
+		"pods blocked by pod disruption budgets were force-removed together with the node: %s",
+		strings.Join(pods, ", "),
+	)
+
+	workflow.GetLogger(ctx).Sugar().Warnw(message, "node", nodeName)
+
+	processActivity := process.StartActivity(ctx, fmt.Sprintf("%s:%s", ForceRemovePodsEventType, nodeName))
+	processActivity.Finish(ctx, errors.New(message))
+}
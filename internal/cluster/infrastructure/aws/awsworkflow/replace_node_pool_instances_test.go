@@ -0,0 +1,106 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsworkflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanNodePoolInstanceReplacements(t *testing.T) {
+	instances := []AutoScalingGroupInstance{
+		{InstanceID: "i-1"},
+		{InstanceID: "i-2"},
+		{InstanceID: "i-3"},
+		{InstanceID: "i-4"},
+		{InstanceID: "i-5"},
+	}
+
+	type batch []string
+
+	tests := map[string]struct {
+		instances      []AutoScalingGroupInstance
+		maxSurge       int
+		maxUnavailable int
+
+		expectedSurge      int
+		expectedBatches    []batch
+		expectedDecrements []string
+	}{
+		"no outdated instances": {
+			maxSurge:       1,
+			maxUnavailable: 1,
+		},
+		"one by one": {
+			instances:       instances,
+			expectedBatches: []batch{{"i-1"}, {"i-2"}, {"i-3"}, {"i-4"}, {"i-5"}},
+		},
+		"max unavailable": {
+			instances:       instances,
+			maxUnavailable:  2,
+			expectedBatches: []batch{{"i-1", "i-2"}, {"i-3", "i-4"}, {"i-5"}},
+		},
+		"max surge": {
+			instances:          instances,
+			maxSurge:           2,
+			expectedSurge:      2,
+			expectedBatches:    []batch{{"i-1", "i-2"}, {"i-3", "i-4"}, {"i-5"}},
+			expectedDecrements: []string{"i-4", "i-5"},
+		},
+		"max surge and max unavailable": {
+			instances:          instances,
+			maxSurge:           1,
+			maxUnavailable:     1,
+			expectedSurge:      1,
+			expectedBatches:    []batch{{"i-1", "i-2"}, {"i-3", "i-4"}, {"i-5"}},
+			expectedDecrements: []string{"i-5"},
+		},
+		"max surge above instance count": {
+			instances:          instances[:2],
+			maxSurge:           5,
+			expectedSurge:      2,
+			expectedBatches:    []batch{{"i-1", "i-2"}},
+			expectedDecrements: []string{"i-1", "i-2"},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			surge, batches := planNodePoolInstanceReplacements(test.instances, test.maxSurge, test.maxUnavailable)
+
+			var actualBatches []batch
+			var actualDecrements []string
+			for _, replacements := range batches {
+				var actualBatch batch
+				for _, replacement := range replacements {
+					actualBatch = append(actualBatch, replacement.Instance.InstanceID)
+
+					if replacement.ShouldDecrementDesiredCapacity {
+						actualDecrements = append(actualDecrements, replacement.Instance.InstanceID)
+					}
+				}
+
+				actualBatches = append(actualBatches, actualBatch)
+			}
+
+			assert.Equal(t, test.expectedSurge, surge)
+			assert.Equal(t, test.expectedBatches, actualBatches)
+			assert.Equal(t, test.expectedDecrements, actualDecrements)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+const SetAutoScalingGroupCapacityActivityName = "aws-common-set-auto-scaling-group-capacity"
+
+// SetAutoScalingGroupCapacityActivity sets the desired capacity and maximum size of an auto scaling group.
+type SetAutoScalingGroupCapacityActivity struct {
+	awsSessionFactory AWSFactory
+}
+
+type SetAutoScalingGroupCapacityActivityInput struct {
+	AWSCommonActivityInput
+
+	AutoScalingGroupName string
+	DesiredCapacity      int
+	MaxSize              int
+}
+
+// NewSetAutoScalingGroupCapacityActivity instantiates a new SetAutoScalingGroupCapacityActivity
+func NewSetAutoScalingGroupCapacityActivity(awsSessionFactory AWSFactory) *SetAutoScalingGroupCapacityActivity {
+	return &SetAutoScalingGroupCapacityActivity{
+		awsSessionFactory: awsSessionFactory,
+	}
+}
+
+func (a *SetAutoScalingGroupCapacityActivity) Execute(ctx context.Context, input SetAutoScalingGroupCapacityActivityInput) error {
+	awsSession, err := a.awsSessionFactory.New(input.OrganizationID, input.SecretID, input.Region)
+	if err = errors.WrapIf(err, "failed to create AWS session"); err != nil {
+		return err
+	}
+
+	_, err = autoscaling.New(awsSession).UpdateAutoScalingGroupWithContext(ctx, &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(input.AutoScalingGroupName),
+		DesiredCapacity:      aws.Int64(int64(input.DesiredCapacity)),
+		MaxSize:              aws.Int64(int64(input.MaxSize)),
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to update auto scaling group",
+			"autoScalingGroup", input.AutoScalingGroupName,
+			"desiredCapacity", input.DesiredCapacity,
+			"maxSize", input.MaxSize,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsworkflow
+
+import (
+	"context"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"go.uber.org/cadence/activity"
+)
+
+const TerminateAutoScalingGroupInstanceActivityName = "aws-common-terminate-auto-scaling-group-instance"
+
+// TerminateAutoScalingGroupInstanceActivity terminates an instance of an auto scaling group.
+type TerminateAutoScalingGroupInstanceActivity struct {
+	awsSessionFactory AWSFactory
+}
+
+type TerminateAutoScalingGroupInstanceActivityInput struct {
+	AWSCommonActivityInput
+
+	InstanceID string
+
+	// ShouldDecrementDesiredCapacity prevents the auto scaling group from launching a replacement instance.
+	ShouldDecrementDesiredCapacity bool
+}
+
+// NewTerminateAutoScalingGroupInstanceActivity instantiates a new TerminateAutoScalingGroupInstanceActivity
+func NewTerminateAutoScalingGroupInstanceActivity(awsSessionFactory AWSFactory) *TerminateAutoScalingGroupInstanceActivity {
+	return &TerminateAutoScalingGroupInstanceActivity{
+		awsSessionFactory: awsSessionFactory,
+	}
+}
+
+func (a *TerminateAutoScalingGroupInstanceActivity) Execute(ctx context.Context, input TerminateAutoScalingGroupInstanceActivityInput) error {
+	logger := activity.GetLogger(ctx).Sugar().With(
+		"organization", input.OrganizationID,
+		"cluster", input.ClusterName,
+		"instance", input.InstanceID,
+	)
+
+	awsSession, err := a.awsSessionFactory.New(input.OrganizationID, input.SecretID, input.Region)
+	if err = errors.WrapIf(err, "failed to create AWS session"); err != nil {
+		return err
+	}
+
+	autoscalingClient := autoscaling.New(awsSession)
+
+	describeOutput, err := autoscalingClient.DescribeAutoScalingInstancesWithContext(ctx, &autoscaling.DescribeAutoScalingInstancesInput{
+		InstanceIds: []*string{aws.String(input.InstanceID)},
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to describe auto scaling instance", "instance", input.InstanceID)
+	}
+
+	// Note: the instance is already being terminated (eg. on activity retry).
+	if len(describeOutput.AutoScalingInstances) == 0 ||
+		strings.HasPrefix(aws.StringValue(describeOutput.AutoScalingInstances[0].LifecycleState), "Terminat") {
+		logger.Info("instance is already terminated")
+
+		return nil
+	}
+
+	logger.Info("terminating instance")
+
+	_, err = autoscalingClient.TerminateInstanceInAutoScalingGroupWithContext(ctx, &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(input.InstanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(input.ShouldDecrementDesiredCapacity),
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to terminate instance", "instance", input.InstanceID)
+	}
+
+	return nil
+}
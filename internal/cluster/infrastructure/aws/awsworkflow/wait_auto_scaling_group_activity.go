@@ -0,0 +1,83 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsworkflow
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+)
+
+const WaitAutoScalingGroupActivityName = "aws-common-wait-auto-scaling-group"
+
+// WaitAutoScalingGroupActivity waits until every instance of an auto scaling
+// group is in service and their number matches the desired capacity.
+type WaitAutoScalingGroupActivity struct {
+	awsSessionFactory AWSFactory
+	pollInterval      time.Duration
+}
+
+type WaitAutoScalingGroupActivityInput struct {
+	AWSCommonActivityInput
+
+	AutoScalingGroupName string
+}
+
+type WaitAutoScalingGroupActivityOutput struct {
+	AutoScalingGroup AutoScalingGroup
+}
+
+// NewWaitAutoScalingGroupActivity instantiates a new WaitAutoScalingGroupActivity
+func NewWaitAutoScalingGroupActivity(awsSessionFactory AWSFactory) *WaitAutoScalingGroupActivity {
+	return &WaitAutoScalingGroupActivity{
+		awsSessionFactory: awsSessionFactory,
+		pollInterval:      20 * time.Second,
+	}
+}
+
+func (a *WaitAutoScalingGroupActivity) Execute(ctx context.Context, input WaitAutoScalingGroupActivityInput) (WaitAutoScalingGroupActivityOutput, error) {
+	awsSession, err := a.awsSessionFactory.New(input.OrganizationID, input.SecretID, input.Region)
+	if err = errors.WrapIf(err, "failed to create AWS session"); err != nil {
+		return WaitAutoScalingGroupActivityOutput{}, err
+	}
+
+	for {
+		autoScalingGroup, err := describeAutoScalingGroup(ctx, awsSession, input.AutoScalingGroupName)
+		if err != nil {
+			return WaitAutoScalingGroupActivityOutput{}, err
+		}
+
+		if autoScalingGroup.IsFulfilled() {
+			return WaitAutoScalingGroupActivityOutput{AutoScalingGroup: autoScalingGroup}, nil
+		}
+
+		inServiceInstanceCount := len(autoScalingGroup.InServiceInstances())
+
+		activity.RecordHeartbeat(ctx, inServiceInstanceCount)
+
+		select {
+		case <-ctx.Done():
+			return WaitAutoScalingGroupActivityOutput{}, errors.WrapIfWithDetails(
+				ctx.Err(), "auto scaling group is not ready",
+				"autoScalingGroup", input.AutoScalingGroupName,
+				"desiredCapacity", autoScalingGroup.DesiredCapacity,
+				"inServiceInstances", inServiceInstanceCount,
+			)
+		case <-time.After(a.pollInterval):
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Supported node pool taint effects.
+const (
+	NodePoolTaintEffectNoSchedule       = "NoSchedule"
+	NodePoolTaintEffectPreferNoSchedule = "PreferNoSchedule"
+	NodePoolTaintEffectNoExecute        = "NoExecute"
+)
+
+// reservedNodePoolTaintKeyPrefixes collects the taint key prefixes managed by Kubernetes itself.
+var reservedNodePoolTaintKeyPrefixes = []string{
+	"node.kubernetes.io/",
+	"node.cloudprovider.kubernetes.io/",
+}
+
+// NodePoolTaint describes a Kubernetes taint applied to every node in a node pool.
+type NodePoolTaint struct {
+	Key    string `json:"key" yaml:"key" mapstructure:"key"`
+	Value  string `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value"`
+	Effect string `json:"effect" yaml:"effect" mapstructure:"effect"`
+}
+
+// String returns the taint in the format accepted by the kubelet (key=value:effect).
+func (t NodePoolTaint) String() string {
+	if t.Value == "" {
+		return t.Key + ":" + t.Effect
+	}
+
+	return t.Key + "=" + t.Value + ":" + t.Effect
+}
+
+// ParseNodePoolTaint parses a taint in the key=value:effect (or key:effect) format.
+func ParseNodePoolTaint(s string) (NodePoolTaint, error) {
+	keyValue, effect := s, ""
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		keyValue, effect = s[:i], s[i+1:]
+	}
+
+	if effect == "" {
+		return NodePoolTaint{}, errors.NewWithDetails("missing taint effect", "taint", s)
+	}
+
+	taint := NodePoolTaint{Key: keyValue, Effect: effect}
+	if i := strings.Index(keyValue, "="); i >= 0 {
+		taint.Key, taint.Value = keyValue[:i], keyValue[i+1:]
+	}
+
+	return taint, nil
+}
+
+// ParseNodePoolTaints parses a comma separated list of taints.
+func ParseNodePoolTaints(s string) ([]NodePoolTaint, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var taints []NodePoolTaint
+	for _, item := range strings.Split(s, ",") {
+		taint, err := ParseNodePoolTaint(item)
+		if err != nil {
+			return nil, err
+		}
+
+		taints = append(taints, taint)
+	}
+
+	return taints, nil
+}
+
+// FormatNodePoolTaints returns the taints as a comma separated list.
+func FormatNodePoolTaints(taints []NodePoolTaint) string {
+	items := make([]string, 0, len(taints))
+	for _, taint := range taints {
+		items = append(items, taint.String())
+	}
+
+	return strings.Join(items, ",")
+}
+
+// ValidateNodePoolTaints validates the taints and startup taints of a node pool and returns the violations.
+//
+// Both lists are registered on the nodes together, so a taint cannot appear in both of them.
+func ValidateNodePoolTaints(taints []NodePoolTaint, startupTaints []NodePoolTaint) []string {
+	var violations []string
+
+	seen := make(map[string]bool, len(taints)+len(startupTaints))
+
+	for _, taint := range append(append([]NodePoolTaint(nil), taints...), startupTaints...) {
+		for _, msg := range validation.IsQualifiedName(taint.Key) {
+			violations = append(violations, fmt.Sprintf("invalid taint key %q: %s", taint.Key, msg))
+		}
+
+		for _, prefix := range reservedNodePoolTaintKeyPrefixes {
+			if strings.HasPrefix(taint.Key, prefix) {
+				violations = append(violations, fmt.Sprintf("taint key %q uses the reserved prefix %q", taint.Key, prefix))
+			}
+		}
+
+		for _, msg := range validation.IsValidLabelValue(taint.Value) {
+			violations = append(violations, fmt.Sprintf("invalid taint value %q: %s", taint.Value, msg))
+		}
+
+		switch taint.Effect {
+		case NodePoolTaintEffectNoSchedule, NodePoolTaintEffectPreferNoSchedule, NodePoolTaintEffectNoExecute:
+		default:
+			violations = append(violations, fmt.Sprintf("invalid taint effect %q for key %q", taint.Effect, taint.Key))
+		}
+
+		id := taint.Key + ":" + taint.Effect
+		if seen[id] {
+			violations = append(violations, fmt.Sprintf("duplicate taint %q", id))
+		}
+		seen[id] = true
+	}
+
+	return violations
+}
@@ -0,0 +1,95 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodePoolTaints(t *testing.T) {
+	taints, err := ParseNodePoolTaints("dedicated=gpu:NoSchedule,example.com/batch:PreferNoSchedule")
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]NodePoolTaint{
+			{Key: "dedicated", Value: "gpu", Effect: NodePoolTaintEffectNoSchedule},
+			{Key: "example.com/batch", Effect: NodePoolTaintEffectPreferNoSchedule},
+		},
+		taints,
+	)
+	assert.Equal(t, "dedicated=gpu:NoSchedule,example.com/batch:PreferNoSchedule", FormatNodePoolTaints(taints))
+
+	taints, err = ParseNodePoolTaints("")
+	require.NoError(t, err)
+	assert.Nil(t, taints)
+
+	_, err = ParseNodePoolTaints("dedicated=gpu")
+	assert.Error(t, err)
+}
+
+func TestValidateNodePoolTaints(t *testing.T) {
+	tests := map[string]struct {
+		taints        []NodePoolTaint
+		startupTaints []NodePoolTaint
+		violations    int
+	}{
+		"valid": {
+			taints: []NodePoolTaint{
+				{Key: "dedicated", Value: "gpu", Effect: NodePoolTaintEffectNoSchedule},
+				{Key: "dedicated", Value: "gpu", Effect: NodePoolTaintEffectNoExecute},
+			},
+		},
+		"invalid key": {
+			taints:     []NodePoolTaint{{Key: "-dedicated", Effect: NodePoolTaintEffectNoSchedule}},
+			violations: 1,
+		},
+		"reserved key": {
+			taints:     []NodePoolTaint{{Key: "node.kubernetes.io/unschedulable", Effect: NodePoolTaintEffectNoSchedule}},
+			violations: 1,
+		},
+		"invalid value": {
+			taints:     []NodePoolTaint{{Key: "dedicated", Value: "a b", Effect: NodePoolTaintEffectNoSchedule}},
+			violations: 1,
+		},
+		"invalid effect": {
+			taints:     []NodePoolTaint{{Key: "dedicated", Effect: "NoWay"}},
+			violations: 1,
+		},
+		"duplicate": {
+			taints: []NodePoolTaint{
+				{Key: "dedicated", Value: "gpu", Effect: NodePoolTaintEffectNoSchedule},
+				{Key: "dedicated", Value: "batch", Effect: NodePoolTaintEffectNoSchedule},
+			},
+			violations: 1,
+		},
+		"duplicate startup taint": {
+			taints:        []NodePoolTaint{{Key: "dedicated", Value: "gpu", Effect: NodePoolTaintEffectNoSchedule}},
+			startupTaints: []NodePoolTaint{{Key: "dedicated", Effect: NodePoolTaintEffectNoSchedule}},
+			violations:    1,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Len(t, ValidateNodePoolTaints(test.taints, test.startupTaints), test.violations)
+		})
+	}
+}
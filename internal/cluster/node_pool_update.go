@@ -0,0 +1,54 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+)
+
+// Node pool update strategies.
+const (
+	// NodePoolUpdateStrategyRollingUpdate leaves the replacement of the nodes to the rolling update of the cloud provider.
+	NodePoolUpdateStrategyRollingUpdate = "rollingUpdate"
+
+	// NodePoolUpdateStrategyReplace replaces the nodes from Pipeline: outdated nodes are cordoned and drained
+	// through the eviction API (respecting pod disruption budgets) before their instances are terminated.
+	NodePoolUpdateStrategyReplace = "replace"
+)
+
+// ValidateNodePoolUpdateOptions validates the generic node pool update options and returns the violations.
+func ValidateNodePoolUpdateOptions(strategy string, maxSurge int, maxUnavailable int, drainTimeout int) []string {
+	var violations []string
+
+	switch strategy {
+	case "", NodePoolUpdateStrategyRollingUpdate, NodePoolUpdateStrategyReplace:
+	default:
+		violations = append(violations, fmt.Sprintf("invalid update strategy %q", strategy))
+	}
+
+	if maxSurge < 0 {
+		violations = append(violations, "maxSurge must not be negative")
+	}
+
+	if maxUnavailable < 0 {
+		violations = append(violations, "maxUnavailable must not be negative")
+	}
+
+	if drainTimeout < 0 {
+		violations = append(violations, "drain timeout must not be negative")
+	}
+
+	return violations
+}
@@ -0,0 +1,65 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNodePoolUpdateOptions(t *testing.T) {
+	tests := map[string]struct {
+		strategy       string
+		maxSurge       int
+		maxUnavailable int
+		drainTimeout   int
+		violations     int
+	}{
+		"default": {},
+		"rolling update": {
+			strategy:       NodePoolUpdateStrategyRollingUpdate,
+			maxSurge:       1,
+			maxUnavailable: 1,
+		},
+		"replace": {
+			strategy:       NodePoolUpdateStrategyReplace,
+			maxSurge:       2,
+			maxUnavailable: 0,
+			drainTimeout:   300,
+		},
+		"invalid strategy": {
+			strategy:   "recreate",
+			violations: 1,
+		},
+		"negative values": {
+			strategy:       NodePoolUpdateStrategyReplace,
+			maxSurge:       -1,
+			maxUnavailable: -1,
+			drainTimeout:   -1,
+			violations:     3,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			violations := ValidateNodePoolUpdateOptions(test.strategy, test.maxSurge, test.maxUnavailable, test.drainTimeout)
+
+			assert.Len(t, violations, test.violations)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"reflect"
+)
+
+// Plan actions.
+const (
+	PlanActionCreate  = "create"
+	PlanActionUpdate  = "update"
+	PlanActionReplace = "replace"
+)
+
+// Plan resource types.
+const (
+	PlanResourceCluster                = "cluster"
+	PlanResourceNodePool               = "nodePool"
+	PlanResourceCloudFormationStack    = "cloudFormationStack"
+	PlanResourceVirtualMachineScaleSet = "virtualMachineScaleSet"
+)
+
+// Plan describes the changes an operation would make without applying them.
+type Plan struct {
+	Changes []PlanChange `json:"changes"`
+}
+
+// PlanChange describes a single change of a resource (attribute).
+type PlanChange struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+
+	// Attribute is the changed attribute of the resource (eg. a CloudFormation stack parameter).
+	// It is empty when the change affects the resource as a whole.
+	Attribute string `json:"attribute,omitempty"`
+
+	Current interface{} `json:"current,omitempty"`
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+// Add appends a change to the plan.
+func (p *Plan) Add(change PlanChange) {
+	p.Changes = append(p.Changes, change)
+}
+
+// AddUpdate appends an attribute update to the plan unless the current and the desired values are equal.
+func (p *Plan) AddUpdate(resource string, name string, attribute string, current interface{}, desired interface{}) {
+	if reflect.DeepEqual(current, desired) {
+		return
+	}
+
+	p.Add(PlanChange{
+		Action:    PlanActionUpdate,
+		Resource:  resource,
+		Name:      name,
+		Attribute: attribute,
+		Current:   current,
+		Desired:   desired,
+	})
+}
+
+// Merge appends the changes of another plan to the plan.
+func (p *Plan) Merge(other Plan) {
+	p.Changes = append(p.Changes, other.Changes...)
+}
+
+// +testify:mock:testOnly=true
+
+// KubernetesVersionGetter returns the current Kubernetes version of a cluster.
+type KubernetesVersionGetter interface {
+	// GetKubernetesVersion returns the current Kubernetes version of a cluster.
+	GetKubernetesVersion(ctx context.Context, clusterID uint) (string, error)
+}
+
+// KubernetesVersionGetterFunc is an adapter to allow using ordinary functions as a KubernetesVersionGetter.
+type KubernetesVersionGetterFunc func(ctx context.Context, clusterID uint) (string, error)
+
+// GetKubernetesVersion calls f(ctx, clusterID).
+func (f KubernetesVersionGetterFunc) GetKubernetesVersion(ctx context.Context, clusterID uint) (string, error) {
+	return f(ctx, clusterID)
+}
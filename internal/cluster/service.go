@@ -209,6 +209,15 @@ type Service interface {
 
 	// ListNodePools lists node pools from a cluster.
 	ListNodePools(ctx context.Context, clusterID uint) (nodePoolList RawNodePoolList, err error)
+
+	// PlanUpdateCluster returns the changes UpdateCluster would make without applying them.
+	PlanUpdateCluster(ctx context.Context, clusterIdentifier Identifier, clusterUpdate ClusterUpdate) (plan Plan, err error)
+
+	// PlanCreateNodePools returns the changes CreateNodePools would make without applying them.
+	PlanCreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]NewRawNodePool) (plan Plan, err error)
+
+	// PlanUpdateNodePool returns the changes UpdateNodePool would make without applying them.
+	PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate RawNodePoolUpdate) (plan Plan, err error)
 }
 
 // DeleteClusterOptions represents cluster deletion options.
@@ -231,6 +240,8 @@ type service struct {
 	nodePools         NodePoolStore
 	nodePoolValidator NodePoolValidator
 	nodePoolProcessor NodePoolProcessor
+
+	kubernetesVersions KubernetesVersionGetter
 }
 
 // +testify:mock:testOnly=true
@@ -255,6 +266,7 @@ func NewService(
 	nodePools NodePoolStore,
 	nodePoolValidator NodePoolValidator,
 	nodePoolProcessor NodePoolProcessor,
+	kubernetesVersions KubernetesVersionGetter,
 ) Service {
 	return service{
 		clusters:            clusters,
@@ -266,6 +278,8 @@ func NewService(
 		nodePools:         nodePools,
 		nodePoolValidator: nodePoolValidator,
 		nodePoolProcessor: nodePoolProcessor,
+
+		kubernetesVersions: kubernetesVersions,
 	}
 }
 
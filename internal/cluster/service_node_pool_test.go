@@ -145,7 +145,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		rawNewNodePool := NewRawNodePool{
 			"name": "pool0",
@@ -183,7 +183,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		rawNewNodePool := NewRawNodePool{
 			"name": "pool0",
@@ -232,7 +232,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		err := service.CreateNodePools(ctx, 1, map[string]NewRawNodePool{"pool0": rawNewNodePool})
 		require.Error(t, err)
@@ -276,7 +276,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		err := service.CreateNodePools(ctx, 1, map[string]NewRawNodePool{"pool0": rawNewNodePool})
 		require.EqualError(t, err, "node pool exists error")
@@ -318,7 +318,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		err := service.CreateNodePools(ctx, 1, map[string]NewRawNodePool{"pool0": rawNewNodePool})
 		require.EqualError(t, err, NodePoolAlreadyExistsError{
@@ -365,7 +365,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		err := service.CreateNodePools(ctx, 1, map[string]NewRawNodePool{"pool0": rawNewNodePool})
 		require.EqualError(t, err, "process new error")
@@ -409,7 +409,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		err := service.CreateNodePools(ctx, 1, map[string]NewRawNodePool{"pool0": rawNewNodePool})
 		require.EqualError(t, err, NotSupportedDistributionError{
@@ -473,6 +473,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 			nodePoolStore,
 			validator,
 			processor,
+			nil,
 		)
 
 		err := service.CreateNodePools(ctx, 1, map[string]NewRawNodePool{"pool0": rawNewNodePool})
@@ -530,6 +531,7 @@ func TestNodePoolService_CreateNodePools(t *testing.T) {
 			nodePoolStore,
 			validator,
 			processor,
+			nil,
 		)
 
 		err := service.CreateNodePools(ctx, 1, map[string]NewRawNodePool{"pool0": rawNewNodePool})
@@ -557,7 +559,7 @@ func TestNodePoolService_UpdateNodePool(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		rawNodePoolUpdate := RawNodePoolUpdate{}
 
@@ -593,7 +595,7 @@ func TestNodePoolService_UpdateNodePool(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, map[string]Service{}, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, map[string]Service{}, nodePoolStore, validator, processor, nil)
 
 		rawNodePoolUpdate := RawNodePoolUpdate{}
 
@@ -638,7 +640,7 @@ func TestNodePoolService_UpdateNodePool(t *testing.T) {
 			cluster.Distribution: nil,
 		}
 
-		service := NewService(clusterStore, nil, clusterGroupManager, distributions, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, distributions, nodePoolStore, validator, processor, nil)
 
 		rawNodePoolUpdate := RawNodePoolUpdate{}
 
@@ -691,7 +693,7 @@ func TestNodePoolService_UpdateNodePool(t *testing.T) {
 			cluster.Distribution: distribution,
 		}
 
-		service := NewService(clusterStore, nil, clusterGroupManager, distributions, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, distributions, nodePoolStore, validator, processor, nil)
 
 		_, err := service.UpdateNodePool(ctx, cluster.ID, nodePoolName, rawNodePoolUpdate)
 		require.Error(t, err)
@@ -741,7 +743,7 @@ func TestNodePoolService_UpdateNodePool(t *testing.T) {
 			cluster.Distribution: distribution,
 		}
 
-		service := NewService(clusterStore, nil, clusterGroupManager, distributions, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, distributions, nodePoolStore, validator, processor, nil)
 
 		_, err := service.UpdateNodePool(ctx, cluster.ID, nodePoolName, rawNodePoolUpdate)
 		require.NoError(t, err)
@@ -769,7 +771,7 @@ func TestNodePoolService_DeleteNodePool(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		_, err := service.DeleteNodePool(ctx, 1, "pool0")
 		require.Error(t, err)
@@ -803,7 +805,7 @@ func TestNodePoolService_DeleteNodePool(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		_, err := service.DeleteNodePool(ctx, 1, "pool0")
 		require.Error(t, err)
@@ -842,7 +844,7 @@ func TestNodePoolService_DeleteNodePool(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		deleted, err := service.DeleteNodePool(ctx, 1, nodePoolName)
 		require.EqualError(t, err, expectedError.Error())
@@ -880,7 +882,7 @@ func TestNodePoolService_DeleteNodePool(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		deleted, err := service.DeleteNodePool(ctx, 1, nodePoolName)
 		require.NoError(t, err)
@@ -918,7 +920,7 @@ func TestNodePoolService_DeleteNodePool(t *testing.T) {
 		processor := new(MockNodePoolProcessor)
 		clusterGroupManager := new(MockClusterGroupManager)
 
-		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor)
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nodePoolStore, validator, processor, nil)
 
 		deleted, err := service.DeleteNodePool(ctx, 1, nodePoolName)
 		require.EqualError(t, err, "not supported distribution")
@@ -971,6 +973,7 @@ func TestNodePoolService_DeleteNodePool(t *testing.T) {
 			nodePoolStore,
 			validator,
 			processor,
+			nil,
 		)
 
 		deleted, err := service.DeleteNodePool(ctx, 1, nodePoolName)
@@ -1023,6 +1026,7 @@ func TestNodePoolService_DeleteNodePool(t *testing.T) {
 			nodePoolStore,
 			validator,
 			processor,
+			nil,
 		)
 
 		deleted, err := service.DeleteNodePool(ctx, 1, nodePoolName)
@@ -0,0 +1,166 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// PlanUpdateCluster returns the changes UpdateCluster would make without applying them.
+func (s service) PlanUpdateCluster(ctx context.Context, clusterIdentifier Identifier, update ClusterUpdate) (Plan, error) {
+	var (
+		c   Cluster
+		err error
+	)
+
+	if clusterIdentifier.ClusterName != "" {
+		c, err = s.clusters.GetClusterByName(ctx, clusterIdentifier.OrganizationID, clusterIdentifier.ClusterName)
+	} else {
+		c, err = s.clusters.GetCluster(ctx, clusterIdentifier.ClusterID)
+	}
+
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if !clusterIsReady(c.Status) {
+		return Plan{}, NotReadyError{
+			OrganizationID: c.OrganizationID,
+			ID:             c.ID,
+			Name:           c.Name,
+		}
+	}
+
+	service, err := s.getDistributionService(c)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	distributionPlan, err := service.PlanUpdateCluster(ctx, Identifier{OrganizationID: c.OrganizationID, ClusterID: c.ID, ClusterName: c.Name}, update)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	var plan Plan
+
+	if update.Version != "" {
+		currentVersion, err := s.kubernetesVersions.GetKubernetesVersion(ctx, c.ID)
+		if err != nil {
+			return Plan{}, errors.WrapIfWithDetails(err, "failed to get current Kubernetes version", "clusterId", c.ID)
+		}
+
+		plan.AddUpdate(PlanResourceCluster, c.Name, "version", currentVersion, update.Version)
+	}
+
+	plan.Merge(distributionPlan)
+
+	return plan, nil
+}
+
+// PlanCreateNodePools returns the changes CreateNodePools would make without applying them.
+func (s service) PlanCreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]NewRawNodePool) (Plan, error) {
+	cluster, err := s.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if err := s.checkClusterForNodePoolChange(cluster); err != nil {
+		return Plan{}, err
+	}
+
+	npErrors := make([]error, 0, len(rawNodePools))
+
+	for _, rawNodePool := range rawNodePools {
+		if err := s.nodePoolValidator.ValidateNew(ctx, cluster, rawNodePool); err != nil {
+			npErrors = append(npErrors, err)
+		}
+	}
+	if len(npErrors) > 0 {
+		return Plan{}, errors.Combine(npErrors...)
+	}
+
+	for rawNodePoolName := range rawNodePools {
+		exists, nodePoolStoredName, err := s.nodePools.NodePoolExists(ctx, clusterID, rawNodePoolName)
+		if err != nil {
+			npErrors = append(npErrors, err)
+		} else if exists {
+			npErrors = append(npErrors, errors.WithStack(NodePoolAlreadyExistsError{
+				ClusterID: clusterID,
+				NodePool:  nodePoolStoredName,
+			}))
+		}
+	}
+	if len(npErrors) > 0 {
+		return Plan{}, errors.Combine(npErrors...)
+	}
+
+	// Processing modifies the descriptors, the request should be left intact.
+	processedNodePools := make(map[string]NewRawNodePool, len(rawNodePools))
+
+	for rawNodePoolName, rawNodePool := range rawNodePools {
+		processedNodePools[rawNodePoolName], err = s.nodePoolProcessor.ProcessNew(ctx, cluster, rawNodePool)
+		if err != nil {
+			npErrors = append(npErrors, err)
+		}
+	}
+	if len(npErrors) > 0 {
+		return Plan{}, errors.Combine(npErrors...)
+	}
+
+	distributionService, err := s.getDistributionService(cluster)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return distributionService.PlanCreateNodePools(ctx, clusterID, processedNodePools)
+}
+
+// PlanUpdateNodePool returns the changes UpdateNodePool would make without applying them.
+func (s service) PlanUpdateNodePool(
+	ctx context.Context,
+	clusterID uint,
+	nodePoolName string,
+	rawNodePoolUpdate RawNodePoolUpdate,
+) (Plan, error) {
+	cluster, err := s.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if err := s.checkCluster(cluster); err != nil {
+		return Plan{}, err
+	}
+
+	service, err := s.getDistributionService(cluster)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	exists, nodePoolStoredName, err := s.nodePools.NodePoolExists(ctx, clusterID, nodePoolName)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if !exists {
+		return Plan{}, errors.WithStack(NodePoolNotFoundError{
+			ClusterID: clusterID,
+			NodePool:  nodePoolName,
+		})
+	}
+
+	return service.PlanUpdateNodePool(ctx, clusterID, nodePoolStoredName, rawNodePoolUpdate)
+}
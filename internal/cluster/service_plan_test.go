@@ -0,0 +1,225 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_PlanUpdateCluster(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := Cluster{
+		ID:             1,
+		OrganizationID: 1,
+		Name:           "cluster",
+		Status:         Running,
+		Cloud:          "amazon",
+		Distribution:   "eks",
+	}
+
+	clusterStore := new(MockStore)
+	clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+	identifier := Identifier{OrganizationID: 1, ClusterID: 1, ClusterName: "cluster"}
+	update := ClusterUpdate{Version: "1.19"}
+
+	distributionService := new(MockService)
+	distributionService.On("PlanUpdateCluster", ctx, identifier, update).Return(Plan{}, nil)
+
+	kubernetesVersions := new(MockKubernetesVersionGetter)
+	kubernetesVersions.On("GetKubernetesVersion", ctx, cluster.ID).Return("1.18", nil)
+
+	service := NewService(
+		clusterStore,
+		nil,
+		nil,
+		map[string]Service{"eks": distributionService},
+		nil,
+		nil,
+		nil,
+		kubernetesVersions,
+	)
+
+	plan, err := service.PlanUpdateCluster(ctx, Identifier{ClusterID: 1}, update)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]PlanChange{
+			{
+				Action:    PlanActionUpdate,
+				Resource:  PlanResourceCluster,
+				Name:      "cluster",
+				Attribute: "version",
+				Current:   "1.18",
+				Desired:   "1.19",
+			},
+		},
+		plan.Changes,
+	)
+
+	// Planning must not change the cluster status.
+	clusterStore.AssertNotCalled(t, "SetStatus")
+	clusterStore.AssertExpectations(t)
+	distributionService.AssertExpectations(t)
+	kubernetesVersions.AssertExpectations(t)
+}
+
+func TestService_PlanCreateNodePools(t *testing.T) {
+	t.Run("NodePoolAlreadyExists", func(t *testing.T) {
+		ctx := context.Background()
+
+		cluster := Cluster{
+			ID:           1,
+			Name:         "cluster",
+			Status:       Running,
+			Cloud:        "amazon",
+			Distribution: "eks",
+		}
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		rawNewNodePool := NewRawNodePool{"name": "pool0"}
+
+		validator := new(MockNodePoolValidator)
+		validator.On("ValidateNew", ctx, cluster, rawNewNodePool).Return(nil)
+
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolStore.On("NodePoolExists", ctx, cluster.ID, "pool0").Return(true, "pool0", nil)
+
+		service := NewService(clusterStore, nil, nil, nil, nodePoolStore, validator, nil, nil)
+
+		_, err := service.PlanCreateNodePools(ctx, 1, map[string]NewRawNodePool{"pool0": rawNewNodePool})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &NodePoolAlreadyExistsError{}))
+
+		clusterStore.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		validator.AssertExpectations(t)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ctx := context.Background()
+
+		cluster := Cluster{
+			ID:           1,
+			Name:         "cluster",
+			Status:       Running,
+			Cloud:        "amazon",
+			Distribution: "eks",
+		}
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		rawNewNodePool := NewRawNodePool{"name": "pool0"}
+		processedNodePool := NewRawNodePool{"name": "pool0", "labels": map[string]string{"key": "value"}}
+
+		validator := new(MockNodePoolValidator)
+		validator.On("ValidateNew", ctx, cluster, rawNewNodePool).Return(nil)
+
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolStore.On("NodePoolExists", ctx, cluster.ID, "pool0").Return(false, "", nil)
+
+		processor := new(MockNodePoolProcessor)
+		processor.On("ProcessNew", ctx, cluster, rawNewNodePool).Return(processedNodePool, nil)
+
+		expectedPlan := Plan{
+			Changes: []PlanChange{
+				{
+					Action:   PlanActionCreate,
+					Resource: PlanResourceNodePool,
+					Name:     "pool0",
+				},
+			},
+		}
+
+		distributionService := new(MockService)
+		distributionService.On("PlanCreateNodePools", ctx, cluster.ID, map[string]NewRawNodePool{"pool0": processedNodePool}).Return(expectedPlan, nil)
+
+		service := NewService(
+			clusterStore,
+			nil,
+			nil,
+			map[string]Service{"eks": distributionService},
+			nodePoolStore,
+			validator,
+			processor,
+			nil,
+		)
+
+		rawNodePools := map[string]NewRawNodePool{"pool0": rawNewNodePool}
+
+		plan, err := service.PlanCreateNodePools(ctx, 1, rawNodePools)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedPlan, plan)
+		assert.Equal(t, map[string]NewRawNodePool{"pool0": rawNewNodePool}, rawNodePools, "the request must be left intact")
+
+		clusterStore.AssertNotCalled(t, "SetStatus")
+		clusterStore.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		validator.AssertExpectations(t)
+		processor.AssertExpectations(t)
+		distributionService.AssertExpectations(t)
+	})
+}
+
+func TestService_PlanUpdateNodePool(t *testing.T) {
+	t.Run("NodePoolNotFound", func(t *testing.T) {
+		ctx := context.Background()
+
+		cluster := Cluster{
+			ID:           1,
+			Name:         "cluster",
+			Status:       Running,
+			Cloud:        "amazon",
+			Distribution: "eks",
+		}
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolStore.On("NodePoolExists", ctx, cluster.ID, "pool0").Return(false, "", nil)
+
+		service := NewService(
+			clusterStore,
+			nil,
+			nil,
+			map[string]Service{"eks": new(MockService)},
+			nodePoolStore,
+			nil,
+			nil,
+			nil,
+		)
+
+		_, err := service.PlanUpdateNodePool(ctx, 1, "pool0", RawNodePoolUpdate{"image": "ami-xyz"})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &NodePoolNotFoundError{}))
+
+		clusterStore.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+	})
+}
@@ -462,6 +462,69 @@ func (_m *MockService) ListNodePools(ctx context.Context, clusterID uint) (nodeP
 	return r0, r1
 }
 
+// PlanCreateNodePools provides a mock function.
+func (_m *MockService) PlanCreateNodePools(ctx context.Context, clusterID uint, rawNodePools map[string]NewRawNodePool) (plan Plan, err error) {
+	ret := _m.Called(ctx, clusterID, rawNodePools)
+
+	var r0 Plan
+	if rf, ok := ret.Get(0).(func(context.Context, uint, map[string]NewRawNodePool) Plan); ok {
+		r0 = rf(ctx, clusterID, rawNodePools)
+	} else {
+		r0 = ret.Get(0).(Plan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, map[string]NewRawNodePool) error); ok {
+		r1 = rf(ctx, clusterID, rawNodePools)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanUpdateCluster provides a mock function.
+func (_m *MockService) PlanUpdateCluster(ctx context.Context, clusterIdentifier Identifier, clusterUpdate ClusterUpdate) (plan Plan, err error) {
+	ret := _m.Called(ctx, clusterIdentifier, clusterUpdate)
+
+	var r0 Plan
+	if rf, ok := ret.Get(0).(func(context.Context, Identifier, ClusterUpdate) Plan); ok {
+		r0 = rf(ctx, clusterIdentifier, clusterUpdate)
+	} else {
+		r0 = ret.Get(0).(Plan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Identifier, ClusterUpdate) error); ok {
+		r1 = rf(ctx, clusterIdentifier, clusterUpdate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanUpdateNodePool provides a mock function.
+func (_m *MockService) PlanUpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate RawNodePoolUpdate) (plan Plan, err error) {
+	ret := _m.Called(ctx, clusterID, nodePoolName, rawNodePoolUpdate)
+
+	var r0 Plan
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, RawNodePoolUpdate) Plan); ok {
+		r0 = rf(ctx, clusterID, nodePoolName, rawNodePoolUpdate)
+	} else {
+		r0 = ret.Get(0).(Plan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, RawNodePoolUpdate) error); ok {
+		r1 = rf(ctx, clusterID, nodePoolName, rawNodePoolUpdate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateCluster provides a mock function.
 func (_m *MockService) UpdateCluster(ctx context.Context, clusterIdentifier Identifier, clusterUpdate ClusterUpdate) (err error) {
 	ret := _m.Called(ctx, clusterIdentifier, clusterUpdate)
@@ -10,6 +10,32 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// MockAccessPolicy is an autogenerated mock for the AccessPolicy type.
+type MockAccessPolicy struct {
+	mock.Mock
+}
+
+// CanAccessCluster provides a mock function.
+func (_m *MockAccessPolicy) CanAccessCluster(ctx context.Context, organizationID uint, clusterID uint, clusterName string, clusterTags map[string]string) (_result_0 bool, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, clusterID, clusterName, clusterTags)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, map[string]string) bool); ok {
+		r0 = rf(ctx, organizationID, clusterID, clusterName, clusterTags)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string, map[string]string) error); ok {
+		r1 = rf(ctx, organizationID, clusterID, clusterName, clusterTags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MockNodePoolLabelSource is an autogenerated mock for the NodePoolLabelSource type.
 type MockNodePoolLabelSource struct {
 	mock.Mock
@@ -188,3 +214,29 @@ func (_m *MockNodePoolProcessor) ProcessNew(ctx context.Context, cluster Cluster
 
 	return r0, r1
 }
+
+// MockKubernetesVersionGetter is an autogenerated mock for the KubernetesVersionGetter type.
+type MockKubernetesVersionGetter struct {
+	mock.Mock
+}
+
+// GetKubernetesVersion provides a mock function.
+func (_m *MockKubernetesVersionGetter) GetKubernetesVersion(ctx context.Context, clusterID uint) (_result_0 string, _result_1 error) {
+	ret := _m.Called(ctx, clusterID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, uint) string); ok {
+		r0 = rf(ctx, clusterID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
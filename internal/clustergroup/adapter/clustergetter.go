@@ -19,18 +19,21 @@ import (
 
 	"github.com/pkg/errors"
 
+	intCluster "github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
 	"github.com/banzaicloud/pipeline/src/cluster"
 )
 
 type clusterGetter struct {
 	clusterManager *cluster.Manager
+	clusterStore   intCluster.Store
 }
 
 // New creates a new ClusterGetter
-func NewClusterGetter(manager *cluster.Manager) api.ClusterGetter {
+func NewClusterGetter(manager *cluster.Manager, store intCluster.Store) api.ClusterGetter {
 	return &clusterGetter{
 		clusterManager: manager,
+		clusterStore:   store,
 	}
 }
 
@@ -75,3 +78,32 @@ func (m *clusterGetter) GetClusterByIDOnly(ctx context.Context, clusterID uint)
 
 	return nil, errors.New("could not assert to Cluster")
 }
+
+// GetClusters returns every cluster instance of an organization.
+func (m *clusterGetter) GetClusters(ctx context.Context, organizationID uint) ([]api.Cluster, error) {
+	clusters, err := m.clusterManager.GetClusters(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.Cluster, 0, len(clusters))
+	for _, c := range clusters {
+		cluster, ok := c.(api.Cluster)
+		if !ok {
+			return nil, errors.New("could not assert to Cluster")
+		}
+		result = append(result, cluster)
+	}
+
+	return result, nil
+}
+
+// GetClusterTags returns the tags of a cluster by cluster ID.
+func (m *clusterGetter) GetClusterTags(ctx context.Context, clusterID uint) (map[string]string, error) {
+	c, err := m.clusterStore.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Tags, nil
+}
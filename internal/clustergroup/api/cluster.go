@@ -21,8 +21,10 @@ import (
 // Cluster
 type Cluster interface {
 	GetID() uint
+	GetOrganizationId() uint
 	GetCloud() string
 	GetDistribution() string
+	GetLocation() string
 	GetName() string
 	GetK8sConfig() ([]byte, error)
 	GetStatus() (*cluster.GetClusterStatusResponse, error)
@@ -20,8 +20,9 @@ import (
 
 // CreateRequest describes fields of a create cluster group request
 type CreateRequest struct {
-	Name    string `json:"name" yaml:"name" example:"cluster_group_name"`
-	Members []uint `json:"members" yaml:"members"`
+	Name     string          `json:"name" yaml:"name" example:"cluster_group_name"`
+	Members  []uint          `json:"members" yaml:"members"`
+	Selector *MemberSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
 }
 
 // Validate validates CreateRequest
@@ -30,10 +31,7 @@ func (g *CreateRequest) Validate() error {
 		return errors.New("cluster group name is empty")
 	}
 
-	if len(g.Members) == 0 {
-		return errors.New("there should be at least one cluster member")
-	}
-	return nil
+	return ValidateMembership(g.Members, g.Selector)
 }
 
 // CreateResponse describes fields of a create cluster group response
@@ -44,8 +42,9 @@ type CreateResponse struct {
 
 // UpdateRequest describes fields of a update cluster group request
 type UpdateRequest struct {
-	Name    string `json:"name" yaml:"name" example:"cluster_group_name"`
-	Members []uint `json:"members,omitempty" yaml:"members"`
+	Name     string          `json:"name" yaml:"name" example:"cluster_group_name"`
+	Members  []uint          `json:"members,omitempty" yaml:"members"`
+	Selector *MemberSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
 }
 
 // Validate validates UpdateRequest
@@ -54,10 +53,7 @@ func (g *UpdateRequest) Validate() error {
 		return errors.New("cluster group name is empty")
 	}
 
-	if len(g.Members) == 0 {
-		return errors.New("there should be at least one cluster member")
-	}
-	return nil
+	return ValidateMembership(g.Members, g.Selector)
 }
 
 // UpdateResponse describes fields of a update cluster group response
@@ -66,6 +62,58 @@ type UpdateResponse struct {
 	ResourceID uint   `json:"id"`
 }
 
+// MemberSelector describes the criteria clusters have to meet to join a cluster group automatically.
+// Empty fields are not taken into account.
+type MemberSelector struct {
+	Tags         map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Cloud        string            `json:"cloud,omitempty" yaml:"cloud,omitempty" example:"amazon"`
+	Distribution string            `json:"distribution,omitempty" yaml:"distribution,omitempty" example:"eks"`
+	Location     string            `json:"location,omitempty" yaml:"location,omitempty" example:"eu-west-1"`
+}
+
+// IsEmpty returns true if the selector defines no criteria at all
+func (s MemberSelector) IsEmpty() bool {
+	return len(s.Tags) == 0 && s.Cloud == "" && s.Distribution == "" && s.Location == ""
+}
+
+// Matches returns true if the cluster with the given tags meets every criteria of the selector
+func (s MemberSelector) Matches(cluster Cluster, tags map[string]string) bool {
+	if s.Cloud != "" && s.Cloud != cluster.GetCloud() {
+		return false
+	}
+	if s.Distribution != "" && s.Distribution != cluster.GetDistribution() {
+		return false
+	}
+	if s.Location != "" && s.Location != cluster.GetLocation() {
+		return false
+	}
+	for key, value := range s.Tags {
+		if tag, ok := tags[key]; !ok || tag != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateMembership validates that a cluster group is either defined by a static member list or by a member selector
+func ValidateMembership(members []uint, selector *MemberSelector) error {
+	if selector == nil {
+		if len(members) == 0 {
+			return errors.New("there should be at least one cluster member")
+		}
+		return nil
+	}
+
+	if len(members) > 0 {
+		return errors.New("members and selector are mutually exclusive")
+	}
+
+	if selector.IsEmpty() {
+		return errors.New("selector should define at least one criteria")
+	}
+	return nil
+}
+
 // Member
 type Member struct {
 	ID           uint   `json:"id" yaml:"id" example:"1001"`
@@ -83,6 +131,7 @@ type ClusterGroup struct {
 	OrganizationID  uint             `json:"organizationId" yaml:"organizationId"`
 	Members         []Member         `json:"members,omitempty" yaml:"members"`
 	EnabledFeatures []string         `json:"enabledFeatures,omitempty" yaml:"enabledFeatures"`
+	Selector        *MemberSelector  `json:"selector,omitempty" yaml:"selector,omitempty"`
 	Clusters        map[uint]Cluster `json:"-" yaml:"-"`
 }
 
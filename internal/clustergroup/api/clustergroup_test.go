@@ -0,0 +1,88 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+type testCluster struct {
+	cloud        string
+	distribution string
+	location     string
+}
+
+func (c testCluster) GetID() uint                   { return 1 }
+func (c testCluster) GetOrganizationId() uint       { return 1 }
+func (c testCluster) GetCloud() string              { return c.cloud }
+func (c testCluster) GetDistribution() string       { return c.distribution }
+func (c testCluster) GetLocation() string           { return c.location }
+func (c testCluster) GetName() string               { return "cluster" }
+func (c testCluster) GetK8sConfig() ([]byte, error) { return nil, nil }
+func (c testCluster) GetStatus() (*cluster.GetClusterStatusResponse, error) {
+	return nil, nil
+}
+func (c testCluster) IsReady() (bool, error) { return true, nil }
+
+func TestMemberSelector_Matches(t *testing.T) {
+	c := testCluster{cloud: "amazon", distribution: "eks", location: "eu-west-1"}
+	tags := map[string]string{"env": "prod", "team": "infra"}
+
+	tests := map[string]struct {
+		selector MemberSelector
+		matches  bool
+	}{
+		"cloud and distribution": {
+			selector: MemberSelector{Cloud: "amazon", Distribution: "eks"},
+			matches:  true,
+		},
+		"location mismatch": {
+			selector: MemberSelector{Location: "us-east-1"},
+			matches:  false,
+		},
+		"tags subset": {
+			selector: MemberSelector{Tags: map[string]string{"env": "prod"}},
+			matches:  true,
+		},
+		"tag value mismatch": {
+			selector: MemberSelector{Tags: map[string]string{"env": "dev"}},
+			matches:  false,
+		},
+		"missing tag": {
+			selector: MemberSelector{Cloud: "amazon", Tags: map[string]string{"region": "eu"}},
+			matches:  false,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.matches, test.selector.Matches(c, tags))
+		})
+	}
+}
+
+func TestValidateMembership(t *testing.T) {
+	assert.NoError(t, ValidateMembership([]uint{1}, nil))
+	assert.NoError(t, ValidateMembership(nil, &MemberSelector{Cloud: "google"}))
+	assert.Error(t, ValidateMembership(nil, nil))
+	assert.Error(t, ValidateMembership([]uint{1}, &MemberSelector{Cloud: "google"}))
+	assert.Error(t, ValidateMembership(nil, &MemberSelector{}))
+}
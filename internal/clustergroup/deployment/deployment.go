@@ -26,17 +26,18 @@ import (
 
 // ClusterGroupDeployment describes a Helm deployment to a Cluster Group
 type ClusterGroupDeployment struct {
-	ReleaseName    string                            `json:"releaseName" yaml:"releaseName"`
-	Name           string                            `json:"name" yaml:"name" binding:"required"`
-	Version        string                            `json:"version,omitempty" yaml:"version,omitempty"`
-	Package        []byte                            `json:"package,omitempty" yaml:"package,omitempty"`
-	ReUseValues    bool                              `json:"reuseValues" yaml:"reuseValues"`
-	Namespace      string                            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
-	DryRun         bool                              `json:"dryrun,omitempty" yaml:"dryrun,omitempty"`
-	Values         map[string]interface{}            `json:"values,omitempty" yaml:"values,omitempty"`
-	ValueOverrides map[string]map[string]interface{} `json:"valueOverrides,omitempty" yaml:"valueOverrides,omitempty"`
-	RollingMode    bool                              `json:"rollingMode,omitempty" yaml:"rollingMode,omitempty"`
-	Atomic         bool                              `json:"atomic,omitempty" yaml:"atomic,omitempty"`
+	ReleaseName     string                            `json:"releaseName" yaml:"releaseName"`
+	Name            string                            `json:"name" yaml:"name" binding:"required"`
+	Version         string                            `json:"version,omitempty" yaml:"version,omitempty"`
+	Package         []byte                            `json:"package,omitempty" yaml:"package,omitempty"`
+	ReUseValues     bool                              `json:"reuseValues" yaml:"reuseValues"`
+	Namespace       string                            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	DryRun          bool                              `json:"dryrun,omitempty" yaml:"dryrun,omitempty"`
+	Values          map[string]interface{}            `json:"values,omitempty" yaml:"values,omitempty"`
+	ValueOverrides  map[string]map[string]interface{} `json:"valueOverrides,omitempty" yaml:"valueOverrides,omitempty"`
+	RollingMode     bool                              `json:"rollingMode,omitempty" yaml:"rollingMode,omitempty"`
+	Atomic          bool                              `json:"atomic,omitempty" yaml:"atomic,omitempty"`
+	RolloutStrategy *RolloutStrategy                  `json:"rolloutStrategy,omitempty" yaml:"rolloutStrategy,omitempty"`
 }
 
 // DeploymentInfo describes the details of a helm deployment
@@ -54,6 +55,8 @@ type DeploymentInfo struct {
 	ValueOverrides       map[string]map[string]interface{} `json:"valueOverrides,omitempty" yaml:"valueOverrides,omitempty"`
 	TargetClusters       map[uint]bool                     `json:"-" yaml:"-"`
 	TargetClustersStatus []TargetClusterStatus             `json:"targetClusters"`
+	RolloutStrategy      *RolloutStrategy                  `json:"rolloutStrategy,omitempty"`
+	RolloutID            string                            `json:"rolloutId,omitempty"`
 }
 
 func (c *DeploymentInfo) GetValuesForCluster(clusterName string) ([]byte, error) {
@@ -79,6 +82,7 @@ func (c *DeploymentInfo) GetValuesForCluster(clusterName string) ([]byte, error)
 type CreateUpdateDeploymentResponse struct {
 	ReleaseName    string                `json:"releaseName"`
 	TargetClusters []TargetClusterStatus `json:"targetClusters"`
+	RolloutID      string                `json:"rolloutId,omitempty"`
 }
 
 // TargetClusterStatus describes a status of a deployment on a target cluster
@@ -0,0 +1,162 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploymentworkflow
+
+import (
+	"context"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+// RolloutManager executes the steps of a cluster group deployment rollout on the member clusters.
+type RolloutManager interface {
+	// GetClusterRevision returns the revision of the release on a member cluster, 0 if the release is not installed.
+	GetClusterRevision(ctx context.Context, orgID uint, clusterID uint, releaseName string, namespace string) (int32, error)
+
+	// RolloutToCluster installs or upgrades the deployment on a member cluster.
+	RolloutToCluster(ctx context.Context, orgID uint, clusterID uint, depInfo *deployment.DeploymentInfo) error
+
+	// CheckClusterHealth checks whether the release is deployed and its workloads are ready on a member cluster.
+	CheckClusterHealth(ctx context.Context, orgID uint, clusterID uint, releaseName string, namespace string) (deployment.ClusterHealth, error)
+
+	// RollbackCluster rolls the release back to the given revision on a member cluster (deletes it if the revision is 0).
+	RollbackCluster(ctx context.Context, orgID uint, clusterID uint, releaseName string, namespace string, revision int32) error
+}
+
+// ClusterActivityInput identifies a member cluster of a cluster group deployment rollout.
+type ClusterActivityInput struct {
+	OrganizationID uint
+	ClusterGroupID uint
+	ReleaseName    string
+	Namespace      string
+	ClusterID      uint
+}
+
+// GetClusterRevisionActivityName is the name of the activity returning the release revision on a member cluster.
+const GetClusterRevisionActivityName = "cluster-group-deployment-get-cluster-revision"
+
+// GetClusterRevisionActivity returns the release revision on a member cluster.
+type GetClusterRevisionActivity struct {
+	manager RolloutManager
+}
+
+// NewGetClusterRevisionActivity returns a new GetClusterRevisionActivity.
+func NewGetClusterRevisionActivity(manager RolloutManager) GetClusterRevisionActivity {
+	return GetClusterRevisionActivity{
+		manager: manager,
+	}
+}
+
+// Execute executes the activity.
+func (a GetClusterRevisionActivity) Execute(ctx context.Context, input ClusterActivityInput) (int32, error) {
+	return a.manager.GetClusterRevision(ctx, input.OrganizationID, input.ClusterID, input.ReleaseName, input.Namespace)
+}
+
+// Register registers the activity in the worker.
+func (a GetClusterRevisionActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: GetClusterRevisionActivityName})
+}
+
+// RolloutClusterActivityName is the name of the activity installing or upgrading the deployment on a member cluster.
+const RolloutClusterActivityName = "cluster-group-deployment-rollout-cluster"
+
+// RolloutClusterActivityInput holds the deployment rolled out to a member cluster.
+type RolloutClusterActivityInput struct {
+	ClusterActivityInput
+
+	Deployment deployment.DeploymentInfo
+}
+
+// RolloutClusterActivity installs or upgrades the deployment on a member cluster.
+type RolloutClusterActivity struct {
+	manager RolloutManager
+}
+
+// NewRolloutClusterActivity returns a new RolloutClusterActivity.
+func NewRolloutClusterActivity(manager RolloutManager) RolloutClusterActivity {
+	return RolloutClusterActivity{
+		manager: manager,
+	}
+}
+
+// Execute executes the activity.
+func (a RolloutClusterActivity) Execute(ctx context.Context, input RolloutClusterActivityInput) error {
+	return a.manager.RolloutToCluster(ctx, input.OrganizationID, input.ClusterID, &input.Deployment)
+}
+
+// Register registers the activity in the worker.
+func (a RolloutClusterActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: RolloutClusterActivityName})
+}
+
+// CheckClusterHealthActivityName is the name of the activity checking the health of the deployment on a member cluster.
+const CheckClusterHealthActivityName = "cluster-group-deployment-check-cluster-health"
+
+// CheckClusterHealthActivity checks the health of the deployment on a member cluster.
+type CheckClusterHealthActivity struct {
+	manager RolloutManager
+}
+
+// NewCheckClusterHealthActivity returns a new CheckClusterHealthActivity.
+func NewCheckClusterHealthActivity(manager RolloutManager) CheckClusterHealthActivity {
+	return CheckClusterHealthActivity{
+		manager: manager,
+	}
+}
+
+// Execute executes the activity.
+func (a CheckClusterHealthActivity) Execute(ctx context.Context, input ClusterActivityInput) (deployment.ClusterHealth, error) {
+	return a.manager.CheckClusterHealth(ctx, input.OrganizationID, input.ClusterID, input.ReleaseName, input.Namespace)
+}
+
+// Register registers the activity in the worker.
+func (a CheckClusterHealthActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: CheckClusterHealthActivityName})
+}
+
+// RollbackClusterActivityName is the name of the activity rolling the deployment back on a member cluster.
+const RollbackClusterActivityName = "cluster-group-deployment-rollback-cluster"
+
+// RollbackClusterActivityInput holds the parameters of the rollback of a member cluster.
+type RollbackClusterActivityInput struct {
+	ClusterActivityInput
+
+	Revision int32
+}
+
+// RollbackClusterActivity rolls the deployment back on a member cluster.
+type RollbackClusterActivity struct {
+	manager RolloutManager
+}
+
+// NewRollbackClusterActivity returns a new RollbackClusterActivity.
+func NewRollbackClusterActivity(manager RolloutManager) RollbackClusterActivity {
+	return RollbackClusterActivity{
+		manager: manager,
+	}
+}
+
+// Execute executes the activity.
+func (a RollbackClusterActivity) Execute(ctx context.Context, input RollbackClusterActivityInput) error {
+	return a.manager.RollbackCluster(ctx, input.OrganizationID, input.ClusterID, input.ReleaseName, input.Namespace, input.Revision)
+}
+
+// Register registers the activity in the worker.
+func (a RollbackClusterActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: RollbackClusterActivityName})
+}
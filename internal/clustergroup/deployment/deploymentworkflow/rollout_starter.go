@@ -0,0 +1,76 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploymentworkflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+)
+
+// rolloutTimeout limits the duration of a whole rollout including the health gates and the rollback.
+const rolloutTimeout = 24 * time.Hour
+
+// RolloutStarter starts cluster group deployment rollouts as workflows.
+type RolloutStarter struct {
+	workflowClient client.Client
+}
+
+// NewRolloutStarter returns a new RolloutStarter.
+func NewRolloutStarter(workflowClient client.Client) RolloutStarter {
+	return RolloutStarter{
+		workflowClient: workflowClient,
+	}
+}
+
+// StartRollout starts the rollout workflow and returns its ID, the ID of the process logged by the workflow as well.
+//
+// The workflow ID is unique for each deployment, so that a deployment is rolled out by a single workflow at a time.
+func (s RolloutStarter) StartRollout(ctx context.Context, rollout deployment.Rollout) (string, error) {
+	workflowID := getRolloutWorkflowID(rollout.ClusterGroupID, rollout.ReleaseName)
+
+	options := client.StartWorkflowOptions{
+		ID:                           workflowID,
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: rolloutTimeout,
+		WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+	}
+
+	execution, err := s.workflowClient.StartWorkflow(ctx, options, RolloutWorkflowName, rollout)
+	if err != nil {
+		var alreadyStartedErr *shared.WorkflowExecutionAlreadyStartedError
+		if errors.As(err, &alreadyStartedErr) {
+			return "", errors.WithStack(deployment.RolloutInProgressError{
+				ClusterGroupID: rollout.ClusterGroupID,
+				ReleaseName:    rollout.ReleaseName,
+				RolloutID:      workflowID,
+			})
+		}
+
+		return "", errors.WrapIfWithDetails(err, "failed to start rollout workflow", "releaseName", rollout.ReleaseName)
+	}
+
+	return execution.ID, nil
+}
+
+func getRolloutWorkflowID(clusterGroupID uint, releaseName string) string {
+	return fmt.Sprintf("%s-%d-%s", RolloutWorkflowName, clusterGroupID, releaseName)
+}
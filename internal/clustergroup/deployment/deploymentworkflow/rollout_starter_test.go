@@ -0,0 +1,73 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploymentworkflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/mocks"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+)
+
+func TestRolloutStarter_StartRollout(t *testing.T) {
+	rollout := deployment.Rollout{
+		OrganizationID: 1,
+		ClusterGroupID: 2,
+		ReleaseName:    "release",
+	}
+
+	workflowClient := &mocks.Client{}
+	workflowClient.On(
+		"StartWorkflow",
+		mock.Anything,
+		mock.MatchedBy(func(options client.StartWorkflowOptions) bool {
+			return options.ID == "cluster-group-deployment-rollout-2-release" &&
+				options.WorkflowIDReusePolicy == client.WorkflowIDReusePolicyAllowDuplicate
+		}),
+		RolloutWorkflowName,
+		rollout,
+	).Return(&workflow.Execution{ID: "cluster-group-deployment-rollout-2-release"}, nil).Once()
+
+	rolloutID, err := NewRolloutStarter(workflowClient).StartRollout(context.Background(), rollout)
+	require.NoError(t, err)
+
+	assert.Equal(t, "cluster-group-deployment-rollout-2-release", rolloutID)
+	workflowClient.AssertExpectations(t)
+}
+
+func TestRolloutStarter_StartRollout_InProgress(t *testing.T) {
+	rollout := deployment.Rollout{
+		OrganizationID: 1,
+		ClusterGroupID: 2,
+		ReleaseName:    "release",
+	}
+
+	workflowClient := &mocks.Client{}
+	workflowClient.On("StartWorkflow", mock.Anything, mock.Anything, RolloutWorkflowName, rollout).
+		Return(nil, &shared.WorkflowExecutionAlreadyStartedError{}).Once()
+
+	_, err := NewRolloutStarter(workflowClient).StartRollout(context.Background(), rollout)
+	require.Error(t, err)
+
+	assert.True(t, deployment.IsRolloutInProgressError(err))
+}
@@ -0,0 +1,255 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploymentworkflow
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+// RolloutWorkflowName is the name of the cluster group deployment rollout workflow.
+const RolloutWorkflowName = "cluster-group-deployment-rollout"
+
+// healthCheckInterval is the time waited between two health checks of the clusters of a wave.
+const healthCheckInterval = 15 * time.Second
+
+// RolloutWorkflow rolls a cluster group deployment out to the member clusters wave by wave.
+// Every wave has to become healthy before the next one is started,
+// the rollout halts at the first failure and optionally rolls back the clusters already touched.
+type RolloutWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewRolloutWorkflow returns a new RolloutWorkflow.
+func NewRolloutWorkflow(processLogger processlog.ProcessLogger) RolloutWorkflow {
+	return RolloutWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+// rolledOutCluster is a cluster touched by the rollout along with the release revision to roll back to.
+type rolledOutCluster struct {
+	target           deployment.RolloutTarget
+	previousRevision int32
+}
+
+// Execute runs the workflow.
+func (w RolloutWorkflow) Execute(ctx workflow.Context, input deployment.Rollout) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    30 * time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          15 * time.Second,
+			BackoffCoefficient:       2.0,
+			MaximumInterval:          5 * time.Minute,
+			MaximumAttempts:          3,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+		},
+	})
+
+	clusterGroupID := brn.New(input.OrganizationID, brn.ClusterGroupResourceType, fmt.Sprint(input.ClusterGroupID))
+
+	process := w.processLogger.StartProcess(ctx, clusterGroupID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+
+	var rolledOut []rolledOutCluster
+	for _, wave := range input.Waves {
+		err = w.rolloutWave(ctx, process, input, wave, &rolledOut)
+		if err != nil {
+			err = errors.WrapIff(err, "rollout halted at %s", wave.Name)
+			break
+		}
+	}
+
+	if err != nil && input.RollbackOnFailure {
+		rollbackCtx := ctx
+		if cadence.IsCanceledError(err) {
+			rollbackCtx, _ = workflow.NewDisconnectedContext(ctx)
+		}
+
+		if rollbackErr := w.rollback(rollbackCtx, process, input, rolledOut); rollbackErr != nil {
+			err = errors.Combine(err, rollbackErr)
+		}
+	}
+
+	return err
+}
+
+// rolloutWave installs or upgrades the deployment on the clusters of the wave respecting the parallelism limit
+// then waits for them to become healthy
+func (w RolloutWorkflow) rolloutWave(ctx workflow.Context, process processlog.Process, input deployment.Rollout, wave deployment.RolloutWave, rolledOut *[]rolledOutCluster) error {
+	var errs []error
+
+	selector := workflow.NewSelector(ctx)
+	running := 0
+
+	for _, target := range wave.Targets {
+		if input.MaxParallel > 0 && running >= input.MaxParallel {
+			selector.Select(ctx)
+			running--
+		}
+
+		// do not touch further clusters once a cluster of the wave failed
+		if len(errs) > 0 {
+			break
+		}
+
+		target := target
+		activityInput := ClusterActivityInput{
+			OrganizationID: input.OrganizationID,
+			ClusterGroupID: input.ClusterGroupID,
+			ReleaseName:    input.ReleaseName,
+			Namespace:      input.Deployment.Namespace,
+			ClusterID:      target.ClusterID,
+		}
+
+		var previousRevision int32
+		err := workflow.ExecuteActivity(ctx, GetClusterRevisionActivityName, activityInput).Get(ctx, &previousRevision)
+		if err != nil {
+			errs = append(errs, errors.WrapIff(err, "failed to get release revision on cluster %s", target.ClusterName))
+			break
+		}
+
+		// the cluster is rolled back even if the upgrade fails as it may have been changed partially
+		*rolledOut = append(*rolledOut, rolledOutCluster{
+			target:           target,
+			previousRevision: previousRevision,
+		})
+
+		processActivity := process.StartActivity(ctx, fmt.Sprintf("%s:%s", RolloutClusterActivityName, target.ClusterName))
+		future := workflow.ExecuteActivity(ctx, RolloutClusterActivityName, RolloutClusterActivityInput{
+			ClusterActivityInput: activityInput,
+			Deployment:           input.Deployment,
+		})
+		running++
+
+		selector.AddFuture(future, func(f workflow.Future) {
+			err := f.Get(ctx, nil)
+			processActivity.Finish(ctx, err)
+			if err != nil {
+				errs = append(errs, errors.WrapIff(err, "failed to roll out to cluster %s", target.ClusterName))
+			}
+		})
+	}
+
+	for ; running > 0; running-- {
+		selector.Select(ctx)
+	}
+
+	if len(errs) > 0 {
+		return errors.Combine(errs...)
+	}
+
+	processActivity := process.StartActivity(ctx, fmt.Sprintf("%s:%s", CheckClusterHealthActivityName, wave.Name))
+	err := w.waitForHealthyWave(ctx, input, wave)
+	processActivity.Finish(ctx, err)
+
+	return err
+}
+
+// waitForHealthyWave checks the health of the clusters of the wave periodically until all of them become healthy or the health check times out
+func (w RolloutWorkflow) waitForHealthyWave(ctx workflow.Context, input deployment.Rollout, wave deployment.RolloutWave) error {
+	deadline := workflow.Now(ctx).Add(input.HealthCheckTimeout)
+	pending := wave.Targets
+
+	for {
+		futures := make([]workflow.Future, 0, len(pending))
+		for _, target := range pending {
+			activityInput := ClusterActivityInput{
+				OrganizationID: input.OrganizationID,
+				ClusterGroupID: input.ClusterGroupID,
+				ReleaseName:    input.ReleaseName,
+				Namespace:      input.Deployment.Namespace,
+				ClusterID:      target.ClusterID,
+			}
+
+			futures = append(futures, workflow.ExecuteActivity(ctx, CheckClusterHealthActivityName, activityInput))
+		}
+
+		var unhealthy []deployment.RolloutTarget
+		var messages []string
+		for i, future := range futures {
+			var health deployment.ClusterHealth
+			if err := future.Get(ctx, &health); err != nil {
+				return errors.WrapIff(err, "failed to check health of cluster %s", pending[i].ClusterName)
+			}
+
+			if !health.Healthy {
+				unhealthy = append(unhealthy, pending[i])
+				messages = append(messages, fmt.Sprintf("%s: %s", pending[i].ClusterName, health.Message))
+			}
+		}
+
+		if len(unhealthy) == 0 {
+			return nil
+		}
+
+		if !workflow.Now(ctx).Before(deadline) {
+			return errors.Errorf("clusters did not become healthy in time: %s", strings.Join(messages, ", "))
+		}
+
+		if err := workflow.Sleep(ctx, healthCheckInterval); err != nil {
+			return err
+		}
+
+		pending = unhealthy
+	}
+}
+
+// rollback rolls the release back to the previous revision on the touched clusters in reverse order
+func (w RolloutWorkflow) rollback(ctx workflow.Context, process processlog.Process, input deployment.Rollout, rolledOut []rolledOutCluster) error {
+	var errs []error
+
+	for i := len(rolledOut) - 1; i >= 0; i-- {
+		cluster := rolledOut[i]
+		activityInput := RollbackClusterActivityInput{
+			ClusterActivityInput: ClusterActivityInput{
+				OrganizationID: input.OrganizationID,
+				ClusterGroupID: input.ClusterGroupID,
+				ReleaseName:    input.ReleaseName,
+				Namespace:      input.Deployment.Namespace,
+				ClusterID:      cluster.target.ClusterID,
+			},
+			Revision: cluster.previousRevision,
+		}
+
+		processActivity := process.StartActivity(ctx, fmt.Sprintf("%s:%s", RollbackClusterActivityName, cluster.target.ClusterName))
+		err := workflow.ExecuteActivity(ctx, RollbackClusterActivityName, activityInput).Get(ctx, nil)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			errs = append(errs, errors.WrapIff(err, "failed to roll back cluster %s", cluster.target.ClusterName))
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
+// Register registers the workflow in the worker.
+func (w RolloutWorkflow) Register(worker worker.Registry) {
+	worker.RegisterWorkflowWithOptions(w.Execute, workflow.RegisterOptions{Name: RolloutWorkflowName})
+}
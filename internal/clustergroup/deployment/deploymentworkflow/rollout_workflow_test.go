@@ -0,0 +1,171 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploymentworkflow
+
+import (
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+type noopProcessLogger struct{}
+
+func (noopProcessLogger) StartProcess(ctx workflow.Context, resourceID string) processlog.Process {
+	return noopProcess{}
+}
+
+type noopProcess struct{}
+
+func (noopProcess) Finish(ctx workflow.Context, err error) {}
+
+func (noopProcess) StartActivity(ctx workflow.Context, typ string) processlog.Activity {
+	return noopProcess{}
+}
+
+type RolloutWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestRolloutWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(RolloutWorkflowTestSuite))
+}
+
+func (s *RolloutWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	NewRolloutWorkflow(noopProcessLogger{}).Register(s.env)
+	NewGetClusterRevisionActivity(nil).Register(s.env)
+	NewRolloutClusterActivity(nil).Register(s.env)
+	NewCheckClusterHealthActivity(nil).Register(s.env)
+	NewRollbackClusterActivity(nil).Register(s.env)
+}
+
+func (s *RolloutWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func clusterInput(clusterID uint) ClusterActivityInput {
+	return ClusterActivityInput{
+		OrganizationID: 1,
+		ClusterGroupID: 2,
+		ReleaseName:    "release",
+		Namespace:      "default",
+		ClusterID:      clusterID,
+	}
+}
+
+func rolloutClusterInput(clusterID uint) RolloutClusterActivityInput {
+	return RolloutClusterActivityInput{
+		ClusterActivityInput: clusterInput(clusterID),
+		Deployment:           testDeployment(),
+	}
+}
+
+func testDeployment() deployment.DeploymentInfo {
+	return deployment.DeploymentInfo{
+		ReleaseName:  "release",
+		Chart:        "stable/app",
+		ChartVersion: "1.0.0",
+		Namespace:    "default",
+	}
+}
+
+func testRollout() deployment.Rollout {
+	return deployment.Rollout{
+		OrganizationID: 1,
+		ClusterGroupID: 2,
+		ReleaseName:    "release",
+		Deployment:     testDeployment(),
+		Waves: []deployment.RolloutWave{
+			{
+				Name:    "canary",
+				Targets: []deployment.RolloutTarget{{ClusterID: 10, ClusterName: "canary"}},
+			},
+			{
+				Name: "wave-1",
+				Targets: []deployment.RolloutTarget{
+					{ClusterID: 11, ClusterName: "first"},
+					{ClusterID: 12, ClusterName: "second"},
+				},
+			},
+		},
+		MaxParallel:        1,
+		HealthCheckTimeout: time.Minute,
+		RollbackOnFailure:  true,
+	}
+}
+
+func (s *RolloutWorkflowTestSuite) Test_Success() {
+	for _, clusterID := range []uint{10, 11, 12} {
+		s.env.OnActivity(GetClusterRevisionActivityName, mock.Anything, clusterInput(clusterID)).Return(int32(1), nil).Once()
+		s.env.OnActivity(RolloutClusterActivityName, mock.Anything, rolloutClusterInput(clusterID)).Return(nil).Once()
+		s.env.OnActivity(CheckClusterHealthActivityName, mock.Anything, clusterInput(clusterID)).Return(deployment.ClusterHealth{Healthy: true}, nil).Once()
+	}
+
+	s.env.ExecuteWorkflow(RolloutWorkflowName, testRollout())
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *RolloutWorkflowTestSuite) Test_UnhealthyCanary() {
+	s.env.OnActivity(GetClusterRevisionActivityName, mock.Anything, clusterInput(10)).Return(int32(3), nil).Once()
+	s.env.OnActivity(RolloutClusterActivityName, mock.Anything, rolloutClusterInput(10)).Return(nil).Once()
+	s.env.OnActivity(CheckClusterHealthActivityName, mock.Anything, clusterInput(10)).Return(deployment.ClusterHealth{Message: "workloads not ready"}, nil)
+	s.env.OnActivity(RollbackClusterActivityName, mock.Anything, RollbackClusterActivityInput{
+		ClusterActivityInput: clusterInput(10),
+		Revision:             3,
+	}).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(RolloutWorkflowName, testRollout())
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+	s.Contains(s.env.GetWorkflowError().Error(), "rollout halted at canary")
+}
+
+func (s *RolloutWorkflowTestSuite) Test_FailedCluster() {
+	s.env.OnActivity(GetClusterRevisionActivityName, mock.Anything, clusterInput(10)).Return(int32(3), nil).Once()
+	s.env.OnActivity(RolloutClusterActivityName, mock.Anything, rolloutClusterInput(10)).Return(nil).Once()
+	s.env.OnActivity(CheckClusterHealthActivityName, mock.Anything, clusterInput(10)).Return(deployment.ClusterHealth{Healthy: true}, nil).Once()
+	s.env.OnActivity(GetClusterRevisionActivityName, mock.Anything, clusterInput(11)).Return(int32(0), nil).Once()
+	s.env.OnActivity(RolloutClusterActivityName, mock.Anything, rolloutClusterInput(11)).Return(errors.New("chart not found")).Once()
+	s.env.OnActivity(RollbackClusterActivityName, mock.Anything, RollbackClusterActivityInput{
+		ClusterActivityInput: clusterInput(11),
+		Revision:             0,
+	}).Return(nil).Once()
+	s.env.OnActivity(RollbackClusterActivityName, mock.Anything, RollbackClusterActivityInput{
+		ClusterActivityInput: clusterInput(10),
+		Revision:             3,
+	}).Return(nil).Once()
+
+	rollout := testRollout()
+	s.env.ExecuteWorkflow(RolloutWorkflowName, rollout)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+	s.Contains(s.env.GetWorkflowError().Error(), "rollout halted at wave-1")
+}
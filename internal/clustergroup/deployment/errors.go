@@ -90,3 +90,48 @@ func IsDeploymentAlreadyExistsError(err error) bool {
 
 	return ok
 }
+
+// RolloutInProgressError is returned when a deployment is rolled out while a previous rollout of it is still running
+type RolloutInProgressError struct {
+	ClusterGroupID uint
+	ReleaseName    string
+	RolloutID      string
+}
+
+func (e RolloutInProgressError) Error() string {
+	return "a rollout of the deployment is already in progress"
+}
+
+func (e RolloutInProgressError) Context() []interface{} {
+	return []interface{}{
+		"clusterGroupID", e.ClusterGroupID,
+		"releaseName", e.ReleaseName,
+		"rolloutID", e.RolloutID,
+	}
+}
+
+// IsRolloutInProgressError returns true if the passed in error designates a rollout in progress error
+func IsRolloutInProgressError(err error) bool {
+	return errors.As(err, &RolloutInProgressError{})
+}
+
+type invalidRolloutStrategyError struct {
+	reason string
+}
+
+func (e *invalidRolloutStrategyError) Error() string {
+	return "invalid rollout strategy: " + e.reason
+}
+
+func (e *invalidRolloutStrategyError) Context() []interface{} {
+	return []interface{}{
+		"reason", e.reason,
+	}
+}
+
+// IsInvalidRolloutStrategyError returns true if the passed in error designates an invalid rollout strategy error
+func IsInvalidRolloutStrategyError(err error) bool {
+	_, ok := errors.Cause(err).(*invalidRolloutStrategyError)
+
+	return ok
+}
@@ -0,0 +1,146 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"emperror.dev/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+)
+
+const releaseStatusDeployed = "deployed"
+
+// workloadChecker checks whether the pods of the workloads rendered by a release are ready
+type workloadChecker struct {
+	clientFactory func(kubeConfig []byte) (kubernetes.Interface, error)
+}
+
+func newWorkloadChecker() workloadChecker {
+	return workloadChecker{
+		clientFactory: func(kubeConfig []byte) (kubernetes.Interface, error) {
+			return k8sclient.NewClientFromKubeConfig(kubeConfig)
+		},
+	}
+}
+
+// UnreadyWorkloads returns the deployments, stateful sets and daemon sets of the manifest not having all of their pods updated and ready
+func (c workloadChecker) UnreadyWorkloads(ctx context.Context, kubeConfig []byte, namespace string, manifest string) ([]string, error) {
+	client, err := c.clientFactory(kubeConfig)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create kubernetes client")
+	}
+
+	unready := make([]string, 0)
+
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	for {
+		var object unstructured.Unstructured
+		if err := decoder.Decode(&object.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, errors.WrapIf(err, "failed to parse release manifest")
+		}
+		if len(object.Object) == 0 {
+			continue
+		}
+
+		objectNamespace := object.GetNamespace()
+		if objectNamespace == "" {
+			objectNamespace = namespace
+		}
+
+		ready, err := c.isReady(ctx, client, object.GetKind(), objectNamespace, object.GetName())
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to check workload", "kind", object.GetKind(), "name", object.GetName())
+		}
+		if !ready {
+			unready = append(unready, fmt.Sprintf("%s/%s", object.GetKind(), object.GetName()))
+		}
+	}
+
+	return unready, nil
+}
+
+func (c workloadChecker) isReady(ctx context.Context, client kubernetes.Interface, kind string, namespace string, name string) (bool, error) {
+	switch kind {
+	case "Deployment":
+		deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return isDeploymentReady(deployment), nil
+
+	case "StatefulSet":
+		statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return isStatefulSetReady(statefulSet), nil
+
+	case "DaemonSet":
+		daemonSet, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return isDaemonSetReady(daemonSet), nil
+	}
+
+	return true, nil
+}
+
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
+func isDeploymentReady(deployment *appsv1.Deployment) bool {
+	replicas := desiredReplicas(deployment.Spec.Replicas)
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.ReadyReplicas >= replicas
+}
+
+func isStatefulSetReady(statefulSet *appsv1.StatefulSet) bool {
+	replicas := desiredReplicas(statefulSet.Spec.Replicas)
+
+	return statefulSet.Status.ObservedGeneration >= statefulSet.Generation &&
+		statefulSet.Status.UpdatedReplicas >= replicas &&
+		statefulSet.Status.ReadyReplicas >= replicas
+}
+
+func isDaemonSetReady(daemonSet *appsv1.DaemonSet) bool {
+	return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+		daemonSet.Status.UpdatedNumberScheduled >= daemonSet.Status.DesiredNumberScheduled &&
+		daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled
+}
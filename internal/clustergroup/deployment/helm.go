@@ -39,6 +39,7 @@ type HelmService interface {
 	) error
 	GetRelease(c helm.ClusterDataProvider, releaseName, namespace string) (helm.Release, error)
 	DeleteRelease(c helm.ClusterDataProvider, releaseName, namespace string) error
+	RollbackRelease(orgID uint, c helm.ClusterDataProvider, releaseName, namespace string, revision int32) error
 }
 
 type helm3Service struct {
@@ -61,6 +62,12 @@ func (h *helm3Service) DeleteRelease(c helm.ClusterDataProvider, releaseName, na
 	return h.releaser.Delete(c, releaseName, namespace)
 }
 
+func (h *helm3Service) RollbackRelease(orgID uint, c helm.ClusterDataProvider, releaseName, namespace string, revision int32) error {
+	return h.facade.RollbackRelease(context.TODO(), orgID, c.GetID(), releaseName, revision, helm.Options{
+		Namespace: namespace,
+	})
+}
+
 func (h *helm3Service) InstallOrUpgrade(orgID uint, c helm.ClusterDataProvider, release helm.Release, opts helm.Options) error {
 	return h.releaser.InstallOrUpgrade(orgID, c, release, opts)
 }
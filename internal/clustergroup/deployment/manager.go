@@ -33,11 +33,13 @@ import (
 
 // CGDeploymentManager
 type CGDeploymentManager struct {
-	clusterGetter api.ClusterGetter
-	repository    *CGDeploymentRepository
-	logger        logrus.FieldLogger
-	errorHandler  emperror.Handler
-	helmService   HelmService
+	clusterGetter   api.ClusterGetter
+	repository      *CGDeploymentRepository
+	logger          logrus.FieldLogger
+	errorHandler    emperror.Handler
+	helmService     HelmService
+	rolloutStarter  RolloutStarter
+	workloadChecker workloadChecker
 }
 
 const (
@@ -62,16 +64,19 @@ func NewCGDeploymentManager(
 	logger logrus.FieldLogger,
 	errorHandler emperror.Handler,
 	helmService HelmService,
+	rolloutStarter RolloutStarter,
 ) *CGDeploymentManager {
 	return &CGDeploymentManager{
 		repository: &CGDeploymentRepository{
 			db:     db,
 			logger: logger,
 		},
-		clusterGetter: clusterGetter,
-		logger:        logger,
-		errorHandler:  errorHandler,
-		helmService:   helmService,
+		clusterGetter:   clusterGetter,
+		logger:          logger,
+		errorHandler:    errorHandler,
+		helmService:     helmService,
+		rolloutStarter:  rolloutStarter,
+		workloadChecker: newWorkloadChecker(),
 	}
 }
 
@@ -294,6 +299,13 @@ func (m CGDeploymentManager) createDeploymentModel(clusterGroup *api.ClusterGrou
 		return nil, err
 	}
 	deploymentModel.Values = values
+	if cgDeployment.RolloutStrategy != nil {
+		rolloutStrategy, err := json.Marshal(cgDeployment.RolloutStrategy)
+		if err != nil {
+			return nil, err
+		}
+		deploymentModel.RolloutStrategy = rolloutStrategy
+	}
 	deploymentModel.TargetClusters = make([]*TargetCluster, 0)
 	for _, cluster := range clusterGroup.Clusters {
 		targetCluster := &TargetCluster{
@@ -341,6 +353,16 @@ func (m CGDeploymentManager) updateDeploymentModel(clusterGroup *api.ClusterGrou
 	}
 	deploymentModel.Values = values
 
+	// the rollout strategy of the request replaces the current one
+	deploymentModel.RolloutStrategy = nil
+	if cgDeployment.RolloutStrategy != nil {
+		rolloutStrategy, err := json.Marshal(cgDeployment.RolloutStrategy)
+		if err != nil {
+			return err
+		}
+		deploymentModel.RolloutStrategy = rolloutStrategy
+	}
+
 	existingTargetsMap := make(map[uint]*TargetCluster, 0)
 	for _, target := range deploymentModel.TargetClusters {
 		existingTargetsMap[target.ClusterID] = target
@@ -390,10 +412,19 @@ func (m CGDeploymentManager) getDeploymentFromModel(deploymentModel *ClusterGrou
 		ChartVersion: deploymentModel.DeploymentVersion,
 		Namespace:    deploymentModel.Namespace,
 		CreatedAt:    deploymentModel.CreatedAt,
+		RolloutID:    deploymentModel.RolloutID,
 	}
 	if deploymentModel.UpdatedAt != nil {
 		deployment.UpdatedAt = *deploymentModel.UpdatedAt
 	}
+	if len(deploymentModel.RolloutStrategy) > 0 {
+		var rolloutStrategy RolloutStrategy
+		err := json.Unmarshal(deploymentModel.RolloutStrategy, &rolloutStrategy)
+		if err != nil {
+			return nil, err
+		}
+		deployment.RolloutStrategy = &rolloutStrategy
+	}
 	values := make(map[string]interface{})
 	err := json.Unmarshal(deploymentModel.Values, &values)
 	if err != nil {
@@ -573,7 +604,10 @@ func (m CGDeploymentManager) SyncDeployment(clusterGroup *api.ClusterGroup, orgI
 	if err != nil {
 		return nil, errors.WrapIf(err, "error getting chart description")
 	}
-	targetClustersStatus := m.upgradeOrInstallDeploymentToTargetClusters(orgId, clusterGroup, depInfo, requestedChart, false)
+	targetClustersStatus, _, err := m.saveAndRolloutDeployment(orgId, clusterGroup, deploymentModel, requestedChart, false)
+	if err != nil {
+		return nil, err
+	}
 	response = append(response, targetClustersStatus...)
 
 	targetClustersStatus, err = m.deleteDeploymentFromTargetClusters(clusterGroup, releaseName, deploymentModel, false, false)
@@ -679,13 +713,16 @@ func (m CGDeploymentManager) upgradeOrInstallDeploymentToTargetClusters(orgID ui
 	return targetClusterStatus
 }
 
-func (m CGDeploymentManager) CreateDeployment(clusterGroup *api.ClusterGroup, orgId uint, orgName string, cgDeployment *ClusterGroupDeployment) ([]TargetClusterStatus, error) {
+func (m CGDeploymentManager) CreateDeployment(clusterGroup *api.ClusterGroup, orgId uint, orgName string, cgDeployment *ClusterGroupDeployment) (*CreateUpdateDeploymentResponse, error) {
 	if len(cgDeployment.ReleaseName) == 0 {
 		return nil, errors.Errorf("release name is mandatory")
 	}
 	if len(cgDeployment.Version) == 0 {
 		return nil, errors.New("chart version must be set explicitly")
 	}
+	if err := m.validateRolloutStrategy(clusterGroup, cgDeployment.RolloutStrategy); err != nil {
+		return nil, err
+	}
 
 	deploymentModel, err := m.repository.FindByName(clusterGroup.Id, cgDeployment.ReleaseName)
 	if err != nil && !IsDeploymentNotFoundError(err) {
@@ -708,30 +745,30 @@ func (m CGDeploymentManager) CreateDeployment(clusterGroup *api.ClusterGroup, or
 		cgDeployment.Namespace = helm.DefaultNamespace
 	}
 
-	// save deployment
 	deploymentModel, err = m.createDeploymentModel(clusterGroup, orgName, cgDeployment, requestedChart)
 	if err != nil {
 		return nil, errors.WrapIf(err, "Error creating deployment model")
 	}
-	if !cgDeployment.DryRun {
-		err = m.repository.Save(deploymentModel)
-		if err != nil {
-			return nil, errors.WrapIf(err, "Error saving deployment model")
-		}
-	}
 
-	depInfo, err := m.getDeploymentFromModel(deploymentModel)
+	targetClusterStatus, rolloutID, err := m.saveAndRolloutDeployment(orgId, clusterGroup, deploymentModel, requestedChart, cgDeployment.DryRun)
 	if err != nil {
 		return nil, err
 	}
 
-	targetClusterStatus := m.upgradeOrInstallDeploymentToTargetClusters(orgId, clusterGroup, depInfo, requestedChart, cgDeployment.DryRun)
-	return targetClusterStatus, nil
+	return &CreateUpdateDeploymentResponse{
+		ReleaseName:    cgDeployment.ReleaseName,
+		TargetClusters: targetClusterStatus,
+		RolloutID:      rolloutID,
+	}, nil
 }
 
 // UpdateDeployment upgrades deployment using provided values or using already provided values if ReUseValues = true.
 // The deployment is installed on a member cluster in case it's was not installed previously.
-func (m CGDeploymentManager) UpdateDeployment(clusterGroup *api.ClusterGroup, orgId uint, cgDeployment *ClusterGroupDeployment) ([]TargetClusterStatus, error) {
+func (m CGDeploymentManager) UpdateDeployment(clusterGroup *api.ClusterGroup, orgId uint, cgDeployment *ClusterGroupDeployment) (*CreateUpdateDeploymentResponse, error) {
+	if err := m.validateRolloutStrategy(clusterGroup, cgDeployment.RolloutStrategy); err != nil {
+		return nil, err
+	}
+
 	requestedChart, err := m.helmService.GetChartMeta(orgId, cgDeployment.Name, cgDeployment.Version)
 	if err != nil {
 		return nil, errors.WrapIf(err, "error getting chart description")
@@ -753,20 +790,17 @@ func (m CGDeploymentManager) UpdateDeployment(clusterGroup *api.ClusterGroup, or
 	if err != nil {
 		return nil, errors.WrapIf(err, "Error updating deployment model")
 	}
-	if !cgDeployment.DryRun {
-		err = m.repository.Save(deploymentModel)
-		if err != nil {
-			return nil, errors.WrapIf(err, "Error saving deployment model")
-		}
-	}
 
-	depInfo, err := m.getDeploymentFromModel(deploymentModel)
+	targetClusterStatus, rolloutID, err := m.saveAndRolloutDeployment(orgId, clusterGroup, deploymentModel, requestedChart, cgDeployment.DryRun)
 	if err != nil {
 		return nil, err
 	}
 
-	targetClusterStatus := m.upgradeOrInstallDeploymentToTargetClusters(orgId, clusterGroup, depInfo, requestedChart, cgDeployment.DryRun)
-	return targetClusterStatus, nil
+	return &CreateUpdateDeploymentResponse{
+		ReleaseName:    cgDeployment.ReleaseName,
+		TargetClusters: targetClusterStatus,
+		RolloutID:      rolloutID,
+	}, nil
 }
 
 func (m *CGDeploymentManager) IsReleaseNameAvailable(clusterGroup *api.ClusterGroup, releaseName string, namespace string) bool {
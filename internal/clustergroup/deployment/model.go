@@ -52,7 +52,9 @@ type ClusterGroupDeploymentModel struct {
 	ChartName             string
 	Namespace             string
 	OrganizationName      string
-	Values                []byte           `sql:"type:text;"`
+	Values                []byte `sql:"type:text;"`
+	RolloutStrategy       []byte `sql:"type:text;"`
+	RolloutID             string
 	TargetClusters        []*TargetCluster `gorm:"foreignkey:ClusterGroupDeploymentID"`
 }
 
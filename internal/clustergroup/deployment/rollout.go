@@ -0,0 +1,357 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+)
+
+// RolloutPendingStatus is the status of the target clusters of a deployment while a rollout is in progress
+const RolloutPendingStatus = "ROLLOUT PENDING"
+
+// DefaultRolloutHealthCheckTimeout is the time in seconds the clusters of a wave have to become healthy by default
+const DefaultRolloutHealthCheckTimeout = 300
+
+// RolloutStrategy describes how a cluster group deployment is rolled out to the member clusters
+type RolloutStrategy struct {
+	// Canary lists the clusters receiving the release first, the rest of the clusters are touched only if the canary clusters become healthy
+	Canary []string `json:"canary,omitempty" yaml:"canary,omitempty"`
+	// Waves lists ordered groups of clusters rolled out one after the other, clusters not listed anywhere are rolled out in a final wave
+	Waves [][]string `json:"waves,omitempty" yaml:"waves,omitempty"`
+	// MaxParallel limits the number of clusters upgraded at the same time, 0 means no limit
+	MaxParallel int `json:"maxParallel,omitempty" yaml:"maxParallel,omitempty"`
+	// HealthCheckTimeout is the time in seconds the clusters of a wave have to become healthy
+	HealthCheckTimeout int `json:"healthCheckTimeout,omitempty" yaml:"healthCheckTimeout,omitempty"`
+	// RollbackOnFailure rolls the release back on the already upgraded clusters if the rollout halts
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty" yaml:"rollbackOnFailure,omitempty"`
+}
+
+// RolloutTarget describes a member cluster a deployment is rolled out to
+type RolloutTarget struct {
+	ClusterID   uint
+	ClusterName string
+}
+
+// RolloutWave describes a group of clusters rolled out together
+type RolloutWave struct {
+	Name    string
+	Targets []RolloutTarget
+}
+
+// Rollout describes the rollout of a cluster group deployment
+type Rollout struct {
+	OrganizationID uint
+	ClusterGroupID uint
+	ReleaseName    string
+	// Deployment is the deployment rolled out, later changes of the stored deployment do not affect the rollout
+	Deployment         DeploymentInfo
+	Waves              []RolloutWave
+	MaxParallel        int
+	HealthCheckTimeout time.Duration
+	RollbackOnFailure  bool
+}
+
+// RolloutStarter starts the rollout of a cluster group deployment
+type RolloutStarter interface {
+	// StartRollout starts the rollout and returns its identifier,
+	// a RolloutInProgressError is returned if a rollout of the same deployment is still running
+	StartRollout(ctx context.Context, rollout Rollout) (string, error)
+}
+
+// ClusterHealth describes the health of a deployment on a member cluster
+type ClusterHealth struct {
+	Healthy bool
+	Message string
+}
+
+// PlanWaves distributes the targets between the waves of the strategy:
+// the canary clusters come first followed by the waves in order and a final wave of the remaining targets.
+// Targets not listed in any wave are rolled out in the order of their name.
+func (s RolloutStrategy) PlanWaves(targets []RolloutTarget) ([]RolloutWave, error) {
+	if s.MaxParallel < 0 {
+		return nil, errors.WithStack(&invalidRolloutStrategyError{reason: "maxParallel must not be negative"})
+	}
+	if s.HealthCheckTimeout < 0 {
+		return nil, errors.WithStack(&invalidRolloutStrategyError{reason: "healthCheckTimeout must not be negative"})
+	}
+
+	targetsByName := make(map[string]RolloutTarget, len(targets))
+	for _, target := range targets {
+		targetsByName[target.ClusterName] = target
+	}
+
+	planned := make(map[string]bool, len(targets))
+	planWave := func(name string, clusterNames []string) (RolloutWave, error) {
+		if len(clusterNames) == 0 {
+			return RolloutWave{}, errors.WithStack(&invalidRolloutStrategyError{reason: fmt.Sprintf("%s is empty", name)})
+		}
+
+		wave := RolloutWave{Name: name}
+		for _, clusterName := range clusterNames {
+			target, ok := targetsByName[clusterName]
+			if !ok {
+				return RolloutWave{}, errors.WithStack(&invalidRolloutStrategyError{
+					reason: fmt.Sprintf("cluster %q of %s is not a target of the deployment", clusterName, name),
+				})
+			}
+			if planned[clusterName] {
+				return RolloutWave{}, errors.WithStack(&invalidRolloutStrategyError{
+					reason: fmt.Sprintf("cluster %q is listed more than once", clusterName),
+				})
+			}
+			planned[clusterName] = true
+			wave.Targets = append(wave.Targets, target)
+		}
+
+		return wave, nil
+	}
+
+	waves := make([]RolloutWave, 0, len(s.Waves)+2)
+	if len(s.Canary) > 0 {
+		wave, err := planWave("canary", s.Canary)
+		if err != nil {
+			return nil, err
+		}
+		waves = append(waves, wave)
+	}
+
+	for i, clusterNames := range s.Waves {
+		wave, err := planWave(fmt.Sprintf("wave-%d", i+1), clusterNames)
+		if err != nil {
+			return nil, err
+		}
+		waves = append(waves, wave)
+	}
+
+	remaining := RolloutWave{Name: fmt.Sprintf("wave-%d", len(s.Waves)+1)}
+	for _, target := range targets {
+		if !planned[target.ClusterName] {
+			remaining.Targets = append(remaining.Targets, target)
+		}
+	}
+	if len(remaining.Targets) > 0 {
+		sort.Slice(remaining.Targets, func(i, j int) bool {
+			return remaining.Targets[i].ClusterName < remaining.Targets[j].ClusterName
+		})
+		waves = append(waves, remaining)
+	}
+
+	return waves, nil
+}
+
+// GetHealthCheckTimeout returns the health check timeout of the strategy falling back to the default one
+func (s RolloutStrategy) GetHealthCheckTimeout() time.Duration {
+	if s.HealthCheckTimeout == 0 {
+		return DefaultRolloutHealthCheckTimeout * time.Second
+	}
+
+	return time.Duration(s.HealthCheckTimeout) * time.Second
+}
+
+// getRolloutTargets returns the member clusters the deployment is targeted to
+func getRolloutTargets(clusterGroup *api.ClusterGroup, targetClusters map[uint]bool) []RolloutTarget {
+	targets := make([]RolloutTarget, 0, len(clusterGroup.Clusters))
+	for _, apiCluster := range clusterGroup.Clusters {
+		if targetClusters != nil && !targetClusters[apiCluster.GetID()] {
+			continue
+		}
+		targets = append(targets, RolloutTarget{
+			ClusterID:   apiCluster.GetID(),
+			ClusterName: apiCluster.GetName(),
+		})
+	}
+
+	return targets
+}
+
+// validateRolloutStrategy checks whether the strategy can be applied to the members of the cluster group
+func (m CGDeploymentManager) validateRolloutStrategy(clusterGroup *api.ClusterGroup, strategy *RolloutStrategy) error {
+	if strategy == nil {
+		return nil
+	}
+
+	_, err := strategy.PlanWaves(getRolloutTargets(clusterGroup, nil))
+
+	return err
+}
+
+// saveAndRolloutDeployment saves the deployment then installs or upgrades it on the target clusters either at once
+// or, if the deployment has a rollout strategy, by starting a rollout following the strategy.
+// The rollout is started before saving the deployment, so that the deployment is left intact if another rollout is in progress.
+func (m CGDeploymentManager) saveAndRolloutDeployment(orgID uint, clusterGroup *api.ClusterGroup, deploymentModel *ClusterGroupDeploymentModel, requestedChart ChartMeta, dryRun bool) ([]TargetClusterStatus, string, error) {
+	depInfo, err := m.getDeploymentFromModel(deploymentModel)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if depInfo.RolloutStrategy == nil || dryRun {
+		if !dryRun {
+			if err := m.repository.Save(deploymentModel); err != nil {
+				return nil, "", errors.WrapIf(err, "Error saving deployment model")
+			}
+		}
+
+		return m.upgradeOrInstallDeploymentToTargetClusters(orgID, clusterGroup, depInfo, requestedChart, dryRun), "", nil
+	}
+
+	targets := getRolloutTargets(clusterGroup, depInfo.TargetClusters)
+	waves, err := depInfo.RolloutStrategy.PlanWaves(targets)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rolloutID, err := m.rolloutStarter.StartRollout(context.Background(), Rollout{
+		OrganizationID:     orgID,
+		ClusterGroupID:     clusterGroup.Id,
+		ReleaseName:        depInfo.ReleaseName,
+		Deployment:         *depInfo,
+		Waves:              waves,
+		MaxParallel:        depInfo.RolloutStrategy.MaxParallel,
+		HealthCheckTimeout: depInfo.RolloutStrategy.GetHealthCheckTimeout(),
+		RollbackOnFailure:  depInfo.RolloutStrategy.RollbackOnFailure,
+	})
+	if err != nil {
+		return nil, "", errors.WrapIfWithDetails(err, "failed to start rollout", "releaseName", depInfo.ReleaseName)
+	}
+
+	deploymentModel.RolloutID = rolloutID
+	if err := m.repository.Save(deploymentModel); err != nil {
+		return nil, "", errors.WrapIf(err, "Error saving deployment model")
+	}
+
+	targetClusterStatus := make([]TargetClusterStatus, 0, len(targets))
+	for _, target := range targets {
+		apiCluster := clusterGroup.Clusters[target.ClusterID]
+		targetClusterStatus = append(targetClusterStatus, TargetClusterStatus{
+			ClusterId:    apiCluster.GetID(),
+			ClusterName:  apiCluster.GetName(),
+			Cloud:        apiCluster.GetCloud(),
+			Distribution: apiCluster.GetDistribution(),
+			Status:       RolloutPendingStatus,
+		})
+	}
+
+	return targetClusterStatus, rolloutID, nil
+}
+
+// getRolloutCluster returns a target cluster of a rollout
+func (m CGDeploymentManager) getRolloutCluster(ctx context.Context, orgID uint, clusterID uint) (api.Cluster, error) {
+	apiCluster, err := m.clusterGetter.GetClusterByID(ctx, orgID, clusterID)
+	if err != nil {
+		return nil, errors.WithStack(&memberClusterNotFoundError{
+			clusterID: clusterID,
+		})
+	}
+
+	return apiCluster, nil
+}
+
+// GetClusterRevision returns the revision of the release of the deployment on a member cluster, 0 if the release is not installed
+func (m CGDeploymentManager) GetClusterRevision(ctx context.Context, orgID uint, clusterID uint, releaseName string, namespace string) (int32, error) {
+	apiCluster, err := m.getRolloutCluster(ctx, orgID, clusterID)
+	if err != nil {
+		return 0, err
+	}
+
+	release, err := m.findRelease(apiCluster, releaseName, namespace)
+	if err != nil {
+		return 0, err
+	}
+	if release == nil {
+		return 0, nil
+	}
+
+	return release.ReleaseVersion, nil
+}
+
+// RolloutToCluster installs or upgrades the deployment on a single member cluster
+func (m CGDeploymentManager) RolloutToCluster(ctx context.Context, orgID uint, clusterID uint, depInfo *DeploymentInfo) error {
+	apiCluster, err := m.getRolloutCluster(ctx, orgID, clusterID)
+	if err != nil {
+		return err
+	}
+
+	requestedChart, err := m.helmService.GetChartMeta(orgID, depInfo.Chart, depInfo.ChartVersion)
+	if err != nil {
+		return errors.WrapIf(err, "error getting chart description")
+	}
+
+	return m.upgradeOrInstallDeploymentOnCluster(orgID, apiCluster, depInfo, requestedChart, false)
+}
+
+// CheckClusterHealth checks whether the release of the deployment is deployed and its workloads are ready on a member cluster
+func (m CGDeploymentManager) CheckClusterHealth(ctx context.Context, orgID uint, clusterID uint, releaseName string, namespace string) (ClusterHealth, error) {
+	apiCluster, err := m.getRolloutCluster(ctx, orgID, clusterID)
+	if err != nil {
+		return ClusterHealth{}, err
+	}
+
+	release, err := m.findRelease(apiCluster, releaseName, namespace)
+	if err != nil {
+		return ClusterHealth{}, err
+	}
+	if release == nil {
+		return ClusterHealth{Message: "release not found"}, nil
+	}
+	if release.ReleaseInfo.Status != releaseStatusDeployed {
+		return ClusterHealth{Message: fmt.Sprintf("release status is %s", release.ReleaseInfo.Status)}, nil
+	}
+
+	kubeConfig, err := apiCluster.GetK8sConfig()
+	if err != nil {
+		return ClusterHealth{}, errors.WrapIf(err, "failed to get kubeconfig")
+	}
+
+	unready, err := m.workloadChecker.UnreadyWorkloads(ctx, kubeConfig, namespace, release.ReleaseInfo.Manifest)
+	if err != nil {
+		return ClusterHealth{}, err
+	}
+	if len(unready) > 0 {
+		return ClusterHealth{Message: fmt.Sprintf("workloads not ready: %v", unready)}, nil
+	}
+
+	return ClusterHealth{Healthy: true}, nil
+}
+
+// RollbackCluster rolls the release of the deployment back to the given revision on a member cluster,
+// the release is deleted if the revision is 0
+func (m CGDeploymentManager) RollbackCluster(ctx context.Context, orgID uint, clusterID uint, releaseName string, namespace string, revision int32) error {
+	apiCluster, err := m.getRolloutCluster(ctx, orgID, clusterID)
+	if err != nil {
+		return err
+	}
+
+	release, err := m.findRelease(apiCluster, releaseName, namespace)
+	if err != nil {
+		return err
+	}
+	if release == nil || release.ReleaseVersion == revision {
+		// nothing has been changed on the cluster
+		return nil
+	}
+
+	if revision == 0 {
+		return m.helmService.DeleteRelease(apiCluster, releaseName, namespace)
+	}
+
+	return m.helmService.RollbackRelease(orgID, apiCluster, releaseName, namespace, revision)
+}
@@ -0,0 +1,161 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+)
+
+func TestRolloutStrategy_PlanWaves(t *testing.T) {
+	targets := []deployment.RolloutTarget{
+		{ClusterID: 1, ClusterName: "prod-eu"},
+		{ClusterID: 2, ClusterName: "prod-us"},
+		{ClusterID: 3, ClusterName: "staging"},
+		{ClusterID: 4, ClusterName: "prod-asia"},
+		{ClusterID: 5, ClusterName: "dev"},
+	}
+
+	tests := []struct {
+		name     string
+		strategy deployment.RolloutStrategy
+		waves    []deployment.RolloutWave
+		err      string
+	}{
+		{
+			name:     "no waves",
+			strategy: deployment.RolloutStrategy{},
+			waves: []deployment.RolloutWave{
+				{
+					Name: "wave-1",
+					Targets: []deployment.RolloutTarget{
+						{ClusterID: 5, ClusterName: "dev"},
+						{ClusterID: 4, ClusterName: "prod-asia"},
+						{ClusterID: 1, ClusterName: "prod-eu"},
+						{ClusterID: 2, ClusterName: "prod-us"},
+						{ClusterID: 3, ClusterName: "staging"},
+					},
+				},
+			},
+		},
+		{
+			name: "canary and waves",
+			strategy: deployment.RolloutStrategy{
+				Canary: []string{"dev"},
+				Waves:  [][]string{{"staging"}, {"prod-us", "prod-eu"}},
+			},
+			waves: []deployment.RolloutWave{
+				{
+					Name:    "canary",
+					Targets: []deployment.RolloutTarget{{ClusterID: 5, ClusterName: "dev"}},
+				},
+				{
+					Name:    "wave-1",
+					Targets: []deployment.RolloutTarget{{ClusterID: 3, ClusterName: "staging"}},
+				},
+				{
+					Name: "wave-2",
+					Targets: []deployment.RolloutTarget{
+						{ClusterID: 2, ClusterName: "prod-us"},
+						{ClusterID: 1, ClusterName: "prod-eu"},
+					},
+				},
+				{
+					Name:    "wave-3",
+					Targets: []deployment.RolloutTarget{{ClusterID: 4, ClusterName: "prod-asia"}},
+				},
+			},
+		},
+		{
+			name: "every cluster listed",
+			strategy: deployment.RolloutStrategy{
+				Canary: []string{"dev", "staging"},
+				Waves:  [][]string{{"prod-us", "prod-eu", "prod-asia"}},
+			},
+			waves: []deployment.RolloutWave{
+				{
+					Name: "canary",
+					Targets: []deployment.RolloutTarget{
+						{ClusterID: 5, ClusterName: "dev"},
+						{ClusterID: 3, ClusterName: "staging"},
+					},
+				},
+				{
+					Name: "wave-1",
+					Targets: []deployment.RolloutTarget{
+						{ClusterID: 2, ClusterName: "prod-us"},
+						{ClusterID: 1, ClusterName: "prod-eu"},
+						{ClusterID: 4, ClusterName: "prod-asia"},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown cluster",
+			strategy: deployment.RolloutStrategy{
+				Canary: []string{"qa"},
+			},
+			err: `invalid rollout strategy: cluster "qa" of canary is not a target of the deployment`,
+		},
+		{
+			name: "duplicate cluster",
+			strategy: deployment.RolloutStrategy{
+				Canary: []string{"dev"},
+				Waves:  [][]string{{"staging", "dev"}},
+			},
+			err: `invalid rollout strategy: cluster "dev" is listed more than once`,
+		},
+		{
+			name: "empty wave",
+			strategy: deployment.RolloutStrategy{
+				Waves: [][]string{{"dev"}, {}},
+			},
+			err: "invalid rollout strategy: wave-2 is empty",
+		},
+		{
+			name: "negative parallelism",
+			strategy: deployment.RolloutStrategy{
+				MaxParallel: -1,
+			},
+			err: "invalid rollout strategy: maxParallel must not be negative",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			waves, err := test.strategy.PlanWaves(targets)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.True(t, deployment.IsInvalidRolloutStrategyError(err))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.waves, waves)
+		})
+	}
+}
+
+func TestRolloutStrategy_GetHealthCheckTimeout(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, deployment.RolloutStrategy{}.GetHealthCheckTimeout())
+	assert.Equal(t, 30*time.Second, deployment.RolloutStrategy{HealthCheckTimeout: 30}.GetHealthCheckTimeout())
+}
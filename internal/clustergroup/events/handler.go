@@ -0,0 +1,60 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+)
+
+type membershipReconciler interface {
+	ReconcileClusterMembership(ctx context.Context, clusterID uint) error
+	ReconcileOrganizationMembership(ctx context.Context, orgID uint) error
+}
+
+// ClusterEventHandler keeps the members of selector based cluster groups in sync with cluster lifecycle events.
+type ClusterEventHandler struct {
+	events       clusterEvents
+	reconciler   membershipReconciler
+	errorHandler emperror.Handler
+}
+
+func NewClusterEventHandler(events clusterEvents, reconciler membershipReconciler, errorHandler emperror.Handler) *ClusterEventHandler {
+	eh := &ClusterEventHandler{
+		events:       events,
+		reconciler:   reconciler,
+		errorHandler: errorHandler,
+	}
+
+	eh.events.NotifyClusterCreated(eh.reconcileCluster)
+	eh.events.NotifyClusterUpdated(eh.reconcileCluster)
+	eh.events.NotifyClusterDeleted(func(orgID uint, clusterName string) {
+		err := eh.reconciler.ReconcileOrganizationMembership(context.Background(), orgID)
+		if err != nil {
+			eh.errorHandler.Handle(errors.WrapIfWithDetails(err, "failed to reconcile cluster group members", "organizationID", orgID, "clusterName", clusterName))
+		}
+	})
+
+	return eh
+}
+
+func (eh *ClusterEventHandler) reconcileCluster(clusterID uint) {
+	err := eh.reconciler.ReconcileClusterMembership(context.Background(), clusterID)
+	if err != nil {
+		eh.errorHandler.Handle(errors.WrapIfWithDetails(err, "failed to reconcile cluster group membership", "clusterID", clusterID))
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"emperror.dev/emperror"
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/src/cluster"
+)
+
+type fakeReconciler struct {
+	mu                 sync.Mutex
+	reconciledClusters []uint
+	reconciledOrgs     []uint
+}
+
+func (r *fakeReconciler) ReconcileClusterMembership(ctx context.Context, clusterID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reconciledClusters = append(r.reconciledClusters, clusterID)
+	return nil
+}
+
+func (r *fakeReconciler) ReconcileOrganizationMembership(ctx context.Context, orgID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reconciledOrgs = append(r.reconciledOrgs, orgID)
+	return nil
+}
+
+func TestClusterEventHandler(t *testing.T) {
+	clusterEventBus := evbus.New()
+	publisher := cluster.NewClusterEvents(clusterEventBus)
+
+	reconciler := &fakeReconciler{}
+	NewClusterEventHandler(NewClusterEvents(clusterEventBus), reconciler, emperror.NewNoopHandler())
+
+	publisher.ClusterCreated(1)
+	clusterEventBus.WaitAsync()
+	publisher.ClusterUpdated(2)
+	clusterEventBus.WaitAsync()
+	publisher.ClusterDeleted(3, "clustername")
+	clusterEventBus.WaitAsync()
+
+	assert.Equal(t, []uint{1, 2}, reconciler.reconciledClusters)
+	assert.Equal(t, []uint{3}, reconciler.reconciledOrgs)
+}
@@ -15,6 +15,7 @@
 package clustergroup
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -103,6 +104,26 @@ func (g *Manager) ReconcileFeature(clusterGroup api.ClusterGroup, featureName st
 	return nil
 }
 
+// ReconcileFeatureOnAllGroups reconciles a feature of every cluster group it is enabled on
+func (g *Manager) ReconcileFeatureOnAllGroups(ctx context.Context, featureName string) error {
+	cgModels, err := g.cgRepo.FindAllWithEnabledFeature(featureName)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, cgModel := range cgModels {
+		clusterGroup := g.GetClusterGroupFromModel(ctx, cgModel, false)
+
+		err := g.ReconcileFeature(*clusterGroup, featureName)
+		if err != nil {
+			errs = append(errs, errors.WithDetails(err, "clusterGroupId", clusterGroup.Id))
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
 func (g *Manager) DisableFeatures(clusterGroup api.ClusterGroup) error {
 	g.logger.WithField("clusterGroupName", clusterGroup.Name).Debug("disable all enabled features")
 
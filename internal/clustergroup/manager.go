@@ -63,7 +63,7 @@ func isValidClusterStatus(clusterStatus *cluster.GetClusterStatusResponse) bool
 }
 
 // CreateClusterGroup creates a cluster group
-func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uint, members []uint) (*uint, error) {
+func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uint, members []uint, selector *api.MemberSelector) (*uint, error) {
 	cgModel, err := g.cgRepo.FindOne(ClusterGroupModel{
 		OrganizationID: orgID,
 		Name:           name,
@@ -79,7 +79,29 @@ func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uin
 		})
 	}
 
+	if err := api.ValidateMembership(members, selector); err != nil {
+		return nil, errors.WithStack(&invalidClusterGroupCreateRequestError{
+			message: err.Error(),
+		})
+	}
+
 	memberClusterModels := make([]MemberClusterModel, 0)
+	if selector != nil {
+		selectedMembers, err := g.selectMembers(ctx, orgID, *selector, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range selectedMembers {
+			memberClusterModels = append(memberClusterModels, MemberClusterModel{
+				ClusterID: cluster.GetID(),
+			})
+			g.logger.WithFields(logrus.Fields{
+				"clusterName":      cluster.GetName(),
+				"clusterGroupName": name,
+			}).Info("Join cluster to group")
+		}
+	}
+
 	for _, clusterID := range members {
 		var cluster api.Cluster
 		cluster, err := g.clusterGetter.GetClusterByID(ctx, orgID, clusterID)
@@ -119,7 +141,7 @@ func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uin
 		}
 	}
 
-	cgId, err := g.cgRepo.Create(name, orgID, memberClusterModels)
+	cgId, err := g.cgRepo.Create(name, orgID, memberClusterModels, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +160,7 @@ func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uin
 }
 
 // UpdateClusterGroup updates a cluster group
-func (g *Manager) UpdateClusterGroup(ctx context.Context, clusterGroupID uint, orgID uint, name string, members []uint) error {
+func (g *Manager) UpdateClusterGroup(ctx context.Context, clusterGroupID uint, orgID uint, name string, members []uint, selector *api.MemberSelector) error {
 	cgModel, err := g.cgRepo.FindOne(ClusterGroupModel{
 		ID:             clusterGroupID,
 		OrganizationID: orgID,
@@ -147,8 +169,20 @@ func (g *Manager) UpdateClusterGroup(ctx context.Context, clusterGroupID uint, o
 		return err
 	}
 
+	if err := api.ValidateMembership(members, selector); err != nil {
+		return errors.WithStack(&invalidClusterGroupCreateRequestError{
+			message: err.Error(),
+		})
+	}
+
 	existingClusterGroup := g.GetClusterGroupFromModel(ctx, cgModel, false)
 	newMembers := make(map[uint]api.Cluster, 0)
+	if selector != nil {
+		newMembers, err = g.selectMembers(ctx, orgID, *selector, existingClusterGroup.Id)
+		if err != nil {
+			return err
+		}
+	}
 
 	for _, clusterID := range members {
 		var cluster api.Cluster
@@ -198,6 +232,11 @@ func (g *Manager) UpdateClusterGroup(ctx context.Context, clusterGroupID uint, o
 		return err
 	}
 
+	err = g.cgRepo.UpdateMemberSelector(existingClusterGroup.Id, selector)
+	if err != nil {
+		return err
+	}
+
 	clusterGroup, err := g.GetClusterGroupByID(ctx, existingClusterGroup.Id, orgID)
 	if err != nil {
 		return err
@@ -290,7 +329,8 @@ func (g *Manager) RemoveClusterFromGroup(ctx context.Context, clusterID uint) er
 		}
 	}
 
-	if len(newMembers) == 0 {
+	// selector based cluster groups are kept even without members
+	if len(newMembers) == 0 && len(cgModel.MemberSelector) == 0 {
 		g.logger.Debug("delete cluster group before deleting it's last member")
 		err := g.DeleteClusterGroupByID(ctx, existingClusterGroup.OrganizationID, existingClusterGroup.Id)
 		if err != nil {
@@ -333,6 +373,12 @@ func (g *Manager) GetClusterGroupFromModel(ctx context.Context, cg *ClusterGroup
 	clusterGroup.Members = make([]api.Member, 0)
 	clusterGroup.Clusters = make(map[uint]api.Cluster, 0)
 
+	selector, err := getMemberSelector(cg)
+	if err != nil {
+		g.errorHandler.Handle(err)
+	}
+	clusterGroup.Selector = selector
+
 	enabledFeatures := make([]string, 0)
 	clusterGroup.EnabledFeatures = enabledFeatures
 	for _, feature := range cg.FeatureParams {
@@ -0,0 +1,263 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustergroup
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"emperror.dev/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+)
+
+// ReconcileClusterMembership evaluates the member selectors of the cluster groups in the organization of a cluster
+// and lets the cluster join or leave them accordingly.
+func (g *Manager) ReconcileClusterMembership(ctx context.Context, clusterID uint) error {
+	cluster, err := g.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not get cluster", "clusterID", clusterID)
+	}
+
+	var leftClusterGroupID uint
+	currentClusterGroupID, err := g.getClusterGroupForCluster(clusterID)
+	if err != nil {
+		return err
+	}
+	if currentClusterGroupID != nil {
+		cgModel, err := g.cgRepo.FindOne(ClusterGroupModel{
+			ID: *currentClusterGroupID,
+		})
+		if err != nil {
+			return err
+		}
+
+		selector, err := getMemberSelector(cgModel)
+		if err != nil {
+			return err
+		}
+
+		// members of static cluster groups are managed explicitly
+		if selector == nil {
+			return nil
+		}
+
+		matches, err := g.matchesMemberSelector(ctx, cluster, *selector, cgModel.ID)
+		if err != nil {
+			return err
+		}
+		if matches {
+			return nil
+		}
+
+		g.logger.WithFields(logrus.Fields{
+			"clusterName":      cluster.GetName(),
+			"clusterGroupName": cgModel.Name,
+		}).Info("Cluster leaves group")
+
+		err = g.setMembership(ctx, cgModel, cluster, false)
+		if err != nil {
+			return err
+		}
+		leftClusterGroupID = cgModel.ID
+	}
+
+	clusterGroups, err := g.cgRepo.FindAll(cluster.GetOrganizationId())
+	if err != nil {
+		return err
+	}
+	sort.Slice(clusterGroups, func(i, j int) bool { return clusterGroups[i].ID < clusterGroups[j].ID })
+
+	for _, cgModel := range clusterGroups {
+		if cgModel.ID == leftClusterGroupID {
+			continue
+		}
+
+		selector, err := getMemberSelector(cgModel)
+		if err != nil {
+			return err
+		}
+		if selector == nil {
+			continue
+		}
+
+		matches, err := g.matchesMemberSelector(ctx, cluster, *selector, cgModel.ID)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			continue
+		}
+
+		g.logger.WithFields(logrus.Fields{
+			"clusterName":      cluster.GetName(),
+			"clusterGroupName": cgModel.Name,
+		}).Info("Join cluster to group")
+
+		// a cluster can be member of only one cluster group
+		return g.setMembership(ctx, cgModel, cluster, true)
+	}
+
+	return nil
+}
+
+// ReconcileOrganizationMembership re-evaluates the member selectors of every cluster group in an organization.
+func (g *Manager) ReconcileOrganizationMembership(ctx context.Context, orgID uint) error {
+	clusterGroups, err := g.cgRepo.FindAll(orgID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(clusterGroups, func(i, j int) bool { return clusterGroups[i].ID < clusterGroups[j].ID })
+
+	var errs []error
+	for _, cgModel := range clusterGroups {
+		selector, err := getMemberSelector(cgModel)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if selector == nil {
+			continue
+		}
+
+		newMembers, err := g.selectMembers(ctx, orgID, *selector, cgModel.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !membersChanged(cgModel.Members, newMembers) {
+			continue
+		}
+
+		err = g.updateMembers(ctx, g.GetClusterGroupFromModel(ctx, cgModel, false), newMembers)
+		if err != nil {
+			errs = append(errs, errors.WithDetails(err, "clusterGroupID", cgModel.ID))
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
+// selectMembers returns the clusters of an organization matching a member selector
+func (g *Manager) selectMembers(ctx context.Context, orgID uint, selector api.MemberSelector, clusterGroupID uint) (map[uint]api.Cluster, error) {
+	clusters, err := g.clusterGetter.GetClusters(ctx, orgID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not list clusters", "organizationID", orgID)
+	}
+
+	members := make(map[uint]api.Cluster, 0)
+	for _, cluster := range clusters {
+		matches, err := g.matchesMemberSelector(ctx, cluster, selector, clusterGroupID)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			members[cluster.GetID()] = cluster
+		}
+	}
+
+	return members, nil
+}
+
+// matchesMemberSelector returns true if a cluster is allowed to be a member of the given selector based cluster group
+func (g *Manager) matchesMemberSelector(ctx context.Context, cluster api.Cluster, selector api.MemberSelector, clusterGroupID uint) (bool, error) {
+	tags, err := g.clusterGetter.GetClusterTags(ctx, cluster.GetID())
+	if err != nil {
+		return false, errors.WrapIfWithDetails(err, "could not get cluster tags", "clusterID", cluster.GetID())
+	}
+
+	if !selector.Matches(cluster, tags) {
+		return false, nil
+	}
+
+	if ok, err := g.isClusterMemberOfAClusterGroup(cluster.GetID(), clusterGroupID); ok || err != nil {
+		return false, err
+	}
+
+	clusterStatus, err := cluster.GetStatus()
+	if err != nil {
+		g.logger.WithField("clusterName", cluster.GetName()).Debug("could not check cluster state, skip selecting it")
+		return false, nil
+	}
+
+	return isValidClusterStatus(clusterStatus), nil
+}
+
+// setMembership adds a cluster to or removes a cluster from a cluster group
+func (g *Manager) setMembership(ctx context.Context, cgModel *ClusterGroupModel, cluster api.Cluster, member bool) error {
+	clusterGroup := g.GetClusterGroupFromModel(ctx, cgModel, false)
+
+	newMembers := make(map[uint]api.Cluster, 0)
+	for id, c := range clusterGroup.Clusters {
+		if id != cluster.GetID() {
+			newMembers[id] = c
+		}
+	}
+	if member {
+		newMembers[cluster.GetID()] = cluster
+	}
+
+	return g.updateMembers(ctx, clusterGroup, newMembers)
+}
+
+// updateMembers persists the new members of a cluster group and reconciles its enabled features
+func (g *Manager) updateMembers(ctx context.Context, clusterGroup *api.ClusterGroup, newMembers map[uint]api.Cluster) error {
+	err := g.validateBeforeClusterGroupUpdate(*clusterGroup, newMembers)
+	if err != nil {
+		return errors.WrapIf(err, "updating cluster group is not allowed")
+	}
+
+	err = g.cgRepo.UpdateMembers(clusterGroup, newMembers)
+	if err != nil {
+		return err
+	}
+
+	updatedClusterGroup, err := g.GetClusterGroupByID(ctx, clusterGroup.Id, clusterGroup.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	// call feature handlers on members update
+	return g.ReconcileFeatures(*updatedClusterGroup, true)
+}
+
+func membersChanged(members []MemberClusterModel, newMembers map[uint]api.Cluster) bool {
+	if len(members) != len(newMembers) {
+		return true
+	}
+	for _, member := range members {
+		if _, ok := newMembers[member.ClusterID]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func getMemberSelector(cg *ClusterGroupModel) (*api.MemberSelector, error) {
+	if len(cg.MemberSelector) == 0 {
+		return nil, nil
+	}
+
+	var selector api.MemberSelector
+	err := json.Unmarshal(cg.MemberSelector, &selector)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not unmarshal member selector", "clusterGroupID", cg.ID)
+	}
+
+	return &selector, nil
+}
@@ -43,6 +43,7 @@ type ClusterGroupModel struct {
 	OrganizationID uint                       `gorm:"unique_index:idx_unique_id"`
 	Members        []MemberClusterModel       `gorm:"foreignkey:ClusterGroupID"`
 	FeatureParams  []ClusterGroupFeatureModel `gorm:"foreignkey:ClusterGroupID"`
+	MemberSelector []byte                     `sql:"type:text;"`
 }
 
 // MemberClusterModel describes a member of a cluster group.
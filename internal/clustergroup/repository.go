@@ -15,6 +15,8 @@
 package clustergroup
 
 import (
+	"encoding/json"
+
 	"emperror.dev/errors"
 	"github.com/jinzhu/gorm"
 	"github.com/sirupsen/logrus"
@@ -74,15 +76,36 @@ func (g *ClusterGroupRepository) FindAll(orgID uint) ([]*ClusterGroupModel, erro
 	return cgroups, nil
 }
 
+// FindAllWithEnabledFeature returns every cluster group a feature is enabled on
+func (g *ClusterGroupRepository) FindAllWithEnabledFeature(featureName string) ([]*ClusterGroupModel, error) {
+	var cgroups []*ClusterGroupModel
+
+	err := g.db.
+		Joins("JOIN "+clusterGroupFeaturesTableName+" ON "+clusterGroupFeaturesTableName+".cluster_group_id = "+clustersTableName+".id").
+		Where(clusterGroupFeaturesTableName+".name = ? AND "+clusterGroupFeaturesTableName+".enabled = ?", featureName, true).
+		Preload("Members").Preload("FeatureParams").Find(&cgroups).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not find cluster groups", "featureName", featureName)
+	}
+
+	return cgroups, nil
+}
+
 // Create persists a cluster group
-func (g *ClusterGroupRepository) Create(name string, orgID uint, memberClusterModels []MemberClusterModel) (*uint, error) {
+func (g *ClusterGroupRepository) Create(name string, orgID uint, memberClusterModels []MemberClusterModel, selector *api.MemberSelector) (*uint, error) {
+	memberSelector, err := marshalMemberSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
 	clusterGroupModel := &ClusterGroupModel{
 		Name:           name,
 		OrganizationID: orgID,
 		Members:        memberClusterModels,
+		MemberSelector: memberSelector,
 	}
 
-	err := g.db.Save(clusterGroupModel).Error
+	err = g.db.Save(clusterGroupModel).Error
 	if err != nil {
 		return nil, errors.WrapIfWithDetails(err, "error creating cluster group", "name", name)
 	}
@@ -127,6 +150,32 @@ func (g *ClusterGroupRepository) UpdateMembers(cgroup *api.ClusterGroup, newMemb
 	return nil
 }
 
+// UpdateMemberSelector updates the member selector of a cluster group, a nil selector makes the group static
+func (g *ClusterGroupRepository) UpdateMemberSelector(clusterGroupID uint, selector *api.MemberSelector) error {
+	memberSelector, err := marshalMemberSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	err = g.db.Model(&ClusterGroupModel{ID: clusterGroupID}).Update("member_selector", memberSelector).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not update member selector", "clusterGroupID", clusterGroupID)
+	}
+	return nil
+}
+
+func marshalMemberSelector(selector *api.MemberSelector) ([]byte, error) {
+	if selector == nil {
+		return nil, nil
+	}
+
+	memberSelector, err := json.Marshal(selector)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not marshal member selector")
+	}
+	return memberSelector, nil
+}
+
 // Delete deletes a cluster group
 func (g *ClusterGroupRepository) Delete(cgroup *ClusterGroupModel) error {
 	for _, fp := range cgroup.FeatureParams {
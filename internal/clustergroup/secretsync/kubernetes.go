@@ -0,0 +1,105 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsync
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+	"github.com/banzaicloud/pipeline/internal/secret/kubesecret"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+	"github.com/banzaicloud/pipeline/pkg/k8sutil"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// syncedSecretLabel marks the Kubernetes secrets managed by the secret sync feature
+const syncedSecretLabel = "clustergroup.banzaicloud.io/synced-secret"
+
+type kubernetesSecretWriter struct{}
+
+// NewKubernetesSecretWriter returns a ClusterSecretWriter writing secrets through the Kubernetes API of clusters.
+func NewKubernetesSecretWriter() ClusterSecretWriter {
+	return kubernetesSecretWriter{}
+}
+
+func (kubernetesSecretWriter) Apply(ctx context.Context, cluster api.Cluster, namespace string, s *secret.SecretItemResponse) error {
+	kubeConfig, err := cluster.GetK8sConfig()
+	if err != nil {
+		return errors.WrapIf(err, "failed to get k8s config")
+	}
+
+	client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create kubernetes client")
+	}
+
+	if err := k8sutil.EnsureNamespace(client, namespace); err != nil {
+		return errors.WrapIf(err, "failed to ensure that namespace exists")
+	}
+
+	kubeSecret, err := kubesecret.CreateKubeSecret(kubesecret.KubeSecretRequest{
+		Name:      s.Name,
+		Namespace: namespace,
+		Type:      s.Type,
+		Values:    s.Values,
+	})
+	if err != nil {
+		return errors.WrapIf(err, "failed to create kubernetes secret")
+	}
+	kubeSecret.Labels = map[string]string{syncedSecretLabel: "true"}
+
+	_, err = client.CoreV1().Secrets(namespace).Create(ctx, &kubeSecret, metav1.CreateOptions{})
+	if k8sapierrors.IsAlreadyExists(err) {
+		existing, err := client.CoreV1().Secrets(namespace).Get(ctx, s.Name, metav1.GetOptions{})
+		if err != nil {
+			return errors.WrapIf(err, "failed to get kubernetes secret")
+		}
+
+		if existing.Labels == nil {
+			existing.Labels = make(map[string]string)
+		}
+		existing.Labels[syncedSecretLabel] = "true"
+		existing.Data = nil // Clear data so that it is created from string data again
+		existing.StringData = kubeSecret.StringData
+
+		_, err = client.CoreV1().Secrets(namespace).Update(ctx, existing, metav1.UpdateOptions{})
+		return errors.WrapIf(err, "failed to update kubernetes secret")
+	}
+
+	return errors.WrapIf(err, "failed to create kubernetes secret")
+}
+
+func (kubernetesSecretWriter) Delete(ctx context.Context, cluster api.Cluster, namespace string, secretName string) error {
+	kubeConfig, err := cluster.GetK8sConfig()
+	if err != nil {
+		return errors.WrapIf(err, "failed to get k8s config")
+	}
+
+	client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create kubernetes client")
+	}
+
+	err = client.CoreV1().Secrets(namespace).Delete(ctx, secretName, metav1.DeleteOptions{})
+	if k8sapierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return errors.WrapIf(err, "failed to delete kubernetes secret")
+}
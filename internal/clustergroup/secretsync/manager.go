@@ -0,0 +1,379 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// FeatureName is the name of the cluster group feature synchronizing organization secrets to member clusters
+const FeatureName = "secret-sync"
+
+// Synced secret states
+const (
+	StatusSynced  = "SYNCED"
+	StatusFailed  = "FAILED"
+	StatusPending = "PENDING"
+)
+
+// FeatureProperties describes the properties of the secret sync feature
+type FeatureProperties struct {
+	// Secrets are the names of the organization secrets to synchronize
+	Secrets []string `json:"secrets" mapstructure:"secrets"`
+	// Namespaces are the namespaces of the member clusters the secrets are synchronized into
+	Namespaces []string `json:"namespaces" mapstructure:"namespaces"`
+}
+
+// SecretStore returns organization secrets.
+type SecretStore interface {
+	GetByName(organizationID uint, name string) (*secret.SecretItemResponse, error)
+}
+
+// ClusterSecretWriter writes Kubernetes secrets to clusters.
+type ClusterSecretWriter interface {
+	// Apply creates or updates a Kubernetes secret from an organization secret.
+	Apply(ctx context.Context, cluster api.Cluster, namespace string, secret *secret.SecretItemResponse) error
+
+	// Delete deletes a Kubernetes secret, a missing secret is not considered to be an error.
+	Delete(ctx context.Context, cluster api.Cluster, namespace string, secretName string) error
+}
+
+// Manager synchronizes organization secrets to the members of cluster groups.
+type Manager struct {
+	repository    *SyncedSecretRepository
+	clusterGetter api.ClusterGetter
+	secretStore   SecretStore
+	secretWriter  ClusterSecretWriter
+	logger        logrus.FieldLogger
+	errorHandler  emperror.Handler
+}
+
+// NewManager returns a new Manager instance.
+func NewManager(
+	repository *SyncedSecretRepository,
+	clusterGetter api.ClusterGetter,
+	secretStore SecretStore,
+	secretWriter ClusterSecretWriter,
+	logger logrus.FieldLogger,
+	errorHandler emperror.Handler,
+) *Manager {
+	return &Manager{
+		repository:    repository,
+		clusterGetter: clusterGetter,
+		secretStore:   secretStore,
+		secretWriter:  secretWriter,
+		logger:        logger,
+		errorHandler:  errorHandler,
+	}
+}
+
+type syncKey struct {
+	clusterID  uint
+	namespace  string
+	secretName string
+}
+
+func keyOf(model *SyncedSecretModel) syncKey {
+	return syncKey{clusterID: model.ClusterID, namespace: model.Namespace, secretName: model.SecretName}
+}
+
+// ReconcileState synchronizes the selected secrets to every member cluster and removes them from
+// clusters which are not members anymore or when the feature is disabled.
+func (m *Manager) ReconcileState(featureState api.Feature) error {
+	ctx := context.Background()
+	clusterGroup := featureState.ClusterGroup
+
+	log := m.logger.WithField("clusterGroupName", clusterGroup.Name)
+	log.Info("reconcile synced secrets")
+
+	records, err := m.repository.FindAll(clusterGroup.Id)
+	if err != nil {
+		return err
+	}
+
+	recordMap := make(map[syncKey]*SyncedSecretModel, len(records))
+	for _, record := range records {
+		recordMap[keyOf(record)] = record
+	}
+
+	var errs []error
+	desired := make(map[syncKey]bool)
+
+	if featureState.Enabled {
+		properties, err := decodeProperties(featureState.Properties)
+		if err != nil {
+			return err
+		}
+
+		for _, secretName := range properties.Secrets {
+			orgSecret, secretErr := m.getSecret(clusterGroup.OrganizationID, secretName)
+			if secretErr != nil {
+				secretErr = errors.WrapIfWithDetails(secretErr, "could not get secret", "secretName", secretName)
+				errs = append(errs, secretErr)
+			}
+
+			for _, clusterID := range sortedClusterIDs(clusterGroup.Clusters) {
+				cluster := clusterGroup.Clusters[clusterID]
+
+				for _, namespace := range properties.Namespaces {
+					key := syncKey{clusterID: clusterID, namespace: namespace, secretName: secretName}
+					desired[key] = true
+
+					record := recordMap[key]
+					if record == nil {
+						record = &SyncedSecretModel{
+							ClusterGroupID: clusterGroup.Id,
+							ClusterID:      clusterID,
+							Namespace:      namespace,
+							SecretName:     secretName,
+						}
+					}
+
+					err := m.syncSecret(ctx, record, cluster, orgSecret, secretErr)
+					if err != nil {
+						errs = append(errs, err)
+					}
+				}
+			}
+		}
+	}
+
+	for _, record := range records {
+		if desired[keyOf(record)] {
+			continue
+		}
+
+		err := m.removeSecret(ctx, record)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
+func (m *Manager) syncSecret(ctx context.Context, record *SyncedSecretModel, cluster api.Cluster, orgSecret *secret.SecretItemResponse, secretErr error) error {
+	if secretErr != nil {
+		record.Status = StatusFailed
+		record.StatusMessage = secretErr.Error()
+
+		return m.repository.Save(record)
+	}
+
+	hash := secretHash(orgSecret)
+	if record.Status == StatusSynced && record.SecretHash == hash {
+		return nil
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"clusterName": cluster.GetName(),
+		"namespace":   record.Namespace,
+		"secretName":  record.SecretName,
+	}).Info("sync secret to cluster")
+
+	var syncErr error
+	err := m.secretWriter.Apply(ctx, cluster, record.Namespace, orgSecret)
+	if err != nil {
+		syncErr = errors.WrapIfWithDetails(err, "could not sync secret to cluster",
+			"clusterName", cluster.GetName(), "namespace", record.Namespace, "secretName", record.SecretName)
+
+		record.Status = StatusFailed
+		record.StatusMessage = err.Error()
+	} else {
+		record.SecretHash = hash
+		record.Status = StatusSynced
+		record.StatusMessage = ""
+	}
+
+	err = m.repository.Save(record)
+	if err != nil {
+		return errors.Combine(syncErr, err)
+	}
+
+	return syncErr
+}
+
+func (m *Manager) removeSecret(ctx context.Context, record *SyncedSecretModel) error {
+	cluster, err := m.clusterGetter.GetClusterByIDOnly(ctx, record.ClusterID)
+	if err != nil {
+		// the cluster is gone along with its secrets
+		m.logger.WithField("clusterID", record.ClusterID).Debug("cluster not found, skip removing synced secret")
+
+		return m.repository.Delete(record)
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"clusterName": cluster.GetName(),
+		"namespace":   record.Namespace,
+		"secretName":  record.SecretName,
+	}).Info("remove synced secret from cluster")
+
+	err = m.secretWriter.Delete(ctx, cluster, record.Namespace, record.SecretName)
+	if err != nil {
+		record.Status = StatusFailed
+		record.StatusMessage = err.Error()
+		if err := m.repository.Save(record); err != nil {
+			m.errorHandler.Handle(err)
+		}
+
+		return errors.WrapIfWithDetails(err, "could not remove synced secret from cluster",
+			"clusterName", cluster.GetName(), "namespace", record.Namespace, "secretName", record.SecretName)
+	}
+
+	return m.repository.Delete(record)
+}
+
+// ValidateState validates the feature state on cluster group member changes
+func (m *Manager) ValidateState(featureState api.Feature) error {
+	return nil
+}
+
+// ValidateProperties validates the feature properties: every selected secret has to exist in the organization
+func (m *Manager) ValidateProperties(clusterGroup api.ClusterGroup, currentProperties, properties interface{}) error {
+	featureProperties, err := decodeProperties(properties)
+	if err != nil {
+		return err
+	}
+
+	if len(featureProperties.Secrets) == 0 {
+		return errors.New("at least one secret is required")
+	}
+
+	if len(featureProperties.Namespaces) == 0 {
+		return errors.New("at least one namespace is required")
+	}
+
+	for _, secretName := range featureProperties.Secrets {
+		_, err := m.getSecret(clusterGroup.OrganizationID, secretName)
+		if errors.Is(err, secret.ErrSecretNotExists) {
+			return errors.Errorf("secret %q not found", secretName)
+		} else if err != nil {
+			return errors.WrapIfWithDetails(err, "could not get secret", "secretName", secretName)
+		}
+	}
+
+	return nil
+}
+
+// getSecret returns an organization secret unless it is managed by Pipeline:
+// hidden and read only secrets cannot be synchronized to clusters.
+func (m *Manager) getSecret(organizationID uint, name string) (*secret.SecretItemResponse, error) {
+	item, err := m.secretStore.GetByName(organizationID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range item.Tags {
+		if tag == secret.TagBanzaiHidden || tag == secret.TagBanzaiReadonly {
+			return nil, errors.NewWithDetails("secret cannot be synchronized", "secretName", name, "tag", tag)
+		}
+	}
+
+	return item, nil
+}
+
+// GetMembersStatus returns the synchronization status of every member cluster
+func (m *Manager) GetMembersStatus(featureState api.Feature) (map[uint]string, error) {
+	statusMap := make(map[uint]string, 0)
+
+	properties, err := decodeProperties(featureState.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := m.repository.FindAll(featureState.ClusterGroup.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	synced := make(map[uint]int)
+	failures := make(map[uint][]string)
+	for _, record := range records {
+		switch record.Status {
+		case StatusSynced:
+			synced[record.ClusterID]++
+		case StatusFailed:
+			failures[record.ClusterID] = append(failures[record.ClusterID],
+				fmt.Sprintf("%s/%s: %s", record.Namespace, record.SecretName, record.StatusMessage))
+		}
+	}
+
+	expected := len(properties.Secrets) * len(properties.Namespaces)
+	for clusterID := range featureState.ClusterGroup.Clusters {
+		switch {
+		case len(failures[clusterID]) > 0:
+			statusMap[clusterID] = StatusFailed + ": " + strings.Join(failures[clusterID], "; ")
+		case synced[clusterID] >= expected:
+			statusMap[clusterID] = StatusSynced
+		default:
+			statusMap[clusterID] = StatusPending
+		}
+	}
+
+	return statusMap, nil
+}
+
+func decodeProperties(properties interface{}) (FeatureProperties, error) {
+	var featureProperties FeatureProperties
+	if properties == nil {
+		return featureProperties, nil
+	}
+
+	err := mapstructure.Decode(properties, &featureProperties)
+	if err != nil {
+		return featureProperties, errors.WrapIf(err, "could not decode secret sync properties")
+	}
+
+	return featureProperties, nil
+}
+
+// secretHash returns a digest of the secret content to detect secret changes
+func secretHash(s *secret.SecretItemResponse) string {
+	keys := make([]string, 0, len(s.Values))
+	for key := range s.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", s.Type)
+	for _, key := range keys {
+		fmt.Fprintf(h, "%s=%s\n", key, s.Values[key])
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func sortedClusterIDs(clusters map[uint]api.Cluster) []uint {
+	ids := make([]uint, 0, len(clusters))
+	for id := range clusters {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
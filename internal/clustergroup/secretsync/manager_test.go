@@ -0,0 +1,228 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsync
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	//  SQLite driver used for integration test
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+	"github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+type testCluster struct {
+	id   uint
+	name string
+}
+
+func (c testCluster) GetID() uint                   { return c.id }
+func (c testCluster) GetOrganizationId() uint       { return 1 }
+func (c testCluster) GetCloud() string              { return "amazon" }
+func (c testCluster) GetDistribution() string       { return "eks" }
+func (c testCluster) GetLocation() string           { return "eu-west-1" }
+func (c testCluster) GetName() string               { return c.name }
+func (c testCluster) GetK8sConfig() ([]byte, error) { return nil, nil }
+func (c testCluster) GetStatus() (*cluster.GetClusterStatusResponse, error) {
+	return &cluster.GetClusterStatusResponse{Status: cluster.Running}, nil
+}
+func (c testCluster) IsReady() (bool, error) { return true, nil }
+
+type testClusterGetter struct {
+	api.ClusterGetter
+
+	clusters map[uint]api.Cluster
+}
+
+func (g testClusterGetter) GetClusterByIDOnly(ctx context.Context, clusterID uint) (api.Cluster, error) {
+	if c, ok := g.clusters[clusterID]; ok {
+		return c, nil
+	}
+
+	return nil, errors.New("cluster not found")
+}
+
+type testSecretStore map[string]*secret.SecretItemResponse
+
+func (s testSecretStore) GetByName(organizationID uint, name string) (*secret.SecretItemResponse, error) {
+	if item, ok := s[name]; ok {
+		return item, nil
+	}
+
+	return nil, secret.ErrSecretNotExists
+}
+
+type testSecretWriter struct {
+	secrets map[string]map[string]string
+	applied int
+}
+
+func (w *testSecretWriter) Apply(ctx context.Context, cluster api.Cluster, namespace string, s *secret.SecretItemResponse) error {
+	w.applied++
+	w.secrets[cluster.GetName()+"/"+namespace+"/"+s.Name] = s.Values
+
+	return nil
+}
+
+func (w *testSecretWriter) Delete(ctx context.Context, cluster api.Cluster, namespace string, secretName string) error {
+	delete(w.secrets, cluster.GetName()+"/"+namespace+"/"+secretName)
+
+	return nil
+}
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	err = Migrate(db, logger)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestManager_ReconcileState(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	clusterA := testCluster{id: 1, name: "cluster-a"}
+	clusterB := testCluster{id: 2, name: "cluster-b"}
+
+	secrets := testSecretStore{
+		"registry": {Name: "registry", Type: "generic", Values: map[string]string{"password": "secret"}},
+	}
+	writer := &testSecretWriter{secrets: make(map[string]map[string]string)}
+
+	manager := NewManager(
+		NewSyncedSecretRepository(setUpDatabase(t)),
+		testClusterGetter{clusters: map[uint]api.Cluster{1: clusterA, 2: clusterB}},
+		secrets,
+		writer,
+		logger,
+		emperror.NewNoopHandler(),
+	)
+
+	feature := api.Feature{
+		Name:    FeatureName,
+		Enabled: true,
+		ClusterGroup: api.ClusterGroup{
+			Id:             1,
+			OrganizationID: 1,
+			Clusters:       map[uint]api.Cluster{1: clusterA, 2: clusterB},
+		},
+		Properties: map[string]interface{}{
+			"secrets":    []interface{}{"registry"},
+			"namespaces": []interface{}{"default"},
+		},
+	}
+
+	t.Run("SyncToMembers", func(t *testing.T) {
+		require.NoError(t, manager.ReconcileState(feature))
+
+		assert.Len(t, writer.secrets, 2)
+		assert.Equal(t, 2, writer.applied)
+
+		status, err := manager.GetMembersStatus(feature)
+		require.NoError(t, err)
+		assert.Equal(t, map[uint]string{1: StatusSynced, 2: StatusSynced}, status)
+	})
+
+	t.Run("SkipUnchanged", func(t *testing.T) {
+		require.NoError(t, manager.ReconcileState(feature))
+
+		assert.Equal(t, 2, writer.applied)
+	})
+
+	t.Run("UpdateChanged", func(t *testing.T) {
+		secrets["registry"].Values = map[string]string{"password": "rotated"}
+
+		require.NoError(t, manager.ReconcileState(feature))
+
+		assert.Equal(t, 4, writer.applied)
+		assert.Equal(t, "rotated", writer.secrets["cluster-a/default/registry"]["password"])
+	})
+
+	t.Run("RemoveFromLeavingMember", func(t *testing.T) {
+		feature.ClusterGroup.Clusters = map[uint]api.Cluster{1: clusterA}
+
+		require.NoError(t, manager.ReconcileState(feature))
+
+		assert.Contains(t, writer.secrets, "cluster-a/default/registry")
+		assert.NotContains(t, writer.secrets, "cluster-b/default/registry")
+	})
+
+	t.Run("MissingSecret", func(t *testing.T) {
+		feature.Properties = map[string]interface{}{
+			"secrets":    []interface{}{"registry", "missing"},
+			"namespaces": []interface{}{"default"},
+		}
+
+		assert.Error(t, manager.ReconcileState(feature))
+
+		status, err := manager.GetMembersStatus(feature)
+		require.NoError(t, err)
+		assert.Contains(t, status[1], StatusFailed)
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		feature.Enabled = false
+
+		require.NoError(t, manager.ReconcileState(feature))
+
+		assert.Empty(t, writer.secrets)
+	})
+}
+
+func TestManager_ValidateProperties(t *testing.T) {
+	secrets := testSecretStore{
+		"registry": {Name: "registry"},
+		"hidden":   {Name: "hidden", Tags: []string{secret.TagBanzaiHidden}},
+		"readonly": {Name: "readonly", Tags: []string{secret.TagBanzaiReadonly}},
+	}
+	manager := NewManager(nil, nil, secrets, nil, logrus.New(), emperror.NewNoopHandler())
+	clusterGroup := api.ClusterGroup{OrganizationID: 1}
+
+	assert.NoError(t, manager.ValidateProperties(clusterGroup, nil, map[string]interface{}{
+		"secrets":    []interface{}{"registry"},
+		"namespaces": []interface{}{"default"},
+	}))
+	assert.Error(t, manager.ValidateProperties(clusterGroup, nil, map[string]interface{}{
+		"secrets":    []interface{}{"missing"},
+		"namespaces": []interface{}{"default"},
+	}))
+	assert.Error(t, manager.ValidateProperties(clusterGroup, nil, map[string]interface{}{
+		"secrets": []interface{}{"registry"},
+	}))
+	assert.Error(t, manager.ValidateProperties(clusterGroup, nil, map[string]interface{}{
+		"secrets":    []interface{}{"hidden"},
+		"namespaces": []interface{}{"default"},
+	}))
+	assert.Error(t, manager.ValidateProperties(clusterGroup, nil, map[string]interface{}{
+		"secrets":    []interface{}{"readonly"},
+		"namespaces": []interface{}{"default"},
+	}))
+}
@@ -0,0 +1,66 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsync
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// TableName constants
+const (
+	syncedSecretsTableName = "clustergroup_synced_secrets"
+)
+
+// SyncedSecretModel describes an organization secret synchronized to a namespace of a member cluster.
+type SyncedSecretModel struct {
+	ID             uint `gorm:"primary_key"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ClusterGroupID uint   `gorm:"unique_index:idx_clustergroup_synced_secret"`
+	ClusterID      uint   `gorm:"unique_index:idx_clustergroup_synced_secret"`
+	Namespace      string `gorm:"unique_index:idx_clustergroup_synced_secret"`
+	SecretName     string `gorm:"unique_index:idx_clustergroup_synced_secret"`
+	SecretHash     string
+	Status         string
+	StatusMessage  string `sql:"type:text"`
+}
+
+// TableName changes the default table name.
+func (SyncedSecretModel) TableName() string {
+	return syncedSecretsTableName
+}
+
+// Migrate executes the table migrations for the cluster group secret sync module.
+func Migrate(db *gorm.DB, logger logrus.FieldLogger) error {
+	tables := []interface{}{
+		&SyncedSecretModel{},
+	}
+
+	var tableNames string
+	for _, table := range tables {
+		tableNames += fmt.Sprintf(" %s", db.NewScope(table).TableName())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"table_names": strings.TrimSpace(tableNames),
+	}).Info("migrating model tables")
+
+	return db.AutoMigrate(tables...).Error
+}
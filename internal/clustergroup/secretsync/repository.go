@@ -0,0 +1,68 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsync
+
+import (
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+)
+
+// SyncedSecretRepository persists the state of secrets synchronized to cluster group members.
+type SyncedSecretRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncedSecretRepository returns a new SyncedSecretRepository instance.
+func NewSyncedSecretRepository(db *gorm.DB) *SyncedSecretRepository {
+	return &SyncedSecretRepository{
+		db: db,
+	}
+}
+
+// FindAll returns every synced secret of a cluster group
+func (r *SyncedSecretRepository) FindAll(clusterGroupID uint) ([]*SyncedSecretModel, error) {
+	var results []*SyncedSecretModel
+
+	err := r.db.Where(SyncedSecretModel{
+		ClusterGroupID: clusterGroupID,
+	}).Order("id").Find(&results).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not find synced secrets", "clusterGroupID", clusterGroupID)
+	}
+
+	return results, nil
+}
+
+// Save persists a synced secret
+func (r *SyncedSecretRepository) Save(model *SyncedSecretModel) error {
+	err := r.db.Save(model).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not save synced secret",
+			"clusterGroupID", model.ClusterGroupID, "clusterID", model.ClusterID, "secretName", model.SecretName)
+	}
+
+	return nil
+}
+
+// Delete deletes a synced secret
+func (r *SyncedSecretRepository) Delete(model *SyncedSecretModel) error {
+	err := r.db.Delete(model).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not delete synced secret",
+			"clusterGroupID", model.ClusterGroupID, "clusterID", model.ClusterID, "secretName", model.SecretName)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsync
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/emperror"
+	"github.com/sirupsen/logrus"
+)
+
+type featureReconciler interface {
+	ReconcileFeatureOnAllGroups(ctx context.Context, featureName string) error
+}
+
+// RunSync periodically reconciles the secret sync feature of every cluster group
+// to propagate secret changes to the member clusters.
+func RunSync(
+	ctx context.Context,
+	reconciler featureReconciler,
+	interval time.Duration,
+	logger logrus.FieldLogger,
+	errorHandler emperror.Handler,
+) {
+	if interval.Seconds() < 1 {
+		logger.WithField("interval", interval.Seconds()).Error("invalid secret sync interval")
+		return
+	}
+
+	logger.WithField("interval", interval).Info("cluster group secret synchronisation starting")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := reconciler.ReconcileFeatureOnAllGroups(ctx, FeatureName)
+			if err != nil {
+				errorHandler.Handle(err)
+			}
+		case <-ctx.Done():
+			logger.Info("cluster group secret synchronisation stopped")
+			return
+		}
+	}
+}
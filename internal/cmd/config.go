@@ -34,6 +34,8 @@ import (
 	"github.com/banzaicloud/pipeline/internal/platform/cadence"
 	"github.com/banzaicloud/pipeline/internal/platform/database"
 	"github.com/banzaicloud/pipeline/internal/platform/log"
+	"github.com/banzaicloud/pipeline/internal/platform/watermill"
+	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
 	"github.com/banzaicloud/pipeline/pkg/cluster"
 	"github.com/banzaicloud/pipeline/pkg/values"
 )
@@ -49,6 +51,8 @@ type Config struct {
 	// Cluster configuration
 	Cluster ClusterConfig
 
+	ClusterTemplate ClusterTemplateConfig
+
 	// Database configuration
 	Database struct {
 		database.Config `mapstructure:",squash"`
@@ -63,6 +67,11 @@ type Config struct {
 
 	Distribution DistributionConfig
 
+	// Event bus configuration
+	EventBus watermill.Config
+
+	Gitops GitopsConfig
+
 	Helm helm.Config
 
 	Kubernetes struct {
@@ -78,11 +87,18 @@ type Config struct {
 		TLS struct {
 			DefaultValidity time.Duration
 		}
+
+		Rotation SecretRotationConfig
+
+		Store secretadapter.Config
 	}
 
 	// Telemetry configuration
 	Telemetry TelemetryConfig
 
+	// Webhook configuration
+	Webhook WebhookConfig
+
 	// temporary switch to control the integrated service implementation
 	IntegratedService struct {
 		V2 bool
@@ -100,12 +116,23 @@ func (c Config) Validate() error {
 
 	err = errors.Append(err, c.Cluster.Validate())
 
+	err = errors.Append(err, c.ClusterTemplate.Validate())
+
 	err = errors.Append(err, c.Database.Validate())
 
+	err = errors.Append(err, c.EventBus.Validate())
+
+	err = errors.Append(err, c.Gitops.Validate())
+
 	err = errors.Append(err, c.Telemetry.Validate())
 
+	err = errors.Append(err, c.Webhook.Validate())
+
 	err = errors.Append(err, c.Helm.Validate())
 
+	err = errors.Append(err, c.Secret.Rotation.Validate())
+	err = errors.Append(err, c.Secret.Store.Validate())
+
 	return err
 }
 
@@ -176,6 +203,8 @@ type ClusterConfig struct {
 
 	Expiry ClusterExpiryConfig
 
+	Group ClusterGroupConfig
+
 	Ingress ClusterIngressConfig
 
 	Labels clusterconfig.LabelConfig
@@ -193,6 +222,8 @@ type ClusterConfig struct {
 	// Posthook configs
 	PostHook cluster.PostHookConfig
 
+	Schedule ClusterScheduleConfig
+
 	SecurityScan ClusterSecurityScanConfig
 
 	Vault ClusterVaultConfig
@@ -350,6 +381,13 @@ type ClusterExpiryConfig struct {
 	Enabled bool
 }
 
+type ClusterGroupConfig struct {
+	SecretSync struct {
+		// Interval of propagating secret changes to cluster group members
+		Interval time.Duration
+	}
+}
+
 type ClusterIngressConfig struct {
 	Enabled bool
 
@@ -400,6 +438,10 @@ func (c ClusterMonitoringConfig) Validate() error {
 	return errs
 }
 
+type ClusterScheduleConfig struct {
+	Enabled bool
+}
+
 // ClusterSecurityScanConfig contains cluster security scan configuration.
 type ClusterSecurityScanConfig struct {
 	Enabled bool
@@ -498,6 +540,109 @@ func (c TelemetryConfig) Validate() error {
 	return err
 }
 
+// SecretRotationConfig configures the periodic rotation of secrets having a rotation policy.
+type SecretRotationConfig struct {
+	Enabled bool
+
+	// Schedule is the cron schedule of checking the rotation policies
+	Schedule string
+
+	// RevocationGracePeriod is the time after which the previous values of a rotated secret are revoked (eg. cloud access keys)
+	RevocationGracePeriod time.Duration
+}
+
+// Validate validates the configuration.
+func (c SecretRotationConfig) Validate() error {
+	var err error
+
+	if c.Enabled && c.Schedule == "" {
+		err = errors.Append(err, errors.New("secret rotation schedule is required"))
+	}
+
+	if c.RevocationGracePeriod < 0 {
+		err = errors.Append(err, errors.New("secret revocation grace period cannot be negative"))
+	}
+
+	return err
+}
+
+// ClusterTemplateConfig contains cluster template configuration.
+type ClusterTemplateConfig struct {
+	// ReconcileSchedule is the cron schedule of applying the integrated services and releases of new clusters.
+	ReconcileSchedule string
+}
+
+// Validate validates the configuration.
+func (c ClusterTemplateConfig) Validate() error {
+	if c.ReconcileSchedule == "" {
+		return errors.New("cluster template reconcile schedule is required")
+	}
+
+	return nil
+}
+
+// GitopsConfig contains GitOps configuration.
+type GitopsConfig struct {
+	// SyncSchedule is the cron schedule of checking which repositories are due for synchronization.
+	SyncSchedule string
+
+	// WorkDir is the directory the working copies of repositories are checked out to.
+	WorkDir string
+
+	// AllowInsecureRepositories allows local paths, file:// and http:// repository URLs
+	// (eg. a local git server during development).
+	AllowInsecureRepositories bool
+}
+
+// Validate validates the configuration.
+func (c GitopsConfig) Validate() error {
+	var err error
+
+	if c.SyncSchedule == "" {
+		err = errors.Append(err, errors.New("gitops sync schedule is required"))
+	}
+
+	if c.WorkDir == "" {
+		err = errors.Append(err, errors.New("gitops work directory is required"))
+	}
+
+	return err
+}
+
+// WebhookConfig contains outgoing webhook configuration.
+type WebhookConfig struct {
+	// MaxAttempts is the number of times a delivery is attempted before it is marked as failed.
+	MaxAttempts int
+
+	// RetryInterval is the initial time to wait between attempts. It doubles after every attempt.
+	RetryInterval time.Duration
+
+	// Timeout is the timeout of a single delivery attempt.
+	Timeout time.Duration
+
+	// AllowPrivateNetworks allows subscriptions to loopback, private and link-local addresses.
+	AllowPrivateNetworks bool
+}
+
+// Validate validates the configuration.
+func (c WebhookConfig) Validate() error {
+	var err error
+
+	if c.MaxAttempts < 1 {
+		err = errors.Append(err, errors.New("webhook max attempts must be at least 1"))
+	}
+
+	if c.RetryInterval <= 0 {
+		err = errors.Append(err, errors.New("webhook retry interval must be positive"))
+	}
+
+	if c.Timeout <= 0 {
+		err = errors.Append(err, errors.New("webhook timeout must be positive"))
+	}
+
+	return err
+}
+
 type DistributionConfig struct {
 	EKS struct {
 		TemplateLocation            string
@@ -563,6 +708,16 @@ func Configure(v *viper.Viper, p *pflag.FlagSet) {
 	})
 	v.SetDefault("database::queryLog", false)
 
+	// Event bus configuration
+	v.SetDefault("eventBus::driver", watermill.DriverGoChannel)
+	v.SetDefault("eventBus::consumerGroup", "")
+	v.SetDefault("eventBus::sql::pollInterval", time.Second)
+	v.SetDefault("eventBus::sql::retryInterval", 10*time.Second)
+	v.SetDefault("eventBus::sql::visibilityTimeout", time.Minute)
+	v.SetDefault("eventBus::sql::retention", 7*24*time.Hour)
+	v.SetDefault("eventBus::kafka::brokers", []string{})
+	v.SetDefault("eventBus::kafka::retryInterval", 10*time.Second)
+
 	// Cadence configuration
 	v.SetDefault("cadence::host", "")
 	v.SetDefault("cadence::port", 7933)
@@ -787,6 +942,10 @@ rbac:
 
 	v.SetDefault("cluster::expiry::enabled", true)
 
+	v.SetDefault("cluster::schedule::enabled", true)
+
+	v.SetDefault("cluster::group::secretSync::interval", "1m")
+
 	// ingress controller config
 	v.SetDefault("cluster::posthook::ingress::enabled", true)
 	v.SetDefault("cluster::posthook::ingress::chart", "banzaicloud-stable/pipeline-cluster-ingress")
@@ -873,6 +1032,8 @@ traefik:
 	v.SetDefault("helm::repositories::bitnami", "https://charts.bitnami.com/bitnami")
 	v.SetDefault("helm::repositories::loki", "https://grafana.github.io/helm-charts")
 	v.SetDefault("helm::repositories::prometheus-community", "https://prometheus-community.github.io/helm-charts")
+	v.SetDefault("helm::drift::enabled", true)
+	v.SetDefault("helm::drift::schedule", "*/15 * * * *")
 
 	// Cloud configuration
 	v.SetDefault("cloud::amazon::defaultRegion", "us-west-1")
@@ -897,6 +1058,13 @@ traefik:
 	v.SetDefault("cloudinfo::endpoint", "")
 
 	v.SetDefault("secret::tls::defaultValidity", "8760h") // 1 year
+	v.SetDefault("secret::rotation::enabled", true)
+	v.SetDefault("secret::rotation::schedule", "0 * * * *")
+	v.SetDefault("secret::rotation::revocationGracePeriod", time.Hour)
+	v.SetDefault("secret::store::backend", "vault")
+	v.SetDefault("secret::store::vault::mountPath", "secret")
+	v.SetDefault("secret::store::database::masterKeyFile", "")
+	v.SetDefault("secret::store::kubernetes::namespace", "pipeline-system")
 
 	// Telemetry configuration
 	v.SetDefault("telemetry::enabled", false)
@@ -905,6 +1073,18 @@ traefik:
 	v.SetDefault("telemetry::addr", "127.0.0.1:9900")
 	v.SetDefault("telemetry::debug", true)
 
+	v.SetDefault("clusterTemplate::reconcileSchedule", "* * * * *")
+
+	v.SetDefault("gitops::syncSchedule", "* * * * *")
+	v.SetDefault("gitops::workDir", "./var/gitops")
+	v.SetDefault("gitops::allowInsecureRepositories", false)
+
+	// Webhook configuration
+	v.SetDefault("webhook::maxAttempts", 5)
+	v.SetDefault("webhook::retryInterval", 10*time.Second)
+	v.SetDefault("webhook::timeout", 10*time.Second)
+	v.SetDefault("webhook::allowPrivateNetworks", false)
+
 	v.SetDefault("integratedservice::v2", false)
 
 	// Integrated Service Operator
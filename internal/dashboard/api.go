@@ -51,6 +51,7 @@ type DashboardAPI struct {
 
 	authConfig         auth.Config
 	clientSecretGetter clusterAuth.ClusterClientSecretGetter
+	clusterAccess      cluster.AccessChecker
 }
 
 func NewDashboardAPI(
@@ -60,6 +61,7 @@ func NewDashboardAPI(
 	errorHandler emperror.Handler,
 	authConfig auth.Config,
 	clientSecretGetter clusterAuth.ClusterClientSecretGetter,
+	clusterAccess cluster.AccessChecker,
 ) *DashboardAPI {
 	return &DashboardAPI{
 		clusterManager:      clusterManager,
@@ -68,6 +70,7 @@ func NewDashboardAPI(
 		errorHandler:        errorHandler,
 		authConfig:          authConfig,
 		clientSecretGetter:  clientSecretGetter,
+		clusterAccess:       clusterAccess,
 	}
 }
 
@@ -84,7 +87,9 @@ func NewDashboardAPI(
 func (d *DashboardAPI) GetDashboard(c *gin.Context) {
 	organizationID := auth.GetCurrentOrganization(c.Request).ID
 
-	clusters, err := d.clusterManager.GetClusters(context.Background(), organizationID)
+	ctx := c.Request.Context()
+
+	clusters, err := d.clusterManager.GetClusters(ctx, organizationID)
 	if err != nil {
 		d.logger.Errorf("error fetching clusters: %s", err.Error())
 		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
@@ -101,6 +106,15 @@ func (d *DashboardAPI) GetDashboard(c *gin.Context) {
 
 	i := 0
 	for _, cl := range clusters {
+		ok, err := d.clusterAccess.CanAccessCluster(ctx, cl.GetID())
+		if err != nil {
+			d.logger.WithField("clusterId", cl.GetID()).Errorf("checking cluster access failed: %s", err.Error())
+
+			continue
+		} else if !ok {
+			continue
+		}
+
 		go func(commonCluster cluster.CommonCluster) {
 			logger := d.logger.WithField("clusterId", commonCluster.GetID())
 			cluster, partial := d.getClusterDashboardInfo(c.Request.Context(), logger, commonCluster, organizationID)
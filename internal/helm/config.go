@@ -14,16 +14,34 @@
 
 package helm
 
-import "path/filepath"
+import (
+	"path/filepath"
+
+	"emperror.dev/errors"
+)
 
 type Config struct {
 	Home string
 
 	Repositories map[string]string
+
+	Drift DriftConfig
+}
+
+// DriftConfig configures the periodic release drift detection.
+type DriftConfig struct {
+	Enabled bool
+
+	// Schedule is the cron schedule of the drift detection
+	Schedule string
 }
 
 // Validate validates the configuration.
 func (c Config) Validate() error {
+	if c.Drift.Enabled && c.Drift.Schedule == "" {
+		return errors.New("helm drift detection schedule is required")
+	}
+
 	return nil
 }
 
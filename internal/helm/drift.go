@@ -0,0 +1,291 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// DriftedResource describes a release resource whose live state differs from the rendered manifest of the release
+type DriftedResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Missing is set when the resource can not be found in the cluster
+	Missing bool `json:"missing,omitempty"`
+	// Fields lists the paths of the fields differing from the manifest
+	Fields []string `json:"fields,omitempty"`
+}
+
+// ReleaseDrift holds the result of the latest drift detection of a release
+type ReleaseDrift struct {
+	ClusterID   uint
+	ReleaseName string
+	Namespace   string
+	// Revision is the release revision the live objects were compared with
+	Revision  int32
+	Drifted   bool
+	Resources []DriftedResource
+	// SelfHeal signals whether the release is to be re-applied when drift is detected
+	SelfHeal  bool
+	CheckedAt time.Time
+	HealedAt  *time.Time
+}
+
+// DriftNotFoundError is returned when there is no drift information for a release.
+type DriftNotFoundError struct {
+	ClusterID   uint
+	ReleaseName string
+}
+
+// Error implements the error interface.
+func (e DriftNotFoundError) Error() string {
+	return "release drift not found"
+}
+
+// Details returns error details.
+func (e DriftNotFoundError) Details() []interface{} {
+	return []interface{}{"clusterId", e.ClusterID, "releaseName", e.ReleaseName}
+}
+
+// ServiceError tells the consumer that this is a business error and it should be returned to the client.
+// Non-service errors are usually translated into "internal" errors.
+func (DriftNotFoundError) ServiceError() bool {
+	return true
+}
+
+func (DriftNotFoundError) NotFound() bool {
+	return true
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock:testOnly=true
+
+// DriftService exposes the drift state and the self-heal settings of releases
+type DriftService interface {
+	// ListReleaseDrifts lists the latest drift detection results of the releases on the cluster
+	ListReleaseDrifts(ctx context.Context, organizationID uint, clusterID uint) (drifts []ReleaseDrift, err error)
+	// GetReleaseDrift retrieves the latest drift detection result of the release
+	GetReleaseDrift(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (drift ReleaseDrift, err error)
+	// SetReleaseSelfHeal turns self-healing of the release on or off
+	SetReleaseSelfHeal(ctx context.Context, organizationID uint, clusterID uint, releaseName string, selfHeal bool, options Options) error
+}
+
+// +testify:mock:testOnly=true
+
+// DriftStore persists release drift detection results and self-heal settings
+type DriftStore interface {
+	// Get retrieves the drift record of the release (in any namespace if the namespace is empty)
+	Get(ctx context.Context, clusterID uint, releaseName string, namespace string) (ReleaseDrift, error)
+	// List retrieves the drift records of the releases on the cluster
+	List(ctx context.Context, clusterID uint) ([]ReleaseDrift, error)
+	// Save persists a detection result leaving the self-heal setting of the release intact
+	Save(ctx context.Context, drift ReleaseDrift) error
+	// SetSelfHeal sets the self-heal setting of the release creating its record if necessary
+	SetSelfHeal(ctx context.Context, clusterID uint, releaseName string, namespace string, selfHeal bool) error
+	// Prune removes the records of the releases not present in the passed in list anymore
+	Prune(ctx context.Context, clusterID uint, releases []Release) error
+}
+
+// +testify:mock:testOnly=true
+
+// ManifestComparer compares rendered manifests with the live objects in a cluster
+type ManifestComparer interface {
+	// CompareManifest returns the resources of the manifest that differ from their live counterparts
+	CompareManifest(ctx context.Context, clusterID uint, namespace string, manifest string) ([]DriftedResource, error)
+}
+
+// driftService component struct implementing the DriftService interface
+type driftService struct {
+	helmFacade Service
+	store      DriftStore
+}
+
+// NewDriftService returns a new DriftService.
+func NewDriftService(helmService Service, store DriftStore) DriftService {
+	return driftService{
+		helmFacade: helmService,
+		store:      store,
+	}
+}
+
+func (s driftService) ListReleaseDrifts(ctx context.Context, organizationID uint, clusterID uint) ([]ReleaseDrift, error) {
+	drifts, err := s.store.List(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list release drifts", "clusterId", clusterID)
+	}
+
+	return drifts, nil
+}
+
+func (s driftService) GetReleaseDrift(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (ReleaseDrift, error) {
+	drift, err := s.store.Get(ctx, clusterID, releaseName, options.Namespace)
+	if err != nil {
+		return ReleaseDrift{}, errors.WrapIfWithDetails(err, "failed to retrieve release drift",
+			"clusterId", clusterID, "releaseName", releaseName)
+	}
+
+	return drift, nil
+}
+
+func (s driftService) SetReleaseSelfHeal(ctx context.Context, organizationID uint, clusterID uint, releaseName string, selfHeal bool, options Options) error {
+	// make sure the release exists and find out its namespace
+	release, err := s.helmFacade.GetRelease(ctx, organizationID, clusterID, releaseName, options)
+	if err != nil {
+		return errors.WrapIf(err, "failed to retrieve release")
+	}
+
+	if err := s.store.SetSelfHeal(ctx, clusterID, release.ReleaseName, release.Namespace, selfHeal); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to set release self-heal",
+			"clusterId", clusterID, "releaseName", releaseName)
+	}
+
+	return nil
+}
+
+// DriftDetector compares the releases on a cluster with their live objects and heals the drifted ones opted in
+type DriftDetector struct {
+	helmFacade      Service
+	unifiedReleaser UnifiedReleaser
+	comparer        ManifestComparer
+	store           DriftStore
+	logger          Logger
+}
+
+// NewDriftDetector returns a new DriftDetector.
+func NewDriftDetector(
+	helmService Service,
+	unifiedReleaser UnifiedReleaser,
+	comparer ManifestComparer,
+	store DriftStore,
+	logger Logger,
+) DriftDetector {
+	return DriftDetector{
+		helmFacade:      helmService,
+		unifiedReleaser: unifiedReleaser,
+		comparer:        comparer,
+		store:           store,
+		logger:          logger,
+	}
+}
+
+// DetectClusterDrift records the drift of every release on the cluster.
+// Releases with self-heal turned on are re-applied when drift is detected.
+func (d DriftDetector) DetectClusterDrift(ctx context.Context, organizationID uint, clusterID uint) error {
+	releases, err := d.helmFacade.ListReleases(ctx, organizationID, clusterID, ReleaseFilter{}, Options{})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list releases", "clusterId", clusterID)
+	}
+
+	var errs []error
+	for _, release := range releases {
+		// only deployed releases have a manifest the live objects are expected to match
+		if release.ReleaseInfo.Status != "deployed" {
+			continue
+		}
+
+		if err := d.detectReleaseDrift(ctx, organizationID, clusterID, release); err != nil {
+			errs = append(errs, errors.WithDetails(err, "releaseName", release.ReleaseName, "namespace", release.Namespace))
+		}
+	}
+
+	if err := d.store.Prune(ctx, clusterID, releases); err != nil {
+		errs = append(errs, errors.WrapIf(err, "failed to prune release drifts"))
+	}
+
+	return errors.Combine(errs...)
+}
+
+func (d DriftDetector) detectReleaseDrift(ctx context.Context, organizationID uint, clusterID uint, release Release) error {
+	deployment, err := d.unifiedReleaser.GetDeployment(ctx, clusterID, release.ReleaseName, release.Namespace)
+	if err != nil {
+		return errors.WrapIf(err, "failed to retrieve deployment")
+	}
+
+	resources, err := d.comparer.CompareManifest(ctx, clusterID, deployment.Namespace, deployment.Manifest)
+	if err != nil {
+		return errors.WrapIf(err, "failed to compare release manifest with live objects")
+	}
+
+	drift := ReleaseDrift{
+		ClusterID:   clusterID,
+		ReleaseName: deployment.ReleaseName,
+		Namespace:   deployment.Namespace,
+		Revision:    deployment.Version,
+		Drifted:     len(resources) > 0,
+		Resources:   resources,
+		CheckedAt:   time.Now(),
+	}
+
+	if drift.Drifted {
+		d.logger.Info("release drift detected", map[string]interface{}{
+			"clusterId":   clusterID,
+			"releaseName": drift.ReleaseName,
+			"namespace":   drift.Namespace,
+			"resources":   len(resources),
+		})
+
+		healed, err := d.heal(ctx, organizationID, drift)
+		if err != nil {
+			return err
+		}
+
+		if healed {
+			now := time.Now()
+			drift.HealedAt = &now
+		}
+	}
+
+	if err := d.store.Save(ctx, drift); err != nil {
+		return errors.WrapIf(err, "failed to save release drift")
+	}
+
+	return nil
+}
+
+// heal re-applies the drifted release if self-heal is turned on for it
+func (d DriftDetector) heal(ctx context.Context, organizationID uint, drift ReleaseDrift) (bool, error) {
+	current, err := d.store.Get(ctx, drift.ClusterID, drift.ReleaseName, drift.Namespace)
+	if err != nil {
+		if errors.As(err, &DriftNotFoundError{}) {
+			return false, nil
+		}
+
+		return false, errors.WrapIf(err, "failed to retrieve release self-heal setting")
+	}
+
+	if !current.SelfHeal || drift.Revision == 0 {
+		return false, nil
+	}
+
+	// rolling back to the deployed revision re-applies its manifest onto the live objects
+	err = d.helmFacade.RollbackRelease(ctx, organizationID, drift.ClusterID, drift.ReleaseName, drift.Revision, Options{Namespace: drift.Namespace})
+	if err != nil {
+		return false, errors.WrapIf(err, "failed to re-apply drifted release")
+	}
+
+	d.logger.Info("drifted release re-applied", map[string]interface{}{
+		"clusterId":   drift.ClusterID,
+		"releaseName": drift.ReleaseName,
+		"namespace":   drift.Namespace,
+		"revision":    drift.Revision,
+	})
+
+	return true, nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	pkgHelm "github.com/banzaicloud/pipeline/pkg/helm"
+)
+
+func TestDriftDetector_DetectClusterDrift(t *testing.T) {
+	releases := []Release{
+		{ReleaseName: "app", Namespace: "apps", ReleaseInfo: ReleaseInfo{Status: "deployed"}},
+		{ReleaseName: "broken", Namespace: "apps", ReleaseInfo: ReleaseInfo{Status: "failed"}},
+	}
+
+	drifted := []DriftedResource{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "apps", Name: "app", Fields: []string{"spec.replicas"}},
+	}
+
+	tests := []struct {
+		name     string
+		selfHeal bool
+	}{
+		{
+			name:     "drift is recorded",
+			selfHeal: false,
+		},
+		{
+			name:     "drifted release is re-applied",
+			selfHeal: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			helmService := new(MockService)
+			helmService.On("ListReleases", ctx, uint(2), uint(1), ReleaseFilter{}, Options{}).Return(releases, nil)
+
+			unifiedReleaser := new(MockUnifiedReleaser)
+			unifiedReleaser.On("GetDeployment", ctx, uint(1), "app", "apps").Return(&pkgHelm.GetDeploymentResponse{
+				ReleaseName: "app",
+				Namespace:   "apps",
+				Version:     3,
+				Manifest:    "manifest",
+			}, nil)
+
+			comparer := new(MockManifestComparer)
+			comparer.On("CompareManifest", ctx, uint(1), "apps", "manifest").Return(drifted, nil)
+
+			store := new(MockDriftStore)
+			store.On("Get", ctx, uint(1), "app", "apps").Return(ReleaseDrift{SelfHeal: test.selfHeal}, nil)
+			store.On("Save", ctx, mock.MatchedBy(func(drift ReleaseDrift) bool {
+				return drift.Drifted && drift.Revision == 3 && (drift.HealedAt != nil) == test.selfHeal
+			})).Return(nil)
+			store.On("Prune", ctx, uint(1), releases).Return(nil)
+
+			if test.selfHeal {
+				helmService.On("RollbackRelease", ctx, uint(2), uint(1), "app", int32(3), Options{Namespace: "apps"}).Return(nil)
+			}
+
+			detector := NewDriftDetector(helmService, unifiedReleaser, comparer, store, common.NoopLogger{})
+
+			err := detector.DetectClusterDrift(ctx, 2, 1)
+			require.NoError(t, err)
+
+			helmService.AssertExpectations(t)
+			unifiedReleaser.AssertExpectations(t)
+			comparer.AssertExpectations(t)
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+// releaseDriftModel describes the release drift model.
+type releaseDriftModel struct {
+	ID          uint `gorm:"primary_key"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ClusterID   uint   `gorm:"unique_index:idx_helm_release_drifts_cluster_release"`
+	Namespace   string `gorm:"unique_index:idx_helm_release_drifts_cluster_release"`
+	ReleaseName string `gorm:"unique_index:idx_helm_release_drifts_cluster_release"`
+	Revision    int32
+	Drifted     bool
+	Resources   driftedResources `gorm:"type:text"`
+	SelfHeal    bool
+	CheckedAt   *time.Time
+	HealedAt    *time.Time
+}
+
+// TableName changes the default table name.
+func (releaseDriftModel) TableName() string {
+	return "helm_release_drifts"
+}
+
+type driftedResources []helm.DriftedResource
+
+// Scan implements the sql.Scanner interface.
+func (r *driftedResources) Scan(src interface{}) error {
+	return json.Scan(src, r)
+}
+
+// Value implements the driver.Valuer interface.
+func (r driftedResources) Value() (driver.Value, error) {
+	if r == nil {
+		return json.Value([]helm.DriftedResource{})
+	}
+
+	return json.Value([]helm.DriftedResource(r))
+}
+
+type driftStore struct {
+	db     *gorm.DB
+	logger Logger
+}
+
+// NewDriftStore returns a new helm.DriftStore persisting release drifts with Gorm.
+func NewDriftStore(db *gorm.DB, logger Logger) helm.DriftStore {
+	return driftStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (s driftStore) Get(_ context.Context, clusterID uint, releaseName string, namespace string) (helm.ReleaseDrift, error) {
+	query := s.db.Where("cluster_id = ? AND release_name = ?", clusterID, releaseName)
+	if namespace != "" {
+		query = query.Where("namespace = ?", namespace)
+	}
+
+	var model releaseDriftModel
+	if err := query.First(&model).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return helm.ReleaseDrift{}, helm.DriftNotFoundError{
+				ClusterID:   clusterID,
+				ReleaseName: releaseName,
+			}
+		}
+
+		return helm.ReleaseDrift{}, errors.WrapIfWithDetails(err, "failed to get release drift",
+			"clusterId", clusterID, "releaseName", releaseName)
+	}
+
+	return toDriftDomain(model), nil
+}
+
+func (s driftStore) List(_ context.Context, clusterID uint) ([]helm.ReleaseDrift, error) {
+	var models []releaseDriftModel
+	if err := s.db.Where("cluster_id = ?", clusterID).Order("namespace, release_name").Find(&models).Error; err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list release drifts", "clusterId", clusterID)
+	}
+
+	drifts := make([]helm.ReleaseDrift, 0, len(models))
+	for _, model := range models {
+		drifts = append(drifts, toDriftDomain(model))
+	}
+
+	return drifts, nil
+}
+
+func (s driftStore) Save(_ context.Context, drift helm.ReleaseDrift) error {
+	model := releaseDriftModel{
+		ClusterID:   drift.ClusterID,
+		Namespace:   drift.Namespace,
+		ReleaseName: drift.ReleaseName,
+	}
+
+	if err := s.db.Where(&model).FirstOrInit(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get release drift",
+			"clusterId", drift.ClusterID, "releaseName", drift.ReleaseName)
+	}
+
+	checkedAt := drift.CheckedAt
+	model.Revision = drift.Revision
+	model.Drifted = drift.Drifted
+	model.Resources = drift.Resources
+	model.CheckedAt = &checkedAt
+	if drift.HealedAt != nil {
+		model.HealedAt = drift.HealedAt
+	}
+
+	if err := s.db.Save(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save release drift",
+			"clusterId", drift.ClusterID, "releaseName", drift.ReleaseName)
+	}
+
+	s.logger.Debug("saved release drift record", map[string]interface{}{
+		"clusterId":   drift.ClusterID,
+		"releaseName": drift.ReleaseName,
+		"drifted":     drift.Drifted,
+	})
+
+	return nil
+}
+
+func (s driftStore) SetSelfHeal(_ context.Context, clusterID uint, releaseName string, namespace string, selfHeal bool) error {
+	model := releaseDriftModel{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		ReleaseName: releaseName,
+	}
+
+	if err := s.db.Where(&model).FirstOrInit(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get release drift",
+			"clusterId", clusterID, "releaseName", releaseName)
+	}
+
+	model.SelfHeal = selfHeal
+
+	if err := s.db.Save(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save release self-heal setting",
+			"clusterId", clusterID, "releaseName", releaseName)
+	}
+
+	return nil
+}
+
+func (s driftStore) Prune(_ context.Context, clusterID uint, releases []helm.Release) error {
+	var models []releaseDriftModel
+	if err := s.db.Where("cluster_id = ?", clusterID).Find(&models).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list release drifts", "clusterId", clusterID)
+	}
+
+	existing := make(map[string]bool, len(releases))
+	for _, release := range releases {
+		existing[release.Namespace+"/"+release.ReleaseName] = true
+	}
+
+	for _, model := range models {
+		if existing[model.Namespace+"/"+model.ReleaseName] {
+			continue
+		}
+
+		if err := s.db.Delete(&model).Error; err != nil {
+			return errors.WrapIfWithDetails(err, "failed to delete release drift",
+				"clusterId", clusterID, "releaseName", model.ReleaseName)
+		}
+	}
+
+	return nil
+}
+
+// toDriftDomain transforms a gorm model to a domain struct
+func toDriftDomain(model releaseDriftModel) helm.ReleaseDrift {
+	drift := helm.ReleaseDrift{
+		ClusterID:   model.ClusterID,
+		ReleaseName: model.ReleaseName,
+		Namespace:   model.Namespace,
+		Revision:    model.Revision,
+		Drifted:     model.Drifted,
+		Resources:   model.Resources,
+		SelfHeal:    model.SelfHeal,
+		HealedAt:    model.HealedAt,
+	}
+
+	if model.CheckedAt != nil {
+		drift.CheckedAt = *model.CheckedAt
+	}
+
+	return drift
+}
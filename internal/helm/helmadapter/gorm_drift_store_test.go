@@ -0,0 +1,81 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+func Test_driftStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewDriftStore(setUpDatabase(t), common.NoopLogger{})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := store.Get(ctx, 1, "app", "apps")
+
+		assert.True(t, errors.As(err, &helm.DriftNotFoundError{}))
+	})
+
+	t.Run("SaveKeepsSelfHeal", func(t *testing.T) {
+		err := store.SetSelfHeal(ctx, 1, "app", "apps", true)
+		require.NoError(t, err)
+
+		checkedAt := time.Now().UTC().Truncate(time.Second)
+		err = store.Save(ctx, helm.ReleaseDrift{
+			ClusterID:   1,
+			ReleaseName: "app",
+			Namespace:   "apps",
+			Revision:    2,
+			Drifted:     true,
+			Resources: []helm.DriftedResource{
+				{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "apps", Name: "app", Fields: []string{"spec.replicas"}},
+			},
+			CheckedAt: checkedAt,
+		})
+		require.NoError(t, err)
+
+		drift, err := store.Get(ctx, 1, "app", "")
+		require.NoError(t, err)
+
+		assert.True(t, drift.SelfHeal)
+		assert.True(t, drift.Drifted)
+		assert.Equal(t, int32(2), drift.Revision)
+		assert.Equal(t, []string{"spec.replicas"}, drift.Resources[0].Fields)
+		assert.True(t, checkedAt.Equal(drift.CheckedAt))
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		err := store.Save(ctx, helm.ReleaseDrift{ClusterID: 1, ReleaseName: "deleted", Namespace: "apps", CheckedAt: time.Now()})
+		require.NoError(t, err)
+
+		err = store.Prune(ctx, 1, []helm.Release{{ReleaseName: "app", Namespace: "apps"}})
+		require.NoError(t, err)
+
+		drifts, err := store.List(ctx, 1)
+		require.NoError(t, err)
+
+		require.Len(t, drifts, 1)
+		assert.Equal(t, "app", drifts[0].ReleaseName)
+	})
+}
@@ -25,6 +25,7 @@ import (
 func Migrate(db *gorm.DB, logger Logger) error {
 	tables := []interface{}{
 		repositoryModel{},
+		releaseDriftModel{},
 	}
 
 	var tableNames string
@@ -109,16 +109,28 @@ func (h helm3EnvService) AddRepository(ctx context.Context, helmEnv helm.HelmEnv
 		c.Password = passwordSecret.Password
 	}
 
-	envSettings := h.processEnvSettings(helmEnv)
-	r, err := repo.NewChartRepository(&c, getter.All(envSettings))
-	if err != nil {
-		return err
-	}
+	if repository.IsOCI() {
+		// OCI registries have no index file, logging in is the best effort validation
+		ref, err := parseOCIReference(repository.URL)
+		if err != nil {
+			return errors.WrapIf(err, "invalid OCI registry URL")
+		}
+
+		if err := newOCIRegistryClient(c.Username, c.Password).Login(ctx, ref.Host); err != nil {
+			return errors.Wrapf(err, "looks like %q is not a valid OCI registry or cannot be reached", repository.URL)
+		}
+	} else {
+		envSettings := h.processEnvSettings(helmEnv)
+		r, err := repo.NewChartRepository(&c, getter.All(envSettings))
+		if err != nil {
+			return err
+		}
 
-	// override the wired repository cache
-	r.CachePath = envSettings.RepositoryCache
-	if _, err := r.DownloadIndexFile(); err != nil {
-		return errors.Wrapf(err, "looks like %q is not a valid chart repository or cannot be reached", repository.URL)
+		// override the wired repository cache
+		r.CachePath = envSettings.RepositoryCache
+		if _, err := r.DownloadIndexFile(); err != nil {
+			return errors.Wrapf(err, "looks like %q is not a valid chart repository or cannot be reached", repository.URL)
+		}
 	}
 
 	f.Update(&c)
@@ -343,7 +355,7 @@ func (h helm3EnvService) getRawChartFileContent(chartFileName string, chartPtr *
 
 // listCharts retrieves  charts based on the input data
 // operates with h3 lib types
-func (h helm3EnvService) listCharts(_ context.Context, helmEnv helm.HelmEnv, filter helm.ChartFilter) (map[string][]repo.ChartVersions, error) {
+func (h helm3EnvService) listCharts(ctx context.Context, helmEnv helm.HelmEnv, filter helm.ChartFilter) (map[string][]repo.ChartVersions, error) {
 	chartVersionsSlice := make(map[string][]repo.ChartVersions)
 
 	repoFile, err := repo.LoadFile(helmEnv.GetHome())
@@ -363,13 +375,28 @@ func (h helm3EnvService) listCharts(_ context.Context, helmEnv helm.HelmEnv, fil
 			continue
 		}
 
-		repoIndexFilePath := path.Join(helmEnv.GetRepoCache(), helmpath.CacheIndexFile(repoEntry.Name))
-		repoIndexFile, err := repo.LoadIndexFile(repoIndexFilePath)
-		if err != nil {
-			return nil, errors.WrapIf(err, "failed to load index file for repo")
+		var entries map[string]repo.ChartVersions
+
+		if helm.IsOCIReference(repoEntry.URL) {
+			entries, err = h.listOCIChartVersions(ctx, repoEntry, filter)
+			if err != nil {
+				// a single unreachable registry should not break listing the charts of other repositories
+				h.logger.Warn("failed to list charts in OCI registry",
+					map[string]interface{}{"repoEntry": repoEntry.Name, "error": err.Error()})
+
+				continue
+			}
+		} else {
+			repoIndexFilePath := path.Join(helmEnv.GetRepoCache(), helmpath.CacheIndexFile(repoEntry.Name))
+			repoIndexFile, err := repo.LoadIndexFile(repoIndexFilePath)
+			if err != nil {
+				return nil, errors.WrapIf(err, "failed to load index file for repo")
+			}
+
+			entries = repoIndexFile.Entries
 		}
 
-		for chartName, chartVersions := range repoIndexFile.Entries {
+		for chartName, chartVersions := range entries {
 			filteredChartVersions := make(repo.ChartVersions, 0, 0)
 
 			if !matchesFilter(filter.StrictNameFilter(), chartName) {
@@ -419,8 +446,59 @@ func (h helm3EnvService) listCharts(_ context.Context, helmEnv helm.HelmEnv, fil
 	return chartVersionsSlice, nil
 }
 
+// listOCIChartVersions retrieves the chart versions stored in an OCI registry in the same structure as a repository index
+func (h helm3EnvService) listOCIChartVersions(ctx context.Context, repoEntry *repo.Entry, filter helm.ChartFilter) (map[string]repo.ChartVersions, error) {
+	base, err := parseOCIReference(repoEntry.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	client := newOCIRegistryClient(repoEntry.Username, repoEntry.Password)
+
+	repositories, err := client.Catalog(ctx, base.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]repo.ChartVersions)
+	for _, repository := range repositories {
+		ref := ociReference{Host: base.Host, Repository: repository}
+		if !base.contains(ref) || !matchesFilter(filter.StrictNameFilter(), ref.ChartName()) {
+			continue
+		}
+
+		versions, err := client.Versions(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		// only the latest version is required, no need to retrieve the others
+		if filter.VersionFilter() == "latest" && len(versions) > 1 {
+			versions = versions[:1]
+		}
+
+		chartVersions := make(repo.ChartVersions, 0, len(versions))
+		for _, version := range versions {
+			chartVersion, err := client.ChartVersion(ctx, ref.WithVersion(version))
+			if err != nil {
+				h.logger.Debug("skipping OCI artifact", map[string]interface{}{"reference": ref.WithVersion(version).String(), "error": err.Error()})
+
+				continue
+			}
+
+			chartVersions = append(chartVersions, chartVersion)
+		}
+
+		if len(chartVersions) > 0 {
+			entries[ref.ChartName()] = chartVersions
+		}
+	}
+
+	return entries, nil
+}
+
 // getDetailedChart gets the chart details from the chart archive
-func (h helm3EnvService) getDetailedCharts(_ context.Context, helmEnv helm.HelmEnv, repoVersions repo.ChartVersions) (map[string]*chart.Chart, error) {
+func (h helm3EnvService) getDetailedCharts(ctx context.Context, helmEnv helm.HelmEnv, repoVersions repo.ChartVersions) (map[string]*chart.Chart, error) {
 	getters := getter.All(h.processEnvSettings(helmEnv))
 
 	detailedCharts := make(map[string]*chart.Chart)
@@ -432,17 +510,33 @@ func (h helm3EnvService) getDetailedCharts(_ context.Context, helmEnv helm.HelmE
 			return nil, errors.Errorf("invalid chart URL format: %s", repoUrl)
 		}
 
-		client, err := getters.ByScheme(u.Scheme)
-		if err != nil {
-			return nil, errors.Errorf("could not find protocol handler for: %s", u.Scheme)
-		}
+		var archive []byte
 
-		buffer, err := client.Get(repoUrl)
-		if err != nil {
-			return nil, errors.WrapIf(err, "failed to get archive")
+		if u.Scheme == helm.OCIScheme {
+			ref, err := parseOCIReference(repoUrl)
+			if err != nil {
+				return nil, errors.WrapIf(err, "invalid OCI chart reference")
+			}
+
+			archive, err = ociRegistryClientFor(helmEnv, ref).PullChart(ctx, ref)
+			if err != nil {
+				return nil, errors.WrapIf(err, "failed to pull chart")
+			}
+		} else {
+			client, err := getters.ByScheme(u.Scheme)
+			if err != nil {
+				return nil, errors.Errorf("could not find protocol handler for: %s", u.Scheme)
+			}
+
+			buffer, err := client.Get(repoUrl)
+			if err != nil {
+				return nil, errors.WrapIf(err, "failed to get archive")
+			}
+
+			archive = buffer.Bytes()
 		}
 
-		bufferedFilePtr, err := loader.LoadArchiveFiles(bytes.NewReader(buffer.Bytes()))
+		bufferedFilePtr, err := loader.LoadArchiveFiles(bytes.NewReader(archive))
 		if err != nil {
 			return nil, errors.WrapIf(err, "failed to load archive files")
 		}
@@ -217,8 +217,9 @@ func (h *helm3UnifiedReleaser) GetDeployment(ctx context.Context, clusterID uint
 		ChartName:    release.ChartName,
 		ChartVersion: release.Version,
 		Namespace:    release.Namespace,
-		Version:      0,
+		Version:      release.ReleaseVersion,
 		Status:       release.ReleaseInfo.Status,
+		Manifest:     release.ReleaseInfo.Manifest,
 	}, nil
 }
 
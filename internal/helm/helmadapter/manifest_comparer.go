@@ -0,0 +1,297 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"emperror.dev/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/pipeline/internal/helm"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+)
+
+// ignoredTopLevelFields collects the fields of the manifest objects that are not expected to match their live counterparts
+var ignoredTopLevelFields = map[string]bool{
+	"apiVersion": true,
+	"kind":       true,
+	"status":     true,
+	// secrets' string data is merged into the data field by the API server
+	"stringData": true,
+}
+
+// comparedMetadataFields collects the metadata fields of the manifest objects that are compared with the live objects
+var comparedMetadataFields = []string{"labels", "annotations"}
+
+type manifestComparer struct {
+	clusterService helm.ClusterService
+	clientFactory  func(kubeConfig []byte) (client.Reader, error)
+	logger         Logger
+}
+
+// NewManifestComparer returns a new helm.ManifestComparer comparing manifests with the live objects of the cluster.
+func NewManifestComparer(clusterService helm.ClusterService, logger Logger) helm.ManifestComparer {
+	return manifestComparer{
+		clusterService: clusterService,
+		clientFactory:  newKubernetesClient,
+		logger:         logger,
+	}
+}
+
+func newKubernetesClient(kubeConfig []byte) (client.Reader, error) {
+	config, err := k8sclient.NewClientConfig(kubeConfig)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create kubernetes client config")
+	}
+
+	return client.New(config, client.Options{})
+}
+
+func (c manifestComparer) CompareManifest(ctx context.Context, clusterID uint, namespace string, manifest string) ([]helm.DriftedResource, error) {
+	objects, err := parseManifest(manifest)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to parse release manifest")
+	}
+
+	kubeConfig, err := c.clusterService.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to retrieve kubeconfig", "clusterId", clusterID)
+	}
+
+	kubeClient, err := c.clientFactory(kubeConfig)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to create kubernetes client", "clusterId", clusterID)
+	}
+
+	drifted := make([]helm.DriftedResource, 0)
+	for _, object := range objects {
+		if object.GetNamespace() == "" {
+			object.SetNamespace(namespace)
+		}
+
+		driftedResource := helm.DriftedResource{
+			APIVersion: object.GetAPIVersion(),
+			Kind:       object.GetKind(),
+			Namespace:  object.GetNamespace(),
+			Name:       object.GetName(),
+		}
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(object.GroupVersionKind())
+
+		err := kubeClient.Get(ctx, client.ObjectKey{Namespace: object.GetNamespace(), Name: object.GetName()}, live)
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			driftedResource.Missing = true
+			drifted = append(drifted, driftedResource)
+
+			continue
+		} else if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to retrieve live object",
+				"kind", driftedResource.Kind, "namespace", driftedResource.Namespace, "name", driftedResource.Name)
+		}
+
+		fields, err := compareObjects(object.Object, live.Object)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to compare live object",
+				"kind", driftedResource.Kind, "namespace", driftedResource.Namespace, "name", driftedResource.Name)
+		}
+
+		if len(fields) > 0 {
+			driftedResource.Fields = fields
+			drifted = append(drifted, driftedResource)
+		}
+	}
+
+	c.logger.Debug("compared release manifest with live objects", map[string]interface{}{
+		"clusterId": clusterID,
+		"objects":   len(objects),
+		"drifted":   len(drifted),
+	})
+
+	return drifted, nil
+}
+
+// parseManifest decodes the objects of a multi-document manifest
+func parseManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(manifest)), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, err
+		}
+
+		// documents containing comments only
+		if len(object.Object) == 0 {
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// compareObjects returns the paths of the fields set in the desired object which differ in the live object
+// Fields defaulted or added by the cluster (not present in the desired object) are not considered as drift.
+func compareObjects(desired map[string]interface{}, live map[string]interface{}) ([]string, error) {
+	desired, err := normalizeObject(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err = normalizeObject(live)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for _, key := range sortedKeys(desired) {
+		if ignoredTopLevelFields[key] {
+			continue
+		}
+
+		if key == "metadata" {
+			desiredMetadata, _ := desired[key].(map[string]interface{})
+			liveMetadata, _ := live[key].(map[string]interface{})
+
+			for _, field := range comparedMetadataFields {
+				if value, ok := desiredMetadata[field]; ok {
+					fields = append(fields, compareValues("metadata."+field, value, liveMetadata[field])...)
+				}
+			}
+
+			continue
+		}
+
+		fields = append(fields, compareValues(key, desired[key], live[key])...)
+	}
+
+	return fields, nil
+}
+
+func compareValues(path string, desired interface{}, live interface{}) []string {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		if len(desiredValue) == 0 && live == nil {
+			return nil
+		}
+
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+
+		var fields []string
+		for _, key := range sortedKeys(desiredValue) {
+			fields = append(fields, compareValues(path+"."+key, desiredValue[key], liveValue[key])...)
+		}
+
+		return fields
+
+	case []interface{}:
+		if len(desiredValue) == 0 && live == nil {
+			return nil
+		}
+
+		liveValue, ok := live.([]interface{})
+		if !ok || len(liveValue) != len(desiredValue) {
+			return []string{path}
+		}
+
+		var fields []string
+		for i := range desiredValue {
+			fields = append(fields, compareValues(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], liveValue[i])...)
+		}
+
+		return fields
+
+	case nil:
+		return nil
+
+	case string:
+		if liveValue, ok := live.(string); ok && equalQuantities(desiredValue, liveValue) {
+			return nil
+		}
+	}
+
+	if !reflect.DeepEqual(desired, live) {
+		return []string{path}
+	}
+
+	return nil
+}
+
+// equalQuantities tells whether both values are the same resource quantity (eg. 1000m and 1)
+func equalQuantities(a string, b string) bool {
+	if a == b {
+		return true
+	}
+
+	qa, err := resource.ParseQuantity(a)
+	if err != nil {
+		return false
+	}
+
+	qb, err := resource.ParseQuantity(b)
+	if err != nil {
+		return false
+	}
+
+	return qa.Cmp(qb) == 0
+}
+
+// normalizeObject makes sure both compared objects use the same types for the same values
+func normalizeObject(object map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,233 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+const testDriftManifest = `---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  labels:
+    app: app
+data:
+  key: value
+---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app-svc
+spec:
+  ports:
+    - port: 80
+---
+# Source: app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: app
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      containers:
+        - name: app
+          image: nginx:1.19
+          resources:
+            limits:
+              cpu: 1000m
+`
+
+// liveObjects is a client.Reader serving unstructured objects
+type liveObjects struct {
+	client.Reader
+
+	objects map[client.ObjectKey]map[string]interface{}
+}
+
+func (l liveObjects) Get(_ context.Context, key client.ObjectKey, obj client.Object) error {
+	object, ok := l.objects[key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+
+	obj.(*unstructured.Unstructured).Object = object
+
+	return nil
+}
+
+func TestManifestComparer_CompareManifest(t *testing.T) {
+	labels := map[string]interface{}{"app": "app"}
+
+	kubeClient := liveObjects{
+		objects: map[client.ObjectKey]map[string]interface{}{
+			{Namespace: "apps", Name: "app-config"}: {
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":        "app-config",
+					"namespace":   "apps",
+					"labels":      labels,
+					"annotations": map[string]interface{}{"meta.helm.sh/release-name": "app"},
+				},
+				"data": map[string]interface{}{"key": "value"},
+			},
+			{Namespace: "apps", Name: "app"}: {
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "app",
+					"namespace": "apps",
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"selector": map[string]interface{}{"matchLabels": labels},
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{"labels": labels},
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{
+									"name":            "app",
+									"image":           "nginx:1.19",
+									"imagePullPolicy": "IfNotPresent",
+									"resources": map[string]interface{}{
+										"limits": map[string]interface{}{"cpu": "1"},
+									},
+								},
+							},
+						},
+					},
+				},
+				"status": map[string]interface{}{"replicas": int64(3)},
+			},
+		},
+	}
+
+	comparer := manifestComparer{
+		clusterService: helm.ClusterKubeConfigFunc(func(ctx context.Context, clusterID uint) ([]byte, error) {
+			return []byte("kubeconfig"), nil
+		}),
+		clientFactory: func(kubeConfig []byte) (client.Reader, error) {
+			return kubeClient, nil
+		},
+		logger: common.NoopLogger{},
+	}
+
+	drifted, err := comparer.CompareManifest(context.Background(), 1, "apps", testDriftManifest)
+	require.NoError(t, err)
+
+	assert.Equal(t, []helm.DriftedResource{
+		{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Namespace:  "apps",
+			Name:       "app-svc",
+			Missing:    true,
+		},
+		{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  "apps",
+			Name:       "app",
+			Fields:     []string{"spec.replicas"},
+		},
+	}, drifted)
+}
+
+func TestCompareObjects(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired map[string]interface{}
+		live    map[string]interface{}
+		fields  []string
+	}{
+		{
+			name: "defaulted fields are ignored",
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": 80}}},
+			},
+			live: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"ports":     []interface{}{map[string]interface{}{"port": int64(80), "protocol": "TCP"}},
+					"clusterIP": "10.0.0.1",
+				},
+				"status": map[string]interface{}{},
+			},
+		},
+		{
+			name: "changed list",
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{"args": []interface{}{"--a", "--b"}},
+			},
+			live: map[string]interface{}{
+				"spec": map[string]interface{}{"args": []interface{}{"--a"}},
+			},
+			fields: []string{"spec.args"},
+		},
+		{
+			name: "changed label",
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "app", "labels": map[string]interface{}{"app": "app"}},
+			},
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "app", "uid": "1234", "labels": map[string]interface{}{"app": "other"}},
+			},
+			fields: []string{"metadata.labels.app"},
+		},
+		{
+			name: "removed field",
+			desired: map[string]interface{}{
+				"data": map[string]interface{}{"key": "value", "other": "value"},
+			},
+			live: map[string]interface{}{
+				"data": map[string]interface{}{"key": "value"},
+			},
+			fields: []string{"data.other"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			fields, err := compareObjects(test.desired, test.live)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,529 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+// Media types of Helm charts stored in OCI registries.
+const (
+	ociManifestMediaType      = "application/vnd.oci.image.manifest.v1+json"
+	helmConfigMediaType       = "application/vnd.cncf.helm.config.v1+json"
+	helmChartContentMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+
+	// legacyHelmChartContentMediaType is the chart layer media type used by Helm versions prior to 3.7
+	legacyHelmChartContentMediaType = "application/tar+gzip"
+)
+
+// ociReference points to a chart (version) in an OCI registry: oci://<host>/<repository>[:<tag>]
+type ociReference struct {
+	Host       string
+	Repository string
+	Tag        string
+}
+
+func parseOCIReference(ref string) (ociReference, error) {
+	if !helm.IsOCIReference(ref) {
+		return ociReference{}, errors.NewWithDetails("not an OCI reference", "reference", ref)
+	}
+
+	rest := strings.TrimPrefix(ref, helm.OCIScheme+"://")
+
+	var reference ociReference
+
+	slash := strings.Index(rest, "/")
+	if slash < 0 {
+		reference.Host = strings.TrimSuffix(rest, "/")
+
+		return reference, nil
+	}
+
+	reference.Host = rest[:slash]
+	reference.Repository = strings.Trim(rest[slash+1:], "/")
+
+	// the tag separator must be in the last path segment (the host may contain a port)
+	if colon := strings.LastIndex(reference.Repository, ":"); colon > strings.LastIndex(reference.Repository, "/") {
+		reference.Tag = reference.Repository[colon+1:]
+		reference.Repository = reference.Repository[:colon]
+	}
+
+	if reference.Host == "" {
+		return ociReference{}, errors.NewWithDetails("missing registry host", "reference", ref)
+	}
+
+	return reference, nil
+}
+
+// String returns the oci:// form of the reference.
+func (r ociReference) String() string {
+	ref := helm.OCIScheme + "://" + r.Host
+	if r.Repository != "" {
+		ref += "/" + r.Repository
+	}
+
+	if r.Tag != "" {
+		ref += ":" + r.Tag
+	}
+
+	return ref
+}
+
+// WithTag returns a copy of the reference pointing to the given tag.
+func (r ociReference) WithTag(tag string) ociReference {
+	r.Tag = tag
+
+	return r
+}
+
+// WithVersion returns a copy of the reference pointing to the tag of the given chart version.
+// Helm replaces the "+" character of semantic versions with "_" as it is not allowed in tags.
+func (r ociReference) WithVersion(version string) ociReference {
+	return r.WithTag(strings.ReplaceAll(version, "+", "_"))
+}
+
+// ChartName returns the name of the chart the reference points to.
+func (r ociReference) ChartName() string {
+	return r.Repository[strings.LastIndex(r.Repository, "/")+1:]
+}
+
+// contains tells whether the other reference is in the namespace of the reference.
+func (r ociReference) contains(other ociReference) bool {
+	if r.Host != other.Host {
+		return false
+	}
+
+	return r.Repository == "" || other.Repository == r.Repository || strings.HasPrefix(other.Repository, r.Repository+"/")
+}
+
+type ociManifest struct {
+	Config ociDescriptor   `json:"config"`
+	Layers []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// ociRegistryClient talks to OCI registries through the distribution API.
+// Both basic and token (bearer) authentication are supported.
+type ociRegistryClient struct {
+	httpClient *http.Client
+	username   string
+	password   string
+}
+
+func newOCIRegistryClient(username string, password string) ociRegistryClient {
+	return ociRegistryClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		username:   username,
+		password:   password,
+	}
+}
+
+// Login checks whether the registry can be accessed with the client's credentials.
+func (c ociRegistryClient) Login(ctx context.Context, host string) error {
+	resp, err := c.get(ctx, host, "/v2/", "", "")
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// Catalog lists the repositories in the registry.
+func (c ociRegistryClient) Catalog(ctx context.Context, host string) ([]string, error) {
+	var catalog struct {
+		Repositories []string `json:"repositories"`
+	}
+
+	if err := c.getJSON(ctx, host, "/v2/_catalog?n=1000", "", "registry:catalog:*", &catalog); err != nil {
+		return nil, errors.WrapIf(err, "failed to list registry repositories")
+	}
+
+	return catalog.Repositories, nil
+}
+
+// Versions lists the semantic versions tagged in a repository, latest first.
+// Use WithVersion to refer to the tag of a version.
+func (c ociRegistryClient) Versions(ctx context.Context, ref ociReference) ([]string, error) {
+	var tagList struct {
+		Tags []string `json:"tags"`
+	}
+
+	if err := c.getJSON(ctx, ref.Host, "/v2/"+ref.Repository+"/tags/list", "", pullScope(ref), &tagList); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list tags", "reference", ref.String())
+	}
+
+	versions := make([]*semver.Version, 0, len(tagList.Tags))
+	for _, tag := range tagList.Tags {
+		// Helm replaces the "+" character of semantic versions as it is not allowed in tags
+		version, err := semver.NewVersion(strings.ReplaceAll(tag, "_", "+"))
+		if err != nil {
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Sort(sort.Reverse(semver.Collection(versions)))
+
+	tags := make([]string, 0, len(versions))
+	for _, version := range versions {
+		tags = append(tags, version.Original())
+	}
+
+	return tags, nil
+}
+
+// ChartVersion retrieves the metadata of a chart version.
+func (c ociRegistryClient) ChartVersion(ctx context.Context, ref ociReference) (*repo.ChartVersion, error) {
+	manifest, digest, err := c.manifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Config.MediaType != helmConfigMediaType {
+		return nil, errors.NewWithDetails("not a helm chart", "reference", ref.String(), "mediaType", manifest.Config.MediaType)
+	}
+
+	config, err := c.blob(ctx, ref, manifest.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata chart.Metadata
+	if err := json.Unmarshal(config, &metadata); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to decode chart metadata", "reference", ref.String())
+	}
+
+	return &repo.ChartVersion{
+		Metadata: &metadata,
+		URLs:     []string{ref.String()},
+		Digest:   digest,
+	}, nil
+}
+
+// PullChart downloads the chart archive the reference points to.
+func (c ociRegistryClient) PullChart(ctx context.Context, ref ociReference) ([]byte, error) {
+	manifest, _, err := c.manifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType == helmChartContentMediaType || layer.MediaType == legacyHelmChartContentMediaType {
+			return c.blob(ctx, ref, layer.Digest)
+		}
+	}
+
+	return nil, errors.NewWithDetails("chart content not found in manifest", "reference", ref.String())
+}
+
+func (c ociRegistryClient) manifest(ctx context.Context, ref ociReference) (ociManifest, string, error) {
+	resp, err := c.get(ctx, ref.Host, "/v2/"+ref.Repository+"/manifests/"+ref.Tag, ociManifestMediaType, pullScope(ref))
+	if err != nil {
+		return ociManifest{}, "", errors.WrapIfWithDetails(err, "failed to get manifest", "reference", ref.String())
+	}
+	defer resp.Body.Close()
+
+	var manifest ociManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return ociManifest{}, "", errors.WrapIfWithDetails(err, "failed to decode manifest", "reference", ref.String())
+	}
+
+	return manifest, resp.Header.Get("Docker-Content-Digest"), nil
+}
+
+func (c ociRegistryClient) blob(ctx context.Context, ref ociReference, digest string) ([]byte, error) {
+	resp, err := c.get(ctx, ref.Host, "/v2/"+ref.Repository+"/blobs/"+digest, "", pullScope(ref))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get blob", "reference", ref.String(), "digest", digest)
+	}
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (c ociRegistryClient) getJSON(ctx context.Context, host string, path string, accept string, scope string, v interface{}) error {
+	resp, err := c.get(ctx, host, path, accept, scope)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// get sends a GET request to the registry and answers the authentication challenge if there is any
+func (c ociRegistryClient) get(ctx context.Context, host string, path string, accept string, scope string) (*http.Response, error) {
+	endpoint := registryScheme(host) + "://" + host + path
+
+	resp, err := c.do(ctx, endpoint, accept, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		authorization, err := c.authorize(ctx, challenge, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err = c.do(ctx, endpoint, accept, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+
+		return nil, errors.NewWithDetails("unexpected registry response", "url", endpoint, "status", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+func (c ociRegistryClient) do(ctx context.Context, endpoint string, accept string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create registry request")
+	}
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to reach registry")
+	}
+
+	return resp, nil
+}
+
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authorize returns the authorization header value answering the passed in authentication challenge
+func (c ociRegistryClient) authorize(ctx context.Context, challenge string, scope string) (string, error) {
+	if c.username == "" && c.password == "" && !strings.HasPrefix(strings.ToLower(challenge), "bearer") {
+		return "", errors.New("registry requires authentication")
+	}
+
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer") {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)), nil
+	}
+
+	params := make(map[string]string)
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2]
+	}
+
+	if params["realm"] == "" {
+		return "", errors.NewWithDetails("invalid authentication challenge", "challenge", challenge)
+	}
+
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+
+	if scope != "" {
+		query.Set("scope", scope)
+	} else if params["scope"] != "" {
+		query.Set("scope", params["scope"])
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to create token request")
+	}
+
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to request registry token")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.NewWithDetails("registry authentication failed", "status", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", errors.WrapIf(err, "failed to decode registry token")
+	}
+
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+
+	return "Bearer " + token.Token, nil
+}
+
+func pullScope(ref ociReference) string {
+	return fmt.Sprintf("repository:%s:pull", ref.Repository)
+}
+
+// registryScheme returns the scheme used for reaching a registry:
+// similarly to Docker, registries on the loopback interface are reached through plain HTTP
+func registryScheme(host string) string {
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+
+	if hostname == "localhost" {
+		return "http"
+	}
+
+	if ip := net.ParseIP(hostname); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+
+	return "https"
+}
+
+// ociRegistryClientFor returns a registry client using the credentials of the OCI repository the reference belongs to
+func ociRegistryClientFor(helmEnv helm.HelmEnv, ref ociReference) ociRegistryClient {
+	repoFile, err := repo.LoadFile(helmEnv.GetHome())
+	if err != nil {
+		return newOCIRegistryClient("", "")
+	}
+
+	for _, entry := range repoFile.Repositories {
+		if !helm.IsOCIReference(entry.URL) {
+			continue
+		}
+
+		base, err := parseOCIReference(entry.URL)
+		if err != nil {
+			continue
+		}
+
+		if base.contains(ref) {
+			return newOCIRegistryClient(entry.Username, entry.Password)
+		}
+	}
+
+	return newOCIRegistryClient("", "")
+}
+
+// resolveOCIChartReference resolves the passed in chart name to an OCI reference if the chart is stored in an OCI registry.
+// Charts can be referenced directly (oci://registry.example.com/charts/chart) or through an OCI repository entry (repository/chart).
+func resolveOCIChartReference(helmEnv helm.HelmEnv, chartName string) (ociReference, bool, error) {
+	if helm.IsOCIReference(chartName) {
+		ref, err := parseOCIReference(chartName)
+
+		return ref, true, err
+	}
+
+	parts := strings.SplitN(chartName, "/", 2)
+	if len(parts) != 2 {
+		return ociReference{}, false, nil
+	}
+
+	repoFile, err := repo.LoadFile(helmEnv.GetHome())
+	if err != nil {
+		return ociReference{}, false, nil
+	}
+
+	entry := repoFile.Get(parts[0])
+	if entry == nil || !helm.IsOCIReference(entry.URL) {
+		return ociReference{}, false, nil
+	}
+
+	ref, err := parseOCIReference(strings.TrimSuffix(entry.URL, "/") + "/" + parts[1])
+
+	return ref, true, err
+}
+
+// pullOCIChart downloads a chart from an OCI registry into the cache directory of the helm environment and returns its path.
+// The latest version is pulled if neither the reference nor the version argument specifies one.
+func pullOCIChart(ctx context.Context, helmEnv helm.HelmEnv, ref ociReference, version string) (string, error) {
+	client := ociRegistryClientFor(helmEnv, ref)
+
+	if version != "" {
+		ref = ref.WithVersion(version)
+	}
+
+	if ref.Tag == "" {
+		versions, err := client.Versions(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+
+		if len(versions) == 0 {
+			return "", errors.NewWithDetails("no chart versions found", "reference", ref.String())
+		}
+
+		ref = ref.WithVersion(versions[0])
+	}
+
+	archive, err := client.PullChart(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(helmEnv.GetCacheDir(), "oci")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", errors.WrapIf(err, "failed to create chart cache directory")
+	}
+
+	chartPath := filepath.Join(dir, fmt.Sprintf("%s-%x.tgz", ref.ChartName(), sha1.Sum([]byte(ref.String()))))
+	if err := ioutil.WriteFile(chartPath, archive, 0o644); err != nil {
+		return "", errors.WrapIf(err, "failed to write chart archive")
+	}
+
+	return chartPath, nil
+}
@@ -0,0 +1,299 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected ociReference
+	}{
+		{
+			ref:      "oci://registry.example.com",
+			expected: ociReference{Host: "registry.example.com"},
+		},
+		{
+			ref:      "oci://registry.example.com/charts/",
+			expected: ociReference{Host: "registry.example.com", Repository: "charts"},
+		},
+		{
+			ref:      "oci://localhost:5000/charts/nginx:1.0.0",
+			expected: ociReference{Host: "localhost:5000", Repository: "charts/nginx", Tag: "1.0.0"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.ref, func(t *testing.T) {
+			ref, err := parseOCIReference(test.ref)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, ref)
+		})
+	}
+
+	_, err := parseOCIReference("https://registry.example.com/charts")
+	assert.Error(t, err)
+}
+
+// fakeRegistry is a minimal stand-in for an OCI registry serving Helm charts with basic authentication
+type fakeRegistry struct {
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	tags      map[string][]string
+}
+
+func newFakeRegistry(t *testing.T, charts ...*chart.Chart) *fakeRegistry {
+	registry := &fakeRegistry{
+		blobs:     make(map[string][]byte),
+		manifests: make(map[string][]byte),
+		tags:      make(map[string][]string),
+	}
+
+	for _, ch := range charts {
+		archivePath, err := chartutil.Save(ch, t.TempDir())
+		require.NoError(t, err)
+
+		archive, err := ioutil.ReadFile(archivePath)
+		require.NoError(t, err)
+
+		config, err := json.Marshal(ch.Metadata)
+		require.NoError(t, err)
+
+		manifest, err := json.Marshal(ociManifest{
+			Config: ociDescriptor{MediaType: helmConfigMediaType, Digest: registry.addBlob(config)},
+			Layers: []ociDescriptor{{MediaType: helmChartContentMediaType, Digest: registry.addBlob(archive)}},
+		})
+		require.NoError(t, err)
+
+		// Helm replaces the "+" character of semantic versions as it is not allowed in tags
+		repository := "charts/" + ch.Name()
+		tag := strings.ReplaceAll(ch.Metadata.Version, "+", "_")
+		registry.manifests[repository+":"+tag] = manifest
+		registry.tags[repository] = append(registry.tags[repository], tag)
+	}
+
+	return registry
+}
+
+func (r *fakeRegistry) addBlob(content []byte) string {
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+	r.blobs[digest] = content
+
+	return digest
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if user, password, ok := req.BasicAuth(); !ok || user != "user" || password != "secret" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+
+	switch {
+	case path == "":
+		w.WriteHeader(http.StatusOK)
+
+	case path == "_catalog":
+		repositories := make([]string, 0, len(r.tags))
+		for repository := range r.tags {
+			repositories = append(repositories, repository)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"repositories": repositories})
+
+	case strings.HasSuffix(path, "/tags/list"):
+		repository := strings.TrimSuffix(path, "/tags/list")
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": repository, "tags": append(r.tags[repository], "latest")})
+
+	case strings.Contains(path, "/manifests/"):
+		parts := strings.SplitN(path, "/manifests/", 2)
+
+		manifest, ok := r.manifests[parts[0]+":"+parts[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", ociManifestMediaType)
+		w.Header().Set("Docker-Content-Digest", fmt.Sprintf("sha256:%x", sha256.Sum256(manifest)))
+		_, _ = w.Write(manifest)
+
+	case strings.Contains(path, "/blobs/"):
+		blob, ok := r.blobs[path[strings.Index(path, "/blobs/")+len("/blobs/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write(blob)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+type ociTestSecretStore struct {
+	helm.SecretStore
+}
+
+func (ociTestSecretStore) ResolvePasswordSecrets(_ context.Context, _ string) (helm.PasswordSecret, error) {
+	return helm.PasswordSecret{UserName: "user", Password: "secret"}, nil
+}
+
+func newTestChart(name string, version string) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion:  chart.APIVersionV2,
+			Name:        name,
+			Version:     version,
+			Description: "test chart",
+			Keywords:    []string{"test"},
+		},
+		Values: map[string]interface{}{"replicaCount": 1},
+		Templates: []*chart.File{
+			{Name: "templates/configmap.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n")},
+		},
+	}
+}
+
+func TestHelm3EnvService_OCIRegistry(t *testing.T) {
+	ts := httptest.NewServer(newFakeRegistry(t,
+		newTestChart("nginx", "0.1.0"),
+		newTestChart("nginx", "0.2.0"),
+		newTestChart("redis", "1.0.0"),
+		newTestChart("redis", "1.1.0+build.1"),
+	))
+	defer ts.Close()
+
+	ctx := context.Background()
+	logger := common.NoopLogger{}
+	registryURL := "oci://" + strings.TrimPrefix(ts.URL, "http://") + "/charts"
+
+	helmEnv, err := helm.NewHelm3EnvResolver(t.TempDir(), nil, logger).ResolvePlatformEnv(ctx)
+	require.NoError(t, err)
+
+	envService := NewHelm3EnvService(ociTestSecretStore{}, logger)
+
+	_, _, err = envService.EnsureEnv(ctx, helmEnv, nil)
+	require.NoError(t, err)
+
+	err = envService.AddRepository(ctx, helmEnv, helm.Repository{Name: "oci-charts", URL: registryURL})
+	require.Error(t, err, "login should fail without credentials")
+
+	err = envService.AddRepository(ctx, helmEnv, helm.Repository{Name: "oci-charts", URL: registryURL, PasswordSecretID: "secret"})
+	require.NoError(t, err)
+
+	repositories, err := envService.ListRepositories(ctx, helmEnv)
+	require.NoError(t, err)
+	require.Len(t, repositories, 1)
+	assert.True(t, repositories[0].IsOCI())
+
+	t.Run("ListCharts", func(t *testing.T) {
+		charts, err := envService.ListCharts(ctx, helmEnv, helm.ChartFilter{Name: []string{"nginx"}, Version: []string{"all"}})
+		require.NoError(t, err)
+		require.Len(t, charts, 1)
+
+		var repoCharts struct {
+			Name   string
+			Charts [][]interface{}
+		}
+
+		raw, err := json.Marshal(charts[0])
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(raw, &repoCharts))
+
+		assert.Equal(t, "oci-charts", repoCharts.Name)
+		require.Len(t, repoCharts.Charts, 1)
+		assert.Len(t, repoCharts.Charts[0], 2)
+	})
+
+	t.Run("GetChart", func(t *testing.T) {
+		details, err := envService.GetChart(ctx, helmEnv, helm.ChartFilter{Repo: []string{"oci-charts"}, Name: []string{"redis"}, Version: []string{"1.0.0"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, "oci-charts", details["repo"])
+		assert.Len(t, details["versions"], 1)
+	})
+
+	t.Run("PullChart", func(t *testing.T) {
+		for _, chartName := range []string{"oci-charts/nginx", registryURL + "/nginx"} {
+			ref, ok, err := resolveOCIChartReference(helmEnv, chartName)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			chartPath, err := pullOCIChart(ctx, helmEnv, ref, "")
+			require.NoError(t, err)
+
+			ch, err := loader.Load(chartPath)
+			require.NoError(t, err)
+			assert.Equal(t, "0.2.0", ch.Metadata.Version, "the latest version should be pulled by default")
+		}
+
+		ref, _, err := resolveOCIChartReference(helmEnv, "oci-charts/nginx")
+		require.NoError(t, err)
+
+		chartPath, err := pullOCIChart(ctx, helmEnv, ref, "0.1.0")
+		require.NoError(t, err)
+
+		ch, err := loader.Load(chartPath)
+		require.NoError(t, err)
+		assert.Equal(t, "0.1.0", ch.Metadata.Version)
+
+		// versions with build metadata are tagged with "_" instead of "+"
+		ref, _, err = resolveOCIChartReference(helmEnv, "oci-charts/redis")
+		require.NoError(t, err)
+
+		for _, version := range []string{"", "1.1.0+build.1"} {
+			chartPath, err := pullOCIChart(ctx, helmEnv, ref, version)
+			require.NoError(t, err)
+
+			ch, err := loader.Load(chartPath)
+			require.NoError(t, err)
+			assert.Equal(t, "1.1.0+build.1", ch.Metadata.Version)
+		}
+
+		_, ok, err := resolveOCIChartReference(helmEnv, "stable/nginx")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
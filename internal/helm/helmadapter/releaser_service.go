@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -96,7 +97,7 @@ func (r releaser) Install(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig
 	installAction.Version = releaseInput.Version
 	installAction.SkipCRDs = options.SkipCRDs
 
-	cp, err := installAction.ChartPathOptions.LocateChart(chartRef, envSettings)
+	cp, err := r.locateChart(ctx, helmEnv, installAction.ChartPathOptions, chartRef)
 	if err != nil {
 		return helm.Release{}, errors.WrapIf(err, "failed to locate chart")
 	}
@@ -199,6 +200,7 @@ func (r releaser) adaptReleasePtr(rawRelease *release.Release) helm.Release {
 			Status:        rawRelease.Info.Status.String(),
 			Notes:         base64.StdEncoding.EncodeToString([]byte(rawRelease.Info.Notes)),
 			Values:        rawRelease.Config,
+			Manifest:      rawRelease.Manifest,
 		},
 		ReleaseResources: releaseResources,
 	}
@@ -309,11 +311,85 @@ func (r releaser) Get(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.K
 			Status:        rawRelease.Info.Status.String(),
 			Notes:         base64.StdEncoding.EncodeToString([]byte(rawRelease.Info.Notes)),
 			Values:        rawRelease.Config,
+			Manifest:      rawRelease.Manifest,
 		},
 	}, nil
 }
 
 func (r releaser) Upgrade(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseInput helm.Release, options helm.Options) (helm.Release, error) {
+	actionConfig, upgradeAction, err := r.newUpgradeAction(helmEnv, kubeConfig, releaseInput, options)
+	if err != nil {
+		return helm.Release{}, err
+	}
+
+	chartPath, err := r.locateChart(ctx, helmEnv, upgradeAction.ChartPathOptions, releaseInput.ChartName)
+	if err != nil {
+		return helm.Release{}, errors.WrapIf(err, "failed to locate chart")
+	}
+
+	if upgradeAction.Install {
+		// If a release does not exist, install it.
+		histClient := action.NewHistory(actionConfig)
+		histClient.Max = 1
+		if _, err := histClient.Run(releaseInput.ReleaseName); err == driver.ErrReleaseNotFound {
+			r.logger.Debug("release doesn't exist, installing it now", map[string]interface{}{"releaseName": releaseInput.ReleaseName})
+
+			rel, err := r.Install(ctx, helmEnv, kubeConfig, releaseInput, options)
+			if err != nil {
+				return helm.Release{}, errors.WrapIf(err, "failed to install release during upgrade")
+			}
+
+			return rel, nil
+		} else if err != nil {
+			return helm.Release{}, errors.WrapIf(err, "failed to install release during upgrade")
+		}
+	}
+
+	ch, err := r.loadUpgradeChart(chartPath)
+	if err != nil {
+		return helm.Release{}, err
+	}
+
+	rel, err := upgradeAction.Run(releaseInput.ReleaseName, ch, releaseInput.Values)
+	if err != nil {
+		return helm.Release{}, errors.Wrap(err, "UPGRADE FAILED")
+	}
+
+	r.logger.Info("release has been upgraded. Happy Helming!", map[string]interface{}{"releaseName": releaseInput.ReleaseName})
+
+	return r.adaptReleasePtr(rel), nil
+}
+
+func (r releaser) RenderUpgrade(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseInput helm.Release, options helm.Options) (helm.ReleaseRevision, error) {
+	_, upgradeAction, err := r.newUpgradeAction(helmEnv, kubeConfig, releaseInput, options)
+	if err != nil {
+		return helm.ReleaseRevision{}, err
+	}
+
+	// nothing gets applied to the cluster
+	upgradeAction.DryRun = true
+	upgradeAction.Wait = false
+
+	chartPath, err := r.locateChart(ctx, helmEnv, upgradeAction.ChartPathOptions, releaseInput.ChartName)
+	if err != nil {
+		return helm.ReleaseRevision{}, errors.WrapIf(err, "failed to locate chart")
+	}
+
+	ch, err := r.loadUpgradeChart(chartPath)
+	if err != nil {
+		return helm.ReleaseRevision{}, err
+	}
+
+	rel, err := upgradeAction.Run(releaseInput.ReleaseName, ch, releaseInput.Values)
+	if err != nil {
+		return helm.ReleaseRevision{}, errors.WrapIf(err, "failed to render upgrade")
+	}
+
+	return adaptReleaseRevision(rel), nil
+}
+
+// newUpgradeAction sets up an upgrade action for the release based on the passed in options
+func (r releaser) newUpgradeAction(helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseInput helm.Release, options helm.Options) (*action.Configuration, *action.Upgrade, error) {
 	// this is the value coming from env settings in the CLI
 	ns := "default"
 
@@ -330,7 +406,7 @@ func (r releaser) Upgrade(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig
 
 	actionConfig, err := r.getActionConfiguration(restClientGetter, ns)
 	if err != nil {
-		return helm.Release{}, errors.WrapIf(err, "failed to get  action configuration")
+		return nil, nil, errors.WrapIf(err, "failed to get  action configuration")
 	}
 
 	upgradeAction := action.NewUpgrade(actionConfig)
@@ -347,37 +423,34 @@ func (r releaser) Upgrade(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig
 		upgradeAction.Version = ">0.0.0-0"
 	}
 
-	chartPath, err := upgradeAction.ChartPathOptions.LocateChart(releaseInput.ChartName, r.processEnvSettings(helmEnv))
+	return actionConfig, upgradeAction, nil
+}
+
+// locateChart returns the local path of the chart: charts stored in OCI registries are pulled into the cache,
+// others are located (and downloaded if necessary) by helm
+func (r releaser) locateChart(ctx context.Context, helmEnv helm.HelmEnv, chartPathOptions action.ChartPathOptions, chartName string) (string, error) {
+	ref, ok, err := resolveOCIChartReference(helmEnv, chartName)
 	if err != nil {
-		return helm.Release{}, errors.WrapIf(err, "failed to locate chart")
+		return "", errors.WrapIf(err, "invalid OCI chart reference")
 	}
 
-	if upgradeAction.Install {
-		// If a release does not exist, install it.
-		histClient := action.NewHistory(actionConfig)
-		histClient.Max = 1
-		if _, err := histClient.Run(releaseInput.ReleaseName); err == driver.ErrReleaseNotFound {
-			r.logger.Debug("release doesn't exist, installing it now", map[string]interface{}{"releaseName": releaseInput.ReleaseName})
-
-			rel, err := r.Install(ctx, helmEnv, kubeConfig, releaseInput, options)
-			if err != nil {
-				return helm.Release{}, errors.WrapIf(err, "failed to install release during upgrade")
-			}
-
-			return rel, nil
-		} else if err != nil {
-			return helm.Release{}, errors.WrapIf(err, "failed to install release during upgrade")
-		}
+	if ok {
+		return pullOCIChart(ctx, helmEnv, ref, chartPathOptions.Version)
 	}
 
+	return chartPathOptions.LocateChart(chartName, r.processEnvSettings(helmEnv))
+}
+
+// loadUpgradeChart loads the chart from the given path and checks its dependencies
+func (r releaser) loadUpgradeChart(chartPath string) (*chart.Chart, error) {
 	// Check chart dependencies to make sure all are present in /charts
 	ch, err := loader.Load(chartPath)
 	if err != nil {
-		return helm.Release{}, errors.WrapIf(err, "failed to load chart")
+		return nil, errors.WrapIf(err, "failed to load chart")
 	}
 	if req := ch.Metadata.Dependencies; req != nil {
 		if err := action.CheckDependencies(ch, req); err != nil {
-			return helm.Release{}, errors.WrapIf(err, "failed to check dependencies")
+			return nil, errors.WrapIf(err, "failed to check dependencies")
 		}
 	}
 
@@ -385,14 +458,104 @@ func (r releaser) Upgrade(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig
 		r.logger.Warn("This chart is deprecated", map[string]interface{}{"chart": ch.Name()})
 	}
 
-	rel, err := upgradeAction.Run(releaseInput.ReleaseName, ch, releaseInput.Values)
+	return ch, nil
+}
+
+func (r releaser) History(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseName string, options helm.Options) ([]helm.Release, error) {
+	// component processing the kubeconfig
+	restClientGetter := NewCustomGetter(options.Namespace, kubeConfig, helmEnv.GetCacheDir(), r.logger)
+
+	actionConfig, err := r.getActionConfiguration(restClientGetter, options.Namespace)
 	if err != nil {
-		return helm.Release{}, errors.Wrap(err, "UPGRADE FAILED")
+		return nil, errors.WrapIf(err, "failed to get action configuration")
 	}
 
-	r.logger.Info("release has been upgraded. Happy Helming!", map[string]interface{}{"releaseName": releaseInput.ReleaseName})
+	historyAction := action.NewHistory(actionConfig)
 
-	return r.adaptReleasePtr(rel), nil
+	results, err := historyAction.Run(releaseName)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to get release history")
+	}
+
+	releases := make([]helm.Release, 0, len(results))
+	for _, result := range results {
+		releases = append(releases, helm.Release{
+			ReleaseName:    result.Name,
+			ChartName:      result.Chart.Metadata.Name,
+			Namespace:      result.Namespace,
+			Version:        result.Chart.Metadata.Version,
+			ReleaseVersion: int32(result.Version),
+			ReleaseInfo: helm.ReleaseInfo{
+				FirstDeployed: result.Info.FirstDeployed.Time,
+				LastDeployed:  result.Info.LastDeployed.Time,
+				Deleted:       result.Info.Deleted.Time,
+				Description:   result.Info.Description,
+				Status:        result.Info.Status.String(),
+				Values:        result.Config,
+			},
+		})
+	}
+
+	// latest revision first
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].ReleaseVersion > releases[j].ReleaseVersion
+	})
+
+	return releases, nil
+}
+
+func (r releaser) Rollback(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseName string, revision int32, options helm.Options) error {
+	// component processing the kubeconfig
+	restClientGetter := NewCustomGetter(options.Namespace, kubeConfig, helmEnv.GetCacheDir(), r.logger)
+
+	actionConfig, err := r.getActionConfiguration(restClientGetter, options.Namespace)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get action configuration")
+	}
+
+	rollbackAction := action.NewRollback(actionConfig)
+	rollbackAction.Version = int(revision)
+	rollbackAction.Wait = options.Wait
+	rollbackAction.DryRun = options.DryRun
+	rollbackAction.Timeout = time.Minute * 5
+
+	if err := rollbackAction.Run(releaseName); err != nil {
+		return errors.WrapIf(err, "failed to roll back release")
+	}
+
+	r.logger.Info("release has been rolled back", map[string]interface{}{"releaseName": releaseName, "revision": revision})
+
+	return nil
+}
+
+func (r releaser) Revision(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseName string, revision int32, options helm.Options) (helm.ReleaseRevision, error) {
+	// component processing the kubeconfig
+	restClientGetter := NewCustomGetter(options.Namespace, kubeConfig, helmEnv.GetCacheDir(), r.logger)
+
+	actionConfig, err := r.getActionConfiguration(restClientGetter, options.Namespace)
+	if err != nil {
+		return helm.ReleaseRevision{}, errors.WrapIf(err, "failed to get action configuration")
+	}
+
+	getAction := action.NewGet(actionConfig)
+	getAction.Version = int(revision)
+
+	rawRelease, err := getAction.Run(releaseName)
+	if err != nil {
+		return helm.ReleaseRevision{}, errors.WrapIf(err, "failed to get release revision")
+	}
+
+	return adaptReleaseRevision(rawRelease), nil
+}
+
+func adaptReleaseRevision(rawRelease *release.Release) helm.ReleaseRevision {
+	return helm.ReleaseRevision{
+		Revision:     int32(rawRelease.Version),
+		ChartName:    rawRelease.Chart.Metadata.Name,
+		ChartVersion: rawRelease.Chart.Metadata.Version,
+		Values:       rawRelease.Config,
+		Manifest:     rawRelease.Manifest,
+	}
 }
 
 func (r releaser) Resources(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseInput helm.Release, options helm.Options) ([]helm.ReleaseResource, error) {
@@ -17,8 +17,10 @@ package helmdriver
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"emperror.dev/errors"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -138,6 +140,60 @@ func RegisterReleaserHTTPHandlers(endpoints Endpoints, router *mux.Router, optio
 		kitxhttp.ErrorResponseEncoder(encodeCheckReleaseHTTPResponse, errorEncoder),
 		options...,
 	))
+
+	router.Methods(http.MethodGet).Path("/{name}/history").Handler(kithttp.NewServer(
+		endpoints.ListReleaseHistory,
+		decodeListReleaseHistoryHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListReleaseHistoryHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/{name}/rollback").Handler(kithttp.NewServer(
+		endpoints.RollbackRelease,
+		decodeRollbackReleaseHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusAccepted), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{name}/diff").Handler(kithttp.NewServer(
+		endpoints.DiffRelease,
+		decodeDiffReleaseHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeDiffReleaseHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/{name}/diff").Handler(kithttp.NewServer(
+		endpoints.DiffReleaseUpgrade,
+		decodeDiffReleaseUpgradeHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeDiffReleaseUpgradeHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+// RegisterDriftHTTPHandlers mounts the release drift endpoints onto the cluster router.
+func RegisterDriftHTTPHandlers(endpoints DriftEndpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodGet).Path("/deployment-drifts").Handler(kithttp.NewServer(
+		endpoints.ListReleaseDrifts,
+		decodeListReleaseDriftsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListReleaseDriftsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/deployments/{name}/drift").Handler(kithttp.NewServer(
+		endpoints.GetReleaseDrift,
+		decodeGetReleaseDriftHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetReleaseDriftHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPut).Path("/deployments/{name}/drift").Handler(kithttp.NewServer(
+		endpoints.SetReleaseSelfHeal,
+		decodeSetReleaseSelfHealHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
 }
 
 func RegisterRestAPI(endpoints RestAPIEndpoints, router *mux.Router, options ...kithttp.ServerOption) {
@@ -624,6 +680,196 @@ func encodeGetReleasesHTTPResponse(ctx context.Context, w http.ResponseWriter, r
 	return kitxhttp.JSONResponseEncoder(ctx, w, resp)
 }
 
+func decodeListReleaseHistoryHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode release history request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode release history request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode release history request")
+	}
+
+	return ListReleaseHistoryRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseName:    releaseName,
+		Options: helm.Options{
+			Namespace: r.URL.Query().Get("namespace"),
+		},
+	}, nil
+}
+
+// releaseHistoryItem describes a revision of a release
+type releaseHistoryItem struct {
+	Revision     int32     `json:"revision"`
+	ChartName    string    `json:"chartName"`
+	ChartVersion string    `json:"chartVersion"`
+	Status       string    `json:"status"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	Description  string    `json:"description"`
+}
+
+func encodeListReleaseHistoryHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(ListReleaseHistoryResponse)
+	if !ok {
+		return errors.New("invalid release history response")
+	}
+
+	history := make([]releaseHistoryItem, 0, len(resp.Releases))
+	for _, release := range resp.Releases {
+		history = append(history, releaseHistoryItem{
+			Revision:     release.ReleaseVersion,
+			ChartName:    release.ChartName,
+			ChartVersion: release.Version,
+			Status:       release.ReleaseInfo.Status,
+			UpdatedAt:    release.ReleaseInfo.LastDeployed,
+			Description:  release.ReleaseInfo.Description,
+		})
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, history)
+}
+
+func decodeRollbackReleaseHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode rollback release request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode rollback release request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode rollback release request")
+	}
+
+	var request struct {
+		Revision int32 `json:"revision"`
+		Wait     bool  `json:"wait"`
+	}
+
+	// the body is optional: the release is rolled back to the previous revision by default
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && err != io.EOF {
+		return nil, errors.WrapIf(err, "failed to decode rollback release request")
+	}
+
+	return RollbackReleaseRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseName:    releaseName,
+		Revision:       request.Revision,
+		Options: helm.Options{
+			Namespace: r.URL.Query().Get("namespace"),
+			Wait:      request.Wait,
+		},
+	}, nil
+}
+
+func decodeDiffReleaseHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	fromRevision, err := extractInt32QueryParamFromRequest("from", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	toRevision, err := extractInt32QueryParamFromRequest("to", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	return DiffReleaseRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseName:    releaseName,
+		FromRevision:   fromRevision,
+		ToRevision:     toRevision,
+		Options: helm.Options{
+			Namespace: r.URL.Query().Get("namespace"),
+		},
+	}, nil
+}
+
+func encodeDiffReleaseHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(DiffReleaseResponse)
+	if !ok {
+		return errors.New("invalid release diff response")
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Diff)
+}
+
+func decodeDiffReleaseUpgradeHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release upgrade request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release upgrade request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release upgrade request")
+	}
+
+	var request pipeline.CreateUpdateDeploymentRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release upgrade request")
+	}
+
+	return DiffReleaseUpgradeRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseInput: helm.Release{
+			ReleaseName: releaseName,
+			ChartName:   request.Name,
+			Namespace:   request.Namespace,
+			Values:      request.Values,
+			Version:     request.Version,
+		},
+		Options: helm.Options{
+			Namespace:   request.Namespace,
+			ReuseValues: request.ReuseValues,
+		},
+	}, nil
+}
+
+func encodeDiffReleaseUpgradeHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(DiffReleaseUpgradeResponse)
+	if !ok {
+		return errors.New("invalid release upgrade diff response")
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Diff)
+}
+
 func decodeListChartsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	orgID, err := extractUintParamFromRequest("orgId", r)
 	if err != nil {
@@ -775,3 +1021,154 @@ func extractUintParamFromRequest(key string, r *http.Request) (uint, error) {
 
 	return uint(uintVal), nil
 }
+
+func extractInt32QueryParamFromRequest(key string, r *http.Request) (int32, error) {
+	strVal := r.URL.Query().Get(key)
+	if strVal == "" {
+		return 0, nil
+	}
+
+	intVal, err := strconv.ParseInt(strVal, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to parse query param", "param", key, "value", strVal)
+	}
+
+	return int32(intVal), nil
+}
+
+func decodeListReleaseDriftsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode list release drifts request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode list release drifts request")
+	}
+
+	return ListReleaseDriftsDriftRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+	}, nil
+}
+
+// releaseDrift describes the latest drift detection result of a release
+type releaseDrift struct {
+	ReleaseName string                 `json:"releaseName"`
+	Namespace   string                 `json:"namespace"`
+	Revision    int32                  `json:"revision,omitempty"`
+	Drifted     bool                   `json:"drifted"`
+	SelfHeal    bool                   `json:"selfHeal"`
+	Resources   []helm.DriftedResource `json:"resources"`
+	CheckedAt   *time.Time             `json:"checkedAt,omitempty"`
+	HealedAt    *time.Time             `json:"healedAt,omitempty"`
+}
+
+func newReleaseDrift(drift helm.ReleaseDrift) releaseDrift {
+	resp := releaseDrift{
+		ReleaseName: drift.ReleaseName,
+		Namespace:   drift.Namespace,
+		Revision:    drift.Revision,
+		Drifted:     drift.Drifted,
+		SelfHeal:    drift.SelfHeal,
+		Resources:   drift.Resources,
+		HealedAt:    drift.HealedAt,
+	}
+
+	if resp.Resources == nil {
+		resp.Resources = []helm.DriftedResource{}
+	}
+
+	// the release has not been checked yet
+	if !drift.CheckedAt.IsZero() {
+		checkedAt := drift.CheckedAt
+		resp.CheckedAt = &checkedAt
+	}
+
+	return resp
+}
+
+func encodeListReleaseDriftsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(ListReleaseDriftsDriftResponse)
+	if !ok {
+		return errors.New("invalid release drift list response")
+	}
+
+	drifts := make([]releaseDrift, 0, len(resp.Drifts))
+	for _, drift := range resp.Drifts {
+		drifts = append(drifts, newReleaseDrift(drift))
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, drifts)
+}
+
+func decodeGetReleaseDriftHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode get release drift request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode get release drift request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode get release drift request")
+	}
+
+	return GetReleaseDriftDriftRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseName:    releaseName,
+		Options: helm.Options{
+			Namespace: r.URL.Query().Get("namespace"),
+		},
+	}, nil
+}
+
+func encodeGetReleaseDriftHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(GetReleaseDriftDriftResponse)
+	if !ok {
+		return errors.New("invalid release drift response")
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, newReleaseDrift(resp.Drift))
+}
+
+func decodeSetReleaseSelfHealHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode set release self-heal request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode set release self-heal request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode set release self-heal request")
+	}
+
+	var request struct {
+		SelfHeal bool `json:"selfHeal"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.WrapIf(err, "failed to decode set release self-heal request")
+	}
+
+	return SetReleaseSelfHealDriftRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseName:    releaseName,
+		SelfHeal:       request.SelfHeal,
+		Options: helm.Options{
+			Namespace: r.URL.Query().Get("namespace"),
+		},
+	}, nil
+}
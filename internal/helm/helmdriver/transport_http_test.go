@@ -157,3 +157,173 @@ func TestRegisterHTTPHandlers_AddRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterReleaserHTTPHandlers_ListReleaseHistory(t *testing.T) {
+	handler := mux.NewRouter()
+	RegisterReleaserHTTPHandlers(
+		Endpoints{
+			ListReleaseHistory: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				req := request.(ListReleaseHistoryRequest)
+
+				return ListReleaseHistoryResponse{
+					Releases: []helm.Release{
+						{
+							ReleaseName:    req.ReleaseName,
+							ChartName:      "nginx-ingress",
+							Version:        "1.1.0",
+							ReleaseVersion: 2,
+							ReleaseInfo:    helm.ReleaseInfo{Status: "deployed", Description: "Upgrade complete"},
+						},
+						{
+							ReleaseName:    req.ReleaseName,
+							ChartName:      "nginx-ingress",
+							Version:        "1.0.0",
+							ReleaseVersion: 1,
+							ReleaseInfo:    helm.ReleaseInfo{Status: "superseded", Description: "Install complete"},
+						},
+					},
+				}, nil
+			},
+		},
+		handler.PathPrefix("/orgs/{orgId}/clusters/{clusterId}/deployments").Subrouter(),
+	)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(fmt.Sprintf("%s/orgs/%d/clusters/%d/deployments/%s/history", ts.URL, 1, 1, "ingress"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history []releaseHistoryItem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	require.Len(t, history, 2)
+	assert.Equal(t, int32(2), history[0].Revision)
+	assert.Equal(t, "1.1.0", history[0].ChartVersion)
+	assert.Equal(t, "superseded", history[1].Status)
+}
+
+func TestRegisterReleaserHTTPHandlers_RollbackRelease(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		expectedRevision   int32
+		expectedStatusCode int
+	}{
+		{
+			name:               "PreviousRevision",
+			body:               "",
+			expectedRevision:   0,
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name:               "GivenRevision",
+			body:               `{"revision": 3}`,
+			expectedRevision:   3,
+			expectedStatusCode: http.StatusAccepted,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := mux.NewRouter()
+			RegisterReleaserHTTPHandlers(
+				Endpoints{
+					RollbackRelease: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+						req := request.(RollbackReleaseRequest)
+
+						assert.Equal(t, "ingress", req.ReleaseName)
+						assert.Equal(t, tt.expectedRevision, req.Revision)
+
+						return RollbackReleaseResponse{}, nil
+					},
+				},
+				handler.PathPrefix("/orgs/{orgId}/clusters/{clusterId}/deployments").Subrouter(),
+			)
+
+			ts := httptest.NewServer(handler)
+			defer ts.Close()
+
+			resp, err := ts.Client().Post(
+				fmt.Sprintf("%s/orgs/%d/clusters/%d/deployments/%s/rollback", ts.URL, 1, 1, "ingress"),
+				"application/json",
+				bytes.NewReader([]byte(tt.body)),
+			)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestRegisterDriftHTTPHandlers_SetReleaseSelfHeal(t *testing.T) {
+	handler := mux.NewRouter()
+	RegisterDriftHTTPHandlers(
+		DriftEndpoints{
+			SetReleaseSelfHeal: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				req := request.(SetReleaseSelfHealDriftRequest)
+
+				assert.Equal(t, "ingress", req.ReleaseName)
+				assert.Equal(t, "pipeline-system", req.Options.Namespace)
+				assert.True(t, req.SelfHeal)
+
+				return SetReleaseSelfHealDriftResponse{}, nil
+			},
+		},
+		handler.PathPrefix("/orgs/{orgId}/clusters/{clusterId}").Subrouter(),
+	)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	req, err := http.NewRequest(
+		http.MethodPut,
+		fmt.Sprintf("%s/orgs/%d/clusters/%d/deployments/%s/drift?namespace=pipeline-system", ts.URL, 1, 1, "ingress"),
+		bytes.NewReader([]byte(`{"selfHeal": true}`)),
+	)
+	require.NoError(t, err)
+
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestRegisterDriftHTTPHandlers_ListReleaseDrifts(t *testing.T) {
+	handler := mux.NewRouter()
+	RegisterDriftHTTPHandlers(
+		DriftEndpoints{
+			ListReleaseDrifts: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				return ListReleaseDriftsDriftResponse{
+					Drifts: []helm.ReleaseDrift{
+						{ReleaseName: "ingress", Namespace: "pipeline-system"},
+					},
+				}, nil
+			},
+		},
+		handler.PathPrefix("/orgs/{orgId}/clusters/{clusterId}").Subrouter(),
+	)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(fmt.Sprintf("%s/orgs/%d/clusters/%d/deployment-drifts", ts.URL, 1, 1))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var drifts []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&drifts))
+
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "ingress", drifts[0]["releaseName"])
+	assert.Equal(t, []interface{}{}, drifts[0]["resources"])
+	assert.NotContains(t, drifts[0], "checkedAt")
+}
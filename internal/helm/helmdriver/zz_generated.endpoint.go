@@ -23,6 +23,148 @@ type serviceError interface {
 	ServiceError() bool
 }
 
+// DriftEndpoints collects all of the endpoints that compose the underlying service. It's
+// meant to be used as a helper struct, to collect all of the endpoints into a
+// single parameter.
+type DriftEndpoints struct {
+	GetReleaseDrift    endpoint.Endpoint
+	ListReleaseDrifts  endpoint.Endpoint
+	SetReleaseSelfHeal endpoint.Endpoint
+}
+
+// MakeDriftEndpoints returns a(n) DriftEndpoints struct where each endpoint invokes
+// the corresponding method on the provided service.
+func MakeDriftEndpoints(service helm.DriftService, middleware ...endpoint.Middleware) DriftEndpoints {
+	mw := kitxendpoint.Combine(middleware...)
+
+	return DriftEndpoints{
+		GetReleaseDrift:    kitxendpoint.OperationNameMiddleware("helm.Drift.GetReleaseDrift")(mw(MakeGetReleaseDriftDriftEndpoint(service))),
+		ListReleaseDrifts:  kitxendpoint.OperationNameMiddleware("helm.Drift.ListReleaseDrifts")(mw(MakeListReleaseDriftsDriftEndpoint(service))),
+		SetReleaseSelfHeal: kitxendpoint.OperationNameMiddleware("helm.Drift.SetReleaseSelfHeal")(mw(MakeSetReleaseSelfHealDriftEndpoint(service))),
+	}
+}
+
+// GetReleaseDriftDriftRequest is a request struct for GetReleaseDrift endpoint.
+type GetReleaseDriftDriftRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseName    string
+	Options        helm.Options
+}
+
+// GetReleaseDriftDriftResponse is a response struct for GetReleaseDrift endpoint.
+type GetReleaseDriftDriftResponse struct {
+	Drift helm.ReleaseDrift
+	Err   error
+}
+
+func (r GetReleaseDriftDriftResponse) Failed() error {
+	return r.Err
+}
+
+// MakeGetReleaseDriftDriftEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeGetReleaseDriftDriftEndpoint(service helm.DriftService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetReleaseDriftDriftRequest)
+
+		drift, err := service.GetReleaseDrift(ctx, req.OrganizationID, req.ClusterID, req.ReleaseName, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return GetReleaseDriftDriftResponse{
+					Drift: drift,
+					Err:   err,
+				}, nil
+			}
+
+			return GetReleaseDriftDriftResponse{
+				Drift: drift,
+				Err:   err,
+			}, err
+		}
+
+		return GetReleaseDriftDriftResponse{Drift: drift}, nil
+	}
+}
+
+// ListReleaseDriftsDriftRequest is a request struct for ListReleaseDrifts endpoint.
+type ListReleaseDriftsDriftRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+}
+
+// ListReleaseDriftsDriftResponse is a response struct for ListReleaseDrifts endpoint.
+type ListReleaseDriftsDriftResponse struct {
+	Drifts []helm.ReleaseDrift
+	Err    error
+}
+
+func (r ListReleaseDriftsDriftResponse) Failed() error {
+	return r.Err
+}
+
+// MakeListReleaseDriftsDriftEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeListReleaseDriftsDriftEndpoint(service helm.DriftService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListReleaseDriftsDriftRequest)
+
+		drifts, err := service.ListReleaseDrifts(ctx, req.OrganizationID, req.ClusterID)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return ListReleaseDriftsDriftResponse{
+					Drifts: drifts,
+					Err:    err,
+				}, nil
+			}
+
+			return ListReleaseDriftsDriftResponse{
+				Drifts: drifts,
+				Err:    err,
+			}, err
+		}
+
+		return ListReleaseDriftsDriftResponse{Drifts: drifts}, nil
+	}
+}
+
+// SetReleaseSelfHealDriftRequest is a request struct for SetReleaseSelfHeal endpoint.
+type SetReleaseSelfHealDriftRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseName    string
+	SelfHeal       bool
+	Options        helm.Options
+}
+
+// SetReleaseSelfHealDriftResponse is a response struct for SetReleaseSelfHeal endpoint.
+type SetReleaseSelfHealDriftResponse struct {
+	Err error
+}
+
+func (r SetReleaseSelfHealDriftResponse) Failed() error {
+	return r.Err
+}
+
+// MakeSetReleaseSelfHealDriftEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeSetReleaseSelfHealDriftEndpoint(service helm.DriftService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SetReleaseSelfHealDriftRequest)
+
+		err := service.SetReleaseSelfHeal(ctx, req.OrganizationID, req.ClusterID, req.ReleaseName, req.SelfHeal, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return SetReleaseSelfHealDriftResponse{Err: err}, nil
+			}
+
+			return SetReleaseSelfHealDriftResponse{Err: err}, err
+		}
+
+		return SetReleaseSelfHealDriftResponse{}, nil
+	}
+}
+
 // RestAPIEndpoints collects all of the endpoints that compose the underlying service. It's
 // meant to be used as a helper struct, to collect all of the endpoints into a
 // single parameter.
@@ -90,15 +232,19 @@ type Endpoints struct {
 	CheckReleases       endpoint.Endpoint
 	DeleteRelease       endpoint.Endpoint
 	DeleteRepository    endpoint.Endpoint
+	DiffRelease         endpoint.Endpoint
+	DiffReleaseUpgrade  endpoint.Endpoint
 	GetChart            endpoint.Endpoint
 	GetRelease          endpoint.Endpoint
 	GetReleaseResources endpoint.Endpoint
 	InstallRelease      endpoint.Endpoint
 	ListCharts          endpoint.Endpoint
 	ListClusterCharts   endpoint.Endpoint
+	ListReleaseHistory  endpoint.Endpoint
 	ListReleases        endpoint.Endpoint
 	ListRepositories    endpoint.Endpoint
 	ModifyRepository    endpoint.Endpoint
+	RollbackRelease     endpoint.Endpoint
 	UpdateRepository    endpoint.Endpoint
 	UpgradeRelease      endpoint.Endpoint
 }
@@ -114,15 +260,19 @@ func MakeEndpoints(service helm.Service, middleware ...endpoint.Middleware) Endp
 		CheckReleases:       kitxendpoint.OperationNameMiddleware("helm.CheckReleases")(mw(MakeCheckReleasesEndpoint(service))),
 		DeleteRelease:       kitxendpoint.OperationNameMiddleware("helm.DeleteRelease")(mw(MakeDeleteReleaseEndpoint(service))),
 		DeleteRepository:    kitxendpoint.OperationNameMiddleware("helm.DeleteRepository")(mw(MakeDeleteRepositoryEndpoint(service))),
+		DiffRelease:         kitxendpoint.OperationNameMiddleware("helm.DiffRelease")(mw(MakeDiffReleaseEndpoint(service))),
+		DiffReleaseUpgrade:  kitxendpoint.OperationNameMiddleware("helm.DiffReleaseUpgrade")(mw(MakeDiffReleaseUpgradeEndpoint(service))),
 		GetChart:            kitxendpoint.OperationNameMiddleware("helm.GetChart")(mw(MakeGetChartEndpoint(service))),
 		GetRelease:          kitxendpoint.OperationNameMiddleware("helm.GetRelease")(mw(MakeGetReleaseEndpoint(service))),
 		GetReleaseResources: kitxendpoint.OperationNameMiddleware("helm.GetReleaseResources")(mw(MakeGetReleaseResourcesEndpoint(service))),
 		InstallRelease:      kitxendpoint.OperationNameMiddleware("helm.InstallRelease")(mw(MakeInstallReleaseEndpoint(service))),
 		ListCharts:          kitxendpoint.OperationNameMiddleware("helm.ListCharts")(mw(MakeListChartsEndpoint(service))),
 		ListClusterCharts:   kitxendpoint.OperationNameMiddleware("helm.ListClusterCharts")(mw(MakeListClusterChartsEndpoint(service))),
+		ListReleaseHistory:  kitxendpoint.OperationNameMiddleware("helm.ListReleaseHistory")(mw(MakeListReleaseHistoryEndpoint(service))),
 		ListReleases:        kitxendpoint.OperationNameMiddleware("helm.ListReleases")(mw(MakeListReleasesEndpoint(service))),
 		ListRepositories:    kitxendpoint.OperationNameMiddleware("helm.ListRepositories")(mw(MakeListRepositoriesEndpoint(service))),
 		ModifyRepository:    kitxendpoint.OperationNameMiddleware("helm.ModifyRepository")(mw(MakeModifyRepositoryEndpoint(service))),
+		RollbackRelease:     kitxendpoint.OperationNameMiddleware("helm.RollbackRelease")(mw(MakeRollbackReleaseEndpoint(service))),
 		UpdateRepository:    kitxendpoint.OperationNameMiddleware("helm.UpdateRepository")(mw(MakeUpdateRepositoryEndpoint(service))),
 		UpgradeRelease:      kitxendpoint.OperationNameMiddleware("helm.UpgradeRelease")(mw(MakeUpgradeReleaseEndpoint(service))),
 	}
@@ -316,6 +466,94 @@ func MakeDeleteRepositoryEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// DiffReleaseRequest is a request struct for DiffRelease endpoint.
+type DiffReleaseRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseName    string
+	FromRevision   int32
+	ToRevision     int32
+	Options        helm.Options
+}
+
+// DiffReleaseResponse is a response struct for DiffRelease endpoint.
+type DiffReleaseResponse struct {
+	Diff helm.ReleaseDiff
+	Err  error
+}
+
+func (r DiffReleaseResponse) Failed() error {
+	return r.Err
+}
+
+// MakeDiffReleaseEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeDiffReleaseEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DiffReleaseRequest)
+
+		diff, err := service.DiffRelease(ctx, req.OrganizationID, req.ClusterID, req.ReleaseName, req.FromRevision, req.ToRevision, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return DiffReleaseResponse{
+					Diff: diff,
+					Err:  err,
+				}, nil
+			}
+
+			return DiffReleaseResponse{
+				Diff: diff,
+				Err:  err,
+			}, err
+		}
+
+		return DiffReleaseResponse{Diff: diff}, nil
+	}
+}
+
+// DiffReleaseUpgradeRequest is a request struct for DiffReleaseUpgrade endpoint.
+type DiffReleaseUpgradeRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseInput   helm.Release
+	Options        helm.Options
+}
+
+// DiffReleaseUpgradeResponse is a response struct for DiffReleaseUpgrade endpoint.
+type DiffReleaseUpgradeResponse struct {
+	Diff helm.ReleaseDiff
+	Err  error
+}
+
+func (r DiffReleaseUpgradeResponse) Failed() error {
+	return r.Err
+}
+
+// MakeDiffReleaseUpgradeEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeDiffReleaseUpgradeEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DiffReleaseUpgradeRequest)
+
+		diff, err := service.DiffReleaseUpgrade(ctx, req.OrganizationID, req.ClusterID, req.ReleaseInput, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return DiffReleaseUpgradeResponse{
+					Diff: diff,
+					Err:  err,
+				}, nil
+			}
+
+			return DiffReleaseUpgradeResponse{
+				Diff: diff,
+				Err:  err,
+			}, err
+		}
+
+		return DiffReleaseUpgradeResponse{Diff: diff}, nil
+	}
+}
+
 // GetChartRequest is a request struct for GetChart endpoint.
 type GetChartRequest struct {
 	OrganizationID uint
@@ -570,6 +808,49 @@ func MakeListClusterChartsEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// ListReleaseHistoryRequest is a request struct for ListReleaseHistory endpoint.
+type ListReleaseHistoryRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseName    string
+	Options        helm.Options
+}
+
+// ListReleaseHistoryResponse is a response struct for ListReleaseHistory endpoint.
+type ListReleaseHistoryResponse struct {
+	Releases []helm.Release
+	Err      error
+}
+
+func (r ListReleaseHistoryResponse) Failed() error {
+	return r.Err
+}
+
+// MakeListReleaseHistoryEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeListReleaseHistoryEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListReleaseHistoryRequest)
+
+		releases, err := service.ListReleaseHistory(ctx, req.OrganizationID, req.ClusterID, req.ReleaseName, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return ListReleaseHistoryResponse{
+					Err:      err,
+					Releases: releases,
+				}, nil
+			}
+
+			return ListReleaseHistoryResponse{
+				Err:      err,
+				Releases: releases,
+			}, err
+		}
+
+		return ListReleaseHistoryResponse{Releases: releases}, nil
+	}
+}
+
 // ListReleasesRequest is a request struct for ListReleases endpoint.
 type ListReleasesRequest struct {
 	OrganizationID uint
@@ -687,6 +968,43 @@ func MakeModifyRepositoryEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// RollbackReleaseRequest is a request struct for RollbackRelease endpoint.
+type RollbackReleaseRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseName    string
+	Revision       int32
+	Options        helm.Options
+}
+
+// RollbackReleaseResponse is a response struct for RollbackRelease endpoint.
+type RollbackReleaseResponse struct {
+	Err error
+}
+
+func (r RollbackReleaseResponse) Failed() error {
+	return r.Err
+}
+
+// MakeRollbackReleaseEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeRollbackReleaseEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RollbackReleaseRequest)
+
+		err := service.RollbackRelease(ctx, req.OrganizationID, req.ClusterID, req.ReleaseName, req.Revision, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return RollbackReleaseResponse{Err: err}, nil
+			}
+
+			return RollbackReleaseResponse{Err: err}, err
+		}
+
+		return RollbackReleaseResponse{}, nil
+	}
+}
+
 // UpdateRepositoryRequest is a request struct for UpdateRepository endpoint.
 type UpdateRepositoryRequest struct {
 	OrganizationID uint
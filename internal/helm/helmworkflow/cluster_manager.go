@@ -0,0 +1,59 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/src/cluster"
+)
+
+// ClusterManagerAdapter lists clusters using the cluster manager.
+type ClusterManagerAdapter struct {
+	clusterManager *cluster.Manager
+}
+
+// NewClusterManagerAdapter creates a new ClusterManagerAdapter.
+func NewClusterManagerAdapter(clusterManager *cluster.Manager) ClusterManagerAdapter {
+	return ClusterManagerAdapter{
+		clusterManager: clusterManager,
+	}
+}
+
+// ListRunningClusters returns the clusters in running state.
+func (a ClusterManagerAdapter) ListRunningClusters(ctx context.Context) ([]Cluster, error) {
+	commonClusters, err := a.clusterManager.GetAllClusters(ctx)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list clusters")
+	}
+
+	clusters := make([]Cluster, 0, len(commonClusters))
+	for _, commonCluster := range commonClusters {
+		status, err := commonCluster.GetStatus()
+		if err != nil || status.Status != pkgCluster.Running {
+			continue
+		}
+
+		clusters = append(clusters, Cluster{
+			OrganizationID: commonCluster.GetOrganizationId(),
+			ID:             commonCluster.GetID(),
+		})
+	}
+
+	return clusters, nil
+}
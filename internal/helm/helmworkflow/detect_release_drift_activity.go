@@ -0,0 +1,97 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmworkflow
+
+import (
+	"context"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+// ListRunningClustersActivityName is the name of the activity listing the clusters checked for release drift.
+const ListRunningClustersActivityName = "helm-list-running-clusters"
+
+// Cluster identifies a cluster the releases of which are checked for drift.
+type Cluster struct {
+	OrganizationID uint
+	ID             uint
+}
+
+// ClusterLister lists the clusters the releases of which are checked for drift.
+type ClusterLister interface {
+	// ListRunningClusters returns the clusters in running state.
+	ListRunningClusters(ctx context.Context) ([]Cluster, error)
+}
+
+// ListRunningClustersActivity lists the clusters the releases of which are checked for drift.
+type ListRunningClustersActivity struct {
+	clusters ClusterLister
+}
+
+// NewListRunningClustersActivity returns a new ListRunningClustersActivity.
+func NewListRunningClustersActivity(clusters ClusterLister) ListRunningClustersActivity {
+	return ListRunningClustersActivity{
+		clusters: clusters,
+	}
+}
+
+// Execute executes the activity.
+func (a ListRunningClustersActivity) Execute(ctx context.Context) ([]Cluster, error) {
+	return a.clusters.ListRunningClusters(ctx)
+}
+
+// Register registers the activity in the worker.
+func (a ListRunningClustersActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: ListRunningClustersActivityName})
+}
+
+// DetectClusterReleaseDriftActivityName is the name of the activity detecting the drift of the releases on a cluster.
+const DetectClusterReleaseDriftActivityName = "helm-detect-cluster-release-drift"
+
+// DetectClusterReleaseDriftActivityInput holds the parameters of the drift detection of a cluster.
+type DetectClusterReleaseDriftActivityInput struct {
+	OrganizationID uint
+	ClusterID      uint
+}
+
+// ClusterDriftDetector detects the drift of the releases on a cluster.
+type ClusterDriftDetector interface {
+	// DetectClusterDrift records the drift of every release on the cluster.
+	DetectClusterDrift(ctx context.Context, organizationID uint, clusterID uint) error
+}
+
+// DetectClusterReleaseDriftActivity detects the drift of the releases on a cluster.
+type DetectClusterReleaseDriftActivity struct {
+	detector ClusterDriftDetector
+}
+
+// NewDetectClusterReleaseDriftActivity returns a new DetectClusterReleaseDriftActivity.
+func NewDetectClusterReleaseDriftActivity(detector ClusterDriftDetector) DetectClusterReleaseDriftActivity {
+	return DetectClusterReleaseDriftActivity{
+		detector: detector,
+	}
+}
+
+// Execute executes the activity.
+func (a DetectClusterReleaseDriftActivity) Execute(ctx context.Context, input DetectClusterReleaseDriftActivityInput) error {
+	return a.detector.DetectClusterDrift(ctx, input.OrganizationID, input.ClusterID)
+}
+
+// Register registers the activity in the worker.
+func (a DetectClusterReleaseDriftActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: DetectClusterReleaseDriftActivityName})
+}
@@ -0,0 +1,90 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmworkflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+// DetectReleaseDriftWorkflowName is the name of the release drift detection workflow.
+const DetectReleaseDriftWorkflowName = "helm-detect-release-drift"
+
+// DetectReleaseDriftWorkflow compares the releases of the running clusters
+// with their live objects and re-applies the drifted ones having self-heal
+// turned on.
+type DetectReleaseDriftWorkflow struct{}
+
+// NewDetectReleaseDriftWorkflow instantiates a release drift detection workflow.
+func NewDetectReleaseDriftWorkflow() *DetectReleaseDriftWorkflow {
+	return &DetectReleaseDriftWorkflow{}
+}
+
+// Execute runs the workflow.
+func (w DetectReleaseDriftWorkflow) Execute(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+
+	activityContext := workflow.WithActivityOptions(
+		ctx,
+		workflow.ActivityOptions{
+			ScheduleToStartTimeout: 10 * time.Minute,
+			StartToCloseTimeout:    15 * time.Minute,
+			WaitForCancellation:    true,
+			RetryPolicy: &cadence.RetryPolicy{
+				InitialInterval:          time.Minute,
+				BackoffCoefficient:       2.0,
+				ExpirationInterval:       30 * time.Minute,
+				MaximumAttempts:          3,
+				NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+			},
+		},
+	)
+
+	var clusters []Cluster
+	err := workflow.ExecuteActivity(activityContext, ListRunningClustersActivityName).Get(ctx, &clusters)
+	if err != nil {
+		return err
+	}
+
+	futures := make([]workflow.Future, 0, len(clusters))
+	for _, cluster := range clusters {
+		input := DetectClusterReleaseDriftActivityInput{
+			OrganizationID: cluster.OrganizationID,
+			ClusterID:      cluster.ID,
+		}
+
+		futures = append(futures, workflow.ExecuteActivity(activityContext, DetectClusterReleaseDriftActivityName, input))
+	}
+
+	// drift detection failing on a cluster should not affect the other clusters
+	for i, future := range futures {
+		if err := future.Get(ctx, nil); err != nil {
+			logger.Sugar().Warnw("release drift detection failed", "clusterId", clusters[i].ID, "error", err.Error())
+		}
+	}
+
+	logger.Sugar().Infow("release drift detection finished", "clusters", len(clusters))
+
+	return nil
+}
+
+// Register registers the workflow in the worker.
+func (w DetectReleaseDriftWorkflow) Register(worker worker.Registry) {
+	worker.RegisterWorkflowWithOptions(w.Execute, workflow.RegisterOptions{Name: DetectReleaseDriftWorkflowName})
+}
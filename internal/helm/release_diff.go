@@ -0,0 +1,86 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// NewReleaseDiff calculates the differences between two revisions of a release.
+// The target revision is treated as a proposed upgrade if its revision number is 0.
+func NewReleaseDiff(releaseName string, from ReleaseRevision, to ReleaseRevision) (ReleaseDiff, error) {
+	fromName := fmt.Sprintf("revision %d", from.Revision)
+	toName := "proposed"
+	if to.Revision != 0 {
+		toName = fmt.Sprintf("revision %d", to.Revision)
+	}
+
+	fromValues, err := valuesToYAML(from.Values)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIfWithDetails(err, "failed to marshal values", "revision", from.Revision)
+	}
+
+	toValues, err := valuesToYAML(to.Values)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIfWithDetails(err, "failed to marshal values", "revision", to.Revision)
+	}
+
+	valuesDiff, err := unifiedDiff(fromName, fromValues, toName, toValues)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIf(err, "failed to diff values")
+	}
+
+	manifestDiff, err := unifiedDiff(fromName, from.Manifest, toName, to.Manifest)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIf(err, "failed to diff manifests")
+	}
+
+	return ReleaseDiff{
+		ReleaseName:      releaseName,
+		FromRevision:     from.Revision,
+		FromChartVersion: from.ChartVersion,
+		ToRevision:       to.Revision,
+		ToChartVersion:   to.ChartVersion,
+		Values:           valuesDiff,
+		Manifest:         manifestDiff,
+	}, nil
+}
+
+func valuesToYAML(values map[string]interface{}) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func unifiedDiff(fromName string, from string, toName string, to string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
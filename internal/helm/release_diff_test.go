@@ -0,0 +1,67 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReleaseDiff(t *testing.T) {
+	from := ReleaseRevision{
+		Revision:     1,
+		ChartName:    "nginx-ingress",
+		ChartVersion: "1.0.0",
+		Values:       map[string]interface{}{"replicaCount": 1},
+		Manifest:     "kind: Deployment\nspec:\n  replicas: 1\n",
+	}
+
+	t.Run("Revisions", func(t *testing.T) {
+		to := ReleaseRevision{
+			Revision:     2,
+			ChartName:    "nginx-ingress",
+			ChartVersion: "1.1.0",
+			Values:       map[string]interface{}{"replicaCount": 2},
+			Manifest:     "kind: Deployment\nspec:\n  replicas: 2\n",
+		}
+
+		diff, err := NewReleaseDiff("ingress", from, to)
+		require.NoError(t, err)
+
+		assert.Equal(t, "ingress", diff.ReleaseName)
+		assert.Equal(t, int32(1), diff.FromRevision)
+		assert.Equal(t, int32(2), diff.ToRevision)
+		assert.Equal(t, "1.0.0", diff.FromChartVersion)
+		assert.Equal(t, "1.1.0", diff.ToChartVersion)
+		assert.Contains(t, diff.Values, "--- revision 1\n+++ revision 2\n")
+		assert.Contains(t, diff.Values, "-replicaCount: 1\n+replicaCount: 2\n")
+		assert.Contains(t, diff.Manifest, "-  replicas: 1\n+  replicas: 2\n")
+	})
+
+	t.Run("ProposedUpgrade", func(t *testing.T) {
+		diff, err := NewReleaseDiff("ingress", from, ReleaseRevision{
+			ChartVersion: "1.0.0",
+			Manifest:     from.Manifest,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(0), diff.ToRevision)
+		assert.Contains(t, diff.Values, "+++ proposed\n")
+		assert.Contains(t, diff.Values, "-replicaCount: 1\n")
+		assert.Empty(t, diff.Manifest)
+	})
+}
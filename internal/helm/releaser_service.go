@@ -38,6 +38,8 @@ type ReleaseInfo struct {
 	Notes string
 	// Contains override values provided to the release
 	Values map[string]interface{}
+	// Contains the rendered templates of the release
+	Manifest string
 }
 
 type ReleaseResource struct {
@@ -58,6 +60,31 @@ type Release struct {
 	ReleaseResources []ReleaseResource
 }
 
+// ReleaseRevision holds the content of a single revision of a release
+type ReleaseRevision struct {
+	Revision     int32
+	ChartName    string
+	ChartVersion string
+	// Values contains the override values provided to the revision
+	Values map[string]interface{}
+	// Manifest contains the rendered templates of the revision
+	Manifest string
+}
+
+// ReleaseDiff describes the differences between two revisions of a release
+type ReleaseDiff struct {
+	ReleaseName      string `json:"releaseName"`
+	FromRevision     int32  `json:"fromRevision"`
+	FromChartVersion string `json:"fromChartVersion"`
+	// ToRevision is left empty when the diff is calculated against a proposed upgrade
+	ToRevision     int32  `json:"toRevision,omitempty"`
+	ToChartVersion string `json:"toChartVersion"`
+	// Values is a unified diff of the override values
+	Values string `json:"values"`
+	// Manifest is a unified diff of the rendered templates
+	Manifest string `json:"manifest"`
+}
+
 type KubeConfigBytes = []byte
 
 // ReleaseFilter struct for release filter data
@@ -84,6 +111,15 @@ type releaser interface {
 	CheckRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (string, error)
 	// ReleaseResources retrieves resources belonging to the release
 	GetReleaseResources(ctx context.Context, organizationID uint, clusterID uint, release Release, options Options) ([]ReleaseResource, error)
+	// ListReleaseHistory retrieves the revisions of the release
+	ListReleaseHistory(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (releases []Release, err error)
+	// RollbackRelease rolls the release back to the given revision (to the previous one if revision is 0)
+	RollbackRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, revision int32, options Options) error
+	// DiffRelease calculates the differences between two revisions of the release
+	// The deployed revision is used if toRevision is 0, the one preceding toRevision if fromRevision is 0
+	DiffRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, fromRevision int32, toRevision int32, options Options) (diff ReleaseDiff, err error)
+	// DiffReleaseUpgrade calculates the differences between the deployed revision and a proposed upgrade of the release
+	DiffReleaseUpgrade(ctx context.Context, organizationID uint, clusterID uint, releaseInput Release, options Options) (diff ReleaseDiff, err error)
 }
 
 // utility for providing input arguments ...
@@ -109,6 +145,14 @@ type Releaser interface {
 	Upgrade(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (release Release, err error)
 	// Resources retrieves the kubernetes resources belonging to the release
 	Resources(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) ([]ReleaseResource, error)
+	// History retrieves the revisions of the given release
+	History(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, options Options) ([]Release, error)
+	// Rollback rolls the given release back to the given revision (to the previous one if revision is 0)
+	Rollback(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, revision int32, options Options) error
+	// Revision retrieves the content of the given revision of the release (of the deployed one if revision is 0)
+	Revision(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, revision int32, options Options) (ReleaseRevision, error)
+	// RenderUpgrade renders the given upgrade of the release without applying it
+	RenderUpgrade(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (ReleaseRevision, error)
 }
 
 func ErrReleaseNotFound(err error) bool {
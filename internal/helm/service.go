@@ -16,6 +16,7 @@ package helm
 
 import (
 	"context"
+	"strings"
 
 	"emperror.dev/errors"
 
@@ -31,6 +32,8 @@ type Repository struct {
 	Name string `json:"name"`
 
 	// URL is the repository URL.
+	//
+	// OCI registries are referenced with the oci scheme (eg. oci://registry.example.com/charts).
 	URL string `json:"url"`
 
 	// PasswordSecretID is the identifier of a password type secret that contains the credentials for a repository.
@@ -46,6 +49,19 @@ type Repository struct {
 	TlsSecretID string `json:"tlsSecretId,omitempty"`
 }
 
+// OCIScheme is the URL scheme of repositories and charts stored in OCI registries.
+const OCIScheme = "oci"
+
+// IsOCI tells whether the repository is an OCI registry.
+func (r Repository) IsOCI() bool {
+	return IsOCIReference(r.URL)
+}
+
+// IsOCIReference tells whether the passed in URL or chart reference points to an OCI registry.
+func IsOCIReference(ref string) bool {
+	return strings.HasPrefix(ref, OCIScheme+"://")
+}
+
 // Options struct holding directives for driving helm operations (similar to command line flags)
 // extend this as required eventually build a more sophisticated solution for it
 type Options struct {
@@ -80,6 +96,8 @@ type ClusterDataProvider interface {
 	GetID() uint
 }
 
+// +testify:mock:testOnly=true
+
 // UnifiedReleaser unifies different helm release interfaces into a single interface
 type UnifiedReleaser interface {
 	// integrated services style
@@ -628,6 +646,110 @@ func (s service) CheckRelease(ctx context.Context, organizationID uint, clusterI
 	return release.ReleaseInfo.Status, nil
 }
 
+func (s service) ListReleaseHistory(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) ([]Release, error) {
+	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to set up helm repository environment")
+	}
+
+	kubeKonfig, err := s.clusterService.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to get cluster configuration")
+	}
+
+	releases, err := s.releaser.History(ctx, helmEnv, kubeKonfig, releaseName, options)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to retrieve release history", "releaseName", releaseName)
+	}
+
+	return releases, nil
+}
+
+func (s service) RollbackRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, revision int32, options Options) error {
+	if revision < 0 {
+		return NewValidationError("invalid revision", []string{"revision must not be negative"})
+	}
+
+	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to set up helm repository environment")
+	}
+
+	kubeKonfig, err := s.clusterService.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get cluster configuration")
+	}
+
+	if err := s.releaser.Rollback(ctx, helmEnv, kubeKonfig, releaseName, revision, options); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to roll back release", "releaseName", releaseName, "revision", revision)
+	}
+
+	return nil
+}
+
+func (s service) DiffRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, fromRevision int32, toRevision int32, options Options) (ReleaseDiff, error) {
+	if fromRevision < 0 || toRevision < 0 {
+		return ReleaseDiff{}, NewValidationError("invalid revision", []string{"revisions must not be negative"})
+	}
+
+	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIf(err, "failed to set up helm repository environment")
+	}
+
+	kubeKonfig, err := s.clusterService.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIf(err, "failed to get cluster configuration")
+	}
+
+	to, err := s.releaser.Revision(ctx, helmEnv, kubeKonfig, releaseName, toRevision, options)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIfWithDetails(err, "failed to get release revision", "releaseName", releaseName, "revision", toRevision)
+	}
+
+	if fromRevision == 0 {
+		fromRevision = to.Revision - 1
+	}
+
+	if fromRevision < 1 {
+		return ReleaseDiff{}, NewValidationError("invalid revision", []string{"release has no revision to compare with"})
+	}
+
+	from, err := s.releaser.Revision(ctx, helmEnv, kubeKonfig, releaseName, fromRevision, options)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIfWithDetails(err, "failed to get release revision", "releaseName", releaseName, "revision", fromRevision)
+	}
+
+	return NewReleaseDiff(releaseName, from, to)
+}
+
+func (s service) DiffReleaseUpgrade(ctx context.Context, organizationID uint, clusterID uint, releaseInput Release, options Options) (ReleaseDiff, error) {
+	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIf(err, "failed to set up helm repository environment")
+	}
+
+	kubeKonfig, err := s.clusterService.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIf(err, "failed to get cluster configuration")
+	}
+
+	deployed, err := s.releaser.Revision(ctx, helmEnv, kubeKonfig, releaseInput.ReleaseName, 0, options)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIfWithDetails(err, "failed to get deployed release revision", "releaseName", releaseInput.ReleaseName)
+	}
+
+	proposed, err := s.releaser.RenderUpgrade(ctx, helmEnv, kubeKonfig, releaseInput, options)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIfWithDetails(err, "failed to render release upgrade", "releaseName", releaseInput.ReleaseName)
+	}
+
+	// the rendered upgrade is not a revision of the release
+	proposed.Revision = 0
+
+	return NewReleaseDiff(releaseInput.ReleaseName, deployed, proposed)
+}
+
 func (s service) CheckReleases(ctx context.Context, organizationID uint, releases []Release) (map[string]bool, error) {
 	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
 	if err != nil {
@@ -7,9 +7,192 @@ package helm
 
 import (
 	"context"
+	pkgHelm "github.com/banzaicloud/pipeline/pkg/helm"
 	"github.com/stretchr/testify/mock"
 )
 
+// MockDriftService is an autogenerated mock for the DriftService type.
+type MockDriftService struct {
+	mock.Mock
+}
+
+// GetReleaseDrift provides a mock function.
+func (_m *MockDriftService) GetReleaseDrift(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (drift ReleaseDrift, err error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseName, options)
+
+	var r0 ReleaseDrift
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, Options) ReleaseDrift); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseName, options)
+	} else {
+		r0 = ret.Get(0).(ReleaseDrift)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string, Options) error); ok {
+		r1 = rf(ctx, organizationID, clusterID, releaseName, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReleaseDrifts provides a mock function.
+func (_m *MockDriftService) ListReleaseDrifts(ctx context.Context, organizationID uint, clusterID uint) (drifts []ReleaseDrift, err error) {
+	ret := _m.Called(ctx, organizationID, clusterID)
+
+	var r0 []ReleaseDrift
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []ReleaseDrift); ok {
+		r0 = rf(ctx, organizationID, clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ReleaseDrift)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, organizationID, clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetReleaseSelfHeal provides a mock function.
+func (_m *MockDriftService) SetReleaseSelfHeal(ctx context.Context, organizationID uint, clusterID uint, releaseName string, selfHeal bool, options Options) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseName, selfHeal, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, bool, Options) error); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseName, selfHeal, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDriftStore is an autogenerated mock for the DriftStore type.
+type MockDriftStore struct {
+	mock.Mock
+}
+
+// Get provides a mock function.
+func (_m *MockDriftStore) Get(ctx context.Context, clusterID uint, releaseName string, namespace string) (_result_0 ReleaseDrift, _result_1 error) {
+	ret := _m.Called(ctx, clusterID, releaseName, namespace)
+
+	var r0 ReleaseDrift
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) ReleaseDrift); ok {
+		r0 = rf(ctx, clusterID, releaseName, namespace)
+	} else {
+		r0 = ret.Get(0).(ReleaseDrift)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, string) error); ok {
+		r1 = rf(ctx, clusterID, releaseName, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function.
+func (_m *MockDriftStore) List(ctx context.Context, clusterID uint) (_result_0 []ReleaseDrift, _result_1 error) {
+	ret := _m.Called(ctx, clusterID)
+
+	var r0 []ReleaseDrift
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []ReleaseDrift); ok {
+		r0 = rf(ctx, clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ReleaseDrift)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Prune provides a mock function.
+func (_m *MockDriftStore) Prune(ctx context.Context, clusterID uint, releases []Release) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, releases)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []Release) error); ok {
+		r0 = rf(ctx, clusterID, releases)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function.
+func (_m *MockDriftStore) Save(ctx context.Context, drift ReleaseDrift) (_result_0 error) {
+	ret := _m.Called(ctx, drift)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ReleaseDrift) error); ok {
+		r0 = rf(ctx, drift)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSelfHeal provides a mock function.
+func (_m *MockDriftStore) SetSelfHeal(ctx context.Context, clusterID uint, releaseName string, namespace string, selfHeal bool) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, releaseName, namespace, selfHeal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string, bool) error); ok {
+		r0 = rf(ctx, clusterID, releaseName, namespace, selfHeal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManifestComparer is an autogenerated mock for the ManifestComparer type.
+type MockManifestComparer struct {
+	mock.Mock
+}
+
+// CompareManifest provides a mock function.
+func (_m *MockManifestComparer) CompareManifest(ctx context.Context, clusterID uint, namespace string, manifest string) (_result_0 []DriftedResource, _result_1 error) {
+	ret := _m.Called(ctx, clusterID, namespace, manifest)
+
+	var r0 []DriftedResource
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) []DriftedResource); ok {
+		r0 = rf(ctx, clusterID, namespace, manifest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]DriftedResource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, string) error); ok {
+		r1 = rf(ctx, clusterID, namespace, manifest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MockOrgService is an autogenerated mock for the OrgService type.
 type MockOrgService struct {
 	mock.Mock
@@ -174,6 +357,48 @@ func (_m *MockService) DeleteRepository(ctx context.Context, organizationID uint
 	return r0
 }
 
+// DiffRelease provides a mock function.
+func (_m *MockService) DiffRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, fromRevision int32, toRevision int32, options Options) (diff ReleaseDiff, err error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseName, fromRevision, toRevision, options)
+
+	var r0 ReleaseDiff
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, int32, int32, Options) ReleaseDiff); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseName, fromRevision, toRevision, options)
+	} else {
+		r0 = ret.Get(0).(ReleaseDiff)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string, int32, int32, Options) error); ok {
+		r1 = rf(ctx, organizationID, clusterID, releaseName, fromRevision, toRevision, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffReleaseUpgrade provides a mock function.
+func (_m *MockService) DiffReleaseUpgrade(ctx context.Context, organizationID uint, clusterID uint, releaseInput Release, options Options) (diff ReleaseDiff, err error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseInput, options)
+
+	var r0 ReleaseDiff
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, Release, Options) ReleaseDiff); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseInput, options)
+	} else {
+		r0 = ret.Get(0).(ReleaseDiff)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, Release, Options) error); ok {
+		r1 = rf(ctx, organizationID, clusterID, releaseInput, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChart provides a mock function.
 func (_m *MockService) GetChart(ctx context.Context, organizationID uint, chartFilter ChartFilter, options Options) (chartDetails ChartDetails, err error) {
 	ret := _m.Called(ctx, organizationID, chartFilter, options)
@@ -308,6 +533,29 @@ func (_m *MockService) ListClusterCharts(ctx context.Context, organizationID uin
 	return r0, r1
 }
 
+// ListReleaseHistory provides a mock function.
+func (_m *MockService) ListReleaseHistory(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (releases []Release, err error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseName, options)
+
+	var r0 []Release
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, Options) []Release); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseName, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Release)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string, Options) error); ok {
+		r1 = rf(ctx, organizationID, clusterID, releaseName, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListReleases provides a mock function.
 func (_m *MockService) ListReleases(ctx context.Context, organizationID uint, clusterID uint, filters ReleaseFilter, options Options) (_result_0 []Release, _result_1 error) {
 	ret := _m.Called(ctx, organizationID, clusterID, filters, options)
@@ -368,6 +616,20 @@ func (_m *MockService) ModifyRepository(ctx context.Context, organizationID uint
 	return r0
 }
 
+// RollbackRelease provides a mock function.
+func (_m *MockService) RollbackRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, revision int32, options Options) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseName, revision, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, int32, Options) error); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseName, revision, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRepository provides a mock function.
 func (_m *MockService) UpdateRepository(ctx context.Context, organizationID uint, repository Repository) (_result_0 error) {
 	ret := _m.Called(ctx, organizationID, repository)
@@ -403,6 +665,153 @@ func (_m *MockService) UpgradeRelease(ctx context.Context, organizationID uint,
 	return r0, r1
 }
 
+// MockUnifiedReleaser is an autogenerated mock for the UnifiedReleaser type.
+type MockUnifiedReleaser struct {
+	mock.Mock
+}
+
+// ApplyDeployment provides a mock function.
+func (_m *MockUnifiedReleaser) ApplyDeployment(ctx context.Context, clusterID uint, namespace string, chartName string, releaseName string, values []byte, upgradeschartVersion string) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, namespace, chartName, releaseName, values, upgradeschartVersion)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string, string, []byte, string) error); ok {
+		r0 = rf(ctx, clusterID, namespace, chartName, releaseName, values, upgradeschartVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApplyDeploymentReuseValues provides a mock function.
+func (_m *MockUnifiedReleaser) ApplyDeploymentReuseValues(ctx context.Context, clusterID uint, namespace string, chartName string, releaseName string, values []byte, chartVersion string, reuseValues bool) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, namespace, chartName, releaseName, values, chartVersion, reuseValues)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string, string, []byte, string, bool) error); ok {
+		r0 = rf(ctx, clusterID, namespace, chartName, releaseName, values, chartVersion, reuseValues)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApplyDeploymentSkipCRDs provides a mock function.
+func (_m *MockUnifiedReleaser) ApplyDeploymentSkipCRDs(ctx context.Context, clusterID uint, namespace string, chartName string, releaseName string, values []byte, upgradeschartVersion string) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, namespace, chartName, releaseName, values, upgradeschartVersion)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string, string, []byte, string) error); ok {
+		r0 = rf(ctx, clusterID, namespace, chartName, releaseName, values, upgradeschartVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function.
+func (_m *MockUnifiedReleaser) Delete(c ClusterDataProvider, releaseName string, namespace string) (_result_0 error) {
+	ret := _m.Called(c, releaseName, namespace)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ClusterDataProvider, string, string) error); ok {
+		r0 = rf(c, releaseName, namespace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDeployment provides a mock function.
+func (_m *MockUnifiedReleaser) DeleteDeployment(ctx context.Context, clusterID uint, releaseName string, namespace string) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, releaseName, namespace)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) error); ok {
+		r0 = rf(ctx, clusterID, releaseName, namespace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeployment provides a mock function.
+func (_m *MockUnifiedReleaser) GetDeployment(ctx context.Context, clusterID uint, releaseName string, namespace string) (_result_0 *pkgHelm.GetDeploymentResponse, _result_1 error) {
+	ret := _m.Called(ctx, clusterID, releaseName, namespace)
+
+	var r0 *pkgHelm.GetDeploymentResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) *pkgHelm.GetDeploymentResponse); ok {
+		r0 = rf(ctx, clusterID, releaseName, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pkgHelm.GetDeploymentResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, string) error); ok {
+		r1 = rf(ctx, clusterID, releaseName, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRelease provides a mock function.
+func (_m *MockUnifiedReleaser) GetRelease(c ClusterDataProvider, releaseName string, namespace string) (_result_0 Release, _result_1 error) {
+	ret := _m.Called(c, releaseName, namespace)
+
+	var r0 Release
+	if rf, ok := ret.Get(0).(func(ClusterDataProvider, string, string) Release); ok {
+		r0 = rf(c, releaseName, namespace)
+	} else {
+		r0 = ret.Get(0).(Release)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ClusterDataProvider, string, string) error); ok {
+		r1 = rf(c, releaseName, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InstallDeployment provides a mock function.
+func (_m *MockUnifiedReleaser) InstallDeployment(ctx context.Context, clusterID uint, namespace string, chartName string, releaseName string, values []byte, chartVersion string, wait bool) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, namespace, chartName, releaseName, values, chartVersion, wait)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string, string, []byte, string, bool) error); ok {
+		r0 = rf(ctx, clusterID, namespace, chartName, releaseName, values, chartVersion, wait)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InstallOrUpgrade provides a mock function.
+func (_m *MockUnifiedReleaser) InstallOrUpgrade(orgID uint, c ClusterDataProvider, release Release, opts Options) (_result_0 error) {
+	ret := _m.Called(orgID, c, release, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, ClusterDataProvider, Release, Options) error); ok {
+		r0 = rf(orgID, c, release, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MockEnvService is an autogenerated mock for the EnvService type.
 type MockEnvService struct {
 	mock.Mock
@@ -0,0 +1,198 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+// controllerManager is the field manager used by built-in controllers (eg. when scaling replicas).
+const controllerManager = "kube-controller-manager"
+
+type releaseLister interface {
+	ListReleases(ctx context.Context, organizationID uint, clusterID uint, filters helm.ReleaseFilter, options helm.Options) ([]helm.Release, error)
+}
+
+type kubernetesClientFactory interface {
+	FromClusterID(ctx context.Context, clusterID uint) (kubernetes.Interface, error)
+}
+
+// ActivityTracker determines the last user activity on a cluster
+// from Helm deployments and changes of workload resources.
+type ActivityTracker struct {
+	clusters          cluster.Store
+	releases          releaseLister
+	clientFactory     kubernetesClientFactory
+	ignoredNamespaces map[string]bool
+}
+
+// NewActivityTracker returns a new ActivityTracker.
+// Changes in the ignored namespaces (eg. the Pipeline system namespace) are not considered user activity.
+func NewActivityTracker(
+	clusters cluster.Store,
+	releases releaseLister,
+	clientFactory kubernetesClientFactory,
+	ignoredNamespaces []string,
+) ActivityTracker {
+	ignored := map[string]bool{
+		metav1.NamespaceSystem: true,
+		metav1.NamespacePublic: true,
+		"kube-node-lease":      true,
+	}
+	for _, namespace := range ignoredNamespaces {
+		ignored[namespace] = true
+	}
+
+	return ActivityTracker{
+		clusters:          clusters,
+		releases:          releases,
+		clientFactory:     clientFactory,
+		ignoredNamespaces: ignored,
+	}
+}
+
+// LastActivity returns the time of the last user activity on a cluster.
+// A zero time is returned if no activity can be found.
+func (t ActivityTracker) LastActivity(ctx context.Context, clusterID uint) (time.Time, error) {
+	c, err := t.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return time.Time{}, errors.WrapIf(err, "failed to get cluster")
+	}
+
+	var last time.Time
+
+	releases, err := t.releases.ListReleases(ctx, c.OrganizationID, c.ID, helm.ReleaseFilter{}, helm.Options{})
+	if err != nil {
+		return time.Time{}, errors.WrapIf(err, "failed to list releases")
+	}
+
+	for _, release := range releases {
+		if t.ignoredNamespaces[release.Namespace] {
+			continue
+		}
+
+		last = latest(last, release.ReleaseInfo.LastDeployed)
+	}
+
+	client, err := t.clientFactory.FromClusterID(ctx, c.ID)
+	if err != nil {
+		return time.Time{}, errors.WrapIf(err, "failed to create Kubernetes client")
+	}
+
+	objects, err := listWorkloads(ctx, client)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, object := range objects {
+		if t.ignoredNamespaces[object.Namespace] || metav1.GetControllerOf(object) != nil {
+			continue
+		}
+
+		last = latest(last, lastChange(object))
+	}
+
+	return last, nil
+}
+
+func listWorkloads(ctx context.Context, client kubernetes.Interface) ([]*metav1.ObjectMeta, error) {
+	var objects []*metav1.ObjectMeta
+
+	deployments, err := client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list deployments")
+	}
+	for i := range deployments.Items {
+		objects = append(objects, &deployments.Items[i].ObjectMeta)
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list statefulsets")
+	}
+	for i := range statefulSets.Items {
+		objects = append(objects, &statefulSets.Items[i].ObjectMeta)
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list daemonsets")
+	}
+	for i := range daemonSets.Items {
+		objects = append(objects, &daemonSets.Items[i].ObjectMeta)
+	}
+
+	jobs, err := client.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list jobs")
+	}
+	for i := range jobs.Items {
+		objects = append(objects, &jobs.Items[i].ObjectMeta)
+	}
+
+	return objects, nil
+}
+
+// lastChange returns the time of the last change made to an object by a user.
+// Status updates and changes made by built-in controllers are not considered.
+func lastChange(object *metav1.ObjectMeta) time.Time {
+	last := object.CreationTimestamp.Time
+
+	for _, entry := range object.ManagedFields {
+		if entry.Time == nil || entry.Manager == controllerManager || isStatusOnly(entry) {
+			continue
+		}
+
+		last = latest(last, entry.Time.Time)
+	}
+
+	return last
+}
+
+func isStatusOnly(entry metav1.ManagedFieldsEntry) bool {
+	if entry.FieldsV1 == nil {
+		return false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+		return false
+	}
+
+	if len(fields) != 1 {
+		return false
+	}
+
+	_, ok := fields["f:status"]
+
+	return ok
+}
+
+func latest(a time.Time, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,112 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+type releaseListerStub []helm.Release
+
+func (s releaseListerStub) ListReleases(_ context.Context, _ uint, _ uint, _ helm.ReleaseFilter, _ helm.Options) ([]helm.Release, error) {
+	return s, nil
+}
+
+type clientFactoryStub struct {
+	client kubernetes.Interface
+}
+
+func (s clientFactoryStub) FromClusterID(_ context.Context, _ uint) (kubernetes.Interface, error) {
+	return s.client, nil
+}
+
+func TestActivityTracker_LastActivity(t *testing.T) {
+	base := time.Date(2021, 5, 7, 12, 0, 0, 0, time.UTC)
+	at := func(hours int) metav1.Time {
+		return metav1.NewTime(base.Add(time.Duration(hours) * time.Hour))
+	}
+	atPtr := func(hours int) *metav1.Time {
+		t := at(hours)
+
+		return &t
+	}
+
+	ctx := context.Background()
+
+	clusters := new(cluster.MockStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 2}, nil)
+
+	releases := releaseListerStub{
+		{Namespace: "default", ReleaseInfo: helm.ReleaseInfo{LastDeployed: base.Add(time.Hour)}},
+		{Namespace: "pipeline-system", ReleaseInfo: helm.ReleaseInfo{LastDeployed: base.Add(10 * time.Hour)}},
+	}
+
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "app",
+				Namespace:         "default",
+				CreationTimestamp: at(0),
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{Manager: "kubectl", Time: atPtr(2)},
+					{Manager: controllerManager, Time: atPtr(5)},
+					{Manager: "kubelet", Time: atPtr(6), FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:status":{}}`)}},
+				},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "system",
+				Namespace:         "kube-system",
+				CreationTimestamp: at(7),
+			},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "owned",
+				Namespace:         "default",
+				CreationTimestamp: at(8),
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "Operator", Name: "owner", Controller: boolPtr(true)},
+				},
+			},
+		},
+	)
+
+	tracker := NewActivityTracker(clusters, releases, clientFactoryStub{client: client}, []string{"pipeline-system"})
+
+	last, err := tracker.LastActivity(ctx, 1)
+	require.NoError(t, err)
+
+	assert.True(t, base.Add(2*time.Hour).Equal(last), "unexpected last activity: %s", last)
+
+	clusters.AssertExpectations(t)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -31,6 +31,9 @@ import (
 // adjust this value if appropriate
 const startToCloseDurationOffset = 24 * time.Hour
 
+// idleStartToCloseTimeout is the timeout of a single idle expiry workflow run (the workflow continues as new periodically).
+const idleStartToCloseTimeout = 7 * 24 * time.Hour
+
 // asyncExpiryService Expirer implementation that uses cadence setup for executing the expiration
 type asyncExpiryService struct {
 	cadenceClient client.Client
@@ -44,22 +47,29 @@ func NewAsyncExpiryService(cadenceClient client.Client, logger common.Logger) ex
 	}
 }
 
-func (a asyncExpiryService) Expire(ctx context.Context, clusterID uint, expiryDate string) error {
-	startToCloseTimeout, err := expiry.CalculateDuration(time.Now(), expiryDate)
-	if err != nil {
-		return err
+func (a asyncExpiryService) Expire(ctx context.Context, clusterID uint, spec expiry.ServiceSpec) error {
+	startToCloseTimeout := idleStartToCloseTimeout
+	if spec.IdleTTL == "" {
+		duration, err := expiry.CalculateDuration(time.Now(), spec.Date)
+		if err != nil {
+			return err
+		}
+
+		startToCloseTimeout = duration + startToCloseDurationOffset
 	}
 
 	options := client.StartWorkflowOptions{
 		ID:                           getWorkflowID(clusterID),
 		TaskList:                     "pipeline",
-		ExecutionStartToCloseTimeout: startToCloseTimeout + startToCloseDurationOffset,
+		ExecutionStartToCloseTimeout: startToCloseTimeout,
 		WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
 	}
 
 	workflowInput := workflow.ExpiryJobWorkflowInput{
-		ClusterID:  clusterID,
-		ExpiryDate: expiryDate,
+		ClusterID:     clusterID,
+		ExpiryDate:    spec.Date,
+		IdleTTL:       spec.IdleTTL,
+		Notifications: spec.Notifications,
 	}
 
 	// cancel the workflow if already set up (support the update flow)
@@ -0,0 +1,158 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/expiry"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+type clusterGetter interface {
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+}
+
+type secretGetter interface {
+	Get(organizationID uint, secretID string) (*secret.SecretItemResponse, error)
+}
+
+// ChannelNotifier sends cluster expiry notifications to Slack and PagerDuty.
+type ChannelNotifier struct {
+	clusters     clusterGetter
+	secrets      secretGetter
+	client       *http.Client
+	pagerDutyURL string
+}
+
+// NewChannelNotifier returns a new ChannelNotifier.
+func NewChannelNotifier(clusters clusterGetter, secrets secretGetter, client *http.Client) ChannelNotifier {
+	return ChannelNotifier{
+		clusters:     clusters,
+		secrets:      secrets,
+		client:       client,
+		pagerDutyURL: pagerDutyEventsURL,
+	}
+}
+
+// NotifyExpiry sends the notification to every enabled channel.
+func (n ChannelNotifier) NotifyExpiry(ctx context.Context, clusterID uint, expiresAt time.Time, notifications expiry.NotificationSpec) error {
+	if !notifications.Slack.Enabled && !notifications.PagerDuty.Enabled {
+		return nil
+	}
+
+	c, err := n.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get cluster")
+	}
+
+	message := fmt.Sprintf("Cluster %q expires at %s", c.Name, expiresAt.UTC().Format(time.RFC3339))
+
+	var errs []error
+
+	if notifications.Slack.Enabled {
+		errs = append(errs, errors.WrapIf(n.notifySlack(ctx, c, notifications.Slack, message), "failed to send Slack notification"))
+	}
+
+	if notifications.PagerDuty.Enabled {
+		errs = append(errs, errors.WrapIf(n.notifyPagerDuty(ctx, c, notifications.PagerDuty, expiresAt, message), "failed to send PagerDuty notification"))
+	}
+
+	return errors.Combine(errs...)
+}
+
+func (n ChannelNotifier) notifySlack(ctx context.Context, c cluster.Cluster, spec expiry.SlackSpec, message string) error {
+	values, err := n.getSecretValues(c.OrganizationID, spec.SecretID, secrettype.SlackSecretType)
+	if err != nil {
+		return err
+	}
+
+	return n.post(ctx, values[secrettype.SlackApiUrl], map[string]interface{}{
+		"channel": spec.Channel,
+		"text":    message,
+	})
+}
+
+func (n ChannelNotifier) notifyPagerDuty(ctx context.Context, c cluster.Cluster, spec expiry.PagerDutySpec, expiresAt time.Time, message string) error {
+	values, err := n.getSecretValues(c.OrganizationID, spec.SecretID, secrettype.PagerDutySecretType)
+	if err != nil {
+		return err
+	}
+
+	return n.post(ctx, n.pagerDutyURL, map[string]interface{}{
+		"routing_key":  values[secrettype.PagerDutyIntegrationKey],
+		"event_action": "trigger",
+		"dedup_key":    fmt.Sprintf("pipeline-cluster-expiry-%d", c.ID),
+		"payload": map[string]interface{}{
+			"summary":  message,
+			"source":   "pipeline",
+			"severity": "warning",
+			"custom_details": map[string]interface{}{
+				"clusterId":   c.ID,
+				"clusterName": c.Name,
+				"expiresAt":   expiresAt.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+}
+
+func (n ChannelNotifier) getSecretValues(organizationID uint, secretID string, secretType string) (map[string]string, error) {
+	s, err := n.secrets.Get(organizationID, secretID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get secret", "secretId", secretID)
+	}
+
+	if s.Type != secretType {
+		return nil, errors.NewWithDetails("unexpected secret type", "secretId", secretID, "secretType", s.Type, "expectedType", secretType)
+	}
+
+	return s.Values, nil
+}
+
+func (n ChannelNotifier) post(ctx context.Context, url string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return errors.WrapIf(err, "failed to marshal request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.WrapIf(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.WrapIf(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.NewWithDetails("unexpected response status", "statusCode", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/expiry"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+type secretGetterStub map[string]*secret.SecretItemResponse
+
+func (s secretGetterStub) Get(_ uint, secretID string) (*secret.SecretItemResponse, error) {
+	item, ok := s[secretID]
+	if !ok {
+		return nil, secret.ErrSecretNotExists
+	}
+
+	return item, nil
+}
+
+func TestChannelNotifier_NotifyExpiry(t *testing.T) {
+	requests := make(map[string]map[string]interface{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		requests[r.URL.Path] = body
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	clusters := new(cluster.MockStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 2, Name: "test"}, nil)
+
+	secrets := secretGetterStub{
+		"slack": {
+			ID:     "slack",
+			Type:   secrettype.SlackSecretType,
+			Values: map[string]string{secrettype.SlackApiUrl: server.URL + "/slack"},
+		},
+		"pagerduty": {
+			ID:     "pagerduty",
+			Type:   secrettype.PagerDutySecretType,
+			Values: map[string]string{secrettype.PagerDutyIntegrationKey: "key"},
+		},
+	}
+
+	notifier := NewChannelNotifier(clusters, secrets, server.Client())
+	notifier.pagerDutyURL = server.URL + "/pagerduty"
+
+	expiresAt := time.Date(2021, 5, 7, 12, 0, 0, 0, time.UTC)
+
+	err := notifier.NotifyExpiry(ctx, 1, expiresAt, expiry.NotificationSpec{
+		Slack:     expiry.SlackSpec{Enabled: true, SecretID: "slack", Channel: "#alerts"},
+		PagerDuty: expiry.PagerDutySpec{Enabled: true, SecretID: "pagerduty"},
+	})
+	require.NoError(t, err)
+
+	require.Contains(t, requests, "/slack")
+	assert.Equal(t, "#alerts", requests["/slack"]["channel"])
+	assert.Equal(t, `Cluster "test" expires at 2021-05-07T12:00:00Z`, requests["/slack"]["text"])
+
+	require.Contains(t, requests, "/pagerduty")
+	assert.Equal(t, "key", requests["/pagerduty"]["routing_key"])
+	assert.Equal(t, "trigger", requests["/pagerduty"]["event_action"])
+}
+
+func TestChannelNotifier_NotifyExpiry_MissingSecret(t *testing.T) {
+	ctx := context.Background()
+
+	clusters := new(cluster.MockStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 2, Name: "test"}, nil)
+
+	notifier := NewChannelNotifier(clusters, secretGetterStub{}, http.DefaultClient)
+
+	err := notifier.NotifyExpiry(ctx, 1, time.Now(), expiry.NotificationSpec{
+		Slack: expiry.SlackSpec{Enabled: true, SecretID: "slack", Channel: "#alerts"},
+	})
+	require.Error(t, err)
+}
+
+func TestChannelNotifier_NotifyExpiry_WrongSecretType(t *testing.T) {
+	ctx := context.Background()
+
+	clusters := new(cluster.MockStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 2, Name: "test"}, nil)
+
+	secrets := secretGetterStub{
+		"generic": {ID: "generic", Type: secrettype.GenericSecret, Values: map[string]string{secrettype.SlackApiUrl: "http://169.254.169.254"}},
+	}
+
+	notifier := NewChannelNotifier(clusters, secrets, http.DefaultClient)
+
+	err := notifier.NotifyExpiry(ctx, 1, time.Now(), expiry.NotificationSpec{
+		Slack: expiry.SlackSpec{Enabled: true, SecretID: "generic", Channel: "#alerts"},
+	})
+	require.Error(t, err)
+}
+
+func TestChannelNotifier_NotifyExpiry_NoChannels(t *testing.T) {
+	notifier := NewChannelNotifier(new(cluster.MockStore), secretGetterStub{}, http.DefaultClient)
+
+	err := notifier.NotifyExpiry(context.Background(), 1, time.Now(), expiry.NotificationSpec{Before: []string{"1h"}})
+	require.NoError(t, err)
+}
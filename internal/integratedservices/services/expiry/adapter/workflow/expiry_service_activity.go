@@ -16,11 +16,17 @@ package workflow
 
 import (
 	"context"
+	"time"
 
 	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/expiry"
 )
 
-const ExpireActivityName = "expire-cluster-activity"
+const (
+	ExpireActivityName          = "expire-cluster-activity"
+	NotifyActivityName          = "expiry-notify-activity"
+	GetLastActivityActivityName = "expiry-get-last-activity-activity"
+)
 
 type ExpiryActivityInput struct {
 	ClusterID uint
@@ -46,3 +52,41 @@ func (a ExpiryActivity) Execute(ctx context.Context, input ExpiryActivityInput)
 type clusterDeleter interface {
 	DeleteCluster(ctx context.Context, clusterID uint, options cluster.DeleteClusterOptions) error
 }
+
+type NotifyActivityInput struct {
+	ClusterID     uint
+	ExpiresAt     time.Time
+	Notifications expiry.NotificationSpec
+}
+
+type NotifyActivity struct {
+	notifier expiry.Notifier
+}
+
+func NewNotifyActivity(notifier expiry.Notifier) NotifyActivity {
+	return NotifyActivity{
+		notifier: notifier,
+	}
+}
+
+func (a NotifyActivity) Execute(ctx context.Context, input NotifyActivityInput) error {
+	return a.notifier.NotifyExpiry(ctx, input.ClusterID, input.ExpiresAt, input.Notifications)
+}
+
+type GetLastActivityActivityInput struct {
+	ClusterID uint
+}
+
+type GetLastActivityActivity struct {
+	activityTracker expiry.ActivityTracker
+}
+
+func NewGetLastActivityActivity(activityTracker expiry.ActivityTracker) GetLastActivityActivity {
+	return GetLastActivityActivity{
+		activityTracker: activityTracker,
+	}
+}
+
+func (a GetLastActivityActivity) Execute(ctx context.Context, input GetLastActivityActivityInput) (time.Time, error) {
+	return a.activityTracker.LastActivity(ctx, input.ClusterID)
+}
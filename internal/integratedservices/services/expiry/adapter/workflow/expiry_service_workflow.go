@@ -18,7 +18,9 @@ import (
 	"time"
 
 	"emperror.dev/errors"
+	"go.uber.org/cadence"
 	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
 
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/expiry"
 )
@@ -27,14 +29,56 @@ const (
 	ExpiryJobWorkflowName = "expiry-job"
 )
 
+const (
+	// idleCheckInterval is the time between two checks of an idle cluster.
+	idleCheckInterval = time.Hour
+
+	// idleRunLength is the time after an idle expiry workflow continues as new.
+	idleRunLength = 24 * time.Hour
+)
+
 // ExpiryJobWorkflowInput defines the fixed inputs of the expiry workflow
 type ExpiryJobWorkflowInput struct {
 	ClusterID  uint
 	ExpiryDate string
+
+	// IdleTTL expires the cluster after being idle for the given duration instead of a fixed date.
+	IdleTTL string
+
+	Notifications expiry.NotificationSpec
+
+	// IdleSince is the earliest time the idle period of the cluster is measured from.
+	IdleSince time.Time
+
+	// NotifiedExpiry is the idle expiry time the notifications in Notified were sent about.
+	NotifiedExpiry time.Time
+	Notified       []time.Duration
 }
 
-// ExpiryJobWorkflow triggers the cluster deletion at a given date
+// ExpiryJobWorkflow triggers the cluster deletion at a given date or after the cluster has been idle for a given duration
 func ExpiryJobWorkflow(ctx workflow.Context, input ExpiryJobWorkflowInput) error {
+	if input.IdleTTL != "" {
+		return idleExpiryJobWorkflow(ctx, input)
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, input.ExpiryDate)
+	if err != nil {
+		return errors.WrapIf(err, "failed to parse the expiry date")
+	}
+
+	for _, before := range input.Notifications.Durations() {
+		notifyAt := expiresAt.Add(-before)
+		if !notifyAt.After(workflow.Now(ctx)) {
+			continue
+		}
+
+		if err := workflow.Sleep(ctx, notifyAt.Sub(workflow.Now(ctx))); err != nil {
+			return errors.WrapIf(err, "sleep cancelled (possibly due to the workflow being cancelled")
+		}
+
+		notify(ctx, input.ClusterID, expiresAt, input.Notifications)
+	}
+
 	sleepDuration, err := expiry.CalculateDuration(workflow.Now(ctx), input.ExpiryDate)
 	if err != nil {
 		return errors.WrapIf(err, "failed to calculate the expiry duration")
@@ -44,8 +88,99 @@ func ExpiryJobWorkflow(ctx workflow.Context, input ExpiryJobWorkflowInput) error
 		return errors.WrapIf(err, "sleep cancelled (possibly due to the workflow being cancelled")
 	}
 
+	return expire(ctx, input.ClusterID)
+}
+
+// idleExpiryJobWorkflow periodically checks the last activity of the cluster and deletes it when it has been idle for too long
+func idleExpiryJobWorkflow(ctx workflow.Context, input ExpiryJobWorkflowInput) error {
+	idleTTL, err := time.ParseDuration(input.IdleTTL)
+	if err != nil {
+		return errors.WrapIf(err, "failed to parse the idle TTL")
+	}
+
+	runStartedAt := workflow.Now(ctx)
+	if input.IdleSince.IsZero() {
+		input.IdleSince = runStartedAt
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    15 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    5 * time.Minute,
+			ExpirationInterval: 30 * time.Minute,
+		},
+	})
+
+	for {
+		var lastActivity time.Time
+		err := workflow.ExecuteActivity(activityCtx, GetLastActivityActivityName, GetLastActivityActivityInput{
+			ClusterID: input.ClusterID,
+		}).Get(activityCtx, &lastActivity)
+		if err != nil {
+			// an unknown last activity must not expire the cluster: skip this check and try again later
+			workflow.GetLogger(ctx).Warn("failed to get the last activity of the cluster", zap.Uint("clusterId", input.ClusterID), zap.Error(err))
+
+			if workflow.Now(ctx).Sub(runStartedAt) >= idleRunLength {
+				return workflow.NewContinueAsNewError(ctx, ExpiryJobWorkflowName, input)
+			}
+
+			if err := workflow.Sleep(ctx, idleCheckInterval); err != nil {
+				return errors.WrapIf(err, "sleep cancelled (possibly due to the workflow being cancelled")
+			}
+
+			continue
+		}
+
+		if lastActivity.Before(input.IdleSince) {
+			lastActivity = input.IdleSince
+		}
+
+		expiresAt := lastActivity.Add(idleTTL)
+		if !expiresAt.Equal(input.NotifiedExpiry) {
+			input.NotifiedExpiry, input.Notified = expiresAt, nil
+		}
+
+		now := workflow.Now(ctx)
+		if !now.Before(expiresAt) {
+			return expire(ctx, input.ClusterID)
+		}
+
+		next := now.Add(idleCheckInterval)
+		if expiresAt.Before(next) {
+			next = expiresAt
+		}
+
+		for _, before := range input.Notifications.Durations() {
+			if isNotified(input.Notified, before) {
+				continue
+			}
+
+			notifyAt := expiresAt.Add(-before)
+			if !now.Before(notifyAt) {
+				notify(ctx, input.ClusterID, expiresAt, input.Notifications)
+				input.Notified = append(input.Notified, before)
+			} else if notifyAt.Before(next) {
+				next = notifyAt
+			}
+		}
+
+		if now.Sub(runStartedAt) >= idleRunLength {
+			return workflow.NewContinueAsNewError(ctx, ExpiryJobWorkflowName, input)
+		}
+
+		if err := workflow.Sleep(ctx, next.Sub(now)); err != nil {
+			return errors.WrapIf(err, "sleep cancelled (possibly due to the workflow being cancelled")
+		}
+	}
+}
+
+func expire(ctx workflow.Context, clusterID uint) error {
 	activityInput := ExpiryActivityInput{
-		ClusterID: input.ClusterID,
+		ClusterID: clusterID,
 	}
 
 	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
@@ -60,3 +195,32 @@ func ExpiryJobWorkflow(ctx workflow.Context, input ExpiryJobWorkflowInput) error
 
 	return nil
 }
+
+// notify sends the expiry notifications. Failing to send notifications does not prevent the expiry.
+func notify(ctx workflow.Context, clusterID uint, expiresAt time.Time, notifications expiry.NotificationSpec) {
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	activityInput := NotifyActivityInput{
+		ClusterID:     clusterID,
+		ExpiresAt:     expiresAt,
+		Notifications: notifications,
+	}
+
+	if err := workflow.ExecuteActivity(activityCtx, NotifyActivityName, activityInput).Get(activityCtx, nil); err != nil {
+		workflow.GetLogger(ctx).Warn("failed to send expiry notifications", zap.Uint("clusterId", clusterID), zap.Error(err))
+	}
+}
+
+func isNotified(notified []time.Duration, before time.Duration) bool {
+	for _, n := range notified {
+		if n == before {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,159 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/expiry"
+)
+
+type ExpiryJobWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestExpiryJobWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(ExpiryJobWorkflowTestSuite))
+}
+
+var testStartTime = time.Date(2021, 5, 7, 12, 0, 0, 0, time.UTC)
+
+func (s *ExpiryJobWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.SetStartTime(testStartTime)
+
+	s.env.RegisterWorkflowWithOptions(ExpiryJobWorkflow, workflow.RegisterOptions{Name: ExpiryJobWorkflowName})
+	s.env.RegisterActivityWithOptions(NewExpiryActivity(nil).Execute, activity.RegisterOptions{Name: ExpireActivityName})
+	s.env.RegisterActivityWithOptions(NewNotifyActivity(nil).Execute, activity.RegisterOptions{Name: NotifyActivityName})
+	s.env.RegisterActivityWithOptions(NewGetLastActivityActivity(nil).Execute, activity.RegisterOptions{Name: GetLastActivityActivityName})
+}
+
+func (s *ExpiryJobWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *ExpiryJobWorkflowTestSuite) Test_ExpiryDate_Notifications() {
+	expiresAt := testStartTime.Add(48 * time.Hour)
+	notifications := expiry.NotificationSpec{Before: []string{"1h", "24h"}}
+
+	var notifiedAt []time.Time
+	s.env.OnActivity(NotifyActivityName, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input NotifyActivityInput) error {
+			s.Equal(uint(1), input.ClusterID)
+			s.True(expiresAt.Equal(input.ExpiresAt))
+			notifiedAt = append(notifiedAt, s.env.Now())
+
+			return nil
+		}).Times(2)
+	s.env.OnActivity(ExpireActivityName, mock.Anything, ExpiryActivityInput{ClusterID: 1}).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(ExpiryJobWorkflowName, ExpiryJobWorkflowInput{
+		ClusterID:     1,
+		ExpiryDate:    expiresAt.Format(time.RFC3339),
+		Notifications: notifications,
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+
+	require.Len(s.T(), notifiedAt, 2)
+	s.True(expiresAt.Add(-24 * time.Hour).Equal(notifiedAt[0]))
+	s.True(expiresAt.Add(-time.Hour).Equal(notifiedAt[1]))
+}
+
+func (s *ExpiryJobWorkflowTestSuite) Test_ExpiryDate_NotificationFailureDoesNotPreventExpiry() {
+	s.env.OnActivity(NotifyActivityName, mock.Anything, mock.Anything).Return(context.DeadlineExceeded).Once()
+	s.env.OnActivity(ExpireActivityName, mock.Anything, ExpiryActivityInput{ClusterID: 1}).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(ExpiryJobWorkflowName, ExpiryJobWorkflowInput{
+		ClusterID:     1,
+		ExpiryDate:    testStartTime.Add(2 * time.Hour).Format(time.RFC3339),
+		Notifications: expiry.NotificationSpec{Before: []string{"1h"}},
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *ExpiryJobWorkflowTestSuite) Test_IdleTTL() {
+	// the cluster was last used an hour after the workflow started
+	lastActivity := testStartTime.Add(time.Hour)
+
+	s.env.OnActivity(GetLastActivityActivityName, mock.Anything, GetLastActivityActivityInput{ClusterID: 1}).
+		Return(func(_ context.Context, _ GetLastActivityActivityInput) (time.Time, error) {
+			if s.env.Now().Before(lastActivity) {
+				return time.Time{}, nil
+			}
+
+			return lastActivity, nil
+		})
+
+	var notifiedExpiry []time.Time
+	s.env.OnActivity(NotifyActivityName, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input NotifyActivityInput) error {
+			notifiedExpiry = append(notifiedExpiry, input.ExpiresAt)
+
+			return nil
+		})
+
+	var expiredAt time.Time
+	s.env.OnActivity(ExpireActivityName, mock.Anything, ExpiryActivityInput{ClusterID: 1}).
+		Return(func(_ context.Context, _ ExpiryActivityInput) error {
+			expiredAt = s.env.Now()
+
+			return nil
+		}).Once()
+
+	s.env.ExecuteWorkflow(ExpiryJobWorkflowName, ExpiryJobWorkflowInput{
+		ClusterID:     1,
+		IdleTTL:       "3h",
+		Notifications: expiry.NotificationSpec{Before: []string{"30m"}},
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+
+	s.True(lastActivity.Add(3 * time.Hour).Equal(expiredAt))
+	require.Len(s.T(), notifiedExpiry, 1)
+	s.True(lastActivity.Add(3 * time.Hour).Equal(notifiedExpiry[0]))
+}
+
+func (s *ExpiryJobWorkflowTestSuite) Test_IdleTTL_LastActivityFailure() {
+	s.env.OnActivity(GetLastActivityActivityName, mock.Anything, GetLastActivityActivityInput{ClusterID: 1}).
+		Return(time.Time{}, errors.New("prometheus unavailable"))
+
+	s.env.ExecuteWorkflow(ExpiryJobWorkflowName, ExpiryJobWorkflowInput{
+		ClusterID: 1,
+		IdleTTL:   "1h",
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.env.GetWorkflowError(), &continueAsNewErr)
+}
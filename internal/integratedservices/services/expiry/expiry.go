@@ -24,7 +24,7 @@ import (
 const ServiceName = "expiry"
 
 type Expirer interface {
-	Expire(ctx context.Context, clusterID uint, expiryDate string) error
+	Expire(ctx context.Context, clusterID uint, spec ServiceSpec) error
 }
 
 type ExpiryCanceller interface {
@@ -44,3 +44,28 @@ func CalculateDuration(now time.Time, tillDate string) (time.Duration, error) {
 
 	return expiryTime.Sub(now), nil
 }
+
+// Notifier sends notifications about the upcoming expiry of a cluster.
+type Notifier interface {
+	NotifyExpiry(ctx context.Context, clusterID uint, expiresAt time.Time, notifications NotificationSpec) error
+}
+
+// Notifiers combines multiple notifiers.
+type Notifiers []Notifier
+
+// NotifyExpiry sends the notification with every notifier.
+func (n Notifiers) NotifyExpiry(ctx context.Context, clusterID uint, expiresAt time.Time, notifications NotificationSpec) error {
+	var errs []error
+	for _, notifier := range n {
+		if err := notifier.NotifyExpiry(ctx, clusterID, expiresAt, notifications); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
+// ActivityTracker tells when a cluster was last changed by its users.
+type ActivityTracker interface {
+	LastActivity(ctx context.Context, clusterID uint) (time.Time, error)
+}
@@ -0,0 +1,89 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+type integratedServiceService interface {
+	Details(ctx context.Context, clusterID uint, serviceName string) (integratedservices.IntegratedService, error)
+	Update(ctx context.Context, clusterID uint, serviceName string, spec map[string]interface{}) error
+}
+
+// ExtensionService extends the expiry date of clusters.
+type ExtensionService struct {
+	integratedServices integratedServiceService
+	specBinderFunc     binderFunc
+}
+
+// NewExtensionService returns a new ExtensionService.
+func NewExtensionService(integratedServices integratedServiceService, binderFn binderFunc) ExtensionService {
+	return ExtensionService{
+		integratedServices: integratedServices,
+		specBinderFunc:     binderFn,
+	}
+}
+
+// Extend postpones the expiry of a cluster by the specified duration and returns the new expiry date.
+func (s ExtensionService) Extend(ctx context.Context, clusterID uint, duration time.Duration) (time.Time, error) {
+	if duration <= 0 {
+		return time.Time{}, errors.WithStack(integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: ServiceName,
+			Problem:               "the extension must be positive",
+		})
+	}
+
+	service, err := s.integratedServices.Details(ctx, clusterID, ServiceName)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var spec ServiceSpec
+	if err := s.specBinderFunc(service.Spec, &spec); err != nil {
+		return time.Time{}, errors.WrapIf(err, "failed to bind the expiry service specification")
+	}
+
+	if spec.Date == "" {
+		return time.Time{}, errors.WithStack(integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: ServiceName,
+			Problem:               "only date based expiry can be extended",
+		})
+	}
+
+	expiryDate, err := time.Parse(time.RFC3339, spec.Date)
+	if err != nil {
+		return time.Time{}, errors.WrapIf(err, "failed to parse the expiry date")
+	}
+
+	expiryDate = expiryDate.Add(duration)
+
+	updatedSpec := make(map[string]interface{}, len(service.Spec))
+	for key, value := range service.Spec {
+		updatedSpec[key] = value
+	}
+	updatedSpec["date"] = expiryDate.Format(time.RFC3339)
+
+	if err := s.integratedServices.Update(ctx, clusterID, ServiceName, updatedSpec); err != nil {
+		return time.Time{}, errors.WrapIf(err, "failed to update the expiry date")
+	}
+
+	return expiryDate, nil
+}
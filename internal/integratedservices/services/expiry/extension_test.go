@@ -0,0 +1,90 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+)
+
+func TestExtensionService_Extend(t *testing.T) {
+	ctx := context.Background()
+	date := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	integratedServices := new(integratedservices.MockService)
+	integratedServices.On("Details", ctx, uint(1), ServiceName).Return(integratedservices.IntegratedService{
+		Name: ServiceName,
+		Spec: integratedservices.IntegratedServiceSpec{
+			"date": date.Format(time.RFC3339),
+			"notifications": map[string]interface{}{
+				"before": []string{"1h"},
+			},
+		},
+	}, nil)
+	integratedServices.On("Update", ctx, uint(1), ServiceName, map[string]interface{}{
+		"date": date.Add(24 * time.Hour).Format(time.RFC3339),
+		"notifications": map[string]interface{}{
+			"before": []string{"1h"},
+		},
+	}).Return(nil)
+
+	service := NewExtensionService(integratedServices, services.BindIntegratedServiceSpec)
+
+	extendedDate, err := service.Extend(ctx, 1, 24*time.Hour)
+	require.NoError(t, err)
+
+	assert.True(t, date.Add(24*time.Hour).Equal(extendedDate))
+	integratedServices.AssertExpectations(t)
+}
+
+func TestExtensionService_Extend_IdleTTL(t *testing.T) {
+	ctx := context.Background()
+
+	integratedServices := new(integratedservices.MockService)
+	integratedServices.On("Details", ctx, uint(1), ServiceName).Return(integratedservices.IntegratedService{
+		Name: ServiceName,
+		Spec: integratedservices.IntegratedServiceSpec{
+			"idleTTL": "72h",
+		},
+	}, nil)
+
+	service := NewExtensionService(integratedServices, services.BindIntegratedServiceSpec)
+
+	_, err := service.Extend(ctx, 1, time.Hour)
+	require.Error(t, err)
+
+	var invalidSpecErr integratedservices.InvalidIntegratedServiceSpecError
+	assert.True(t, errors.As(err, &invalidSpecErr))
+	integratedServices.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExtensionService_Extend_InvalidDuration(t *testing.T) {
+	service := NewExtensionService(new(integratedservices.MockService), services.BindIntegratedServiceSpec)
+
+	_, err := service.Extend(context.Background(), 1, 0)
+	require.Error(t, err)
+
+	var invalidSpecErr integratedservices.InvalidIntegratedServiceSpecError
+	assert.True(t, errors.As(err, &invalidSpecErr))
+}
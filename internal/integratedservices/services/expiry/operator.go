@@ -47,7 +47,7 @@ func (e expiryServiceOperator) Apply(ctx context.Context, clusterID uint, spec i
 		return errors.WrapIf(err, "failed to bind the expiry service specification")
 	}
 
-	if err := e.expiryService.Expire(ctx, clusterID, expirySpec.Date); err != nil {
+	if err := e.expiryService.Expire(ctx, clusterID, expirySpec); err != nil {
 		return errors.WrapIf(err, "failed to expire the resource")
 	}
 
@@ -15,6 +15,7 @@
 package expiry
 
 import (
+	"sort"
 	"time"
 
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
@@ -23,25 +24,113 @@ import (
 type binderFunc = func(inputSpec integratedservices.IntegratedServiceSpec, boundSpec interface{}) error
 
 type ServiceSpec struct {
-	Date string `json:"date" mapstructure:"date"`
+	// Date is the RFC3339 date the cluster expires at.
+	Date string `json:"date,omitempty" mapstructure:"date"`
+
+	// IdleTTL expires the cluster when no Helm releases or user workloads have changed for the given duration (eg. "72h").
+	IdleTTL string `json:"idleTTL,omitempty" mapstructure:"idleTTL"`
+
+	Notifications NotificationSpec `json:"notifications,omitempty" mapstructure:"notifications"`
+}
+
+// NotificationSpec describes the notifications sent before a cluster expires.
+type NotificationSpec struct {
+	// Before lists how long before the expiry notifications are sent (eg. ["24h", "1h"]).
+	Before []string `json:"before,omitempty" mapstructure:"before"`
+
+	Slack     SlackSpec     `json:"slack,omitempty" mapstructure:"slack"`
+	PagerDuty PagerDutySpec `json:"pagerDuty,omitempty" mapstructure:"pagerDuty"`
+}
+
+// SlackSpec describes the Slack channel expiry notifications are sent to.
+type SlackSpec struct {
+	Enabled  bool   `json:"enabled" mapstructure:"enabled"`
+	SecretID string `json:"secretId" mapstructure:"secretId"`
+	Channel  string `json:"channel" mapstructure:"channel"`
+}
+
+// PagerDutySpec describes the PagerDuty service expiry notifications are sent to.
+type PagerDutySpec struct {
+	Enabled  bool   `json:"enabled" mapstructure:"enabled"`
+	SecretID string `json:"secretId" mapstructure:"secretId"`
 }
 
-// https://www.ietf.org/rfc/rfc3339.txt
 func (s ServiceSpec) Validate() error {
-	t, err := time.Parse(time.RFC3339, s.Date)
-	if err != nil {
+	if (s.Date == "") == (s.IdleTTL == "") {
 		return integratedservices.InvalidIntegratedServiceSpecError{
 			IntegratedServiceName: ServiceName,
-			Problem:               "date must be in RFC3339 format",
+			Problem:               "exactly one of date and idleTTL must be specified",
+		}
+	}
+
+	if s.Date != "" {
+		t, err := time.Parse(time.RFC3339, s.Date)
+		if err != nil {
+			return integratedservices.InvalidIntegratedServiceSpecError{
+				IntegratedServiceName: ServiceName,
+				Problem:               "date must be in RFC3339 format",
+			}
+		}
+
+		if !t.After(time.Now()) {
+			return integratedservices.InvalidIntegratedServiceSpecError{
+				IntegratedServiceName: ServiceName,
+				Problem:               "the provided date must be in the future",
+			}
+		}
+	}
+
+	if s.IdleTTL != "" {
+		idleTTL, err := time.ParseDuration(s.IdleTTL)
+		if err != nil || idleTTL <= 0 {
+			return integratedservices.InvalidIntegratedServiceSpecError{
+				IntegratedServiceName: ServiceName,
+				Problem:               "idleTTL must be a positive duration",
+			}
 		}
 	}
 
-	if !t.After(time.Now()) {
+	return s.Notifications.Validate()
+}
+
+func (s NotificationSpec) Validate() error {
+	for _, before := range s.Before {
+		d, err := time.ParseDuration(before)
+		if err != nil || d <= 0 {
+			return integratedservices.InvalidIntegratedServiceSpecError{
+				IntegratedServiceName: ServiceName,
+				Problem:               "notification times must be positive durations",
+			}
+		}
+	}
+
+	if s.Slack.Enabled && (s.Slack.SecretID == "" || s.Slack.Channel == "") {
+		return integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: ServiceName,
+			Problem:               "secretId and channel are required for Slack notifications",
+		}
+	}
+
+	if s.PagerDuty.Enabled && s.PagerDuty.SecretID == "" {
 		return integratedservices.InvalidIntegratedServiceSpecError{
 			IntegratedServiceName: ServiceName,
-			Problem:               "the provided date must be in the future",
+			Problem:               "secretId is required for PagerDuty notifications",
 		}
 	}
 
 	return nil
 }
+
+// Durations returns how long before the expiry notifications are sent in descending order.
+func (s NotificationSpec) Durations() []time.Duration {
+	durations := make([]time.Duration, 0, len(s.Before))
+	for _, before := range s.Before {
+		if d, err := time.ParseDuration(before); err == nil && d > 0 {
+			durations = append(durations, d)
+		}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] > durations[j] })
+
+	return durations
+}
@@ -21,7 +21,9 @@ import (
 
 func TestServiceSpec_Validate(t *testing.T) {
 	type fields struct {
-		Date string
+		Date          string
+		IdleTTL       string
+		Notifications NotificationSpec
 	}
 	tests := []struct {
 		name    string
@@ -49,12 +51,92 @@ func TestServiceSpec_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "either expiry date or idle TTL is required",
+			fields:  fields{},
+			wantErr: true,
+		},
+		{
+			name: "expiry date and idle TTL are mutually exclusive",
+			fields: fields{
+				Date:    time.Now().Add(60 * time.Minute).Format(time.RFC3339),
+				IdleTTL: "72h",
+			},
+			wantErr: true,
+		},
+		{
+			name: "idle TTL must be a duration",
+			fields: fields{
+				IdleTTL: "3 days",
+			},
+			wantErr: true,
+		},
+		{
+			name: "idle TTL must be positive",
+			fields: fields{
+				IdleTTL: "-1h",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid idle TTL",
+			fields: fields{
+				IdleTTL: "72h",
+			},
+			wantErr: false,
+		},
+		{
+			name: "notification times must be durations",
+			fields: fields{
+				IdleTTL: "72h",
+				Notifications: NotificationSpec{
+					Before: []string{"1 day"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "slack notifications require a channel",
+			fields: fields{
+				IdleTTL: "72h",
+				Notifications: NotificationSpec{
+					Before: []string{"24h"},
+					Slack:  SlackSpec{Enabled: true, SecretID: "secret"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pagerduty notifications require a secret",
+			fields: fields{
+				IdleTTL: "72h",
+				Notifications: NotificationSpec{
+					Before:    []string{"24h"},
+					PagerDuty: PagerDutySpec{Enabled: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid notifications",
+			fields: fields{
+				Date: time.Now().Add(60 * time.Minute).Format(time.RFC3339),
+				Notifications: NotificationSpec{
+					Before:    []string{"1h", "24h"},
+					Slack:     SlackSpec{Enabled: true, SecretID: "secret", Channel: "#alerts"},
+					PagerDuty: PagerDutySpec{Enabled: true, SecretID: "secret"},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			s := ServiceSpec{
-				Date: tt.fields.Date,
+				Date:          tt.fields.Date,
+				IdleTTL:       tt.fields.IdleTTL,
+				Notifications: tt.fields.Notifications,
 			}
 			if err := s.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -62,3 +144,20 @@ func TestServiceSpec_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationSpec_Durations(t *testing.T) {
+	spec := NotificationSpec{Before: []string{"1h", "24h", "30m"}}
+
+	durations := spec.Durations()
+
+	expected := []time.Duration{24 * time.Hour, time.Hour, 30 * time.Minute}
+	if len(durations) != len(expected) {
+		t.Fatalf("Durations() = %v, want %v", durations, expected)
+	}
+
+	for i := range expected {
+		if durations[i] != expected[i] {
+			t.Errorf("Durations() = %v, want %v", durations, expected)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"sort"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/ekscluster/nodepools"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+type clusterGetter interface {
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+}
+
+type nodePoolLister interface {
+	ListNodePools(ctx context.Context, organizationID uint, clusterID uint, clusterName string) (map[string]eks.ExistingNodePool, error)
+}
+
+type awsSessionFactory interface {
+	New(organizationID uint, secretID string, region string) (*session.Session, error)
+}
+
+type eksNodePoolScaler struct {
+	clusters       clusterGetter
+	nodePools      nodePoolLister
+	sessionFactory awsSessionFactory
+}
+
+// NewEKSNodePoolScaler returns a node pool scaler changing the auto scaling groups of EKS node pools directly.
+//
+// The maximum size of the auto scaling groups is changed as well,
+// so that the cluster autoscaler cannot scale up a hibernated node pool.
+func NewEKSNodePoolScaler(clusters clusterGetter, nodePools nodePoolLister, sessionFactory awsSessionFactory) schedule.NodePoolScaler {
+	return eksNodePoolScaler{
+		clusters:       clusters,
+		nodePools:      nodePools,
+		sessionFactory: sessionFactory,
+	}
+}
+
+func (s eksNodePoolScaler) GetNodePoolSizes(ctx context.Context, clusterID uint) ([]schedule.NodePoolSize, error) {
+	c, sess, err := s.getCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingNodePools, err := s.nodePools.ListNodePools(ctx, c.OrganizationID, c.ID, c.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePoolNames := make([]string, 0, len(existingNodePools))
+	for name := range existingNodePools {
+		nodePoolNames = append(nodePoolNames, name)
+	}
+
+	sort.Strings(nodePoolNames)
+
+	autoscalingClient := autoscaling.New(sess)
+	cloudformationClient := cloudformation.New(sess)
+
+	sizes := make([]schedule.NodePoolSize, 0, len(nodePoolNames))
+	for _, name := range nodePoolNames {
+		asgName, err := getAutoScalingGroupName(cloudformationClient, c.Name, name)
+		if err != nil {
+			return nil, err
+		}
+
+		output, err := autoscalingClient.DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []*string{aws.String(asgName)},
+		})
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to describe auto scaling group", "nodePool", name)
+		}
+
+		if len(output.AutoScalingGroups) == 0 {
+			return nil, errors.NewWithDetails("auto scaling group not found", "nodePool", name)
+		}
+
+		asg := output.AutoScalingGroups[0]
+
+		sizes = append(sizes, schedule.NodePoolSize{
+			Name:        name,
+			MinSize:     int(aws.Int64Value(asg.MinSize)),
+			MaxSize:     int(aws.Int64Value(asg.MaxSize)),
+			DesiredSize: int(aws.Int64Value(asg.DesiredCapacity)),
+		})
+	}
+
+	return sizes, nil
+}
+
+func (s eksNodePoolScaler) ScaleNodePools(ctx context.Context, clusterID uint, nodePools []schedule.NodePoolSize) error {
+	c, sess, err := s.getCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	autoscalingClient := autoscaling.New(sess)
+	cloudformationClient := cloudformation.New(sess)
+
+	var errs []error
+	for _, nodePool := range nodePools {
+		asgName, err := getAutoScalingGroupName(cloudformationClient, c.Name, nodePool.Name)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		_, err = autoscalingClient.UpdateAutoScalingGroupWithContext(ctx, &autoscaling.UpdateAutoScalingGroupInput{
+			AutoScalingGroupName: aws.String(asgName),
+			MinSize:              aws.Int64(int64(nodePool.MinSize)),
+			MaxSize:              aws.Int64(int64(nodePool.MaxSize)),
+			DesiredCapacity:      aws.Int64(int64(nodePool.DesiredSize)),
+		})
+		if err != nil {
+			errs = append(errs, errors.WrapIfWithDetails(err, "failed to update auto scaling group", "nodePool", nodePool.Name))
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
+func (s eksNodePoolScaler) getCluster(ctx context.Context, clusterID uint) (cluster.Cluster, *session.Session, error) {
+	c, err := s.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return c, nil, err
+	}
+
+	if c.Distribution != pkgCluster.EKS {
+		return c, nil, errors.WithStack(cluster.NotSupportedDistributionError{
+			ID:           c.ID,
+			Cloud:        c.Cloud,
+			Distribution: c.Distribution,
+
+			Message: "the schedule integrated service is not supported for this distribution",
+		})
+	}
+
+	sess, err := s.sessionFactory.New(c.OrganizationID, c.SecretID.ResourceID, c.Location)
+	if err != nil {
+		return c, nil, errors.WrapIf(err, "failed to create AWS session")
+	}
+
+	return c, sess, nil
+}
+
+func getAutoScalingGroupName(cloudformationClient *cloudformation.CloudFormation, clusterName string, nodePoolName string) (string, error) {
+	output, err := cloudformationClient.DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		LogicalResourceId: aws.String("NodeGroup"),
+		StackName:         aws.String(nodepools.GenerateNodePoolStackName(clusterName, nodePoolName)),
+	})
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to find the auto scaling group of the node pool", "nodePool", nodePoolName)
+	}
+
+	return aws.StringValue(output.StackResourceDetail.PhysicalResourceId), nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule/adapter/workflow"
+)
+
+// executionTimeout is the timeout of a single sleep/wake cycle (the workflow continues as new after every cycle).
+const executionTimeout = 365 * 24 * time.Hour
+
+type asyncScheduleService struct {
+	cadenceClient client.Client
+	logger        common.Logger
+}
+
+func NewAsyncScheduleService(cadenceClient client.Client, logger common.Logger) schedule.ScheduleService {
+	return asyncScheduleService{
+		cadenceClient: cadenceClient,
+		logger:        logger,
+	}
+}
+
+func (a asyncScheduleService) Schedule(ctx context.Context, clusterID uint, spec schedule.ServiceSpec) error {
+	options := client.StartWorkflowOptions{
+		ID:                           getWorkflowID(clusterID),
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: executionTimeout,
+		WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+	}
+
+	workflowInput := workflow.ScheduleJobWorkflowInput{
+		ClusterID: clusterID,
+		Spec:      spec,
+	}
+
+	// the running workflow keeps the state of a hibernated cluster, so updates are signaled instead of restarting it
+	_, err := a.cadenceClient.SignalWithStartWorkflow(
+		ctx,
+		options.ID,
+		workflow.UpdateScheduleSignalName,
+		spec,
+		options,
+		workflow.ScheduleJobWorkflowName,
+		workflowInput,
+	)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to start the schedule workflow", "workflowId", options.ID)
+	}
+
+	a.logger.Info("schedule workflow successfully started", map[string]interface{}{"workflowID": options.ID})
+
+	return nil
+}
+
+func (a asyncScheduleService) CancelSchedule(ctx context.Context, clusterID uint) error {
+	// cancellation (as opposed to termination) lets the workflow wake up a hibernated cluster
+	if err := a.cadenceClient.CancelWorkflow(ctx, getWorkflowID(clusterID), ""); err != nil {
+		var entityNotExistsErr *shared.EntityNotExistsError
+		var alreadyCompletedErr *shared.WorkflowExecutionAlreadyCompletedError
+
+		// Note: a never existed or already closed workflow has nothing to cancel.
+		if !errors.As(err, &entityNotExistsErr) && !errors.As(err, &alreadyCompletedErr) {
+			return errors.WrapIfWithDetails(err, "failed to cancel the schedule workflow", "clusterID", clusterID)
+		}
+	}
+
+	a.logger.Info("schedule workflow successfully cancelled", map[string]interface{}{"workflowID": getWorkflowID(clusterID)})
+
+	return nil
+}
+
+func getWorkflowID(clusterID uint) string {
+	return fmt.Sprintf("%s-%d", workflow.ScheduleJobWorkflowName, clusterID)
+}
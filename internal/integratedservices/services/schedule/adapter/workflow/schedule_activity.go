@@ -0,0 +1,82 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule"
+)
+
+const (
+	GetNodePoolSizesActivityName = "schedule-get-node-pool-sizes-activity"
+	ScaleNodePoolsActivityName   = "schedule-scale-node-pools-activity"
+	NotifyActivityName           = "schedule-notify-activity"
+)
+
+type GetNodePoolSizesActivityInput struct {
+	ClusterID uint
+}
+
+type GetNodePoolSizesActivity struct {
+	scaler schedule.NodePoolScaler
+}
+
+func NewGetNodePoolSizesActivity(scaler schedule.NodePoolScaler) GetNodePoolSizesActivity {
+	return GetNodePoolSizesActivity{
+		scaler: scaler,
+	}
+}
+
+func (a GetNodePoolSizesActivity) Execute(ctx context.Context, input GetNodePoolSizesActivityInput) ([]schedule.NodePoolSize, error) {
+	return a.scaler.GetNodePoolSizes(ctx, input.ClusterID)
+}
+
+type ScaleNodePoolsActivityInput struct {
+	ClusterID uint
+	NodePools []schedule.NodePoolSize
+}
+
+type ScaleNodePoolsActivity struct {
+	scaler schedule.NodePoolScaler
+}
+
+func NewScaleNodePoolsActivity(scaler schedule.NodePoolScaler) ScaleNodePoolsActivity {
+	return ScaleNodePoolsActivity{
+		scaler: scaler,
+	}
+}
+
+func (a ScaleNodePoolsActivity) Execute(ctx context.Context, input ScaleNodePoolsActivityInput) error {
+	return a.scaler.ScaleNodePools(ctx, input.ClusterID, input.NodePools)
+}
+
+type NotifyActivityInput struct {
+	Notification schedule.Notification
+}
+
+type NotifyActivity struct {
+	notifier schedule.Notifier
+}
+
+func NewNotifyActivity(notifier schedule.Notifier) NotifyActivity {
+	return NotifyActivity{
+		notifier: notifier,
+	}
+}
+
+func (a NotifyActivity) Execute(ctx context.Context, input NotifyActivityInput) error {
+	return a.notifier.Notify(ctx, input.Notification)
+}
@@ -0,0 +1,257 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule"
+)
+
+const (
+	ScheduleJobWorkflowName = "schedule-job"
+
+	// UpdateScheduleSignalName is the signal carrying an updated schedule specification.
+	UpdateScheduleSignalName = "update-schedule"
+)
+
+type ScheduleJobWorkflowInput struct {
+	ClusterID uint
+	Spec      schedule.ServiceSpec
+}
+
+// ScheduleJobWorkflow puts a cluster to sleep and wakes it up according to its schedule.
+//
+// The workflow runs until it is cancelled and continues as new after every sleep/wake cycle.
+// The node pool sizes before the hibernation are kept in the workflow state,
+// the cluster is woken up when the workflow is cancelled during hibernation.
+func ScheduleJobWorkflow(ctx workflow.Context, input ScheduleJobWorkflowInput) error {
+	logger := workflow.GetLogger(ctx).With(zap.Uint("clusterId", input.ClusterID))
+
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    5 * time.Minute,
+			ExpirationInterval: time.Hour,
+		},
+	})
+
+	signals := workflow.GetSignalChannel(ctx, UpdateScheduleSignalName)
+	spec := input.Spec
+
+	var (
+		nodePools []schedule.NodePoolSize // node pool sizes before the hibernation, nil while the cluster is awake
+		sleepAt   time.Time
+		warned    bool
+	)
+
+	for {
+		now := workflow.Now(ctx)
+
+		wakeAt, err := spec.NextWake(now)
+		if err != nil {
+			return errors.WrapIf(err, "failed to calculate the next wake time")
+		}
+
+		var next time.Time
+		if nodePools != nil {
+			next = wakeAt
+		} else {
+			if sleepAt.IsZero() {
+				sleepAt, err = spec.NextSleep(now)
+				if err != nil {
+					return errors.WrapIf(err, "failed to calculate the next sleep time")
+				}
+
+				// the cluster is inside a sleep window already (eg. the schedule has just been set up)
+				if immediateSleepAt := now.Add(spec.Warning()); wakeAt.Before(sleepAt) && immediateSleepAt.Before(wakeAt) {
+					sleepAt = immediateSleepAt
+				}
+			}
+
+			next = sleepAt
+			if !warned && spec.Warning() > 0 {
+				next = sleepAt.Add(-spec.Warning())
+			}
+		}
+
+		updated, err := waitUntil(ctx, signals, next.Sub(now), &spec)
+		if err != nil {
+			return restoreOnExit(activityCtx, input.ClusterID, nodePools, err)
+		}
+
+		if updated {
+			logger.Info("schedule updated")
+
+			sleepAt, warned = time.Time{}, false
+
+			continue
+		}
+
+		switch {
+		case nodePools != nil:
+			if err := wakeUp(activityCtx, logger, input.ClusterID, nodePools); err != nil {
+				return err
+			}
+
+			nextSleep, _ := spec.NextSleep(workflow.Now(ctx))
+			notify(activityCtx, logger, schedule.Notification{
+				Type:      schedule.NotificationWokenUp,
+				ClusterID: input.ClusterID,
+				SleepAt:   nextSleep,
+			})
+
+			// drain pending updates, so they are not lost
+			for signals.ReceiveAsync(&spec) {
+			}
+
+			return workflow.NewContinueAsNewError(ctx, ScheduleJobWorkflowName, ScheduleJobWorkflowInput{
+				ClusterID: input.ClusterID,
+				Spec:      spec,
+			})
+
+		case !warned && spec.Warning() > 0:
+			warned = true
+
+			wakeAt, _ := spec.NextWake(sleepAt)
+			notify(activityCtx, logger, schedule.Notification{
+				Type:      schedule.NotificationHibernationDue,
+				ClusterID: input.ClusterID,
+				SleepAt:   sleepAt,
+				WakeAt:    wakeAt,
+			})
+
+		default:
+			var sizes []schedule.NodePoolSize
+			err := workflow.ExecuteActivity(activityCtx, GetNodePoolSizesActivityName, GetNodePoolSizesActivityInput{
+				ClusterID: input.ClusterID,
+			}).Get(activityCtx, &sizes)
+			if err != nil {
+				return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", GetNodePoolSizesActivityName)
+			}
+
+			nodePools = append([]schedule.NodePoolSize{}, sizes...)
+
+			err = workflow.ExecuteActivity(activityCtx, ScaleNodePoolsActivityName, ScaleNodePoolsActivityInput{
+				ClusterID: input.ClusterID,
+				NodePools: schedule.ZeroNodePoolSizes(nodePools),
+			}).Get(activityCtx, nil)
+			if err != nil {
+				return restoreOnExit(activityCtx, input.ClusterID, nodePools, errors.WrapIfWithDetails(err, "failed to execute activity", "activity", ScaleNodePoolsActivityName))
+			}
+
+			logger.Info("cluster is put to sleep")
+
+			wakeAt, _ := spec.NextWake(workflow.Now(ctx))
+			notify(activityCtx, logger, schedule.Notification{
+				Type:      schedule.NotificationHibernated,
+				ClusterID: input.ClusterID,
+				SleepAt:   sleepAt,
+				WakeAt:    wakeAt,
+			})
+		}
+	}
+}
+
+// waitUntil waits for the specified duration or an updated specification, whichever comes first.
+func waitUntil(ctx workflow.Context, signals workflow.Channel, duration time.Duration, spec *schedule.ServiceSpec) (bool, error) {
+	if duration < 0 {
+		duration = 0
+	}
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	var (
+		updated bool
+		err     error
+	)
+
+	selector := workflow.NewSelector(ctx)
+	selector.AddFuture(workflow.NewTimer(timerCtx, duration), func(f workflow.Future) {
+		err = f.Get(ctx, nil)
+	})
+	selector.AddReceive(signals, func(c workflow.Channel, more bool) {
+		c.Receive(ctx, spec)
+		updated = true
+	})
+	selector.Select(ctx)
+
+	return updated, err
+}
+
+// wakeUp restores the node pools of a hibernated cluster.
+//
+// The current node pools are read again, so that node pools changed during the hibernation are not overwritten.
+func wakeUp(ctx workflow.Context, logger *zap.Logger, clusterID uint, nodePools []schedule.NodePoolSize) error {
+	var current []schedule.NodePoolSize
+	err := workflow.ExecuteActivity(ctx, GetNodePoolSizesActivityName, GetNodePoolSizesActivityInput{
+		ClusterID: clusterID,
+	}).Get(ctx, &current)
+	if err != nil {
+		logger.Warn("failed to get the current node pool sizes, restoring every node pool", zap.Error(err))
+	} else {
+		nodePools = schedule.WakeNodePoolSizes(nodePools, current)
+	}
+
+	if len(nodePools) == 0 {
+		return nil
+	}
+
+	err = workflow.ExecuteActivity(ctx, ScaleNodePoolsActivityName, ScaleNodePoolsActivityInput{
+		ClusterID: clusterID,
+		NodePools: nodePools,
+	}).Get(ctx, nil)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", ScaleNodePoolsActivityName)
+	}
+
+	return nil
+}
+
+// restoreOnExit wakes up a hibernated cluster before the workflow exits with the specified error.
+func restoreOnExit(ctx workflow.Context, clusterID uint, nodePools []schedule.NodePoolSize, err error) error {
+	if nodePools == nil {
+		return err
+	}
+
+	ctx, _ = workflow.NewDisconnectedContext(ctx)
+
+	wakeErr := wakeUp(ctx, workflow.GetLogger(ctx).With(zap.Uint("clusterId", clusterID)), clusterID, nodePools)
+	if wakeErr != nil {
+		return errors.Combine(err, errors.WrapIf(wakeErr, "failed to wake up the cluster"))
+	}
+
+	return err
+}
+
+// notify sends a notification. Failing to send a notification does not stop the schedule.
+func notify(ctx workflow.Context, logger *zap.Logger, notification schedule.Notification) {
+	err := workflow.ExecuteActivity(ctx, NotifyActivityName, NotifyActivityInput{
+		Notification: notification,
+	}).Get(ctx, nil)
+	if err != nil {
+		logger.Warn("failed to send notification", zap.String("type", notification.Type), zap.Error(err))
+	}
+}
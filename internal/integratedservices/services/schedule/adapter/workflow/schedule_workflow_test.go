@@ -0,0 +1,214 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/schedule"
+)
+
+type ScheduleJobWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestScheduleJobWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleJobWorkflowTestSuite))
+}
+
+func (s *ScheduleJobWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.SetStartTime(time.Date(2021, 5, 7, 12, 0, 0, 0, time.UTC))
+
+	s.env.RegisterWorkflowWithOptions(ScheduleJobWorkflow, workflow.RegisterOptions{Name: ScheduleJobWorkflowName})
+	s.env.RegisterActivityWithOptions(NewGetNodePoolSizesActivity(nil).Execute, activity.RegisterOptions{Name: GetNodePoolSizesActivityName})
+	s.env.RegisterActivityWithOptions(NewScaleNodePoolsActivity(nil).Execute, activity.RegisterOptions{Name: ScaleNodePoolsActivityName})
+	s.env.RegisterActivityWithOptions(NewNotifyActivity(nil).Execute, activity.RegisterOptions{Name: NotifyActivityName})
+}
+
+func (s *ScheduleJobWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+var testNodePools = []schedule.NodePoolSize{
+	{Name: "pool0", MinSize: 1, MaxSize: 3, DesiredSize: 2},
+	{Name: "pool1", MinSize: 0, MaxSize: 5, DesiredSize: 1},
+}
+
+func (s *ScheduleJobWorkflowTestSuite) expectHibernation(clusterID uint) {
+	s.env.OnActivity(GetNodePoolSizesActivityName, mock.Anything, GetNodePoolSizesActivityInput{ClusterID: clusterID}).
+		Return(testNodePools, nil).Once()
+	s.env.OnActivity(GetNodePoolSizesActivityName, mock.Anything, GetNodePoolSizesActivityInput{ClusterID: clusterID}).
+		Return(schedule.ZeroNodePoolSizes(testNodePools), nil).Once()
+	s.env.OnActivity(ScaleNodePoolsActivityName, mock.Anything, ScaleNodePoolsActivityInput{
+		ClusterID: clusterID,
+		NodePools: schedule.ZeroNodePoolSizes(testNodePools),
+	}).Return(nil).Once()
+	s.env.OnActivity(ScaleNodePoolsActivityName, mock.Anything, ScaleNodePoolsActivityInput{
+		ClusterID: clusterID,
+		NodePools: testNodePools,
+	}).Return(nil).Once()
+}
+
+func (s *ScheduleJobWorkflowTestSuite) Test_SleepAndWake() {
+	var notifications []schedule.Notification
+
+	s.expectHibernation(1)
+	s.env.OnActivity(NotifyActivityName, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input NotifyActivityInput) error {
+			notifications = append(notifications, input.Notification)
+
+			return nil
+		})
+
+	s.env.ExecuteWorkflow(ScheduleJobWorkflowName, ScheduleJobWorkflowInput{
+		ClusterID: 1,
+		Spec: schedule.ServiceSpec{
+			Sleep:         "0 20 * * *",
+			Wake:          "0 7 * * *",
+			WarningBefore: "30m",
+		},
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.env.GetWorkflowError(), &continueAsNewErr)
+
+	require.Len(s.T(), notifications, 3)
+	s.Equal(schedule.NotificationHibernationDue, notifications[0].Type)
+	s.Equal(time.Date(2021, 5, 7, 20, 0, 0, 0, time.UTC), notifications[0].SleepAt)
+	s.Equal(time.Date(2021, 5, 8, 7, 0, 0, 0, time.UTC), notifications[0].WakeAt)
+	s.Equal(schedule.NotificationHibernated, notifications[1].Type)
+	s.Equal(schedule.NotificationWokenUp, notifications[2].Type)
+	s.Equal(time.Date(2021, 5, 8, 7, 0, 0, 0, time.UTC), s.env.Now().UTC())
+}
+
+func (s *ScheduleJobWorkflowTestSuite) Test_SleepInsideWindow() {
+	s.expectHibernation(1)
+	s.env.OnActivity(NotifyActivityName, mock.Anything, mock.Anything).Return(nil)
+
+	s.env.ExecuteWorkflow(ScheduleJobWorkflowName, ScheduleJobWorkflowInput{
+		ClusterID: 1,
+		Spec: schedule.ServiceSpec{
+			Sleep: "0 8 * * *",
+			Wake:  "0 18 * * *",
+		},
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.env.GetWorkflowError(), &continueAsNewErr)
+	s.Equal(time.Date(2021, 5, 7, 18, 0, 0, 0, time.UTC), s.env.Now().UTC())
+}
+
+func (s *ScheduleJobWorkflowTestSuite) Test_CancelDuringHibernation() {
+	s.expectHibernation(1)
+	s.env.OnActivity(NotifyActivityName, mock.Anything, mock.Anything).Return(nil)
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.CancelWorkflow()
+	}, 10*time.Hour)
+
+	s.env.ExecuteWorkflow(ScheduleJobWorkflowName, ScheduleJobWorkflowInput{
+		ClusterID: 1,
+		Spec: schedule.ServiceSpec{
+			Sleep: "0 20 * * *",
+			Wake:  "0 7 * * *",
+		},
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+	s.Equal(time.Date(2021, 5, 7, 22, 0, 0, 0, time.UTC), s.env.Now().UTC())
+}
+
+func (s *ScheduleJobWorkflowTestSuite) Test_NodePoolsChangedDuringHibernation() {
+	s.env.OnActivity(GetNodePoolSizesActivityName, mock.Anything, GetNodePoolSizesActivityInput{ClusterID: 1}).
+		Return(testNodePools, nil).Once()
+	s.env.OnActivity(ScaleNodePoolsActivityName, mock.Anything, ScaleNodePoolsActivityInput{
+		ClusterID: 1,
+		NodePools: schedule.ZeroNodePoolSizes(testNodePools),
+	}).Return(nil).Once()
+
+	// pool0 has been updated and pool1 has been deleted, a new pool2 has been created
+	s.env.OnActivity(GetNodePoolSizesActivityName, mock.Anything, GetNodePoolSizesActivityInput{ClusterID: 1}).
+		Return([]schedule.NodePoolSize{
+			{Name: "pool0", MinSize: 1, MaxSize: 4, DesiredSize: 1},
+			{Name: "pool2", MinSize: 1, MaxSize: 2, DesiredSize: 1},
+		}, nil).Once()
+	s.env.OnActivity(NotifyActivityName, mock.Anything, mock.Anything).Return(nil)
+
+	s.env.ExecuteWorkflow(ScheduleJobWorkflowName, ScheduleJobWorkflowInput{
+		ClusterID: 1,
+		Spec: schedule.ServiceSpec{
+			Sleep: "0 20 * * *",
+			Wake:  "0 7 * * *",
+		},
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.env.GetWorkflowError(), &continueAsNewErr)
+}
+
+func (s *ScheduleJobWorkflowTestSuite) Test_UpdateSchedule() {
+	var notifications []schedule.Notification
+
+	s.expectHibernation(1)
+	s.env.OnActivity(NotifyActivityName, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input NotifyActivityInput) error {
+			notifications = append(notifications, input.Notification)
+
+			return nil
+		})
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(UpdateScheduleSignalName, schedule.ServiceSpec{
+			Sleep:         "0 22 * * *",
+			Wake:          "0 6 * * *",
+			WarningBefore: "1h",
+		})
+	}, time.Hour)
+
+	s.env.ExecuteWorkflow(ScheduleJobWorkflowName, ScheduleJobWorkflowInput{
+		ClusterID: 1,
+		Spec: schedule.ServiceSpec{
+			Sleep: "0 20 * * *",
+			Wake:  "0 7 * * *",
+		},
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+
+	require.Len(s.T(), notifications, 3)
+	s.Equal(schedule.NotificationHibernationDue, notifications[0].Type)
+	s.Equal(time.Date(2021, 5, 7, 22, 0, 0, 0, time.UTC), notifications[0].SleepAt)
+	s.Equal(time.Date(2021, 5, 8, 6, 0, 0, 0, time.UTC), s.env.Now().UTC())
+}
@@ -0,0 +1,85 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+type clusterGetter interface {
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+}
+
+type scheduleServiceManager struct {
+	clusters       clusterGetter
+	specBinderFunc binderFunc
+}
+
+func (m scheduleServiceManager) GetOutput(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceOutput, error) {
+	return integratedservices.IntegratedServiceOutput{}, nil
+}
+
+func (m scheduleServiceManager) ValidateSpec(ctx context.Context, spec integratedservices.IntegratedServiceSpec) error {
+	var scheduleSpec ServiceSpec
+	if err := m.specBinderFunc(spec, &scheduleSpec); err != nil {
+		return integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: ServiceName,
+			Problem:               "failed to bind the schedule service specification",
+		}
+	}
+
+	if err := scheduleSpec.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PrepareSpec rejects clusters the node pools of which cannot be hibernated (only EKS clusters are supported).
+func (m scheduleServiceManager) PrepareSpec(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceSpec, error) {
+	c, err := m.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to get cluster")
+	}
+
+	if c.Distribution != pkgCluster.EKS {
+		return nil, errors.WithStack(cluster.NotSupportedDistributionError{
+			ID:           c.ID,
+			Cloud:        c.Cloud,
+			Distribution: c.Distribution,
+
+			Message: "the schedule integrated service is not supported for this distribution",
+		})
+	}
+
+	return spec, nil
+}
+
+func (m scheduleServiceManager) Name() string {
+	return ServiceName
+}
+
+func NewScheduleServiceManager(clusters clusterGetter, specBinderFn binderFunc) scheduleServiceManager {
+	return scheduleServiceManager{
+		clusters:       clusters,
+		specBinderFunc: specBinderFn,
+	}
+}
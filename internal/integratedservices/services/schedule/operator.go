@@ -0,0 +1,63 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+type scheduleServiceOperator struct {
+	scheduleService ScheduleService
+	specBinderFunc  binderFunc
+	logger          common.Logger
+}
+
+func NewScheduleServiceOperator(scheduleService ScheduleService, binderFn binderFunc, logger common.Logger) scheduleServiceOperator {
+	return scheduleServiceOperator{
+		scheduleService: scheduleService,
+		specBinderFunc:  binderFn,
+		logger:          logger,
+	}
+}
+
+func (o scheduleServiceOperator) Name() string {
+	return ServiceName
+}
+
+func (o scheduleServiceOperator) Apply(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) error {
+	scheduleSpec := ServiceSpec{}
+	if err := o.specBinderFunc(spec, &scheduleSpec); err != nil {
+		return errors.WrapIf(err, "failed to bind the schedule service specification")
+	}
+
+	if err := o.scheduleService.Schedule(ctx, clusterID, scheduleSpec); err != nil {
+		return errors.WrapIf(err, "failed to schedule the cluster")
+	}
+
+	return nil
+}
+
+func (o scheduleServiceOperator) Deactivate(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) error {
+	if err := o.scheduleService.CancelSchedule(ctx, clusterID); err != nil {
+		return errors.WrapIf(err, "failed to cancel the schedule")
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"context"
+	"time"
+)
+
+const ServiceName = "schedule"
+
+// Notification types sent about the hibernation of a cluster.
+const (
+	NotificationHibernationDue = "hibernation-due"
+	NotificationHibernated     = "hibernated"
+	NotificationWokenUp        = "woken-up"
+)
+
+// NodePoolSize describes the size and the autoscaling bounds of a node pool.
+type NodePoolSize struct {
+	Name        string
+	MinSize     int
+	MaxSize     int
+	DesiredSize int
+}
+
+// Notification is sent before and after the hibernation of a cluster.
+type Notification struct {
+	Type      string
+	ClusterID uint
+	SleepAt   time.Time
+	WakeAt    time.Time
+}
+
+// Scheduler sets up the sleep/wake schedule of a cluster.
+type Scheduler interface {
+	Schedule(ctx context.Context, clusterID uint, spec ServiceSpec) error
+}
+
+// ScheduleCanceller cancels the sleep/wake schedule of a cluster waking it up when necessary.
+type ScheduleCanceller interface {
+	CancelSchedule(ctx context.Context, clusterID uint) error
+}
+
+type ScheduleService interface {
+	Scheduler
+	ScheduleCanceller
+}
+
+// NodePoolScaler reads and changes the size of the node pools of a cluster.
+type NodePoolScaler interface {
+	// GetNodePoolSizes returns the current size of every node pool of a cluster.
+	GetNodePoolSizes(ctx context.Context, clusterID uint) ([]NodePoolSize, error)
+
+	// ScaleNodePools changes the size of the specified node pools of a cluster.
+	ScaleNodePools(ctx context.Context, clusterID uint, nodePools []NodePoolSize) error
+}
+
+// Notifier sends notifications about the hibernation of a cluster.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// WakeNodePoolSizes returns the sizes restoring the node pools hibernated with the specified sizes.
+//
+// Only node pools still scaled to zero are restored:
+// node pools deleted or resized (eg. updated) during the hibernation are left intact.
+func WakeNodePoolSizes(hibernated []NodePoolSize, current []NodePoolSize) []NodePoolSize {
+	currentSizes := make(map[string]NodePoolSize, len(current))
+	for _, nodePool := range current {
+		currentSizes[nodePool.Name] = nodePool
+	}
+
+	sizes := make([]NodePoolSize, 0, len(hibernated))
+	for _, nodePool := range hibernated {
+		if currentSize, ok := currentSizes[nodePool.Name]; ok && currentSize == (NodePoolSize{Name: nodePool.Name}) {
+			sizes = append(sizes, nodePool)
+		}
+	}
+
+	return sizes
+}
+
+// ZeroNodePoolSizes returns the sizes scaling every specified node pool to zero.
+func ZeroNodePoolSizes(nodePools []NodePoolSize) []NodePoolSize {
+	sizes := make([]NodePoolSize, 0, len(nodePools))
+	for _, nodePool := range nodePools {
+		sizes = append(sizes, NodePoolSize{Name: nodePool.Name})
+	}
+
+	return sizes
+}
@@ -0,0 +1,40 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWakeNodePoolSizes(t *testing.T) {
+	hibernated := []NodePoolSize{
+		{Name: "pool0", MinSize: 1, MaxSize: 3, DesiredSize: 2},
+		{Name: "pool1", MinSize: 0, MaxSize: 2, DesiredSize: 1},
+		{Name: "pool2", MinSize: 1, MaxSize: 1, DesiredSize: 1},
+	}
+
+	current := []NodePoolSize{
+		{Name: "pool0"},
+		{Name: "pool1", MinSize: 1, MaxSize: 4, DesiredSize: 1},
+		{Name: "pool3", MinSize: 1, MaxSize: 2, DesiredSize: 1},
+	}
+
+	want := []NodePoolSize{{Name: "pool0", MinSize: 1, MaxSize: 3, DesiredSize: 2}}
+
+	if got := WakeNodePoolSizes(hibernated, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("WakeNodePoolSizes() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/robfig/cron"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+type binderFunc = func(inputSpec integratedservices.IntegratedServiceSpec, boundSpec interface{}) error
+
+// ServiceSpec describes the sleep/wake windows of a cluster.
+type ServiceSpec struct {
+	// Sleep is a standard cron expression telling when to scale the node pools of the cluster to zero.
+	Sleep string `json:"sleep" mapstructure:"sleep"`
+
+	// Wake is a standard cron expression telling when to restore the node pools of the cluster.
+	Wake string `json:"wake" mapstructure:"wake"`
+
+	// Timezone is the IANA name of the time zone the cron expressions are evaluated in (defaults to UTC).
+	Timezone string `json:"timezone,omitempty" mapstructure:"timezone"`
+
+	// WarningBefore is the duration a warning is sent before the cluster is put to sleep (eg. "30m").
+	WarningBefore string `json:"warningBefore,omitempty" mapstructure:"warningBefore"`
+}
+
+func (s ServiceSpec) Validate() error {
+	if _, err := cron.ParseStandard(s.Sleep); err != nil {
+		return integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: ServiceName,
+			Problem:               "sleep must be a valid cron expression",
+		}
+	}
+
+	if _, err := cron.ParseStandard(s.Wake); err != nil {
+		return integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: ServiceName,
+			Problem:               "wake must be a valid cron expression",
+		}
+	}
+
+	if s.Sleep == s.Wake {
+		return integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: ServiceName,
+			Problem:               "sleep and wake must be different",
+		}
+	}
+
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: ServiceName,
+			Problem:               "timezone must be a valid IANA time zone name",
+		}
+	}
+
+	if s.WarningBefore != "" {
+		warningBefore, err := time.ParseDuration(s.WarningBefore)
+		if err != nil || warningBefore < 0 {
+			return integratedservices.InvalidIntegratedServiceSpecError{
+				IntegratedServiceName: ServiceName,
+				Problem:               "warningBefore must be a non-negative duration",
+			}
+		}
+	}
+
+	return nil
+}
+
+// NextSleep returns the first time after the specified one when the cluster should be put to sleep.
+func (s ServiceSpec) NextSleep(after time.Time) (time.Time, error) {
+	return s.next(s.Sleep, after)
+}
+
+// NextWake returns the first time after the specified one when the cluster should be woken up.
+func (s ServiceSpec) NextWake(after time.Time) (time.Time, error) {
+	return s.next(s.Wake, after)
+}
+
+// Warning returns the duration a warning is sent before the cluster is put to sleep.
+func (s ServiceSpec) Warning() time.Duration {
+	warningBefore, _ := time.ParseDuration(s.WarningBefore)
+
+	return warningBefore
+}
+
+func (s ServiceSpec) next(expression string, after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return time.Time{}, errors.WrapIf(err, "failed to parse the cron expression")
+	}
+
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Time{}, errors.WrapIf(err, "failed to load the time zone")
+	}
+
+	return schedule.Next(after.In(location)), nil
+}
@@ -0,0 +1,128 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestServiceSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ServiceSpec
+		wantErr bool
+	}{
+		{
+			name: "sleep must be a cron expression",
+			spec: ServiceSpec{
+				Sleep: "every evening",
+				Wake:  "0 7 * * 1-5",
+			},
+			wantErr: true,
+		},
+		{
+			name: "wake must be a cron expression",
+			spec: ServiceSpec{
+				Sleep: "0 20 * * 1-5",
+				Wake:  "0 25 * * *",
+			},
+			wantErr: true,
+		},
+		{
+			name: "sleep and wake must be different",
+			spec: ServiceSpec{
+				Sleep: "0 20 * * *",
+				Wake:  "0 20 * * *",
+			},
+			wantErr: true,
+		},
+		{
+			name: "timezone must be valid",
+			spec: ServiceSpec{
+				Sleep:    "0 20 * * 1-5",
+				Wake:     "0 7 * * 1-5",
+				Timezone: "Europe/Nowhere",
+			},
+			wantErr: true,
+		},
+		{
+			name: "warning must be a duration",
+			spec: ServiceSpec{
+				Sleep:         "0 20 * * 1-5",
+				Wake:          "0 7 * * 1-5",
+				WarningBefore: "half an hour",
+			},
+			wantErr: true,
+		},
+		{
+			name: "warning must not be negative",
+			spec: ServiceSpec{
+				Sleep:         "0 20 * * 1-5",
+				Wake:          "0 7 * * 1-5",
+				WarningBefore: "-30m",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid schedule",
+			spec: ServiceSpec{
+				Sleep:         "0 20 * * 1-5",
+				Wake:          "0 7 * * 1-5",
+				Timezone:      "Europe/Budapest",
+				WarningBefore: "30m",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spec.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceSpec_Next(t *testing.T) {
+	spec := ServiceSpec{
+		Sleep:    "0 20 * * 1-5",
+		Wake:     "0 7 * * 1-5",
+		Timezone: "Europe/Budapest",
+	}
+
+	// Friday, 2021-05-07 12:00 in Budapest
+	now := time.Date(2021, 5, 7, 10, 0, 0, 0, time.UTC)
+
+	nextSleep, err := spec.NextSleep(now)
+	if err != nil {
+		t.Fatalf("NextSleep() error = %v", err)
+	}
+
+	if want := time.Date(2021, 5, 7, 18, 0, 0, 0, time.UTC); !nextSleep.Equal(want) {
+		t.Errorf("NextSleep() = %v, want %v", nextSleep, want)
+	}
+
+	nextWake, err := spec.NextWake(nextSleep)
+	if err != nil {
+		t.Fatalf("NextWake() error = %v", err)
+	}
+
+	// Monday, 2021-05-10 07:00 in Budapest
+	if want := time.Date(2021, 5, 10, 5, 0, 0, 0, time.UTC); !nextWake.Equal(want) {
+		t.Errorf("NextWake() = %v, want %v", nextWake, want)
+	}
+}
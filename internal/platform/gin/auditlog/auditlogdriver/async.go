@@ -0,0 +1,131 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlogdriver
+
+import (
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	lbackoff "github.com/lestrrat-go/backoff"
+
+	"github.com/banzaicloud/pipeline/internal/platform/gin/auditlog"
+	"github.com/banzaicloud/pipeline/pkg/backoff"
+)
+
+// AsyncDriverConfig configures an audit log driver storing entries in the background.
+type AsyncDriverConfig struct {
+	// QueueSize is the number of entries waiting to be stored.
+	// Entries are dropped when the queue is full.
+	QueueSize int
+
+	// MaxRetries is the number of times storing an entry is retried.
+	MaxRetries int
+
+	// RetryInterval is the delay before the first retry, it is doubled after every further attempt.
+	RetryInterval time.Duration
+}
+
+// AsyncDriver stores entries through another driver in the background,
+// so that slow or unavailable sinks do not delay or fail API calls.
+type AsyncDriver struct {
+	driver       auditlog.Driver
+	config       AsyncDriverConfig
+	errorHandler auditlog.ErrorHandler
+
+	queue  chan auditlog.Entry
+	done   chan struct{}
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncDriver returns an audit log driver storing entries through another driver in the background.
+func NewAsyncDriver(driver auditlog.Driver, config AsyncDriverConfig, errorHandler auditlog.ErrorHandler) *AsyncDriver {
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1000
+	}
+
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = 5
+	}
+
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = time.Second
+	}
+
+	d := &AsyncDriver{
+		driver:       driver,
+		config:       config,
+		errorHandler: errorHandler,
+		queue:        make(chan auditlog.Entry, config.QueueSize),
+		done:         make(chan struct{}),
+	}
+
+	go d.run()
+
+	return d
+}
+
+// Store queues an entry, it fails when the queue is full.
+func (d *AsyncDriver) Store(entry auditlog.Entry) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return errors.New("audit log: driver is closed")
+	}
+
+	select {
+	case d.queue <- entry:
+		return nil
+
+	default:
+		return errors.NewWithDetails("audit log: queue is full, entry dropped", "correlationId", entry.CorrelationID)
+	}
+}
+
+// Close stops accepting new entries and waits until the queued ones are stored.
+func (d *AsyncDriver) Close() error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	<-d.done
+
+	return nil
+}
+
+func (d *AsyncDriver) run() {
+	defer close(d.done)
+
+	policy := lbackoff.NewExponential(
+		lbackoff.WithInterval(d.config.RetryInterval),
+		lbackoff.WithMaxInterval(d.config.RetryInterval*32),
+		lbackoff.WithJitterFactor(0.1),
+		lbackoff.WithMaxRetries(d.config.MaxRetries),
+	)
+
+	for entry := range d.queue {
+		entry := entry
+
+		err := backoff.Retry(func() error { return d.driver.Store(entry) }, policy)
+		if err != nil {
+			d.errorHandler.Handle(errors.WithDetails(err, "correlationId", entry.CorrelationID))
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlogdriver
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/platform/gin/auditlog"
+)
+
+type flakyDriver struct {
+	mu       sync.Mutex
+	failures int
+	entries  []auditlog.Entry
+}
+
+func (d *flakyDriver) Store(entry auditlog.Entry) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.failures > 0 {
+		d.failures--
+
+		return errors.New("sink unavailable")
+	}
+
+	d.entries = append(d.entries, entry)
+
+	return nil
+}
+
+type errorCollector struct {
+	mu     sync.Mutex
+	errors []error
+}
+
+func (h *errorCollector) Handle(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.errors = append(h.errors, err)
+}
+
+func (h *errorCollector) HandleContext(_ context.Context, err error) {
+	h.Handle(err)
+}
+
+func TestAsyncDriver(t *testing.T) {
+	t.Run("Retry", func(t *testing.T) {
+		sink := &flakyDriver{failures: 2}
+		errorHandler := &errorCollector{}
+
+		driver := NewAsyncDriver(sink, AsyncDriverConfig{MaxRetries: 3, RetryInterval: time.Millisecond}, errorHandler)
+
+		require.NoError(t, driver.Store(auditlog.Entry{CorrelationID: "1"}))
+		require.NoError(t, driver.Close())
+
+		require.Len(t, sink.entries, 1)
+		assert.Equal(t, "1", sink.entries[0].CorrelationID)
+		assert.Empty(t, errorHandler.errors)
+	})
+
+	t.Run("GiveUp", func(t *testing.T) {
+		sink := &flakyDriver{failures: 100}
+		errorHandler := &errorCollector{}
+
+		driver := NewAsyncDriver(sink, AsyncDriverConfig{MaxRetries: 2, RetryInterval: time.Millisecond}, errorHandler)
+
+		require.NoError(t, driver.Store(auditlog.Entry{CorrelationID: "1"}))
+		require.NoError(t, driver.Close())
+
+		assert.Empty(t, sink.entries)
+		assert.Len(t, errorHandler.errors, 1)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		driver := NewAsyncDriver(&flakyDriver{}, AsyncDriverConfig{}, &errorCollector{})
+		require.NoError(t, driver.Close())
+
+		assert.Error(t, driver.Store(auditlog.Entry{}))
+	})
+}
@@ -15,6 +15,7 @@
 package auditlogdriver
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -26,20 +27,21 @@ import (
 
 // EntryModel holds all information related to a user interaction.
 type EntryModel struct {
-	ID            uint      `gorm:"primary_key"`
-	Time          time.Time `gorm:"index"`
-	CorrelationID string    `gorm:"size:36"`
-	ClientIP      string    `gorm:"size:45"`
-	UserAgent     string
-	Path          string `gorm:"size:8000"`
-	Method        string `gorm:"size:7"`
-	UserID        uint
-	StatusCode    int
-	Body          *string `gorm:"type:json"`
-	Headers       string  `gorm:"type:json"`
-	ResponseTime  int
-	ResponseSize  int
-	Errors        *string `gorm:"type:json"`
+	ID             uint      `gorm:"primary_key"`
+	Time           time.Time `gorm:"index"`
+	CorrelationID  string    `gorm:"size:36"`
+	ClientIP       string    `gorm:"size:45"`
+	UserAgent      string
+	Path           string `gorm:"size:8000"`
+	Method         string `gorm:"size:7"`
+	UserID         uint
+	OrganizationID uint `gorm:"index"`
+	StatusCode     int
+	Body           *string `gorm:"type:json"`
+	Headers        string  `gorm:"type:json"`
+	ResponseTime   int
+	ResponseSize   int
+	Errors         *string `gorm:"type:json"`
 }
 
 // TableName specifies a database table name for the model.
@@ -60,24 +62,35 @@ type dbDriver struct {
 
 func (d dbDriver) Store(entry auditlog.Entry) error {
 	model := EntryModel{
-		Time:          entry.Time,
-		CorrelationID: entry.CorrelationID,
-		ClientIP:      entry.HTTP.ClientIP,
-		UserAgent:     entry.HTTP.UserAgent,
-		Path:          entry.HTTP.Path,
-		Method:        entry.HTTP.Method,
-		UserID:        entry.UserID,
-		StatusCode:    entry.HTTP.StatusCode,
-		Headers:       "{}",
-		ResponseTime:  entry.HTTP.ResponseTime,
-		ResponseSize:  entry.HTTP.ResponseSize,
-	}
-
-	// Saving the model fails when the body is not valid JSON (and not empty).
-	// The previous implementation transparently suppressed these errors, so this should be fine for now.
-	// The Body column should probably accept a raw body instead of enforcing JSON.
+		Time:           entry.Time,
+		CorrelationID:  entry.CorrelationID,
+		ClientIP:       entry.HTTP.ClientIP,
+		UserAgent:      entry.HTTP.UserAgent,
+		Path:           entry.HTTP.Path,
+		Method:         entry.HTTP.Method,
+		UserID:         entry.UserID,
+		OrganizationID: entry.OrganizationID,
+		StatusCode:     entry.HTTP.StatusCode,
+		Headers:        "{}",
+		ResponseTime:   entry.HTTP.ResponseTime,
+		ResponseSize:   entry.HTTP.ResponseSize,
+	}
+
+	// The Body column only accepts JSON documents (saving the model fails otherwise),
+	// so anything else (eg. redacted bodies) is stored as a JSON string.
 	if entry.HTTP.RequestBody != "" {
-		model.Body = &entry.HTTP.RequestBody
+		body := entry.HTTP.RequestBody
+
+		if !json.Valid([]byte(body)) {
+			b, err := json.Marshal(body)
+			if err != nil {
+				return errors.Wrap(err, "audit log")
+			}
+
+			body = string(b)
+		}
+
+		model.Body = &body
 	}
 
 	if len(entry.HTTP.Errors) > 0 {
@@ -96,3 +109,88 @@ func (d dbDriver) Store(entry auditlog.Entry) error {
 
 	return nil
 }
+
+// NewDatabaseReader returns an audit log reader that queries entries recorded by the database driver.
+func NewDatabaseReader(db *gorm.DB) auditlog.Reader {
+	return dbDriver{
+		db: db,
+	}
+}
+
+func (d dbDriver) FindEntries(ctx context.Context, query auditlog.Query) ([]auditlog.Entry, error) {
+	db := d.db
+
+	if query.OrganizationID != 0 {
+		db = db.Where("organization_id = ?", query.OrganizationID)
+	}
+
+	if query.UserID != 0 {
+		db = db.Where("user_id = ?", query.UserID)
+	}
+
+	if query.CorrelationID != "" {
+		db = db.Where("correlation_id = ?", query.CorrelationID)
+	}
+
+	if query.PathPrefix != "" {
+		db = db.Where("path LIKE ?", query.PathPrefix+"%")
+	}
+
+	if query.StatusCode != 0 {
+		db = db.Where("status_code = ?", query.StatusCode)
+	}
+
+	if !query.From.IsZero() {
+		db = db.Where("time >= ?", query.From)
+	}
+
+	if !query.To.IsZero() {
+		db = db.Where("time <= ?", query.To)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = auditlog.DefaultQueryLimit
+	}
+
+	var models []EntryModel
+
+	err := db.Order("time DESC, id DESC").Limit(limit).Offset(query.Offset).Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "audit log")
+	}
+
+	entries := make([]auditlog.Entry, 0, len(models))
+
+	for _, model := range models {
+		entry := auditlog.Entry{
+			Time:           model.Time,
+			CorrelationID:  model.CorrelationID,
+			UserID:         model.UserID,
+			OrganizationID: model.OrganizationID,
+			HTTP: auditlog.HTTPEntry{
+				ClientIP:     model.ClientIP,
+				UserAgent:    model.UserAgent,
+				Method:       model.Method,
+				Path:         model.Path,
+				StatusCode:   model.StatusCode,
+				ResponseTime: model.ResponseTime,
+				ResponseSize: model.ResponseSize,
+			},
+		}
+
+		if model.Body != nil {
+			entry.HTTP.RequestBody = *model.Body
+		}
+
+		if model.Errors != nil {
+			if err := json.Unmarshal([]byte(*model.Errors), &entry.HTTP.Errors); err != nil {
+				return nil, errors.Wrap(err, "audit log")
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
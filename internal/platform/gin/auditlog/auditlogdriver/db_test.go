@@ -15,6 +15,7 @@
 package auditlogdriver
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -95,3 +96,89 @@ func TestDatabaseDriver(t *testing.T) {
 
 	assert.Equal(t, model, expectedModel)
 }
+
+func TestDatabaseReader(t *testing.T) {
+	db := setUpDatabase(t)
+
+	driver := NewDatabaseDriver(db)
+
+	base := time.Date(1984, time.April, 4, 0, 0, 0, 0, time.UTC)
+
+	entries := []auditlog.Entry{
+		{
+			Time:           base,
+			CorrelationID:  "cid1",
+			UserID:         1,
+			OrganizationID: 1,
+			HTTP: auditlog.HTTPEntry{
+				Method:      "POST",
+				Path:        "/api/v1/orgs/1/clusters",
+				RequestBody: auditlog.RedactedValue,
+				StatusCode:  201,
+			},
+		},
+		{
+			Time:           base.Add(time.Hour),
+			CorrelationID:  "cid2",
+			UserID:         2,
+			OrganizationID: 1,
+			HTTP: auditlog.HTTPEntry{
+				Method:     "DELETE",
+				Path:       "/api/v1/orgs/1/clusters/1",
+				StatusCode: 202,
+				Errors:     []string{"error"},
+			},
+		},
+		{
+			Time:           base.Add(2 * time.Hour),
+			CorrelationID:  "cid3",
+			UserID:         1,
+			OrganizationID: 2,
+			HTTP: auditlog.HTTPEntry{
+				Method:     "GET",
+				Path:       "/api/v1/orgs/2/secrets",
+				StatusCode: 200,
+			},
+		},
+	}
+
+	for _, entry := range entries {
+		err := driver.Store(entry)
+		require.NoError(t, err)
+	}
+
+	reader := NewDatabaseReader(db)
+
+	t.Run("Organization", func(t *testing.T) {
+		result, err := reader.FindEntries(context.Background(), auditlog.Query{OrganizationID: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+
+		assert.Equal(t, "cid2", result[0].CorrelationID)
+		assert.Equal(t, []string{"error"}, result[0].HTTP.Errors)
+		assert.Equal(t, "cid1", result[1].CorrelationID)
+		assert.Equal(t, `"[REDACTED]"`, result[1].HTTP.RequestBody)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		result, err := reader.FindEntries(context.Background(), auditlog.Query{
+			UserID:     1,
+			PathPrefix: "/api/v1/orgs/1/",
+			StatusCode: 201,
+			From:       base,
+			To:         base.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+
+		assert.Equal(t, "cid1", result[0].CorrelationID)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		result, err := reader.FindEntries(context.Background(), auditlog.Query{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+
+		assert.Equal(t, "cid2", result[0].CorrelationID)
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlogdriver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/platform/gin/auditlog"
+)
+
+// FileDriverConfig configures a local file audit log driver.
+type FileDriverConfig struct {
+	// Path of the active log file.
+	Path string
+
+	// MaxSize is the size in megabytes the active log file can grow to before it gets rotated.
+	MaxSize int
+
+	// MaxBackups is the number of rotated log files to retain.
+	MaxBackups int
+}
+
+// Validate validates the configuration.
+func (c FileDriverConfig) Validate() error {
+	var err error
+
+	if c.Path == "" {
+		err = errors.Append(err, errors.New("audit log file path is required"))
+	}
+
+	if c.MaxSize < 1 {
+		err = errors.Append(err, errors.New("audit log file max size must be a positive number"))
+	}
+
+	if c.MaxBackups < 0 {
+		err = errors.Append(err, errors.New("audit log file max backups cannot be negative"))
+	}
+
+	return err
+}
+
+// NewFileDriver returns an audit log driver that writes entries as JSON lines to a local file.
+// The file is rotated when it reaches the configured size: rotated files get a numeric suffix
+// (the most recent one being ".1") and the oldest ones are removed.
+func NewFileDriver(config FileDriverConfig) auditlog.Driver {
+	return &fileDriver{
+		config: config,
+	}
+}
+
+type fileDriver struct {
+	config FileDriverConfig
+
+	file *os.File
+	size int64
+
+	mu sync.Mutex
+}
+
+func (d *fileDriver) Store(entry auditlog.Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "audit log")
+	}
+
+	line = append(line, '\n')
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.file == nil {
+		if err := d.open(); err != nil {
+			return err
+		}
+	}
+
+	if d.size+int64(len(line)) > int64(d.config.MaxSize)*1024*1024 && d.size > 0 {
+		if err := d.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := d.file.Write(line)
+	d.size += int64(n)
+	if err != nil {
+		return errors.WrapIf(err, "audit log")
+	}
+
+	return nil
+}
+
+func (d *fileDriver) open() error {
+	file, err := os.OpenFile(d.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.WrapIf(err, "audit log")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return errors.WrapIf(err, "audit log")
+	}
+
+	d.file = file
+	d.size = info.Size()
+
+	return nil
+}
+
+func (d *fileDriver) rotate() error {
+	if err := d.file.Close(); err != nil {
+		return errors.WrapIf(err, "audit log")
+	}
+
+	d.file = nil
+
+	if d.config.MaxBackups == 0 {
+		if err := os.Remove(d.config.Path); err != nil && !os.IsNotExist(err) {
+			return errors.WrapIf(err, "audit log")
+		}
+
+		return d.open()
+	}
+
+	// Shift the backups: the oldest one is overwritten
+	for i := d.config.MaxBackups - 1; i > 0; i-- {
+		err := os.Rename(d.backupPath(i), d.backupPath(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.WrapIf(err, "audit log")
+		}
+	}
+
+	if err := os.Rename(d.config.Path, d.backupPath(1)); err != nil {
+		return errors.WrapIf(err, "audit log")
+	}
+
+	return d.open()
+}
+
+func (d *fileDriver) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", d.config.Path, i)
+}
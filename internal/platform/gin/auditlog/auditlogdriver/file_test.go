@@ -0,0 +1,84 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlogdriver
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/platform/gin/auditlog"
+)
+
+func TestFileDriver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	driver := NewFileDriver(FileDriverConfig{
+		Path:       path,
+		MaxSize:    1,
+		MaxBackups: 2,
+	})
+
+	entry := auditlog.Entry{
+		Time:          time.Date(1984, time.April, 4, 0, 0, 0, 0, time.UTC),
+		CorrelationID: "cid",
+		UserID:        1,
+		HTTP: auditlog.HTTPEntry{
+			Method: "POST",
+			Path:   "/",
+		},
+	}
+
+	err := driver.Store(entry)
+	require.NoError(t, err)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	require.True(t, scanner.Scan())
+
+	var stored auditlog.Entry
+
+	err = json.Unmarshal(scanner.Bytes(), &stored)
+	require.NoError(t, err)
+
+	assert.Equal(t, entry, stored)
+
+	// Fill the file over the size limit
+	entry.HTTP.RequestBody = strings.Repeat("a", 1024*1024)
+
+	for i := 0; i < 4; i++ {
+		err := driver.Store(entry)
+		require.NoError(t, err)
+	}
+
+	_, err = os.Stat(path + ".1")
+	assert.NoError(t, err)
+
+	_, err = os.Stat(path + ".2")
+	assert.NoError(t, err)
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,131 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlogdriver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/platform/gin/auditlog"
+)
+
+// HTTPDriverConfig configures an audit log driver forwarding entries to an HTTP endpoint.
+type HTTPDriverConfig struct {
+	URL     string
+	Headers map[string]string
+	Timeout time.Duration
+}
+
+// Validate validates the configuration.
+func (c HTTPDriverConfig) Validate() error {
+	if c.URL == "" {
+		return errors.New("audit log webhook URL is required")
+	}
+
+	return nil
+}
+
+// NewHTTPDriver returns an audit log driver that posts entries as JSON documents to an HTTP endpoint.
+func NewHTTPDriver(config HTTPDriverConfig) auditlog.Driver {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+
+	return httpDriver{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type httpDriver struct {
+	config HTTPDriverConfig
+	client *http.Client
+}
+
+func (d httpDriver) Store(entry auditlog.Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "audit log")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, d.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapIf(err, "audit log")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, value := range d.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return errors.WrapIf(err, "audit log")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.NewWithDetails("audit log: unexpected webhook response", "statusCode", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SyslogDriverConfig configures an audit log driver forwarding entries to a syslog server.
+type SyslogDriverConfig struct {
+	// Network is the network of the syslog server ("tcp" or "udp").
+	// The local syslog server is used when empty.
+	Network string
+	Address string
+	Tag     string
+}
+
+// NewSyslogDriver returns an audit log driver that sends entries as JSON documents to a syslog server.
+func NewSyslogDriver(config SyslogDriverConfig) (auditlog.Driver, error) {
+	tag := config.Tag
+	if tag == "" {
+		tag = "pipeline-audit"
+	}
+
+	writer, err := syslog.Dial(config.Network, config.Address, syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, errors.WrapIf(err, fmt.Sprintf("failed to connect to syslog server %q", config.Address))
+	}
+
+	return syslogDriver{
+		writer: writer,
+	}, nil
+}
+
+type syslogDriver struct {
+	writer *syslog.Writer
+}
+
+func (d syslogDriver) Store(entry auditlog.Entry) error {
+	message, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "audit log")
+	}
+
+	return errors.WrapIf(d.writer.Info(string(message)), "audit log")
+}
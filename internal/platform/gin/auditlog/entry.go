@@ -20,21 +20,22 @@ import (
 
 // Entry holds all information related to an API call event.
 type Entry struct {
-	Time          time.Time
-	CorrelationID string
-	UserID        uint
-	HTTP          HTTPEntry
+	Time           time.Time `json:"time"`
+	CorrelationID  string    `json:"correlationId,omitempty"`
+	UserID         uint      `json:"userId"`
+	OrganizationID uint      `json:"organizationId,omitempty"`
+	HTTP           HTTPEntry `json:"http"`
 }
 
 // HTTPEntry contains details related to an HTTP call for an audit log entry.
 type HTTPEntry struct {
-	ClientIP     string
-	UserAgent    string
-	Method       string
-	Path         string
-	RequestBody  string
-	StatusCode   int
-	ResponseTime int
-	ResponseSize int
-	Errors       []string
+	ClientIP     string   `json:"clientIP"`
+	UserAgent    string   `json:"userAgent"`
+	Method       string   `json:"method"`
+	Path         string   `json:"path"`
+	RequestBody  string   `json:"requestBody,omitempty"`
+	StatusCode   int      `json:"statusCode"`
+	ResponseTime int      `json:"responseTime"`
+	ResponseSize int      `json:"responseSize"`
+	Errors       []string `json:"errors,omitempty"`
 }
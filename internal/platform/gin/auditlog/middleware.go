@@ -56,10 +56,12 @@ type Option interface {
 // respectively to generalize them for multiple use cases, but for now this solution (borrowed from the previous one)
 // should be fine.
 type middlewareOptions struct {
-	clock           Clock
-	sensitivePaths  []*regexp.Regexp
-	userIDExtractor func(req *http.Request) uint
-	errorHandler    ErrorHandler
+	clock                   Clock
+	sensitivePaths          []*regexp.Regexp
+	redactionRules          []RedactionRule
+	userIDExtractor         func(req *http.Request) uint
+	organizationIDExtractor func(req *http.Request) uint
+	errorHandler            ErrorHandler
 }
 
 type optionFunc func(o *middlewareOptions)
@@ -82,6 +84,13 @@ func WithSensitivePaths(sensitivePaths []*regexp.Regexp) Option {
 	})
 }
 
+// WithRedactionRules sets the rules used to redact sensitive information from request bodies.
+func WithRedactionRules(rules []RedactionRule) Option {
+	return optionFunc(func(o *middlewareOptions) {
+		o.redactionRules = rules
+	})
+}
+
 // WithErrorHandler sets the clock in an audit log middleware.
 func WithErrorHandler(errorHandler ErrorHandler) Option {
 	return optionFunc(func(o *middlewareOptions) {
@@ -96,12 +105,20 @@ func WithUserIDExtractor(userIDExtractor func(req *http.Request) uint) Option {
 	})
 }
 
+// WithOrganizationIDExtractor sets the function that extracts the organization ID from the request.
+func WithOrganizationIDExtractor(organizationIDExtractor func(req *http.Request) uint) Option {
+	return optionFunc(func(o *middlewareOptions) {
+		o.organizationIDExtractor = organizationIDExtractor
+	})
+}
+
 // Middleware returns a new HTTP middleware that records audit log entries.
 func Middleware(driver Driver, opts ...Option) gin.HandlerFunc {
 	options := middlewareOptions{
-		clock:           realClock{},
-		userIDExtractor: func(req *http.Request) uint { return 0 },
-		errorHandler:    NoopErrorHandler{},
+		clock:                   realClock{},
+		userIDExtractor:         func(req *http.Request) uint { return 0 },
+		organizationIDExtractor: func(req *http.Request) uint { return 0 },
+		errorHandler:            NoopErrorHandler{},
 	}
 
 	for _, opt := range opts {
@@ -150,6 +167,7 @@ func Middleware(driver Driver, opts ...Option) gin.HandlerFunc {
 		c.Next() // process request
 
 		entry.UserID = options.userIDExtractor(c.Request)
+		entry.OrganizationID = options.organizationIDExtractor(c.Request)
 
 		// Consider making this configurable if you need to log unauthorized requests,
 		// but keep in mind that in case of a public installation it's a potential DoS attack vector.
@@ -168,7 +186,7 @@ func Middleware(driver Driver, opts ...Option) gin.HandlerFunc {
 				options.errorHandler.HandleContext(c.Request.Context(), errors.WithStack(err))
 			}
 
-			entry.HTTP.RequestBody = string(buf.Bytes())
+			entry.HTTP.RequestBody = redactBody(options.redactionRules, c.Request.URL.Path, buf.Bytes())
 		}
 
 		if c.IsAborted() {
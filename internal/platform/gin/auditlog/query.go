@@ -0,0 +1,146 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/gin-gonic/gin"
+
+	"github.com/banzaicloud/pipeline/pkg/problems"
+)
+
+// Query limits.
+const (
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 1000
+)
+
+// Query filters audit log entries.
+// Zero values are ignored.
+type Query struct {
+	OrganizationID uint
+	UserID         uint
+	CorrelationID  string
+
+	// PathPrefix matches the beginning of the API call path.
+	PathPrefix string
+
+	StatusCode int
+
+	// From and To limit the time range of the entries (inclusive).
+	From time.Time
+	To   time.Time
+
+	Limit  int
+	Offset int
+}
+
+// Reader reads audit log entries back from a durable driver.
+type Reader interface {
+	// FindEntries returns the entries matching a query in reverse chronological order.
+	FindEntries(ctx context.Context, query Query) ([]Entry, error)
+}
+
+// QueryHandler returns a new HTTP handler that lists the audit log entries of an organization.
+//
+// Supported query parameters: userId, correlationId, path, statusCode, from, to (RFC3339), limit and offset.
+func QueryHandler(reader Reader, organizationIDExtractor func(req *http.Request) uint, errorHandler ErrorHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query, err := parseQuery(c)
+		if err != nil {
+			problem := problems.NewDetailedProblem(http.StatusBadRequest, err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, problem)
+
+			return
+		}
+
+		query.OrganizationID = organizationIDExtractor(c.Request)
+
+		entries, err := reader.FindEntries(c.Request.Context(), query)
+		if err != nil {
+			errorHandler.HandleContext(c.Request.Context(), err)
+
+			problem := problems.NewDetailedProblem(http.StatusInternalServerError, "failed to query audit log")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, problem)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, entries)
+	}
+}
+
+func parseQuery(c *gin.Context) (Query, error) {
+	query := Query{
+		CorrelationID: c.Query("correlationId"),
+		PathPrefix:    c.Query("path"),
+		Limit:         DefaultQueryLimit,
+	}
+
+	if v := c.Query("userId"); v != "" {
+		userID, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return query, errors.New("userId must be a positive number")
+		}
+
+		query.UserID = uint(userID)
+	}
+
+	if v := c.Query("statusCode"); v != "" {
+		statusCode, err := strconv.Atoi(v)
+		if err != nil {
+			return query, errors.New("statusCode must be a number")
+		}
+
+		query.StatusCode = statusCode
+	}
+
+	for param, t := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+		if v := c.Query(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return query, errors.New(fmt.Sprintf("%s must be an RFC3339 timestamp", param))
+			}
+
+			*t = parsed
+		}
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > MaxQueryLimit {
+			return query, errors.New(fmt.Sprintf("limit must be between 1 and %d", MaxQueryLimit))
+		}
+
+		query.Limit = limit
+	}
+
+	if v := c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return query, errors.New("offset must be a non-negative number")
+		}
+
+		query.Offset = offset
+	}
+
+	return query, nil
+}
@@ -0,0 +1,108 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces redacted information in request bodies.
+const RedactedValue = "[REDACTED]"
+
+// RedactionRule redacts sensitive information from the request body of matching API calls.
+type RedactionRule struct {
+	// Path matches the API call path. Every path is matched when empty.
+	Path *regexp.Regexp
+
+	// Keys lists the (case insensitive) JSON object keys whose values are redacted at any depth of the request body.
+	// The whole request body is redacted when empty or when the body is not a valid JSON document.
+	Keys []string
+}
+
+func (r RedactionRule) matches(path string) bool {
+	return r.Path == nil || r.Path.MatchString(path)
+}
+
+// redactBody applies the matching redaction rules on a request body.
+func redactBody(rules []RedactionRule, path string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	keys := make(map[string]bool)
+	var matched bool
+
+	for _, rule := range rules {
+		if !rule.matches(path) {
+			continue
+		}
+
+		if len(rule.Keys) == 0 {
+			return RedactedValue
+		}
+
+		matched = true
+
+		for _, key := range rule.Keys {
+			keys[strings.ToLower(key)] = true
+		}
+	}
+
+	if !matched {
+		return string(body)
+	}
+
+	var document interface{}
+
+	if err := json.Unmarshal(body, &document); err != nil {
+		return RedactedValue
+	}
+
+	redacted, err := json.Marshal(redactValue(document, keys))
+	if err != nil {
+		return RedactedValue
+	}
+
+	return string(redacted)
+}
+
+func redactValue(value interface{}, keys map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if keys[strings.ToLower(key)] {
+				v[key] = RedactedValue
+
+				continue
+			}
+
+			v[key] = redactValue(item, keys)
+		}
+
+		return v
+
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, keys)
+		}
+
+		return v
+
+	default:
+		return v
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactBody(t *testing.T) {
+	rules := []RedactionRule{
+		{
+			Keys: []string{"password"},
+		},
+		{
+			Path: regexp.MustCompile("^/api/v1/orgs/[0-9]+/clusters$"),
+			Keys: []string{"kubeconfig"},
+		},
+		{
+			Path: regexp.MustCompile("^/api/v1/orgs/[0-9]+/secrets"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "NoMatchingKey",
+			path:     "/api/v1/orgs/1/helm",
+			body:     `{"name":"release"}`,
+			expected: `{"name":"release"}`,
+		},
+		{
+			name:     "NestedKey",
+			path:     "/api/v1/orgs/1/helm",
+			body:     `{"values":{"db":{"Password":"secret"}}}`,
+			expected: `{"values":{"db":{"Password":"[REDACTED]"}}}`,
+		},
+		{
+			name:     "PathSpecificKey",
+			path:     "/api/v1/orgs/1/clusters",
+			body:     `{"name":"cluster","properties":[{"kubeconfig":"abcd"}]}`,
+			expected: `{"name":"cluster","properties":[{"kubeconfig":"[REDACTED]"}]}`,
+		},
+		{
+			name:     "WholeBody",
+			path:     "/api/v1/orgs/1/secrets",
+			body:     `{"values":{"token":"abcd"}}`,
+			expected: RedactedValue,
+		},
+		{
+			name:     "InvalidJSON",
+			path:     "/api/v1/orgs/1/helm",
+			body:     `password=secret`,
+			expected: RedactedValue,
+		},
+		{
+			name:     "EmptyBody",
+			path:     "/api/v1/orgs/1/secrets",
+			body:     "",
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, redactBody(rules, test.path, []byte(test.body)))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watermill
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// Supported event bus drivers.
+const (
+	// DriverGoChannel is an in-memory driver: messages are lost on restart
+	// and they are only delivered to subscribers within the same process.
+	DriverGoChannel = "gochannel"
+
+	// DriverSQL stores messages in the Pipeline database.
+	DriverSQL = "sql"
+
+	// DriverKafka publishes messages to a Kafka cluster.
+	DriverKafka = "kafka"
+)
+
+// Config holds event bus configuration.
+type Config struct {
+	// Driver is the event bus implementation (gochannel, sql or kafka).
+	Driver string
+
+	// ConsumerGroup identifies the subscribers of a process.
+	// Every handler of every consumer group receives every message,
+	// replicas of a handler within the same consumer group share their progress.
+	ConsumerGroup string
+
+	SQL   SQLConfig
+	Kafka KafkaConfig
+}
+
+// SQLConfig holds configuration for the SQL driver.
+type SQLConfig struct {
+	// PollInterval is the time subscribers wait before looking for new messages.
+	PollInterval time.Duration
+
+	// RetryInterval is the time subscribers wait before redelivering a rejected message.
+	RetryInterval time.Duration
+
+	// VisibilityTimeout is the time subscribers wait for messages published by concurrent transactions
+	// to become visible before skipping them. It should be longer than any publishing transaction.
+	VisibilityTimeout time.Duration
+
+	// Retention is the time messages are kept in the database. Zero means forever.
+	Retention time.Duration
+}
+
+// KafkaConfig holds configuration for the Kafka driver.
+type KafkaConfig struct {
+	// Brokers is the list of Kafka broker addresses.
+	Brokers []string
+
+	// RetryInterval is the time subscribers wait before redelivering a rejected message.
+	RetryInterval time.Duration
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	switch c.Driver {
+	case DriverGoChannel:
+		return nil
+
+	case DriverSQL:
+		var errs error
+
+		if c.ConsumerGroup == "" {
+			errs = errors.Append(errs, errors.New("event bus consumer group is required"))
+		}
+
+		if c.SQL.PollInterval <= 0 {
+			errs = errors.Append(errs, errors.New("event bus sql poll interval must be positive"))
+		}
+
+		if c.SQL.RetryInterval <= 0 {
+			errs = errors.Append(errs, errors.New("event bus sql retry interval must be positive"))
+		}
+
+		if c.SQL.VisibilityTimeout < 0 {
+			errs = errors.Append(errs, errors.New("event bus sql visibility timeout cannot be negative"))
+		}
+
+		if c.SQL.Retention < 0 {
+			errs = errors.Append(errs, errors.New("event bus sql retention cannot be negative"))
+		}
+
+		return errs
+
+	case DriverKafka:
+		var errs error
+
+		if c.ConsumerGroup == "" {
+			errs = errors.Append(errs, errors.New("event bus consumer group is required"))
+		}
+
+		if len(c.Kafka.Brokers) == 0 {
+			errs = errors.Append(errs, errors.New("event bus kafka brokers are required"))
+		}
+
+		if c.Kafka.RetryInterval <= 0 {
+			errs = errors.Append(errs, errors.New("event bus kafka retry interval must be positive"))
+		}
+
+		return errs
+
+	default:
+		return errors.Errorf("unsupported event bus driver: %q", c.Driver)
+	}
+}
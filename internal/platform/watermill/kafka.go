@@ -0,0 +1,145 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watermill
+
+import (
+	"context"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// KafkaPubSub is a durable publisher and subscriber backed by a Kafka cluster.
+//
+// Every subscriber of the consumer group (see Subscriber) joins its own Kafka consumer group,
+// so that every handler receives every message and replicas of a handler share their progress.
+// Subscribers subscribing to a topic for the first time only receive messages published after the subscription.
+type KafkaPubSub struct {
+	publisher     *kafka.Publisher
+	consumerGroup string
+	config        KafkaConfig
+	logger        watermill.LoggerAdapter
+
+	mu          sync.Mutex
+	subscribers []*kafka.Subscriber
+	closed      bool
+}
+
+// NewKafkaPubSub returns a new KafkaPubSub.
+func NewKafkaPubSub(consumerGroup string, config KafkaConfig, logger watermill.LoggerAdapter) (*KafkaPubSub, error) {
+	publisher, err := kafka.NewPublisher(
+		kafka.PublisherConfig{
+			Brokers:   config.Brokers,
+			Marshaler: kafka.DefaultMarshaler{},
+		},
+		logger,
+	)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create kafka publisher")
+	}
+
+	return &KafkaPubSub{
+		publisher:     publisher,
+		consumerGroup: consumerGroup,
+		config:        config,
+		logger:        logger,
+	}, nil
+}
+
+// Publish publishes messages to a Kafka topic.
+func (p *KafkaPubSub) Publish(topic string, messages ...*message.Message) error {
+	return p.publisher.Publish(topic, messages...)
+}
+
+// Subscribe returns a channel of messages published to a topic using the unnamed subscriber of the consumer group.
+func (p *KafkaPubSub) Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error) {
+	return p.subscribe(ctx, "", topic)
+}
+
+// Subscriber returns a subscriber of the consumer group tracking its progress under the given name.
+func (p *KafkaPubSub) Subscriber(name string) message.Subscriber {
+	return namedKafkaSubscriber{
+		pubsub: p,
+		name:   name,
+	}
+}
+
+type namedKafkaSubscriber struct {
+	pubsub *KafkaPubSub
+	name   string
+}
+
+func (s namedKafkaSubscriber) Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error) {
+	return s.pubsub.subscribe(ctx, s.name, topic)
+}
+
+// Close is a noop: the subscriptions are stopped when the pub/sub is closed.
+func (s namedKafkaSubscriber) Close() error {
+	return nil
+}
+
+func (p *KafkaPubSub) subscribe(ctx context.Context, subscriber string, topic string) (<-chan *message.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, errors.New("subscriber is closed")
+	}
+
+	consumerGroup := p.consumerGroup
+	if subscriber != "" {
+		consumerGroup += "." + subscriber
+	}
+
+	s, err := kafka.NewSubscriber(
+		kafka.SubscriberConfig{
+			Brokers:         p.config.Brokers,
+			Unmarshaler:     kafka.DefaultMarshaler{},
+			ConsumerGroup:   consumerGroup,
+			NackResendSleep: p.config.RetryInterval,
+		},
+		p.logger,
+	)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to create kafka subscriber", "consumerGroup", consumerGroup)
+	}
+
+	p.subscribers = append(p.subscribers, s)
+
+	return s.Subscribe(ctx, topic)
+}
+
+// Close closes the publisher and stops subscribers.
+func (p *KafkaPubSub) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+
+	p.closed = true
+
+	err := p.publisher.Close()
+
+	for _, s := range p.subscribers {
+		err = errors.Append(err, s.Close())
+	}
+
+	return err
+}
@@ -15,18 +15,52 @@
 package watermill
 
 import (
+	"emperror.dev/errors"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/jinzhu/gorm"
 	watermilllog "logur.dev/integration/watermill"
 	"logur.dev/logur"
 )
 
-// NewPubSub returns a new PubSub.
-func NewPubSub(logger logur.Logger) (message.Publisher, message.Subscriber) {
-	pubsub := gochannel.NewGoChannel(
-		gochannel.Config{},
-		watermilllog.New(logur.WithFields(logger, map[string]interface{}{"component": "watermill"})),
-	)
+// NewPubSub returns a new publisher and subscriber based on the configured driver.
+func NewPubSub(config Config, db *gorm.DB, logger logur.Logger) (message.Publisher, message.Subscriber, error) {
+	wlogger := watermilllog.New(logur.WithFields(logger, map[string]interface{}{"component": "watermill"}))
 
-	return pubsub, pubsub
+	switch config.Driver {
+	case DriverGoChannel:
+		pubsub := gochannel.NewGoChannel(gochannel.Config{}, wlogger)
+
+		return pubsub, pubsub, nil
+
+	case DriverSQL:
+		pubsub := NewSQLPubSub(db, config.ConsumerGroup, config.SQL, wlogger)
+
+		return pubsub, pubsub, nil
+
+	case DriverKafka:
+		pubsub, err := NewKafkaPubSub(config.ConsumerGroup, config.Kafka, wlogger)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return pubsub, pubsub, nil
+
+	default:
+		return nil, nil, errors.Errorf("unsupported event bus driver: %q", config.Driver)
+	}
+}
+
+// HandlerSubscriber returns a subscriber for a single router handler.
+//
+// Durable subscribers track the progress of every handler separately,
+// so that handlers subscribing to the same topic all receive every message.
+func HandlerSubscriber(subscriber message.Subscriber, handlerName string) message.Subscriber {
+	if s, ok := subscriber.(interface {
+		Subscriber(name string) message.Subscriber
+	}); ok {
+		return s.Subscriber(handlerName)
+	}
+
+	return subscriber
 }
@@ -0,0 +1,437 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watermill
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+)
+
+const (
+	messageTableName = "event_bus_messages"
+	offsetTableName  = "event_bus_offsets"
+)
+
+type messageModel struct {
+	ID        uint64        `gorm:"primary_key"`
+	Topic     string        `gorm:"index;not null"`
+	UUID      string        `gorm:"not null"`
+	Metadata  metadataModel `gorm:"type:text"`
+	Payload   []byte
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName changes the default table name.
+func (messageModel) TableName() string {
+	return messageTableName
+}
+
+type metadataModel message.Metadata
+
+// Scan implements the sql.Scanner interface.
+func (m *metadataModel) Scan(src interface{}) error {
+	return json.Scan(src, m)
+}
+
+// Value implements the driver.Valuer interface.
+func (m metadataModel) Value() (driver.Value, error) {
+	return json.Value(m)
+}
+
+// offsetModel stores the last message of a topic acknowledged by a subscriber of a consumer group.
+type offsetModel struct {
+	ConsumerGroup string `gorm:"primary_key;size:255"`
+	Subscriber    string `gorm:"primary_key;size:255;not null;default:''"`
+	Topic         string `gorm:"primary_key;size:255"`
+	LastMessageID uint64 `gorm:"not null"`
+	UpdatedAt     time.Time
+}
+
+// TableName changes the default table name.
+func (offsetModel) TableName() string {
+	return offsetTableName
+}
+
+// Migrate executes the table migrations for the SQL event bus.
+func Migrate(db *gorm.DB, logger logrus.FieldLogger) error {
+	tables := []interface{}{
+		&messageModel{},
+		&offsetModel{},
+	}
+
+	var tableNames string
+	for _, table := range tables {
+		tableNames += fmt.Sprintf(" %s", db.NewScope(table).TableName())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"table_names": strings.TrimSpace(tableNames),
+	}).Info("migrating event bus tables")
+
+	return db.AutoMigrate(tables...).Error
+}
+
+// SQLPubSub is a durable publisher and subscriber storing messages in a relational database.
+//
+// Messages are delivered at least once to every subscriber of every consumer group in the order they were published.
+// Messages published by concurrent transactions are delivered in the order of their IDs:
+// subscribers wait for messages with lower IDs to become visible up to the configured visibility timeout.
+// Subscribers deliver messages one by one and only move on to the next message
+// once the current one is acknowledged. Rejected messages are redelivered after the configured retry interval.
+// Subscribers are identified by their name within the consumer group (see Subscriber):
+// replicas of the same subscriber share their progress, but they may end up processing the same message.
+type SQLPubSub struct {
+	db            *gorm.DB
+	consumerGroup string
+	config        SQLConfig
+	logger        watermill.LoggerAdapter
+
+	subscribers sync.WaitGroup
+	closing     chan struct{}
+	closeOnce   sync.Once
+}
+
+// NewSQLPubSub returns a new SQLPubSub.
+func NewSQLPubSub(db *gorm.DB, consumerGroup string, config SQLConfig, logger watermill.LoggerAdapter) *SQLPubSub {
+	p := &SQLPubSub{
+		db:            db,
+		consumerGroup: consumerGroup,
+		config:        config,
+		logger:        logger,
+		closing:       make(chan struct{}),
+	}
+
+	if config.Retention > 0 {
+		p.subscribers.Add(1)
+		go p.purge()
+	}
+
+	return p
+}
+
+// Publish stores messages in the database.
+func (p *SQLPubSub) Publish(topic string, messages ...*message.Message) error {
+	if p.isClosed() {
+		return errors.New("publisher is closed")
+	}
+
+	tx := p.db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	for _, msg := range messages {
+		model := messageModel{
+			Topic:    topic,
+			UUID:     msg.UUID,
+			Metadata: metadataModel(msg.Metadata),
+			Payload:  msg.Payload,
+		}
+
+		if err := tx.Create(&model).Error; err != nil {
+			tx.Rollback()
+
+			return errors.WrapIfWithDetails(err, "failed to publish message", "topic", topic, "uuid", msg.UUID)
+		}
+	}
+
+	return errors.WrapIf(tx.Commit().Error, "failed to commit transaction")
+}
+
+// Subscribe returns a channel of messages published to a topic using the unnamed subscriber of the consumer group.
+// Subscribers subscribing to a topic for the first time only receive messages published after the subscription.
+func (p *SQLPubSub) Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error) {
+	return p.subscribe(ctx, "", topic)
+}
+
+// Subscriber returns a subscriber of the consumer group tracking its progress under the given name.
+//
+// Every subscriber of the consumer group receives every message,
+// so multiple handlers of a process can subscribe to the same topic.
+func (p *SQLPubSub) Subscriber(name string) message.Subscriber {
+	return namedSQLSubscriber{
+		pubsub: p,
+		name:   name,
+	}
+}
+
+type namedSQLSubscriber struct {
+	pubsub *SQLPubSub
+	name   string
+}
+
+func (s namedSQLSubscriber) Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error) {
+	return s.pubsub.subscribe(ctx, s.name, topic)
+}
+
+// Close is a noop: the subscriptions are stopped when the pub/sub is closed.
+func (s namedSQLSubscriber) Close() error {
+	return nil
+}
+
+func (p *SQLPubSub) subscribe(ctx context.Context, subscriber string, topic string) (<-chan *message.Message, error) {
+	if p.isClosed() {
+		return nil, errors.New("subscriber is closed")
+	}
+
+	key := offsetModel{
+		ConsumerGroup: p.consumerGroup,
+		Subscriber:    subscriber,
+		Topic:         topic,
+	}
+
+	if err := p.ensureOffset(key); err != nil {
+		return nil, err
+	}
+
+	out := make(chan *message.Message)
+
+	p.subscribers.Add(1)
+	go func() {
+		defer p.subscribers.Done()
+		defer close(out)
+
+		p.consume(ctx, key, out)
+	}()
+
+	return out, nil
+}
+
+// Close stops subscribers and waits for them to finish.
+func (p *SQLPubSub) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+	})
+
+	p.subscribers.Wait()
+
+	return nil
+}
+
+func (p *SQLPubSub) isClosed() bool {
+	select {
+	case <-p.closing:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *SQLPubSub) ensureOffset(key offsetModel) error {
+	var lastMessageID uint64
+
+	// The last message of any topic is used, so that the messages of other topics do not look like gaps
+	err := p.db.Model(&messageModel{}).Select("COALESCE(MAX(id), 0)").Row().Scan(&lastMessageID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to find last message")
+	}
+
+	offset := key
+
+	// Subscriber is included explicitly: the unnamed subscriber would match the offsets of every subscriber
+	err = p.db.Where(map[string]interface{}{
+		"consumer_group": key.ConsumerGroup,
+		"subscriber":     key.Subscriber,
+		"topic":          key.Topic,
+	}).Attrs(offsetModel{LastMessageID: lastMessageID}).FirstOrCreate(&offset).Error
+	if err != nil {
+		// Another replica of the subscriber might have created the offset in the meantime
+		if _, err := p.findOffset(key); err != nil {
+			return errors.WrapIfWithDetails(
+				err, "failed to create offset",
+				"consumerGroup", key.ConsumerGroup,
+				"subscriber", key.Subscriber,
+				"topic", key.Topic,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (p *SQLPubSub) findOffset(key offsetModel) (offsetModel, error) {
+	var offset offsetModel
+
+	err := p.db.
+		Where("consumer_group = ? AND subscriber = ? AND topic = ?", key.ConsumerGroup, key.Subscriber, key.Topic).
+		First(&offset).Error
+
+	return offset, err
+}
+
+func (p *SQLPubSub) consume(ctx context.Context, key offsetModel, out chan<- *message.Message) {
+	logFields := watermill.LogFields{"consumer_group": key.ConsumerGroup, "subscriber": key.Subscriber, "topic": key.Topic}
+
+	for {
+		model, lastMessageID, found, err := p.next(key)
+		if err != nil {
+			p.logger.Error("failed to fetch next message", err, logFields)
+		}
+
+		if err != nil || !found {
+			if !p.wait(ctx, p.config.PollInterval) {
+				return
+			}
+
+			continue
+		}
+
+		msg := message.NewMessage(model.UUID, model.Payload)
+		msg.Metadata = message.Metadata(model.Metadata)
+
+		msgCtx, cancel := context.WithCancel(ctx)
+		msg.SetContext(msgCtx)
+
+		select {
+		case out <- msg:
+		case <-ctx.Done():
+			cancel()
+			return
+		case <-p.closing:
+			cancel()
+			return
+		}
+
+		select {
+		case <-msg.Acked():
+			cancel()
+
+			err := p.ack(key, lastMessageID, model.ID)
+			if err != nil {
+				p.logger.Error("failed to acknowledge message", err, logFields.Add(watermill.LogFields{"uuid": model.UUID}))
+			}
+
+		case <-msg.Nacked():
+			cancel()
+
+			p.logger.Debug("message rejected, redelivering", logFields.Add(watermill.LogFields{"uuid": model.UUID}))
+
+			if !p.wait(ctx, p.config.RetryInterval) {
+				return
+			}
+
+		case <-ctx.Done():
+			cancel()
+			return
+
+		case <-p.closing:
+			cancel()
+			return
+		}
+	}
+}
+
+// next returns the first message of a topic not yet acknowledged by the subscriber.
+func (p *SQLPubSub) next(key offsetModel) (messageModel, uint64, bool, error) {
+	offset, err := p.findOffset(key)
+	if err != nil {
+		return messageModel{}, 0, false, errors.WrapIf(err, "failed to find offset")
+	}
+
+	var models []messageModel
+
+	err = p.db.Where("topic = ? AND id > ?", key.Topic, offset.LastMessageID).Order("id").Limit(1).Find(&models).Error
+	if err != nil {
+		return messageModel{}, 0, false, errors.WrapIf(err, "failed to find message")
+	}
+
+	if len(models) == 0 {
+		return messageModel{}, 0, false, nil
+	}
+
+	model := models[0]
+
+	// IDs are assigned in insertion order, but transactions may commit in a different order:
+	// missing IDs before the message may belong to messages that are not visible yet.
+	// Gaps are only skipped (eg. rolled back or purged messages) once the message is older than the visibility timeout.
+	if model.ID > offset.LastMessageID+1 && time.Since(model.CreatedAt) < p.config.VisibilityTimeout {
+		var count int64
+
+		err := p.db.Model(&messageModel{}).Where("id > ? AND id < ?", offset.LastMessageID, model.ID).Count(&count).Error
+		if err != nil {
+			return messageModel{}, 0, false, errors.WrapIf(err, "failed to count messages")
+		}
+
+		if uint64(count) < model.ID-offset.LastMessageID-1 {
+			return messageModel{}, 0, false, nil
+		}
+	}
+
+	return model, offset.LastMessageID, true, nil
+}
+
+// ack moves the offset of the subscriber past a message.
+// If another replica already moved the offset the update is skipped.
+func (p *SQLPubSub) ack(key offsetModel, lastMessageID uint64, messageID uint64) error {
+	return p.db.Model(&offsetModel{}).
+		Where(
+			"consumer_group = ? AND subscriber = ? AND topic = ? AND last_message_id = ?",
+			key.ConsumerGroup, key.Subscriber, key.Topic, lastMessageID,
+		).
+		Updates(map[string]interface{}{"last_message_id": messageID, "updated_at": time.Now()}).
+		Error
+}
+
+func (p *SQLPubSub) purge() {
+	defer p.subscribers.Done()
+
+	interval := time.Hour
+	if p.config.Retention < interval {
+		interval = p.config.Retention
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := p.db.Where("created_at < ?", time.Now().Add(-p.config.Retention)).Delete(&messageModel{}).Error
+			if err != nil {
+				p.logger.Error("failed to purge expired messages", err, nil)
+			}
+
+		case <-p.closing:
+			return
+		}
+	}
+}
+
+// wait waits for the given duration and returns false if the subscriber should stop in the meantime.
+func (p *SQLPubSub) wait(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-p.closing:
+		return false
+	}
+}
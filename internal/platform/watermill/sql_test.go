@@ -0,0 +1,238 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watermill
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/jinzhu/gorm"
+
+	//  SQLite driver used for integration test
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	// in-memory databases are connection scoped
+	db.DB().SetMaxOpenConns(1)
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	err = Migrate(db, logger)
+	require.NoError(t, err)
+
+	return db
+}
+
+func receive(t *testing.T, messages <-chan *message.Message) *message.Message {
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+
+	return nil
+}
+
+func TestSQLPubSub(t *testing.T) {
+	db := setUpDatabase(t)
+
+	config := SQLConfig{
+		PollInterval:  10 * time.Millisecond,
+		RetryInterval: 10 * time.Millisecond,
+	}
+
+	pipeline := NewSQLPubSub(db, "pipeline", config, watermill.NopLogger{})
+	defer pipeline.Close()
+
+	worker := NewSQLPubSub(db, "worker", config, watermill.NopLogger{})
+	defer worker.Close()
+
+	err := pipeline.Publish("cluster", message.NewMessage("before-subscription", []byte("0")))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pipelineMessages, err := pipeline.Subscribe(ctx, "cluster")
+	require.NoError(t, err)
+
+	workerMessages, err := worker.Subscribe(ctx, "cluster")
+	require.NoError(t, err)
+
+	msg := message.NewMessage("first", []byte("1"))
+	msg.Metadata.Set("name", "ClusterCreated")
+
+	err = pipeline.Publish("cluster", msg, message.NewMessage("second", []byte("2")))
+	require.NoError(t, err)
+
+	// every consumer group receives every message
+	received := receive(t, pipelineMessages)
+	assert.Equal(t, "first", received.UUID)
+	assert.Equal(t, "ClusterCreated", received.Metadata.Get("name"))
+	received.Ack()
+
+	received = receive(t, workerMessages)
+	assert.Equal(t, "first", received.UUID)
+	received.Ack()
+
+	// rejected messages are redelivered
+	received = receive(t, workerMessages)
+	assert.Equal(t, "second", received.UUID)
+	received.Nack()
+
+	received = receive(t, workerMessages)
+	assert.Equal(t, "second", received.UUID)
+	received.Ack()
+
+	received = receive(t, pipelineMessages)
+	assert.Equal(t, "second", received.UUID)
+	received.Ack()
+}
+
+func TestSQLPubSub_Resume(t *testing.T) {
+	db := setUpDatabase(t)
+
+	config := SQLConfig{
+		PollInterval:  10 * time.Millisecond,
+		RetryInterval: 10 * time.Millisecond,
+	}
+
+	pubsub := NewSQLPubSub(db, "worker", config, watermill.NopLogger{})
+
+	messages, err := pubsub.Subscribe(context.Background(), "cluster")
+	require.NoError(t, err)
+
+	err = pubsub.Publish("cluster", message.NewMessage("first", nil), message.NewMessage("second", nil))
+	require.NoError(t, err)
+
+	receive(t, messages).Ack()
+
+	// unacknowledged messages are delivered again after a restart
+	msg := receive(t, messages)
+	assert.Equal(t, "second", msg.UUID)
+
+	require.NoError(t, pubsub.Close())
+
+	pubsub = NewSQLPubSub(db, "worker", config, watermill.NopLogger{})
+	defer pubsub.Close()
+
+	messages, err = pubsub.Subscribe(context.Background(), "cluster")
+	require.NoError(t, err)
+
+	msg = receive(t, messages)
+	assert.Equal(t, "second", msg.UUID)
+	msg.Ack()
+}
+
+func TestSQLPubSub_Subscriber(t *testing.T) {
+	db := setUpDatabase(t)
+
+	config := SQLConfig{
+		PollInterval:  10 * time.Millisecond,
+		RetryInterval: 10 * time.Millisecond,
+	}
+
+	pubsub := NewSQLPubSub(db, "pipeline", config, watermill.NopLogger{})
+	defer pubsub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	webhookMessages, err := HandlerSubscriber(pubsub, "webhook").Subscribe(ctx, "cluster")
+	require.NoError(t, err)
+
+	loggerMessages, err := HandlerSubscriber(pubsub, "logger").Subscribe(ctx, "cluster")
+	require.NoError(t, err)
+
+	err = pubsub.Publish("cluster", message.NewMessage("first", nil), message.NewMessage("second", nil))
+	require.NoError(t, err)
+
+	// every handler of a consumer group receives every message
+	for _, messages := range []<-chan *message.Message{webhookMessages, loggerMessages} {
+		msg := receive(t, messages)
+		assert.Equal(t, "first", msg.UUID)
+		msg.Ack()
+
+		msg = receive(t, messages)
+		assert.Equal(t, "second", msg.UUID)
+		msg.Ack()
+	}
+}
+
+func TestSQLPubSub_Gap(t *testing.T) {
+	db := setUpDatabase(t)
+
+	config := SQLConfig{
+		PollInterval:      10 * time.Millisecond,
+		RetryInterval:     10 * time.Millisecond,
+		VisibilityTimeout: time.Hour,
+	}
+
+	pubsub := NewSQLPubSub(db, "worker", config, watermill.NopLogger{})
+	defer pubsub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, err := pubsub.Subscribe(ctx, "cluster")
+	require.NoError(t, err)
+
+	err = db.Create(&messageModel{ID: 1, Topic: "cluster", UUID: "first"}).Error
+	require.NoError(t, err)
+
+	receive(t, messages).Ack()
+
+	// a transaction with a higher ID commits before a concurrent one with a lower ID
+	err = db.Create(&messageModel{ID: 3, Topic: "cluster", UUID: "third"}).Error
+	require.NoError(t, err)
+
+	select {
+	case msg := <-messages:
+		t.Fatalf("unexpected message: %s", msg.UUID)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	err = db.Create(&messageModel{ID: 2, Topic: "cluster", UUID: "second"}).Error
+	require.NoError(t, err)
+
+	msg := receive(t, messages)
+	assert.Equal(t, "second", msg.UUID)
+	msg.Ack()
+
+	msg = receive(t, messages)
+	assert.Equal(t, "third", msg.UUID)
+	msg.Ack()
+
+	// gaps are skipped once the visibility timeout passes
+	err = db.Create(&messageModel{ID: 5, Topic: "cluster", UUID: "fifth", CreatedAt: time.Now().Add(-2 * time.Hour)}).Error
+	require.NoError(t, err)
+
+	msg = receive(t, messages)
+	assert.Equal(t, "fifth", msg.UUID)
+	msg.Ack()
+}
@@ -40,6 +40,8 @@ type NodePool struct {
 	ProviderConfig Config            `yaml:"providerConfig" gorm:"column:provider_config;type:text"`
 	Labels         map[string]string `yaml:"labels" gorm:"-"`
 	Autoscaling    bool              `yaml:"autoscaling" gorm:"default:false"`
+	Taints         Taints            `yaml:"taints" gorm:"type:text"`
+	StartupTaints  Taints            `yaml:"startupTaints" gorm:"type:text"`
 }
 
 // TableName changes the default table name.
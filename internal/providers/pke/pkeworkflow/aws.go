@@ -18,8 +18,11 @@ import (
 	"emperror.dev/errors"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
 
+	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/pkg/providers/amazon"
+	"github.com/banzaicloud/pipeline/pkg/providers/amazon/autoscaling"
 )
 
 const PKECloudFormationTemplateBasePath = "templates/pke"
@@ -80,3 +83,32 @@ func (f *AWSClientFactory) New(organizationID uint, secretID string, region stri
 		Credentials: awsCred,
 	})
 }
+
+// setAutoscalerTaints advertises the node pool taints (key=value:effect) to the
+// cluster autoscaler through the node template tags of the node pool's ASG.
+func setAutoscalerTaints(sess *session.Session, stackName string, taints []string) error {
+	nodeTemplateTaints := make(map[string]string, len(taints))
+	for _, item := range taints {
+		taint, err := cluster.ParseNodePoolTaint(item)
+		if err != nil {
+			return err
+		}
+
+		nodeTemplateTaints[taint.Key] = taint.Value + ":" + taint.Effect
+	}
+
+	output, err := cloudformation.New(sess).DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		LogicalResourceId: aws.String("AutoScalingGroup"),
+		StackName:         aws.String(stackName),
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not get ASG", "stackName", stackName)
+	}
+
+	asGroup, err := autoscaling.NewManager(sess).GetAutoscalingGroupByID(aws.StringValue(output.StackResourceDetail.PhysicalResourceId))
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not get ASG", "stackName", stackName)
+	}
+
+	return asGroup.SetNodeTemplateTaints(nodeTemplateTaints)
+}
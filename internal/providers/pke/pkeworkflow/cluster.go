@@ -61,4 +61,5 @@ type NodePool struct {
 	VolumeSize        int
 	SpotPrice         string
 	Subnets           []string
+	Taints            []string
 }
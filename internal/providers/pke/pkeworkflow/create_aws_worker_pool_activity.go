@@ -220,6 +220,15 @@ func (a *CreateWorkerPoolActivity) Execute(ctx context.Context, input CreateWork
 		return "", errors.WrapIf(pkgCloudformation.NewAwsStackFailure(err, stackName, "", cfClient), "waiting for stack creation")
 	}
 
+	// Note: startup taints are expected to be removed once the node is ready,
+	// so only the regular taints are advertised to the cluster autoscaler.
+	if len(input.Pool.Taints) > 0 {
+		err = setAutoscalerTaints(client, stackName, input.Pool.Taints)
+		if err != nil {
+			return "", errors.WrapIff(err, "could not set autoscaler taints of node pool %q", input.Pool.Name)
+		}
+	}
+
 	if output.StackId != nil {
 		return *output.StackId, nil
 	}
@@ -88,6 +88,7 @@ func (c *Cluster) GetNodePools() []pkeworkflow.NodePool {
 			VolumeSize:        np.VolumeSize,
 			SpotPrice:         np.SpotPrice,
 			Subnets:           np.Subnets,
+			Taints:            np.Taints,
 		}
 	}
 	return nodePools
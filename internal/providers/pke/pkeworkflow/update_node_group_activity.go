@@ -117,9 +117,12 @@ func (a UpdateNodeGroupActivity) Execute(ctx context.Context, input UpdateNodeGr
 		return UpdateNodeGroupActivityOutput{}, err
 	}
 
+	var taints []string
 	for _, np := range cluster.GetNodePools() {
 		if input.NodePoolName == np.Name && np.Master {
 			return UpdateNodeGroupActivityOutput{}, errors.New("updating master node pool is not supported with this activity")
+		} else if input.NodePoolName == np.Name {
+			taints = np.Taints
 		}
 	}
 
@@ -252,6 +255,12 @@ func (a UpdateNodeGroupActivity) Execute(ctx context.Context, input UpdateNodeGr
 		TemplateBody:       aws.String(template),
 	}
 
+	// Note: the stored taints may have been changed by the node pool update.
+	err = setAutoscalerTaints(sess, input.StackName, taints)
+	if err != nil {
+		return UpdateNodeGroupActivityOutput{}, errors.WrapIff(err, "could not set autoscaler taints of node pool %q", input.NodePoolName)
+	}
+
 	_, err = cloudformationClient.UpdateStack(updateStackInput)
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationError" && strings.HasPrefix(awsErr.Message(), awsNoUpdatesError) {
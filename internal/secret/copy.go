@@ -0,0 +1,50 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// CopySecrets copies every secret of the given organizations from one store to another.
+//
+// Secrets already present in the destination store are overwritten, so copying can safely be repeated.
+// It returns the number of copied secrets.
+func CopySecrets(ctx context.Context, source Store, destination Store, organizationIDs []uint) (int, error) {
+	var copied int
+
+	for _, organizationID := range organizationIDs {
+		models, err := source.List(ctx, organizationID)
+		if err != nil {
+			return copied, errors.WrapIfWithDetails(err, "failed to list secrets", "organizationId", organizationID)
+		}
+
+		for _, model := range models {
+			if err := destination.Put(ctx, organizationID, model); err != nil {
+				return copied, errors.WrapIfWithDetails(
+					err, "failed to copy secret",
+					"organizationId", organizationID,
+					"secretId", model.ID,
+				)
+			}
+
+			copied++
+		}
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inmemoryStore struct {
+	secrets map[uint]map[string]Model
+}
+
+func newInmemoryStore() *inmemoryStore {
+	return &inmemoryStore{secrets: map[uint]map[string]Model{}}
+}
+
+func (s *inmemoryStore) Create(ctx context.Context, organizationID uint, model Model) error {
+	if _, ok := s.secrets[organizationID][model.ID]; ok {
+		return AlreadyExistsError{OrganizationID: organizationID, SecretID: model.ID}
+	}
+
+	return s.Put(ctx, organizationID, model)
+}
+
+func (s *inmemoryStore) Put(_ context.Context, organizationID uint, model Model) error {
+	if s.secrets[organizationID] == nil {
+		s.secrets[organizationID] = map[string]Model{}
+	}
+
+	s.secrets[organizationID][model.ID] = model
+
+	return nil
+}
+
+func (s *inmemoryStore) Get(_ context.Context, organizationID uint, id string) (Model, error) {
+	model, ok := s.secrets[organizationID][id]
+	if !ok {
+		return Model{}, NotFoundError{OrganizationID: organizationID, SecretID: id}
+	}
+
+	return model, nil
+}
+
+func (s *inmemoryStore) List(_ context.Context, organizationID uint) ([]Model, error) {
+	models := make([]Model, 0, len(s.secrets[organizationID]))
+	for _, model := range s.secrets[organizationID] {
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+func (s *inmemoryStore) Delete(_ context.Context, organizationID uint, id string) error {
+	delete(s.secrets[organizationID], id)
+
+	return nil
+}
+
+func TestCopySecrets(t *testing.T) {
+	ctx := context.Background()
+
+	source := newInmemoryStore()
+	require.NoError(t, source.Create(ctx, 1, Model{ID: "a", Name: "a", Type: "password", Values: map[string]string{"password": "a"}}))
+	require.NoError(t, source.Create(ctx, 1, Model{ID: "b", Name: "b", Type: "password", Values: map[string]string{"password": "b"}}))
+	require.NoError(t, source.Create(ctx, 2, Model{ID: "c", Name: "c", Type: "password", Values: map[string]string{"password": "c"}}))
+	require.NoError(t, source.Create(ctx, 3, Model{ID: "d", Name: "d", Type: "password", Values: map[string]string{"password": "d"}}))
+
+	destination := newInmemoryStore()
+	require.NoError(t, destination.Create(ctx, 1, Model{ID: "a", Name: "a", Type: "password", Values: map[string]string{"password": "old"}}))
+
+	copied, err := CopySecrets(ctx, source, destination, []uint{1, 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, copied)
+	assert.Equal(t, source.secrets[1], destination.secrets[1])
+	assert.Equal(t, source.secrets[2], destination.secrets[2])
+	assert.Empty(t, destination.secrets[3])
+
+	// Copying is repeatable
+	copied, err = CopySecrets(ctx, source, destination, []uint{1, 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, copied)
+}
@@ -26,7 +26,12 @@ var GlobalSecretStore *restrictedSecretStore
 
 // InitSecretStore initializes the global secret store.
 func InitSecretStore(store secretStore) {
-	GlobalSecretStore = &restrictedSecretStore{
+	GlobalSecretStore = NewSecretStore(store)
+}
+
+// NewSecretStore returns a secret store restricting access to the items of the wrapped store.
+func NewSecretStore(store secretStore) *restrictedSecretStore {
+	return &restrictedSecretStore{
 		secretStore: store,
 	}
 }
@@ -40,8 +45,14 @@ type restrictedSecretStore struct {
 type secretStore interface {
 	Delete(orgID uint, secretID string) error
 	Get(orgID uint, secretID string) (*secret.SecretItemResponse, error)
+	GetByName(orgID uint, name string) (*secret.SecretItemResponse, error)
+	GetVersion(orgID uint, secretID string, version int) (*secret.SecretItemResponse, error)
 	List(orgID uint, query *secret.ListSecretsQuery) ([]*secret.SecretItemResponse, error)
+	ListVersions(orgID uint, secretID string) ([]*secret.SecretVersionResponse, error)
+	Rollback(orgID uint, secretID string, version int, updatedBy string) error
+	SoftDelete(orgID uint, secretID string) error
 	Store(orgID uint, request *secret.CreateSecretRequest) (string, error)
+	Undelete(orgID uint, secretID string) error
 	Update(orgID uint, secretID string, request *secret.CreateSecretRequest) error
 	Verify(organizationID uint, secretID string) error
 }
@@ -79,6 +90,49 @@ func (s *restrictedSecretStore) Delete(organizationID uint, secretID string) err
 	return s.secretStore.Delete(organizationID, secretID)
 }
 
+func (s *restrictedSecretStore) GetVersion(organizationID uint, secretID string, version int) (*secret.SecretItemResponse, error) {
+	secretItem, err := s.secretStore.GetVersion(organizationID, secretID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := HasForbiddenTag(secretItem.Tags); err != nil {
+		return nil, err
+	}
+
+	return secretItem, nil
+}
+
+func (s *restrictedSecretStore) GetByName(organizationID uint, name string) (*secret.SecretItemResponse, error) {
+	secretItem, err := s.secretStore.GetByName(organizationID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := HasForbiddenTag(secretItem.Tags); err != nil {
+		return nil, err
+	}
+
+	return secretItem, nil
+}
+
+func (s *restrictedSecretStore) Rollback(organizationID uint, secretID string, version int, updatedBy string) error {
+	if err := s.checkBlockingTags(organizationID, secretID); err != nil {
+		return err
+	}
+
+	return s.secretStore.Rollback(organizationID, secretID, version, updatedBy)
+}
+
+// SoftDelete checks the latest version only: a secret that cannot be soft deleted cannot be undeleted either.
+func (s *restrictedSecretStore) SoftDelete(organizationID uint, secretID string) error {
+	if err := s.checkBlockingTags(organizationID, secretID); err != nil {
+		return err
+	}
+
+	return s.secretStore.SoftDelete(organizationID, secretID)
+}
+
 func (s *restrictedSecretStore) Verify(organizationID uint, secretID string) error {
 	if err := s.checkBlockingTags(organizationID, secretID); err != nil {
 		return err
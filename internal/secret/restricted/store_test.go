@@ -54,6 +54,16 @@ func TestBlockingTags(t *testing.T) {
 				t.Fatalf("readonly secret deleted..")
 			}
 
+			err = store.SoftDelete(orgID, secretID)
+			if err == nil {
+				t.Fatalf("readonly secret soft deleted..")
+			}
+
+			err = store.Rollback(orgID, secretID, 1, "banzaiuser")
+			if err == nil {
+				t.Fatalf("readonly secret rolled back..")
+			}
+
 			err = store.Update(orgID, secretID, tc.request)
 			if err == nil {
 				t.Fatalf("readonly secret updated..")
@@ -123,6 +133,34 @@ func (ss inMemorySecretStore) Get(orgID uint, secretID string) (*secret.SecretIt
 	return nil, secret.ErrSecretNotExists
 }
 
+func (ss inMemorySecretStore) GetByName(orgID uint, name string) (*secret.SecretItemResponse, error) {
+	return ss.Get(orgID, secret.GenerateSecretIDFromName(name))
+}
+
+func (ss inMemorySecretStore) GetVersion(orgID uint, secretID string, version int) (*secret.SecretItemResponse, error) {
+	if version != 1 {
+		return nil, secret.ErrSecretNotExists
+	}
+
+	return ss.Get(orgID, secretID)
+}
+
+func (ss inMemorySecretStore) ListVersions(orgID uint, secretID string) ([]*secret.SecretVersionResponse, error) {
+	panic("implement me")
+}
+
+func (ss inMemorySecretStore) Rollback(orgID uint, secretID string, version int, updatedBy string) error {
+	panic("implement me")
+}
+
+func (ss inMemorySecretStore) SoftDelete(orgID uint, secretID string) error {
+	return ss.Delete(orgID, secretID)
+}
+
+func (ss inMemorySecretStore) Undelete(orgID uint, secretID string) error {
+	panic("implement me")
+}
+
 func (ss inMemorySecretStore) List(orgID uint, query *secret.ListSecretsQuery) ([]*secret.SecretItemResponse, error) {
 	var list []*secret.SecretItemResponse
 	for _, os := range ss.secrets {
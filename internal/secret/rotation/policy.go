@@ -0,0 +1,101 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotation
+
+import (
+	"context"
+	"time"
+)
+
+// Policy describes how often a secret is rotated.
+type Policy struct {
+	OrganizationID uint
+	SecretID       string
+
+	// Interval is the time elapsing between two rotations.
+	Interval time.Duration
+
+	// NotifyBefore is the time before a rotation when subscribers are notified about it (turned off when zero).
+	NotifyBefore time.Duration
+
+	LastRotatedAt time.Time
+
+	// Notified is set when the subscribers have been notified about the next rotation.
+	Notified bool
+}
+
+// NextRotation returns the time of the next rotation.
+func (p Policy) NextRotation() time.Time {
+	return p.LastRotatedAt.Add(p.Interval)
+}
+
+// RotationDue tells whether the secret should be rotated.
+func (p Policy) RotationDue(now time.Time) bool {
+	return !now.Before(p.NextRotation())
+}
+
+// NotificationDue tells whether the subscribers should be notified about the next rotation.
+func (p Policy) NotificationDue(now time.Time) bool {
+	if p.NotifyBefore <= 0 || p.Notified {
+		return false
+	}
+
+	return !now.Before(p.NextRotation().Add(-p.NotifyBefore))
+}
+
+// PolicyNotFoundError is returned when a secret has no rotation policy.
+type PolicyNotFoundError struct {
+	OrganizationID uint
+	SecretID       string
+}
+
+// Error implements the error interface.
+func (PolicyNotFoundError) Error() string {
+	return "secret rotation policy not found"
+}
+
+// Details returns error details.
+func (e PolicyNotFoundError) Details() []interface{} {
+	return []interface{}{"organizationId", e.OrganizationID, "secretId", e.SecretID}
+}
+
+// NotFound tells a consumer that this error is related to a resource being not found.
+// Can be used to translate the error to the consumer's response format (eg. status codes).
+func (PolicyNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the consumer that this is a business error and it should be returned to the client.
+// Non-service errors are usually translated into "internal" errors.
+func (PolicyNotFoundError) ServiceError() bool {
+	return true
+}
+
+// Store persists secret rotation policies.
+type Store interface {
+	// Get retrieves the rotation policy of a secret.
+	//
+	// Returns a PolicyNotFoundError if the secret has no rotation policy.
+	Get(ctx context.Context, organizationID uint, secretID string) (Policy, error)
+
+	// List retrieves the rotation policies of every organization.
+	List(ctx context.Context) ([]Policy, error)
+
+	// Save creates or updates the rotation policy of a secret.
+	Save(ctx context.Context, policy Policy) error
+
+	// Delete deletes the rotation policy of a secret.
+	Delete(ctx context.Context, organizationID uint, secretID string) error
+}
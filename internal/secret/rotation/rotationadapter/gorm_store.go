@@ -0,0 +1,153 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotationadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+)
+
+// TableName constants
+const (
+	policyTableName = "secret_rotation_policies"
+)
+
+// policyModel is the persisted form of a rotation policy.
+type policyModel struct {
+	ID             uint `gorm:"primary_key"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint   `gorm:"unique_index:idx_secret_rotation_policies_org_secret"`
+	SecretID       string `gorm:"unique_index:idx_secret_rotation_policies_org_secret"`
+	// Interval is a reserved word in MySQL
+	RotationInterval time.Duration
+	NotifyBefore     time.Duration
+	LastRotatedAt    time.Time
+	Notified         bool
+}
+
+// TableName changes the default table name.
+func (policyModel) TableName() string {
+	return policyTableName
+}
+
+// Migrate executes the table migrations for the secret rotation module.
+func Migrate(db *gorm.DB, logger rotation.Logger) error {
+	tables := []interface{}{
+		&policyModel{},
+	}
+
+	logger.Info("migrating model tables", map[string]interface{}{"table_names": policyTableName})
+
+	return db.AutoMigrate(tables...).Error
+}
+
+// GormStore persists rotation policies with Gorm.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new GormStore.
+func NewGormStore(db *gorm.DB) GormStore {
+	return GormStore{
+		db: db,
+	}
+}
+
+// Get retrieves the rotation policy of a secret.
+func (s GormStore) Get(_ context.Context, organizationID uint, secretID string) (rotation.Policy, error) {
+	var model policyModel
+
+	err := s.db.Where(policyModel{OrganizationID: organizationID, SecretID: secretID}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return rotation.Policy{}, errors.WithStack(rotation.PolicyNotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+		})
+	} else if err != nil {
+		return rotation.Policy{}, errors.WrapIfWithDetails(err, "failed to get secret rotation policy",
+			"organizationId", organizationID, "secretId", secretID)
+	}
+
+	return toPolicy(model), nil
+}
+
+// List retrieves the rotation policies of every organization.
+func (s GormStore) List(_ context.Context) ([]rotation.Policy, error) {
+	var models []policyModel
+
+	if err := s.db.Order("organization_id, secret_id").Find(&models).Error; err != nil {
+		return nil, errors.WrapIf(err, "failed to list secret rotation policies")
+	}
+
+	policies := make([]rotation.Policy, 0, len(models))
+	for _, model := range models {
+		policies = append(policies, toPolicy(model))
+	}
+
+	return policies, nil
+}
+
+// Save creates or updates the rotation policy of a secret.
+func (s GormStore) Save(_ context.Context, policy rotation.Policy) error {
+	model := policyModel{
+		OrganizationID: policy.OrganizationID,
+		SecretID:       policy.SecretID,
+	}
+
+	if err := s.db.Where(model).FirstOrInit(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get secret rotation policy",
+			"organizationId", policy.OrganizationID, "secretId", policy.SecretID)
+	}
+
+	model.RotationInterval = policy.Interval
+	model.NotifyBefore = policy.NotifyBefore
+	model.LastRotatedAt = policy.LastRotatedAt
+	model.Notified = policy.Notified
+
+	if err := s.db.Save(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save secret rotation policy",
+			"organizationId", policy.OrganizationID, "secretId", policy.SecretID)
+	}
+
+	return nil
+}
+
+// Delete deletes the rotation policy of a secret.
+func (s GormStore) Delete(_ context.Context, organizationID uint, secretID string) error {
+	err := s.db.Where(policyModel{OrganizationID: organizationID, SecretID: secretID}).Delete(policyModel{}).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to delete secret rotation policy",
+			"organizationId", organizationID, "secretId", secretID)
+	}
+
+	return nil
+}
+
+func toPolicy(model policyModel) rotation.Policy {
+	return rotation.Policy{
+		OrganizationID: model.OrganizationID,
+		SecretID:       model.SecretID,
+		Interval:       model.RotationInterval,
+		NotifyBefore:   model.NotifyBefore,
+		LastRotatedAt:  model.LastRotatedAt,
+		Notified:       model.Notified,
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotationadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" //  SQLite driver used for integration test
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, rotation.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewGormStore(setUpDatabase(t))
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := store.Get(ctx, 1, "secret")
+
+		assert.True(t, errors.As(err, &rotation.PolicyNotFoundError{}))
+	})
+
+	lastRotatedAt := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("Save", func(t *testing.T) {
+		err := store.Save(ctx, rotation.Policy{
+			OrganizationID: 1,
+			SecretID:       "secret",
+			Interval:       24 * time.Hour,
+			NotifyBefore:   time.Hour,
+			LastRotatedAt:  lastRotatedAt,
+		})
+		require.NoError(t, err)
+
+		err = store.Save(ctx, rotation.Policy{
+			OrganizationID: 1,
+			SecretID:       "secret",
+			Interval:       48 * time.Hour,
+			NotifyBefore:   time.Hour,
+			LastRotatedAt:  lastRotatedAt,
+			Notified:       true,
+		})
+		require.NoError(t, err)
+
+		policy, err := store.Get(ctx, 1, "secret")
+		require.NoError(t, err)
+
+		assert.Equal(t, 48*time.Hour, policy.Interval)
+		assert.Equal(t, time.Hour, policy.NotifyBefore)
+		assert.True(t, policy.LastRotatedAt.Equal(lastRotatedAt))
+		assert.True(t, policy.Notified)
+
+		policies, err := store.List(ctx)
+		require.NoError(t, err)
+
+		assert.Len(t, policies, 1)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := store.Delete(ctx, 1, "secret")
+		require.NoError(t, err)
+
+		_, err = store.Get(ctx, 1, "secret")
+		assert.True(t, errors.As(err, &rotation.PolicyNotFoundError{}))
+	})
+}
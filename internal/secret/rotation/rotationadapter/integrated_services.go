@@ -0,0 +1,139 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotationadapter
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/secret/usage"
+	"github.com/banzaicloud/pipeline/src/cluster"
+)
+
+// Cluster identifies a cluster the integrated services of which may depend on a secret.
+type Cluster struct {
+	ID  uint
+	UID string
+}
+
+// ClusterLister lists the clusters of an organization.
+type ClusterLister interface {
+	// ListClusters returns the clusters of an organization.
+	ListClusters(ctx context.Context, organizationID uint) ([]Cluster, error)
+}
+
+// ClusterManagerAdapter lists clusters using the cluster manager.
+type ClusterManagerAdapter struct {
+	clusterManager *cluster.Manager
+}
+
+// NewClusterManagerAdapter returns a new ClusterManagerAdapter.
+func NewClusterManagerAdapter(clusterManager *cluster.Manager) ClusterManagerAdapter {
+	return ClusterManagerAdapter{
+		clusterManager: clusterManager,
+	}
+}
+
+// ListClusters returns the clusters of an organization.
+func (a ClusterManagerAdapter) ListClusters(ctx context.Context, organizationID uint) ([]Cluster, error) {
+	commonClusters, err := a.clusterManager.GetClusters(ctx, organizationID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list clusters", "organizationId", organizationID)
+	}
+
+	clusters := make([]Cluster, 0, len(commonClusters))
+	for _, commonCluster := range commonClusters {
+		clusters = append(clusters, Cluster{
+			ID:  commonCluster.GetID(),
+			UID: commonCluster.GetUID(),
+		})
+	}
+
+	return clusters, nil
+}
+
+// IntegratedServiceRedeployer re-applies the integrated services depending on a rotated secret.
+//
+// An integrated service depends on a secret when its spec refers to the secret ID
+// or when the secret belongs to the cluster of the integrated service (eg. generated Grafana credentials).
+type IntegratedServiceRedeployer struct {
+	clusters   ClusterLister
+	repository integratedservices.IntegratedServiceRepository
+	dispatcher integratedservices.IntegratedServiceOperationDispatcher
+}
+
+// NewIntegratedServiceRedeployer returns a new IntegratedServiceRedeployer.
+func NewIntegratedServiceRedeployer(
+	clusters ClusterLister,
+	repository integratedservices.IntegratedServiceRepository,
+	dispatcher integratedservices.IntegratedServiceOperationDispatcher,
+) IntegratedServiceRedeployer {
+	return IntegratedServiceRedeployer{
+		clusters:   clusters,
+		repository: repository,
+		dispatcher: dispatcher,
+	}
+}
+
+// SecretRotated re-applies the integrated services depending on the secret.
+func (r IntegratedServiceRedeployer) SecretRotated(ctx context.Context, organizationID uint, secretID string, tags []string) error {
+	clusters, err := r.clusters.ListClusters(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, c := range clusters {
+		services, err := r.repository.GetIntegratedServices(ctx, c.ID)
+		if err != nil {
+			errs = append(errs, errors.WrapIfWithDetails(err, "failed to list integrated services", "clusterId", c.ID))
+
+			continue
+		}
+
+		ownedByCluster := hasTag(tags, fmt.Sprintf("clusterUID:%s", c.UID))
+
+		for _, service := range services {
+			if service.Status != integratedservices.IntegratedServiceStatusActive {
+				continue
+			}
+
+			if !ownedByCluster && !usage.RefersTo(service.Spec, secretID) {
+				continue
+			}
+
+			if err := r.dispatcher.DispatchApply(ctx, c.ID, service.Name, service.Spec); err != nil {
+				errs = append(errs, errors.WrapIfWithDetails(err, "failed to re-apply integrated service",
+					"clusterId", c.ID, "integratedService", service.Name))
+			}
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotationadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+type staticClusterLister []Cluster
+
+func (l staticClusterLister) ListClusters(_ context.Context, _ uint) ([]Cluster, error) {
+	return l, nil
+}
+
+type staticRepository struct {
+	integratedservices.IntegratedServiceRepository
+
+	services map[uint][]integratedservices.IntegratedService
+}
+
+func (r staticRepository) GetIntegratedServices(_ context.Context, clusterID uint) ([]integratedservices.IntegratedService, error) {
+	return r.services[clusterID], nil
+}
+
+type recordingDispatcher struct {
+	integratedservices.IntegratedServiceOperationDispatcher
+
+	applied []string
+}
+
+func (d *recordingDispatcher) DispatchApply(_ context.Context, _ uint, integratedServiceName string, _ integratedservices.IntegratedServiceSpec) error {
+	d.applied = append(d.applied, integratedServiceName)
+
+	return nil
+}
+
+func TestIntegratedServiceRedeployer(t *testing.T) {
+	clusters := staticClusterLister{
+		{ID: 1, UID: "uid-1"},
+		{ID: 2, UID: "uid-2"},
+	}
+
+	repository := staticRepository{services: map[uint][]integratedservices.IntegratedService{
+		1: {
+			{
+				Name:   "dns",
+				Spec:   map[string]interface{}{"clusterDomain": "example.com", "provider": map[string]interface{}{"secretId": "secret"}},
+				Status: integratedservices.IntegratedServiceStatusActive,
+			},
+			{
+				Name:   "logging",
+				Spec:   map[string]interface{}{"outputs": []interface{}{map[string]interface{}{"secretId": "other"}}},
+				Status: integratedservices.IntegratedServiceStatusActive,
+			},
+			{
+				Name:   "vault",
+				Spec:   map[string]interface{}{"secretId": "secret"},
+				Status: integratedservices.IntegratedServiceStatusInactive,
+			},
+		},
+		2: {
+			{
+				Name:   "monitoring",
+				Spec:   map[string]interface{}{},
+				Status: integratedservices.IntegratedServiceStatusActive,
+			},
+		},
+	}}
+
+	t.Run("ReferencedSecret", func(t *testing.T) {
+		dispatcher := &recordingDispatcher{}
+		redeployer := NewIntegratedServiceRedeployer(clusters, repository, dispatcher)
+
+		err := redeployer.SecretRotated(context.Background(), 1, "secret", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"dns"}, dispatcher.applied)
+	})
+
+	t.Run("ClusterSecret", func(t *testing.T) {
+		dispatcher := &recordingDispatcher{}
+		redeployer := NewIntegratedServiceRedeployer(clusters, repository, dispatcher)
+
+		err := redeployer.SecretRotated(context.Background(), 1, "cluster-secret", []string{"clusterUID:uid-2"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"monitoring"}, dispatcher.applied)
+	})
+}
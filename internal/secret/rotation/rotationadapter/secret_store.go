@@ -0,0 +1,76 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotationadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+	legacysecret "github.com/banzaicloud/pipeline/src/secret"
+)
+
+// rotationUser is recorded as the author of the rotated secret versions.
+const rotationUser = "pipeline:secret-rotation"
+
+type legacySecretStore interface {
+	Get(organizationID uint, secretID string) (*legacysecret.SecretItemResponse, error)
+	Update(organizationID uint, secretID string, request *legacysecret.CreateSecretRequest) error
+}
+
+// SecretStore reads and writes secrets using the legacy secret store.
+type SecretStore struct {
+	store legacySecretStore
+}
+
+// NewSecretStore returns a new SecretStore.
+func NewSecretStore(store legacySecretStore) SecretStore {
+	return SecretStore{
+		store: store,
+	}
+}
+
+// Get retrieves a secret.
+func (s SecretStore) Get(_ context.Context, organizationID uint, secretID string) (rotation.Secret, error) {
+	item, err := s.store.Get(organizationID, secretID)
+	if errors.Is(err, legacysecret.ErrSecretNotExists) {
+		return rotation.Secret{}, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+		})
+	} else if err != nil {
+		return rotation.Secret{}, err
+	}
+
+	return rotation.Secret{
+		Name:   item.Name,
+		Type:   item.Type,
+		Values: item.Values,
+		Tags:   item.Tags,
+	}, nil
+}
+
+// Update writes a new version of a secret.
+func (s SecretStore) Update(_ context.Context, organizationID uint, secretID string, sec rotation.Secret) error {
+	return s.store.Update(organizationID, secretID, &legacysecret.CreateSecretRequest{
+		Name:      sec.Name,
+		Type:      sec.Type,
+		Values:    sec.Values,
+		Tags:      sec.Tags,
+		UpdatedBy: rotationUser,
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotationworkflow
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+// Rotator executes the actions of secret rotation policies.
+type Rotator interface {
+	// DueActions returns the rotations and notifications due at the given time.
+	DueActions(ctx context.Context, now time.Time) ([]rotation.DueAction, error)
+
+	// RotateSecret generates new values for a secret, then updates its dependents.
+	RotateSecret(ctx context.Context, organizationID uint, secretID string) (*rotation.Revocation, error)
+
+	// RevokeSecret revokes the previous values of a rotated secret.
+	RevokeSecret(ctx context.Context, revocation rotation.Revocation) error
+
+	// NotifyRotation notifies subscribers about the upcoming rotation of a secret.
+	NotifyRotation(ctx context.Context, organizationID uint, secretID string) error
+}
+
+// ListDueActionsActivityName is the name of the activity listing the due secret rotation actions.
+const ListDueActionsActivityName = "secret-list-due-rotation-actions"
+
+// ListDueActionsActivity lists the due secret rotation actions.
+type ListDueActionsActivity struct {
+	rotator Rotator
+}
+
+// NewListDueActionsActivity returns a new ListDueActionsActivity.
+func NewListDueActionsActivity(rotator Rotator) ListDueActionsActivity {
+	return ListDueActionsActivity{
+		rotator: rotator,
+	}
+}
+
+// Execute executes the activity.
+func (a ListDueActionsActivity) Execute(ctx context.Context) ([]rotation.DueAction, error) {
+	return a.rotator.DueActions(ctx, time.Now())
+}
+
+// Register registers the activity in the worker.
+func (a ListDueActionsActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: ListDueActionsActivityName})
+}
+
+// SecretActivityInput identifies the secret a rotation action is executed on.
+type SecretActivityInput struct {
+	OrganizationID uint
+	SecretID       string
+}
+
+// RotateSecretActivityName is the name of the secret rotation activity.
+const RotateSecretActivityName = "secret-rotate-secret"
+
+// RotateSecretActivity rotates a secret.
+//
+// It returns the previous values to be revoked, if any.
+type RotateSecretActivity struct {
+	rotator Rotator
+}
+
+// NewRotateSecretActivity returns a new RotateSecretActivity.
+func NewRotateSecretActivity(rotator Rotator) RotateSecretActivity {
+	return RotateSecretActivity{
+		rotator: rotator,
+	}
+}
+
+// Execute executes the activity.
+func (a RotateSecretActivity) Execute(ctx context.Context, input SecretActivityInput) (*rotation.Revocation, error) {
+	return a.rotator.RotateSecret(ctx, input.OrganizationID, input.SecretID)
+}
+
+// Register registers the activity in the worker.
+func (a RotateSecretActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: RotateSecretActivityName})
+}
+
+// NotifySecretRotationActivityName is the name of the activity notifying about an upcoming secret rotation.
+const NotifySecretRotationActivityName = "secret-notify-secret-rotation"
+
+// NotifySecretRotationActivity notifies subscribers about an upcoming secret rotation.
+type NotifySecretRotationActivity struct {
+	rotator Rotator
+}
+
+// NewNotifySecretRotationActivity returns a new NotifySecretRotationActivity.
+func NewNotifySecretRotationActivity(rotator Rotator) NotifySecretRotationActivity {
+	return NotifySecretRotationActivity{
+		rotator: rotator,
+	}
+}
+
+// Execute executes the activity.
+func (a NotifySecretRotationActivity) Execute(ctx context.Context, input SecretActivityInput) error {
+	return a.rotator.NotifyRotation(ctx, input.OrganizationID, input.SecretID)
+}
+
+// Register registers the activity in the worker.
+func (a NotifySecretRotationActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: NotifySecretRotationActivityName})
+}
+
+// RevokeSecretActivityName is the name of the activity revoking the previous values of a rotated secret.
+const RevokeSecretActivityName = "secret-revoke-secret"
+
+// RevokeSecretActivity revokes the previous values of a rotated secret.
+type RevokeSecretActivity struct {
+	rotator Rotator
+}
+
+// NewRevokeSecretActivity returns a new RevokeSecretActivity.
+func NewRevokeSecretActivity(rotator Rotator) RevokeSecretActivity {
+	return RevokeSecretActivity{
+		rotator: rotator,
+	}
+}
+
+// Execute executes the activity.
+func (a RevokeSecretActivity) Execute(ctx context.Context, input rotation.Revocation) error {
+	return a.rotator.RevokeSecret(ctx, input)
+}
+
+// Register registers the activity in the worker.
+func (a RevokeSecretActivity) Register(worker worker.Registry) {
+	worker.RegisterActivityWithOptions(a.Execute, activity.RegisterOptions{Name: RevokeSecretActivityName})
+}
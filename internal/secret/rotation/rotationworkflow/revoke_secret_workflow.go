@@ -0,0 +1,74 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotationworkflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+// RevokeSecretWorkflowName is the name of the workflow revoking the previous values of a rotated secret.
+const RevokeSecretWorkflowName = "secret-revocation"
+
+// RevokeSecretWorkflowInput is the input of the secret revocation workflow.
+type RevokeSecretWorkflowInput struct {
+	Revocation rotation.Revocation
+
+	// GracePeriod is the time the dependents of the secret have to pick up the new values.
+	GracePeriod time.Duration
+}
+
+// RevokeSecretWorkflow revokes the previous values of a rotated secret after a grace period.
+type RevokeSecretWorkflow struct{}
+
+// NewRevokeSecretWorkflow instantiates a secret revocation workflow.
+func NewRevokeSecretWorkflow() *RevokeSecretWorkflow {
+	return &RevokeSecretWorkflow{}
+}
+
+// Execute runs the workflow.
+func (w RevokeSecretWorkflow) Execute(ctx workflow.Context, input RevokeSecretWorkflowInput) error {
+	if err := workflow.Sleep(ctx, input.GracePeriod); err != nil {
+		return err
+	}
+
+	activityContext := workflow.WithActivityOptions(
+		ctx,
+		workflow.ActivityOptions{
+			ScheduleToStartTimeout: 10 * time.Minute,
+			StartToCloseTimeout:    5 * time.Minute,
+			WaitForCancellation:    true,
+			RetryPolicy: &cadence.RetryPolicy{
+				InitialInterval:          time.Minute,
+				BackoffCoefficient:       2.0,
+				MaximumInterval:          15 * time.Minute,
+				ExpirationInterval:       time.Hour,
+				NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+			},
+		},
+	)
+
+	return workflow.ExecuteActivity(activityContext, RevokeSecretActivityName, input.Revocation).Get(ctx, nil)
+}
+
+// Register registers the workflow in the worker.
+func (w RevokeSecretWorkflow) Register(worker worker.Registry) {
+	worker.RegisterWorkflowWithOptions(w.Execute, workflow.RegisterOptions{Name: RevokeSecretWorkflowName})
+}
@@ -0,0 +1,141 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotationworkflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+	"github.com/banzaicloud/pipeline/pkg/cadence/worker"
+)
+
+// RotateSecretsWorkflowName is the name of the secret rotation workflow.
+const RotateSecretsWorkflowName = "secret-rotate-secrets"
+
+// RotateSecretsWorkflow rotates the secrets the rotation policy of which is due
+// and notifies subscribers about the upcoming rotations.
+//
+// The previous values of the rotated secrets are revoked by separate workflows after a grace period.
+type RotateSecretsWorkflow struct {
+	revocationGracePeriod time.Duration
+}
+
+// NewRotateSecretsWorkflow instantiates a secret rotation workflow.
+func NewRotateSecretsWorkflow(revocationGracePeriod time.Duration) *RotateSecretsWorkflow {
+	return &RotateSecretsWorkflow{
+		revocationGracePeriod: revocationGracePeriod,
+	}
+}
+
+// Execute runs the workflow.
+func (w RotateSecretsWorkflow) Execute(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+
+	activityContext := workflow.WithActivityOptions(
+		ctx,
+		workflow.ActivityOptions{
+			ScheduleToStartTimeout: 10 * time.Minute,
+			StartToCloseTimeout:    15 * time.Minute,
+			WaitForCancellation:    true,
+			RetryPolicy: &cadence.RetryPolicy{
+				InitialInterval:          time.Minute,
+				BackoffCoefficient:       2.0,
+				ExpirationInterval:       30 * time.Minute,
+				MaximumAttempts:          3,
+				NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+			},
+		},
+	)
+
+	var actions []rotation.DueAction
+	err := workflow.ExecuteActivity(activityContext, ListDueActionsActivityName).Get(ctx, &actions)
+	if err != nil {
+		return err
+	}
+
+	futures := make([]workflow.Future, 0, len(actions))
+	for _, action := range actions {
+		input := SecretActivityInput{
+			OrganizationID: action.OrganizationID,
+			SecretID:       action.SecretID,
+		}
+
+		activityName := RotateSecretActivityName
+		if action.Action == rotation.ActionNotify {
+			activityName = NotifySecretRotationActivityName
+		}
+
+		futures = append(futures, workflow.ExecuteActivity(activityContext, activityName, input))
+	}
+
+	var revocations []rotation.Revocation
+
+	// failing to rotate a secret should not affect the other secrets
+	for i, future := range futures {
+		var revocation *rotation.Revocation
+
+		if err := future.Get(ctx, &revocation); err != nil {
+			logger.Sugar().Warnw(
+				"secret rotation action failed",
+				"organizationId", actions[i].OrganizationID,
+				"secretId", actions[i].SecretID,
+				"action", actions[i].Action,
+				"error", err.Error(),
+			)
+
+			continue
+		}
+
+		if revocation != nil {
+			revocations = append(revocations, *revocation)
+		}
+	}
+
+	// the revocation workflows outlive this workflow, so that the next cron run is not delayed by the grace period
+	for _, revocation := range revocations {
+		childContext := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			ExecutionStartToCloseTimeout: w.revocationGracePeriod + 2*time.Hour,
+			ParentClosePolicy:            client.ParentClosePolicyAbandon,
+		})
+
+		input := RevokeSecretWorkflowInput{
+			Revocation:  revocation,
+			GracePeriod: w.revocationGracePeriod,
+		}
+
+		err := workflow.ExecuteChildWorkflow(childContext, RevokeSecretWorkflowName, input).GetChildWorkflowExecution().Get(ctx, nil)
+		if err != nil {
+			logger.Sugar().Warnw(
+				"failed to start secret revocation",
+				"organizationId", revocation.OrganizationID,
+				"secretId", revocation.SecretID,
+				"error", err.Error(),
+			)
+		}
+	}
+
+	logger.Sugar().Infow("secret rotation finished", "actions", len(actions))
+
+	return nil
+}
+
+// Register registers the workflow in the worker.
+func (w RotateSecretsWorkflow) Register(worker worker.Registry) {
+	worker.RegisterWorkflowWithOptions(w.Execute, workflow.RegisterOptions{Name: RotateSecretsWorkflowName})
+}
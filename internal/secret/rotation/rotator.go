@@ -0,0 +1,402 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotation
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// Secret is the part of a secret relevant for rotation.
+type Secret struct {
+	Name   string
+	Type   string
+	Values map[string]string
+	Tags   []string
+}
+
+// SecretStore reads and writes secrets.
+type SecretStore interface {
+	// Get retrieves a secret.
+	//
+	// Returns a secret.NotFoundError if the secret does not exist.
+	Get(ctx context.Context, organizationID uint, secretID string) (Secret, error)
+
+	// Update writes a new version of a secret.
+	Update(ctx context.Context, organizationID uint, secretID string, s Secret) error
+}
+
+// Notifier notifies subscribers about secret rotations.
+type Notifier interface {
+	// SecretRotationDue notifies subscribers about an upcoming rotation.
+	SecretRotationDue(ctx context.Context, policy Policy) error
+
+	// SecretRotated notifies subscribers about a finished rotation.
+	SecretRotated(ctx context.Context, policy Policy) error
+}
+
+// DependentHandler updates the resources depending on a rotated secret.
+type DependentHandler interface {
+	// SecretRotated is called after a new version of a secret is written.
+	SecretRotated(ctx context.Context, organizationID uint, secretID string, tags []string) error
+}
+
+// DependentHandlers calls every handler in the list, collecting their errors.
+type DependentHandlers []DependentHandler
+
+// SecretRotated calls every handler in the list.
+func (h DependentHandlers) SecretRotated(ctx context.Context, organizationID uint, secretID string, tags []string) error {
+	var errs []error
+
+	for _, handler := range h {
+		errs = append(errs, handler.SecretRotated(ctx, organizationID, secretID, tags))
+	}
+
+	return errors.Combine(errs...)
+}
+
+// Service manages secret rotation policies.
+type Service interface {
+	// GetPolicy returns the rotation policy of a secret.
+	GetPolicy(ctx context.Context, organizationID uint, secretID string) (Policy, error)
+
+	// SetPolicy creates or updates the rotation policy of a secret.
+	SetPolicy(ctx context.Context, organizationID uint, secretID string, interval time.Duration, notifyBefore time.Duration) (Policy, error)
+
+	// DeletePolicy turns off the rotation of a secret.
+	DeletePolicy(ctx context.Context, organizationID uint, secretID string) error
+}
+
+// NewService returns a new Service.
+func NewService(store Store, secrets SecretStore, types secret.TypeList) Service {
+	return service{
+		store:   store,
+		secrets: secrets,
+		types:   types,
+	}
+}
+
+type service struct {
+	store   Store
+	secrets SecretStore
+	types   secret.TypeList
+}
+
+func (s service) GetPolicy(ctx context.Context, organizationID uint, secretID string) (Policy, error) {
+	return s.store.Get(ctx, organizationID, secretID)
+}
+
+func (s service) SetPolicy(ctx context.Context, organizationID uint, secretID string, interval time.Duration, notifyBefore time.Duration) (Policy, error) {
+	var violations []string
+
+	if interval <= 0 {
+		violations = append(violations, "interval must be positive")
+	}
+
+	if notifyBefore < 0 || (interval > 0 && notifyBefore >= interval) {
+		violations = append(violations, "notifyBefore must be less than interval")
+	}
+
+	if len(violations) > 0 {
+		return Policy{}, secret.NewValidationError(violations[0], violations)
+	}
+
+	sec, err := s.secrets.Get(ctx, organizationID, secretID)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	if _, ok := s.types.Type(sec.Type).(secret.RotatorType); !ok {
+		violation := "secret type does not support rotation: " + sec.Type
+
+		return Policy{}, secret.NewValidationError(violation, []string{violation})
+	}
+
+	var policyNotFoundErr PolicyNotFoundError
+
+	policy, err := s.store.Get(ctx, organizationID, secretID)
+	if errors.As(err, &policyNotFoundErr) {
+		policy = Policy{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+			LastRotatedAt:  time.Now(),
+		}
+	} else if err != nil {
+		return Policy{}, err
+	}
+
+	policy.Interval = interval
+	policy.NotifyBefore = notifyBefore
+	policy.Notified = false
+
+	if err := s.store.Save(ctx, policy); err != nil {
+		return Policy{}, err
+	}
+
+	return policy, nil
+}
+
+func (s service) DeletePolicy(ctx context.Context, organizationID uint, secretID string) error {
+	return s.store.Delete(ctx, organizationID, secretID)
+}
+
+// Action tells what to do with a secret having a rotation policy.
+type Action string
+
+// Actions
+const (
+	ActionRotate Action = "rotate"
+	ActionNotify Action = "notify"
+)
+
+// DueAction is an action to be executed on a secret.
+type DueAction struct {
+	OrganizationID uint
+	SecretID       string
+	Action         Action
+}
+
+// Revocation identifies the previous values of a rotated secret to be revoked after a grace period.
+type Revocation struct {
+	OrganizationID uint
+	SecretID       string
+
+	// PreviousValues contains the fields of the previous values which are safe to display (eg. access key IDs).
+	PreviousValues map[string]string
+}
+
+// Rotator rotates secrets according to their rotation policies.
+type Rotator struct {
+	store      Store
+	secrets    SecretStore
+	types      secret.TypeList
+	notifier   Notifier
+	dependents DependentHandler
+
+	logger       Logger
+	errorHandler ErrorHandler
+}
+
+// NewRotator returns a new Rotator.
+func NewRotator(
+	store Store,
+	secrets SecretStore,
+	types secret.TypeList,
+	notifier Notifier,
+	dependents DependentHandler,
+	logger Logger,
+	errorHandler ErrorHandler,
+) Rotator {
+	return Rotator{
+		store:        store,
+		secrets:      secrets,
+		types:        types,
+		notifier:     notifier,
+		dependents:   dependents,
+		logger:       logger,
+		errorHandler: errorHandler,
+	}
+}
+
+// DueActions returns the rotations and notifications due at the given time.
+func (r Rotator) DueActions(ctx context.Context, now time.Time) ([]DueAction, error) {
+	policies, err := r.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []DueAction
+
+	for _, policy := range policies {
+		action := DueAction{
+			OrganizationID: policy.OrganizationID,
+			SecretID:       policy.SecretID,
+		}
+
+		switch {
+		case policy.RotationDue(now):
+			action.Action = ActionRotate
+		case policy.NotificationDue(now):
+			action.Action = ActionNotify
+		default:
+			continue
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// RotateSecret generates new values for a secret, then updates its dependents.
+//
+// When the previous values of the secret have to be revoked, a Revocation is returned.
+// The rotation policy of a secret which does not exist anymore is deleted.
+func (r Rotator) RotateSecret(ctx context.Context, organizationID uint, secretID string) (*Revocation, error) {
+	logger := r.logger.WithFields(map[string]interface{}{"organizationId": organizationID, "secretId": secretID})
+
+	var policyNotFoundErr PolicyNotFoundError
+
+	policy, err := r.store.Get(ctx, organizationID, secretID)
+	if errors.As(err, &policyNotFoundErr) { // Rotation has been turned off in the meantime
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var secretNotFoundErr secret.NotFoundError
+
+	sec, err := r.secrets.Get(ctx, organizationID, secretID)
+	if errors.As(err, &secretNotFoundErr) {
+		logger.Info("secret not found, deleting rotation policy")
+
+		return nil, r.store.Delete(ctx, organizationID, secretID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	secretType := r.types.Type(sec.Type)
+
+	rotatorType, ok := secretType.(secret.RotatorType)
+	if !ok {
+		return nil, errors.NewWithDetails("secret type does not support rotation", "type", sec.Type)
+	}
+
+	values := make(map[string]string, len(sec.Values))
+	for key, value := range sec.Values {
+		values[key] = value
+	}
+
+	values, err = rotatorType.Rotate(organizationID, sec.Name, values, sec.Tags)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to rotate secret", "organizationId", organizationID, "secretId", secretID)
+	}
+
+	previousValues := sec.Values
+	sec.Values = values
+
+	if err := r.secrets.Update(ctx, organizationID, secretID, sec); err != nil {
+		err = errors.WrapIfWithDetails(err, "failed to store rotated secret", "organizationId", organizationID, "secretId", secretID)
+
+		// Do not leave the new, unused values behind
+		if revokerType, ok := secretType.(secret.RevokerType); ok {
+			if revokeErr := revokerType.Revoke(previousValues, safeValues(secretType, values)); revokeErr != nil {
+				err = errors.Append(err, errors.WrapIf(revokeErr, "failed to revoke new values"))
+			}
+		}
+
+		return nil, err
+	}
+
+	logger.Info("secret rotated")
+
+	policy.LastRotatedAt = time.Now()
+	policy.Notified = false
+
+	if err := r.store.Save(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	// The new secret version is already in place, failing dependents should not trigger another rotation
+	if err := r.notifier.SecretRotated(ctx, policy); err != nil {
+		r.errorHandler.HandleContext(ctx, err)
+	}
+
+	if err := r.dependents.SecretRotated(ctx, organizationID, secretID, sec.Tags); err != nil {
+		r.errorHandler.HandleContext(ctx, errors.WithDetails(err, "organizationId", organizationID, "secretId", secretID))
+	}
+
+	if _, ok := secretType.(secret.RevokerType); !ok {
+		return nil, nil
+	}
+
+	return &Revocation{
+		OrganizationID: organizationID,
+		SecretID:       secretID,
+		PreviousValues: safeValues(secretType, previousValues),
+	}, nil
+}
+
+// RevokeSecret revokes the previous values of a rotated secret.
+//
+// The previous values of a secret which does not exist anymore are not revoked.
+func (r Rotator) RevokeSecret(ctx context.Context, revocation Revocation) error {
+	var secretNotFoundErr secret.NotFoundError
+
+	sec, err := r.secrets.Get(ctx, revocation.OrganizationID, revocation.SecretID)
+	if errors.As(err, &secretNotFoundErr) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	revokerType, ok := r.types.Type(sec.Type).(secret.RevokerType)
+	if !ok {
+		return errors.NewWithDetails("secret type does not support revocation", "type", sec.Type)
+	}
+
+	if err := revokerType.Revoke(sec.Values, revocation.PreviousValues); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to revoke previous secret values",
+			"organizationId", revocation.OrganizationID, "secretId", revocation.SecretID)
+	}
+
+	r.logger.Info("previous secret values revoked", map[string]interface{}{
+		"organizationId": revocation.OrganizationID,
+		"secretId":       revocation.SecretID,
+	})
+
+	return nil
+}
+
+// safeValues returns the fields of the values which are safe to display according to the type definition.
+func safeValues(t secret.Type, values map[string]string) map[string]string {
+	safe := make(map[string]string)
+
+	for _, field := range t.Definition().Fields {
+		if value, ok := values[field.Name]; ok && field.IsSafeToDisplay {
+			safe[field.Name] = value
+		}
+	}
+
+	return safe
+}
+
+// NotifyRotation notifies subscribers about the upcoming rotation of a secret.
+func (r Rotator) NotifyRotation(ctx context.Context, organizationID uint, secretID string) error {
+	var policyNotFoundErr PolicyNotFoundError
+
+	policy, err := r.store.Get(ctx, organizationID, secretID)
+	if errors.As(err, &policyNotFoundErr) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !policy.NotificationDue(time.Now()) {
+		return nil
+	}
+
+	if err := r.notifier.SecretRotationDue(ctx, policy); err != nil {
+		return err
+	}
+
+	policy.Notified = true
+
+	return r.store.Save(ctx, policy)
+}
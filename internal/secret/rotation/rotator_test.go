@@ -0,0 +1,371 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+type inmemoryStore struct {
+	policies map[string]Policy
+}
+
+func (s *inmemoryStore) Get(_ context.Context, organizationID uint, secretID string) (Policy, error) {
+	policy, ok := s.policies[secretID]
+	if !ok || policy.OrganizationID != organizationID {
+		return Policy{}, PolicyNotFoundError{OrganizationID: organizationID, SecretID: secretID}
+	}
+
+	return policy, nil
+}
+
+func (s *inmemoryStore) List(_ context.Context) ([]Policy, error) {
+	var policies []Policy
+	for _, policy := range s.policies {
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+func (s *inmemoryStore) Save(_ context.Context, policy Policy) error {
+	s.policies[policy.SecretID] = policy
+
+	return nil
+}
+
+func (s *inmemoryStore) Delete(_ context.Context, _ uint, secretID string) error {
+	delete(s.policies, secretID)
+
+	return nil
+}
+
+type inmemorySecretStore struct {
+	secrets   map[string]Secret
+	updateErr error
+}
+
+func (s *inmemorySecretStore) Get(_ context.Context, organizationID uint, secretID string) (Secret, error) {
+	sec, ok := s.secrets[secretID]
+	if !ok {
+		return Secret{}, secret.NotFoundError{OrganizationID: organizationID, SecretID: secretID}
+	}
+
+	return sec, nil
+}
+
+func (s *inmemorySecretStore) Update(_ context.Context, _ uint, secretID string, sec Secret) error {
+	if s.updateErr != nil {
+		return s.updateErr
+	}
+
+	s.secrets[secretID] = sec
+
+	return nil
+}
+
+type counterType struct{}
+
+func (counterType) Name() string                       { return "counter" }
+func (counterType) Definition() secret.TypeDefinition  { return secret.TypeDefinition{} }
+func (counterType) Validate(_ map[string]string) error { return nil }
+func (counterType) Rotate(_ uint, _ string, data map[string]string, _ []string) (map[string]string, error) {
+	data["value"] += "+"
+
+	return data, nil
+}
+
+// keyType issues a new key on every rotation and keeps track of the keys not revoked yet.
+type keyType struct {
+	keys map[string]bool
+}
+
+func (keyType) Name() string { return "key" }
+func (keyType) Definition() secret.TypeDefinition {
+	return secret.TypeDefinition{
+		Fields: []secret.FieldDefinition{
+			{Name: "id", IsSafeToDisplay: true},
+			{Name: "key"},
+		},
+	}
+}
+func (keyType) Validate(_ map[string]string) error { return nil }
+func (t keyType) Rotate(_ uint, _ string, data map[string]string, _ []string) (map[string]string, error) {
+	id := data["id"] + "+"
+	t.keys[id] = true
+
+	return map[string]string{"id": id, "key": "key-" + id}, nil
+}
+func (t keyType) Revoke(data map[string]string, previous map[string]string) error {
+	if previous["id"] != data["id"] {
+		delete(t.keys, previous["id"])
+	}
+
+	return nil
+}
+
+type staticType struct{}
+
+func (staticType) Name() string                       { return "static" }
+func (staticType) Definition() secret.TypeDefinition  { return secret.TypeDefinition{} }
+func (staticType) Validate(_ map[string]string) error { return nil }
+
+type recordingNotifier struct {
+	due     []string
+	rotated []string
+}
+
+func (n *recordingNotifier) SecretRotationDue(_ context.Context, policy Policy) error {
+	n.due = append(n.due, policy.SecretID)
+
+	return nil
+}
+
+func (n *recordingNotifier) SecretRotated(_ context.Context, policy Policy) error {
+	n.rotated = append(n.rotated, policy.SecretID)
+
+	return nil
+}
+
+type recordingDependents struct {
+	secretIDs []string
+}
+
+func (d *recordingDependents) SecretRotated(_ context.Context, _ uint, secretID string, _ []string) error {
+	d.secretIDs = append(d.secretIDs, secretID)
+
+	return nil
+}
+
+func TestPolicy(t *testing.T) {
+	lastRotatedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	policy := Policy{
+		Interval:      24 * time.Hour,
+		NotifyBefore:  time.Hour,
+		LastRotatedAt: lastRotatedAt,
+	}
+
+	assert.False(t, policy.RotationDue(lastRotatedAt.Add(23*time.Hour)))
+	assert.True(t, policy.RotationDue(lastRotatedAt.Add(24*time.Hour)))
+
+	assert.False(t, policy.NotificationDue(lastRotatedAt.Add(22*time.Hour)))
+	assert.True(t, policy.NotificationDue(lastRotatedAt.Add(23*time.Hour)))
+
+	policy.Notified = true
+	assert.False(t, policy.NotificationDue(lastRotatedAt.Add(23*time.Hour)))
+}
+
+func TestService_SetPolicy(t *testing.T) {
+	store := &inmemoryStore{policies: map[string]Policy{}}
+	secrets := &inmemorySecretStore{secrets: map[string]Secret{
+		"rotatable": {Type: "counter"},
+		"static":    {Type: "static"},
+	}}
+	service := NewService(store, secrets, secret.NewTypeList([]secret.Type{counterType{}, staticType{}}))
+
+	t.Run("Valid", func(t *testing.T) {
+		policy, err := service.SetPolicy(context.Background(), 1, "rotatable", time.Hour, time.Minute)
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Hour, policy.Interval)
+		assert.Equal(t, time.Minute, policy.NotifyBefore)
+		assert.Contains(t, store.policies, "rotatable")
+	})
+
+	t.Run("InvalidInterval", func(t *testing.T) {
+		_, err := service.SetPolicy(context.Background(), 1, "rotatable", time.Hour, time.Hour)
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &secret.ValidationError{}))
+	})
+
+	t.Run("NotRotatable", func(t *testing.T) {
+		_, err := service.SetPolicy(context.Background(), 1, "static", time.Hour, 0)
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &secret.ValidationError{}))
+	})
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		_, err := service.SetPolicy(context.Background(), 1, "missing", time.Hour, 0)
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &secret.NotFoundError{}))
+	})
+}
+
+func TestRotator(t *testing.T) {
+	now := time.Now()
+
+	store := &inmemoryStore{policies: map[string]Policy{
+		"due": {
+			OrganizationID: 1,
+			SecretID:       "due",
+			Interval:       time.Hour,
+			LastRotatedAt:  now.Add(-2 * time.Hour),
+		},
+		"notify": {
+			OrganizationID: 1,
+			SecretID:       "notify",
+			Interval:       time.Hour,
+			NotifyBefore:   30 * time.Minute,
+			LastRotatedAt:  now.Add(-45 * time.Minute),
+		},
+		"later": {
+			OrganizationID: 1,
+			SecretID:       "later",
+			Interval:       time.Hour,
+			LastRotatedAt:  now,
+		},
+		"deleted": {
+			OrganizationID: 1,
+			SecretID:       "deleted",
+			Interval:       time.Hour,
+			LastRotatedAt:  now.Add(-2 * time.Hour),
+		},
+	}}
+	secrets := &inmemorySecretStore{secrets: map[string]Secret{
+		"due":    {Type: "counter", Values: map[string]string{"value": "v"}},
+		"notify": {Type: "counter", Values: map[string]string{"value": "v"}},
+		"later":  {Type: "counter", Values: map[string]string{"value": "v"}},
+	}}
+	notifier := &recordingNotifier{}
+	dependents := &recordingDependents{}
+
+	rotator := NewRotator(
+		store,
+		secrets,
+		secret.NewTypeList([]secret.Type{counterType{}}),
+		notifier,
+		dependents,
+		NoopLogger{},
+		NoopErrorHandler{},
+	)
+
+	actions, err := rotator.DueActions(context.Background(), now)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(
+		t,
+		[]DueAction{
+			{OrganizationID: 1, SecretID: "due", Action: ActionRotate},
+			{OrganizationID: 1, SecretID: "notify", Action: ActionNotify},
+			{OrganizationID: 1, SecretID: "deleted", Action: ActionRotate},
+		},
+		actions,
+	)
+
+	for _, action := range actions {
+		switch action.Action {
+		case ActionRotate:
+			var revocation *Revocation
+
+			revocation, err = rotator.RotateSecret(context.Background(), action.OrganizationID, action.SecretID)
+			assert.Nil(t, revocation)
+		case ActionNotify:
+			err = rotator.NotifyRotation(context.Background(), action.OrganizationID, action.SecretID)
+		}
+
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, "v+", secrets.secrets["due"].Values["value"])
+	assert.Equal(t, "v", secrets.secrets["notify"].Values["value"])
+	assert.False(t, store.policies["due"].RotationDue(now))
+	assert.True(t, store.policies["notify"].Notified)
+	assert.NotContains(t, store.policies, "deleted")
+
+	assert.Equal(t, []string{"due"}, notifier.rotated)
+	assert.Equal(t, []string{"notify"}, notifier.due)
+	assert.Equal(t, []string{"due"}, dependents.secretIDs)
+}
+
+func TestRotator_Revocation(t *testing.T) {
+	newRotator := func(secrets *inmemorySecretStore, keys keyType) Rotator {
+		store := &inmemoryStore{policies: map[string]Policy{
+			"key": {
+				OrganizationID: 1,
+				SecretID:       "key",
+				Interval:       time.Hour,
+				LastRotatedAt:  time.Now().Add(-2 * time.Hour),
+			},
+		}}
+
+		return NewRotator(
+			store,
+			secrets,
+			secret.NewTypeList([]secret.Type{keys}),
+			&recordingNotifier{},
+			&recordingDependents{},
+			NoopLogger{},
+			NoopErrorHandler{},
+		)
+	}
+
+	t.Run("RevokeAfterRotation", func(t *testing.T) {
+		keys := keyType{keys: map[string]bool{"k": true}}
+		secrets := &inmemorySecretStore{secrets: map[string]Secret{
+			"key": {Type: "key", Values: map[string]string{"id": "k", "key": "key-k"}},
+		}}
+		rotator := newRotator(secrets, keys)
+
+		revocation, err := rotator.RotateSecret(context.Background(), 1, "key")
+		require.NoError(t, err)
+		require.NotNil(t, revocation)
+
+		assert.Equal(t, Revocation{OrganizationID: 1, SecretID: "key", PreviousValues: map[string]string{"id": "k"}}, *revocation)
+		assert.Equal(t, map[string]bool{"k": true, "k+": true}, keys.keys)
+
+		require.NoError(t, rotator.RevokeSecret(context.Background(), *revocation))
+		assert.Equal(t, map[string]bool{"k+": true}, keys.keys)
+
+		// revoking again is a noop
+		require.NoError(t, rotator.RevokeSecret(context.Background(), *revocation))
+		assert.Equal(t, map[string]bool{"k+": true}, keys.keys)
+	})
+
+	t.Run("RevokeNewValuesWhenUpdateFails", func(t *testing.T) {
+		keys := keyType{keys: map[string]bool{"k": true}}
+		secrets := &inmemorySecretStore{
+			secrets: map[string]Secret{
+				"key": {Type: "key", Values: map[string]string{"id": "k", "key": "key-k"}},
+			},
+			updateErr: errors.New("update failed"),
+		}
+		rotator := newRotator(secrets, keys)
+
+		revocation, err := rotator.RotateSecret(context.Background(), 1, "key")
+		require.Error(t, err)
+
+		assert.Nil(t, revocation)
+		assert.Equal(t, map[string]bool{"k": true}, keys.keys)
+	})
+
+	t.Run("SecretDeleted", func(t *testing.T) {
+		rotator := newRotator(&inmemorySecretStore{secrets: map[string]Secret{}}, keyType{keys: map[string]bool{}})
+
+		err := rotator.RevokeSecret(context.Background(), Revocation{OrganizationID: 1, SecretID: "key"})
+		require.NoError(t, err)
+	})
+}
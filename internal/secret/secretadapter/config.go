@@ -0,0 +1,113 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"emperror.dev/errors"
+	"github.com/banzaicloud/bank-vaults/pkg/sdk/vault"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+)
+
+// Secret store backends
+const (
+	BackendVault      = "vault"
+	BackendDatabase   = "database"
+	BackendKubernetes = "kubernetes"
+)
+
+// Config configures the backend of the secret store.
+//
+// The backend only stores organization secrets: access tokens and PKE certificates are kept in Vault regardless of it.
+type Config struct {
+	// Backend is one of vault, database or kubernetes
+	Backend string
+
+	Vault      VaultConfig
+	Database   DatabaseConfig
+	Kubernetes KubernetesConfig
+}
+
+// VaultConfig configures the Vault secret store backend.
+type VaultConfig struct {
+	// MountPath is the path of the KV secrets engine
+	MountPath string
+}
+
+// DatabaseConfig configures the database secret store backend.
+type DatabaseConfig struct {
+	// MasterKeyFile contains the base64 encoded 256 bit master key used for encrypting data keys
+	MasterKeyFile string
+}
+
+// KubernetesConfig configures the Kubernetes secret store backend.
+type KubernetesConfig struct {
+	// Namespace is where the Kubernetes secrets are stored in the cluster running Pipeline
+	Namespace string
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	switch c.Backend {
+	case BackendVault:
+		if c.Vault.MountPath == "" {
+			return errors.New("vault secret store mount path is required")
+		}
+
+	case BackendDatabase:
+		if c.Database.MasterKeyFile == "" {
+			return errors.New("database secret store master key file is required")
+		}
+
+	case BackendKubernetes:
+		if c.Kubernetes.Namespace == "" {
+			return errors.New("kubernetes secret store namespace is required")
+		}
+
+	default:
+		return errors.NewWithDetails("unsupported secret store backend", "backend", c.Backend)
+	}
+
+	return nil
+}
+
+// NewStore returns a new secret store for the configured backend.
+func NewStore(config Config, vaultClient *vault.Client, db *gorm.DB) (secret.Store, error) {
+	switch config.Backend {
+	case BackendVault:
+		return NewVaultStore(vaultClient, config.Vault.MountPath), nil
+
+	case BackendDatabase:
+		masterKey, err := NewLocalMasterKeyFromFile(config.Database.MasterKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewDatabaseStore(db, masterKey), nil
+
+	case BackendKubernetes:
+		client, err := k8sclient.NewInClusterClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewKubernetesStore(client, config.Kubernetes.Namespace), nil
+
+	default:
+		return nil, errors.NewWithDetails("unsupported secret store backend", "backend", config.Backend)
+	}
+}
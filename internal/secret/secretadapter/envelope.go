@@ -0,0 +1,169 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// dataKeySize is the size of the generated data encryption keys (AES-256).
+const dataKeySize = 32
+
+// MasterKey encrypts and decrypts data encryption keys.
+//
+// Implementations may keep the key material locally or delegate to an external key management service.
+type MasterKey interface {
+	// EncryptKey encrypts a data encryption key.
+	EncryptKey(ctx context.Context, key []byte) ([]byte, error)
+
+	// DecryptKey decrypts a data encryption key.
+	DecryptKey(ctx context.Context, encryptedKey []byte) ([]byte, error)
+}
+
+// NewLocalMasterKey returns a MasterKey using a locally available 256 bit key.
+func NewLocalMasterKey(key []byte) (MasterKey, error) {
+	if len(key) != dataKeySize {
+		return nil, errors.NewWithDetails("master key must be 32 bytes long", "length", len(key))
+	}
+
+	return localMasterKey{key: key}, nil
+}
+
+// NewLocalMasterKeyFromFile reads a base64 encoded 256 bit key from a file.
+func NewLocalMasterKeyFromFile(path string) (MasterKey, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "failed to read master key file", "path", path)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "failed to decode master key", "path", path)
+	}
+
+	return NewLocalMasterKey(key)
+}
+
+type localMasterKey struct {
+	key []byte
+}
+
+func (k localMasterKey) EncryptKey(_ context.Context, key []byte) ([]byte, error) {
+	return encrypt(k.key, key, nil)
+}
+
+func (k localMasterKey) DecryptKey(_ context.Context, encryptedKey []byte) ([]byte, error) {
+	return decrypt(k.key, encryptedKey, nil)
+}
+
+// envelope is a piece of data encrypted with its own data key,
+// which is in turn encrypted with a master key.
+//
+// The ciphertext is bound to the additional data passed to sealEnvelope (eg. the owner of the data):
+// an envelope can only be opened with the same additional data.
+type envelope struct {
+	EncryptedKey []byte
+	Ciphertext   []byte
+}
+
+func sealEnvelope(ctx context.Context, masterKey MasterKey, plaintext []byte, additionalData []byte) (envelope, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return envelope{}, errors.Wrap(err, "failed to generate data key")
+	}
+
+	ciphertext, err := encrypt(dataKey, plaintext, additionalData)
+	if err != nil {
+		return envelope{}, err
+	}
+
+	encryptedKey, err := masterKey.EncryptKey(ctx, dataKey)
+	if err != nil {
+		return envelope{}, errors.WrapIf(err, "failed to encrypt data key")
+	}
+
+	return envelope{
+		EncryptedKey: encryptedKey,
+		Ciphertext:   ciphertext,
+	}, nil
+}
+
+func openEnvelope(ctx context.Context, masterKey MasterKey, e envelope, additionalData []byte) ([]byte, error) {
+	dataKey, err := masterKey.DecryptKey(ctx, e.EncryptedKey)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decrypt data key")
+	}
+
+	return decrypt(dataKey, e.Ciphertext, additionalData)
+}
+
+// encrypt encrypts data with AES-GCM and prepends the generated nonce to the result.
+// The additional data is authenticated, but not encrypted.
+func encrypt(key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func decrypt(key []byte, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt data")
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	return gcm, nil
+}
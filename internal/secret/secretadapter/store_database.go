@@ -0,0 +1,259 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// TableName constants
+const (
+	encryptedSecretTableName = "encrypted_secrets"
+)
+
+// encryptedSecretModel is the persisted form of an encrypted secret.
+type encryptedSecretModel struct {
+	ID             uint `gorm:"primary_key"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint   `gorm:"unique_index:idx_encrypted_secrets_org_secret"`
+	SecretID       string `gorm:"unique_index:idx_encrypted_secrets_org_secret"`
+	Version        int
+	EncryptedKey   []byte
+	Data           []byte
+}
+
+// TableName changes the default table name.
+func (encryptedSecretModel) TableName() string {
+	return encryptedSecretTableName
+}
+
+// secretPayload is the encrypted part of a secret.
+type secretPayload struct {
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Values    map[string]string `json:"values"`
+	Tags      []string          `json:"tags"`
+	UpdatedBy string            `json:"updatedBy"`
+}
+
+// Migrate executes the table migrations for the database secret store.
+func Migrate(db *gorm.DB, logger common.Logger) error {
+	tables := []interface{}{
+		&encryptedSecretModel{},
+	}
+
+	logger.Info("migrating model tables", map[string]interface{}{"table_names": encryptedSecretTableName})
+
+	return db.AutoMigrate(tables...).Error
+}
+
+// NewDatabaseStore returns a new secret store keeping secrets in a database using envelope encryption.
+func NewDatabaseStore(db *gorm.DB, masterKey MasterKey) secret.Store {
+	return databaseStore{
+		db:        db,
+		masterKey: masterKey,
+	}
+}
+
+type databaseStore struct {
+	db        *gorm.DB
+	masterKey MasterKey
+}
+
+func (s databaseStore) Create(ctx context.Context, organizationID uint, model secret.Model) error {
+	_, found, err := s.find(organizationID, model.ID)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		return secret.AlreadyExistsError{
+			OrganizationID: organizationID,
+			SecretID:       model.ID,
+		}
+	}
+
+	return s.save(ctx, organizationID, encryptedSecretModel{}, model)
+}
+
+func (s databaseStore) Put(ctx context.Context, organizationID uint, model secret.Model) error {
+	existing, _, err := s.find(organizationID, model.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.save(ctx, organizationID, existing, model)
+}
+
+func (s databaseStore) Get(ctx context.Context, organizationID uint, id string) (secret.Model, error) {
+	existing, found, err := s.find(organizationID, id)
+	if err != nil {
+		return secret.Model{}, err
+	}
+
+	if !found {
+		return secret.Model{}, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+		})
+	}
+
+	return s.decode(ctx, existing)
+}
+
+func (s databaseStore) List(ctx context.Context, organizationID uint) ([]secret.Model, error) {
+	var secretModels []encryptedSecretModel
+
+	err := s.db.Where(encryptedSecretModel{OrganizationID: organizationID}).Order("secret_id").Find(&secretModels).Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "failed to list secrets", "organizationId", organizationID)
+	}
+
+	models := make([]secret.Model, 0, len(secretModels))
+
+	for _, secretModel := range secretModels {
+		model, err := s.decode(ctx, secretModel)
+		if err != nil {
+			return nil, errors.WithDetails(
+				err,
+				"organizationId", organizationID,
+				"secretId", secretModel.SecretID,
+			)
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+func (s databaseStore) Delete(_ context.Context, organizationID uint, id string) error {
+	err := s.db.Where(encryptedSecretModel{OrganizationID: organizationID, SecretID: id}).Delete(&encryptedSecretModel{}).Error
+	if err != nil {
+		return errors.WrapWithDetails(
+			err, "failed to delete secret",
+			"organizationId", organizationID,
+			"secretId", id,
+		)
+	}
+
+	return nil
+}
+
+func (s databaseStore) find(organizationID uint, id string) (encryptedSecretModel, bool, error) {
+	var secretModel encryptedSecretModel
+
+	err := s.db.Where(encryptedSecretModel{OrganizationID: organizationID, SecretID: id}).First(&secretModel).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return encryptedSecretModel{}, false, nil
+	} else if err != nil {
+		return encryptedSecretModel{}, false, errors.WrapWithDetails(
+			err, "failed to read secret",
+			"organizationId", organizationID,
+			"secretId", id,
+		)
+	}
+
+	return secretModel, true, nil
+}
+
+func (s databaseStore) save(ctx context.Context, organizationID uint, secretModel encryptedSecretModel, model secret.Model) error {
+	tags := append([]string{}, model.Tags...)
+	sort.Strings(tags)
+
+	payload, err := json.Marshal(secretPayload{
+		Name:      model.Name,
+		Type:      model.Type,
+		Values:    model.Values,
+		Tags:      tags,
+		UpdatedBy: model.UpdatedBy,
+	})
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to encode secret", "secretId", model.ID)
+	}
+
+	e, err := sealEnvelope(ctx, s.masterKey, payload, secretAdditionalData(organizationID, model.ID))
+	if err != nil {
+		return errors.WithDetails(err, "secretId", model.ID)
+	}
+
+	secretModel.OrganizationID = organizationID
+	secretModel.SecretID = model.ID
+	secretModel.Version++
+	secretModel.EncryptedKey = e.EncryptedKey
+	secretModel.Data = e.Ciphertext
+
+	if err := s.db.Save(&secretModel).Error; err != nil {
+		return errors.WrapWithDetails(
+			err, "failed to store secret",
+			"organizationId", organizationID,
+			"secretId", model.ID,
+		)
+	}
+
+	return nil
+}
+
+func (s databaseStore) decode(ctx context.Context, secretModel encryptedSecretModel) (secret.Model, error) {
+	data, err := openEnvelope(
+		ctx,
+		s.masterKey,
+		envelope{
+			EncryptedKey: secretModel.EncryptedKey,
+			Ciphertext:   secretModel.Data,
+		},
+		secretAdditionalData(secretModel.OrganizationID, secretModel.SecretID),
+	)
+	if err != nil {
+		return secret.Model{}, err
+	}
+
+	var payload secretPayload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return secret.Model{}, errors.Wrap(err, "failed to parse secret")
+	}
+
+	if payload.Tags == nil {
+		payload.Tags = []string{}
+	}
+
+	return secret.Model{
+		ID:        secretModel.SecretID,
+		Name:      payload.Name,
+		Type:      payload.Type,
+		Values:    payload.Values,
+		Tags:      payload.Tags,
+		Version:   secretModel.Version,
+		UpdatedAt: secretModel.UpdatedAt,
+		UpdatedBy: payload.UpdatedBy,
+	}, nil
+}
+
+// secretAdditionalData binds the encrypted data to the secret, so that it cannot be moved to another secret or organization.
+func secretAdditionalData(organizationID uint, secretID string) []byte {
+	return []byte(fmt.Sprintf("%d/%s", organizationID, secretID))
+}
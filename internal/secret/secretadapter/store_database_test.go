@@ -0,0 +1,198 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" //  SQLite driver used for integration test
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+func newTestMasterKey(t *testing.T) MasterKey {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	masterKey, err := NewLocalMasterKey(key)
+	require.NoError(t, err)
+
+	return masterKey
+}
+
+func TestNewLocalMasterKeyFromFile(t *testing.T) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "master.key")
+	require.NoError(t, ioutil.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+
+	masterKey, err := NewLocalMasterKeyFromFile(path)
+	require.NoError(t, err)
+
+	encryptedKey, err := masterKey.EncryptKey(context.Background(), []byte("data key"))
+	require.NoError(t, err)
+
+	dataKey, err := masterKey.DecryptKey(context.Background(), encryptedKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("data key"), dataKey)
+
+	_, err = NewLocalMasterKey([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestEnvelope(t *testing.T) {
+	ctx := context.Background()
+	masterKey := newTestMasterKey(t)
+
+	e, err := sealEnvelope(ctx, masterKey, []byte("plaintext"), []byte("1/secret"))
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(e.Ciphertext), "plaintext")
+
+	plaintext, err := openEnvelope(ctx, masterKey, e, []byte("1/secret"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("plaintext"), plaintext)
+
+	_, err = openEnvelope(ctx, newTestMasterKey(t), e, []byte("1/secret"))
+	assert.Error(t, err, "opening an envelope with another master key should fail")
+
+	_, err = openEnvelope(ctx, masterKey, e, []byte("2/secret"))
+	assert.Error(t, err, "opening an envelope with other additional data should fail")
+}
+
+func TestDatabaseStore(t *testing.T) {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	require.NoError(t, Migrate(db, common.NoopLogger{}))
+
+	store := NewDatabaseStore(db, newTestMasterKey(t))
+
+	testStore(t, store)
+
+	t.Run("MovedCiphertext", func(t *testing.T) {
+		ctx := context.Background()
+
+		require.NoError(t, store.Put(ctx, 1, secret.Model{ID: "moved", Name: "moved", Type: "password"}))
+
+		// Move the encrypted data of a secret to another organization
+		err := db.Model(&encryptedSecretModel{}).
+			Where(encryptedSecretModel{OrganizationID: 1, SecretID: "moved"}).
+			Update("organization_id", 3).Error
+		require.NoError(t, err)
+
+		_, err = store.Get(ctx, 3, "moved")
+		assert.Error(t, err, "reading the data of another secret should fail")
+	})
+}
+
+func testStore(t *testing.T, store secret.Store) {
+	ctx := context.Background()
+
+	model := secret.Model{
+		ID:   "secret",
+		Name: "secret",
+		Type: "password",
+		Values: map[string]string{
+			"username": "user",
+			"password": "pass",
+		},
+		Tags:      []string{"tag2", "tag1"},
+		UpdatedBy: "user",
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := store.Get(ctx, 1, "secret")
+
+		var notFoundErr secret.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		require.NoError(t, store.Create(ctx, 1, model))
+
+		actual, err := store.Get(ctx, 1, "secret")
+		require.NoError(t, err)
+
+		assert.Equal(t, model.Name, actual.Name)
+		assert.Equal(t, model.Type, actual.Type)
+		assert.Equal(t, model.Values, actual.Values)
+		assert.Equal(t, []string{"tag1", "tag2"}, actual.Tags)
+		assert.Equal(t, model.UpdatedBy, actual.UpdatedBy)
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		err := store.Create(ctx, 1, model)
+
+		var alreadyExistsErr secret.AlreadyExistsError
+		assert.True(t, errors.As(err, &alreadyExistsErr))
+	})
+
+	t.Run("Put", func(t *testing.T) {
+		updated := model
+		updated.Values = map[string]string{"username": "user", "password": "new"}
+
+		require.NoError(t, store.Put(ctx, 1, updated))
+		require.NoError(t, store.Put(ctx, 2, updated))
+
+		actual, err := store.Get(ctx, 1, "secret")
+		require.NoError(t, err)
+
+		assert.Equal(t, updated.Values, actual.Values)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		other := model
+		other.ID = "other"
+		other.Name = "other"
+
+		require.NoError(t, store.Create(ctx, 1, other))
+
+		models, err := store.List(ctx, 1)
+		require.NoError(t, err)
+
+		require.Len(t, models, 2)
+		assert.Equal(t, "other", models[0].ID)
+		assert.Equal(t, "secret", models[1].ID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, 1, "secret"))
+		require.NoError(t, store.Delete(ctx, 1, "secret"))
+
+		_, err := store.Get(ctx, 1, "secret")
+
+		var notFoundErr secret.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+
+		_, err = store.Get(ctx, 2, "secret")
+		assert.NoError(t, err, "secrets of other organizations should be kept")
+	})
+}
@@ -0,0 +1,222 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// Kubernetes secret store labels and keys
+const (
+	kubernetesStoreLabel          = "pipeline.banzaicloud.io/secret-store"
+	kubernetesOrganizationIDLabel = "pipeline.banzaicloud.io/organization-id"
+	kubernetesSecretIDAnnotation  = "pipeline.banzaicloud.io/secret-id"
+	kubernetesSecretDataKey       = "secret"
+)
+
+// NewKubernetesStore returns a new secret store backed by Kubernetes secrets in the given namespace.
+func NewKubernetesStore(client kubernetes.Interface, namespace string) secret.Store {
+	return kubernetesStore{
+		client:    client,
+		namespace: namespace,
+	}
+}
+
+type kubernetesStore struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+func (s kubernetesStore) Create(ctx context.Context, organizationID uint, model secret.Model) error {
+	kubeSecret, err := s.kubernetesSecret(organizationID, model)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.CoreV1().Secrets(s.namespace).Create(ctx, kubeSecret, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		return secret.AlreadyExistsError{
+			OrganizationID: organizationID,
+			SecretID:       model.ID,
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "failed to store secret")
+	}
+
+	return nil
+}
+
+func (s kubernetesStore) Put(ctx context.Context, organizationID uint, model secret.Model) error {
+	kubeSecret, err := s.kubernetesSecret(organizationID, model)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.client.CoreV1().Secrets(s.namespace).Get(ctx, kubeSecret.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		if _, err := s.client.CoreV1().Secrets(s.namespace).Create(ctx, kubeSecret, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "failed to store secret")
+		}
+
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check if secret exists")
+	}
+
+	kubeSecret.ResourceVersion = existing.ResourceVersion
+
+	if _, err := s.client.CoreV1().Secrets(s.namespace).Update(ctx, kubeSecret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to store secret")
+	}
+
+	return nil
+}
+
+func (s kubernetesStore) Get(ctx context.Context, organizationID uint, id string) (secret.Model, error) {
+	kubeSecret, err := s.client.CoreV1().Secrets(s.namespace).Get(ctx, kubernetesSecretName(organizationID, id), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return secret.Model{}, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+		})
+	} else if err != nil {
+		return secret.Model{}, errors.Wrap(err, "failed to read secret")
+	}
+
+	return parseKubernetesSecret(*kubeSecret)
+}
+
+func (s kubernetesStore) List(ctx context.Context, organizationID uint) ([]secret.Model, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		kubernetesStoreLabel:          "true",
+		kubernetesOrganizationIDLabel: strconv.FormatUint(uint64(organizationID), 10),
+	})
+
+	kubeSecrets, err := s.client.CoreV1().Secrets(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "failed to list secrets", "organizationId", organizationID)
+	}
+
+	models := make([]secret.Model, 0, len(kubeSecrets.Items))
+
+	for _, kubeSecret := range kubeSecrets.Items {
+		model, err := parseKubernetesSecret(kubeSecret)
+		if err != nil {
+			return nil, errors.WithDetails(
+				err,
+				"organizationId", organizationID,
+				"secretId", model.ID,
+			)
+		}
+
+		models = append(models, model)
+	}
+
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+
+	return models, nil
+}
+
+func (s kubernetesStore) Delete(ctx context.Context, organizationID uint, id string) error {
+	err := s.client.CoreV1().Secrets(s.namespace).Delete(ctx, kubernetesSecretName(organizationID, id), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.WrapWithDetails(
+			err, "failed to delete secret",
+			"organizationId", organizationID,
+			"secretId", id,
+		)
+	}
+
+	return nil
+}
+
+func (s kubernetesStore) kubernetesSecret(organizationID uint, model secret.Model) (*corev1.Secret, error) {
+	tags := append([]string{}, model.Tags...)
+	sort.Strings(tags)
+
+	data, err := json.Marshal(secretPayload{
+		Name:      model.Name,
+		Type:      model.Type,
+		Values:    model.Values,
+		Tags:      tags,
+		UpdatedBy: model.UpdatedBy,
+	})
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "failed to encode secret", "secretId", model.ID)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kubernetesSecretName(organizationID, model.ID),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				kubernetesStoreLabel:          "true",
+				kubernetesOrganizationIDLabel: strconv.FormatUint(uint64(organizationID), 10),
+			},
+			Annotations: map[string]string{
+				kubernetesSecretIDAnnotation: model.ID,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			kubernetesSecretDataKey: data,
+		},
+	}, nil
+}
+
+// kubernetesSecretName returns the name of the Kubernetes secret holding a Pipeline secret.
+//
+// Secret IDs are hex encoded hashes, so they are valid as part of a resource name.
+func kubernetesSecretName(organizationID uint, id string) string {
+	return fmt.Sprintf("pipeline-secret-%d-%s", organizationID, id)
+}
+
+func parseKubernetesSecret(kubeSecret corev1.Secret) (secret.Model, error) {
+	model := secret.Model{
+		ID:        kubeSecret.Annotations[kubernetesSecretIDAnnotation],
+		UpdatedAt: kubeSecret.CreationTimestamp.Time,
+		Tags:      []string{},
+	}
+
+	var payload secretPayload
+
+	if err := json.Unmarshal(kubeSecret.Data[kubernetesSecretDataKey], &payload); err != nil {
+		return model, errors.Wrap(err, "failed to parse secret")
+	}
+
+	model.Name = payload.Name
+	model.Type = payload.Type
+	model.Values = payload.Values
+	model.UpdatedBy = payload.UpdatedBy
+
+	if payload.Tags != nil {
+		model.Tags = payload.Tags
+	}
+
+	return model, nil
+}
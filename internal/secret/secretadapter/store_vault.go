@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,7 +33,7 @@ import (
 )
 
 // NewVaultStore returns a new secret store backed by Vault.
-func NewVaultStore(client *vault.Client, mountPath string) secret.Store {
+func NewVaultStore(client *vault.Client, mountPath string) secret.VersionedStore {
 	return vaultStore{
 		client: client,
 
@@ -51,7 +52,30 @@ func (s vaultStore) Create(_ context.Context, organizationID uint, model secret.
 
 	sort.Strings(model.Tags)
 
-	data, err := secretData(0, model)
+	var version int
+	{ // A soft deleted secret can be created again on top of its previous versions
+		vaultMetadata, err := s.client.RawClient().Logical().Read(s.secretMetadataPath(organizationID, model.ID))
+		if err != nil {
+			return errors.Wrap(err, "failed to check if secret exists")
+		}
+
+		if vaultMetadata != nil {
+			if v, ok := vaultMetadata.Data["current_version"].(json.Number); ok {
+				currentVersion, _ := v.Int64()
+				version = int(currentVersion)
+			}
+
+			current := cast.ToStringMap(cast.ToStringMap(vaultMetadata.Data["versions"])[strconv.Itoa(version)])
+			if cast.ToString(current["deletion_time"]) == "" && !cast.ToBool(current["destroyed"]) {
+				return secret.AlreadyExistsError{
+					OrganizationID: organizationID,
+					SecretID:       model.ID,
+				}
+			}
+		}
+	}
+
+	data, err := secretData(version, model)
 	if err != nil {
 		return err
 	}
@@ -110,7 +134,8 @@ func (s vaultStore) Get(_ context.Context, organizationID uint, id string) (secr
 		return secret.Model{}, errors.Wrap(err, "failed to read secret")
 	}
 
-	if vaultSecret == nil {
+	// The latest version of a soft deleted secret has no data
+	if vaultSecret == nil || vaultSecret.Data["data"] == nil {
 		return secret.Model{}, errors.WithStack(secret.NotFoundError{
 			OrganizationID: organizationID,
 			SecretID:       id,
@@ -146,7 +171,7 @@ func (s vaultStore) List(_ context.Context, organizationID uint) ([]secret.Model
 			)
 		}
 
-		if vaultSecret == nil { // Secret was removed?
+		if vaultSecret == nil || vaultSecret.Data["data"] == nil { // Secret was removed?
 			continue
 		}
 
@@ -166,7 +191,7 @@ func (s vaultStore) List(_ context.Context, organizationID uint) ([]secret.Model
 }
 
 func (s vaultStore) Delete(_ context.Context, organizationID uint, id string) error {
-	path := fmt.Sprintf("%s/metadata/orgs/%d/%s", s.mountPath, organizationID, id)
+	path := s.secretMetadataPath(organizationID, id)
 
 	if _, err := s.client.RawClient().Logical().Delete(path); err != nil {
 		return errors.WrapWithDetails(
@@ -179,10 +204,112 @@ func (s vaultStore) Delete(_ context.Context, organizationID uint, id string) er
 	return nil
 }
 
+func (s vaultStore) ListVersions(_ context.Context, organizationID uint, id string) ([]secret.VersionMetadata, error) {
+	path := s.secretMetadataPath(organizationID, id)
+
+	vaultMetadata, err := s.client.RawClient().Logical().Read(path)
+	if err != nil {
+		return nil, errors.WrapWithDetails(
+			err, "failed to read secret metadata",
+			"organizationId", organizationID,
+			"secretId", id,
+		)
+	}
+
+	if vaultMetadata == nil {
+		return nil, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+		})
+	}
+
+	versions := cast.ToStringMap(vaultMetadata.Data["versions"])
+
+	result := make([]secret.VersionMetadata, 0, len(versions))
+
+	for key, value := range versions {
+		version, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, errors.WrapWithDetails(err, "failed to parse secret version", "version", key)
+		}
+
+		metadata := cast.ToStringMap(value)
+
+		createdAt, err := time.Parse(time.RFC3339, cast.ToString(metadata["created_time"]))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse creation time")
+		}
+
+		result = append(result, secret.VersionMetadata{
+			Version:   version,
+			CreatedAt: createdAt,
+			Deleted:   cast.ToString(metadata["deletion_time"]) != "",
+			Destroyed: cast.ToBool(metadata["destroyed"]),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+
+	return result, nil
+}
+
+func (s vaultStore) GetVersion(_ context.Context, organizationID uint, id string, version int) (secret.Model, error) {
+	path := s.secretDataPath(organizationID, id)
+
+	vaultSecret, err := s.client.RawClient().Logical().ReadWithData(path, map[string][]string{
+		"version": {strconv.Itoa(version)},
+	})
+	if err != nil {
+		return secret.Model{}, errors.WrapWithDetails(err, "failed to read secret version", "version", version)
+	}
+
+	if vaultSecret == nil || vaultSecret.Data["data"] == nil {
+		return secret.Model{}, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+		})
+	}
+
+	return parseSecret(id, vaultSecret)
+}
+
+func (s vaultStore) SoftDelete(_ context.Context, organizationID uint, id string) error {
+	path := s.secretDataPath(organizationID, id)
+
+	if _, err := s.client.RawClient().Logical().Delete(path); err != nil {
+		return errors.WrapWithDetails(
+			err, "failed to soft delete secret",
+			"organizationId", organizationID,
+			"secretId", id,
+		)
+	}
+
+	return nil
+}
+
+func (s vaultStore) Undelete(_ context.Context, organizationID uint, id string, version int) error {
+	path := fmt.Sprintf("%s/undelete/orgs/%d/%s", s.mountPath, organizationID, id)
+
+	if _, err := s.client.RawClient().Logical().Write(path, map[string]interface{}{"versions": []int{version}}); err != nil {
+		return errors.WrapWithDetails(
+			err, "failed to undelete secret",
+			"organizationId", organizationID,
+			"secretId", id,
+			"version", version,
+		)
+	}
+
+	return nil
+}
+
 func (s vaultStore) secretDataPath(organizationID uint, secretID string) string {
 	return fmt.Sprintf("%s/data/orgs/%d/%s", s.mountPath, organizationID, secretID)
 }
 
+func (s vaultStore) secretMetadataPath(organizationID uint, secretID string) string {
+	return fmt.Sprintf("%s/metadata/orgs/%d/%s", s.mountPath, organizationID, secretID)
+}
+
 func secretData(version int, model secret.Model) (map[string]interface{}, error) {
 	values := map[string]interface{}{}
 
@@ -205,8 +332,14 @@ func parseSecret(id string, vaultSecret *vaultapi.Secret) (secret.Model, error)
 		return secret.Model{}, errors.Wrap(err, "failed to parse update time")
 	}
 
+	var version int64
+	if v, ok := metadata["version"].(json.Number); ok {
+		version, _ = v.Int64()
+	}
+
 	model := secret.Model{
 		ID:        id,
+		Version:   int(version),
 		UpdatedAt: updatedAt,
 		Tags:      []string{},
 	}
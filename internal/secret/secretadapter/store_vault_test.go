@@ -45,7 +45,7 @@ type VaultStoreTestSuite struct {
 	client    *vault.Client
 	mountPath string
 
-	store secret.Store
+	store secret.VersionedStore
 }
 
 func (s *VaultStoreTestSuite) SetupSuite() {
@@ -237,6 +237,7 @@ func (s *VaultStoreTestSuite) TestGet() {
 			"key": "value",
 		},
 		Tags:      []string{"tag:value"},
+		Version:   1,
 		UpdatedBy: "user",
 	}
 
@@ -284,6 +285,7 @@ func (s *VaultStoreTestSuite) TestList() {
 				"key": "value",
 			},
 			Tags:      []string{"tag:value"},
+			Version:   1,
 			UpdatedBy: "user",
 		},
 	}
@@ -325,3 +327,121 @@ func (s *VaultStoreTestSuite) TestDelete_Idempotent() {
 	err := s.store.Delete(context.Background(), 1, "delete-idempotent-secret-id")
 	s.Require().NoError(err)
 }
+
+func (s *VaultStoreTestSuite) TestVersions() {
+	model := secret.Model{
+		ID:        "versioned-secret-id",
+		Name:      "versioned-secret-name",
+		Type:      "example",
+		Values:    map[string]string{"key": "value"},
+		Tags:      []string{},
+		UpdatedBy: "user",
+	}
+
+	err := s.store.Put(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	model.Values = map[string]string{"key": "value2"}
+	model.UpdatedBy = "user2"
+
+	err = s.store.Put(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	versions, err := s.store.ListVersions(context.Background(), 1, "versioned-secret-id")
+	s.Require().NoError(err)
+
+	s.Require().Len(versions, 2)
+	s.Assert().Equal(1, versions[0].Version)
+	s.Assert().Equal(2, versions[1].Version)
+
+	previous, err := s.store.GetVersion(context.Background(), 1, "versioned-secret-id", 1)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(1, previous.Version)
+	s.Assert().Equal("value", previous.Values["key"])
+	s.Assert().Equal("user", previous.UpdatedBy)
+
+	latest, err := s.store.Get(context.Background(), 1, "versioned-secret-id")
+	s.Require().NoError(err)
+
+	s.Assert().Equal(2, latest.Version)
+	s.Assert().Equal("value2", latest.Values["key"])
+}
+
+func (s *VaultStoreTestSuite) TestSoftDelete() {
+	model := secret.Model{
+		ID:        "soft-delete-secret-id",
+		Name:      "soft-delete-secret-name",
+		Type:      "example",
+		Values:    map[string]string{"key": "value"},
+		Tags:      []string{},
+		UpdatedBy: "user",
+	}
+
+	err := s.store.Put(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	err = s.store.SoftDelete(context.Background(), 1, "soft-delete-secret-id")
+	s.Require().NoError(err)
+
+	_, err = s.store.Get(context.Background(), 1, "soft-delete-secret-id")
+	s.Require().Error(err)
+
+	var notFoundErr secret.NotFoundError
+	s.Assert().True(errors.As(err, &notFoundErr))
+
+	versions, err := s.store.ListVersions(context.Background(), 1, "soft-delete-secret-id")
+	s.Require().NoError(err)
+
+	s.Require().Len(versions, 1)
+	s.Assert().True(versions[0].Deleted)
+
+	err = s.store.Undelete(context.Background(), 1, "soft-delete-secret-id", 1)
+	s.Require().NoError(err)
+
+	actual, err := s.store.Get(context.Background(), 1, "soft-delete-secret-id")
+	s.Require().NoError(err)
+
+	s.Assert().Equal("value", actual.Values["key"])
+}
+
+func (s *VaultStoreTestSuite) TestSoftDelete_Create() {
+	model := secret.Model{
+		ID:        "recreated-secret-id",
+		Name:      "recreated-secret-name",
+		Type:      "example",
+		Values:    map[string]string{"key": "value"},
+		Tags:      []string{},
+		UpdatedBy: "user",
+	}
+
+	err := s.store.Create(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	err = s.store.SoftDelete(context.Background(), 1, "recreated-secret-id")
+	s.Require().NoError(err)
+
+	model.Values = map[string]string{"key": "value2"}
+
+	err = s.store.Create(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	err = s.store.Create(context.Background(), 1, model)
+	s.Require().Error(err)
+
+	var alreadyExistsErr secret.AlreadyExistsError
+	s.Assert().True(errors.As(err, &alreadyExistsErr))
+
+	actual, err := s.store.Get(context.Background(), 1, "recreated-secret-id")
+	s.Require().NoError(err)
+
+	s.Assert().Equal(2, actual.Version)
+	s.Assert().Equal("value2", actual.Values["key"])
+
+	versions, err := s.store.ListVersions(context.Background(), 1, "recreated-secret-id")
+	s.Require().NoError(err)
+
+	s.Require().Len(versions, 2)
+	s.Assert().True(versions[0].Deleted)
+	s.Assert().False(versions[1].Deleted)
+}
@@ -82,10 +82,21 @@ type Model struct {
 	Type      string            `mapstructure:"type"`
 	Values    map[string]string `mapstructure:"values"`
 	Tags      []string          `mapstructure:"tags"`
+	Version   int               `mapstructure:"-"`
 	UpdatedAt time.Time         `mapstructure:"-"`
 	UpdatedBy string            `mapstructure:"updatedBy"`
 }
 
+// VersionMetadata describes a version of a secret.
+//
+// It is read from the metadata of the secret only: the author of a version is returned by GetVersion.
+type VersionMetadata struct {
+	Version   int
+	CreatedAt time.Time
+	Deleted   bool
+	Destroyed bool
+}
+
 // Store is a low-level interface for a key-value like secret store.
 type Store interface {
 	// Create writes a new secret in the store.
@@ -105,3 +116,22 @@ type Store interface {
 	// Delete deletes a secret from the store.
 	Delete(ctx context.Context, organizationID uint, id string) error
 }
+
+// VersionedStore is a secret store keeping the previous versions of secrets.
+type VersionedStore interface {
+	Store
+
+	// ListVersions lists every version of a secret including the deleted ones.
+	ListVersions(ctx context.Context, organizationID uint, id string) ([]VersionMetadata, error)
+
+	// GetVersion retrieves a specific version of a secret from the store.
+	//
+	// Returns a NotFoundError if the version does not exist or it is deleted.
+	GetVersion(ctx context.Context, organizationID uint, id string, version int) (Model, error)
+
+	// SoftDelete deletes the latest version of a secret, keeping it recoverable.
+	SoftDelete(ctx context.Context, organizationID uint, id string) error
+
+	// Undelete recovers a soft deleted version of a secret.
+	Undelete(ctx context.Context, organizationID uint, id string, version int) error
+}
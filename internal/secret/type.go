@@ -57,6 +57,25 @@ type GeneratorType interface {
 	Generate(organizationID uint, secretName string, data map[string]string, tags []string) (map[string]string, error)
 }
 
+// RotatorType can be implemented by a secret type that is able to replace the values of an existing secret.
+//
+// The returned values are stored as a new version of the secret, so they go through processing as well.
+type RotatorType interface {
+	// Rotate generates new values for an existing secret.
+	Rotate(organizationID uint, secretName string, data map[string]string, tags []string) (map[string]string, error)
+}
+
+// RevokerType can be implemented by a RotatorType the previous values of which stay valid after a rotation
+// until they are revoked (eg. cloud credentials).
+//
+// The previous values are revoked after a grace period, so that the dependents of the secret can pick up the new values.
+type RevokerType interface {
+	// Revoke revokes the previous values of a rotated secret using its current values.
+	//
+	// Only the fields safe to display are passed from the previous values. Revoke has to be idempotent.
+	Revoke(data map[string]string, previous map[string]string) error
+}
+
 // ProcessorType can be implemented by a secret type that adds secret processing abilities to the type.
 //
 // Secret processing is done when a secret is created or updated (eg. making sure a secret is in a specific format).
@@ -15,10 +15,13 @@
 package types
 
 import (
+	"emperror.dev/errors"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
 
 	"github.com/banzaicloud/pipeline/internal/secret"
 )
@@ -82,3 +85,68 @@ func (t AmazonType) Verify(data map[string]string) error {
 
 	return nil
 }
+
+// Rotate creates a new access key for the IAM user of the current credentials.
+//
+// The current access key is left intact: it is deleted by Revoke once the new access key is stored.
+func (t AmazonType) Rotate(_ uint, _ string, data map[string]string, _ []string) (map[string]string, error) {
+	client, err := t.iamClient(data)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.CreateAccessKey(&iam.CreateAccessKeyInput{})
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create access key")
+	}
+
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		values[key] = value
+	}
+
+	values[FieldAmazonAccessKeyId] = aws.StringValue(output.AccessKey.AccessKeyId)
+	values[FieldAmazonSecretAccessKey] = aws.StringValue(output.AccessKey.SecretAccessKey)
+
+	return values, nil
+}
+
+// Revoke deletes the previous access key of the IAM user of the current credentials.
+func (t AmazonType) Revoke(data map[string]string, previous map[string]string) error {
+	accessKeyID := previous[FieldAmazonAccessKeyId]
+	if accessKeyID == "" || accessKeyID == data[FieldAmazonAccessKeyId] {
+		return nil
+	}
+
+	client, err := t.iamClient(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteAccessKey(&iam.DeleteAccessKeyInput{
+		AccessKeyId: aws.String(accessKeyID),
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == iam.ErrCodeNoSuchEntityException {
+		return nil
+	} else if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to delete previous access key", "accessKeyId", accessKeyID)
+	}
+
+	return nil
+}
+
+func (t AmazonType) iamClient(data map[string]string) (*iam.IAM, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(
+			data[FieldAmazonAccessKeyId],
+			data[FieldAmazonSecretAccessKey],
+			"",
+		),
+		Region: aws.String(t.Region),
+	})
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create AWS session")
+	}
+
+	return iam.New(sess), nil
+}
@@ -26,6 +26,7 @@ import (
 func TestAmazonType(t *testing.T) {
 	assert.Implements(t, (*secret.Type)(nil), new(AmazonType))
 	assert.Implements(t, (*secret.VerifierType)(nil), new(AmazonType))
+	assert.Implements(t, (*secret.RotatorType)(nil), new(AmazonType))
 }
 
 func TestAmazonType_Validate(t *testing.T) {
@@ -96,6 +96,16 @@ func (t HtpasswdType) Generate(_ uint, _ string, data map[string]string, _ []str
 	return data, nil
 }
 
+// Rotate generates a new password, the htpasswd file is regenerated when the secret is processed.
+func (t HtpasswdType) Rotate(organizationID uint, secretName string, data map[string]string, tags []string) (map[string]string, error) {
+	username, ok := data[FieldHtpasswdUsername]
+	if !ok {
+		return nil, errors.Errorf("missing key: %s", FieldHtpasswdUsername)
+	}
+
+	return t.Generate(organizationID, secretName, map[string]string{FieldHtpasswdUsername: username}, tags)
+}
+
 func (t HtpasswdType) Process(data map[string]string) (map[string]string, error) {
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(data[FieldHtpasswdPassword]), bcrypt.DefaultCost)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 func TestHtpasswdType(t *testing.T) {
 	assert.Implements(t, (*secret.Type)(nil), new(HtpasswdType))
 	assert.Implements(t, (*secret.GeneratorType)(nil), new(HtpasswdType))
+	assert.Implements(t, (*secret.RotatorType)(nil), new(HtpasswdType))
 }
 
 func TestHtpasswdType_Validate(t *testing.T) {
@@ -109,6 +109,19 @@ func (t PasswordType) Generate(_ uint, _ string, data map[string]string, _ []str
 	return data, nil
 }
 
+// Rotate generates a new password with the same length as the current one.
+func (t PasswordType) Rotate(organizationID uint, secretName string, data map[string]string, tags []string) (map[string]string, error) {
+	password := defaultPasswordFormat
+	if length := len(data[FieldPasswordPassword]); length > 0 {
+		password = fmt.Sprintf("randAlphaNum,%d", length)
+	}
+
+	return t.Generate(organizationID, secretName, map[string]string{
+		FieldPasswordUsername: data[FieldPasswordUsername],
+		FieldPasswordPassword: password,
+	}, tags)
+}
+
 // passwordRandomString creates a random string whose length is the number of characters specified.
 // TODO: reuse random function (or use single, struct level password generator in the type?).
 func passwordRandomString(genType string, length int) (res string, err error) {
@@ -137,3 +137,21 @@ func (t TLSType) Generate(_ uint, _ string, data map[string]string, _ []string)
 
 	return data, nil
 }
+
+// Rotate generates new certificates for the same hosts with the same validity.
+func (t TLSType) Rotate(organizationID uint, secretName string, data map[string]string, tags []string) (map[string]string, error) {
+	hosts, ok := data[FieldTLSHosts]
+	if !ok {
+		return nil, errors.Errorf("missing key: %s", FieldTLSHosts)
+	}
+
+	input := map[string]string{
+		FieldTLSHosts: hosts,
+	}
+
+	if validity, ok := data[FieldTLSValidity]; ok {
+		input[FieldTLSValidity] = validity
+	}
+
+	return t.Generate(organizationID, secretName, input, tags)
+}
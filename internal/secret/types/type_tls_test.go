@@ -17,8 +17,10 @@ package types
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/banzaicloud/pipeline/internal/secret"
 )
@@ -26,6 +28,7 @@ import (
 func TestTLSType(t *testing.T) {
 	assert.Implements(t, (*secret.Type)(nil), new(TLSType))
 	assert.Implements(t, (*secret.GeneratorType)(nil), new(TLSType))
+	assert.Implements(t, (*secret.RotatorType)(nil), new(TLSType))
 }
 
 func TestTLSType_Validate(t *testing.T) {
@@ -191,3 +194,26 @@ func TestTLSType_ValidateNew(t *testing.T) {
 func TestTLSType_Generate(t *testing.T) {
 	// TODO
 }
+
+func TestTLSType_Rotate(t *testing.T) {
+	typ := TLSType{DefaultValidity: time.Hour}
+
+	data, err := typ.Generate(0, "secret", map[string]string{FieldTLSHosts: "localhost"}, nil)
+	require.NoError(t, err)
+
+	caCert := data[FieldTLSCACert]
+
+	values, err := typ.Rotate(0, "secret", data, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "localhost", values[FieldTLSHosts])
+	assert.NotEmpty(t, values[FieldTLSCACert])
+	assert.NotEqual(t, caCert, values[FieldTLSCACert])
+}
+
+func TestTLSType_Rotate_MissingHosts(t *testing.T) {
+	typ := TLSType{}
+
+	_, err := typ.Rotate(0, "secret", map[string]string{FieldTLSCACert: "cert"}, nil)
+	require.Error(t, err)
+}
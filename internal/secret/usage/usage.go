@@ -0,0 +1,169 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// Usage types
+const (
+	TypeCluster           = "cluster"
+	TypeHelmRepository    = "helmRepository"
+	TypeIntegratedService = "integratedService"
+	TypeBackupBucket      = "backupBucket"
+	TypeClusterGroup      = "clusterGroup"
+	TypeClusterTemplate   = "clusterTemplate"
+	TypeGitopsRepository  = "gitopsRepository"
+)
+
+// Usage is a resource referencing a secret.
+type Usage struct {
+	Type string
+	ID   uint
+	Name string
+
+	// ClusterID is set for resources belonging to a cluster
+	ClusterID uint
+}
+
+// RefersTo checks if a decoded JSON value contains the secret ID as a string value.
+func RefersTo(value interface{}, secretID string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == secretID
+
+	case map[string]interface{}:
+		for _, item := range v {
+			if RefersTo(item, secretID) {
+				return true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if RefersTo(item, secretID) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Finder finds the resources referencing a secret.
+type Finder interface {
+	FindUsages(ctx context.Context, organizationID uint, secretID string) ([]Usage, error)
+}
+
+// Finders combines the usages found by multiple finders.
+type Finders []Finder
+
+// FindUsages implements the Finder interface.
+func (f Finders) FindUsages(ctx context.Context, organizationID uint, secretID string) ([]Usage, error) {
+	var usages []Usage
+
+	for _, finder := range f {
+		u, err := finder.FindUsages(ctx, organizationID, secretID)
+		if err != nil {
+			return nil, err
+		}
+
+		usages = append(usages, u...)
+	}
+
+	return usages, nil
+}
+
+// InUseError is returned when a secret is still referenced by other resources.
+type InUseError struct {
+	OrganizationID uint
+	SecretID       string
+	Usages         []Usage
+}
+
+// Error implements the error interface.
+func (InUseError) Error() string {
+	return "secret is in use"
+}
+
+// Details returns error details.
+func (e InUseError) Details() []interface{} {
+	return []interface{}{"organizationId", e.OrganizationID, "secretId", e.SecretID, "usages", len(e.Usages)}
+}
+
+// Conflict tells the consumer that this error is related to a conflicting request.
+// Can be used to translate the error to the consumer's response format (eg. status codes).
+func (InUseError) Conflict() bool {
+	return true
+}
+
+// ServiceError tells the consumer that this is a business error and it should be returned to the client.
+// Non-service errors are usually translated into "internal" errors.
+func (InUseError) ServiceError() bool {
+	return true
+}
+
+// Service tracks the usages of secrets.
+type Service interface {
+	// ListUsages lists the resources referencing a secret.
+	ListUsages(ctx context.Context, organizationID uint, secretID string) ([]Usage, error)
+
+	// CheckUnused returns an InUseError if a secret is referenced by any resource.
+	CheckUnused(ctx context.Context, organizationID uint, secretID string) error
+}
+
+// NewService returns a new Service.
+func NewService(finder Finder) Service {
+	return service{
+		finder: finder,
+	}
+}
+
+type service struct {
+	finder Finder
+}
+
+func (s service) ListUsages(ctx context.Context, organizationID uint, secretID string) ([]Usage, error) {
+	usages, err := s.finder.FindUsages(ctx, organizationID, secretID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to find secret usages", "organizationId", organizationID, "secretId", secretID)
+	}
+
+	if usages == nil {
+		usages = []Usage{}
+	}
+
+	return usages, nil
+}
+
+func (s service) CheckUnused(ctx context.Context, organizationID uint, secretID string) error {
+	usages, err := s.ListUsages(ctx, organizationID, secretID)
+	if err != nil {
+		return err
+	}
+
+	if len(usages) > 0 {
+		return errors.WithStack(InUseError{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+			Usages:         usages,
+		})
+	}
+
+	return nil
+}
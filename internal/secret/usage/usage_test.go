@@ -0,0 +1,75 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type finderFunc func(ctx context.Context, organizationID uint, secretID string) ([]Usage, error)
+
+func (fn finderFunc) FindUsages(ctx context.Context, organizationID uint, secretID string) ([]Usage, error) {
+	return fn(ctx, organizationID, secretID)
+}
+
+func TestService(t *testing.T) {
+	finder := Finders{
+		finderFunc(func(_ context.Context, _ uint, secretID string) ([]Usage, error) {
+			if secretID == "used" {
+				return []Usage{{Type: TypeCluster, ID: 1, Name: "cluster", ClusterID: 1}}, nil
+			}
+
+			return nil, nil
+		}),
+		finderFunc(func(_ context.Context, _ uint, secretID string) ([]Usage, error) {
+			if secretID == "used" {
+				return []Usage{{Type: TypeHelmRepository, ID: 1, Name: "repo"}}, nil
+			}
+
+			return nil, nil
+		}),
+	}
+
+	service := NewService(finder)
+
+	t.Run("ListUsages", func(t *testing.T) {
+		usages, err := service.ListUsages(context.Background(), 1, "used")
+		require.NoError(t, err)
+
+		assert.Len(t, usages, 2)
+
+		usages, err = service.ListUsages(context.Background(), 1, "unused")
+		require.NoError(t, err)
+
+		assert.NotNil(t, usages)
+		assert.Empty(t, usages)
+	})
+
+	t.Run("CheckUnused", func(t *testing.T) {
+		err := service.CheckUnused(context.Background(), 1, "used")
+
+		var inUseErr InUseError
+		require.True(t, errors.As(err, &inUseErr))
+
+		assert.Len(t, inUseErr.Usages, 2)
+
+		assert.NoError(t, service.CheckUnused(context.Background(), 1, "unused"))
+	})
+}
@@ -0,0 +1,410 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usageadapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/secret/usage"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// clusterModel is a read model of the clusters table.
+type clusterModel struct {
+	ID             uint
+	Name           string
+	OrganizationID uint
+	SecretID       string
+	ConfigSecretID string
+	SSHSecretID    string
+	DeletedAt      *time.Time
+}
+
+// TableName changes the default table name.
+func (clusterModel) TableName() string {
+	return "clusters"
+}
+
+// helmRepositoryModel is a read model of the helm repositories table.
+type helmRepositoryModel struct {
+	ID               uint
+	Name             string
+	OrganizationID   uint
+	PasswordSecretID string
+	TlsSecretID      string
+}
+
+// TableName changes the default table name.
+func (helmRepositoryModel) TableName() string {
+	return "helm_repositories"
+}
+
+// integratedServiceModel is a read model of the integrated services table.
+type integratedServiceModel struct {
+	ID        uint
+	Name      string
+	ClusterID uint `gorm:"column:cluster_id"`
+	Spec      string
+}
+
+// TableName changes the default table name.
+func (integratedServiceModel) TableName() string {
+	return "cluster_features"
+}
+
+// backupBucketModel is a read model of the backup buckets table.
+type backupBucketModel struct {
+	ID             uint
+	BucketName     string
+	OrganizationID uint
+	SecretID       string
+	DeletedAt      *time.Time
+}
+
+// TableName changes the default table name.
+func (backupBucketModel) TableName() string {
+	return "ark_backup_buckets"
+}
+
+// clusterGroupModel is a read model of the cluster groups table.
+type clusterGroupModel struct {
+	ID             uint
+	Name           string
+	OrganizationID uint
+	DeletedAt      *time.Time
+}
+
+// TableName changes the default table name.
+func (clusterGroupModel) TableName() string {
+	return "clustergroups"
+}
+
+// clusterGroupFeatureModel is a read model of the cluster group features table.
+type clusterGroupFeatureModel struct {
+	ID             uint
+	Name           string
+	ClusterGroupID uint
+	Properties     string
+}
+
+// TableName changes the default table name.
+func (clusterGroupFeatureModel) TableName() string {
+	return "clustergroup_features"
+}
+
+// clusterTemplateModel is a read model of the cluster templates table.
+type clusterTemplateModel struct {
+	ID             uint
+	Name           string
+	Version        int
+	OrganizationID uint
+	Spec           string
+}
+
+// TableName changes the default table name.
+func (clusterTemplateModel) TableName() string {
+	return "cluster_templates"
+}
+
+// gitopsRepositoryModel is a read model of the gitops repositories table.
+type gitopsRepositoryModel struct {
+	ID             uint
+	URL            string
+	OrganizationID uint
+	Status         string
+}
+
+// TableName changes the default table name.
+func (gitopsRepositoryModel) TableName() string {
+	return "gitops_repositories"
+}
+
+// GormFinder finds the usages of a secret in the database.
+type GormFinder struct {
+	db *gorm.DB
+}
+
+// NewGormFinder returns a new GormFinder.
+func NewGormFinder(db *gorm.DB) GormFinder {
+	return GormFinder{
+		db: db,
+	}
+}
+
+// FindUsages implements the usage.Finder interface.
+func (f GormFinder) FindUsages(_ context.Context, organizationID uint, secretID string) ([]usage.Usage, error) {
+	var usages []usage.Usage
+
+	finders := []func(organizationID uint, secretID string) ([]usage.Usage, error){
+		f.findClusters,
+		f.findHelmRepositories,
+		f.findIntegratedServices,
+		f.findBackupBuckets,
+		f.findClusterGroups,
+		f.findClusterTemplates,
+		f.findGitopsRepositories,
+	}
+
+	for _, find := range finders {
+		u, err := find(organizationID, secretID)
+		if err != nil {
+			return nil, errors.WithDetails(err, "organizationId", organizationID, "secretId", secretID)
+		}
+
+		usages = append(usages, u...)
+	}
+
+	return usages, nil
+}
+
+func (f GormFinder) findClusters(organizationID uint, secretID string) ([]usage.Usage, error) {
+	var models []clusterModel
+
+	err := f.db.
+		Where("organization_id = ? AND deleted_at IS NULL", organizationID).
+		Where("secret_id = ? OR config_secret_id = ? OR ssh_secret_id = ?", secretID, secretID, secretID).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find clusters referencing the secret")
+	}
+
+	usages := make([]usage.Usage, 0, len(models))
+	for _, model := range models {
+		usages = append(usages, usage.Usage{
+			Type:      usage.TypeCluster,
+			ID:        model.ID,
+			Name:      model.Name,
+			ClusterID: model.ID,
+		})
+	}
+
+	return usages, nil
+}
+
+func (f GormFinder) findHelmRepositories(organizationID uint, secretID string) ([]usage.Usage, error) {
+	var models []helmRepositoryModel
+
+	err := f.db.
+		Where("organization_id = ?", organizationID).
+		Where("password_secret_id = ? OR tls_secret_id = ?", secretID, secretID).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find helm repositories referencing the secret")
+	}
+
+	usages := make([]usage.Usage, 0, len(models))
+	for _, model := range models {
+		usages = append(usages, usage.Usage{
+			Type: usage.TypeHelmRepository,
+			ID:   model.ID,
+			Name: model.Name,
+		})
+	}
+
+	return usages, nil
+}
+
+func (f GormFinder) findIntegratedServices(organizationID uint, secretID string) ([]usage.Usage, error) {
+	var models []integratedServiceModel
+
+	// The spec is filtered by a substring match first, then checked for an exact reference
+	err := f.db.
+		Joins("JOIN clusters ON clusters.id = cluster_features.cluster_id").
+		Where("clusters.organization_id = ? AND clusters.deleted_at IS NULL", organizationID).
+		Where("cluster_features.spec LIKE ?", fmt.Sprintf("%%%s%%", secretID)).
+		Order("cluster_features.id").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find integrated services referencing the secret")
+	}
+
+	usages := make([]usage.Usage, 0, len(models))
+	for _, model := range models {
+		var spec interface{}
+		if err := json.Unmarshal([]byte(model.Spec), &spec); err != nil {
+			return nil, errors.WrapWithDetails(err, "failed to parse integrated service spec", "integratedService", model.Name)
+		}
+
+		if !usage.RefersTo(spec, secretID) {
+			continue
+		}
+
+		usages = append(usages, usage.Usage{
+			Type:      usage.TypeIntegratedService,
+			ID:        model.ID,
+			Name:      model.Name,
+			ClusterID: model.ClusterID,
+		})
+	}
+
+	return usages, nil
+}
+
+func (f GormFinder) findBackupBuckets(organizationID uint, secretID string) ([]usage.Usage, error) {
+	var models []backupBucketModel
+
+	err := f.db.
+		Where("organization_id = ? AND secret_id = ? AND deleted_at IS NULL", organizationID, secretID).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find backup buckets referencing the secret")
+	}
+
+	usages := make([]usage.Usage, 0, len(models))
+	for _, model := range models {
+		usages = append(usages, usage.Usage{
+			Type: usage.TypeBackupBucket,
+			ID:   model.ID,
+			Name: model.BucketName,
+		})
+	}
+
+	return usages, nil
+}
+
+// secretSyncFeatureName is the name of the cluster group feature synchronizing secrets to member clusters.
+const secretSyncFeatureName = "secret-sync"
+
+func (f GormFinder) findClusterGroups(organizationID uint, secretID string) ([]usage.Usage, error) {
+	var models []struct {
+		ClusterGroupID   uint
+		ClusterGroupName string
+		Properties       string
+	}
+
+	// Secrets are synchronized by name, so every synchronized secret of the organization is checked
+	err := f.db.
+		Table("clustergroup_features").
+		Select("clustergroup_features.cluster_group_id, clustergroups.name AS cluster_group_name, clustergroup_features.properties").
+		Joins("JOIN clustergroups ON clustergroups.id = clustergroup_features.cluster_group_id").
+		Where("clustergroups.organization_id = ? AND clustergroups.deleted_at IS NULL", organizationID).
+		Where("clustergroup_features.name = ?", secretSyncFeatureName).
+		Order("clustergroup_features.id").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find cluster groups referencing the secret")
+	}
+
+	usages := make([]usage.Usage, 0, len(models))
+	for _, model := range models {
+		var properties struct {
+			Secrets []string `json:"secrets"`
+		}
+
+		if model.Properties != "" {
+			if err := json.Unmarshal([]byte(model.Properties), &properties); err != nil {
+				return nil, errors.WrapWithDetails(err, "failed to parse cluster group feature properties", "clusterGroup", model.ClusterGroupName)
+			}
+		}
+
+		for _, secretName := range properties.Secrets {
+			if secret.GenerateSecretIDFromName(secretName) == secretID {
+				usages = append(usages, usage.Usage{
+					Type: usage.TypeClusterGroup,
+					ID:   model.ClusterGroupID,
+					Name: model.ClusterGroupName,
+				})
+
+				break
+			}
+		}
+	}
+
+	return usages, nil
+}
+
+func (f GormFinder) findClusterTemplates(organizationID uint, secretID string) ([]usage.Usage, error) {
+	var models []clusterTemplateModel
+
+	// The spec is filtered by a substring match first, then checked for an exact reference
+	err := f.db.
+		Where("organization_id = ?", organizationID).
+		Where("spec LIKE ?", fmt.Sprintf("%%%s%%", secretID)).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find cluster templates referencing the secret")
+	}
+
+	usages := make([]usage.Usage, 0, len(models))
+	for _, model := range models {
+		var spec interface{}
+		if err := json.Unmarshal([]byte(model.Spec), &spec); err != nil {
+			return nil, errors.WrapWithDetails(err, "failed to parse cluster template spec", "clusterTemplate", model.Name)
+		}
+
+		if !usage.RefersTo(spec, secretID) {
+			continue
+		}
+
+		usages = append(usages, usage.Usage{
+			Type: usage.TypeClusterTemplate,
+			ID:   model.ID,
+			Name: fmt.Sprintf("%s (version %d)", model.Name, model.Version),
+		})
+	}
+
+	return usages, nil
+}
+
+func (f GormFinder) findGitopsRepositories(organizationID uint, secretID string) ([]usage.Usage, error) {
+	var models []gitopsRepositoryModel
+
+	// The secrets referenced by the manifests are recorded in the status of the last synchronization
+	err := f.db.
+		Where("organization_id = ?", organizationID).
+		Where("status LIKE ?", fmt.Sprintf("%%%s%%", secretID)).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find gitops repositories referencing the secret")
+	}
+
+	usages := make([]usage.Usage, 0, len(models))
+	for _, model := range models {
+		var status struct {
+			Drift struct {
+				SecretIDs []string `json:"secretIds"`
+			} `json:"drift"`
+		}
+
+		if err := json.Unmarshal([]byte(model.Status), &status); err != nil {
+			return nil, errors.WrapWithDetails(err, "failed to parse gitops repository status", "gitopsRepository", model.URL)
+		}
+
+		for _, id := range status.Drift.SecretIDs {
+			if id == secretID {
+				usages = append(usages, usage.Usage{
+					Type: usage.TypeGitopsRepository,
+					ID:   model.ID,
+					Name: model.URL,
+				})
+
+				break
+			}
+		}
+	}
+
+	return usages, nil
+}
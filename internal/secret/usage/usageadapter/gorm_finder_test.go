@@ -0,0 +1,99 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usageadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" //  SQLite driver used for integration test
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/secret/usage"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+func TestGormFinder(t *testing.T) {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(
+		&clusterModel{},
+		&helmRepositoryModel{},
+		&integratedServiceModel{},
+		&backupBucketModel{},
+		&clusterGroupModel{},
+		&clusterGroupFeatureModel{},
+		&clusterTemplateModel{},
+		&gitopsRepositoryModel{},
+	).Error)
+
+	deletedAt := time.Now()
+
+	records := []interface{}{
+		&clusterModel{ID: 1, Name: "cluster", OrganizationID: 1, SecretID: "secret", ConfigSecretID: "config"},
+		&clusterModel{ID: 2, Name: "config-cluster", OrganizationID: 1, SecretID: "other", ConfigSecretID: "secret"},
+		&clusterModel{ID: 3, Name: "deleted-cluster", OrganizationID: 1, SecretID: "secret", DeletedAt: &deletedAt},
+		&clusterModel{ID: 4, Name: "other-org-cluster", OrganizationID: 2, SecretID: "secret"},
+		&helmRepositoryModel{ID: 1, Name: "repo", OrganizationID: 1, TlsSecretID: "secret"},
+		&helmRepositoryModel{ID: 2, Name: "other-repo", OrganizationID: 1, PasswordSecretID: "other"},
+		&integratedServiceModel{ID: 1, Name: "monitoring", ClusterID: 2, Spec: `{"grafana":{"secretId":"secret"}}`},
+		&integratedServiceModel{ID: 2, Name: "logging", ClusterID: 2, Spec: `{"loki":{"secretId":"secret-but-longer"}}`},
+		&integratedServiceModel{ID: 3, Name: "dns", ClusterID: 4, Spec: `{"secretId":"secret"}`},
+		&backupBucketModel{ID: 1, BucketName: "bucket", OrganizationID: 1, SecretID: "secret"},
+		&backupBucketModel{ID: 2, BucketName: "deleted-bucket", OrganizationID: 1, SecretID: "secret", DeletedAt: &deletedAt},
+		&clusterGroupModel{ID: 1, Name: "group", OrganizationID: 1},
+		&clusterGroupModel{ID: 2, Name: "deleted-group", OrganizationID: 1, DeletedAt: &deletedAt},
+		&clusterGroupFeatureModel{ID: 1, Name: "secret-sync", ClusterGroupID: 1, Properties: `{"secrets":["registry"],"namespaces":["default"]}`},
+		&clusterGroupFeatureModel{ID: 2, Name: "secret-sync", ClusterGroupID: 2, Properties: `{"secrets":["registry"],"namespaces":["default"]}`},
+		&clusterGroupFeatureModel{ID: 3, Name: "deployment", ClusterGroupID: 1, Properties: `{"secrets":["registry"]}`},
+		&clusterTemplateModel{ID: 1, Name: "template", Version: 2, OrganizationID: 1, Spec: `{"cluster":{"secretId":"secret"}}`},
+		&clusterTemplateModel{ID: 2, Name: "other-template", Version: 1, OrganizationID: 1, Spec: `{"cluster":{"secretId":"secret-but-longer"}}`},
+		&gitopsRepositoryModel{ID: 1, URL: "https://example.com/org.git", OrganizationID: 1, Status: `{"drift":{"secretIds":["other","secret"]}}`},
+		&gitopsRepositoryModel{ID: 2, URL: "https://example.com/other.git", OrganizationID: 2, Status: `{"drift":{"secretIds":["secret"]}}`},
+	}
+
+	for _, record := range records {
+		require.NoError(t, db.Create(record).Error)
+	}
+
+	usages, err := NewGormFinder(db).FindUsages(context.Background(), 1, "secret")
+	require.NoError(t, err)
+
+	expected := []usage.Usage{
+		{Type: usage.TypeCluster, ID: 1, Name: "cluster", ClusterID: 1},
+		{Type: usage.TypeCluster, ID: 2, Name: "config-cluster", ClusterID: 2},
+		{Type: usage.TypeHelmRepository, ID: 1, Name: "repo"},
+		{Type: usage.TypeIntegratedService, ID: 1, Name: "monitoring", ClusterID: 2},
+		{Type: usage.TypeBackupBucket, ID: 1, Name: "bucket"},
+		{Type: usage.TypeClusterTemplate, ID: 1, Name: "template (version 2)"},
+		{Type: usage.TypeGitopsRepository, ID: 1, Name: "https://example.com/org.git"},
+	}
+
+	assert.Equal(t, expected, usages)
+
+	usages, err = NewGormFinder(db).FindUsages(context.Background(), 1, secret.GenerateSecretIDFromName("registry"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []usage.Usage{{Type: usage.TypeClusterGroup, ID: 1, Name: "group"}}, usages)
+
+	usages, err = NewGormFinder(db).FindUsages(context.Background(), 1, "unused")
+	require.NoError(t, err)
+
+	assert.Empty(t, usages)
+}
@@ -0,0 +1,95 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usageadapter
+
+import (
+	"context"
+	"encoding/json"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/secret/usage"
+)
+
+// IntegratedServiceFinder finds the usages of a secret in integrated services stored outside of the database
+// (eg. the custom resources of integrated services v2).
+//
+// Secrets cannot be considered unused if the integrated services of a cluster cannot be listed,
+// so lookup errors are returned instead of being skipped.
+type IntegratedServiceFinder struct {
+	db         *gorm.DB
+	repository integratedservices.IntegratedServiceRepository
+}
+
+// NewIntegratedServiceFinder returns a new IntegratedServiceFinder.
+func NewIntegratedServiceFinder(db *gorm.DB, repository integratedservices.IntegratedServiceRepository) IntegratedServiceFinder {
+	return IntegratedServiceFinder{
+		db:         db,
+		repository: repository,
+	}
+}
+
+// FindUsages implements the usage.Finder interface.
+func (f IntegratedServiceFinder) FindUsages(ctx context.Context, organizationID uint, secretID string) ([]usage.Usage, error) {
+	var clusters []clusterModel
+
+	err := f.db.
+		Where("organization_id = ? AND deleted_at IS NULL", organizationID).
+		Order("id").
+		Find(&clusters).Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "failed to list clusters", "organizationId", organizationID)
+	}
+
+	var usages []usage.Usage
+
+	for _, cluster := range clusters {
+		services, err := f.repository.GetIntegratedServices(ctx, cluster.ID)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(
+				err, "failed to list integrated services",
+				"organizationId", organizationID,
+				"clusterId", cluster.ID,
+			)
+		}
+
+		for _, service := range services {
+			// The spec is decoded from JSON, so that nested structures can be checked as well
+			raw, err := json.Marshal(service.Spec)
+			if err != nil {
+				return nil, errors.WrapWithDetails(err, "failed to encode integrated service spec", "integratedService", service.Name)
+			}
+
+			var spec interface{}
+			if err := json.Unmarshal(raw, &spec); err != nil {
+				return nil, errors.WrapWithDetails(err, "failed to parse integrated service spec", "integratedService", service.Name)
+			}
+
+			if !usage.RefersTo(spec, secretID) {
+				continue
+			}
+
+			usages = append(usages, usage.Usage{
+				Type:      usage.TypeIntegratedService,
+				Name:      service.Name,
+				ClusterID: cluster.ID,
+			})
+		}
+	}
+
+	return usages, nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usageadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/secret/usage"
+)
+
+type integratedServiceRepositoryStub struct {
+	integratedservices.IntegratedServiceRepository
+
+	services map[uint][]integratedservices.IntegratedService
+	err      error
+}
+
+func (r integratedServiceRepositoryStub) GetIntegratedServices(_ context.Context, clusterID uint) ([]integratedservices.IntegratedService, error) {
+	return r.services[clusterID], r.err
+}
+
+func TestIntegratedServiceFinder(t *testing.T) {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(&clusterModel{}).Error)
+
+	deletedAt := time.Now()
+
+	records := []interface{}{
+		&clusterModel{ID: 1, Name: "cluster", OrganizationID: 1},
+		&clusterModel{ID: 2, Name: "other-cluster", OrganizationID: 1},
+		&clusterModel{ID: 3, Name: "deleted-cluster", OrganizationID: 1, DeletedAt: &deletedAt},
+		&clusterModel{ID: 4, Name: "other-org-cluster", OrganizationID: 2},
+	}
+
+	for _, record := range records {
+		require.NoError(t, db.Create(record).Error)
+	}
+
+	repository := integratedServiceRepositoryStub{
+		services: map[uint][]integratedservices.IntegratedService{
+			1: {
+				{Name: "monitoring", Spec: integratedservices.IntegratedServiceSpec{"grafana": map[string]interface{}{"secretId": "secret"}}},
+				{Name: "logging", Spec: integratedservices.IntegratedServiceSpec{"loki": map[string]interface{}{"secretId": "secret-but-longer"}}},
+			},
+			2: {
+				{Name: "dns", Spec: integratedservices.IntegratedServiceSpec{"providers": []interface{}{map[string]interface{}{"secretId": "secret"}}}},
+			},
+			3: {
+				{Name: "monitoring", Spec: integratedservices.IntegratedServiceSpec{"secretId": "secret"}},
+			},
+			4: {
+				{Name: "monitoring", Spec: integratedservices.IntegratedServiceSpec{"secretId": "secret"}},
+			},
+		},
+	}
+
+	usages, err := NewIntegratedServiceFinder(db, repository).FindUsages(context.Background(), 1, "secret")
+	require.NoError(t, err)
+
+	expected := []usage.Usage{
+		{Type: usage.TypeIntegratedService, Name: "monitoring", ClusterID: 1},
+		{Type: usage.TypeIntegratedService, Name: "dns", ClusterID: 2},
+	}
+
+	assert.Equal(t, expected, usages)
+
+	// secrets cannot be considered unused if the integrated services cannot be listed
+	repository.err = errors.New("cluster is unreachable")
+
+	_, err = NewIntegratedServiceFinder(db, repository).FindUsages(context.Background(), 1, "secret")
+	assert.Error(t, err)
+}
@@ -15,8 +15,11 @@
 package pke
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 
+	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/internal/global"
 	intPKE "github.com/banzaicloud/pipeline/internal/pke"
 	"github.com/banzaicloud/pipeline/pkg/common"
@@ -38,6 +41,12 @@ func (pke *CreateClusterPKE) Validate() error {
 		return err
 	}
 
+	for _, np := range pke.NodePools {
+		if err := np.validateTaints(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -95,6 +104,30 @@ type NodePool struct {
 	ProviderConfig map[string]interface{} `json:"providerConfig" yaml:"providerConfig" binding:"required"`
 	Labels         map[string]string      `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Autoscaling    bool                   `json:"autoscaling" yaml:"autoscaling"`
+
+	// Taints (key=value:effect) are registered on every node of the pool and
+	// advertised to the cluster autoscaler, while StartupTaints are only
+	// registered on the nodes as they are expected to be removed once the node is ready.
+	Taints        Taints `json:"taints,omitempty" yaml:"taints,omitempty"`
+	StartupTaints Taints `json:"startupTaints,omitempty" yaml:"startupTaints,omitempty"`
+}
+
+func (np NodePool) validateTaints() error {
+	taints, err := cluster.ParseNodePoolTaints(np.Taints.String())
+	if err != nil {
+		return errors.Wrapf(err, "invalid taints of node pool %q", np.Name)
+	}
+
+	startupTaints, err := cluster.ParseNodePoolTaints(np.StartupTaints.String())
+	if err != nil {
+		return errors.Wrapf(err, "invalid startup taints of node pool %q", np.Name)
+	}
+
+	if violations := cluster.ValidateNodePoolTaints(taints, startupTaints); len(violations) > 0 {
+		return errors.Errorf("invalid taints of node pool %q: %s", np.Name, strings.Join(violations, ", "))
+	}
+
+	return nil
 }
 
 type NodePoolProvider string
@@ -135,6 +168,16 @@ type (
 	Taint  string
 )
 
+// String returns the taints as a comma separated list.
+func (t Taints) String() string {
+	items := make([]string, 0, len(t))
+	for _, taint := range t {
+		items = append(items, string(taint))
+	}
+
+	return strings.Join(items, ",")
+}
+
 // TODO add required field to LaunchTemplate if applicable
 type AmazonProviderConfig struct {
 	AutoScalingGroup struct {
@@ -37,9 +37,13 @@ func NewGroup(manager *Manager, group *autoscaling.Group) *Group {
 }
 
 // IsHealthy checks whether an ASG is in a healthy state
-// which means it has as many healthy instances as desired
+// which means it has as much healthy capacity as desired.
+//
+// The capacity of an ASG with a mixed instances policy is measured in the
+// capacity units (weights) of its instances, otherwise in instances.
 func (group *Group) IsHealthy() (bool, error) {
-	healthyInstanceCount := 0
+	healthyCapacity := 0
+	isWeighted := group.MixedInstancesPolicy != nil
 
 	instances := group.getInstances()
 	for _, instance := range instances {
@@ -47,7 +51,7 @@ func (group *Group) IsHealthy() (bool, error) {
 			if group.manager.StopMetricTimer(instance) {
 				group.manager.RegisterSpotFulfillmentDuration(instance, group)
 			}
-			healthyInstanceCount++
+			healthyCapacity += instance.capacityUnits(isWeighted)
 		}
 		if instance.LifecycleState != nil && *instance.LifecycleState == "Pending" {
 			group.manager.StartMetricTimer(instance)
@@ -59,7 +63,13 @@ func (group *Group) IsHealthy() (bool, error) {
 		desiredCapacity = int(*group.DesiredCapacity)
 	}
 
-	if desiredCapacity == healthyInstanceCount {
+	if desiredCapacity == healthyCapacity {
+		return true, nil
+	}
+
+	// Note: weighted instances can exceed the desired capacity, because
+	// the last launched instance may provide more units than missing.
+	if isWeighted && healthyCapacity > desiredCapacity {
 		return true, nil
 	}
 
@@ -69,7 +79,7 @@ func (group *Group) IsHealthy() (bool, error) {
 	}
 
 	if len(spotRequests) == 0 {
-		return false, NewAutoscalingGroupNotHealthyError(desiredCapacity, healthyInstanceCount)
+		return false, NewAutoscalingGroupNotHealthyError(desiredCapacity, healthyCapacity)
 	}
 
 	for _, spotRequest := range spotRequests {
@@ -78,7 +88,7 @@ func (group *Group) IsHealthy() (bool, error) {
 		}
 	}
 
-	return false, NewAutoscalingGroupNotHealthyError(desiredCapacity, healthyInstanceCount)
+	return false, NewAutoscalingGroupNotHealthyError(desiredCapacity, healthyCapacity)
 }
 
 func (group *Group) getInstances() []*Instance {
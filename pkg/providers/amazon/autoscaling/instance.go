@@ -15,6 +15,8 @@
 package autoscaling
 
 import (
+	"strconv"
+
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
@@ -51,6 +53,22 @@ func (i *Instance) IsHealthyAndInService() bool {
 	return healthStatus == "Healthy" && lifecycleState == "InService"
 }
 
+// capacityUnits returns the number of capacity units the instance provides
+// towards the desired capacity of its group.
+// Instances without a weight (or when weights are not used) provide one unit.
+func (i *Instance) capacityUnits(isWeighted bool) int {
+	if !isWeighted || i.WeightedCapacity == nil {
+		return 1
+	}
+
+	weight, err := strconv.Atoi(*i.WeightedCapacity)
+	if err != nil || weight < 1 {
+		return 1
+	}
+
+	return weight
+}
+
 // Describe returns detailed information about the instance
 func (i *Instance) Describe() (*ec2.Instance, error) {
 	if i.InstanceId == nil {
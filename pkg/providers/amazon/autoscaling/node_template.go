@@ -0,0 +1,78 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+// NodeTemplateTaintTagKeyPrefix is the prefix of the tags the cluster autoscaler
+// uses to determine the taints of the group's nodes when scaling up from zero.
+const NodeTemplateTaintTagKeyPrefix = "k8s.io/cluster-autoscaler/node-template/taint/"
+
+// SetNodeTemplateTaints sets the cluster autoscaler node template taint tags
+// of the group to the specified taints (key => value:effect)
+// and removes the ones not present anymore.
+func (group *Group) SetNodeTemplateTaints(taints map[string]string) error {
+	var obsoleteTags []*autoscaling.Tag
+
+	for _, tag := range group.Tags {
+		key := aws.StringValue(tag.Key)
+		if !strings.HasPrefix(key, NodeTemplateTaintTagKeyPrefix) {
+			continue
+		}
+
+		if _, ok := taints[strings.TrimPrefix(key, NodeTemplateTaintTagKeyPrefix)]; !ok {
+			obsoleteTags = append(obsoleteTags, group.newTag(key, aws.StringValue(tag.Value)))
+		}
+	}
+
+	if len(obsoleteTags) > 0 {
+		_, err := group.manager.asSvc.DeleteTags(&autoscaling.DeleteTagsInput{Tags: obsoleteTags})
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not delete obsolete node template taint tags", "asg", group.getName())
+		}
+	}
+
+	if len(taints) == 0 {
+		return nil
+	}
+
+	tags := make([]*autoscaling.Tag, 0, len(taints))
+	for key, value := range taints {
+		tags = append(tags, group.newTag(NodeTemplateTaintTagKeyPrefix+key, value))
+	}
+
+	_, err := group.manager.asSvc.CreateOrUpdateTags(&autoscaling.CreateOrUpdateTagsInput{Tags: tags})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not set node template taint tags", "asg", group.getName())
+	}
+
+	return nil
+}
+
+func (group *Group) newTag(key string, value string) *autoscaling.Tag {
+	return &autoscaling.Tag{
+		Key:               aws.String(key),
+		PropagateAtLaunch: aws.Bool(false),
+		ResourceId:        group.AutoScalingGroupName,
+		ResourceType:      aws.String("auto-scaling-group"),
+		Value:             aws.String(value),
+	}
+}
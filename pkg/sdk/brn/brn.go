@@ -29,9 +29,10 @@ const SchemePrefix = Scheme + ":"
 
 // Resource type constants
 const (
-	SecretResourceType  = "secret"  // resource ID: <secret sha id>
-	ClusterResourceType = "cluster" // resource ID: <cluster id>
-	NodeResourceType    = "node"    // resource ID: <cluster id>/<node name>
+	SecretResourceType       = "secret"       // resource ID: <secret sha id>
+	ClusterResourceType      = "cluster"      // resource ID: <cluster id>
+	NodeResourceType         = "node"         // resource ID: <cluster id>/<node name>
+	ClusterGroupResourceType = "clustergroup" // resource ID: <cluster group id>
 )
 
 // ErrInvalid is returned when a BRN fails validation checks.
@@ -28,8 +28,8 @@ const (
 )
 
 // AddOrgRoutes adds routes for managing ARK backups within an organization
-func AddOrgRoutes(group *gin.RouterGroup, clusterManager *cluster.Manager) {
-	orgBackups := &orgBackups{clusterManager: clusterManager}
+func AddOrgRoutes(group *gin.RouterGroup, clusterManager *cluster.Manager, clusterAccess cluster.AccessChecker) {
+	orgBackups := &orgBackups{clusterManager: clusterManager, clusterAccess: clusterAccess}
 	group.GET("", orgBackups.List)
 	group.PUT("/sync", orgBackups.Sync)
 }
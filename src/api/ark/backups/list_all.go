@@ -21,6 +21,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/banzaicloud/pipeline/internal/ark"
+	"github.com/banzaicloud/pipeline/internal/ark/api"
 	"github.com/banzaicloud/pipeline/internal/global"
 	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
 	"github.com/banzaicloud/pipeline/src/api/ark/common"
@@ -30,9 +31,10 @@ import (
 
 type orgBackups struct {
 	clusterManager *cluster.Manager
+	clusterAccess  cluster.AccessChecker
 }
 
-// List lists every ARK backup for the organization
+// List lists every ARK backup for the organization, the backups of clusters the current user cannot access are left out
 func (b *orgBackups) List(c *gin.Context) {
 	logger := correlationid.LogrusLogger(common.Log, c)
 	logger.Info("getting backups")
@@ -45,5 +47,12 @@ func (b *orgBackups) List(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, backups)
+	accessibleBackups := make([]*api.Backup, 0, len(backups))
+	for _, backup := range backups {
+		if common.CanAccessCluster(c, b.clusterAccess, backup.ClusterID) {
+			accessibleBackups = append(accessibleBackups, backup)
+		}
+	}
+
+	c.JSON(http.StatusOK, accessibleBackups)
 }
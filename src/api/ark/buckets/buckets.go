@@ -16,6 +16,8 @@ package buckets
 
 import (
 	"github.com/gin-gonic/gin"
+
+	"github.com/banzaicloud/pipeline/src/cluster"
 )
 
 const (
@@ -23,8 +25,9 @@ const (
 )
 
 // AddRoutes adds ARK buckets related API routes
-func AddRoutes(group *gin.RouterGroup) {
-	group.GET("", List)
+func AddRoutes(group *gin.RouterGroup, clusterAccess cluster.AccessChecker) {
+	orgBuckets := &orgBuckets{clusterAccess: clusterAccess}
+	group.GET("", orgBuckets.List)
 	group.POST("", Create)
 	group.PUT("/sync", Sync)
 	item := group.Group("/:" + IDParamName)
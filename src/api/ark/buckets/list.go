@@ -21,14 +21,20 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/banzaicloud/pipeline/internal/ark"
+	"github.com/banzaicloud/pipeline/internal/ark/api"
 	"github.com/banzaicloud/pipeline/internal/global"
 	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
 	"github.com/banzaicloud/pipeline/src/api/ark/common"
 	"github.com/banzaicloud/pipeline/src/auth"
+	"github.com/banzaicloud/pipeline/src/cluster"
 )
 
-// List lists ARK backup buckets
-func List(c *gin.Context) {
+type orgBuckets struct {
+	clusterAccess cluster.AccessChecker
+}
+
+// List lists ARK backup buckets, the buckets used by clusters the current user cannot access are left out
+func (b *orgBuckets) List(c *gin.Context) {
 	logger := correlationid.LogrusLogger(common.Log, c)
 	logger.Info("getting buckets")
 
@@ -41,5 +47,12 @@ func List(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, buckets)
+	accessibleBuckets := make([]*api.Bucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		if common.CanAccessCluster(c, b.clusterAccess, bucket.ClusterID) {
+			accessibleBuckets = append(accessibleBuckets, bucket)
+		}
+	}
+
+	c.JSON(http.StatusOK, accessibleBuckets)
 }
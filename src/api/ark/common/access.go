@@ -0,0 +1,38 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/banzaicloud/pipeline/src/cluster"
+)
+
+// CanAccessCluster checks whether the current user can access the cluster an ARK resource belongs to.
+// Resources not belonging to any cluster are accessible, failed checks are handled and reported as not accessible.
+func CanAccessCluster(c *gin.Context, clusterAccess cluster.AccessChecker, clusterID uint) bool {
+	if clusterID == 0 {
+		return true
+	}
+
+	ok, err := clusterAccess.CanAccessCluster(c.Request.Context(), clusterID)
+	if err != nil {
+		ErrorHandler.Handle(err)
+
+		return false
+	}
+
+	return ok
+}
@@ -66,6 +66,7 @@ type ClusterAPI struct {
 	authConfig         auth.Config
 	distributionConfig cmd.DistributionConfig
 	clientSecretGetter clusterAuth.ClusterClientSecretGetter
+	clusterAccess      cluster.AccessChecker
 }
 
 type ClusterCreators struct {
@@ -101,6 +102,7 @@ func NewClusterAPI(
 	authConfig auth.Config,
 	distributionConfig cmd.DistributionConfig,
 	clientSecretGetter clusterAuth.ClusterClientSecretGetter,
+	clusterAccess cluster.AccessChecker,
 ) *ClusterAPI {
 	return &ClusterAPI{
 		clusterManager:          clusterManager,
@@ -117,6 +119,7 @@ func NewClusterAPI(
 		authConfig:              authConfig,
 		distributionConfig:      distributionConfig,
 		clientSecretGetter:      clientSecretGetter,
+		clusterAccess:           clusterAccess,
 	}
 }
 
@@ -185,7 +188,9 @@ func (a *ClusterAPI) GetClusters(c *gin.Context) {
 
 	logger.Info("fetching clusters")
 
-	clusters, err := a.clusterManager.GetClusters(context.Background(), organizationID)
+	ctx := c.Request.Context()
+
+	clusters, err := a.clusterManager.GetClusters(ctx, organizationID)
 	if err != nil {
 		logger.Errorf("error listing clusters: %s", err.Error())
 
@@ -203,6 +208,15 @@ func (a *ClusterAPI) GetClusters(c *gin.Context) {
 	for _, c := range clusters {
 		logger := logger.WithField("cluster", c.GetName())
 
+		ok, err := a.clusterAccess.CanAccessCluster(ctx, c.GetID())
+		if err != nil {
+			logger.Errorf("checking cluster access failed: %s", err.Error())
+
+			continue
+		} else if !ok {
+			continue
+		}
+
 		status, err := c.GetStatus()
 		if err != nil {
 			// TODO we want skip or return error?
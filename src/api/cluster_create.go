@@ -310,3 +310,65 @@ func (a *ClusterAPI) createCluster(
 
 	return commonCluster, nil
 }
+
+// CreateClusterFromRequest creates a cluster from a cluster creation request on behalf of a user.
+// The request goes through the same validation as the ones received by the cluster creation endpoint.
+func (a *ClusterAPI) CreateClusterFromRequest(
+	ctx context.Context,
+	organizationID uint,
+	userID uint,
+	createClusterRequest pkgCluster.CreateClusterRequest,
+) (uint, error) {
+	if createClusterRequest.SecretId == "" && len(createClusterRequest.SecretIds) == 0 {
+		if createClusterRequest.SecretName == "" {
+			return 0, ClusterCreationError{Code: http.StatusBadRequest, Message: "either secretId or secretName has to be set"}
+		}
+
+		createClusterRequest.SecretId = secret.GenerateSecretIDFromName(createClusterRequest.SecretName)
+	}
+
+	existingCluster, err := a.clusterManager.GetClusterByName(ctx, organizationID, createClusterRequest.Name)
+	if err != nil && !isNotFoundError(err) {
+		return 0, errors.WrapIf(err, "check if the cluster already exists failed")
+	}
+
+	if existingCluster != nil {
+		return 0, ClusterCreationError{Code: http.StatusConflict, Message: cluster.ErrAlreadyExists.Error()}
+	}
+
+	commonCluster, errorResponse := a.createCluster(ctx, &createClusterRequest, organizationID, userID, createClusterRequest.PostHooks)
+	if errorResponse != nil {
+		return 0, ClusterCreationError{Code: errorResponse.Code, Message: errorResponse.Message}
+	}
+
+	return commonCluster.GetID(), nil
+}
+
+// ClusterCreationError is returned when a cluster creation request is rejected.
+type ClusterCreationError struct {
+	Code    int
+	Message string
+}
+
+// Error implements the error interface.
+func (e ClusterCreationError) Error() string {
+	return e.Message
+}
+
+// BadRequest tells a client that this error is related to an invalid request.
+// Can be used to translate the error to eg. status code.
+func (e ClusterCreationError) BadRequest() bool {
+	return e.Code == http.StatusBadRequest
+}
+
+// Conflict tells a client that this error is related to a conflicting request.
+// Can be used to translate the error to eg. status code.
+func (e ClusterCreationError) Conflict() bool {
+	return e.Code == http.StatusConflict
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (e ClusterCreationError) ServiceError() bool {
+	return e.Code < http.StatusInternalServerError
+}
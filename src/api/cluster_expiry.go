@@ -0,0 +1,105 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
+	"github.com/banzaicloud/pipeline/pkg/common"
+	apiCommon "github.com/banzaicloud/pipeline/src/api/common"
+)
+
+type clusterExpiryExtender interface {
+	Extend(ctx context.Context, clusterID uint, duration time.Duration) (time.Time, error)
+}
+
+// ClusterExpiryAPI implements cluster expiry functions
+type ClusterExpiryAPI struct {
+	clusterGetter apiCommon.ClusterGetter
+	service       clusterExpiryExtender
+	logger        logrus.FieldLogger
+}
+
+// NewClusterExpiryAPI returns a new ClusterExpiryAPI instance
+func NewClusterExpiryAPI(clusterGetter apiCommon.ClusterGetter, service clusterExpiryExtender, logger logrus.FieldLogger) *ClusterExpiryAPI {
+	return &ClusterExpiryAPI{
+		clusterGetter: clusterGetter,
+		service:       service,
+		logger:        logger,
+	}
+}
+
+// ExtendClusterExpiryRequest describes how long the expiry of a cluster is postponed
+type ExtendClusterExpiryRequest struct {
+	Hours int `json:"hours" binding:"required,min=1"`
+}
+
+// ExtendClusterExpiryResponse describes the new expiry date of a cluster
+type ExtendClusterExpiryResponse struct {
+	Date time.Time `json:"date"`
+}
+
+// ExtendClusterExpiry postpones the expiry date of a cluster
+func (a *ClusterExpiryAPI) ExtendClusterExpiry(c *gin.Context) {
+	logger := correlationid.LogrusLogger(a.logger, c)
+
+	commonCluster, ok := a.clusterGetter.GetClusterFromRequest(c)
+	if !ok {
+		return
+	}
+
+	var request ExtendClusterExpiryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error parsing request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	date, err := a.service.Extend(c.Request.Context(), commonCluster.GetID(), time.Duration(request.Hours)*time.Hour)
+	if err != nil {
+		status := http.StatusInternalServerError
+
+		var invalidSpecErr integratedservices.InvalidIntegratedServiceSpecError
+
+		switch {
+		case integratedservices.IsIntegratedServiceNotFoundError(err):
+			status = http.StatusNotFound
+		case errors.As(err, &invalidSpecErr):
+			status = http.StatusBadRequest
+		default:
+			logger.Error(err.Error())
+		}
+
+		c.AbortWithStatusJSON(status, common.ErrorResponse{
+			Code:    status,
+			Message: "Error during extending cluster expiry",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ExtendClusterExpiryResponse{Date: date})
+}
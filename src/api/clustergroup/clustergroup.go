@@ -24,6 +24,7 @@ import (
 	"github.com/banzaicloud/pipeline/src/api/clustergroup/common"
 	"github.com/banzaicloud/pipeline/src/api/clustergroup/deployment"
 	"github.com/banzaicloud/pipeline/src/api/clustergroup/feature"
+	"github.com/banzaicloud/pipeline/src/cluster"
 )
 
 const (
@@ -36,6 +37,7 @@ type API struct {
 	deploymentManager   *pkgDep.CGDeploymentManager
 	logger              logrus.FieldLogger
 	errorHandler        common.ErrorHandler
+	clusterAccess       cluster.AccessChecker
 }
 
 func NewAPI(
@@ -43,6 +45,7 @@ func NewAPI(
 	deploymentManager *pkgDep.CGDeploymentManager,
 	logger logrus.FieldLogger,
 	baseErrorHandler emperror.Handler,
+	clusterAccess cluster.AccessChecker,
 ) *API {
 	return &API{
 		clusterGroupManager: clusterGroupManager,
@@ -51,6 +54,7 @@ func NewAPI(
 		errorHandler: common.ErrorHandler{
 			Handler: baseErrorHandler,
 		},
+		clusterAccess: clusterAccess,
 	}
 }
 
@@ -66,5 +70,5 @@ func (a *API) AddRoutes(group *gin.RouterGroup) {
 	}
 
 	feature.NewAPI(a.clusterGroupManager, a.deploymentManager, a.logger, a.errorHandler.Handler).AddRoutes(item.Group("/features"))
-	deployment.NewAPI(a.clusterGroupManager, a.deploymentManager, a.logger, a.errorHandler.Handler).AddRoutes(item.Group("/deployments"))
+	deployment.NewAPI(a.clusterGroupManager, a.deploymentManager, a.logger, a.errorHandler.Handler, a.clusterAccess).AddRoutes(item.Group("/deployments"))
 }
@@ -52,8 +52,10 @@ func (e ErrorHandler) errorResponseFrom(err error) *pkgCommon.ErrorResponse {
 	var code int
 	if cgroup.IsClusterGroupNotFoundError(err) || deployment.IsDeploymentNotFoundError(err) || cgroup.IsFeatureRecordNotFoundError(err) {
 		code = http.StatusNotFound
-	} else if cgroup.IsClusterGroupAlreadyExistsError(err) || cgroup.IsUnableToJoinMemberClusterError(err) || cgroup.IsInvalidClusterGroupCreateRequestError(err) || cgroup.IsClusterGroupUpdateRejectedError(err) {
+	} else if cgroup.IsClusterGroupAlreadyExistsError(err) || cgroup.IsUnableToJoinMemberClusterError(err) || cgroup.IsInvalidClusterGroupCreateRequestError(err) || cgroup.IsClusterGroupUpdateRejectedError(err) || deployment.IsInvalidRolloutStrategyError(err) {
 		code = http.StatusBadRequest
+	} else if deployment.IsRolloutInProgressError(err) {
+		code = http.StatusConflict
 	}
 
 	if code > 0 {
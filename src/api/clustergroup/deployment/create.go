@@ -90,21 +90,17 @@ func (n *API) Create(c *gin.Context) {
 		return
 	}
 
-	targetClusterStatus, err := n.deploymentManager.CreateDeployment(clusterGroup, organization.ID, organization.Name, deployment)
+	response, err := n.deploymentManager.CreateDeployment(clusterGroup, organization.ID, organization.Name, deployment)
 	if err != nil {
 		n.errorHandler.Handle(c, err)
 		return
 	}
 
-	if n.returnOperationErrorsIfAny(c, targetClusterStatus, deployment.ReleaseName) {
+	if n.returnOperationErrorsIfAny(c, response.TargetClusters, deployment.ReleaseName) {
 		return
 	}
 
 	n.logger.Debug("Release name: ", deployment.ReleaseName)
-	response := pkgDep.CreateUpdateDeploymentResponse{
-		ReleaseName:    deployment.ReleaseName,
-		TargetClusters: targetClusterStatus,
-	}
 	c.JSON(http.StatusCreated, response)
 	return
 }
@@ -22,6 +22,7 @@ import (
 	cgroup "github.com/banzaicloud/pipeline/internal/clustergroup"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
 	"github.com/banzaicloud/pipeline/src/api/clustergroup/common"
+	"github.com/banzaicloud/pipeline/src/cluster"
 )
 
 const (
@@ -33,6 +34,7 @@ type API struct {
 	deploymentManager   *deployment.CGDeploymentManager
 	logger              logrus.FieldLogger
 	errorHandler        common.ErrorHandler
+	clusterAccess       cluster.AccessChecker
 }
 
 func NewAPI(
@@ -40,6 +42,7 @@ func NewAPI(
 	deploymentManager *deployment.CGDeploymentManager,
 	logger logrus.FieldLogger,
 	baseErrorHandler emperror.Handler,
+	clusterAccess cluster.AccessChecker,
 ) *API {
 	return &API{
 		clusterGroupManager: clusterGroupManager,
@@ -48,6 +51,7 @@ func NewAPI(
 		errorHandler: common.ErrorHandler{
 			Handler: baseErrorHandler,
 		},
+		clusterAccess: clusterAccess,
 	}
 }
 
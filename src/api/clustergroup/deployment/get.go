@@ -20,6 +20,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
 	ginutils "github.com/banzaicloud/pipeline/internal/platform/gin/utils"
 	"github.com/banzaicloud/pipeline/src/auth"
 )
@@ -60,5 +61,21 @@ func (n *API) Get(c *gin.Context) {
 		return
 	}
 
+	// hide the releases on clusters the current user cannot access
+	targetClustersStatus := make([]deployment.TargetClusterStatus, 0, len(response.TargetClustersStatus))
+	for _, status := range response.TargetClustersStatus {
+		ok, err := n.clusterAccess.CanAccessCluster(c.Request.Context(), status.ClusterId)
+		if err != nil {
+			n.logger.WithField("clusterId", status.ClusterId).Errorf("checking cluster access failed: %s", err.Error())
+
+			continue
+		} else if !ok {
+			continue
+		}
+
+		targetClustersStatus = append(targetClustersStatus, status)
+	}
+	response.TargetClustersStatus = targetClustersStatus
+
 	c.JSON(http.StatusOK, response)
 }
@@ -71,21 +71,16 @@ func (n *API) Upgrade(c *gin.Context) {
 
 	deployment.ReleaseName = name
 
-	targetClusterStatus, err := n.deploymentManager.UpdateDeployment(clusterGroup, orgID, deployment)
+	response, err := n.deploymentManager.UpdateDeployment(clusterGroup, orgID, deployment)
 	if err != nil {
 		n.errorHandler.Handle(c, err)
 		return
 	}
 
-	if n.returnOperationErrorsIfAny(c, targetClusterStatus, name) {
+	if n.returnOperationErrorsIfAny(c, response.TargetClusters, name) {
 		return
 	}
 
-	response := pkgDep.CreateUpdateDeploymentResponse{
-		ReleaseName:    deployment.ReleaseName,
-		TargetClusters: targetClusterStatus,
-	}
-
 	c.JSON(http.StatusAccepted, response)
 
 	return
@@ -0,0 +1,168 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
+	internalSecret "github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/rotation"
+	"github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// SecretRotationAPI implements secret rotation policy functions
+type SecretRotationAPI struct {
+	service rotation.Service
+	logger  logrus.FieldLogger
+}
+
+// NewSecretRotationAPI returns a new SecretRotationAPI instance
+func NewSecretRotationAPI(service rotation.Service, logger logrus.FieldLogger) *SecretRotationAPI {
+	return &SecretRotationAPI{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// SecretRotationPolicyRequest describes a secret rotation policy to be set
+type SecretRotationPolicyRequest struct {
+	Interval     string `json:"interval" binding:"required"`
+	NotifyBefore string `json:"notifyBefore,omitempty"`
+}
+
+// SecretRotationPolicyResponse describes the rotation policy of a secret
+type SecretRotationPolicyResponse struct {
+	Interval      string    `json:"interval"`
+	NotifyBefore  string    `json:"notifyBefore"`
+	LastRotatedAt time.Time `json:"lastRotatedAt"`
+	NextRotation  time.Time `json:"nextRotation"`
+}
+
+// GetSecretRotation returns the rotation policy of a secret
+func (a *SecretRotationAPI) GetSecretRotation(c *gin.Context) {
+	logger := correlationid.LogrusLogger(a.logger, c)
+
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	policy, err := a.service.GetPolicy(c.Request.Context(), organizationID, secretID)
+	if err != nil {
+		a.abortWithError(c, logger, err, "Error during getting secret rotation policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, newSecretRotationPolicyResponse(policy))
+}
+
+// SetSecretRotation creates or updates the rotation policy of a secret
+func (a *SecretRotationAPI) SetSecretRotation(c *gin.Context) {
+	logger := correlationid.LogrusLogger(a.logger, c)
+
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	var request SecretRotationPolicyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error parsing request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	interval, err := time.ParseDuration(request.Interval)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid rotation interval",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var notifyBefore time.Duration
+	if request.NotifyBefore != "" {
+		notifyBefore, err = time.ParseDuration(request.NotifyBefore)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid notification period",
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
+
+	policy, err := a.service.SetPolicy(c.Request.Context(), organizationID, secretID, interval, notifyBefore)
+	if err != nil {
+		a.abortWithError(c, logger, err, "Error during setting secret rotation policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, newSecretRotationPolicyResponse(policy))
+}
+
+// DeleteSecretRotation turns off the rotation of a secret
+func (a *SecretRotationAPI) DeleteSecretRotation(c *gin.Context) {
+	logger := correlationid.LogrusLogger(a.logger, c)
+
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	if err := a.service.DeletePolicy(c.Request.Context(), organizationID, secretID); err != nil {
+		a.abortWithError(c, logger, err, "Error during deleting secret rotation policy")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (a *SecretRotationAPI) abortWithError(c *gin.Context, logger logrus.FieldLogger, err error, message string) {
+	status := http.StatusInternalServerError
+
+	var validationErr internalSecret.ValidationError
+
+	switch {
+	case isNotFoundError(err):
+		status = http.StatusNotFound
+	case errors.As(err, &validationErr):
+		status = http.StatusBadRequest
+	default:
+		logger.Error(err.Error())
+	}
+
+	c.AbortWithStatusJSON(status, common.ErrorResponse{
+		Code:    status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+func newSecretRotationPolicyResponse(policy rotation.Policy) SecretRotationPolicyResponse {
+	return SecretRotationPolicyResponse{
+		Interval:      policy.Interval.String(),
+		NotifyBefore:  policy.NotifyBefore.String(),
+		LastRotatedAt: policy.LastRotatedAt,
+		NextRotation:  policy.NextRotation(),
+	}
+}
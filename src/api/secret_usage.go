@@ -0,0 +1,141 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
+	"github.com/banzaicloud/pipeline/internal/secret/usage"
+	"github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// SecretUsageAPI implements secret usage tracking functions
+type SecretUsageAPI struct {
+	service usage.Service
+	logger  logrus.FieldLogger
+}
+
+// NewSecretUsageAPI returns a new SecretUsageAPI instance
+func NewSecretUsageAPI(service usage.Service, logger logrus.FieldLogger) *SecretUsageAPI {
+	return &SecretUsageAPI{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// SecretUsageResponse describes a resource referencing a secret
+type SecretUsageResponse struct {
+	Type      string `json:"type"`
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	ClusterID uint   `json:"clusterId,omitempty"`
+}
+
+// ListSecretUsages lists the resources referencing a secret
+func (a *SecretUsageAPI) ListSecretUsages(c *gin.Context) {
+	logger := correlationid.LogrusLogger(a.logger, c)
+
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	usages, err := a.service.ListUsages(c.Request.Context(), organizationID, secretID)
+	if err != nil {
+		logger.Errorf("error during listing secret usages: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, common.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Error during listing secret usages",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response := make([]SecretUsageResponse, 0, len(usages))
+	for _, u := range usages {
+		response = append(response, SecretUsageResponse{
+			Type:      u.Type,
+			ID:        u.ID,
+			Name:      u.Name,
+			ClusterID: u.ClusterID,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteSecret deletes a secret with the given secret id
+//
+// Secrets referenced by other resources are only deleted when forced.
+func (a *SecretUsageAPI) DeleteSecret(c *gin.Context) {
+	logger := correlationid.LogrusLogger(a.logger, c)
+
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	if c.Query("force") != "true" {
+		err := a.service.CheckUnused(c.Request.Context(), organizationID, secretID)
+
+		var inUseErr usage.InUseError
+		if errors.As(err, &inUseErr) {
+			c.AbortWithStatusJSON(http.StatusConflict, common.ErrorResponse{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("Secret is in use by %s", describeSecretUsages(inUseErr.Usages)),
+				Error:   err.Error(),
+			})
+			return
+		} else if err != nil {
+			logger.Errorf("error during checking secret usages: %s", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, common.ErrorResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "Error during checking secret usages",
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
+
+	if err := deleteSecret(organizationID, secretID, c.Query("soft") == "true"); err != nil {
+		logger.Errorf("error during deleting secret: %s", err.Error())
+		code := http.StatusInternalServerError
+		if errors.Is(err, secret.ErrVersioningNotSupported) {
+			code = http.StatusNotImplemented
+		}
+		c.AbortWithStatusJSON(code, common.ErrorResponse{
+			Code:    code,
+			Message: "Error during deleting secrets",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func describeSecretUsages(usages []usage.Usage) string {
+	descriptions := make([]string, 0, len(usages))
+	for _, u := range usages {
+		descriptions = append(descriptions, fmt.Sprintf("%s %q", u.Type, u.Name))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
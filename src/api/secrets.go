@@ -15,7 +15,6 @@
 package api
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -25,13 +24,9 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
-	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter"
-	"github.com/banzaicloud/pipeline/internal/global"
 	"github.com/banzaicloud/pipeline/internal/secret/restricted"
 	"github.com/banzaicloud/pipeline/pkg/common"
-	"github.com/banzaicloud/pipeline/pkg/providers"
 	"github.com/banzaicloud/pipeline/src/auth"
-	"github.com/banzaicloud/pipeline/src/cluster"
 	"github.com/banzaicloud/pipeline/src/secret"
 )
 
@@ -287,36 +282,136 @@ func GetSecret(c *gin.Context) {
 	}
 }
 
-// DeleteSecrets delete a secret with the given secret id
-func DeleteSecrets(c *gin.Context) {
-	log.Info("Start deleting secrets")
+func deleteSecret(organizationID uint, secretID string, soft bool) error {
+	if soft {
+		return restricted.GlobalSecretStore.SoftDelete(organizationID, secretID)
+	}
 
-	log.Info("Get organization id from params")
+	return restricted.GlobalSecretStore.Delete(organizationID, secretID)
+}
+
+// UndeleteSecret recovers a soft deleted secret with the given secret id
+func UndeleteSecret(c *gin.Context) {
 	organizationID := auth.GetCurrentOrganization(c.Request).ID
-	log.Infof("Organization id: %d", organizationID)
+	secretID := getSecretID(c)
+
+	if err := restricted.GlobalSecretStore.Undelete(organizationID, secretID); err != nil {
+		log.Errorf("Error during undeleting secret: %s", err.Error())
+		status := secretVersionErrorStatus(err)
+		c.AbortWithStatusJSON(status, common.ErrorResponse{
+			Code:    status,
+			Message: "Error during undeleting secret",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
 
+// ListSecretVersions returns the version history of a secret by ID
+func ListSecretVersions(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
 	secretID := getSecretID(c)
 
-	log.Infof("Check clusters before delete secret[%s]", secretID)
-	if err := checkClustersBeforeDelete(organizationID, secretID); err != nil {
-		log.Errorf("Cluster found with this secret[%s]: %s", secretID, err.Error())
-		c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
-			Code:    http.StatusBadRequest,
-			Message: fmt.Sprintf("Cluster found with this secret[%s]", secretID),
+	versions, err := restricted.GlobalSecretStore.ListVersions(organizationID, secretID)
+	if err != nil {
+		log.Errorf("Error during listing secret versions: %s", err.Error())
+		status := secretVersionErrorStatus(err)
+		c.AbortWithStatusJSON(status, common.ErrorResponse{
+			Code:    status,
+			Message: "Error during listing secret versions",
 			Error:   err.Error(),
 		})
-	} else if err := restricted.GlobalSecretStore.Delete(organizationID, secretID); err != nil {
-		log.Errorf("Error during deleting secrets: %s", err.Error())
-		code := http.StatusInternalServerError
-		resp := common.ErrorResponse{
-			Code:    code,
-			Message: "Error during deleting secrets",
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+// GetSecretVersion returns a specific version of a secret by ID
+func GetSecretVersion(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	version, ok := getSecretVersion(c)
+	if !ok {
+		return
+	}
+
+	s, err := restricted.GlobalSecretStore.GetVersion(organizationID, secretID, version)
+	if err != nil {
+		log.Errorf("Error during getting secret version: %s", err.Error())
+		status := secretVersionErrorStatus(err)
+		c.AbortWithStatusJSON(status, common.ErrorResponse{
+			Code:    status,
+			Message: "Error during getting secret version",
 			Error:   err.Error(),
-		}
-		c.AbortWithStatusJSON(code, resp)
-	} else {
-		log.Info("Delete secrets succeeded")
-		c.Status(http.StatusNoContent)
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// RollbackSecret restores a previous version of a secret as its latest version
+func RollbackSecret(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	version, ok := getSecretVersion(c)
+	if !ok {
+		return
+	}
+
+	err := restricted.GlobalSecretStore.Rollback(organizationID, secretID, version, auth.GetCurrentUser(c.Request).Login)
+	if err != nil {
+		log.Errorf("Error during rolling back secret: %s", err.Error())
+		status := secretVersionErrorStatus(err)
+		c.AbortWithStatusJSON(status, common.ErrorResponse{
+			Code:    status,
+			Message: "Error during rolling back secret",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	s, err := restricted.GlobalSecretStore.Get(organizationID, secretID)
+	if err != nil {
+		log.Errorf("Error during getting secret: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, common.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Error during getting secret",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+func getSecretVersion(c *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid secret version",
+			Error:   fmt.Sprintf("invalid secret version: %s", c.Param("version")),
+		})
+		return 0, false
+	}
+
+	return version, true
+}
+
+func secretVersionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, secret.ErrSecretNotExists):
+		return http.StatusNotFound
+	case errors.Is(err, secret.ErrVersioningNotSupported):
+		return http.StatusNotImplemented
+	default:
+		return http.StatusBadRequest
 	}
 }
 
@@ -464,33 +559,6 @@ func DeleteSecretTag(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-// checkClustersBeforeDelete returns error if there's a running cluster that created with the given secret
-func checkClustersBeforeDelete(orgId uint, secretId string) error {
-	// TODO: move these to a struct and create them only once upon application init
-	secretValidator := providers.NewSecretValidator(secret.Store)
-	clusterRepo := clusteradapter.NewClusters(global.DB())
-	clusterStore := clusteradapter.NewStore(global.DB(), clusterRepo)
-	clusterManager := cluster.NewManager(clusterRepo, secretValidator, cluster.NewNopClusterEvents(), nil, nil, nil, log, errorHandler, clusterStore, nil)
-
-	clusters, err := clusterManager.GetClustersBySecretID(context.Background(), orgId, secretId)
-	if err != nil {
-		log.Warnf("could not get clusters: %s", err.Error())
-	}
-
-	if len(clusters) == 0 {
-		log.Infof("no cluster found in database with the given orgId[%d] and secretId[%s]", orgId, secretId)
-		return nil
-	}
-
-	for _, c := range clusters {
-		if _, err := c.GetStatus(); err == nil {
-			return fmt.Errorf("there's a running cluster with this secret: %s[%d]", c.GetName(), c.GetID())
-		}
-	}
-
-	return nil
-}
-
 func addElement(s []string, v string) []string {
 	for _, vv := range s {
 		if vv == v {
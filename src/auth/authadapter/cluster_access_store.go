@@ -0,0 +1,148 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authadapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+const clusterAccessRuleTableName = "organization_cluster_access_rules"
+
+type clusterAccessRuleModel struct {
+	ID             uint                 `gorm:"primary_key"`
+	OrganizationID uint                 `gorm:"index;not null"`
+	SubjectKind    string               `gorm:"not null"`
+	Subject        string               `gorm:"not null"`
+	Selector       clusterSelectorModel `gorm:"type:text"`
+	CreatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (clusterAccessRuleModel) TableName() string {
+	return clusterAccessRuleTableName
+}
+
+func (m clusterAccessRuleModel) toClusterAccessRule() auth.ClusterAccessRule {
+	createdAt := m.CreatedAt
+
+	return auth.ClusterAccessRule{
+		ID:          m.ID,
+		SubjectKind: m.SubjectKind,
+		Subject:     m.Subject,
+		Selector:    auth.ClusterSelector(m.Selector),
+		CreatedAt:   &createdAt,
+	}
+}
+
+type clusterSelectorModel auth.ClusterSelector
+
+// Scan implements the sql.Scanner interface.
+func (m *clusterSelectorModel) Scan(src interface{}) error {
+	return json.Scan(src, m)
+}
+
+// Value implements the driver.Valuer interface.
+func (m clusterSelectorModel) Value() (driver.Value, error) {
+	return json.Value(m)
+}
+
+// ListClusterAccessRules lists the cluster access rules of an organization.
+func (s GormRoleStore) ListClusterAccessRules(ctx context.Context, organizationID uint) ([]auth.ClusterAccessRule, error) {
+	var models []clusterAccessRuleModel
+
+	err := s.db.Where(clusterAccessRuleModel{OrganizationID: organizationID}).Order("id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list cluster access rules", "organizationId", organizationID)
+	}
+
+	rules := make([]auth.ClusterAccessRule, 0, len(models))
+	for _, model := range models {
+		rules = append(rules, model.toClusterAccessRule())
+	}
+
+	return rules, nil
+}
+
+// CreateClusterAccessRule persists a new cluster access rule.
+func (s GormRoleStore) CreateClusterAccessRule(ctx context.Context, organizationID uint, rule auth.ClusterAccessRule) (auth.ClusterAccessRule, error) {
+	model := clusterAccessRuleModel{
+		OrganizationID: organizationID,
+		SubjectKind:    rule.SubjectKind,
+		Subject:        rule.Subject,
+		Selector:       clusterSelectorModel(rule.Selector),
+	}
+
+	err := s.db.Create(&model).Error
+	if err != nil {
+		return auth.ClusterAccessRule{}, errors.WrapIfWithDetails(
+			err, "failed to create cluster access rule",
+			"organizationId", organizationID,
+		)
+	}
+
+	return model.toClusterAccessRule(), nil
+}
+
+// DeleteClusterAccessRule deletes a cluster access rule.
+func (s GormRoleStore) DeleteClusterAccessRule(ctx context.Context, organizationID uint, ruleID uint) error {
+	result := s.db.Where("id = ? AND organization_id = ?", ruleID, organizationID).Delete(clusterAccessRuleModel{})
+	if result.Error != nil {
+		return errors.WrapIfWithDetails(
+			result.Error, "failed to delete cluster access rule",
+			"organizationId", organizationID,
+			"clusterAccessRuleId", ruleID,
+		)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.WithStack(auth.ClusterAccessRuleNotFoundError{ID: ruleID})
+	}
+
+	return nil
+}
+
+// FindUserClusterSelectors returns the cluster selectors of the access rules applying to a user
+// either directly or through one of the user's groups.
+func (s GormRoleStore) FindUserClusterSelectors(ctx context.Context, organizationID uint, userID uint) ([]auth.ClusterSelector, error) {
+	query, member, err := s.userSubjectQuery(organizationID, userID)
+	if err != nil || !member {
+		return nil, err
+	}
+
+	var models []clusterAccessRuleModel
+
+	err = query.Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to find cluster access rules of user",
+			"organizationId", organizationID,
+			"userId", userID,
+		)
+	}
+
+	selectors := make([]auth.ClusterSelector, 0, len(models))
+	for _, model := range models {
+		selectors = append(selectors, auth.ClusterSelector(model.Selector))
+	}
+
+	return selectors, nil
+}
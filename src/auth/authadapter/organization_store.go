@@ -170,6 +170,25 @@ func (g GormOrganizationStore) ApplyUserMembership(ctx context.Context, organiza
 	return nil
 }
 
+// ApplyUserGroups stores the upstream groups of a user within an organization.
+func (g GormOrganizationStore) ApplyUserGroups(ctx context.Context, organizationID uint, userID uint, groups []string) error {
+	err := g.db.
+		Model(&auth.UserOrganization{}).
+		Where(auth.UserOrganization{UserID: userID, OrganizationID: organizationID}).
+		Update("groups", auth.UserGroups(groups)).
+		Error
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err,
+			"failed to apply user groups",
+			"userId", userID,
+			"organizationId", organizationID,
+		)
+	}
+
+	return nil
+}
+
 // FindUserRole returns the user's role in a given organization.
 // Returns false as the second parameter if the user is not a member of the organization.
 func (g GormOrganizationStore) FindUserRole(ctx context.Context, orgID uint, userID uint) (string, bool, error) {
@@ -0,0 +1,371 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authadapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// TableName constants
+const (
+	roleTableName        = "organization_roles"
+	roleBindingTableName = "organization_role_bindings"
+)
+
+type roleModel struct {
+	ID             uint             `gorm:"primary_key"`
+	OrganizationID uint             `gorm:"unique_index:idx_organization_roles_org_id_name;not null"`
+	Name           string           `gorm:"unique_index:idx_organization_roles_org_id_name;not null"`
+	Description    string           `gorm:"type:text"`
+	Rules          policyRulesModel `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (roleModel) TableName() string {
+	return roleTableName
+}
+
+func (m roleModel) toRole() auth.Role {
+	createdAt, updatedAt := m.CreatedAt, m.UpdatedAt
+
+	return auth.Role{
+		Name:        m.Name,
+		Description: m.Description,
+		Rules:       m.Rules,
+		CreatedAt:   &createdAt,
+		UpdatedAt:   &updatedAt,
+	}
+}
+
+type policyRulesModel []auth.PolicyRule
+
+// Scan implements the sql.Scanner interface.
+func (m *policyRulesModel) Scan(src interface{}) error {
+	return json.Scan(src, m)
+}
+
+// Value implements the driver.Valuer interface.
+func (m policyRulesModel) Value() (driver.Value, error) {
+	return json.Value(m)
+}
+
+type roleBindingModel struct {
+	ID             uint   `gorm:"primary_key"`
+	OrganizationID uint   `gorm:"index;not null"`
+	Role           string `gorm:"not null"`
+	SubjectKind    string `gorm:"not null"`
+	Subject        string `gorm:"not null"`
+	CreatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (roleBindingModel) TableName() string {
+	return roleBindingTableName
+}
+
+func (m roleBindingModel) toRoleBinding() auth.RoleBinding {
+	createdAt := m.CreatedAt
+
+	return auth.RoleBinding{
+		ID:          m.ID,
+		Role:        m.Role,
+		SubjectKind: m.SubjectKind,
+		Subject:     m.Subject,
+		CreatedAt:   &createdAt,
+	}
+}
+
+// GormRoleStore implements custom role and role binding persistence using Gorm.
+type GormRoleStore struct {
+	db *gorm.DB
+}
+
+// NewGormRoleStore returns a new GormRoleStore.
+func NewGormRoleStore(db *gorm.DB) GormRoleStore {
+	return GormRoleStore{
+		db: db,
+	}
+}
+
+// ListRoles lists the custom roles of an organization.
+func (s GormRoleStore) ListRoles(ctx context.Context, organizationID uint) ([]auth.Role, error) {
+	var models []roleModel
+
+	err := s.db.Where(roleModel{OrganizationID: organizationID}).Order("name").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list roles", "organizationId", organizationID)
+	}
+
+	roles := make([]auth.Role, 0, len(models))
+	for _, model := range models {
+		roles = append(roles, model.toRole())
+	}
+
+	return roles, nil
+}
+
+// GetRole returns a custom role.
+func (s GormRoleStore) GetRole(ctx context.Context, organizationID uint, roleName string) (auth.Role, error) {
+	model, err := s.getRoleModel(organizationID, roleName)
+	if err != nil {
+		return auth.Role{}, err
+	}
+
+	return model.toRole(), nil
+}
+
+func (s GormRoleStore) getRoleModel(organizationID uint, roleName string) (roleModel, error) {
+	var model roleModel
+
+	err := s.db.Where("organization_id = ? AND name = ?", organizationID, roleName).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return model, errors.WithStack(auth.RoleNotFoundError{Name: roleName})
+	} else if err != nil {
+		return model, errors.WrapIfWithDetails(
+			err, "failed to get role",
+			"organizationId", organizationID,
+			"role", roleName,
+		)
+	}
+
+	return model, nil
+}
+
+// CreateRole persists a new custom role.
+func (s GormRoleStore) CreateRole(ctx context.Context, organizationID uint, role auth.Role) (auth.Role, error) {
+	_, err := s.getRoleModel(organizationID, role.Name)
+	if err == nil {
+		return auth.Role{}, errors.WithStack(auth.RoleAlreadyExistsError{Name: role.Name})
+	} else if !errors.As(err, &auth.RoleNotFoundError{}) {
+		return auth.Role{}, err
+	}
+
+	model := roleModel{
+		OrganizationID: organizationID,
+		Name:           role.Name,
+		Description:    role.Description,
+		Rules:          role.Rules,
+	}
+
+	err = s.db.Create(&model).Error
+	if err != nil {
+		return auth.Role{}, errors.WrapIfWithDetails(
+			err, "failed to create role",
+			"organizationId", organizationID,
+			"role", role.Name,
+		)
+	}
+
+	return model.toRole(), nil
+}
+
+// UpdateRole updates an existing custom role.
+func (s GormRoleStore) UpdateRole(ctx context.Context, organizationID uint, role auth.Role) (auth.Role, error) {
+	model, err := s.getRoleModel(organizationID, role.Name)
+	if err != nil {
+		return auth.Role{}, err
+	}
+
+	model.Description = role.Description
+	model.Rules = role.Rules
+
+	err = s.db.Save(&model).Error
+	if err != nil {
+		return auth.Role{}, errors.WrapIfWithDetails(
+			err, "failed to update role",
+			"organizationId", organizationID,
+			"role", role.Name,
+		)
+	}
+
+	return model.toRole(), nil
+}
+
+// DeleteRole deletes a custom role and its bindings.
+func (s GormRoleStore) DeleteRole(ctx context.Context, organizationID uint, roleName string) error {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return errors.WrapIf(tx.Error, "failed to begin transaction")
+	}
+
+	err := tx.Where("organization_id = ? AND role = ?", organizationID, roleName).Delete(roleBindingModel{}).Error
+	if err != nil {
+		tx.Rollback()
+
+		return errors.WrapIfWithDetails(
+			err, "failed to delete role bindings",
+			"organizationId", organizationID,
+			"role", roleName,
+		)
+	}
+
+	err = tx.Where("organization_id = ? AND name = ?", organizationID, roleName).Delete(roleModel{}).Error
+	if err != nil {
+		tx.Rollback()
+
+		return errors.WrapIfWithDetails(
+			err, "failed to delete role",
+			"organizationId", organizationID,
+			"role", roleName,
+		)
+	}
+
+	return errors.WrapIf(tx.Commit().Error, "failed to commit transaction")
+}
+
+// ListRoleBindings lists the role bindings of an organization.
+func (s GormRoleStore) ListRoleBindings(ctx context.Context, organizationID uint) ([]auth.RoleBinding, error) {
+	var models []roleBindingModel
+
+	err := s.db.Where(roleBindingModel{OrganizationID: organizationID}).Order("id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list role bindings", "organizationId", organizationID)
+	}
+
+	bindings := make([]auth.RoleBinding, 0, len(models))
+	for _, model := range models {
+		bindings = append(bindings, model.toRoleBinding())
+	}
+
+	return bindings, nil
+}
+
+// CreateRoleBinding persists a new role binding.
+func (s GormRoleStore) CreateRoleBinding(ctx context.Context, organizationID uint, binding auth.RoleBinding) (auth.RoleBinding, error) {
+	model := roleBindingModel{
+		OrganizationID: organizationID,
+		Role:           binding.Role,
+		SubjectKind:    binding.SubjectKind,
+		Subject:        binding.Subject,
+	}
+
+	err := s.db.Create(&model).Error
+	if err != nil {
+		return auth.RoleBinding{}, errors.WrapIfWithDetails(
+			err, "failed to create role binding",
+			"organizationId", organizationID,
+			"role", binding.Role,
+		)
+	}
+
+	return model.toRoleBinding(), nil
+}
+
+// DeleteRoleBinding deletes a role binding.
+func (s GormRoleStore) DeleteRoleBinding(ctx context.Context, organizationID uint, bindingID uint) error {
+	result := s.db.Where("id = ? AND organization_id = ?", bindingID, organizationID).Delete(roleBindingModel{})
+	if result.Error != nil {
+		return errors.WrapIfWithDetails(
+			result.Error, "failed to delete role binding",
+			"organizationId", organizationID,
+			"roleBindingId", bindingID,
+		)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.WithStack(auth.RoleBindingNotFoundError{ID: bindingID})
+	}
+
+	return nil
+}
+
+// FindUserRules returns the rules of the custom roles bound to a user either directly
+// or through one of the user's groups.
+func (s GormRoleStore) FindUserRules(ctx context.Context, organizationID uint, userID uint) ([]auth.PolicyRule, error) {
+	query, member, err := s.userSubjectQuery(organizationID, userID)
+	if err != nil || !member {
+		return nil, err
+	}
+
+	var bindings []roleBindingModel
+
+	err = query.Find(&bindings).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to find role bindings of user",
+			"organizationId", organizationID,
+			"userId", userID,
+		)
+	}
+
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+
+	roleNames := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		roleNames = append(roleNames, binding.Role)
+	}
+
+	var roles []roleModel
+
+	err = s.db.Where("organization_id = ? AND name IN (?)", organizationID, roleNames).Find(&roles).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to find roles of user",
+			"organizationId", organizationID,
+			"userId", userID,
+		)
+	}
+
+	var rules []auth.PolicyRule
+	for _, role := range roles {
+		rules = append(rules, role.Rules...)
+	}
+
+	return rules, nil
+}
+
+// userSubjectQuery returns a query selecting the records of an organization whose subject is either the user
+// or one of the user's groups.
+// Returns false as the second parameter if the user is not a member of the organization.
+func (s GormRoleStore) userSubjectQuery(organizationID uint, userID uint) (*gorm.DB, bool, error) {
+	var membership auth.UserOrganization
+
+	err := s.db.Where(auth.UserOrganization{UserID: userID, OrganizationID: organizationID}).First(&membership).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, errors.WrapIfWithDetails(
+			err, "cannot fetch organization membership details from the database",
+			"organizationId", organizationID,
+			"userId", userID,
+		)
+	}
+
+	query := s.db.Where("organization_id = ?", organizationID)
+	if len(membership.Groups) > 0 {
+		query = query.Where(
+			"(subject_kind = ? AND subject = ?) OR (subject_kind = ? AND subject IN (?))",
+			auth.SubjectKindUser, fmt.Sprint(userID),
+			auth.SubjectKindGroup, []string(membership.Groups),
+		)
+	} else {
+		query = query.Where("subject_kind = ? AND subject = ?", auth.SubjectKindUser, fmt.Sprint(userID))
+	}
+
+	return query, true, nil
+}
@@ -0,0 +1,148 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authadapter
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+func TestGormRoleStore(t *testing.T) {
+	db := setUpDatabase(t)
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	err := Migrate(db, logger)
+	require.NoError(t, err)
+
+	store := NewGormRoleStore(db)
+	ctx := context.Background()
+
+	role := auth.Role{
+		Name: "secret-reader",
+		Rules: []auth.PolicyRule{
+			{
+				Resources: []string{"secrets"},
+				Verbs:     []string{auth.VerbGet},
+			},
+		},
+	}
+
+	_, err = store.CreateRole(ctx, 1, role)
+	require.NoError(t, err)
+
+	_, err = store.CreateRole(ctx, 1, role)
+	assert.True(t, errors.As(err, &auth.RoleAlreadyExistsError{}))
+
+	roles, err := store.ListRoles(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, role.Rules, roles[0].Rules)
+
+	err = db.Create(&auth.UserOrganization{
+		UserID:         1,
+		OrganizationID: 1,
+		Role:           auth.RoleMember,
+		Groups:         auth.UserGroups{"developers"},
+	}).Error
+	require.NoError(t, err)
+
+	rules, err := store.FindUserRules(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	binding, err := store.CreateRoleBinding(ctx, 1, auth.RoleBinding{
+		Role:        role.Name,
+		SubjectKind: auth.SubjectKindGroup,
+		Subject:     "developers",
+	})
+	require.NoError(t, err)
+
+	rules, err = store.FindUserRules(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, role.Rules, rules)
+
+	rules, err = store.FindUserRules(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	err = store.DeleteRoleBinding(ctx, 1, binding.ID)
+	require.NoError(t, err)
+
+	err = store.DeleteRoleBinding(ctx, 1, binding.ID)
+	assert.True(t, errors.As(err, &auth.RoleBindingNotFoundError{}))
+
+	err = store.DeleteRole(ctx, 1, role.Name)
+	require.NoError(t, err)
+
+	_, err = store.GetRole(ctx, 1, role.Name)
+	assert.True(t, errors.As(err, &auth.RoleNotFoundError{}))
+}
+
+func TestGormRoleStore_ClusterAccessRules(t *testing.T) {
+	db := setUpDatabase(t)
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	err := Migrate(db, logger)
+	require.NoError(t, err)
+
+	store := NewGormRoleStore(db)
+	ctx := context.Background()
+
+	err = db.Create(&auth.UserOrganization{
+		UserID:         1,
+		OrganizationID: 1,
+		Role:           auth.RoleMember,
+	}).Error
+	require.NoError(t, err)
+
+	selector := auth.ClusterSelector{
+		NamePatterns: []string{"dev-*"},
+		Tags:         map[string]string{"env": "dev"},
+	}
+
+	rule, err := store.CreateClusterAccessRule(ctx, 1, auth.ClusterAccessRule{
+		SubjectKind: auth.SubjectKindUser,
+		Subject:     "1",
+		Selector:    selector,
+	})
+	require.NoError(t, err)
+
+	rules, err := store.ListClusterAccessRules(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, selector, rules[0].Selector)
+
+	selectors, err := store.FindUserClusterSelectors(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []auth.ClusterSelector{selector}, selectors)
+
+	err = store.DeleteClusterAccessRule(ctx, 1, rule.ID)
+	require.NoError(t, err)
+
+	err = store.DeleteClusterAccessRule(ctx, 1, rule.ID)
+	assert.True(t, errors.As(err, &auth.ClusterAccessRuleNotFoundError{}))
+}
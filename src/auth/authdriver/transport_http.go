@@ -0,0 +1,337 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// RegisterRoleHTTPHandlers mounts the role, role binding and cluster access rule endpoints into a router.
+func RegisterRoleHTTPHandlers(endpoints RoleEndpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodGet).Path("/roles").Handler(kithttp.NewServer(
+		endpoints.ListRoles,
+		decodeListRolesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListRolesHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/roles").Handler(kithttp.NewServer(
+		endpoints.CreateRole,
+		decodeCreateRoleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateRoleHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/roles/{roleName}").Handler(kithttp.NewServer(
+		endpoints.GetRole,
+		decodeGetRoleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetRoleHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPut).Path("/roles/{roleName}").Handler(kithttp.NewServer(
+		endpoints.UpdateRole,
+		decodeUpdateRoleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeUpdateRoleHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/roles/{roleName}").Handler(kithttp.NewServer(
+		endpoints.DeleteRole,
+		decodeDeleteRoleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/rolebindings").Handler(kithttp.NewServer(
+		endpoints.ListRoleBindings,
+		decodeListRoleBindingsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListRoleBindingsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/rolebindings").Handler(kithttp.NewServer(
+		endpoints.CreateRoleBinding,
+		decodeCreateRoleBindingHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateRoleBindingHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/rolebindings/{bindingId}").Handler(kithttp.NewServer(
+		endpoints.DeleteRoleBinding,
+		decodeDeleteRoleBindingHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/clusteraccessrules").Handler(kithttp.NewServer(
+		endpoints.ListClusterAccessRules,
+		decodeListClusterAccessRulesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListClusterAccessRulesHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/clusteraccessrules").Handler(kithttp.NewServer(
+		endpoints.CreateClusterAccessRule,
+		decodeCreateClusterAccessRuleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateClusterAccessRuleHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/clusteraccessrules/{ruleId}").Handler(kithttp.NewServer(
+		endpoints.DeleteClusterAccessRule,
+		decodeDeleteClusterAccessRuleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+}
+
+func decodeListRolesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListRolesRoleRequest{OrganizationID: orgID}, nil
+}
+
+func encodeListRolesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListRolesRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Roles)
+}
+
+func decodeCreateRoleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var role auth.Role
+
+	err = json.NewDecoder(r.Body).Decode(&role)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return CreateRoleRoleRequest{OrganizationID: orgID, Role: role}, nil
+}
+
+func encodeCreateRoleHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateRoleRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.NewRole, http.StatusCreated))
+}
+
+func decodeGetRoleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	roleName, err := extractStringParamFromRequest("roleName", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetRoleRoleRequest{OrganizationID: orgID, RoleName: roleName}, nil
+}
+
+func encodeGetRoleHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetRoleRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Role)
+}
+
+func decodeUpdateRoleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	roleName, err := extractStringParamFromRequest("roleName", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var role auth.Role
+
+	err = json.NewDecoder(r.Body).Decode(&role)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return UpdateRoleRoleRequest{OrganizationID: orgID, RoleName: roleName, Role: role}, nil
+}
+
+func encodeUpdateRoleHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(UpdateRoleRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.UpdatedRole)
+}
+
+func decodeDeleteRoleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	roleName, err := extractStringParamFromRequest("roleName", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeleteRoleRoleRequest{OrganizationID: orgID, RoleName: roleName}, nil
+}
+
+func decodeListRoleBindingsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListRoleBindingsRoleRequest{OrganizationID: orgID}, nil
+}
+
+func encodeListRoleBindingsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListRoleBindingsRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Bindings)
+}
+
+func decodeCreateRoleBindingHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var binding auth.RoleBinding
+
+	err = json.NewDecoder(r.Body).Decode(&binding)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return CreateRoleBindingRoleRequest{OrganizationID: orgID, Binding: binding}, nil
+}
+
+func encodeCreateRoleBindingHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateRoleBindingRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.NewBinding, http.StatusCreated))
+}
+
+func decodeDeleteRoleBindingHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	bindingID, err := extractUintParamFromRequest("bindingId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeleteRoleBindingRoleRequest{OrganizationID: orgID, BindingID: bindingID}, nil
+}
+
+func decodeListClusterAccessRulesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListClusterAccessRulesRoleRequest{OrganizationID: orgID}, nil
+}
+
+func encodeListClusterAccessRulesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListClusterAccessRulesRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Rules)
+}
+
+func decodeCreateClusterAccessRuleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule auth.ClusterAccessRule
+
+	err = json.NewDecoder(r.Body).Decode(&rule)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return CreateClusterAccessRuleRoleRequest{OrganizationID: orgID, Rule: rule}, nil
+}
+
+func encodeCreateClusterAccessRuleHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateClusterAccessRuleRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.NewRule, http.StatusCreated))
+}
+
+func decodeDeleteClusterAccessRuleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleID, err := extractUintParamFromRequest("ruleId", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeleteClusterAccessRuleRoleRequest{OrganizationID: orgID, RuleID: ruleID}, nil
+}
+
+func extractUintParamFromRequest(key string, r *http.Request) (uint, error) {
+	strVal, err := extractStringParamFromRequest(key, r)
+	if err != nil {
+		return 0, err
+	}
+
+	uintVal, err := strconv.ParseUint(strVal, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to parse path parameter", "param", key, "value", strVal)
+	}
+
+	return uint(uintVal), nil
+}
+
+func extractStringParamFromRequest(key string, r *http.Request) (string, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[key]
+	if !ok || value == "" {
+		return "", errors.NewWithDetails("missing path parameter", "param", key)
+	}
+
+	return value, nil
+}
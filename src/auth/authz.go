@@ -30,6 +30,7 @@ import (
 // RbacEnforcer makes authorization decisions based on user roles.
 type RbacEnforcer struct {
 	roleSource            RoleSource
+	policySource          PolicySource
 	serviceAccountService ServiceAccountService
 	logger                Logger
 }
@@ -44,9 +45,11 @@ type RoleSource interface {
 }
 
 // NewRbacEnforcer returns a new RbacEnforcer.
-func NewRbacEnforcer(roleSource RoleSource, serviceAccountService ServiceAccountService, logger Logger) RbacEnforcer {
+// The policy source is optional: without it only the built-in roles are enforced.
+func NewRbacEnforcer(roleSource RoleSource, policySource PolicySource, serviceAccountService ServiceAccountService, logger Logger) RbacEnforcer {
 	return RbacEnforcer{
 		roleSource:            roleSource,
+		policySource:          policySource,
 		serviceAccountService: serviceAccountService,
 
 		logger: logger,
@@ -144,22 +147,12 @@ func (e RbacEnforcer) Enforce(org *Organization, user *User, path, method string
 	case RoleAdmin:
 		return true, nil
 	case RoleMember:
-		// Members can only read organization resources
-		if ok, err := regexp.MatchString(`^/api/v1/orgs(?:/.*)?$`, path); err != nil || (ok && method != http.MethodGet && method != http.MethodHead) {
-			return false, errors.WithStackIf(err)
+		allowed, err := memberAllowed(path, method)
+		if err != nil || allowed {
+			return allowed, err
 		}
 
-		// Members cannot download admin kube config
-		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/clusters/[^/]+/config$`, path); err != nil || ok {
-			return false, errors.WithStackIf(err)
-		}
-
-		// Members cannot access secrets at all
-		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/secrets(?:/.*)?$`, path); err != nil || ok {
-			return false, errors.WithStackIf(err)
-		}
-
-		return true, nil
+		return e.customRolesAllow(org, user, path, method)
 	default:
 		return false, errors.NewWithDetails(
 			"unknown membership role",
@@ -172,17 +165,68 @@ func (e RbacEnforcer) Enforce(org *Organization, user *User, path, method string
 	}
 }
 
+// memberAllowed decides whether the built-in member role allows a request.
+func memberAllowed(path, method string) (bool, error) {
+	// Members can only read organization resources
+	if ok, err := regexp.MatchString(`^/api/v1/orgs(?:/.*)?$`, path); err != nil || (ok && method != http.MethodGet && method != http.MethodHead) {
+		return false, errors.WithStackIf(err)
+	}
+
+	// Members cannot download admin kube config
+	if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/clusters/[^/]+/config$`, path); err != nil || ok {
+		return false, errors.WithStackIf(err)
+	}
+
+	// Members cannot access secrets at all
+	if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/secrets(?:/.*)?$`, path); err != nil || ok {
+		return false, errors.WithStackIf(err)
+	}
+
+	// Members cannot read the audit log
+	if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/auditlog$`, path); err != nil || ok {
+		return false, errors.WithStackIf(err)
+	}
+
+	return true, nil
+}
+
+// customRolesAllow decides whether the custom roles bound to a user allow a request.
+func (e RbacEnforcer) customRolesAllow(org *Organization, user *User, path, method string) (bool, error) {
+	if e.policySource == nil {
+		return false, nil
+	}
+
+	resource, ok := resourceForPath(path)
+	if !ok {
+		return false, nil
+	}
+
+	rules, err := e.policySource.FindUserRules(context.Background(), org.ID, user.ID)
+	if err != nil {
+		return false, errors.WrapIfWithDetails(
+			err, "failed to find custom role rules of user",
+			"method", method,
+			"path", path,
+		)
+	}
+
+	return PolicyRules(rules).Allows(resource, verbForMethod(method)), nil
+}
+
 // Authorizer checks if a context has permission to execute an action.
 type Authorizer struct {
-	db         *gorm.DB
-	roleSource RoleSource
+	db           *gorm.DB
+	roleSource   RoleSource
+	policySource PolicySource
 }
 
 // NewAuthorizer returns a new Authorizer.
-func NewAuthorizer(db *gorm.DB, roleSource RoleSource) Authorizer {
+// The policy source is optional: without it only the built-in roles are authorized.
+func NewAuthorizer(db *gorm.DB, roleSource RoleSource, policySource PolicySource) Authorizer {
 	return Authorizer{
-		db:         db,
-		roleSource: roleSource,
+		db:           db,
+		roleSource:   roleSource,
+		policySource: policySource,
 	}
 }
 
@@ -212,10 +256,24 @@ func (a Authorizer) Authorize(ctx context.Context, action string, object interfa
 			return false, errors.WithMessage(err, "failed to query organization membership for virtual user")
 		}
 
-		// TODO: implement better authorization here
-		if !member || role != RoleAdmin {
+		if !member {
 			return false, nil
 		}
+
+		if role == RoleAdmin {
+			return true, nil
+		}
+
+		if a.policySource == nil {
+			return false, nil
+		}
+
+		rules, err := a.policySource.FindUserRules(ctx, organization.ID, userID)
+		if err != nil {
+			return false, errors.WithMessage(err, "failed to query custom role rules for virtual user")
+		}
+
+		return PolicyRules(rules).Allows(ResourceVirtualUsers, VerbCreate), nil
 	}
 
 	return true, nil
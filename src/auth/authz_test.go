@@ -26,7 +26,7 @@ import (
 )
 
 func TestRbacEnforcer_Enforce_NoOrgIsAllowed(t *testing.T) {
-	enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+	enforcer := NewRbacEnforcer(nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 	ok, err := enforcer.Enforce(nil, &User{}, "/", "GET", nil)
 	require.NoError(t, err)
@@ -35,7 +35,7 @@ func TestRbacEnforcer_Enforce_NoOrgIsAllowed(t *testing.T) {
 }
 
 func TestRbacEnforcer_Enforce_NoUserIsNotAllowed(t *testing.T) {
-	enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+	enforcer := NewRbacEnforcer(nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 	ok, err := enforcer.Enforce(&Organization{}, nil, "/", "GET", nil)
 	require.NoError(t, err)
@@ -120,7 +120,7 @@ func TestRbacEnforcer_Enforce_VirtualUser(t *testing.T) {
 		test := test
 
 		t.Run("", func(t *testing.T) {
-			enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+			enforcer := NewRbacEnforcer(nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 			ok, err := enforcer.Enforce(&test.organization, &test.user, test.path, "GET", test.query)
 			require.NoError(t, err)
@@ -257,7 +257,7 @@ func TestRbacEnforcer_Enforce_VirtualUser_Invalid(t *testing.T) {
 		test := test
 
 		t.Run(test.user.Login, func(t *testing.T) {
-			enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+			enforcer := NewRbacEnforcer(nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 			ok, err := enforcer.Enforce(&test.organization, &test.user, test.path, "GET", test.query)
 			if test.error {
@@ -283,7 +283,7 @@ func TestRbacEnforcer_Enforce_NotAMember(t *testing.T) {
 	roleSource := &MockRoleSource{}
 	roleSource.On("FindUserRole", mock.Anything, org.ID, user.ID).Return("", false, nil)
 
-	enforcer := NewRbacEnforcer(roleSource, NewServiceAccountService(), common.NoopLogger{})
+	enforcer := NewRbacEnforcer(roleSource, nil, NewServiceAccountService(), common.NoopLogger{})
 
 	ok, err := enforcer.Enforce(&org, &user, "/", "GET", nil)
 	require.NoError(t, err)
@@ -383,7 +383,7 @@ func TestRbacEnforcer_Enforce(t *testing.T) {
 			roleSource := &MockRoleSource{}
 			roleSource.On("FindUserRole", mock.Anything, org.ID, user.ID).Return(test.role, true, nil)
 
-			enforcer := NewRbacEnforcer(roleSource, NewServiceAccountService(), common.NoopLogger{})
+			enforcer := NewRbacEnforcer(roleSource, nil, NewServiceAccountService(), common.NoopLogger{})
 
 			ok, err := enforcer.Enforce(&org, &user, test.path, test.method, nil)
 			require.NoError(t, err)
@@ -0,0 +1,192 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// ClusterSelector selects clusters of an organization.
+// A cluster is selected if it matches any of the IDs, any of the name patterns or all of the tags.
+type ClusterSelector struct {
+	// IDs selects clusters by their ID.
+	IDs []uint `json:"ids,omitempty"`
+
+	// NamePatterns selects clusters by their name using shell file name patterns (eg. "dev-*").
+	NamePatterns []string `json:"namePatterns,omitempty"`
+
+	// Tags selects clusters having every listed tag with the given value.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Empty checks whether the selector selects nothing.
+func (s ClusterSelector) Empty() bool {
+	return len(s.IDs) == 0 && len(s.NamePatterns) == 0 && len(s.Tags) == 0
+}
+
+// Matches checks whether the selector selects a cluster.
+func (s ClusterSelector) Matches(clusterID uint, clusterName string, clusterTags map[string]string) bool {
+	for _, id := range s.IDs {
+		if id == clusterID {
+			return true
+		}
+	}
+
+	for _, pattern := range s.NamePatterns {
+		if ok, _ := path.Match(pattern, clusterName); ok {
+			return true
+		}
+	}
+
+	if len(s.Tags) == 0 {
+		return false
+	}
+
+	for key, value := range s.Tags {
+		if v, ok := clusterTags[key]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ClusterAccessRule restricts a user or a group to a subset of the clusters within an organization.
+//
+// Subjects without any rules can access every cluster of the organization (subject to their roles).
+// Subjects with rules can only access the clusters selected by at least one of them.
+// Organization admins are never restricted.
+type ClusterAccessRule struct {
+	ID uint `json:"id"`
+
+	// SubjectKind is either "user" or "group".
+	SubjectKind string `json:"subjectKind"`
+
+	// Subject is the user ID for user rules or the group (team) name for group rules.
+	Subject string `json:"subject"`
+
+	Selector ClusterSelector `json:"selector"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// +testify:mock:testOnly=true
+
+// ClusterAccessSource returns the cluster selectors of the access rules applying to a user in a given organization.
+type ClusterAccessSource interface {
+	// FindUserClusterSelectors returns the cluster selectors of the access rules applying to a user
+	// either directly or through one of the user's groups.
+	FindUserClusterSelectors(ctx context.Context, organizationID uint, userID uint) ([]ClusterSelector, error)
+}
+
+// ClusterAccessResolver decides whether the user in the context can access a cluster.
+type ClusterAccessResolver struct {
+	roleSource RoleSource
+	source     ClusterAccessSource
+}
+
+// NewClusterAccessResolver returns a new ClusterAccessResolver.
+func NewClusterAccessResolver(roleSource RoleSource, source ClusterAccessSource) ClusterAccessResolver {
+	return ClusterAccessResolver{
+		roleSource: roleSource,
+		source:     source,
+	}
+}
+
+// CanAccessCluster checks whether the user in the context can access a cluster.
+//
+// Contexts without a user (eg. background processes) and virtual users are not restricted:
+// virtual user access is decided by the RbacEnforcer.
+func (r ClusterAccessResolver) CanAccessCluster(
+	ctx context.Context,
+	organizationID uint,
+	clusterID uint,
+	clusterName string,
+	clusterTags map[string]string,
+) (bool, error) {
+	user, ok := ctx.Value(CurrentUser).(*User)
+	if !ok || user == nil || user.ID == 0 {
+		return true, nil
+	}
+
+	role, member, err := r.roleSource.FindUserRole(ctx, organizationID, user.ID)
+	if err != nil {
+		return false, errors.WrapIfWithDetails(
+			err, "failed to check user organization membership",
+			"organizationId", organizationID,
+			"userId", user.ID,
+		)
+	}
+
+	if !member {
+		return false, nil
+	}
+
+	if role == RoleAdmin {
+		return true, nil
+	}
+
+	selectors, err := r.source.FindUserClusterSelectors(ctx, organizationID, user.ID)
+	if err != nil {
+		return false, errors.WrapIfWithDetails(
+			err, "failed to find cluster access rules of user",
+			"organizationId", organizationID,
+			"userId", user.ID,
+		)
+	}
+
+	if len(selectors) == 0 {
+		return true, nil
+	}
+
+	for _, selector := range selectors {
+		if selector.Matches(clusterID, clusterName, clusterTags) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ClusterAccessRuleNotFoundError is returned if a cluster access rule cannot be found.
+type ClusterAccessRuleNotFoundError struct {
+	ID uint
+}
+
+// Error implements the error interface.
+func (ClusterAccessRuleNotFoundError) Error() string {
+	return "cluster access rule not found"
+}
+
+// Details returns error details.
+func (e ClusterAccessRuleNotFoundError) Details() []interface{} {
+	return []interface{}{"clusterAccessRuleId", e.ID}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (ClusterAccessRuleNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (ClusterAccessRuleNotFoundError) ServiceError() bool {
+	return true
+}
@@ -0,0 +1,111 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterSelector_Matches(t *testing.T) {
+	tags := map[string]string{"env": "dev", "team": "ops"}
+
+	tests := []struct {
+		selector ClusterSelector
+		matches  bool
+	}{
+		{selector: ClusterSelector{IDs: []uint{1, 2}}, matches: true},
+		{selector: ClusterSelector{IDs: []uint{3}}, matches: false},
+		{selector: ClusterSelector{NamePatterns: []string{"dev-*"}}, matches: true},
+		{selector: ClusterSelector{NamePatterns: []string{"prod-*"}}, matches: false},
+		{selector: ClusterSelector{Tags: map[string]string{"env": "dev"}}, matches: true},
+		{selector: ClusterSelector{Tags: map[string]string{"env": "dev", "team": "dev"}}, matches: false},
+		{selector: ClusterSelector{}, matches: false},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, test.matches, test.selector.Matches(2, "dev-cluster", tags))
+		})
+	}
+}
+
+func TestClusterAccessResolver_CanAccessCluster(t *testing.T) {
+	user := &User{ID: 1}
+	ctx := context.WithValue(context.Background(), CurrentUser, user)
+
+	t.Run("NoUser", func(t *testing.T) {
+		resolver := NewClusterAccessResolver(new(MockRoleSource), new(MockClusterAccessSource))
+
+		ok, err := resolver.CanAccessCluster(context.Background(), 1, 1, "cluster", nil)
+		require.NoError(t, err)
+
+		assert.True(t, ok)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		roleSource := new(MockRoleSource)
+		roleSource.On("FindUserRole", ctx, uint(1), user.ID).Return(RoleAdmin, true, nil)
+
+		resolver := NewClusterAccessResolver(roleSource, new(MockClusterAccessSource))
+
+		ok, err := resolver.CanAccessCluster(ctx, 1, 1, "cluster", nil)
+		require.NoError(t, err)
+
+		assert.True(t, ok)
+	})
+
+	t.Run("Unrestricted", func(t *testing.T) {
+		roleSource := new(MockRoleSource)
+		roleSource.On("FindUserRole", ctx, uint(1), user.ID).Return(RoleMember, true, nil)
+
+		source := new(MockClusterAccessSource)
+		source.On("FindUserClusterSelectors", ctx, uint(1), user.ID).Return(nil, nil)
+
+		resolver := NewClusterAccessResolver(roleSource, source)
+
+		ok, err := resolver.CanAccessCluster(ctx, 1, 1, "cluster", nil)
+		require.NoError(t, err)
+
+		assert.True(t, ok)
+	})
+
+	t.Run("Restricted", func(t *testing.T) {
+		roleSource := new(MockRoleSource)
+		roleSource.On("FindUserRole", ctx, uint(1), user.ID).Return(RoleMember, true, nil)
+
+		source := new(MockClusterAccessSource)
+		source.On("FindUserClusterSelectors", mock.Anything, uint(1), user.ID).Return(
+			[]ClusterSelector{{Tags: map[string]string{"env": "dev"}}},
+			nil,
+		)
+
+		resolver := NewClusterAccessResolver(roleSource, source)
+
+		ok, err := resolver.CanAccessCluster(ctx, 1, 1, "dev", map[string]string{"env": "dev"})
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = resolver.CanAccessCluster(ctx, 1, 2, "prod", map[string]string{"env": "prod"})
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -426,7 +426,8 @@ func (s oidcOrganizationSyncer) SyncOrganizations(ctx gocontext.Context, user Us
 				Name:     org,
 				Provider: provider,
 			},
-			Role: s.roleBinder.BindRole(groups),
+			Role:   s.roleBinder.BindRole(groups),
+			Groups: groups,
 		}
 
 		upstreamMemberships = append(upstreamMemberships, membership)
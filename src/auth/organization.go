@@ -138,6 +138,9 @@ type OrganizationStore interface {
 
 	// ApplyUserMembership ensures that a user is a member of an organization with the necessary role.
 	ApplyUserMembership(ctx context.Context, organizationID uint, userID uint, role string) error
+
+	// ApplyUserGroups stores the upstream groups of a user within an organization.
+	ApplyUserGroups(ctx context.Context, organizationID uint, userID uint, groups []string) error
 }
 
 // +mga:event:dispatcher
@@ -163,6 +166,10 @@ type OrganizationCreated struct {
 type UpstreamOrganizationMembership struct {
 	Organization UpstreamOrganization
 	Role         string
+
+	// Groups are the groups of the user within the upstream organization.
+	// They are used for matching group role bindings.
+	Groups []string
 }
 
 // UpstreamOrganization represents an organization from the upstream authentication source.
@@ -178,6 +185,7 @@ func (s organizationSyncer) SyncOrganizations(ctx context.Context, user User, up
 	})
 
 	membershipsToAdd := make(map[string]string, len(upstreamMemberships))
+	groups := make(map[string][]string, len(upstreamMemberships))
 	organizations := make(map[string]uint)
 
 	logger.Info("syncing organizations for user")
@@ -198,6 +206,7 @@ func (s organizationSyncer) SyncOrganizations(ctx context.Context, user User, up
 		}
 
 		membershipsToAdd[membership.Organization.Name] = membership.Role
+		groups[membership.Organization.Name] = membership.Groups
 
 		// This index is used both in case of new organizations and when adding users to existing organizations.
 		organizations[membership.Organization.Name] = id
@@ -237,6 +246,17 @@ func (s organizationSyncer) SyncOrganizations(ctx context.Context, user User, up
 			continue
 		}
 
+		if !equalGroups(currentMembership.Groups, groups[currentMembership.Organization.Name]) {
+			logger.Info("updating user groups", map[string]interface{}{
+				"organizationId": currentMembership.OrganizationID,
+			})
+
+			err := s.store.ApplyUserGroups(ctx, currentMembership.OrganizationID, user.ID, groups[currentMembership.Organization.Name])
+			if err != nil {
+				return err
+			}
+		}
+
 		// Membership is already up to date, there is nothing to do
 		if currentMembership.Role == role {
 			logger.Debug("user is already in the organization", map[string]interface{}{
@@ -273,9 +293,30 @@ func (s organizationSyncer) SyncOrganizations(ctx context.Context, user User, up
 		if err != nil {
 			return err
 		}
+
+		if len(groups[organizationName]) > 0 {
+			err := s.store.ApplyUserGroups(ctx, organizations[organizationName], user.ID, groups[organizationName])
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	logger.Info("organizations synchronized successfully for user")
 
 	return nil
 }
+
+func equalGroups(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
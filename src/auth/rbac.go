@@ -0,0 +1,156 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Verbs understood by policy rules.
+const (
+	VerbGet    = "get"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbDelete = "delete"
+
+	// VerbAll matches every verb.
+	VerbAll = "*"
+)
+
+// Resources that are not derived from an organization API path.
+const (
+	// ResourceOrganization is the organization itself.
+	ResourceOrganization = "organization"
+
+	// ResourceVirtualUsers is used to authorize virtual user token creation.
+	ResourceVirtualUsers = "virtualusers"
+
+	// ResourceAll matches every resource.
+	ResourceAll = "*"
+)
+
+// nolint: gochecknoglobals
+var validVerbs = map[string]bool{
+	VerbGet:    true,
+	VerbCreate: true,
+	VerbUpdate: true,
+	VerbDelete: true,
+	VerbAll:    true,
+}
+
+// PolicyRule allows a set of verbs on a set of resources.
+//
+// Resources are named after the organization API path segments (eg. "clusters", "secrets", "helm").
+// Cluster subresources are named as "clusters/<subresource>" (eg. "clusters/config", "clusters/deployments").
+// A resource ending with "/*" matches every subresource, "*" matches every resource.
+type PolicyRule struct {
+	Resources []string `json:"resources"`
+	Verbs     []string `json:"verbs"`
+}
+
+// Allows checks whether the rule allows a verb on a resource.
+func (r PolicyRule) Allows(resource string, verb string) bool {
+	return r.matchesVerb(verb) && r.matchesResource(resource)
+}
+
+func (r PolicyRule) matchesVerb(verb string) bool {
+	for _, v := range r.Verbs {
+		if v == VerbAll || v == verb {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r PolicyRule) matchesResource(resource string) bool {
+	for _, res := range r.Resources {
+		if res == ResourceAll || res == resource {
+			return true
+		}
+
+		if strings.HasSuffix(res, "/*") && strings.HasPrefix(resource, strings.TrimSuffix(res, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PolicyRules is a list of rules.
+type PolicyRules []PolicyRule
+
+// Allows checks whether any of the rules allows a verb on a resource.
+func (r PolicyRules) Allows(resource string, verb string) bool {
+	for _, rule := range r {
+		if rule.Allows(resource, verb) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// +testify:mock:testOnly=true
+
+// PolicySource returns the rules of the custom roles bound to a user in a given organization.
+type PolicySource interface {
+	// FindUserRules returns the rules of the custom roles bound to a user either directly
+	// or through one of the user's groups.
+	FindUserRules(ctx context.Context, organizationID uint, userID uint) ([]PolicyRule, error)
+}
+
+// nolint: gochecknoglobals
+var organizationResourcePathRegexp = regexp.MustCompile(`^/(?:api/v1|dashboard)/orgs/\d+(?:/([^?]*))?$`)
+
+// resourceForPath returns the policy resource of an organization path.
+// Returns false as the second parameter if the path is not an organization path.
+func resourceForPath(path string) (string, bool) {
+	match := organizationResourcePathRegexp.FindStringSubmatch(path)
+	if match == nil {
+		return "", false
+	}
+
+	rest := strings.Trim(match[1], "/")
+	if rest == "" {
+		return ResourceOrganization, true
+	}
+
+	segments := strings.Split(rest, "/")
+	if segments[0] == "clusters" && len(segments) > 2 {
+		return "clusters/" + segments[2], true
+	}
+
+	return segments[0], true
+}
+
+// verbForMethod returns the policy verb of an HTTP method.
+func verbForMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return VerbGet
+	case http.MethodPost:
+		return VerbCreate
+	case http.MethodPut, http.MethodPatch:
+		return VerbUpdate
+	case http.MethodDelete:
+		return VerbDelete
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func TestPolicyRule_Allows(t *testing.T) {
+	tests := []struct {
+		rule     PolicyRule
+		resource string
+		verb     string
+		allowed  bool
+	}{
+		{
+			rule:     PolicyRule{Resources: []string{"secrets"}, Verbs: []string{VerbGet}},
+			resource: "secrets",
+			verb:     VerbGet,
+			allowed:  true,
+		},
+		{
+			rule:     PolicyRule{Resources: []string{"secrets"}, Verbs: []string{VerbGet}},
+			resource: "secrets",
+			verb:     VerbDelete,
+			allowed:  false,
+		},
+		{
+			rule:     PolicyRule{Resources: []string{"clusters"}, Verbs: []string{VerbAll}},
+			resource: "clusters/config",
+			verb:     VerbGet,
+			allowed:  false,
+		},
+		{
+			rule:     PolicyRule{Resources: []string{"clusters/*"}, Verbs: []string{VerbAll}},
+			resource: "clusters/config",
+			verb:     VerbGet,
+			allowed:  true,
+		},
+		{
+			rule:     PolicyRule{Resources: []string{ResourceAll}, Verbs: []string{VerbCreate}},
+			resource: "helm",
+			verb:     VerbCreate,
+			allowed:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, test.allowed, test.rule.Allows(test.resource, test.verb))
+		})
+	}
+}
+
+func TestResourceForPath(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/orgs/1":                              ResourceOrganization,
+		"/api/v1/orgs/1/secrets":                      "secrets",
+		"/api/v1/orgs/1/secrets/abcd":                 "secrets",
+		"/api/v1/orgs/1/clusters":                     "clusters",
+		"/api/v1/orgs/1/clusters/2":                   "clusters",
+		"/api/v1/orgs/1/clusters/2/config":            "clusters/config",
+		"/api/v1/orgs/1/clusters/2/deployments/hello": "clusters/deployments",
+		"/dashboard/orgs/1/clusters":                  "clusters",
+	}
+
+	for path, resource := range tests {
+		path, resource := path, resource
+
+		t.Run(path, func(t *testing.T) {
+			r, ok := resourceForPath(path)
+			require.True(t, ok)
+
+			assert.Equal(t, resource, r)
+		})
+	}
+
+	_, ok := resourceForPath("/api/v1/tokens")
+	assert.False(t, ok)
+}
+
+func TestRbacEnforcer_Enforce_CustomRoles(t *testing.T) {
+	org := &Organization{ID: 1, Name: "example"}
+	user := &User{ID: 1, Login: "john"}
+
+	roleSource := new(MockRoleSource)
+	roleSource.On("FindUserRole", mock.Anything, org.ID, user.ID).Return(RoleMember, true, nil)
+
+	policySource := new(MockPolicySource)
+	policySource.On("FindUserRules", mock.Anything, org.ID, user.ID).Return(
+		[]PolicyRule{
+			{
+				Resources: []string{"secrets"},
+				Verbs:     []string{VerbGet},
+			},
+			{
+				Resources: []string{"clusters"},
+				Verbs:     []string{VerbCreate},
+			},
+		},
+		nil,
+	)
+
+	enforcer := NewRbacEnforcer(roleSource, policySource, NewServiceAccountService(), common.NoopLogger{})
+
+	tests := []struct {
+		path    string
+		method  string
+		allowed bool
+	}{
+		{path: "/api/v1/orgs/1/clusters", method: "GET", allowed: true},
+		{path: "/api/v1/orgs/1/clusters", method: "POST", allowed: true},
+		{path: "/api/v1/orgs/1/clusters/2", method: "DELETE", allowed: false},
+		{path: "/api/v1/orgs/1/secrets", method: "GET", allowed: true},
+		{path: "/api/v1/orgs/1/secrets/abcd", method: "DELETE", allowed: false},
+		{path: "/api/v1/orgs/1/clusters/2/config", method: "GET", allowed: false},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.method+" "+test.path, func(t *testing.T) {
+			ok, err := enforcer.Enforce(org, user, test.path, test.method, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.allowed, ok)
+		})
+	}
+}
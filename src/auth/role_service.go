@@ -0,0 +1,461 @@
+// Copyright © 2021 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Role is a named set of policy rules in an organization.
+type Role struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Rules       []PolicyRule `json:"rules"`
+	BuiltIn     bool         `json:"builtIn"`
+	CreatedAt   *time.Time   `json:"createdAt,omitempty"`
+	UpdatedAt   *time.Time   `json:"updatedAt,omitempty"`
+}
+
+// Subject kinds of role bindings.
+const (
+	SubjectKindUser  = "user"
+	SubjectKindGroup = "group"
+)
+
+// RoleBinding grants the rules of a custom role to a user or to an OIDC group within an organization.
+type RoleBinding struct {
+	ID   uint   `json:"id"`
+	Role string `json:"role"`
+
+	// SubjectKind is either "user" or "group".
+	SubjectKind string `json:"subjectKind"`
+
+	// Subject is the user ID for user bindings or the group (team) name for group bindings.
+	// Group names are the part of the OIDC group after the organization name (eg. "ops" in "example:ops").
+	Subject string `json:"subject"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// BuiltInRoles returns the built-in organization roles.
+// Built-in roles are assigned through organization membership, they cannot be bound or modified.
+func BuiltInRoles() []Role {
+	return []Role{
+		{
+			Name:        RoleAdmin,
+			Description: "Full access to every organization resource.",
+			Rules: []PolicyRule{
+				{Resources: []string{ResourceAll}, Verbs: []string{VerbAll}},
+			},
+			BuiltIn: true,
+		},
+		{
+			Name:        RoleMember,
+			Description: "Read-only access to organization resources, except secrets and cluster kubeconfigs.",
+			Rules: []PolicyRule{
+				{Resources: []string{ResourceAll}, Verbs: []string{VerbGet}},
+			},
+			BuiltIn: true,
+		},
+	}
+}
+
+func isBuiltInRole(name string) bool {
+	_, ok := roleIndex[name]
+
+	return ok
+}
+
+// +kit:endpoint:errorStrategy=service
+
+// RoleService manages custom organization roles, their bindings and cluster access rules.
+type RoleService interface {
+	// ListRoles lists the built-in and custom roles of an organization.
+	ListRoles(ctx context.Context, organizationID uint) (roles []Role, err error)
+
+	// GetRole returns a single role.
+	GetRole(ctx context.Context, organizationID uint, roleName string) (role Role, err error)
+
+	// CreateRole creates a new custom role.
+	CreateRole(ctx context.Context, organizationID uint, role Role) (newRole Role, err error)
+
+	// UpdateRole replaces the description and the rules of a custom role.
+	UpdateRole(ctx context.Context, organizationID uint, roleName string, role Role) (updatedRole Role, err error)
+
+	// DeleteRole deletes a custom role together with its bindings.
+	DeleteRole(ctx context.Context, organizationID uint, roleName string) error
+
+	// ListRoleBindings lists the role bindings of an organization.
+	ListRoleBindings(ctx context.Context, organizationID uint) (bindings []RoleBinding, err error)
+
+	// CreateRoleBinding binds a custom role to a user or a group.
+	CreateRoleBinding(ctx context.Context, organizationID uint, binding RoleBinding) (newBinding RoleBinding, err error)
+
+	// DeleteRoleBinding deletes a role binding.
+	DeleteRoleBinding(ctx context.Context, organizationID uint, bindingID uint) error
+
+	// ListClusterAccessRules lists the cluster access rules of an organization.
+	ListClusterAccessRules(ctx context.Context, organizationID uint) (rules []ClusterAccessRule, err error)
+
+	// CreateClusterAccessRule restricts a user or a group to a subset of the organization's clusters.
+	CreateClusterAccessRule(ctx context.Context, organizationID uint, rule ClusterAccessRule) (newRule ClusterAccessRule, err error)
+
+	// DeleteClusterAccessRule deletes a cluster access rule.
+	DeleteClusterAccessRule(ctx context.Context, organizationID uint, ruleID uint) error
+}
+
+// +testify:mock:testOnly=true
+
+// RoleStore is a persistence layer for custom roles, role bindings and cluster access rules.
+type RoleStore interface {
+	// ListRoles lists the custom roles of an organization.
+	ListRoles(ctx context.Context, organizationID uint) ([]Role, error)
+
+	// GetRole returns a custom role.
+	// Returns a RoleNotFoundError if the role cannot be found.
+	GetRole(ctx context.Context, organizationID uint, roleName string) (Role, error)
+
+	// CreateRole persists a new custom role.
+	// Returns a RoleAlreadyExistsError if a role with the same name already exists.
+	CreateRole(ctx context.Context, organizationID uint, role Role) (Role, error)
+
+	// UpdateRole updates an existing custom role.
+	// Returns a RoleNotFoundError if the role cannot be found.
+	UpdateRole(ctx context.Context, organizationID uint, role Role) (Role, error)
+
+	// DeleteRole deletes a custom role and its bindings.
+	DeleteRole(ctx context.Context, organizationID uint, roleName string) error
+
+	// ListRoleBindings lists the role bindings of an organization.
+	ListRoleBindings(ctx context.Context, organizationID uint) ([]RoleBinding, error)
+
+	// CreateRoleBinding persists a new role binding.
+	CreateRoleBinding(ctx context.Context, organizationID uint, binding RoleBinding) (RoleBinding, error)
+
+	// DeleteRoleBinding deletes a role binding.
+	// Returns a RoleBindingNotFoundError if the binding cannot be found.
+	DeleteRoleBinding(ctx context.Context, organizationID uint, bindingID uint) error
+
+	// ListClusterAccessRules lists the cluster access rules of an organization.
+	ListClusterAccessRules(ctx context.Context, organizationID uint) ([]ClusterAccessRule, error)
+
+	// CreateClusterAccessRule persists a new cluster access rule.
+	CreateClusterAccessRule(ctx context.Context, organizationID uint, rule ClusterAccessRule) (ClusterAccessRule, error)
+
+	// DeleteClusterAccessRule deletes a cluster access rule.
+	// Returns a ClusterAccessRuleNotFoundError if the rule cannot be found.
+	DeleteClusterAccessRule(ctx context.Context, organizationID uint, ruleID uint) error
+}
+
+// NewRoleService returns a new RoleService.
+func NewRoleService(store RoleStore) RoleService {
+	return roleService{
+		store: store,
+	}
+}
+
+type roleService struct {
+	store RoleStore
+}
+
+// nolint: gochecknoglobals
+var roleNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func (s roleService) ListRoles(ctx context.Context, organizationID uint) ([]Role, error) {
+	roles, err := s.store.ListRoles(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(BuiltInRoles(), roles...), nil
+}
+
+func (s roleService) GetRole(ctx context.Context, organizationID uint, roleName string) (Role, error) {
+	for _, role := range BuiltInRoles() {
+		if role.Name == roleName {
+			return role, nil
+		}
+	}
+
+	return s.store.GetRole(ctx, organizationID, roleName)
+}
+
+func (s roleService) CreateRole(ctx context.Context, organizationID uint, role Role) (Role, error) {
+	if err := validateRole(role); err != nil {
+		return Role{}, err
+	}
+
+	if isBuiltInRole(role.Name) {
+		return Role{}, RoleAlreadyExistsError{Name: role.Name}
+	}
+
+	role.BuiltIn = false
+
+	return s.store.CreateRole(ctx, organizationID, role)
+}
+
+func (s roleService) UpdateRole(ctx context.Context, organizationID uint, roleName string, role Role) (Role, error) {
+	if isBuiltInRole(roleName) {
+		return Role{}, NewRoleValidationError("built-in roles cannot be modified", nil)
+	}
+
+	role.Name = roleName
+	if err := validateRole(role); err != nil {
+		return Role{}, err
+	}
+
+	return s.store.UpdateRole(ctx, organizationID, role)
+}
+
+func (s roleService) DeleteRole(ctx context.Context, organizationID uint, roleName string) error {
+	if isBuiltInRole(roleName) {
+		return NewRoleValidationError("built-in roles cannot be deleted", nil)
+	}
+
+	if _, err := s.store.GetRole(ctx, organizationID, roleName); err != nil {
+		return err
+	}
+
+	return s.store.DeleteRole(ctx, organizationID, roleName)
+}
+
+func (s roleService) ListRoleBindings(ctx context.Context, organizationID uint) ([]RoleBinding, error) {
+	return s.store.ListRoleBindings(ctx, organizationID)
+}
+
+func (s roleService) CreateRoleBinding(ctx context.Context, organizationID uint, binding RoleBinding) (RoleBinding, error) {
+	violations := validateSubject(binding.SubjectKind, binding.Subject)
+
+	if isBuiltInRole(binding.Role) {
+		violations = append(violations, "built-in roles are assigned through organization membership and cannot be bound")
+	}
+
+	if len(violations) > 0 {
+		return RoleBinding{}, NewRoleValidationError("invalid role binding", violations)
+	}
+
+	if _, err := s.store.GetRole(ctx, organizationID, binding.Role); err != nil {
+		return RoleBinding{}, err
+	}
+
+	return s.store.CreateRoleBinding(ctx, organizationID, binding)
+}
+
+func (s roleService) DeleteRoleBinding(ctx context.Context, organizationID uint, bindingID uint) error {
+	return s.store.DeleteRoleBinding(ctx, organizationID, bindingID)
+}
+
+func (s roleService) ListClusterAccessRules(ctx context.Context, organizationID uint) ([]ClusterAccessRule, error) {
+	return s.store.ListClusterAccessRules(ctx, organizationID)
+}
+
+func (s roleService) CreateClusterAccessRule(ctx context.Context, organizationID uint, rule ClusterAccessRule) (ClusterAccessRule, error) {
+	violations := validateSubject(rule.SubjectKind, rule.Subject)
+
+	if rule.Selector.Empty() {
+		violations = append(violations, "cluster selector must select clusters by ID, name pattern or tags")
+	}
+
+	for _, pattern := range rule.Selector.NamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			violations = append(violations, fmt.Sprintf("invalid cluster name pattern: %s", pattern))
+		}
+	}
+
+	if len(violations) > 0 {
+		return ClusterAccessRule{}, NewRoleValidationError("invalid cluster access rule", violations)
+	}
+
+	return s.store.CreateClusterAccessRule(ctx, organizationID, rule)
+}
+
+func (s roleService) DeleteClusterAccessRule(ctx context.Context, organizationID uint, ruleID uint) error {
+	return s.store.DeleteClusterAccessRule(ctx, organizationID, ruleID)
+}
+
+func validateSubject(kind string, subject string) []string {
+	var violations []string
+
+	switch kind {
+	case SubjectKindUser:
+		if _, err := strconv.ParseUint(subject, 10, 32); err != nil {
+			violations = append(violations, "user subject must be a user ID")
+		}
+	case SubjectKindGroup:
+		if subject == "" {
+			violations = append(violations, "group subject cannot be empty")
+		}
+	default:
+		violations = append(violations, fmt.Sprintf("subject kind must be either %q or %q", SubjectKindUser, SubjectKindGroup))
+	}
+
+	return violations
+}
+
+func validateRole(role Role) error {
+	var violations []string
+
+	if !roleNameRegexp.MatchString(role.Name) {
+		violations = append(violations, "role name must consist of lower case alphanumeric characters or '-'")
+	}
+
+	if len(role.Rules) == 0 {
+		violations = append(violations, "role must have at least one rule")
+	}
+
+	for i, rule := range role.Rules {
+		if len(rule.Resources) == 0 {
+			violations = append(violations, fmt.Sprintf("rule %d must have at least one resource", i))
+		}
+
+		if len(rule.Verbs) == 0 {
+			violations = append(violations, fmt.Sprintf("rule %d must have at least one verb", i))
+		}
+
+		for _, verb := range rule.Verbs {
+			if !validVerbs[verb] {
+				violations = append(violations, fmt.Sprintf("rule %d has an invalid verb: %s", i, verb))
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return NewRoleValidationError("invalid role", violations)
+	}
+
+	return nil
+}
+
+// RoleValidationError is returned when a role, a role binding or a cluster access rule is invalid.
+type RoleValidationError struct {
+	message    string
+	violations []string
+}
+
+// NewRoleValidationError returns a new RoleValidationError.
+func NewRoleValidationError(message string, violations []string) RoleValidationError {
+	return RoleValidationError{
+		message:    message,
+		violations: violations,
+	}
+}
+
+// Error implements the error interface.
+func (e RoleValidationError) Error() string {
+	return e.message
+}
+
+// Violations returns details of the failed validation.
+func (e RoleValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (RoleValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (RoleValidationError) ServiceError() bool {
+	return true
+}
+
+// RoleNotFoundError is returned if a role cannot be found.
+type RoleNotFoundError struct {
+	Name string
+}
+
+// Error implements the error interface.
+func (RoleNotFoundError) Error() string {
+	return "role not found"
+}
+
+// Details returns error details.
+func (e RoleNotFoundError) Details() []interface{} {
+	return []interface{}{"role", e.Name}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (RoleNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (RoleNotFoundError) ServiceError() bool {
+	return true
+}
+
+// RoleAlreadyExistsError is returned if a role with the same name already exists.
+type RoleAlreadyExistsError struct {
+	Name string
+}
+
+// Error implements the error interface.
+func (RoleAlreadyExistsError) Error() string {
+	return "role already exists"
+}
+
+// Details returns error details.
+func (e RoleAlreadyExistsError) Details() []interface{} {
+	return []interface{}{"role", e.Name}
+}
+
+// Conflict tells a client that this error is related to a conflicting request.
+// Can be used to translate the error to eg. status code.
+func (RoleAlreadyExistsError) Conflict() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (RoleAlreadyExistsError) ServiceError() bool {
+	return true
+}
+
+// RoleBindingNotFoundError is returned if a role binding cannot be found.
+type RoleBindingNotFoundError struct {
+	ID uint
+}
+
+// Error implements the error interface.
+func (RoleBindingNotFoundError) Error() string {
+	return "role binding not found"
+}
+
+// Details returns error details.
+func (e RoleBindingNotFoundError) Details() []interface{} {
+	return []interface{}{"roleBindingId", e.ID}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (RoleBindingNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (RoleBindingNotFoundError) ServiceError() bool {
+	return true
+}
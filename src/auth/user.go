@@ -17,6 +17,7 @@ package auth
 import (
 	"context"
 	"crypto/md5"
+	"database/sql/driver"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,6 +30,7 @@ import (
 	"github.com/jinzhu/gorm"
 	"gopkg.in/square/go-jose.v2/jwt"
 
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
 	"github.com/banzaicloud/pipeline/internal/global"
 )
 
@@ -133,6 +135,32 @@ type UserOrganization struct {
 	OrganizationID uint
 
 	Role string `gorm:"default:'member'"`
+
+	// Groups are the upstream (OIDC) groups of the user within the organization.
+	Groups UserGroups `gorm:"type:text"`
+}
+
+// UserGroups is a list of upstream groups stored as a JSON array.
+type UserGroups []string
+
+// Scan implements the sql.Scanner interface.
+func (g *UserGroups) Scan(src interface{}) error {
+	if src == nil {
+		*g = nil
+
+		return nil
+	}
+
+	return json.Scan(src, g)
+}
+
+// Value implements the driver.Valuer interface.
+func (g UserGroups) Value() (driver.Value, error) {
+	if g == nil {
+		return nil, nil
+	}
+
+	return json.Value(g)
 }
 
 // IDString returns the ID as string
@@ -43,6 +43,34 @@ func (_m *MockRoleSource) FindUserRole(ctx context.Context, organizationID uint,
 	return r0, r1, r2
 }
 
+// MockClusterAccessSource is an autogenerated mock for the ClusterAccessSource type.
+type MockClusterAccessSource struct {
+	mock.Mock
+}
+
+// FindUserClusterSelectors provides a mock function.
+func (_m *MockClusterAccessSource) FindUserClusterSelectors(ctx context.Context, organizationID uint, userID uint) (_result_0 []ClusterSelector, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, userID)
+
+	var r0 []ClusterSelector
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []ClusterSelector); ok {
+		r0 = rf(ctx, organizationID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ClusterSelector)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, organizationID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MockOIDCOrganizationSyncer is an autogenerated mock for the OIDCOrganizationSyncer type.
 type MockOIDCOrganizationSyncer struct {
 	mock.Mock
@@ -86,6 +114,20 @@ type MockOrganizationStore struct {
 	mock.Mock
 }
 
+// ApplyUserGroups provides a mock function.
+func (_m *MockOrganizationStore) ApplyUserGroups(ctx context.Context, organizationID uint, userID uint, groups []string) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, userID, groups)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, []string) error); ok {
+		r0 = rf(ctx, organizationID, userID, groups)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ApplyUserMembership provides a mock function.
 func (_m *MockOrganizationStore) ApplyUserMembership(ctx context.Context, organizationID uint, userID uint, role string) (_result_0 error) {
 	ret := _m.Called(ctx, organizationID, userID, role)
@@ -183,3 +225,252 @@ func (_m *MockOrganizationEvents) OrganizationCreated(ctx context.Context, event
 
 	return r0
 }
+
+// MockPolicySource is an autogenerated mock for the PolicySource type.
+type MockPolicySource struct {
+	mock.Mock
+}
+
+// FindUserRules provides a mock function.
+func (_m *MockPolicySource) FindUserRules(ctx context.Context, organizationID uint, userID uint) (_result_0 []PolicyRule, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, userID)
+
+	var r0 []PolicyRule
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []PolicyRule); ok {
+		r0 = rf(ctx, organizationID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]PolicyRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, organizationID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleStore is an autogenerated mock for the RoleStore type.
+type MockRoleStore struct {
+	mock.Mock
+}
+
+// CreateClusterAccessRule provides a mock function.
+func (_m *MockRoleStore) CreateClusterAccessRule(ctx context.Context, organizationID uint, rule ClusterAccessRule) (_result_0 ClusterAccessRule, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, rule)
+
+	var r0 ClusterAccessRule
+	if rf, ok := ret.Get(0).(func(context.Context, uint, ClusterAccessRule) ClusterAccessRule); ok {
+		r0 = rf(ctx, organizationID, rule)
+	} else {
+		r0 = ret.Get(0).(ClusterAccessRule)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, ClusterAccessRule) error); ok {
+		r1 = rf(ctx, organizationID, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRole provides a mock function.
+func (_m *MockRoleStore) CreateRole(ctx context.Context, organizationID uint, role Role) (_result_0 Role, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, role)
+
+	var r0 Role
+	if rf, ok := ret.Get(0).(func(context.Context, uint, Role) Role); ok {
+		r0 = rf(ctx, organizationID, role)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, Role) error); ok {
+		r1 = rf(ctx, organizationID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoleBinding provides a mock function.
+func (_m *MockRoleStore) CreateRoleBinding(ctx context.Context, organizationID uint, binding RoleBinding) (_result_0 RoleBinding, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, binding)
+
+	var r0 RoleBinding
+	if rf, ok := ret.Get(0).(func(context.Context, uint, RoleBinding) RoleBinding); ok {
+		r0 = rf(ctx, organizationID, binding)
+	} else {
+		r0 = ret.Get(0).(RoleBinding)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, RoleBinding) error); ok {
+		r1 = rf(ctx, organizationID, binding)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteClusterAccessRule provides a mock function.
+func (_m *MockRoleStore) DeleteClusterAccessRule(ctx context.Context, organizationID uint, ruleID uint) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, ruleID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, organizationID, ruleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRole provides a mock function.
+func (_m *MockRoleStore) DeleteRole(ctx context.Context, organizationID uint, roleName string) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, roleName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, organizationID, roleName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoleBinding provides a mock function.
+func (_m *MockRoleStore) DeleteRoleBinding(ctx context.Context, organizationID uint, bindingID uint) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, bindingID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, organizationID, bindingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRole provides a mock function.
+func (_m *MockRoleStore) GetRole(ctx context.Context, organizationID uint, roleName string) (_result_0 Role, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, roleName)
+
+	var r0 Role
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) Role); ok {
+		r0 = rf(ctx, organizationID, roleName)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, organizationID, roleName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClusterAccessRules provides a mock function.
+func (_m *MockRoleStore) ListClusterAccessRules(ctx context.Context, organizationID uint) (_result_0 []ClusterAccessRule, _result_1 error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []ClusterAccessRule
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []ClusterAccessRule); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ClusterAccessRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoleBindings provides a mock function.
+func (_m *MockRoleStore) ListRoleBindings(ctx context.Context, organizationID uint) (_result_0 []RoleBinding, _result_1 error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []RoleBinding
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []RoleBinding); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]RoleBinding)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoles provides a mock function.
+func (_m *MockRoleStore) ListRoles(ctx context.Context, organizationID uint) (_result_0 []Role, _result_1 error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []Role
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []Role); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function.
+func (_m *MockRoleStore) UpdateRole(ctx context.Context, organizationID uint, role Role) (_result_0 Role, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, role)
+
+	var r0 Role
+	if rf, ok := ret.Get(0).(func(context.Context, uint, Role) Role); ok {
+		r0 = rf(ctx, organizationID, role)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, Role) error); ok {
+		r1 = rf(ctx, organizationID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
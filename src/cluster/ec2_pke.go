@@ -374,6 +374,7 @@ func createNodePoolsFromPKENodePools(pkeNodePools []PKENodePool) []pkeworkflow.N
 				ImageID:           np.ImageID,
 				VolumeSize:        np.VolumeSize,
 				SpotPrice:         np.SpotPrice,
+				Taints:            np.Taints,
 			})
 	}
 
@@ -683,6 +684,7 @@ type PKENodePool struct {
 	VolumeSize        int
 	SpotPrice         string
 	Subnets           []string
+	Taints            []string
 }
 
 func (c *EC2ClusterPKE) GetNodePools() []PKENodePool {
@@ -715,6 +717,10 @@ func (c *EC2ClusterPKE) GetNodePools() []PKENodePool {
 			Subnets:           subnets,
 		}
 
+		for _, taint := range np.Taints {
+			pools[i].Taints = append(pools[i].Taints, string(taint))
+		}
+
 		for _, role := range np.Roles {
 			if role == "master" {
 				pools[i].Master = true
@@ -906,7 +912,7 @@ func (c *EC2ClusterPKE) GetBootstrapCommand(
 	}
 
 	// worker
-	return fmt.Sprintf("pke install %s "+
+	command := fmt.Sprintf("pke install %s "+
 		"--pipeline-url=%q "+
 		"--pipeline-insecure=%q "+
 		"--pipeline-token=%q "+
@@ -928,7 +934,21 @@ func (c *EC2ClusterPKE) GetBootstrapCommand(
 		version,
 		cri,
 		strings.Join(labels, ","),
-	), nil
+	)
+
+	taints := make([]string, 0, len(np.Taints)+len(np.StartupTaints))
+	for _, taint := range np.Taints {
+		taints = append(taints, string(taint))
+	}
+	for _, taint := range np.StartupTaints {
+		taints = append(taints, string(taint))
+	}
+
+	if len(taints) > 0 {
+		command = fmt.Sprintf("%s--taints=%q ", command, strings.Join(taints, ","))
+	}
+
+	return command, nil
 }
 
 func (c *EC2ClusterPKE) GetKubernetesVersion() (string, error) {
@@ -1089,6 +1109,8 @@ func createEC2ClusterPKENodePoolsFromRequest(pools pke.NodePools, userId uint) i
 			ProviderConfig: pool.ProviderConfig,
 			Labels:         pool.Labels,
 			Autoscaling:    pool.Autoscaling,
+			Taints:         convertTaints(pool.Taints),
+			StartupTaints:  convertTaints(pool.StartupTaints),
 		}
 		np.CreatedBy = userId
 		nps = append(nps, np)
@@ -132,6 +132,8 @@ func createNodePoolsFromRequest(nodePools map[string]*pkgEks.NodePool, userId ui
 			StatusMessage:    "",
 			Labels:           nodePool.Labels,
 			Delete:           false,
+
+			MixedInstancesPolicy: (*eksmodel.JSONNodePoolMixedInstancesPolicy)(nodePool.MixedInstancesPolicy),
 		}
 		i++
 	}
@@ -348,6 +348,8 @@ func (w EKSUpdateClusterWorkflow) Execute(ctx workflow.Context, input EKSUpdateC
 				NodeInstanceType:       updatedNodePool.NodeInstanceType,
 				SecurityGroups:         updatedNodePool.SecurityGroups,
 				Labels:                 updatedNodePool.Labels,
+				Taints:                 updatedNodePool.Taints,
+				StartupTaints:          updatedNodePool.StartupTaints,
 				Tags:                   input.Tags,
 				CurrentTemplateVersion: currentTemplateVersion,
 				UseInstanceStore:       updatedNodePool.UseInstanceStore,
@@ -14,6 +14,12 @@
 
 package cluster
 
+import (
+	"context"
+
+	"emperror.dev/emperror"
+)
+
 type clusterEvents interface {
 	// ClusterCreated event is emitted when a cluster creation workflow finishes.
 	ClusterCreated(clusterID uint)
@@ -73,3 +79,89 @@ func (c *clusterEventBus) ClusterDeleted(orgID uint, clusterName string) {
 func (c *clusterEventBus) ClusterUpdated(clusterID uint) {
 	c.eb.Publish(clusterUpdatedTopic, clusterID)
 }
+
+// EventTopic is the event bus topic cluster events are dispatched to.
+const EventTopic = "cluster"
+
+// +mga:event:dispatcher
+
+// ClusterEvents dispatches cluster events to the event bus.
+type ClusterEvents interface {
+	// ClusterCreated dispatches a ClusterCreated event.
+	ClusterCreated(ctx context.Context, event ClusterCreated) error
+
+	// ClusterDeleted dispatches a ClusterDeleted event.
+	ClusterDeleted(ctx context.Context, event ClusterDeleted) error
+
+	// ClusterUpdated dispatches a ClusterUpdated event.
+	ClusterUpdated(ctx context.Context, event ClusterUpdated) error
+}
+
+// ClusterCreated event is triggered when a cluster creation workflow finishes.
+type ClusterCreated struct {
+	ClusterID uint
+}
+
+// ClusterDeleted event is triggered when a cluster is completely deleted.
+type ClusterDeleted struct {
+	OrganizationID uint
+	ClusterName    string
+}
+
+// ClusterUpdated event is triggered when a cluster update workflow finishes.
+type ClusterUpdated struct {
+	ClusterID uint
+}
+
+// DispatchingClusterEvents emits cluster events on the in-process event bus
+// and dispatches them to the event bus shared by the processes.
+type DispatchingClusterEvents struct {
+	clusterEvents
+
+	dispatcher   ClusterEvents
+	errorHandler emperror.Handler
+}
+
+// NewDispatchingClusterEvents returns cluster events that are dispatched to the event bus
+// (so that they can reach other processes) in addition to the in-process event bus.
+func NewDispatchingClusterEvents(
+	events clusterEvents,
+	dispatcher ClusterEvents,
+	errorHandler emperror.Handler,
+) *DispatchingClusterEvents {
+	return &DispatchingClusterEvents{
+		clusterEvents: events,
+		dispatcher:    dispatcher,
+		errorHandler:  errorHandler,
+	}
+}
+
+// ClusterCreated emits and dispatches a ClusterCreated event.
+func (c *DispatchingClusterEvents) ClusterCreated(clusterID uint) {
+	c.clusterEvents.ClusterCreated(clusterID)
+
+	err := c.dispatcher.ClusterCreated(context.Background(), ClusterCreated{ClusterID: clusterID})
+	if err != nil {
+		c.errorHandler.Handle(err)
+	}
+}
+
+// ClusterDeleted emits and dispatches a ClusterDeleted event.
+func (c *DispatchingClusterEvents) ClusterDeleted(orgID uint, clusterName string) {
+	c.clusterEvents.ClusterDeleted(orgID, clusterName)
+
+	err := c.dispatcher.ClusterDeleted(context.Background(), ClusterDeleted{OrganizationID: orgID, ClusterName: clusterName})
+	if err != nil {
+		c.errorHandler.Handle(err)
+	}
+}
+
+// ClusterUpdated emits and dispatches a ClusterUpdated event.
+func (c *DispatchingClusterEvents) ClusterUpdated(clusterID uint) {
+	c.clusterEvents.ClusterUpdated(clusterID)
+
+	err := c.dispatcher.ClusterUpdated(context.Background(), ClusterUpdated{ClusterID: clusterID})
+	if err != nil {
+		c.errorHandler.Handle(err)
+	}
+}
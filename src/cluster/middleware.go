@@ -86,3 +86,40 @@ func NewClusterCheckMiddleware(manager *Manager, errorHandler emperror.Handler)
 		c.Request = c.Request.WithContext(ctxutil.WithClusterID(c.Request.Context(), cl.GetID()))
 	}
 }
+
+// AccessChecker checks whether the current user can access a cluster.
+type AccessChecker interface {
+	// CanAccessCluster checks whether the current user can access a cluster.
+	CanAccessCluster(ctx context.Context, clusterID uint) (bool, error)
+}
+
+// NewClusterAccessMiddleware returns a new gin middleware that checks the current user can access the cluster.
+// It has to be used after the middleware returned by NewClusterCheckMiddleware.
+// Clusters the user cannot access are reported as missing to avoid disclosing their existence.
+func NewClusterAccessMiddleware(checker AccessChecker, errorHandler emperror.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterID, ok := ctxutil.ClusterID(c.Request.Context())
+		if !ok {
+			c.Abort()
+
+			return
+		}
+
+		ok, err := checker.CanAccessCluster(c.Request.Context(), clusterID)
+		if err != nil {
+			errorHandler.Handle(err)
+
+			problem := problems.NewDetailedProblem(http.StatusInternalServerError, "internal server error")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, problem)
+
+			return
+		}
+
+		if !ok {
+			problem := problems.NewDetailedProblem(http.StatusNotFound, "cluster not found")
+			c.AbortWithStatusJSON(http.StatusNotFound, problem)
+
+			return
+		}
+	}
+}
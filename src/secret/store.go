@@ -38,6 +38,10 @@ var Store *secretStore
 // nolint: gochecknoglobals
 var ErrSecretNotExists = fmt.Errorf("There's no secret with this ID")
 
+// ErrVersioningNotSupported is returned when the underlying secret store does not keep secret versions
+// nolint: gochecknoglobals
+var ErrVersioningNotSupported = fmt.Errorf("secret store does not support versioning")
+
 // InitSecretStore initializes the global secret store.
 func InitSecretStore(store secret.Store, types secret.TypeList) {
 	Store = &secretStore{
@@ -86,6 +90,14 @@ type SecretItemResponse struct {
 	UpdatedBy string            `json:"updatedBy,omitempty" mapstructure:"updatedBy"`
 }
 
+// SecretVersionResponse describes a version of a secret
+type SecretVersionResponse struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Deleted   bool      `json:"deleted"`
+	Destroyed bool      `json:"destroyed"`
+}
+
 // ValidateSecretType validates the secret type
 func ValidateSecretType(s *SecretItemResponse, validType string) error {
 	if s.Type != validType {
@@ -354,12 +366,156 @@ func (ss *secretStore) Get(organizationID uint, secretID string) (*SecretItemRes
 		Type:      model.Type,
 		Values:    model.Values,
 		Tags:      model.Tags,
-		Version:   1,
+		Version:   model.Version,
+		UpdatedAt: model.UpdatedAt,
+		UpdatedBy: model.UpdatedBy,
+	}, nil
+}
+
+// ListVersions lists the versions of a secret secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) ListVersions(organizationID uint, secretID string) ([]*SecretVersionResponse, error) {
+	store, err := ss.versionedStore()
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := store.ListVersions(context.Background(), organizationID, secretID)
+	if err != nil && errors.As(err, &secret.NotFoundError{}) {
+		return nil, ErrSecretNotExists
+	} else if err != nil {
+		return nil, err
+	}
+
+	responseItems := make([]*SecretVersionResponse, 0, len(versions))
+
+	for _, version := range versions {
+		responseItems = append(responseItems, &SecretVersionResponse{
+			Version:   version.Version,
+			UpdatedAt: version.CreatedAt,
+			Deleted:   version.Deleted,
+			Destroyed: version.Destroyed,
+		})
+	}
+
+	return responseItems, nil
+}
+
+// GetVersion retrieves a specific version of a secret secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) GetVersion(organizationID uint, secretID string, version int) (*SecretItemResponse, error) {
+	store, err := ss.versionedStore()
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := store.GetVersion(context.Background(), organizationID, secretID, version)
+	if err != nil && errors.As(err, &secret.NotFoundError{}) {
+		return nil, ErrSecretNotExists
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &SecretItemResponse{
+		ID:        model.ID,
+		Name:      model.Name,
+		Type:      model.Type,
+		Values:    model.Values,
+		Tags:      model.Tags,
+		Version:   model.Version,
 		UpdatedAt: model.UpdatedAt,
 		UpdatedBy: model.UpdatedBy,
 	}, nil
 }
 
+// Rollback writes the content of a previous version as the latest version of a secret secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) Rollback(organizationID uint, secretID string, version int, updatedBy string) error {
+	store, err := ss.versionedStore()
+	if err != nil {
+		return err
+	}
+
+	model, err := store.GetVersion(context.Background(), organizationID, secretID, version)
+	if err != nil && errors.As(err, &secret.NotFoundError{}) {
+		return ErrSecretNotExists
+	} else if err != nil {
+		return err
+	}
+
+	log.Debug("rolling back secret", map[string]interface{}{
+		"organizationId": organizationID,
+		"secretId":       secretID,
+		"version":        version,
+	})
+
+	model.UpdatedBy = updatedBy
+
+	return store.Put(context.Background(), organizationID, model)
+}
+
+// SoftDelete deletes a secret keeping its previous versions, so that it can be recovered later secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) SoftDelete(organizationID uint, secretID string) error {
+	store, err := ss.versionedStore()
+	if err != nil {
+		return err
+	}
+
+	log.Debug("soft deleting secret", map[string]interface{}{
+		"organizationId": organizationID,
+		"secretId":       secretID,
+	})
+
+	if _, err := ss.Get(organizationID, secretID); err == ErrSecretNotExists { // Already deleted
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "Error during querying secret before deletion")
+	}
+
+	return store.SoftDelete(context.Background(), organizationID, secretID)
+}
+
+// Undelete recovers the latest version of a soft deleted secret secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) Undelete(organizationID uint, secretID string) error {
+	versions, err := ss.ListVersions(organizationID, secretID)
+	if err != nil {
+		return err
+	}
+
+	if len(versions) == 0 {
+		return ErrSecretNotExists
+	}
+
+	latest := versions[len(versions)-1]
+
+	if !latest.Deleted { // Not deleted
+		return nil
+	}
+
+	if latest.Destroyed {
+		return ErrSecretNotExists
+	}
+
+	log.Debug("undeleting secret", map[string]interface{}{
+		"organizationId": organizationID,
+		"secretId":       secretID,
+		"version":        latest.Version,
+	})
+
+	store, err := ss.versionedStore()
+	if err != nil {
+		return err
+	}
+
+	return store.Undelete(context.Background(), organizationID, secretID, latest.Version)
+}
+
+func (ss *secretStore) versionedStore() (secret.VersionedStore, error) {
+	store, ok := ss.SecretStore.(secret.VersionedStore)
+	if !ok {
+		return nil, ErrVersioningNotSupported
+	}
+
+	return store, nil
+}
+
 // Retrieve secret by secret Name secret/orgs/:orgid:/:id: scope
 func (ss *secretStore) GetByName(organizationID uint, name string) (*SecretItemResponse, error) {
 	secretID := GenerateSecretIDFromName(name)
@@ -414,7 +570,7 @@ func (ss *secretStore) List(orgid uint, query *ListSecretsQuery) ([]*SecretItemR
 			Type:      model.Type,
 			Values:    model.Values,
 			Tags:      model.Tags,
-			Version:   1,
+			Version:   model.Version,
 			UpdatedAt: model.UpdatedAt,
 			UpdatedBy: model.UpdatedBy,
 		}